@@ -12,7 +12,6 @@ import (
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
 
 	"github.com/zitadel/zitadel/internal/crypto"
-	cryptoDB "github.com/zitadel/zitadel/internal/crypto/database"
 	"github.com/zitadel/zitadel/internal/database"
 )
 
@@ -21,7 +20,8 @@ const (
 )
 
 type Config struct {
-	Database database.Config
+	Database   database.Config
+	KeyStorage KeyStorageConfig
 }
 
 func New() *cobra.Command {
@@ -31,6 +31,7 @@ func New() *cobra.Command {
 	}
 	AddMasterKeyFlag(cmd)
 	cmd.AddCommand(newKey())
+	cmd.AddCommand(newRotate())
 	return cmd
 }
 
@@ -38,7 +39,7 @@ func newKey() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "new [keyID=key]... [-f file]",
 		Short: "create new encryption key(s)",
-		Long: `create new encryption key(s) (encrypted by the provided master key)
+		Long: `create new encryption key(s) (encrypted by the provided master key or the configured key storage)
 provide key(s) by YAML file and/or by argument
 Requirements:
 - cockroachdb`,
@@ -66,11 +67,11 @@ new -f keys.yaml key2=anotherkey`,
 			if err := viper.Unmarshal(config); err != nil {
 				return err
 			}
-			masterKey, err := MasterKey(cmd)
+			masterKey, err := config.KeyStorage.MasterKey(cmd)
 			if err != nil {
 				return err
 			}
-			storage, err := keyStorage(config.Database, masterKey)
+			storage, err := keyStorage(config, masterKey)
 			if err != nil {
 				return err
 			}
@@ -123,10 +124,10 @@ func openFile(fileName string) (io.Reader, error) {
 	return file, nil
 }
 
-func keyStorage(config database.Config, masterKey string) (crypto.KeyStorage, error) {
-	db, err := database.Connect(config, false)
+func keyStorage(config *Config, masterKey string) (crypto.KeyStorage, error) {
+	db, err := database.Connect(config.Database, false)
 	if err != nil {
 		return nil, err
 	}
-	return cryptoDB.NewKeyStorage(db, masterKey)
+	return config.KeyStorage.NewKeyStorage(db, masterKey)
 }
@@ -0,0 +1,117 @@
+package key
+
+import (
+	"database/sql"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	cryptoDB "github.com/zitadel/zitadel/internal/crypto/database"
+	"github.com/zitadel/zitadel/internal/crypto/vault"
+	"github.com/zitadel/zitadel/internal/database"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	flagNewMasterKeyArg = "newMasterkey"
+	flagNewMasterKeyEnv = "newMasterkeyFromEnv"
+	envNewMasterKey     = "ZITADEL_NEW_MASTERKEY"
+)
+
+// rewrapper re-encrypts all stored keys
+type rewrapper interface {
+	RewrapKeys(crypto.KeyWrapper) error
+}
+
+func newRotate() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "re-encrypt the stored encryption keys",
+		Long: `re-encrypts all encryption keys stored in the database, depending on the configured KeyStorage.Type:
+- database: the keys are re-encrypted from the master key to the new master key
+- vault-transit: the transit key is rotated and all keys are wrapped by its new version,
+  if a master key is provided, the keys are migrated from the master key to the transit key
+keys stored in the kv secrets engine of vault (vault-kv) are protected by vault itself and can't be rotated
+Requirements:
+- cockroachdb`,
+		Example: `rotate --masterkeyFromEnv --newMasterkeyFromEnv
+rotate
+rotate --masterkey "thirtytwo bytes long master key!"`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := new(Config)
+			if err := viper.Unmarshal(config); err != nil {
+				return err
+			}
+			db, err := database.Connect(config.Database, false)
+			if err != nil {
+				return err
+			}
+			switch config.KeyStorage.Type {
+			case "", KeyStorageTypeDatabase:
+				return rotateMasterKey(cmd, config, db)
+			case KeyStorageTypeVaultTransit:
+				return rotateTransitKey(cmd, config, db)
+			default:
+				return caos_errs.ThrowInvalidArgumentf(nil, "KEY-Ls92n", "keys of storage type %s can't be rotated", config.KeyStorage.Type)
+			}
+		},
+	}
+	cmd.PersistentFlags().String(flagNewMasterKeyArg, "", "new masterkey as argument for en/decryption keys")
+	cmd.PersistentFlags().Bool(flagNewMasterKeyEnv, false, "read new masterkey for en/decryption keys from environment variable ("+envNewMasterKey+")")
+	return cmd
+}
+
+func rotateMasterKey(cmd *cobra.Command, config *Config, db *sql.DB) error {
+	masterKey, err := MasterKey(cmd)
+	if err != nil {
+		return err
+	}
+	newMasterKey, err := newMasterKey(cmd)
+	if err != nil {
+		return err
+	}
+	wrapper, err := crypto.NewMasterKeyWrapper(newMasterKey)
+	if err != nil {
+		return err
+	}
+	storage, err := cryptoDB.NewKeyStorage(db, masterKey)
+	if err != nil {
+		return err
+	}
+	return storage.RewrapKeys(wrapper)
+}
+
+func rotateTransitKey(cmd *cobra.Command, config *Config, db *sql.DB) error {
+	transit, err := vault.NewTransit(&config.KeyStorage.Vault)
+	if err != nil {
+		return err
+	}
+	var storage rewrapper
+	if masterKey, err := MasterKey(cmd); err == nil {
+		// migrate the keys encrypted by the master key
+		storage, err = cryptoDB.NewKeyStorage(db, masterKey)
+		if err != nil {
+			return err
+		}
+	} else {
+		storage = cryptoDB.NewWrappedKeyStorage(db, transit)
+		if err = transit.RotateKey(); err != nil {
+			return err
+		}
+	}
+	return storage.RewrapKeys(transit)
+}
+
+func newMasterKey(cmd *cobra.Command) (string, error) {
+	newMasterKeyFromArg, _ := cmd.Flags().GetString(flagNewMasterKeyArg)
+	newMasterKeyFromEnv, _ := cmd.Flags().GetBool(flagNewMasterKeyEnv)
+	if (newMasterKeyFromArg == "") == !newMasterKeyFromEnv {
+		return "", caos_errs.ThrowInvalidArgument(nil, "KEY-Wm2ps", "new masterkey must either be provided by value or environment variable")
+	}
+	if newMasterKeyFromEnv {
+		return os.Getenv(envNewMasterKey), nil
+	}
+	return newMasterKeyFromArg, nil
+}
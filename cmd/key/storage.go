@@ -0,0 +1,59 @@
+package key
+
+import (
+	"database/sql"
+
+	"github.com/spf13/cobra"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	cryptoDB "github.com/zitadel/zitadel/internal/crypto/database"
+	"github.com/zitadel/zitadel/internal/crypto/vault"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	// KeyStorageTypeDatabase stores the keys in the database, encrypted by the master key
+	KeyStorageTypeDatabase = "database"
+	// KeyStorageTypeVaultTransit stores the keys in the database, wrapped by the transit secrets engine of Vault
+	KeyStorageTypeVaultTransit = "vault-transit"
+	// KeyStorageTypeVaultKV stores the keys in the kv secrets engine of Vault
+	KeyStorageTypeVaultKV = "vault-kv"
+)
+
+type KeyStorageConfig struct {
+	Type  string
+	Vault vault.Config
+}
+
+// NeedsMasterKey is true if the keys are encrypted by the master key
+func (c *KeyStorageConfig) NeedsMasterKey() bool {
+	return c.Type == "" || c.Type == KeyStorageTypeDatabase
+}
+
+// MasterKey returns the master key provided by flag, file or environment variable
+// if the configured storage doesn't need one, it's optional
+func (c *KeyStorageConfig) MasterKey(cmd *cobra.Command) (string, error) {
+	masterKey, err := MasterKey(cmd)
+	if err != nil && c.NeedsMasterKey() {
+		return "", err
+	}
+	return masterKey, nil
+}
+
+// NewKeyStorage returns the configured storage
+func (c *KeyStorageConfig) NewKeyStorage(client *sql.DB, masterKey string) (crypto.KeyStorage, error) {
+	switch c.Type {
+	case "", KeyStorageTypeDatabase:
+		return cryptoDB.NewKeyStorage(client, masterKey)
+	case KeyStorageTypeVaultTransit:
+		transit, err := vault.NewTransit(&c.Vault)
+		if err != nil {
+			return nil, err
+		}
+		return cryptoDB.NewWrappedKeyStorage(client, transit), nil
+	case KeyStorageTypeVaultKV:
+		return vault.NewKVStorage(&c.Vault)
+	default:
+		return nil, caos_errs.ThrowInvalidArgumentf(nil, "KEY-Ns92k", "unknown key storage type %s", c.Type)
+	}
+}
@@ -0,0 +1,68 @@
+package key
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/crypto/vault"
+)
+
+func TestKeyStorageConfig_NewKeyStorage(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         KeyStorageConfig
+		needsMasterKey bool
+		wantErr        assert.ErrorAssertionFunc
+	}{
+		{
+			"unknown type, error",
+			KeyStorageConfig{Type: "unknown"},
+			false,
+			assert.Error,
+		},
+		{
+			"vault kv without address, error",
+			KeyStorageConfig{Type: KeyStorageTypeVaultKV, Vault: vault.Config{KV: vault.KVConfig{Mount: "secret"}}},
+			false,
+			assert.Error,
+		},
+		{
+			"vault transit without key, error",
+			KeyStorageConfig{Type: KeyStorageTypeVaultTransit, Vault: vault.Config{Address: "http://localhost:8200", Token: "token"}},
+			false,
+			assert.Error,
+		},
+		{
+			"vault kv, ok",
+			KeyStorageConfig{Type: KeyStorageTypeVaultKV, Vault: vault.Config{Address: "http://localhost:8200", Token: "token", KV: vault.KVConfig{Mount: "secret"}}},
+			false,
+			assert.NoError,
+		},
+		{
+			"vault transit, ok",
+			KeyStorageConfig{Type: KeyStorageTypeVaultTransit, Vault: vault.Config{Address: "http://localhost:8200", Token: "token", Transit: vault.TransitConfig{Mount: "transit", Key: "zitadel"}}},
+			false,
+			assert.NoError,
+		},
+		{
+			"database without masterkey, error",
+			KeyStorageConfig{Type: KeyStorageTypeDatabase},
+			true,
+			assert.Error,
+		},
+		{
+			"default type is database",
+			KeyStorageConfig{},
+			true,
+			assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.needsMasterKey, tt.config.NeedsMasterKey())
+			_, err := tt.config.NewKeyStorage(nil, "")
+			tt.wantErr(t, err)
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+)
+
+var (
+	//go:embed 09.sql
+	tokenActor09 string
+)
+
+type AuthTokenActor struct {
+	dbClient *sql.DB
+}
+
+func (mig *AuthTokenActor) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, tokenActor09)
+	return err
+}
+
+func (mig *AuthTokenActor) String() string {
+	return "09_auth_token_actor"
+}
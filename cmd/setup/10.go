@@ -0,0 +1,25 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+)
+
+var (
+	//go:embed 10.sql
+	userOTPColumns10 string
+)
+
+type UserOTPColumns struct {
+	dbClient *sql.DB
+}
+
+func (mig *UserOTPColumns) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, userOTPColumns10)
+	return err
+}
+
+func (mig *UserOTPColumns) String() string {
+	return "10_user_otp_columns"
+}
@@ -0,0 +1,25 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+)
+
+var (
+	//go:embed 11.sql
+	userRecoveryCodes11 string
+)
+
+type UserRecoveryCodesColumn struct {
+	dbClient *sql.DB
+}
+
+func (mig *UserRecoveryCodesColumn) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, userRecoveryCodes11)
+	return err
+}
+
+func (mig *UserRecoveryCodesColumn) String() string {
+	return "11_user_recovery_codes_column"
+}
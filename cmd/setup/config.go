@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/viper"
 	"github.com/zitadel/logging"
 
+	"github.com/zitadel/zitadel/cmd/key"
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/config/hook"
@@ -28,6 +29,7 @@ type Config struct {
 	ExternalSecure  bool
 	Log             *logging.Config
 	EncryptionKeys  *encryptionKeyConfig
+	KeyStorage      key.KeyStorageConfig
 	DefaultInstance command.InstanceSetup
 	Machine         *id.Config
 	Projections     projection.Config
@@ -64,6 +66,9 @@ type Steps struct {
 	s6OwnerRemoveColumns *OwnerRemoveColumns
 	s7LogstoreTables     *LogstoreTables
 	s8AuthTokens         *AuthTokenIndexes
+	s9AuthTokenActor     *AuthTokenActor
+	s10UserOTPColumns    *UserOTPColumns
+	s11UserRecoveryCodes *UserRecoveryCodesColumn
 }
 
 type encryptionKeyConfig struct {
@@ -37,7 +37,7 @@ Requirements:
 			config := MustNewConfig(viper.GetViper())
 			steps := MustNewSteps(viper.New())
 
-			masterKey, err := key.MasterKey(cmd)
+			masterKey, err := config.KeyStorage.MasterKey(cmd)
 			logging.OnError(err).Panic("No master key provided")
 
 			Setup(config, steps, masterKey)
@@ -73,6 +73,7 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	steps.FirstInstance.userEncryptionKey = config.EncryptionKeys.User
 	steps.FirstInstance.smtpEncryptionKey = config.EncryptionKeys.SMTP
 	steps.FirstInstance.masterKey = masterKey
+	steps.FirstInstance.keyStorage = config.KeyStorage
 	steps.FirstInstance.db = dbClient
 	steps.FirstInstance.es = eventstoreClient
 	steps.FirstInstance.defaults = config.SystemDefaults
@@ -86,6 +87,9 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	steps.s6OwnerRemoveColumns = &OwnerRemoveColumns{dbClient: dbClient}
 	steps.s7LogstoreTables = &LogstoreTables{dbClient: dbClient, username: config.Database.Username(), dbType: config.Database.Type()}
 	steps.s8AuthTokens = &AuthTokenIndexes{dbClient: dbClient}
+	steps.s9AuthTokenActor = &AuthTokenActor{dbClient: dbClient}
+	steps.s10UserOTPColumns = &UserOTPColumns{dbClient: dbClient}
+	steps.s11UserRecoveryCodes = &UserRecoveryCodesColumn{dbClient: dbClient}
 
 	err = projection.Create(ctx, dbClient, eventstoreClient, config.Projections, nil, nil)
 	logging.OnError(err).Fatal("unable to start projections")
@@ -119,6 +123,12 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	logging.OnError(err).Fatal("unable to migrate step 7")
 	err = migration.Migrate(ctx, eventstoreClient, steps.s8AuthTokens)
 	logging.OnError(err).Fatal("unable to migrate step 8")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s9AuthTokenActor)
+	logging.OnError(err).Fatal("unable to migrate step 9")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s10UserOTPColumns)
+	logging.OnError(err).Fatal("unable to migrate step 10")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s11UserRecoveryCodes)
+	logging.OnError(err).Fatal("unable to migrate step 11")
 
 	for _, repeatableStep := range repeatableSteps {
 		err = migration.Migrate(ctx, eventstoreClient, repeatableStep)
@@ -15,6 +15,8 @@ var (
 		"smsKey",
 		"smtpKey",
 		"userKey",
+		"webhookKey",
+		"actionsSecretKey",
 		"csrfCookieKey",
 		"userAgentCookieKey",
 	}
@@ -29,6 +31,8 @@ type encryptionKeys struct {
 	SMS                crypto.EncryptionAlgorithm
 	SMTP               crypto.EncryptionAlgorithm
 	User               crypto.EncryptionAlgorithm
+	Webhook            crypto.EncryptionAlgorithm
+	ActionsSecret      crypto.EncryptionAlgorithm
 	CSRFCookieKey      []byte
 	UserAgentCookieKey []byte
 	OIDCKey            []byte
@@ -76,6 +80,14 @@ func ensureEncryptionKeys(keyConfig *encryptionKeyConfig, keyStorage crypto.KeyS
 	if err != nil {
 		return nil, err
 	}
+	keys.Webhook, err = crypto.NewAESCrypto(keyConfig.Webhook, keyStorage)
+	if err != nil {
+		return nil, err
+	}
+	keys.ActionsSecret, err = crypto.NewAESCrypto(keyConfig.ActionsSecret, keyStorage)
+	if err != nil {
+		return nil, err
+	}
 	key, err = crypto.LoadKey(keyConfig.CSRFCookieKeyID, keyStorage)
 	if err != nil {
 		return nil, err
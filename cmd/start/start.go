@@ -24,31 +24,35 @@ import (
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
 
-	"github.com/zitadel/zitadel/cmd/key"
 	cmd_tls "github.com/zitadel/zitadel/cmd/tls"
 	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/actions/usermanagement"
 	admin_es "github.com/zitadel/zitadel/internal/admin/repository/eventsourcing"
 	"github.com/zitadel/zitadel/internal/api"
 	"github.com/zitadel/zitadel/internal/api/assets"
 	internal_authz "github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/export"
 	"github.com/zitadel/zitadel/internal/api/grpc/admin"
 	"github.com/zitadel/zitadel/internal/api/grpc/auth"
 	"github.com/zitadel/zitadel/internal/api/grpc/management"
+	"github.com/zitadel/zitadel/internal/api/grpc/session"
 	"github.com/zitadel/zitadel/internal/api/grpc/system"
 	http_util "github.com/zitadel/zitadel/internal/api/http"
 	"github.com/zitadel/zitadel/internal/api/http/middleware"
 	"github.com/zitadel/zitadel/internal/api/oidc"
 	"github.com/zitadel/zitadel/internal/api/saml"
+	"github.com/zitadel/zitadel/internal/api/scim"
 	"github.com/zitadel/zitadel/internal/api/ui/console"
 	"github.com/zitadel/zitadel/internal/api/ui/login"
 	auth_es "github.com/zitadel/zitadel/internal/auth/repository/eventsourcing"
 	"github.com/zitadel/zitadel/internal/authz"
 	authz_repo "github.com/zitadel/zitadel/internal/authz/repository"
 	"github.com/zitadel/zitadel/internal/command"
-	cryptoDB "github.com/zitadel/zitadel/internal/crypto/database"
 	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventactions"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/ldapsync"
 	"github.com/zitadel/zitadel/internal/logstore"
 	"github.com/zitadel/zitadel/internal/logstore/emitters/access"
 	"github.com/zitadel/zitadel/internal/logstore/emitters/execution"
@@ -56,7 +60,9 @@ import (
 	"github.com/zitadel/zitadel/internal/notification"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/static"
+	"github.com/zitadel/zitadel/internal/userdeletion"
 	"github.com/zitadel/zitadel/internal/webauthn"
+	"github.com/zitadel/zitadel/internal/webhook"
 	"github.com/zitadel/zitadel/openapi"
 )
 
@@ -73,7 +79,7 @@ Requirements:
 				return err
 			}
 			config := MustNewConfig(viper.GetViper())
-			masterKey, err := key.MasterKey(cmd)
+			masterKey, err := config.KeyStorage.MasterKey(cmd)
 			if err != nil {
 				return err
 			}
@@ -95,7 +101,7 @@ func startZitadel(config *Config, masterKey string) error {
 		return fmt.Errorf("cannot start client for projection: %w", err)
 	}
 
-	keyStorage, err := cryptoDB.NewKeyStorage(dbClient, masterKey)
+	keyStorage, err := config.KeyStorage.NewKeyStorage(dbClient, masterKey)
 	if err != nil {
 		return fmt.Errorf("cannot start key storage: %w", err)
 	}
@@ -105,6 +111,7 @@ func startZitadel(config *Config, masterKey string) error {
 	}
 
 	config.Eventstore.Client = dbClient
+	config.Eventstore.KeyStorage = keyStorage
 	eventstoreClient, err := eventstore.Start(config.Eventstore)
 	if err != nil {
 		return fmt.Errorf("cannot start eventstore for queries: %w", err)
@@ -145,7 +152,12 @@ func startZitadel(config *Config, masterKey string) error {
 		keys.DomainVerification,
 		keys.OIDC,
 		keys.SAML,
+		keys.Webhook,
+		keys.ActionsSecret,
 		&http.Client{},
+		usermanagement.NewActions(queries),
+		queries,
+		actions.CheckURLAllowed,
 	)
 	if err != nil {
 		return fmt.Errorf("cannot start commands: %w", err)
@@ -167,15 +179,22 @@ func startZitadel(config *Config, masterKey string) error {
 		logging.Warn("execution logs are currently in beta")
 	}
 	actions.SetLogstoreService(actionsLogstoreSvc)
+	actions.SetSecrets(queries, keys.ActionsSecret)
+	actions.SetKeyValueStorage(queries, commands)
 
 	notification.Start(ctx, config.Projections.Customizations["notifications"], config.ExternalPort, config.ExternalSecure, commands, queries, eventstoreClient, assets.AssetAPIFromDomain(config.ExternalSecure, config.ExternalPort), config.SystemDefaults.Notifications.FileSystemPath, keys.User, keys.SMTP, keys.SMS)
+	webhook.Start(ctx, config.Projections.Customizations["webhook_deliveries"], queries, commands, eventstoreClient, dbClient, keys.Webhook, config.SystemDefaults.Webhooks)
+	eventactions.Start(ctx, config.Projections.Customizations["event_action_executions"], queries, dbClient, config.SystemDefaults.EventActions)
+	ldapReconciler := ldapsync.NewReconciler(queries, commands, keys.IDPConfig)
+	ldapsync.Start(ctx, config.LDAPSync, queries, ldapReconciler)
+	userdeletion.Start(ctx, config.UserDeletion, queries, commands)
 
 	router := mux.NewRouter()
 	tlsConfig, err := config.TLS.Config()
 	if err != nil {
 		return err
 	}
-	err = startAPIs(ctx, clock, router, commands, queries, eventstoreClient, dbClient, config, storage, authZRepo, keys, commands, usageReporter)
+	err = startAPIs(ctx, clock, router, commands, queries, eventstoreClient, dbClient, config, storage, authZRepo, keys, commands, usageReporter, ldapReconciler)
 	if err != nil {
 		return err
 	}
@@ -196,6 +215,7 @@ func startAPIs(
 	keys *encryptionKeys,
 	quotaQuerier logstore.QuotaQuerier,
 	usageReporter logstore.UsageReporter,
+	ldapReconciler *ldapsync.Reconciler,
 ) error {
 	repo := struct {
 		authz_repo.Repository
@@ -236,10 +256,10 @@ func startAPIs(
 	if err := apis.RegisterServer(ctx, system.CreateServer(commands, queries, adminRepo, config.Database.Database(), config.DefaultInstance, config.ExternalDomain)); err != nil {
 		return err
 	}
-	if err := apis.RegisterServer(ctx, admin.CreateServer(config.Database.Database(), commands, queries, config.SystemDefaults, adminRepo, config.ExternalSecure, keys.User)); err != nil {
+	if err := apis.RegisterServer(ctx, admin.CreateServer(config.Database.Database(), commands, queries, config.SystemDefaults, adminRepo, config.ExternalSecure, keys.User, ldapReconciler)); err != nil {
 		return err
 	}
-	if err := apis.RegisterServer(ctx, management.CreateServer(commands, queries, config.SystemDefaults, keys.User, config.ExternalSecure, config.AuditLogRetention)); err != nil {
+	if err := apis.RegisterServer(ctx, management.CreateServer(commands, queries, config.SystemDefaults, keys.User, config.ExternalSecure, config.AuditLogRetention, ldapReconciler)); err != nil {
 		return err
 	}
 	if err := apis.RegisterServer(ctx, auth.CreateServer(commands, queries, authRepo, config.SystemDefaults, keys.User, config.ExternalSecure, config.AuditLogRetention)); err != nil {
@@ -249,7 +269,7 @@ func startAPIs(
 	assetsCache := middleware.AssetsCacheInterceptor(config.AssetStorage.Cache.MaxAge, config.AssetStorage.Cache.SharedMaxAge)
 	apis.RegisterHandler(assets.HandlerPrefix, assets.NewHandler(commands, verifier, config.InternalAuthZ, id.SonyFlakeGenerator(), store, queries, instanceInterceptor.Handler, assetsCache.Handler, accessInterceptor.Handle))
 
-	userAgentInterceptor, err := middleware.NewUserAgentHandler(config.UserAgentCookie, keys.UserAgentCookieKey, id.SonyFlakeGenerator(), config.ExternalSecure, login.EndpointResources)
+	userAgentInterceptor, err := middleware.NewUserAgentHandler(config.UserAgentCookie, keys.UserAgentCookieKey, id.SonyFlakeGenerator(), config.ExternalSecure, login.EndpointResources, login.EndpointSAMLACS)
 	if err != nil {
 		return err
 	}
@@ -261,6 +281,9 @@ func startAPIs(
 	}
 	apis.RegisterHandler(openapi.HandlerPrefix, openAPIHandler)
 
+	apis.RegisterHandler(scim.HandlerPrefix, scim.NewHandler(commands, queries, verifier, config.InternalAuthZ, config.ExternalSecure, instanceInterceptor.Handler, accessInterceptor.Handle))
+	apis.RegisterHandler(export.HandlerPrefix, export.NewHandler(queries, verifier, config.InternalAuthZ, instanceInterceptor.Handler, accessInterceptor.Handle))
+
 	oidcProvider, err := oidc.NewProvider(ctx, config.OIDC, login.DefaultLoggedOutPath, config.ExternalSecure, commands, queries, authRepo, keys.OIDC, keys.OIDCKey, eventstore, dbClient, userAgentInterceptor, instanceInterceptor.Handler, accessInterceptor.Handle)
 	if err != nil {
 		return fmt.Errorf("unable to start oidc provider: %w", err)
@@ -272,6 +295,10 @@ func startAPIs(
 	}
 	apis.RegisterHandler(saml.HandlerPrefix, samlProvider.HttpHandler())
 
+	if err := apis.RegisterServer(ctx, session.CreateServer(commands, queries, authRepo, keys.User, config.ExternalSecure, op.AuthCallbackURL(oidcProvider), provider.AuthCallbackURL(samlProvider))); err != nil {
+		return err
+	}
+
 	c, err := console.Start(config.Console, config.ExternalSecure, oidcProvider.IssuerFromRequest, instanceInterceptor.Handler, accessInterceptor.Handle, config.CustomerPortal)
 	if err != nil {
 		return fmt.Errorf("unable to start console: %w", err)
@@ -230,6 +230,18 @@ module.exports = {
           collapsed: true,
           items: ["apis/assets/assets"],
         },
+        {
+          type: "category",
+          label: "SCIM 2.0",
+          collapsed: true,
+          items: ["apis/scim/scim"],
+        },
+        {
+          type: "category",
+          label: "User Data Export",
+          collapsed: true,
+          items: ["apis/export/export"],
+        },
       ]
     },
     {
@@ -268,6 +280,10 @@ module.exports = {
         "apis/actions/internal-authentication",
         "apis/actions/external-authentication",
         "apis/actions/complement-token",
+        "apis/actions/user-management",
+        "apis/actions/pre-authentication",
+        "apis/actions/customise-saml-response",
+        "apis/actions/event-actions",
         "apis/actions/objects",
       ]
     },
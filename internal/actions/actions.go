@@ -30,7 +30,7 @@ func actionFailedMessage(err error) string {
 }
 
 func Run(ctx context.Context, ctxParam contextFields, apiParam apiFields, script, name string, opts ...Option) (err error) {
-	config := newRunConfig(ctx, append(opts, withLogger(ctx))...)
+	config := newRunConfig(ctx, append(opts, withLogger(ctx), withKeyValue(ctx))...)
 	if config.functionTimeout == 0 {
 		return z_errs.ThrowInternal(nil, "ACTIO-uCpCx", "Errrors.Internal")
 	}
@@ -54,6 +54,10 @@ func Run(ctx context.Context, ctxParam contextFields, apiParam apiFields, script
 		}
 	}()
 
+	if err := setSecrets(ctx, config); err != nil {
+		return err
+	}
+
 	if err := executeScript(config, ctxParam, apiParam, script); err != nil {
 		return err
 	}
@@ -133,7 +137,8 @@ func executeFn(config *runConfig, fn jsAction) (err error) {
 }
 
 func ActionToOptions(a *query.Action) []Option {
-	opts := make([]Option, 0, 1)
+	opts := make([]Option, 0, 3)
+	opts = append(opts, WithActionID(a.ID), WithResourceOwner(a.ResourceOwner))
 	if a.AllowedToFail {
 		opts = append(opts, WithAllowedToFail())
 	}
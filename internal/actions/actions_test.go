@@ -3,11 +3,13 @@ package actions
 import (
 	"context"
 	"errors"
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/dop251/goja"
 	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/logstore/emitters/execution"
 )
 
 func TestRun(t *testing.T) {
@@ -69,3 +71,28 @@ function testFunc() {
 		})
 	}
 }
+
+func TestRun_logRecorder(t *testing.T) {
+	SetLogstoreService(logstore.New(nil, nil, nil))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	messages := make([]string, 0, 3)
+	err := Run(ctx, nil, nil, `
+let logger = require('zitadel/log')
+function testFunc() {
+	logger.log('hello')
+}`,
+		"testFunc",
+		WithLogRecorder(func(record *execution.Record) {
+			messages = append(messages, record.Message)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("Run() unexpected error = %v", err)
+	}
+	want := []string{actionStartedMessage, "hello", actionSucceededMessage}
+	if !reflect.DeepEqual(messages, want) {
+		t.Errorf("recorded messages = %v, want %v", messages, want)
+	}
+}
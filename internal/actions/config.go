@@ -7,6 +7,8 @@ import (
 	"github.com/dop251/goja"
 	"github.com/dop251/goja_nodejs/require"
 	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/logstore/emitters/execution"
 )
 
 const (
@@ -21,8 +23,42 @@ func WithAllowedToFail() Option {
 	}
 }
 
+// WithActionID sets the id of the executed action on the execution logs
+func WithActionID(id string) Option {
+	return func(c *runConfig) {
+		c.actionID = id
+	}
+}
+
+// WithLogMetadata adds the metadata to all execution logs of the run
+func WithLogMetadata(metadata map[string]interface{}) Option {
+	return func(c *runConfig) {
+		c.logMetadata = metadata
+	}
+}
+
+// WithDryRun executes the action without persisting its changes,
+// e.g. the key-value storage is only changed in memory for the run
+func WithDryRun() Option {
+	return func(c *runConfig) {
+		c.dryRun = true
+	}
+}
+
+// WithLogRecorder passes each execution log of the run to record additionally to storing it
+func WithLogRecorder(record func(*execution.Record)) Option {
+	return func(c *runConfig) {
+		c.logRecorder = record
+	}
+}
+
 type runConfig struct {
 	allowedToFail bool
+	dryRun        bool
+	actionID      string
+	resourceOwner string
+	logMetadata   map[string]interface{}
+	logRecorder   func(*execution.Record)
 	functionTimeout,
 	scriptTimeout time.Duration
 	modules    map[string]require.ModuleLoader
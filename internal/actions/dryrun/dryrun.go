@@ -0,0 +1,380 @@
+package dryrun
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/zitadel/saml/pkg/provider"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/actions/object"
+	"github.com/zitadel/zitadel/internal/actions/trigger"
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/logstore/emitters/execution"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	// maxTimeout is the same as for the execution of stored actions
+	maxTimeout = 20 * time.Second
+	// authMethod is passed to the triggers which provide the method of the authentication
+	authMethod = "password"
+	// claimActionLogFormat is the claim the logs of the action are appended to, the same as on the token
+	claimActionLogFormat = "urn:zitadel:iam:action:%s:log"
+)
+
+// Context is the fake context an action is executed with on a dry run.
+// The values are passed to the action the same way the trigger of the flow passes the real ones.
+type Context struct {
+	User         *domain.Human
+	AuthRequest  *domain.AuthRequest
+	ExternalUser *domain.ExternalUser
+	// Metadata of the user returned by `getMetadata`
+	Metadata []*domain.Metadata
+}
+
+// Event is the fake event an event action is executed with on a dry run,
+// the payload is the json of the event
+type Event struct {
+	Type          string
+	AggregateType string
+	AggregateID   string
+	ResourceOwner string
+	Sequence      uint64
+	CreationDate  time.Time
+	EditorUser    string
+	EditorService string
+	Payload       []byte
+}
+
+// Result of a dry run
+type Result struct {
+	// Logs are all execution logs of the run, including the ones of `zitadel/log`
+	Logs []*execution.Record
+	// MutatedFields are the values the action changed using the api, e.g. `api.setFirstName()`
+	MutatedFields map[string]interface{}
+	// Err is the error the action failed with, regardless if it's allowed to fail
+	Err error
+}
+
+// Run executes the action on the trigger of the flow with the fake context.
+// Nothing the action mutates is persisted, changes of the key-value storage are only kept in memory during the run.
+// Calls to other services using `zitadel/http` are executed.
+func Run(ctx context.Context, action *domain.Action, flowType domain.FlowType, triggerType domain.TriggerType, fake *Context) (*Result, error) {
+	if !flowType.HasTrigger(triggerType) {
+		return nil, errors.ThrowInvalidArgument(nil, "DRYRN-ooS3i", "Errors.Flow.WrongTriggerType")
+	}
+	t, err := newTrigger(ctx, action, flowType, triggerType, fake)
+	if err != nil {
+		return nil, err
+	}
+	return run(ctx, action, t), nil
+}
+
+// RunEvent executes the action as event action with the fake event,
+// the payload is passed without password hashes, secrets, codes and tokens, the same way as for stored events
+func RunEvent(ctx context.Context, action *domain.Action, fake *Event) (*Result, error) {
+	if fake == nil || fake.Type == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "DRYRN-Ko3ie", "Errors.Flow.EventTypeMissing")
+	}
+	return run(ctx, action, &triggerFields{
+		ctxFields:     trigger.EventContextFields(fake.event(ctx, action)),
+		mutatedFields: noMutations,
+	}), nil
+}
+
+func run(ctx context.Context, action *domain.Action, t *triggerFields) *Result {
+	timeout := action.Timeout
+	if timeout <= 0 || timeout > maxTimeout {
+		timeout = maxTimeout
+	}
+	actionCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result := &Result{
+		Logs: make([]*execution.Record, 0),
+	}
+	result.Err = actions.Run(
+		actionCtx,
+		actions.SetContextFields(t.ctxFields...),
+		actions.WithAPIFields(t.apiFields...),
+		action.Script,
+		action.Name,
+		actions.WithActionID(action.AggregateID),
+		actions.WithResourceOwner(action.ResourceOwner),
+		actions.WithDryRun(),
+		actions.WithHTTP(actionCtx),
+		actions.WithLogMetadata(map[string]interface{}{"dryRun": true}),
+		actions.WithLogRecorder(func(record *execution.Record) {
+			result.Logs = append(result.Logs, record)
+		}),
+	)
+	result.MutatedFields = t.mutatedFields()
+	return result
+}
+
+// triggerFields provides the fields of the action the same way as the trigger of the flow
+type triggerFields struct {
+	ctxFields     []actions.FieldOption
+	apiFields     []actions.FieldOption
+	mutatedFields func() map[string]interface{}
+}
+
+func newTrigger(ctx context.Context, action *domain.Action, flowType domain.FlowType, triggerType domain.TriggerType, fake *Context) (*triggerFields, error) {
+	fake = fake.withDefaults()
+	switch flowType {
+	case domain.FlowTypeExternalAuthentication, domain.FlowTypeInternalAuthentication:
+		return authenticationTrigger(flowType, triggerType, fake), nil
+	case domain.FlowTypePreAuthentication:
+		return &triggerFields{
+			ctxFields:     trigger.PreAuthenticationContextFields(authMethod, fake.getUser, fake.AuthRequest, fakeHTTPRequest()),
+			mutatedFields: noMutations,
+		}, nil
+	case domain.FlowTypeUserManagement:
+		return userManagementTrigger(ctx, triggerType, fake), nil
+	case domain.FlowTypeCustomiseToken:
+		return customiseTokenTrigger(action, triggerType, fake), nil
+	case domain.FlowTypeCustomiseSAMLResponse:
+		return samlResponseTrigger(fake), nil
+	default:
+		return nil, errors.ThrowInvalidArgument(nil, "DRYRN-Ahd7e", "Errors.Action.DryRun.TriggerNotSupported")
+	}
+}
+
+func authenticationTrigger(flowType domain.FlowType, triggerType domain.TriggerType, fake *Context) *triggerFields {
+	switch triggerType {
+	case domain.TriggerTypePostAuthentication:
+		if flowType == domain.FlowTypeInternalAuthentication {
+			metadata := object.MetadataListFromDomain(nil)
+			return &triggerFields{
+				ctxFields: trigger.PostInternalAuthenticationContextFields(authMethod, fake.AuthRequest, fakeHTTPRequest(), nil),
+				apiFields: trigger.MetadataAPIFields(metadata),
+				mutatedFields: func() map[string]interface{} {
+					return withMetadata(map[string]interface{}{}, nil, metadata)
+				},
+			}
+		}
+		before := externalUserValues(fake.ExternalUser)
+		metadata := object.MetadataListFromDomain(fake.ExternalUser.Metadatas)
+		return &triggerFields{
+			ctxFields: trigger.PostExternalAuthenticationContextFields(nil, fake.ExternalUser, fake.AuthRequest, fakeHTTPRequest(), nil),
+			apiFields: trigger.PostExternalAuthenticationAPIFields(fake.ExternalUser, metadata),
+			mutatedFields: func() map[string]interface{} {
+				return withMetadata(changedValues(before, externalUserValues(fake.ExternalUser)), fake.ExternalUser.Metadatas, metadata)
+			},
+		}
+	case domain.TriggerTypePreCreation:
+		before := humanValues(fake.User)
+		metadata := object.MetadataListFromDomain(nil)
+		return &triggerFields{
+			ctxFields: trigger.PreCreationContextFields(fake.User, fake.AuthRequest, fakeHTTPRequest()),
+			apiFields: trigger.PreCreationAPIFields(fake.User, metadata),
+			mutatedFields: func() map[string]interface{} {
+				return withMetadata(changedValues(before, humanValues(fake.User)), nil, metadata)
+			},
+		}
+	default:
+		grants := &object.UserGrants{UserGrants: make([]object.UserGrant, 0)}
+		return &triggerFields{
+			ctxFields: trigger.PostCreationContextFields(fake.getUser, fake.AuthRequest, fakeHTTPRequest()),
+			apiFields: trigger.UserGrantAPIFields(grants),
+			mutatedFields: func() map[string]interface{} {
+				return withUserGrants(map[string]interface{}{}, grants)
+			},
+		}
+	}
+}
+
+func userManagementTrigger(ctx context.Context, triggerType domain.TriggerType, fake *Context) *triggerFields {
+	editorUserID := authz.GetCtxData(ctx).UserID
+	switch triggerType {
+	case domain.TriggerTypePreCreation:
+		before := humanValues(fake.User)
+		return &triggerFields{
+			ctxFields: trigger.UserManagementPreCreationContextFields(editorUserID, fake.User),
+			apiFields: object.HumanSetterFields(fake.User),
+			mutatedFields: func() map[string]interface{} {
+				return changedValues(before, humanValues(fake.User))
+			},
+		}
+	case domain.TriggerTypePreUpdate, domain.TriggerTypePostUpdate:
+		profile := fake.User.Profile
+		before := profileValues(profile)
+		t := &triggerFields{
+			ctxFields:     trigger.UserManagementProfileContextFields(editorUserID, fake.getUser, profile),
+			mutatedFields: noMutations,
+		}
+		if triggerType == domain.TriggerTypePreUpdate {
+			t.apiFields = object.ProfileSetterFields(profile)
+			t.mutatedFields = func() map[string]interface{} {
+				return changedValues(before, profileValues(profile))
+			}
+		}
+		return t
+	default:
+		return &triggerFields{
+			ctxFields:     trigger.UserManagementUserContextFields(editorUserID, fake.getUser),
+			mutatedFields: noMutations,
+		}
+	}
+}
+
+// customiseTokenTrigger starts without claims, the metadata set by the action is returned instead of stored
+func customiseTokenTrigger(action *domain.Action, triggerType domain.TriggerType, fake *Context) *triggerFields {
+	claims := make(trigger.Claims)
+	claimLogs := []string{}
+	metadata := make([]*domain.Metadata, 0)
+	setMetadata := func(md *domain.Metadata) error {
+		metadata = append(metadata, md)
+		return nil
+	}
+	t := &triggerFields{
+		ctxFields: trigger.CustomiseTokenContextFields(fake.getMetadata),
+		apiFields: trigger.PreAccessTokenCreationAPIFields(claims, &claimLogs, setMetadata),
+		mutatedFields: func() map[string]interface{} {
+			if len(claimLogs) > 0 {
+				claims.AppendClaims(fmt.Sprintf(claimActionLogFormat, action.Name), claimLogs)
+			}
+			return withClaims(withSetMetadata(map[string]interface{}{}, metadata), claims)
+		},
+	}
+	if triggerType == domain.TriggerTypePreUserinfoCreation {
+		t.apiFields = trigger.PreUserinfoCreationAPIFields(claims, &claimLogs, setMetadata)
+	}
+	return t
+}
+
+// samlResponseTrigger starts with the attributes of the fake user, only the attributes the action set are returned
+func samlResponseTrigger(fake *Context) *triggerFields {
+	attributes := fake.samlAttributes()
+	before := attributeValues(attributes)
+	return &triggerFields{
+		ctxFields: trigger.PreSAMLResponseCreationContextFields(fake.getUser, fake.getMetadata, fake.getGrants),
+		apiFields: trigger.PreSAMLResponseCreationAPIFields(attributes),
+		mutatedFields: func() map[string]interface{} {
+			changed := changedAttributes(before, attributeValues(attributes))
+			if len(changed) == 0 {
+				return map[string]interface{}{}
+			}
+			return map[string]interface{}{"attributes": changed}
+		},
+	}
+}
+
+func (c *Context) withDefaults() *Context {
+	fake := new(Context)
+	if c != nil {
+		*fake = *c
+	}
+	if fake.User == nil {
+		fake.User = new(domain.Human)
+	}
+	if fake.User.Profile == nil {
+		fake.User.Profile = new(domain.Profile)
+	}
+	if fake.AuthRequest == nil {
+		fake.AuthRequest = new(domain.AuthRequest)
+	}
+	if fake.ExternalUser == nil {
+		fake.ExternalUser = new(domain.ExternalUser)
+	}
+	return fake
+}
+
+// getUser returns the fake user instead of querying it
+func (c *Context) getUser() (*query.User, error) {
+	return queryUserFromHuman(c.User), nil
+}
+
+// getMetadata returns the fake metadata instead of querying it
+func (c *Context) getMetadata() (*query.UserMetadataList, error) {
+	list := &query.UserMetadataList{
+		SearchResponse: query.SearchResponse{
+			Count:          uint64(len(c.Metadata)),
+			LatestSequence: new(query.LatestSequence),
+		},
+		Metadata: make([]*query.UserMetadata, len(c.Metadata)),
+	}
+	for i, md := range c.Metadata {
+		list.Metadata[i] = &query.UserMetadata{
+			ResourceOwner: c.User.ResourceOwner,
+			Key:           md.Key,
+			Value:         md.Value,
+		}
+	}
+	return list, nil
+}
+
+// getGrants returns no grants, as the fake user has none
+func (c *Context) getGrants() (*query.UserGrants, error) {
+	return &query.UserGrants{
+		SearchResponse: query.SearchResponse{
+			LatestSequence: new(query.LatestSequence),
+		},
+		UserGrants: make([]*query.UserGrant, 0),
+	}, nil
+}
+
+// samlAttributes are the default attributes of the SAML response for the fake user
+func (c *Context) samlAttributes() *provider.Attributes {
+	attributes := new(provider.Attributes)
+	attributes.SetUsername(c.User.PreferredLoginName)
+	attributes.SetUserID(c.User.AggregateID)
+	if c.User.Email != nil {
+		attributes.SetEmail(c.User.EmailAddress)
+	}
+	attributes.SetSurname(c.User.LastName)
+	attributes.SetGivenName(c.User.FirstName)
+	attributes.SetFullName(c.User.DisplayName)
+	return attributes
+}
+
+// event returns the fake event as if it was stored by the editor in the organisation of the action
+func (e *Event) event(ctx context.Context, action *domain.Action) eventstore.Event {
+	resourceOwner := e.ResourceOwner
+	if resourceOwner == "" {
+		resourceOwner = action.ResourceOwner
+	}
+	creationDate := e.CreationDate
+	if creationDate.IsZero() {
+		creationDate = time.Now()
+	}
+	editorUser := e.EditorUser
+	if editorUser == "" {
+		editorUser = authz.GetCtxData(ctx).UserID
+	}
+	return eventstore.BaseEventFromRepo(&repository.Event{
+		Sequence:      e.Sequence,
+		CreationDate:  creationDate,
+		Type:          repository.EventType(e.Type),
+		Data:          e.Payload,
+		EditorService: e.EditorService,
+		EditorUser:    editorUser,
+		ResourceOwner: sql.NullString{String: resourceOwner, Valid: resourceOwner != ""},
+		InstanceID:    authz.GetInstance(ctx).InstanceID(),
+		AggregateType: repository.AggregateType(e.AggregateType),
+		AggregateID:   e.AggregateID,
+	})
+}
+
+// fakeHTTPRequest is passed as `httpRequest`, as there is no request of the user on a dry run
+func fakeHTTPRequest() *http.Request {
+	return &http.Request{
+		Method: http.MethodGet,
+		URL:    &url.URL{Path: "/"},
+		Proto:  "HTTP/1.1",
+		Header: make(http.Header),
+	}
+}
+
+func noMutations() map[string]interface{} {
+	return map[string]interface{}{}
+}
@@ -0,0 +1,385 @@
+package dryrun
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/logstore"
+)
+
+func TestRun(t *testing.T) {
+	actions.SetLogstoreService(logstore.New(nil, nil, nil))
+	type args struct {
+		script      string
+		flowType    domain.FlowType
+		triggerType domain.TriggerType
+		fake        *Context
+	}
+	type res struct {
+		mutatedFields map[string]interface{}
+		logs          []string
+		runErr        bool
+		err           func(error) bool
+	}
+	tests := []struct {
+		name string
+		args args
+		res  res
+	}{
+		{
+			name: "wrong trigger type, error",
+			args: args{
+				script:      "function test(ctx, api) {}",
+				flowType:    domain.FlowTypePreAuthentication,
+				triggerType: domain.TriggerTypePostCreation,
+			},
+			res: res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "external authentication, external user mutated",
+			args: args{
+				script: `
+let logger = require('zitadel/log')
+function test(ctx, api) {
+	logger.log(ctx.v1.externalUser.human.firstName)
+	api.setFirstName('Jane')
+	api.setEmailVerified(true)
+	api.v1.user.appendMetadata('key', 'value')
+}`,
+				flowType:    domain.FlowTypeExternalAuthentication,
+				triggerType: domain.TriggerTypePostAuthentication,
+				fake: &Context{
+					ExternalUser: &domain.ExternalUser{
+						FirstName: "John",
+						Email:     "john@example.com",
+					},
+				},
+			},
+			res: res{
+				mutatedFields: map[string]interface{}{
+					"firstName":     "Jane",
+					"emailVerified": true,
+					"metadata": []interface{}{
+						map[string]interface{}{"key": "key", "value": `"value"`},
+					},
+				},
+				logs: []string{"action run started", "John", "action run succeeded"},
+			},
+		},
+		{
+			name: "internal authentication pre creation, user mutated",
+			args: args{
+				script: `
+function test(ctx, api) {
+	api.setEmail(ctx.v1.user.username + '@example.com')
+}`,
+				flowType:    domain.FlowTypeInternalAuthentication,
+				triggerType: domain.TriggerTypePreCreation,
+				fake: &Context{
+					User: &domain.Human{
+						Username: "john",
+					},
+				},
+			},
+			res: res{
+				mutatedFields: map[string]interface{}{
+					"email": "john@example.com",
+				},
+				logs: []string{"action run started", "action run succeeded"},
+			},
+		},
+		{
+			name: "post creation, user grant appended",
+			args: args{
+				script: `
+function test(ctx, api) {
+	api.v1.appendUserGrant({
+		projectId: 'project',
+		roles: ['role'],
+	})
+}`,
+				flowType:    domain.FlowTypeExternalAuthentication,
+				triggerType: domain.TriggerTypePostCreation,
+			},
+			res: res{
+				mutatedFields: map[string]interface{}{
+					"userGrants": []interface{}{
+						map[string]interface{}{"projectId": "project", "projectGrantId": "", "roles": []interface{}{"role"}},
+					},
+				},
+				logs: []string{"action run started", "action run succeeded"},
+			},
+		},
+		{
+			name: "user management pre update, profile mutated",
+			args: args{
+				script: `
+function test(ctx, api) {
+	if (ctx.v1.editorUserId !== 'editor') {
+		throw 'wrong editor'
+	}
+	api.setDisplayName(ctx.v1.profile.firstName + ' ' + ctx.v1.getUser().human.lastName)
+}`,
+				flowType:    domain.FlowTypeUserManagement,
+				triggerType: domain.TriggerTypePreUpdate,
+				fake: &Context{
+					User: &domain.Human{
+						Profile: &domain.Profile{
+							FirstName: "John",
+							LastName:  "Doe",
+						},
+					},
+				},
+			},
+			res: res{
+				mutatedFields: map[string]interface{}{
+					"displayName": "John Doe",
+				},
+				logs: []string{"action run started", "action run succeeded"},
+			},
+		},
+		{
+			name: "customise userinfo, claims and metadata set",
+			args: args{
+				script: `
+function test(ctx, api) {
+	let md = ctx.v1.user.getMetadata()
+	api.v1.userinfo.setClaim('role', md.metadata[0].value)
+	api.v1.userinfo.setClaim('role', 'other')
+	api.v1.user.setMetadata('key', 'value')
+}`,
+				flowType:    domain.FlowTypeCustomiseToken,
+				triggerType: domain.TriggerTypePreUserinfoCreation,
+				fake: &Context{
+					Metadata: []*domain.Metadata{
+						{Key: "role", Value: []byte(`"admin"`)},
+					},
+				},
+			},
+			res: res{
+				mutatedFields: map[string]interface{}{
+					"claims": map[string]interface{}{
+						"role":                            "admin",
+						"urn:zitadel:iam:action:test:log": []interface{}{`key "role" already exists`},
+					},
+					"metadata": []interface{}{
+						map[string]interface{}{"key": "key", "value": `"value"`},
+					},
+				},
+				logs: []string{"action run started", "action run succeeded"},
+			},
+		},
+		{
+			name: "customise access token, claim set",
+			args: args{
+				script: `
+function test(ctx, api) {
+	api.v1.claims.setClaim('groups', ['a', 'b'])
+}`,
+				flowType:    domain.FlowTypeCustomiseToken,
+				triggerType: domain.TriggerTypePreAccessTokenCreation,
+			},
+			res: res{
+				mutatedFields: map[string]interface{}{
+					"claims": map[string]interface{}{
+						"groups": []interface{}{"a", "b"},
+					},
+				},
+				logs: []string{"action run started", "action run succeeded"},
+			},
+		},
+		{
+			name: "customise saml response, attributes set",
+			args: args{
+				script: `
+function test(ctx, api) {
+	api.v1.attributes.setFullName(ctx.v1.getUser().human.firstName + ' ' + ctx.v1.getUser().human.lastName)
+	api.v1.attributes.setCustomAttribute('roles', 'urn:oasis:names:tc:SAML:2.0:attrname-format:basic', 'admin', 'user')
+}`,
+				flowType:    domain.FlowTypeCustomiseSAMLResponse,
+				triggerType: domain.TriggerTypePreSAMLResponseCreation,
+				fake: &Context{
+					User: &domain.Human{
+						Profile: &domain.Profile{
+							FirstName:   "John",
+							LastName:    "Doe",
+							DisplayName: "John",
+						},
+					},
+				},
+			},
+			res: res{
+				mutatedFields: map[string]interface{}{
+					"attributes": map[string]interface{}{
+						"FullName": []interface{}{"John Doe"},
+						"roles":    []interface{}{"admin", "user"},
+					},
+				},
+				logs: []string{"action run started", "action run succeeded"},
+			},
+		},
+		{
+			name: "pre authentication, action failed",
+			args: args{
+				script: `
+function test(ctx, api) {
+	if (ctx.v1.getUser().username === 'blocked') {
+		throw 'user is blocked'
+	}
+}`,
+				flowType:    domain.FlowTypePreAuthentication,
+				triggerType: domain.TriggerTypePreAuthentication,
+				fake: &Context{
+					User: &domain.Human{
+						Username: "blocked",
+					},
+				},
+			},
+			res: res{
+				mutatedFields: map[string]interface{}{},
+				runErr:        true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := authz.SetCtxData(context.Background(), authz.CtxData{UserID: "editor"})
+			action := &domain.Action{
+				Name:   "test",
+				Script: tt.args.script,
+			}
+			got, err := Run(ctx, action, tt.args.flowType, tt.args.triggerType, tt.args.fake)
+			if tt.res.err != nil {
+				if !tt.res.err(err) {
+					t.Errorf("got wrong err: %v ", err)
+				}
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			if tt.res.runErr {
+				assert.Error(t, got.Err)
+			} else {
+				assert.NoError(t, got.Err)
+			}
+			assert.Equal(t, tt.res.mutatedFields, got.MutatedFields)
+			if tt.res.logs == nil {
+				return
+			}
+			logs := make([]string, len(got.Logs))
+			for i, record := range got.Logs {
+				logs[i] = record.Message
+			}
+			assert.Equal(t, tt.res.logs, logs)
+		})
+	}
+}
+
+func TestRunEvent(t *testing.T) {
+	actions.SetLogstoreService(logstore.New(nil, nil, nil))
+	type args struct {
+		script string
+		fake   *Event
+	}
+	type res struct {
+		logs   []string
+		runErr bool
+		err    func(error) bool
+	}
+	tests := []struct {
+		name string
+		args args
+		res  res
+	}{
+		{
+			name: "event type missing, error",
+			args: args{
+				script: "function test(ctx, api) {}",
+				fake:   &Event{},
+			},
+			res: res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "event passed redacted, ok",
+			args: args{
+				script: `
+let logger = require('zitadel/log')
+function test(ctx, api) {
+	if (ctx.v1.event.payload.password !== undefined || ctx.v1.event.resourceOwner !== 'org1' || ctx.v1.event.editorUser !== 'editor') {
+		throw 'wrong event'
+	}
+	logger.log(ctx.v1.event.type + ' ' + ctx.v1.event.payload.userName)
+}`,
+				fake: &Event{
+					Type:          "user.human.added",
+					AggregateType: "user",
+					AggregateID:   "user1",
+					Payload:       []byte(`{"userName": "john", "password": "secret"}`),
+				},
+			},
+			res: res{
+				logs: []string{"action run started", "user.human.added john", "action run succeeded"},
+			},
+		},
+		{
+			name: "action failed, run error",
+			args: args{
+				script: "function test(ctx, api) { throw 'failed' }",
+				fake: &Event{
+					Type: "user.human.added",
+				},
+			},
+			res: res{
+				runErr: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := authz.SetCtxData(context.Background(), authz.CtxData{UserID: "editor"})
+			action := &domain.Action{
+				ObjectRoot: models.ObjectRoot{
+					ResourceOwner: "org1",
+				},
+				Name:   "test",
+				Script: tt.args.script,
+			}
+			got, err := RunEvent(ctx, action, tt.args.fake)
+			if tt.res.err != nil {
+				if !tt.res.err(err) {
+					t.Errorf("got wrong err: %v ", err)
+				}
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			if tt.res.runErr {
+				assert.Error(t, got.Err)
+			} else {
+				assert.NoError(t, got.Err)
+			}
+			assert.Equal(t, map[string]interface{}{}, got.MutatedFields)
+			if tt.res.logs == nil {
+				return
+			}
+			logs := make([]string, len(got.Logs))
+			for i, record := range got.Logs {
+				logs[i] = record.Message
+			}
+			assert.Equal(t, tt.res.logs, logs)
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package dryrun
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/zitadel/saml/pkg/provider"
+
+	"github.com/zitadel/zitadel/internal/actions/object"
+	"github.com/zitadel/zitadel/internal/actions/trigger"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// changedValues returns the values of after which differ from before
+func changedValues(before, after map[string]interface{}) map[string]interface{} {
+	changed := make(map[string]interface{})
+	for key, value := range after {
+		if before[key] != value {
+			changed[key] = value
+		}
+	}
+	return changed
+}
+
+func humanValues(user *domain.Human) map[string]interface{} {
+	values := profileValues(user.Profile)
+	values["username"] = user.Username
+	values["email"] = ""
+	values["emailVerified"] = false
+	values["phone"] = ""
+	values["phoneVerified"] = false
+	if user.Email != nil {
+		values["email"] = user.EmailAddress
+		values["emailVerified"] = user.IsEmailVerified
+	}
+	if user.Phone != nil {
+		values["phone"] = user.PhoneNumber
+		values["phoneVerified"] = user.IsPhoneVerified
+	}
+	return values
+}
+
+func profileValues(profile *domain.Profile) map[string]interface{} {
+	if profile == nil {
+		profile = new(domain.Profile)
+	}
+	return map[string]interface{}{
+		"firstName":         profile.FirstName,
+		"lastName":          profile.LastName,
+		"nickName":          profile.NickName,
+		"displayName":       profile.DisplayName,
+		"preferredLanguage": profile.PreferredLanguage.String(),
+		"gender":            int32(profile.Gender),
+	}
+}
+
+func externalUserValues(user *domain.ExternalUser) map[string]interface{} {
+	return map[string]interface{}{
+		"firstName":         user.FirstName,
+		"lastName":          user.LastName,
+		"nickName":          user.NickName,
+		"displayName":       user.DisplayName,
+		"preferredLanguage": user.PreferredLanguage.String(),
+		"preferredUsername": user.PreferredUsername,
+		"email":             user.Email,
+		"emailVerified":     user.IsEmailVerified,
+		"phone":             user.Phone,
+		"phoneVerified":     user.IsPhoneVerified,
+	}
+}
+
+// withMetadata adds the metadata to the values if the action changed it
+func withMetadata(values map[string]interface{}, before []*domain.Metadata, metadata *object.MetadataList) map[string]interface{} {
+	after := object.MetadataListToDomain(metadata)
+	if equalMetadata(before, after) {
+		return values
+	}
+	values["metadata"] = metadataValues(after)
+	return values
+}
+
+// withSetMetadata adds the metadata the action set on the user, instead of storing it
+func withSetMetadata(values map[string]interface{}, metadata []*domain.Metadata) map[string]interface{} {
+	if len(metadata) == 0 {
+		return values
+	}
+	values["metadata"] = metadataValues(metadata)
+	return values
+}
+
+func metadataValues(metadata []*domain.Metadata) []interface{} {
+	list := make([]interface{}, len(metadata))
+	for i, md := range metadata {
+		list[i] = map[string]interface{}{
+			"key":   md.Key,
+			"value": string(md.Value),
+		}
+	}
+	return list
+}
+
+func equalMetadata(before, after []*domain.Metadata) bool {
+	if len(before) != len(after) {
+		return false
+	}
+	for i := range before {
+		if before[i].Key != after[i].Key || !bytes.Equal(before[i].Value, after[i].Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// withUserGrants adds the user grants to the values if the action appended any
+func withUserGrants(values map[string]interface{}, grants *object.UserGrants) map[string]interface{} {
+	if len(grants.UserGrants) == 0 {
+		return values
+	}
+	list := make([]interface{}, len(grants.UserGrants))
+	for i, grant := range grants.UserGrants {
+		roles := make([]interface{}, len(grant.Roles))
+		for j, role := range grant.Roles {
+			roles[j] = role
+		}
+		list[i] = map[string]interface{}{
+			"projectId":      grant.ProjectID,
+			"projectGrantId": grant.ProjectGrantID,
+			"roles":          roles,
+		}
+	}
+	values["userGrants"] = list
+	return values
+}
+
+// withClaims adds the claims to the values if the action added any,
+// the values are converted to json types, so they can be returned by the api
+func withClaims(values map[string]interface{}, claims trigger.Claims) map[string]interface{} {
+	if len(claims) == 0 {
+		return values
+	}
+	converted := make(map[string]interface{}, len(claims))
+	for key, value := range claims {
+		converted[key] = jsonValue(value)
+	}
+	values["claims"] = converted
+	return values
+}
+
+func jsonValue(value interface{}) interface{} {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	var converted interface{}
+	if err = json.Unmarshal(data, &converted); err != nil {
+		return fmt.Sprint(value)
+	}
+	return converted
+}
+
+// attributeValues maps the names of the attributes of the SAML response to their values
+func attributeValues(attributes *provider.Attributes) map[string][]string {
+	values := make(map[string][]string)
+	for _, attribute := range attributes.GetSAML() {
+		values[attribute.Name] = attribute.AttributeValue
+	}
+	return values
+}
+
+// changedAttributes returns the attributes of after which differ from before
+func changedAttributes(before, after map[string][]string) map[string]interface{} {
+	changed := make(map[string]interface{})
+	for name, value := range after {
+		if equalStrings(before[name], value) {
+			continue
+		}
+		list := make([]interface{}, len(value))
+		for i, v := range value {
+			list[i] = v
+		}
+		changed[name] = list
+	}
+	return changed
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func queryUserFromHuman(user *domain.Human) *query.User {
+	u := &query.User{
+		ID:                 user.AggregateID,
+		CreationDate:       user.CreationDate,
+		ChangeDate:         user.ChangeDate,
+		ResourceOwner:      user.ResourceOwner,
+		Sequence:           user.Sequence,
+		State:              user.State,
+		Type:               domain.UserTypeHuman,
+		Username:           user.Username,
+		LoginNames:         user.LoginNames,
+		PreferredLoginName: user.PreferredLoginName,
+		Human:              new(query.Human),
+	}
+	if user.Profile != nil {
+		u.Human.FirstName = user.FirstName
+		u.Human.LastName = user.LastName
+		u.Human.NickName = user.NickName
+		u.Human.DisplayName = user.DisplayName
+		u.Human.PreferredLanguage = user.PreferredLanguage
+		u.Human.Gender = user.Gender
+	}
+	if user.Email != nil {
+		u.Human.Email = user.EmailAddress
+		u.Human.IsEmailVerified = user.IsEmailVerified
+	}
+	if user.Phone != nil {
+		u.Human.Phone = user.PhoneNumber
+		u.Human.IsPhoneVerified = user.IsPhoneVerified
+	}
+	return u
+}
@@ -5,9 +5,11 @@ import (
 	"context"
 	"encoding/json"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/dop251/goja"
@@ -182,6 +184,58 @@ func (*transport) RoundTrip(req *http.Request) (*http.Response, error) {
 	return http.DefaultTransport.RoundTrip(req)
 }
 
+// CheckURLAllowed returns an error if the host of the url is part of the deny list of the http config
+func CheckURLAllowed(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return z_errs.ThrowInvalidArgument(err, "ACTIO-Ahx3o", "invalid url")
+	}
+	if httpConfig != nil && isHostBlocked(httpConfig.DenyList, u) {
+		return z_errs.ThrowInvalidArgument(nil, "ACTIO-Eiz2a", "host is denied")
+	}
+	return nil
+}
+
+// NewDenyListTransport returns a transport, which checks the host of every request
+// as well as the ip address of every connection (in case a host resolves to a denied ip) against the deny list of the http config
+func NewDenyListTransport() http.RoundTripper {
+	defaultTransport := http.DefaultTransport.(*http.Transport).Clone()
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   denyListControl,
+	}
+	defaultTransport.DialContext = dialer.DialContext
+	return &denyListTransport{base: defaultTransport}
+}
+
+type denyListTransport struct {
+	base http.RoundTripper
+}
+
+func (t *denyListTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := CheckURLAllowed(req.URL.String()); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
+
+func denyListControl(_, address string, _ syscall.RawConn) error {
+	if httpConfig == nil {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	for _, blocked := range httpConfig.DenyList {
+		if blocked.Matches(host) {
+			return z_errs.ThrowInvalidArgument(nil, "ACTIO-Ohp5e", "address is denied")
+		}
+	}
+	return nil
+}
+
 func isHostBlocked(denyList []AddressChecker, address *url.URL) bool {
 	for _, blocked := range denyList {
 		if blocked.Matches(address.Hostname()) {
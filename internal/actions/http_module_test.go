@@ -67,6 +67,60 @@ func Test_isHostBlocked(t *testing.T) {
 	}
 }
 
+func TestCheckURLAllowed(t *testing.T) {
+	SetHTTPConfig(&HTTPConfig{
+		DenyList: []AddressChecker{
+			mustNewIPChecker(t, "10.0.0.0/8"),
+			&DomainChecker{Domain: "localhost"},
+		},
+	})
+	defer SetHTTPConfig(nil)
+
+	tests := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{
+			name: "allowed",
+			url:  "https://example.com/hook",
+		},
+		{
+			name:    "denied ip",
+			url:     "http://10.1.2.3:8080/hook",
+			wantErr: true,
+		},
+		{
+			name:    "denied domain",
+			url:     "http://localhost/hook",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckURLAllowed(tt.url); (err != nil) != tt.wantErr {
+				t.Errorf("CheckURLAllowed() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_denyListControl(t *testing.T) {
+	SetHTTPConfig(&HTTPConfig{
+		DenyList: []AddressChecker{
+			mustNewIPChecker(t, "127.0.0.0/8"),
+		},
+	})
+	defer SetHTTPConfig(nil)
+
+	if err := denyListControl("tcp", "127.0.0.1:443", nil); err == nil {
+		t.Error("connection to denied ip must fail")
+	}
+	if err := denyListControl("tcp", "93.184.216.34:443", nil); err != nil {
+		t.Errorf("connection to allowed ip failed: %v", err)
+	}
+}
+
 func mustNewIPChecker(t *testing.T, ip string) AddressChecker {
 	t.Helper()
 	checker, err := NewIPChecker(ip)
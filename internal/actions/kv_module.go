@@ -0,0 +1,137 @@
+package actions
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/dop251/goja"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+var (
+	keyValueQuerier  KeyValueQuerier
+	keyValueCommands KeyValueCommands
+)
+
+// KeyValueQuerier reads the key-value storage of an organisation
+type KeyValueQuerier interface {
+	ActionKeyValue(ctx context.Context, shouldTriggerBulk bool, orgID, key string) (*query.ActionKeyValue, error)
+}
+
+// KeyValueCommands changes the key-value storage of an organisation,
+// the quotas of the storage are checked by the commands
+type KeyValueCommands interface {
+	SetActionKeyValue(ctx context.Context, key string, value []byte, resourceOwner string) (*domain.ObjectDetails, error)
+	RemoveActionKeyValue(ctx context.Context, key, resourceOwner string) (*domain.ObjectDetails, error)
+}
+
+// SetKeyValueStorage enables the `zitadel/kv` module
+func SetKeyValueStorage(querier KeyValueQuerier, commands KeyValueCommands) {
+	keyValueQuerier = querier
+	keyValueCommands = commands
+}
+
+func withKeyValue(ctx context.Context) Option {
+	return func(c *runConfig) {
+		if keyValueQuerier == nil || keyValueCommands == nil || c.resourceOwner == "" {
+			return
+		}
+		c.modules["zitadel/kv"] = func(runtime *goja.Runtime, module *goja.Object) {
+			requireKeyValue(ctx, c.resourceOwner, c.dryRun, runtime, module)
+		}
+	}
+}
+
+type keyValue struct {
+	runtime       *goja.Runtime
+	resourceOwner string
+	// dryRun values are set and removed (nil) in memory only
+	dryRun       bool
+	dryRunValues map[string][]byte
+}
+
+func requireKeyValue(ctx context.Context, resourceOwner string, dryRun bool, runtime *goja.Runtime, module *goja.Object) {
+	kv := &keyValue{
+		runtime:       runtime,
+		resourceOwner: resourceOwner,
+		dryRun:        dryRun,
+		dryRunValues:  make(map[string][]byte),
+	}
+	o := module.Get("exports").(*goja.Object)
+	logging.OnError(o.Set("get", kv.get(ctx))).Warn("unable to set module")
+	logging.OnError(o.Set("set", kv.set(ctx))).Warn("unable to set module")
+	logging.OnError(o.Set("remove", kv.remove(ctx))).Warn("unable to set module")
+}
+
+// get returns the parsed value of the key or undefined if the key does not exist
+func (kv *keyValue) get(ctx context.Context) func(key string) goja.Value {
+	return func(key string) goja.Value {
+		data, err := kv.value(ctx, key)
+		if z_errs.IsNotFound(err) {
+			return goja.Undefined()
+		}
+		if err != nil {
+			logging.WithError(err).Debug("unable to get value")
+			panic(err)
+		}
+		var value interface{}
+		if err = json.Unmarshal(data, &value); err != nil {
+			logging.WithError(err).Debug("unable to unmarshal value")
+			panic(err)
+		}
+		return kv.runtime.ToValue(value)
+	}
+}
+
+func (kv *keyValue) value(ctx context.Context, key string) ([]byte, error) {
+	if value, ok := kv.dryRunValues[key]; ok {
+		if value == nil {
+			return nil, z_errs.ThrowNotFound(nil, "ACTIO-ahW3i", "Errors.Action.KeyValue.NotFound")
+		}
+		return value, nil
+	}
+	entry, err := keyValueQuerier.ActionKeyValue(ctx, true, kv.resourceOwner, key)
+	if err != nil {
+		return nil, err
+	}
+	return entry.Value, nil
+}
+
+// set stores the value of the key as json
+func (kv *keyValue) set(ctx context.Context) func(key string, value goja.Value) {
+	return func(key string, value goja.Value) {
+		if value == nil || goja.IsUndefined(value) {
+			panic("value is required, use remove to delete a key")
+		}
+		data, err := json.Marshal(value.Export())
+		if err != nil {
+			logging.WithError(err).Debug("unable to marshal value")
+			panic(err)
+		}
+		if kv.dryRun {
+			kv.dryRunValues[key] = data
+			return
+		}
+		if _, err = keyValueCommands.SetActionKeyValue(ctx, key, data, kv.resourceOwner); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// remove deletes the key, removing a key which does not exist is no error
+func (kv *keyValue) remove(ctx context.Context) func(key string) {
+	return func(key string) {
+		if kv.dryRun {
+			kv.dryRunValues[key] = nil
+			return
+		}
+		_, err := keyValueCommands.RemoveActionKeyValue(ctx, key, kv.resourceOwner)
+		if err != nil && !z_errs.IsNotFound(err) {
+			panic(err)
+		}
+	}
+}
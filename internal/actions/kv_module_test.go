@@ -0,0 +1,148 @@
+package actions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+type mockKeyValueStorage struct {
+	values map[string][]byte
+}
+
+func (m *mockKeyValueStorage) ActionKeyValue(_ context.Context, _ bool, orgID, key string) (*query.ActionKeyValue, error) {
+	value, ok := m.values[orgID+":"+key]
+	if !ok {
+		return nil, z_errs.ThrowNotFound(nil, "ID", "not found")
+	}
+	return &query.ActionKeyValue{Key: key, ResourceOwner: orgID, Value: value}, nil
+}
+
+func (m *mockKeyValueStorage) SetActionKeyValue(_ context.Context, key string, value []byte, resourceOwner string) (*domain.ObjectDetails, error) {
+	if len(value) > 20 {
+		return nil, z_errs.ThrowResourceExhausted(nil, "ID", "quota exceeded")
+	}
+	m.values[resourceOwner+":"+key] = value
+	return &domain.ObjectDetails{ResourceOwner: resourceOwner}, nil
+}
+
+func (m *mockKeyValueStorage) RemoveActionKeyValue(_ context.Context, key, resourceOwner string) (*domain.ObjectDetails, error) {
+	if _, ok := m.values[resourceOwner+":"+key]; !ok {
+		return nil, z_errs.ThrowNotFound(nil, "ID", "not found")
+	}
+	delete(m.values, resourceOwner+":"+key)
+	return &domain.ObjectDetails{ResourceOwner: resourceOwner}, nil
+}
+
+func TestKeyValueModule(t *testing.T) {
+	SetLogstoreService(logstore.New(nil, nil, nil))
+	storage := &mockKeyValueStorage{values: map[string][]byte{
+		"org1:counter": []byte(`{"count":1}`),
+		"org2:counter": []byte(`{"count":10}`),
+	}}
+	SetKeyValueStorage(storage, storage)
+	t.Cleanup(func() {
+		SetKeyValueStorage(nil, nil)
+	})
+
+	tests := []struct {
+		name       string
+		script     string
+		opts       []Option
+		wantErr    bool
+		wantValues map[string][]byte
+	}{
+		{
+			name: "increment",
+			script: `
+let kv = require('zitadel/kv')
+function test() {
+	let counter = kv.get('counter')
+	counter.count++
+	kv.set('counter', counter)
+}`,
+			opts: []Option{WithResourceOwner("org1")},
+			wantValues: map[string][]byte{
+				"org1:counter": []byte(`{"count":2}`),
+				"org2:counter": []byte(`{"count":10}`),
+			},
+		},
+		{
+			name: "missing key undefined, remove",
+			script: `
+let kv = require('zitadel/kv')
+function test() {
+	if (kv.get('missing') !== undefined) {
+		throw 'expected undefined'
+	}
+	kv.remove('missing')
+	kv.remove('counter')
+}`,
+			opts: []Option{WithResourceOwner("org1")},
+			wantValues: map[string][]byte{
+				"org2:counter": []byte(`{"count":10}`),
+			},
+		},
+		{
+			name: "quota exceeded",
+			script: `
+let kv = require('zitadel/kv')
+function test() {
+	kv.set('large', 'value which exceeds the quota')
+}`,
+			opts:    []Option{WithResourceOwner("org1")},
+			wantErr: true,
+		},
+		{
+			name: "dry run, values not stored",
+			script: `
+let kv = require('zitadel/kv')
+function test() {
+	kv.set('counter', {count: 5})
+	if (kv.get('counter').count !== 5) {
+		throw 'expected value of run'
+	}
+	kv.remove('counter')
+	if (kv.get('counter') !== undefined) {
+		throw 'expected undefined'
+	}
+}`,
+			opts: []Option{WithResourceOwner("org1"), WithDryRun()},
+			wantValues: map[string][]byte{
+				"org1:counter": []byte(`{"count":1}`),
+				"org2:counter": []byte(`{"count":10}`),
+			},
+		},
+		{
+			name: "no resource owner, no module",
+			script: `
+let kv = require('zitadel/kv')
+function test() {}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage.values = map[string][]byte{
+				"org1:counter": []byte(`{"count":1}`),
+				"org2:counter": []byte(`{"count":10}`),
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			err := Run(ctx, nil, nil, tt.script, "test", tt.opts...)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantValues, storage.values)
+		})
+	}
+}
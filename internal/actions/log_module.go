@@ -26,15 +26,20 @@ type logger struct {
 	ctx        context.Context
 	started    time.Time
 	instanceID string
+	actionID   string
+	metadata   map[string]interface{}
+	recorder   func(*execution.Record)
 }
 
 // newLogger returns a *logger instance that should only be used for a single action run.
 // The first log call sets the started field for subsequent log calls
-func newLogger(ctx context.Context, instanceID string) *logger {
+func newLogger(ctx context.Context, instanceID, actionID string, metadata map[string]interface{}) *logger {
 	return &logger{
 		ctx:        ctx,
 		started:    time.Time{},
 		instanceID: instanceID,
+		actionID:   actionID,
+		metadata:   metadata,
 	}
 }
 
@@ -61,12 +66,18 @@ func (l *logger) log(msg string, level logrus.Level, last bool) {
 		InstanceID: l.instanceID,
 		Message:    msg,
 		LogLevel:   level,
+		ActionID:   l.actionID,
+		Metadata:   l.metadata,
 	}
 
 	if last {
 		record.Took = ts.Sub(l.started)
 	}
 
+	if l.recorder != nil {
+		l.recorder(record)
+	}
+
 	logstoreService.Handle(l.ctx, record)
 }
 
@@ -74,7 +85,8 @@ func withLogger(ctx context.Context) Option {
 	instance := authz.GetInstance(ctx)
 	instanceID := instance.InstanceID()
 	return func(c *runConfig) {
-		c.logger = newLogger(ctx, instanceID)
+		c.logger = newLogger(ctx, instanceID, c.actionID, c.logMetadata)
+		c.logger.recorder = c.logRecorder
 		c.instanceID = instanceID
 		c.modules["zitadel/log"] = func(runtime *goja.Runtime, module *goja.Object) {
 			console.RequireWithPrinter(c.logger)(runtime, module)
@@ -0,0 +1,53 @@
+package object
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+// EventField maps the event into a javascript object, the payload is parsed from json
+// without password hashes, secrets, codes and tokens
+func EventField(event eventstore.Event) func(c *actions.FieldConfig) interface{} {
+	return func(c *actions.FieldConfig) interface{} {
+		return eventFromEventstore(c, event)
+	}
+}
+
+func eventFromEventstore(c *actions.FieldConfig, event eventstore.Event) goja.Value {
+	var payload interface{}
+	if data := eventstore.RedactedData(event); len(data) > 0 {
+		if err := json.Unmarshal(data, &payload); err != nil {
+			logging.WithError(err).Debug("unable to unmarshal event payload")
+			panic(err)
+		}
+	}
+	return c.Runtime.ToValue(&eventObject{
+		Type:          string(event.Type()),
+		AggregateType: string(event.Aggregate().Type),
+		AggregateID:   event.Aggregate().ID,
+		ResourceOwner: event.Aggregate().ResourceOwner,
+		Sequence:      event.Sequence(),
+		CreationDate:  event.CreationDate(),
+		EditorUser:    event.EditorUser(),
+		EditorService: event.EditorService(),
+		Payload:       c.Runtime.ToValue(payload),
+	})
+}
+
+type eventObject struct {
+	Type          string
+	AggregateType string
+	AggregateID   string
+	ResourceOwner string
+	Sequence      uint64
+	CreationDate  time.Time
+	EditorUser    string
+	EditorService string
+	Payload       goja.Value
+}
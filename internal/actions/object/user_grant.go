@@ -1,10 +1,13 @@
 package object
 
 import (
+	"time"
+
 	"github.com/dop251/goja"
 
 	"github.com/zitadel/zitadel/internal/actions"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
 )
 
 type UserGrants struct {
@@ -17,8 +20,10 @@ type UserGrant struct {
 	Roles          []string
 }
 
-func AppendGrantFunc(userGrants *UserGrants) func(c *actions.FieldConfig) func(call goja.FunctionCall) goja.Value {
-	return func(c *actions.FieldConfig) func(call goja.FunctionCall) goja.Value {
+// AppendGrantFunc returns the value of the `appendUserGrant` api field,
+// it's evaluated lazily as it needs the runtime of the action
+func AppendGrantFunc(userGrants *UserGrants) func(c *actions.FieldConfig) interface{} {
+	return func(c *actions.FieldConfig) interface{} {
 		return func(call goja.FunctionCall) goja.Value {
 			firstArg := objectFromFirstArgument(call, c.Runtime)
 			grant := UserGrant{}
@@ -66,3 +71,57 @@ func UserGrantsToDomain(userID string, actionUserGrants []UserGrant) []*domain.U
 	}
 	return userGrants
 }
+
+func UserGrantsFromQuery(c *actions.FieldConfig, userGrants *query.UserGrants) goja.Value {
+	if userGrants == nil {
+		return c.Runtime.ToValue(nil)
+	}
+	grantList := &userGrantList{
+		Count:     userGrants.Count,
+		Sequence:  userGrants.Sequence,
+		Timestamp: userGrants.Timestamp,
+		Grants:    make([]*userGrant, len(userGrants.UserGrants)),
+	}
+
+	for i, grant := range userGrants.UserGrants {
+		grantList.Grants[i] = &userGrant{
+			Id:                         grant.ID,
+			ProjectGrantId:             grant.GrantID,
+			State:                      grant.State,
+			CreationDate:               grant.CreationDate,
+			ChangeDate:                 grant.ChangeDate,
+			Sequence:                   grant.Sequence,
+			UserId:                     grant.UserID,
+			Roles:                      grant.Roles,
+			UserResourceOwner:          grant.UserResourceOwner,
+			UserGrantResourceOwner:     grant.ResourceOwner,
+			UserGrantResourceOwnerName: grant.OrgName,
+			ProjectId:                  grant.ProjectID,
+			ProjectName:                grant.ProjectName,
+		}
+	}
+	return c.Runtime.ToValue(grantList)
+}
+
+type userGrantList struct {
+	Count     uint64
+	Sequence  uint64
+	Timestamp time.Time
+	Grants    []*userGrant
+}
+
+type userGrant struct {
+	Id                         string
+	ProjectGrantId             string
+	State                      domain.UserGrantState
+	CreationDate               time.Time
+	ChangeDate                 time.Time
+	Sequence                   uint64
+	UserId                     string
+	Roles                      []string
+	UserResourceOwner          string
+	UserGrantResourceOwner     string
+	UserGrantResourceOwnerName string
+	ProjectId                  string
+	ProjectName                string
+}
@@ -0,0 +1,143 @@
+package object
+
+import (
+	"github.com/dop251/goja"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// GetUserFunc returns the value of the `getUser` context field.
+// The user is only queried if the action calls the function.
+func GetUserFunc(getUser func() (*query.User, error)) func(c *actions.FieldConfig) interface{} {
+	return func(c *actions.FieldConfig) interface{} {
+		return func(call goja.FunctionCall) goja.Value {
+			user, err := getUser()
+			if err != nil {
+				panic(err)
+			}
+			if user == nil {
+				return goja.Null()
+			}
+			return UserFromQuery(c, user)
+		}
+	}
+}
+
+func ProfileFromDomain(c *actions.FieldConfig, p *domain.Profile) goja.Value {
+	return c.Runtime.ToValue(&profile{
+		FirstName:         p.FirstName,
+		LastName:          p.LastName,
+		NickName:          p.NickName,
+		DisplayName:       p.DisplayName,
+		PreferredLanguage: p.PreferredLanguage.String(),
+		Gender:            p.Gender,
+	})
+}
+
+// HumanSetterFields returns the api fields which allow an action to modify the user before it's created
+func HumanSetterFields(user *domain.Human) []actions.FieldOption {
+	if user.Profile == nil {
+		user.Profile = &domain.Profile{}
+	}
+	return append(ProfileSetterFields(user.Profile),
+		actions.SetFields("setUsername", func(username string) {
+			user.Username = username
+		}),
+		actions.SetFields("setEmail", func(email string) {
+			if user.Email == nil {
+				user.Email = &domain.Email{}
+			}
+			user.Email.EmailAddress = email
+		}),
+		actions.SetFields("setEmailVerified", func(verified bool) {
+			if user.Email == nil {
+				return
+			}
+			user.Email.IsEmailVerified = verified
+		}),
+		actions.SetFields("setPhone", func(phone string) {
+			if user.Phone == nil {
+				user.Phone = &domain.Phone{}
+			}
+			user.Phone.PhoneNumber = phone
+		}),
+		actions.SetFields("setPhoneVerified", func(verified bool) {
+			if user.Phone == nil {
+				return
+			}
+			user.Phone.IsPhoneVerified = verified
+		}),
+	)
+}
+
+// ExternalUserSetterFields returns the api fields which allow an action to modify the user of the identity provider
+func ExternalUserSetterFields(user *domain.ExternalUser) []actions.FieldOption {
+	return []actions.FieldOption{
+		actions.SetFields("setFirstName", func(firstName string) {
+			user.FirstName = firstName
+		}),
+		actions.SetFields("setLastName", func(lastName string) {
+			user.LastName = lastName
+		}),
+		actions.SetFields("setNickName", func(nickName string) {
+			user.NickName = nickName
+		}),
+		actions.SetFields("setDisplayName", func(displayName string) {
+			user.DisplayName = displayName
+		}),
+		actions.SetFields("setPreferredLanguage", func(preferredLanguage string) {
+			user.PreferredLanguage = language.Make(preferredLanguage)
+		}),
+		actions.SetFields("setPreferredUsername", func(username string) {
+			user.PreferredUsername = username
+		}),
+		actions.SetFields("setEmail", func(email string) {
+			user.Email = email
+		}),
+		actions.SetFields("setEmailVerified", func(verified bool) {
+			user.IsEmailVerified = verified
+		}),
+		actions.SetFields("setPhone", func(phone string) {
+			user.Phone = phone
+		}),
+		actions.SetFields("setPhoneVerified", func(verified bool) {
+			user.IsPhoneVerified = verified
+		}),
+	}
+}
+
+// ProfileSetterFields returns the api fields which allow an action to modify the profile before it's saved
+func ProfileSetterFields(profile *domain.Profile) []actions.FieldOption {
+	return []actions.FieldOption{
+		actions.SetFields("setFirstName", func(firstName string) {
+			profile.FirstName = firstName
+		}),
+		actions.SetFields("setLastName", func(lastName string) {
+			profile.LastName = lastName
+		}),
+		actions.SetFields("setNickName", func(nickName string) {
+			profile.NickName = nickName
+		}),
+		actions.SetFields("setDisplayName", func(displayName string) {
+			profile.DisplayName = displayName
+		}),
+		actions.SetFields("setPreferredLanguage", func(preferredLanguage string) {
+			profile.PreferredLanguage = language.Make(preferredLanguage)
+		}),
+		actions.SetFields("setGender", func(gender domain.Gender) {
+			profile.Gender = gender
+		}),
+	}
+}
+
+type profile struct {
+	FirstName         string
+	LastName          string
+	NickName          string
+	DisplayName       string
+	PreferredLanguage string
+	Gender            domain.Gender
+}
@@ -0,0 +1,54 @@
+// Package script validates the scripts of actions,
+// it does not depend on their execution (and the queries), so it can be used by the command side
+package script
+
+import (
+	"github.com/dop251/goja/ast"
+	"github.com/dop251/goja/parser"
+
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+// Validate parses the script without executing it
+// and checks if it declares the function called on execution, which has the name of the action.
+func Validate(source, name string) error {
+	program, err := parser.ParseFile(nil, name, source, 0)
+	if err != nil {
+		return z_errs.ThrowInvalidArgument(err, "ACTIO-ieC8i", "Errors.Action.ScriptInvalid")
+	}
+	if !declaresFunction(program, name) {
+		return z_errs.ThrowInvalidArgument(nil, "ACTIO-Ohph4", "Errors.Action.FunctionMissing")
+	}
+	return nil
+}
+
+// declaresFunction checks the top level statements of the script,
+// as only global functions and variables are found on execution
+func declaresFunction(program *ast.Program, name string) bool {
+	for _, statement := range program.Body {
+		switch s := statement.(type) {
+		case *ast.FunctionDeclaration:
+			if s.Function.Name != nil && s.Function.Name.Name.String() == name {
+				return true
+			}
+		case *ast.VariableStatement:
+			if bindsName(s.List, name) {
+				return true
+			}
+		case *ast.LexicalDeclaration:
+			if bindsName(s.List, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func bindsName(bindings []*ast.Binding, name string) bool {
+	for _, binding := range bindings {
+		if id, ok := binding.Target.(*ast.Identifier); ok && id.Name.String() == name {
+			return true
+		}
+	}
+	return false
+}
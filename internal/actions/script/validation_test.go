@@ -0,0 +1,61 @@
+package script
+
+import (
+	"testing"
+
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		fnName  string
+		wantErr func(error) bool
+	}{
+		{
+			name:    "function declaration",
+			script:  "function test(ctx, api) {}",
+			fnName:  "test",
+			wantErr: func(err error) bool { return err == nil },
+		},
+		{
+			name: "function expression",
+			script: `let http = require('zitadel/http')
+var test = function(ctx, api) {}`,
+			fnName:  "test",
+			wantErr: func(err error) bool { return err == nil },
+		},
+		{
+			name:    "syntax error",
+			script:  "function test(ctx, api) {",
+			fnName:  "test",
+			wantErr: z_errs.IsErrorInvalidArgument,
+		},
+		{
+			name:    "function missing",
+			script:  "function other(ctx, api) {}",
+			fnName:  "test",
+			wantErr: z_errs.IsErrorInvalidArgument,
+		},
+		{
+			name:    "nested function not found",
+			script:  "function other(ctx, api) { function test() {} }",
+			fnName:  "test",
+			wantErr: z_errs.IsErrorInvalidArgument,
+		},
+		{
+			name:    "lexical declaration",
+			script:  "const test = (ctx, api) => {}",
+			fnName:  "test",
+			wantErr: func(err error) bool { return err == nil },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Validate(tt.script, tt.fnName); !tt.wantErr(err) {
+				t.Errorf("Validate() unexpected error = %v", err)
+			}
+		})
+	}
+}
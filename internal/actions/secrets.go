@@ -0,0 +1,52 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+var (
+	secretsQuerier    SecretsQuerier
+	secretsEncryption crypto.EncryptionAlgorithm
+)
+
+// SecretsQuerier provides the encrypted secrets of an organisation
+type SecretsQuerier interface {
+	ActionSecrets(ctx context.Context, orgID string, withOwnerRemoved bool) ([]*query.ActionSecret, error)
+}
+
+// SetSecrets enables the secrets of the organisation of the action in the `ctx.secrets` field
+func SetSecrets(querier SecretsQuerier, encryption crypto.EncryptionAlgorithm) {
+	secretsQuerier = querier
+	secretsEncryption = encryption
+}
+
+// WithResourceOwner sets the organisation of the action,
+// which owns the secrets and the key-value storage available to the action
+func WithResourceOwner(orgID string) Option {
+	return func(c *runConfig) {
+		c.resourceOwner = orgID
+	}
+}
+
+// setSecrets decrypts the secrets of the organisation of the action into the `ctx.secrets` field
+func setSecrets(ctx context.Context, config *runConfig) error {
+	if secretsQuerier == nil || config.resourceOwner == "" {
+		return nil
+	}
+	secrets, err := secretsQuerier.ActionSecrets(ctx, config.resourceOwner, false)
+	if err != nil {
+		return err
+	}
+	values := make(map[string]string, len(secrets))
+	for _, secret := range secrets {
+		values[secret.Name], err = crypto.DecryptString(secret.Value, secretsEncryption)
+		if err != nil {
+			return err
+		}
+	}
+	config.ctxParam.set("secrets", values)
+	return nil
+}
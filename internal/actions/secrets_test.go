@@ -0,0 +1,77 @@
+package actions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+type mockSecretsQuerier struct {
+	secrets map[string][]*query.ActionSecret
+}
+
+func (m *mockSecretsQuerier) ActionSecrets(_ context.Context, orgID string, _ bool) ([]*query.ActionSecret, error) {
+	return m.secrets[orgID], nil
+}
+
+func TestSecrets(t *testing.T) {
+	SetLogstoreService(logstore.New(nil, nil, nil))
+	SetSecrets(&mockSecretsQuerier{secrets: map[string][]*query.ActionSecret{
+		"org1": {
+			{
+				Name: "apiKey",
+				Value: &crypto.CryptoValue{
+					CryptoType: crypto.TypeEncryption,
+					Algorithm:  "enc",
+					KeyID:      "id",
+					Crypted:    []byte("secret"),
+				},
+			},
+		},
+	}}, crypto.CreateMockEncryptionAlg(gomock.NewController(t)))
+	t.Cleanup(func() {
+		SetSecrets(nil, nil)
+	})
+
+	tests := []struct {
+		name    string
+		script  string
+		opts    []Option
+		wantErr bool
+	}{
+		{
+			name:   "secret of organisation",
+			script: `function test(ctx) { if (ctx.secrets.apiKey !== 'secret') { throw 'wrong secret' } }`,
+			opts:   []Option{WithResourceOwner("org1")},
+		},
+		{
+			name:   "secrets of other organisation",
+			script: `function test(ctx) { if (ctx.secrets.apiKey !== undefined) { throw 'secret of other organisation' } }`,
+			opts:   []Option{WithResourceOwner("org2")},
+		},
+		{
+			name:    "no resource owner, no secrets",
+			script:  `function test(ctx) { ctx.secrets.apiKey }`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			err := Run(ctx, SetContextFields(), nil, tt.script, "test", tt.opts...)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
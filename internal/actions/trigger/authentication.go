@@ -0,0 +1,123 @@
+package trigger
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/actions/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// PostExternalAuthenticationContextFields are passed after the user authenticated at an external identity provider,
+// the token fields are empty if no tokens are passed
+func PostExternalAuthenticationContextFields(
+	tokens *oidc.Tokens,
+	user *domain.ExternalUser,
+	authRequest *domain.AuthRequest,
+	httpRequest *http.Request,
+	authenticationError error,
+) []actions.FieldOption {
+	return append(tokenFields(tokens),
+		actions.SetFields("v1", append(requestFields(authRequest, httpRequest),
+			actions.SetFields("externalUser", func(c *actions.FieldConfig) interface{} {
+				return object.UserFromExternalUser(c, user)
+			}),
+			actions.SetFields("authError", authErrorString(authenticationError)),
+		)...),
+	)
+}
+
+// PostExternalAuthenticationAPIFields allow an action to modify the external user and append metadata
+func PostExternalAuthenticationAPIFields(user *domain.ExternalUser, metadata *object.MetadataList) []actions.FieldOption {
+	return append(object.ExternalUserSetterFields(user), MetadataAPIFields(metadata)...)
+}
+
+// PostInternalAuthenticationContextFields are passed after the user authenticated with the authMethod
+func PostInternalAuthenticationContextFields(
+	authMethod string,
+	authRequest *domain.AuthRequest,
+	httpRequest *http.Request,
+	authenticationError error,
+) []actions.FieldOption {
+	return []actions.FieldOption{
+		actions.SetFields("v1", append(requestFields(authRequest, httpRequest),
+			actions.SetFields("authMethod", authMethod),
+			actions.SetFields("authError", authErrorString(authenticationError)),
+		)...),
+	}
+}
+
+// PreAuthenticationContextFields are passed before the first factor is checked,
+// getUser returns nil if the user is not known yet
+func PreAuthenticationContextFields(
+	authMethod string,
+	getUser func() (*query.User, error),
+	authRequest *domain.AuthRequest,
+	httpRequest *http.Request,
+) []actions.FieldOption {
+	return []actions.FieldOption{
+		actions.SetFields("v1", append(requestFields(authRequest, httpRequest),
+			actions.SetFields("authMethod", authMethod),
+			actions.SetFields("getUser", object.GetUserFunc(getUser)),
+		)...),
+	}
+}
+
+// PreCreationContextFields are passed before the user is created in the login
+func PreCreationContextFields(user *domain.Human, authRequest *domain.AuthRequest, httpRequest *http.Request) []actions.FieldOption {
+	return []actions.FieldOption{
+		actions.SetFields("v1", append(requestFields(authRequest, httpRequest),
+			actions.SetFields("user", func(c *actions.FieldConfig) interface{} {
+				return object.UserFromHuman(c, user)
+			}),
+		)...),
+	}
+}
+
+// PreCreationAPIFields allow an action to modify the user and append metadata before it's created in the login
+func PreCreationAPIFields(user *domain.Human, metadata *object.MetadataList) []actions.FieldOption {
+	return append(object.HumanSetterFields(user), MetadataAPIFields(metadata)...)
+}
+
+// PostCreationContextFields are passed after the user is created in the login
+func PostCreationContextFields(getUser func() (*query.User, error), authRequest *domain.AuthRequest, httpRequest *http.Request) []actions.FieldOption {
+	return []actions.FieldOption{
+		actions.SetFields("v1", append(requestFields(authRequest, httpRequest),
+			actions.SetFields("getUser", object.GetUserFunc(getUser)),
+		)...),
+	}
+}
+
+func tokenFields(tokens *oidc.Tokens) []actions.FieldOption {
+	if tokens == nil {
+		tokens = new(oidc.Tokens)
+	}
+	var accessToken string
+	if tokens.Token != nil {
+		accessToken = tokens.AccessToken
+	}
+	return []actions.FieldOption{
+		actions.SetFields("accessToken", accessToken),
+		actions.SetFields("idToken", tokens.IDToken),
+		actions.SetFields("getClaim", func(claim string) interface{} {
+			if tokens.IDTokenClaims == nil {
+				return nil
+			}
+			return tokens.IDTokenClaims.GetClaim(claim)
+		}),
+		actions.SetFields("claimsJSON", func() (string, error) {
+			if tokens.IDTokenClaims == nil {
+				return "{}", nil
+			}
+			c, err := json.Marshal(tokens.IDTokenClaims)
+			if err != nil {
+				return "", err
+			}
+			return string(c), nil
+		}),
+	}
+}
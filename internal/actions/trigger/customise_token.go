@@ -0,0 +1,94 @@
+package trigger
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/dop251/goja"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// ClaimSetter holds the claims of the userinfo or the access token an action can add
+type ClaimSetter interface {
+	GetClaim(key string) interface{}
+	AppendClaims(key string, value interface{})
+}
+
+// Claims is a ClaimSetter of the claims of the access token
+type Claims map[string]interface{}
+
+func (c Claims) GetClaim(key string) interface{} {
+	return c[key]
+}
+
+func (c Claims) AppendClaims(key string, value interface{}) {
+	c[key] = value
+}
+
+// CustomiseTokenContextFields are passed before the userinfo or the access token is created,
+// the metadata is only queried if the action calls `getMetadata`
+func CustomiseTokenContextFields(getMetadata func() (*query.UserMetadataList, error)) []actions.FieldOption {
+	return []actions.FieldOption{
+		actions.SetFields("v1",
+			actions.SetFields("user",
+				actions.SetFields("getMetadata", userMetadataGetter(getMetadata)),
+			),
+		),
+	}
+}
+
+// PreUserinfoCreationAPIFields allow an action to add claims to the userinfo and to set metadata of the user.
+// Existing claims are not overwritten, the logs of the action are appended to claimLogs.
+func PreUserinfoCreationAPIFields(userinfo ClaimSetter, claimLogs *[]string, setMetadata func(*domain.Metadata) error) []actions.FieldOption {
+	return customiseTokenAPIFields("userinfo", userinfo, claimLogs, setMetadata)
+}
+
+// PreAccessTokenCreationAPIFields allow an action to add claims to the access token and to set metadata of the user.
+// Existing claims are not overwritten, the logs of the action are appended to claimLogs.
+func PreAccessTokenCreationAPIFields(claims ClaimSetter, claimLogs *[]string, setMetadata func(*domain.Metadata) error) []actions.FieldOption {
+	return customiseTokenAPIFields("claims", claims, claimLogs, setMetadata)
+}
+
+func customiseTokenAPIFields(claimsField string, claims ClaimSetter, claimLogs *[]string, setMetadata func(*domain.Metadata) error) []actions.FieldOption {
+	return []actions.FieldOption{
+		actions.SetFields("v1",
+			actions.SetFields(claimsField,
+				actions.SetFields("setClaim", func(key string, value interface{}) {
+					if claims.GetClaim(key) == nil {
+						claims.AppendClaims(key, value)
+						return
+					}
+					*claimLogs = append(*claimLogs, fmt.Sprintf("key %q already exists", key))
+				}),
+				actions.SetFields("appendLogIntoClaims", func(entry string) {
+					*claimLogs = append(*claimLogs, entry)
+				}),
+			),
+			actions.SetFields("user",
+				actions.SetFields("setMetadata", func(call goja.FunctionCall) goja.Value {
+					if len(call.Arguments) != 2 {
+						panic("exactly 2 (key, value) arguments expected")
+					}
+					key := call.Arguments[0].Export().(string)
+					val := call.Arguments[1].Export()
+
+					value, err := json.Marshal(val)
+					if err != nil {
+						logging.WithError(err).Debug("unable to marshal")
+						panic(err)
+					}
+
+					if err = setMetadata(&domain.Metadata{Key: key, Value: value}); err != nil {
+						logging.WithError(err).Info("unable to set md in action")
+						panic(err)
+					}
+					return nil
+				}),
+			),
+		),
+	}
+}
@@ -0,0 +1,95 @@
+package trigger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/logstore"
+)
+
+func TestPreAccessTokenCreationAPIFields(t *testing.T) {
+	actions.SetLogstoreService(logstore.New(nil, nil, nil))
+	type res struct {
+		claims    Claims
+		claimLogs []string
+		metadata  []*domain.Metadata
+		err       bool
+	}
+	tests := []struct {
+		name           string
+		script         string
+		setMetadataErr error
+		res            res
+	}{
+		{
+			name: "claims set, existing claim logged",
+			script: `function customise(ctx, api) {
+	api.v1.claims.setClaim('existing', 'changed')
+	api.v1.claims.setClaim('added', 'value')
+	api.v1.claims.appendLogIntoClaims('entry')
+}`,
+			res: res{
+				claims: Claims{
+					"existing": "value",
+					"added":    "value",
+				},
+				claimLogs: []string{`key "existing" already exists`, "entry"},
+			},
+		},
+		{
+			name: "metadata set, ok",
+			script: `function customise(ctx, api) {
+	api.v1.user.setMetadata('key', {value: 1})
+}`,
+			res: res{
+				claims:    Claims{"existing": "value"},
+				claimLogs: []string{},
+				metadata:  []*domain.Metadata{{Key: "key", Value: []byte(`{"value":1}`)}},
+			},
+		},
+		{
+			name: "set metadata failed, error",
+			script: `function customise(ctx, api) {
+	api.v1.user.setMetadata('key', 'value')
+}`,
+			setMetadataErr: errors.ThrowInternal(nil, "id", "failed"),
+			res: res{
+				claims:    Claims{"existing": "value"},
+				claimLogs: []string{},
+				err:       true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := Claims{"existing": "value"}
+			claimLogs := []string{}
+			var metadata []*domain.Metadata
+			setMetadata := func(md *domain.Metadata) error {
+				if tt.setMetadataErr != nil {
+					return tt.setMetadataErr
+				}
+				metadata = append(metadata, md)
+				return nil
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			err := actions.Run(ctx, actions.SetContextFields(), actions.WithAPIFields(PreAccessTokenCreationAPIFields(claims, &claimLogs, setMetadata)...), tt.script, "customise")
+			if tt.res.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.res.claims, claims)
+			assert.Equal(t, tt.res.claimLogs, claimLogs)
+			assert.Equal(t, tt.res.metadata, metadata)
+		})
+	}
+}
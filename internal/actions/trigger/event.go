@@ -0,0 +1,16 @@
+package trigger
+
+import (
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/actions/object"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+// EventContextFields are passed to the actions subscribed to the type of the event
+func EventContextFields(event eventstore.Event) []actions.FieldOption {
+	return []actions.FieldOption{
+		actions.SetFields("v1",
+			actions.SetFields("event", object.EventField(event)),
+		),
+	}
+}
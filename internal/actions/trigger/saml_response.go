@@ -0,0 +1,45 @@
+package trigger
+
+import (
+	"github.com/zitadel/saml/pkg/provider/models"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/actions/object"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// PreSAMLResponseCreationContextFields are passed before the SAML response is created,
+// the metadata and grants are only queried if the action calls the getters
+func PreSAMLResponseCreationContextFields(
+	getUser func() (*query.User, error),
+	getMetadata func() (*query.UserMetadataList, error),
+	getGrants func() (*query.UserGrants, error),
+) []actions.FieldOption {
+	return []actions.FieldOption{
+		actions.SetFields("v1",
+			actions.SetFields("getUser", object.GetUserFunc(getUser)),
+			actions.SetFields("user",
+				actions.SetFields("getMetadata", userMetadataGetter(getMetadata)),
+				actions.SetFields("getGrants", userGrantsGetter(getGrants)),
+			),
+		),
+	}
+}
+
+// PreSAMLResponseCreationAPIFields allow an action to override the attributes
+// and add custom attributes to the SAML response
+func PreSAMLResponseCreationAPIFields(attributes models.AttributeSetter) []actions.FieldOption {
+	return []actions.FieldOption{
+		actions.SetFields("v1",
+			actions.SetFields("attributes",
+				actions.SetFields("setCustomAttribute", func(name, nameFormat string, attributeValue ...string) {
+					attributes.SetCustomAttribute(name, "", nameFormat, attributeValue)
+				}),
+				actions.SetFields("setEmail", attributes.SetEmail),
+				actions.SetFields("setGivenName", attributes.SetGivenName),
+				actions.SetFields("setSurname", attributes.SetSurname),
+				actions.SetFields("setFullName", attributes.SetFullName),
+			),
+		),
+	}
+}
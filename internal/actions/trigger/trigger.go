@@ -0,0 +1,81 @@
+// Package trigger provides the context and api fields the triggers of the flows pass to the actions.
+// The real triggers and the dry run of an action build their fields with the same functions,
+// only the values and getters passed in differ.
+package trigger
+
+import (
+	"net/http"
+
+	"github.com/dop251/goja"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/actions/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// MetadataAPIFields allow an action to append metadata to the user
+func MetadataAPIFields(metadata *object.MetadataList) []actions.FieldOption {
+	return []actions.FieldOption{
+		actions.SetFields("metadata", &metadata.Metadata),
+		actions.SetFields("v1",
+			actions.SetFields("user",
+				actions.SetFields("appendMetadata", metadata.AppendMetadataFunc),
+			),
+		),
+	}
+}
+
+// UserGrantAPIFields allow an action to append grants to the user
+func UserGrantAPIFields(grants *object.UserGrants) []actions.FieldOption {
+	return []actions.FieldOption{
+		actions.SetFields("userGrants", &grants.UserGrants),
+		actions.SetFields("v1",
+			actions.SetFields("appendUserGrant", object.AppendGrantFunc(grants)),
+		),
+	}
+}
+
+// requestFields are the `authRequest` and `httpRequest` of the login flows
+func requestFields(authRequest *domain.AuthRequest, httpRequest *http.Request) []interface{} {
+	return []interface{}{
+		actions.SetFields("authRequest", object.AuthRequestField(authRequest)),
+		actions.SetFields("httpRequest", object.HTTPRequestField(httpRequest)),
+	}
+}
+
+func authErrorString(authenticationError error) string {
+	if authenticationError != nil {
+		return authenticationError.Error()
+	}
+	return "none"
+}
+
+// userMetadataGetter returns the value of `getMetadata`, the metadata is only queried if the action calls the function
+func userMetadataGetter(getMetadata func() (*query.UserMetadataList, error)) func(c *actions.FieldConfig) interface{} {
+	return func(c *actions.FieldConfig) interface{} {
+		return func(goja.FunctionCall) goja.Value {
+			metadata, err := getMetadata()
+			if err != nil {
+				logging.WithError(err).Info("unable to get md in action")
+				panic(err)
+			}
+			return object.UserMetadataListFromQuery(c, metadata)
+		}
+	}
+}
+
+// userGrantsGetter returns the value of `getGrants`, the grants are only queried if the action calls the function
+func userGrantsGetter(getGrants func() (*query.UserGrants, error)) func(c *actions.FieldConfig) interface{} {
+	return func(c *actions.FieldConfig) interface{} {
+		return func(goja.FunctionCall) goja.Value {
+			grants, err := getGrants()
+			if err != nil {
+				logging.WithError(err).Info("unable to get grants in action")
+				panic(err)
+			}
+			return object.UserGrantsFromQuery(c, grants)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package trigger
+
+import (
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/actions/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// UserManagementPreCreationContextFields are passed before the human is created,
+// editorUserID is the user calling the api
+func UserManagementPreCreationContextFields(editorUserID string, user *domain.Human) []actions.FieldOption {
+	return userManagementContextFields(editorUserID,
+		actions.SetFields("user", func(c *actions.FieldConfig) interface{} {
+			return object.UserFromHuman(c, user)
+		}),
+	)
+}
+
+// UserManagementUserContextFields are passed on the triggers which only provide the user,
+// e.g. after the creation or before the deactivation
+func UserManagementUserContextFields(editorUserID string, getUser func() (*query.User, error)) []actions.FieldOption {
+	return userManagementContextFields(editorUserID,
+		actions.SetFields("getUser", object.GetUserFunc(getUser)),
+	)
+}
+
+// UserManagementProfileContextFields are passed before and after the profile is changed
+func UserManagementProfileContextFields(editorUserID string, getUser func() (*query.User, error), profile *domain.Profile) []actions.FieldOption {
+	return userManagementContextFields(editorUserID,
+		actions.SetFields("getUser", object.GetUserFunc(getUser)),
+		actions.SetFields("profile", func(c *actions.FieldConfig) interface{} {
+			return object.ProfileFromDomain(c, profile)
+		}),
+	)
+}
+
+func userManagementContextFields(editorUserID string, fields ...interface{}) []actions.FieldOption {
+	return []actions.FieldOption{
+		actions.SetFields("v1", append([]interface{}{
+			actions.SetFields("editorUserId", editorUserID),
+		}, fields...)...),
+	}
+}
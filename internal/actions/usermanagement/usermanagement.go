@@ -0,0 +1,104 @@
+// Package usermanagement runs the actions of the user management flow,
+// it implements command.UserManagementActions, as the command side must not depend on the queries
+package usermanagement
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/actions/object"
+	"github.com/zitadel/zitadel/internal/actions/trigger"
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// Queries provides the actions and users needed to run the user management flow
+type Queries interface {
+	GetActiveActionsByFlowAndTriggerType(ctx context.Context, flowType domain.FlowType, triggerType domain.TriggerType, orgID string, withOwnerRemoved bool) ([]*query.Action, error)
+	GetUserByID(ctx context.Context, shouldTriggerBulk bool, userID string, withOwnerRemoved bool, queries ...query.SearchQuery) (*query.User, error)
+}
+
+type Actions struct {
+	queries Queries
+}
+
+func NewActions(queries Queries) *Actions {
+	return &Actions{queries: queries}
+}
+
+// PreCreation runs the actions before the human is created, the user is changed by the setters of the api
+func (a *Actions) PreCreation(ctx context.Context, user *domain.Human) error {
+	return a.run(ctx, domain.TriggerTypePreCreation, user.ResourceOwner,
+		trigger.UserManagementPreCreationContextFields(authz.GetCtxData(ctx).UserID, user),
+		object.HumanSetterFields(user),
+	)
+}
+
+func (a *Actions) PostCreation(ctx context.Context, userID, resourceOwner string) error {
+	return a.run(ctx, domain.TriggerTypePostCreation, resourceOwner,
+		trigger.UserManagementUserContextFields(authz.GetCtxData(ctx).UserID, a.userGetter(ctx, userID)),
+		nil,
+	)
+}
+
+// PreUpdate runs the actions before the profile is changed, the profile is changed by the setters of the api
+func (a *Actions) PreUpdate(ctx context.Context, profile *domain.Profile) error {
+	return a.run(ctx, domain.TriggerTypePreUpdate, profile.ResourceOwner,
+		trigger.UserManagementProfileContextFields(authz.GetCtxData(ctx).UserID, a.userGetter(ctx, profile.AggregateID), profile),
+		object.ProfileSetterFields(profile),
+	)
+}
+
+func (a *Actions) PostUpdate(ctx context.Context, profile *domain.Profile) error {
+	return a.run(ctx, domain.TriggerTypePostUpdate, profile.ResourceOwner,
+		trigger.UserManagementProfileContextFields(authz.GetCtxData(ctx).UserID, a.userGetter(ctx, profile.AggregateID), profile),
+		nil,
+	)
+}
+
+func (a *Actions) PreDeactivation(ctx context.Context, userID, resourceOwner string) error {
+	return a.run(ctx, domain.TriggerTypePreDeactivation, resourceOwner,
+		trigger.UserManagementUserContextFields(authz.GetCtxData(ctx).UserID, a.userGetter(ctx, userID)),
+		nil,
+	)
+}
+
+func (a *Actions) PostDeactivation(ctx context.Context, userID, resourceOwner string) error {
+	return a.run(ctx, domain.TriggerTypePostDeactivation, resourceOwner,
+		trigger.UserManagementUserContextFields(authz.GetCtxData(ctx).UserID, a.userGetter(ctx, userID)),
+		nil,
+	)
+}
+
+// run runs the actions of the organisation set on the trigger of the user management flow,
+// an error of an action which is not allowed to fail is returned.
+func (a *Actions) run(ctx context.Context, triggerType domain.TriggerType, resourceOwner string, ctxFields []actions.FieldOption, apiFields []actions.FieldOption) error {
+	triggerActions, err := a.queries.GetActiveActionsByFlowAndTriggerType(ctx, domain.FlowTypeUserManagement, triggerType, resourceOwner, false)
+	if err != nil {
+		return err
+	}
+	for _, action := range triggerActions {
+		actionCtx, cancel := context.WithTimeout(ctx, action.Timeout())
+
+		err = actions.Run(
+			actionCtx,
+			actions.SetContextFields(ctxFields...),
+			actions.WithAPIFields(apiFields...),
+			action.Script,
+			action.Name,
+			append(actions.ActionToOptions(action), actions.WithHTTP(actionCtx))...,
+		)
+		cancel()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *Actions) userGetter(ctx context.Context, userID string) func() (*query.User, error) {
+	return func() (*query.User, error) {
+		return a.queries.GetUserByID(ctx, true, userID, false)
+	}
+}
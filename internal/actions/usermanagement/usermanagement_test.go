@@ -0,0 +1,195 @@
+package usermanagement
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+type mockQueries struct {
+	actions map[domain.TriggerType][]*query.Action
+}
+
+func (m *mockQueries) GetActiveActionsByFlowAndTriggerType(_ context.Context, flowType domain.FlowType, triggerType domain.TriggerType, _ string, _ bool) ([]*query.Action, error) {
+	if flowType != domain.FlowTypeUserManagement {
+		return nil, nil
+	}
+	return m.actions[triggerType], nil
+}
+
+func (m *mockQueries) GetUserByID(_ context.Context, _ bool, userID string, _ bool, _ ...query.SearchQuery) (*query.User, error) {
+	return &query.User{ID: userID, Human: &query.Human{}}, nil
+}
+
+func testHuman(firstName, email string) *domain.Human {
+	return &domain.Human{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID:   "user1",
+			ResourceOwner: "org1",
+		},
+		Username: "username",
+		Profile: &domain.Profile{
+			FirstName: firstName,
+		},
+		Email: &domain.Email{
+			EmailAddress: email,
+		},
+		Phone: &domain.Phone{},
+	}
+}
+
+func TestActions_PreCreation(t *testing.T) {
+	actions.SetLogstoreService(logstore.New(nil, nil, nil))
+	type args struct {
+		human *domain.Human
+	}
+	type res struct {
+		want *domain.Human
+		err  func(error) bool
+	}
+	tests := []struct {
+		name    string
+		actions map[domain.TriggerType][]*query.Action
+		args    args
+		res     res
+	}{
+		{
+			name: "no actions, unchanged",
+			args: args{
+				human: testHuman("firstname", "email@test.ch"),
+			},
+			res: res{
+				want: testHuman("firstname", "email@test.ch"),
+			},
+		},
+		{
+			name: "action sets fields, changed",
+			actions: map[domain.TriggerType][]*query.Action{
+				domain.TriggerTypePreCreation: {
+					{
+						Name: "preCreation",
+						Script: `function preCreation(ctx, api) {
+	api.setFirstName(ctx.v1.user.human.firstName + '-changed');
+	api.setPreferredLanguage('de');
+	api.setEmail('changed@test.ch');
+	api.setEmailVerified(true);
+}`,
+					},
+				},
+			},
+			args: args{
+				human: testHuman("firstname", "email@test.ch"),
+			},
+			res: res{
+				want: func() *domain.Human {
+					human := testHuman("firstname-changed", "changed@test.ch")
+					human.PreferredLanguage = language.German
+					human.IsEmailVerified = true
+					return human
+				}(),
+			},
+		},
+		{
+			name: "action fails, error",
+			actions: map[domain.TriggerType][]*query.Action{
+				domain.TriggerTypePreCreation: {
+					{
+						Name:   "preCreation",
+						Script: `function preCreation(ctx, api) { throw 'not allowed' }`,
+					},
+				},
+			},
+			args: args{
+				human: testHuman("firstname", "email@test.ch"),
+			},
+			res: res{
+				err: func(err error) bool { return err != nil },
+			},
+		},
+		{
+			name: "action allowed to fail, unchanged",
+			actions: map[domain.TriggerType][]*query.Action{
+				domain.TriggerTypePreCreation: {
+					{
+						Name:          "preCreation",
+						Script:        `function preCreation(ctx, api) { throw 'not allowed' }`,
+						AllowedToFail: true,
+					},
+				},
+			},
+			args: args{
+				human: testHuman("firstname", "email@test.ch"),
+			},
+			res: res{
+				want: testHuman("firstname", "email@test.ch"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewActions(&mockQueries{actions: tt.actions})
+			err := a.PreCreation(context.Background(), tt.args.human)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, tt.args.human)
+			}
+		})
+	}
+}
+
+func TestActions_PreDeactivation(t *testing.T) {
+	actions.SetLogstoreService(logstore.New(nil, nil, nil))
+	tests := []struct {
+		name    string
+		actions map[domain.TriggerType][]*query.Action
+		wantErr bool
+	}{
+		{
+			name: "action reads user, ok",
+			actions: map[domain.TriggerType][]*query.Action{
+				domain.TriggerTypePreDeactivation: {
+					{
+						Name:   "preDeactivation",
+						Script: `function preDeactivation(ctx, api) { if (ctx.v1.getUser().id !== 'user1') { throw 'wrong user' } }`,
+					},
+				},
+			},
+		},
+		{
+			name: "action fails, error",
+			actions: map[domain.TriggerType][]*query.Action{
+				domain.TriggerTypePreDeactivation: {
+					{
+						Name:   "preDeactivation",
+						Script: `function preDeactivation(ctx, api) { throw 'not allowed' }`,
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewActions(&mockQueries{actions: tt.actions})
+			err := a.PreDeactivation(context.Background(), "user1", "org1")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
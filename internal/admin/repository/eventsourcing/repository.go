@@ -24,7 +24,7 @@ type EsRepository struct {
 }
 
 func Start(ctx context.Context, conf Config, static static.Storage, dbClient *sql.DB, esV2 *eventstore2.Eventstore) (*EsRepository, error) {
-	es, err := v1.Start(dbClient)
+	es, err := v1.Start(dbClient, esV2)
 	if err != nil {
 		return nil, err
 	}
@@ -25,3 +25,20 @@ func (a *Config) getPermissionsFromRole(role string) []string {
 	}
 	return nil
 }
+
+func (a *Config) hasRole(role string) bool {
+	for _, roleMap := range a.RolePermissionMappings {
+		if roleMap.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// withCustomRoles returns a copy of the config, which additionally contains the customRoles
+func (a Config) withCustomRoles(customRoles []RoleMapping) Config {
+	mappings := make([]RoleMapping, 0, len(a.RolePermissionMappings)+len(customRoles))
+	mappings = append(mappings, a.RolePermissionMappings...)
+	a.RolePermissionMappings = append(mappings, customRoles...)
+	return a
+}
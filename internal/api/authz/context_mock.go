@@ -12,3 +12,8 @@ func NewMockContextWithPermissions(instanceID, orgID, userID string, permissions
 	ctx = context.WithValue(ctx, instanceKey, instanceID)
 	return context.WithValue(ctx, requestPermissionsKey, permissions)
 }
+
+func NewMockContextWithAllPermissions(instanceID, orgID, userID string, requestPermissions, allPermissions []string) context.Context {
+	ctx := NewMockContextWithPermissions(instanceID, orgID, userID, requestPermissions)
+	return context.WithValue(ctx, allPermissionsKey, allPermissions)
+}
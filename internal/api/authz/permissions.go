@@ -35,10 +35,30 @@ func getUserMethodPermissions(ctx context.Context, t *TokenVerifier, requiredPer
 			return nil, nil, nil
 		}
 	}
+	if hasUnknownRoles(memberships, authConfig) {
+		customRoles, err := t.CustomRoleMappings(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		authConfig = authConfig.withCustomRoles(customRoles)
+	}
 	requestedPermissions, allPermissions = mapMembershipsToPermissions(requiredPerm, memberships, authConfig)
 	return requestedPermissions, allPermissions, nil
 }
 
+// hasUnknownRoles checks if any of the roles is not part of the configured role mappings,
+// only then the custom roles of the instance have to be queried
+func hasUnknownRoles(memberships []*Membership, authConfig Config) bool {
+	for _, membership := range memberships {
+		for _, role := range membership.Roles {
+			if !authConfig.hasRole(role) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func mapMembershipsToPermissions(requiredPerm string, memberships []*Membership, authConfig Config) (requestPermissions, allPermissions []string) {
 	requestPermissions = make([]string, 0)
 	allPermissions = make([]string, 0)
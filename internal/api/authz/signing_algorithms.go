@@ -0,0 +1,37 @@
+package authz
+
+import (
+	"github.com/zitadel/oidc/v2/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+)
+
+// AccessTokenVerifierWithSigningAlgorithms returns the verifier accepting JWTs signed with any supported signing algorithm,
+// as the verifiers of the oidc library only accept RS256
+func AccessTokenVerifierWithSigningAlgorithms(verifier op.AccessTokenVerifier) op.AccessTokenVerifier {
+	return &accessTokenVerifier{verifier}
+}
+
+// IDTokenHintVerifierWithSigningAlgorithms returns the verifier accepting id_token_hints signed with any supported signing algorithm,
+// as the verifiers of the oidc library only accept RS256
+func IDTokenHintVerifierWithSigningAlgorithms(verifier op.IDTokenHintVerifier) op.IDTokenHintVerifier {
+	return &idTokenHintVerifier{verifier}
+}
+
+type accessTokenVerifier struct {
+	op.AccessTokenVerifier
+}
+
+// SupportedSignAlgs implements the op.AccessTokenVerifier interface
+func (v *accessTokenVerifier) SupportedSignAlgs() []string {
+	return crypto.SigningAlgorithms()
+}
+
+type idTokenHintVerifier struct {
+	op.IDTokenHintVerifier
+}
+
+// SupportedSignAlgs implements the op.IDTokenHintVerifier interface
+func (v *idTokenHintVerifier) SupportedSignAlgs() []string {
+	return crypto.SigningAlgorithms()
+}
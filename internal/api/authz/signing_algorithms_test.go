@@ -0,0 +1,68 @@
+package authz
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+	"gopkg.in/square/go-jose.v2"
+)
+
+const testIssuer = "https://issuer.test"
+
+type testKeySet struct {
+	key *ecdsa.PublicKey
+}
+
+func (k *testKeySet) VerifySignature(_ context.Context, jws *jose.JSONWebSignature) ([]byte, error) {
+	return jws.Verify(k.key)
+}
+
+func signES256(t *testing.T, key *ecdsa.PrivateKey, claims interface{}) string {
+	t.Helper()
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: key}, nil)
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	signed, err := signer.Sign(payload)
+	require.NoError(t, err)
+	token, err := signed.CompactSerialize()
+	require.NoError(t, err)
+	return token
+}
+
+func TestAccessTokenVerifierWithSigningAlgorithms_ES256(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	keySet := &testKeySet{key: &key.PublicKey}
+	token := signES256(t, key, oidc.NewAccessTokenClaims(testIssuer, "user", []string{"client"}, time.Now().Add(time.Hour), "token", "client", 0))
+
+	_, err = op.VerifyAccessToken(context.Background(), token, op.NewAccessTokenVerifier(testIssuer, keySet))
+	assert.ErrorIs(t, err, oidc.ErrSignatureUnsupportedAlg, "the verifier of the oidc library only accepts RS256")
+
+	claims, err := op.VerifyAccessToken(context.Background(), token, AccessTokenVerifierWithSigningAlgorithms(op.NewAccessTokenVerifier(testIssuer, keySet)))
+	require.NoError(t, err)
+	assert.Equal(t, "user", claims.GetSubject())
+}
+
+func TestIDTokenHintVerifierWithSigningAlgorithms_ES256(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	keySet := &testKeySet{key: &key.PublicKey}
+	token := signES256(t, key, oidc.NewIDTokenClaims(testIssuer, "user", []string{"client"}, time.Now().Add(time.Hour), time.Now(), "", "", nil, "client", 0))
+
+	_, err = op.VerifyIDTokenHint(context.Background(), token, op.NewIDTokenHintVerifier(testIssuer, keySet))
+	assert.ErrorIs(t, err, oidc.ErrSignatureUnsupportedAlg, "the verifier of the oidc library only accepts RS256")
+
+	claims, err := op.VerifyIDTokenHint(context.Background(), token, IDTokenHintVerifierWithSigningAlgorithms(op.NewIDTokenHintVerifier(testIssuer, keySet)))
+	require.NoError(t, err)
+	assert.Equal(t, "user", claims.GetSubject())
+}
@@ -31,6 +31,7 @@ type authZRepo interface {
 	VerifyAccessToken(ctx context.Context, token, verifierClientID, projectID string) (userID, agentID, clientID, prefLang, resourceOwner string, err error)
 	VerifierClientID(ctx context.Context, name string) (clientID, projectID string, err error)
 	SearchMyMemberships(ctx context.Context) ([]*Membership, error)
+	CustomRoleMappings(ctx context.Context) ([]RoleMapping, error)
 	ProjectIDAndOriginsByClientID(ctx context.Context, clientID string) (projectID string, origins []string, err error)
 	ExistsOrg(ctx context.Context, orgID string) error
 }
@@ -133,6 +134,13 @@ func (v *TokenVerifier) SearchMyMemberships(ctx context.Context) (_ []*Membershi
 	return v.authZRepo.SearchMyMemberships(ctx)
 }
 
+// CustomRoleMappings returns the permissions of the custom roles defined on the instance
+func (v *TokenVerifier) CustomRoleMappings(ctx context.Context) (_ []RoleMapping, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	return v.authZRepo.CustomRoleMappings(ctx)
+}
+
 func (v *TokenVerifier) ProjectIDAndOriginsByClientID(ctx context.Context, clientID string) (_ string, _ []string, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -0,0 +1,130 @@
+// Package export serves the data export of a single user (see userexport) as download,
+// so the ZIP archive is streamed to the client instead of being buffered in a gRPC response
+package export
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_util "github.com/zitadel/zitadel/internal/api/http"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/userexport"
+)
+
+const (
+	HandlerPrefix = "/export/v1"
+
+	contentTypeZIP = "application/zip"
+
+	varID = "id"
+
+	permissionAuthenticated = "authenticated"
+	permissionUserRead      = "user.read"
+)
+
+type Handler struct {
+	queries    *query.Queries
+	verifier   *authz.TokenVerifier
+	authConfig authz.Config
+}
+
+func NewHandler(
+	queries *query.Queries,
+	verifier *authz.TokenVerifier,
+	authConfig authz.Config,
+	instanceInterceptor,
+	accessInterceptor func(handler http.Handler) http.Handler,
+) http.Handler {
+	h := &Handler{
+		queries:    queries,
+		verifier:   verifier,
+		authConfig: authConfig,
+	}
+	router := mux.NewRouter()
+	router.HandleFunc("/users/me", h.exportMyUser).Methods(http.MethodGet)
+	router.HandleFunc("/users/{"+varID+"}", h.exportUser).Methods(http.MethodGet)
+	return http_util.CopyHeadersToContext(instanceInterceptor(accessInterceptor(router)))
+}
+
+// exportMyUser exports the data of the authenticated user
+func (h *Handler) exportMyUser(w http.ResponseWriter, r *http.Request) {
+	ctx, err := h.authorize(r, permissionAuthenticated)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	ctxData := authz.GetCtxData(ctx)
+	h.export(w, r.WithContext(ctx), ctxData.UserID, ctxData.ResourceOwner)
+}
+
+// exportUser exports the data of a user of the organization (x-zitadel-orgid header or the organization of the caller)
+func (h *Handler) exportUser(w http.ResponseWriter, r *http.Request) {
+	ctx, err := h.authorize(r, permissionUserRead)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	h.export(w, r.WithContext(ctx), mux.Vars(r)[varID], authz.GetCtxData(ctx).OrgID)
+}
+
+// export collects the data before the response is started, so errors can still be returned with the proper status.
+// The ZIP archive is then written directly to the response.
+func (h *Handler) export(w http.ResponseWriter, r *http.Request, userID, resourceOwner string) {
+	bundle, err := userexport.Collect(r.Context(), h.queries, userID, resourceOwner)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("content-type", contentTypeZIP)
+	w.Header().Set("content-disposition", `attachment; filename="user_`+userID+`.zip"`)
+	w.Header().Set("cache-control", "no-store")
+	err = bundle.WriteZIP(w)
+	logging.WithFields("uri", r.RequestURI).OnError(err).Warn("unable to write user export")
+}
+
+func (h *Handler) authorize(r *http.Request, permission string) (_ context.Context, err error) {
+	ctx := r.Context()
+	authCtx, span := tracing.NewServerInterceptorSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	authToken := http_util.GetAuthorization(r)
+	if authToken == "" {
+		return nil, caos_errs.ThrowUnauthenticated(nil, "EXPORT-Ohx3i", "auth header missing")
+	}
+	ctxSetter, err := authz.CheckUserAuthorization(authCtx, nil, authToken, http_util.GetOrgID(r), h.verifier, h.authConfig, authz.Option{Permission: permission}, r.RequestURI)
+	if err != nil {
+		return nil, err
+	}
+	return ctxSetter(ctx), nil
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	status := errorStatus(err)
+	if status == http.StatusInternalServerError {
+		logging.WithFields("uri", r.RequestURI).WithError(err).Warn("error occurred on export api")
+	}
+	http.Error(w, http.StatusText(status), status)
+}
+
+func errorStatus(err error) int {
+	switch {
+	case caos_errs.IsNotFound(err):
+		return http.StatusNotFound
+	case caos_errs.IsErrorInvalidArgument(err):
+		return http.StatusBadRequest
+	case caos_errs.IsUnauthenticated(err):
+		return http.StatusUnauthorized
+	case caos_errs.IsPermissionDenied(err):
+		return http.StatusForbidden
+	case caos_errs.IsResourceExhausted(err):
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -2,9 +2,11 @@ package action
 
 import (
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	object_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/logstore/emitters/execution"
 	"github.com/zitadel/zitadel/internal/query"
 	action_pb "github.com/zitadel/zitadel/pkg/grpc/action"
 	message_pb "github.com/zitadel/zitadel/pkg/grpc/message"
@@ -19,6 +21,12 @@ func FlowTypeToDomain(flowType string) domain.FlowType {
 		return domain.FlowTypeCustomiseToken
 	case domain.FlowTypeInternalAuthentication.ID():
 		return domain.FlowTypeInternalAuthentication
+	case domain.FlowTypeUserManagement.ID():
+		return domain.FlowTypeUserManagement
+	case domain.FlowTypePreAuthentication.ID():
+		return domain.FlowTypePreAuthentication
+	case domain.FlowTypeCustomiseSAMLResponse.ID():
+		return domain.FlowTypeCustomiseSAMLResponse
 	default:
 		return domain.FlowTypeUnspecified
 	}
@@ -47,6 +55,18 @@ func TriggerTypeToDomain(triggerType string) domain.TriggerType {
 		return domain.TriggerTypePreAccessTokenCreation
 	case domain.TriggerTypePreUserinfoCreation.ID():
 		return domain.TriggerTypePreUserinfoCreation
+	case domain.TriggerTypePreUpdate.ID():
+		return domain.TriggerTypePreUpdate
+	case domain.TriggerTypePostUpdate.ID():
+		return domain.TriggerTypePostUpdate
+	case domain.TriggerTypePreDeactivation.ID():
+		return domain.TriggerTypePreDeactivation
+	case domain.TriggerTypePostDeactivation.ID():
+		return domain.TriggerTypePostDeactivation
+	case domain.TriggerTypePreAuthentication.ID():
+		return domain.TriggerTypePreAuthentication
+	case domain.TriggerTypePreSAMLResponseCreation.ID():
+		return domain.TriggerTypePreSAMLResponseCreation
 	default:
 		return domain.TriggerTypeUnspecified
 	}
@@ -145,3 +165,70 @@ func ActionStateToDomain(state action_pb.ActionState) domain.ActionState {
 		return domain.ActionStateUnspecified
 	}
 }
+
+func EventActionsListToPb(eventActions []*query.EventActions) []*action_pb.EventActions {
+	list := make([]*action_pb.EventActions, len(eventActions))
+	for i, e := range eventActions {
+		list[i] = EventActionsToPb(e)
+	}
+	return list
+}
+
+func EventActionsToPb(eventActions *query.EventActions) *action_pb.EventActions {
+	return &action_pb.EventActions{
+		EventType: eventActions.EventType,
+		Details:   object_grpc.ChangeToDetailsPb(eventActions.Sequence, eventActions.ChangeDate, eventActions.ResourceOwner),
+		Actions:   ActionsToPb(eventActions.Actions),
+	}
+}
+
+func ActionSecretsToPb(secrets []*query.ActionSecret) []*action_pb.ActionSecret {
+	list := make([]*action_pb.ActionSecret, len(secrets))
+	for i, secret := range secrets {
+		list[i] = ActionSecretToPb(secret)
+	}
+	return list
+}
+
+// ActionSecretToPb maps the secret without its value, which must never be returned
+func ActionSecretToPb(secret *query.ActionSecret) *action_pb.ActionSecret {
+	return &action_pb.ActionSecret{
+		Name:    secret.Name,
+		Details: object_grpc.ChangeToDetailsPb(secret.Sequence, secret.ChangeDate, secret.ResourceOwner),
+	}
+}
+
+func ActionVersionsToPb(versions []*query.ActionVersion) []*action_pb.ActionVersion {
+	list := make([]*action_pb.ActionVersion, len(versions))
+	for i, version := range versions {
+		list[i] = ActionVersionToPb(version)
+	}
+	return list
+}
+
+func ActionVersionToPb(version *query.ActionVersion) *action_pb.ActionVersion {
+	return &action_pb.ActionVersion{
+		Version:       version.Version,
+		Details:       object_grpc.ChangeToDetailsPb(version.Sequence, version.CreationDate, version.ResourceOwner),
+		EditorUserId:  version.EditorUser,
+		Name:          version.Name,
+		Script:        version.Script,
+		Timeout:       durationpb.New(version.Timeout),
+		AllowedToFail: version.AllowedToFail,
+	}
+}
+
+func ActionLogsToPb(records []*execution.Record) []*action_pb.ActionLog {
+	logs := make([]*action_pb.ActionLog, len(records))
+	for i, record := range records {
+		logs[i] = &action_pb.ActionLog{
+			LogDate: timestamppb.New(record.LogDate),
+			Level:   record.LogLevel.String(),
+			Message: record.Message,
+		}
+		if record.Took > 0 {
+			logs[i].Took = durationpb.New(record.Took)
+		}
+	}
+	return logs
+}
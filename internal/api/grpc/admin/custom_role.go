@@ -0,0 +1,88 @@
+package admin
+
+import (
+	"context"
+
+	member_grpc "github.com/zitadel/zitadel/internal/api/grpc/member"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) ListCustomRoles(ctx context.Context, req *admin_pb.ListCustomRolesRequest) (*admin_pb.ListCustomRolesResponse, error) {
+	queries, err := listCustomRolesToQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.query.SearchCustomRoles(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListCustomRolesResponse{
+		Details: object.ToListDetails(res.Count, res.Sequence, res.Timestamp),
+		Result:  member_grpc.CustomRolesToPb(res.CustomRoles),
+	}, nil
+}
+
+func (s *Server) GetCustomRole(ctx context.Context, req *admin_pb.GetCustomRoleRequest) (*admin_pb.GetCustomRoleResponse, error) {
+	role, err := s.query.CustomRoleByKey(ctx, req.Key)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetCustomRoleResponse{
+		Role: member_grpc.CustomRoleToPb(role),
+	}, nil
+}
+
+func (s *Server) ListCustomRolePermissions(ctx context.Context, _ *admin_pb.ListCustomRolePermissionsRequest) (*admin_pb.ListCustomRolePermissionsResponse, error) {
+	return &admin_pb.ListCustomRolePermissionsResponse{
+		IamPermissions: s.query.GetCustomRolePermissions(domain.IAMRolePrefix),
+		OrgPermissions: s.query.GetCustomRolePermissions(domain.OrgRolePrefix),
+	}, nil
+}
+
+func (s *Server) AddCustomRole(ctx context.Context, req *admin_pb.AddCustomRoleRequest) (*admin_pb.AddCustomRoleResponse, error) {
+	details, err := s.command.AddCustomRole(ctx, &domain.CustomRole{
+		Key:         req.Key,
+		DisplayName: req.DisplayName,
+		Permissions: req.Permissions,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddCustomRoleResponse{
+		Details: object.AddToDetailsPb(
+			details.Sequence,
+			details.EventDate,
+			details.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) UpdateCustomRole(ctx context.Context, req *admin_pb.UpdateCustomRoleRequest) (*admin_pb.UpdateCustomRoleResponse, error) {
+	details, err := s.command.ChangeCustomRole(ctx, &domain.CustomRole{
+		Key:         req.Key,
+		DisplayName: req.DisplayName,
+		Permissions: req.Permissions,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateCustomRoleResponse{
+		Details: object.ChangeToDetailsPb(
+			details.Sequence,
+			details.EventDate,
+			details.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) RemoveCustomRole(ctx context.Context, req *admin_pb.RemoveCustomRoleRequest) (*admin_pb.RemoveCustomRoleResponse, error) {
+	details, err := s.command.RemoveCustomRole(ctx, req.Key)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.RemoveCustomRoleResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
@@ -486,6 +486,7 @@ func (s *Server) getLockoutPolicy(ctx context.Context, orgID string) (_ *managem
 	if !queriedLockout.IsDefault {
 		return &management_pb.AddCustomLockoutPolicyRequest{
 			MaxPasswordAttempts: uint32(queriedLockout.MaxPasswordAttempts),
+			MaxOtpAttempts:      uint32(queriedLockout.MaxOTPAttempts),
 		}, nil
 	}
 	return nil, nil
@@ -521,9 +522,10 @@ func (s *Server) getPrivacyPolicy(ctx context.Context, orgID string) (_ *managem
 	}
 	if !queriedPrivacy.IsDefault {
 		return &management_pb.AddCustomPrivacyPolicyRequest{
-			TosLink:     queriedPrivacy.TOSLink,
-			PrivacyLink: queriedPrivacy.PrivacyLink,
-			HelpLink:    queriedPrivacy.HelpLink,
+			TosLink:             queriedPrivacy.TOSLink,
+			PrivacyLink:         queriedPrivacy.PrivacyLink,
+			HelpLink:            queriedPrivacy.HelpLink,
+			DeletionGracePeriod: durationpb.New(queriedPrivacy.DeletionGracePeriod),
 		}, nil
 	}
 	return nil, nil
@@ -659,7 +661,7 @@ func (s *Server) getUsers(ctx context.Context, org string, withPasswords bool, w
 func (s *Server) getTriggerActions(ctx context.Context, org string, processedActions []string) (_ []*management_pb.SetTriggerActionsRequest, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
-	flowTypes := []domain.FlowType{domain.FlowTypeExternalAuthentication, domain.FlowTypeInternalAuthentication}
+	flowTypes := []domain.FlowType{domain.FlowTypeExternalAuthentication, domain.FlowTypeInternalAuthentication, domain.FlowTypeUserManagement, domain.FlowTypePreAuthentication, domain.FlowTypeCustomiseSAMLResponse}
 	triggerActions := make([]*management_pb.SetTriggerActionsRequest, 0)
 
 	for _, flowType := range flowTypes {
@@ -93,6 +93,16 @@ func (s *Server) ListLoginPolicySecondFactors(ctx context.Context, req *admin_pb
 	}, nil
 }
 
+func (s *Server) SetLoginPolicyRiskRules(ctx context.Context, req *admin_pb.SetLoginPolicyRiskRulesRequest) (*admin_pb.SetLoginPolicyRiskRulesResponse, error) {
+	objectDetails, err := s.command.SetDefaultLoginPolicyRiskRules(ctx, setLoginPolicyRiskRulesToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetLoginPolicyRiskRulesResponse{
+		Details: object.DomainToChangeDetailsPb(objectDetails),
+	}, nil
+}
+
 func (s *Server) AddSecondFactorToLoginPolicy(ctx context.Context, req *admin_pb.AddSecondFactorToLoginPolicyRequest) (*admin_pb.AddSecondFactorToLoginPolicyResponse, error) {
 	objectDetails, err := s.command.AddSecondFactorToDefaultLoginPolicy(ctx, policy_grpc.SecondFactorTypeToDomain(req.Type))
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
 	policy_grpc "github.com/zitadel/zitadel/internal/api/grpc/policy"
 	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/query"
 	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
 )
@@ -29,6 +30,14 @@ func updateLoginPolicyToCommand(p *admin_pb.UpdateLoginPolicyRequest) *command.C
 	}
 }
 
+func setLoginPolicyRiskRulesToDomain(req *admin_pb.SetLoginPolicyRiskRulesRequest) domain.LoginPolicyRiskRules {
+	return domain.LoginPolicyRiskRules{
+		TrustedIPRanges:    req.TrustedIpRanges,
+		MFAOnNewUserAgent:  req.MfaOnNewUserAgent,
+		MFAOnCountryChange: req.MfaOnCountryChange,
+	}
+}
+
 func ListLoginPolicyIDPsRequestToQuery(req *admin_pb.ListLoginPolicyIDPsRequest) *query.IDPLoginPolicyLinksSearchQuery {
 	offset, limit, asc := object.ListQueryToModel(req.Query)
 	return &query.IDPLoginPolicyLinksSearchQuery{
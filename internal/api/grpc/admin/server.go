@@ -12,6 +12,7 @@ import (
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/config/systemdefaults"
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/ldapsync"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/pkg/grpc/admin"
 )
@@ -31,6 +32,7 @@ type Server struct {
 	assetsAPIDomain func(context.Context) string
 	userCodeAlg     crypto.EncryptionAlgorithm
 	passwordHashAlg crypto.HashAlgorithm
+	ldapReconciler  *ldapsync.Reconciler
 }
 
 type Config struct {
@@ -45,6 +47,7 @@ func CreateServer(
 	repo repository.Repository,
 	externalSecure bool,
 	userCodeAlg crypto.EncryptionAlgorithm,
+	ldapReconciler *ldapsync.Reconciler,
 ) *Server {
 	return &Server{
 		database:        database,
@@ -54,6 +57,7 @@ func CreateServer(
 		assetsAPIDomain: assets.AssetAPI(externalSecure),
 		userCodeAlg:     userCodeAlg,
 		passwordHashAlg: crypto.NewBCrypt(sd.SecretGenerators.PasswordSaltCost),
+		ldapReconciler:  ldapReconciler,
 	}
 }
 
@@ -0,0 +1,58 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	policy_grpc "github.com/zitadel/zitadel/internal/api/grpc/policy"
+	"github.com/zitadel/zitadel/internal/domain"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) AddSigningKeyPolicy(ctx context.Context, req *admin_pb.AddSigningKeyPolicyRequest) (*admin_pb.AddSigningKeyPolicyResponse, error) {
+	result, err := s.command.AddDefaultSigningKeyPolicy(ctx, &domain.SigningKeyPolicy{
+		Algorithm:            req.GetAlgorithm(),
+		KeySize:              int(req.GetKeySize()),
+		KeyLifetime:          req.GetKeyLifetime().AsDuration(),
+		OverlapPeriod:        req.GetOverlapPeriod().AsDuration(),
+		PrepublicationWindow: req.GetPrepublicationWindow().AsDuration(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddSigningKeyPolicyResponse{
+		Details: object.AddToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) GetSigningKeyPolicy(ctx context.Context, _ *admin_pb.GetSigningKeyPolicyRequest) (*admin_pb.GetSigningKeyPolicyResponse, error) {
+	policy, err := s.query.SigningKeyPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetSigningKeyPolicyResponse{Policy: policy_grpc.ModelSigningKeyPolicyToPb(policy)}, nil
+}
+
+func (s *Server) UpdateSigningKeyPolicy(ctx context.Context, req *admin_pb.UpdateSigningKeyPolicyRequest) (*admin_pb.UpdateSigningKeyPolicyResponse, error) {
+	result, err := s.command.ChangeDefaultSigningKeyPolicy(ctx, &domain.SigningKeyPolicy{
+		Algorithm:            req.GetAlgorithm(),
+		KeySize:              int(req.GetKeySize()),
+		KeyLifetime:          req.GetKeyLifetime().AsDuration(),
+		OverlapPeriod:        req.GetOverlapPeriod().AsDuration(),
+		PrepublicationWindow: req.GetPrepublicationWindow().AsDuration(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateSigningKeyPolicyResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
@@ -0,0 +1,46 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	policy_grpc "github.com/zitadel/zitadel/internal/api/grpc/policy"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) AddTokenExchangePolicy(ctx context.Context, req *admin_pb.AddTokenExchangePolicyRequest) (*admin_pb.AddTokenExchangePolicyResponse, error) {
+	result, err := s.command.AddDefaultTokenExchangePolicy(ctx, authz.GetInstance(ctx).InstanceID(), req.GetDelegationActors(), req.GetImpersonationActors())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddTokenExchangePolicyResponse{
+		Details: object.AddToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) GetTokenExchangePolicy(ctx context.Context, _ *admin_pb.GetTokenExchangePolicyRequest) (*admin_pb.GetTokenExchangePolicyResponse, error) {
+	policy, err := s.query.DefaultTokenExchangePolicy(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetTokenExchangePolicyResponse{Policy: policy_grpc.ModelTokenExchangePolicyToPb(policy)}, nil
+}
+
+func (s *Server) UpdateTokenExchangePolicy(ctx context.Context, req *admin_pb.UpdateTokenExchangePolicyRequest) (*admin_pb.UpdateTokenExchangePolicyResponse, error) {
+	result, err := s.command.ChangeDefaultTokenExchangePolicy(ctx, authz.GetInstance(ctx).InstanceID(), req.GetDelegationActors(), req.GetImpersonationActors())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateTokenExchangePolicyResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
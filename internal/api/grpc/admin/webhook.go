@@ -0,0 +1,124 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	obj_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
+	webhook_grpc "github.com/zitadel/zitadel/internal/api/grpc/webhook"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) ListWebhooks(ctx context.Context, req *admin_pb.ListWebhooksRequest) (*admin_pb.ListWebhooksResponse, error) {
+	queries, err := listWebhooksToQuery(authz.GetInstance(ctx).InstanceID(), req)
+	if err != nil {
+		return nil, err
+	}
+	webhooks, err := s.query.SearchWebhooks(ctx, queries, false)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListWebhooksResponse{
+		Details: obj_grpc.ToListDetails(webhooks.Count, webhooks.Sequence, webhooks.Timestamp),
+		Result:  webhook_grpc.WebhooksToPb(webhooks.Webhooks),
+	}, nil
+}
+
+func (s *Server) GetWebhookByID(ctx context.Context, req *admin_pb.GetWebhookByIDRequest) (*admin_pb.GetWebhookByIDResponse, error) {
+	webhook, err := s.query.GetWebhookByID(ctx, req.Id, authz.GetInstance(ctx).InstanceID(), false)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetWebhookByIDResponse{
+		Webhook: webhook_grpc.WebhookToPb(webhook),
+	}, nil
+}
+
+func (s *Server) AddWebhook(ctx context.Context, req *admin_pb.AddWebhookRequest) (*admin_pb.AddWebhookResponse, error) {
+	id, signingKey, details, err := s.command.AddWebhook(ctx, addWebhookRequestToDomain(req), authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddWebhookResponse{
+		Id:         id,
+		SigningKey: signingKey,
+		Details:    obj_grpc.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) UpdateWebhook(ctx context.Context, req *admin_pb.UpdateWebhookRequest) (*admin_pb.UpdateWebhookResponse, error) {
+	details, err := s.command.ChangeWebhook(ctx, updateWebhookRequestToDomain(req), authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateWebhookResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RegenerateWebhookSigningKey(ctx context.Context, req *admin_pb.RegenerateWebhookSigningKeyRequest) (*admin_pb.RegenerateWebhookSigningKeyResponse, error) {
+	signingKey, details, err := s.command.RegenerateWebhookSigningKey(ctx, req.Id, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.RegenerateWebhookSigningKeyResponse{
+		SigningKey: signingKey,
+		Details:    obj_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) DeactivateWebhook(ctx context.Context, req *admin_pb.DeactivateWebhookRequest) (*admin_pb.DeactivateWebhookResponse, error) {
+	details, err := s.command.DeactivateWebhook(ctx, req.Id, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.DeactivateWebhookResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ReactivateWebhook(ctx context.Context, req *admin_pb.ReactivateWebhookRequest) (*admin_pb.ReactivateWebhookResponse, error) {
+	details, err := s.command.ReactivateWebhook(ctx, req.Id, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ReactivateWebhookResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveWebhook(ctx context.Context, req *admin_pb.RemoveWebhookRequest) (*admin_pb.RemoveWebhookResponse, error) {
+	details, err := s.command.RemoveWebhook(ctx, req.Id, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.RemoveWebhookResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ListWebhookDeliveries(ctx context.Context, req *admin_pb.ListWebhookDeliveriesRequest) (*admin_pb.ListWebhookDeliveriesResponse, error) {
+	queries, err := listWebhookDeliveriesToQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	deliveries, err := s.query.SearchWebhookDeliveries(ctx, req.WebhookId, authz.GetInstance(ctx).InstanceID(), queries)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListWebhookDeliveriesResponse{
+		Details: obj_grpc.ToListDetails(deliveries.Count, deliveries.Sequence, deliveries.Timestamp),
+		Result:  webhook_grpc.WebhookDeliveriesToPb(deliveries.Deliveries),
+	}, nil
+}
+
+func (s *Server) ReplayWebhookDelivery(ctx context.Context, req *admin_pb.ReplayWebhookDeliveryRequest) (*admin_pb.ReplayWebhookDeliveryResponse, error) {
+	details, err := s.command.ReplayWebhookDelivery(ctx, req.WebhookId, authz.GetInstance(ctx).InstanceID(), req.EventSequence, eventstore.AggregateType(req.AggregateType))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ReplayWebhookDeliveryResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
@@ -55,6 +55,17 @@ func (s *Server) RemoveMyUser(ctx context.Context, _ *auth_pb.RemoveMyUserReques
 	}, nil
 }
 
+func (s *Server) RequestMyUserDeletion(ctx context.Context, _ *auth_pb.RequestMyUserDeletionRequest) (*auth_pb.RequestMyUserDeletionResponse, error) {
+	ctxData := authz.GetCtxData(ctx)
+	details, err := s.command.RequestUserDeletion(ctx, ctxData.UserID, ctxData.ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	return &auth_pb.RequestMyUserDeletionResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
 func (s *Server) ListMyUserChanges(ctx context.Context, req *auth_pb.ListMyUserChangesRequest) (*auth_pb.ListMyUserChangesResponse, error) {
 	sequence, limit, asc := change.ChangeQueryToQuery(req.Query)
 	changes, err := s.query.UserChanges(ctx, authz.GetCtxData(ctx).UserID, sequence, limit, asc, s.auditLogRetention)
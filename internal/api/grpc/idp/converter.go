@@ -4,6 +4,7 @@ import (
 	obj_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/domain"
 	iam_model "github.com/zitadel/zitadel/internal/iam/model"
+	"github.com/zitadel/zitadel/internal/ldapsync"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/repository/idp"
 	idp_pb "github.com/zitadel/zitadel/pkg/grpc/idp"
@@ -328,6 +329,50 @@ func LDAPAttributesToCommand(attributes *idp_pb.LDAPAttributes) idp.LDAPAttribut
 		PreferredLanguageAttribute: attributes.PreferredLanguageAttribute,
 		AvatarURLAttribute:         attributes.AvatarUrlAttribute,
 		ProfileAttribute:           attributes.ProfileAttribute,
+		GroupsAttribute:            attributes.GroupsAttribute,
+		DisabledAttribute:          attributes.DisabledAttribute,
+	}
+}
+
+func LDAPGroupMappingsToCommand(mappings []*idp_pb.LDAPGroupMapping) []idp.LDAPGroupMapping {
+	if len(mappings) == 0 {
+		return nil
+	}
+	list := make([]idp.LDAPGroupMapping, len(mappings))
+	for i, mapping := range mappings {
+		list[i] = idp.LDAPGroupMapping{
+			GroupDN:         mapping.GroupDn,
+			ProjectID:       mapping.ProjectId,
+			ProjectRoleKeys: mapping.ProjectRoleKeys,
+			OrgMemberRoles:  mapping.OrgMemberRoles,
+		}
+	}
+	return list
+}
+
+func LDAPReconciliationReportToPb(report *ldapsync.Report) []*idp_pb.LDAPReconciliationEntry {
+	entries := make([]*idp_pb.LDAPReconciliationEntry, len(report.Entries))
+	for i, entry := range report.Entries {
+		entries[i] = &idp_pb.LDAPReconciliationEntry{
+			UserId:           entry.UserID,
+			ResourceOwner:    entry.ResourceOwner,
+			ProvidedUserId:   entry.ProvidedUserID,
+			ProvidedUserName: entry.ProvidedUsername,
+			Reason:           ldapReconciliationReasonToPb(entry.Reason),
+			Deactivated:      entry.Deactivated,
+		}
+	}
+	return entries
+}
+
+func ldapReconciliationReasonToPb(reason ldapsync.Reason) idp_pb.LDAPReconciliationReason {
+	switch reason {
+	case ldapsync.ReasonNotFound:
+		return idp_pb.LDAPReconciliationReason_LDAP_RECONCILIATION_REASON_NOT_FOUND
+	case ldapsync.ReasonDisabled:
+		return idp_pb.LDAPReconciliationReason_LDAP_RECONCILIATION_REASON_DISABLED
+	default:
+		return idp_pb.LDAPReconciliationReason_LDAP_RECONCILIATION_REASON_UNSPECIFIED
 	}
 }
 
@@ -386,6 +431,8 @@ func providerTypeToPb(idpType domain.IDPType) idp_pb.ProviderType {
 		return idp_pb.ProviderType_PROVIDER_TYPE_GITLAB_SELF_HOSTED
 	case domain.IDPTypeGoogle:
 		return idp_pb.ProviderType_PROVIDER_TYPE_GOOGLE
+	case domain.IDPTypeSAML:
+		return idp_pb.ProviderType_PROVIDER_TYPE_SAML
 	case domain.IDPTypeUnspecified:
 		return idp_pb.ProviderType_PROVIDER_TYPE_UNSPECIFIED
 	default:
@@ -414,6 +461,34 @@ func configToPb(config *query.IDPTemplate) *idp_pb.ProviderConfig {
 		ldapConfigToPb(providerConfig, config.LDAPIDPTemplate)
 		return providerConfig
 	}
+	if config.SAMLIDPTemplate != nil {
+		samlConfigToPb(providerConfig, config.SAMLIDPTemplate)
+		return providerConfig
+	}
+	if config.OIDCIDPTemplate != nil {
+		oidcConfigToPb(providerConfig, config.OIDCIDPTemplate)
+		return providerConfig
+	}
+	if config.AzureADIDPTemplate != nil {
+		azureADConfigToPb(providerConfig, config.AzureADIDPTemplate)
+		return providerConfig
+	}
+	if config.GitHubIDPTemplate != nil {
+		githubConfigToPb(providerConfig, config.GitHubIDPTemplate)
+		return providerConfig
+	}
+	if config.GitHubEnterpriseIDPTemplate != nil {
+		githubEnterpriseConfigToPb(providerConfig, config.GitHubEnterpriseIDPTemplate)
+		return providerConfig
+	}
+	if config.GitLabIDPTemplate != nil {
+		gitlabConfigToPb(providerConfig, config.GitLabIDPTemplate)
+		return providerConfig
+	}
+	if config.GitLabSelfHostedIDPTemplate != nil {
+		gitlabSelfHostedConfigToPb(providerConfig, config.GitLabSelfHostedIDPTemplate)
+		return providerConfig
+	}
 	return providerConfig
 }
 
@@ -449,6 +524,78 @@ func ldapConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.LDAPI
 			UserUniqueAttribute: template.UserUniqueAttribute,
 			Admin:               template.Admin,
 			Attributes:          ldapAttributesToPb(template.LDAPAttributes),
+			GroupMappings:       ldapGroupMappingsToPb(template.GroupMappings),
+		},
+	}
+}
+
+func samlConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.SAMLIDPTemplate) {
+	providerConfig.Config = &idp_pb.ProviderConfig_Saml{
+		Saml: &idp_pb.SAMLConfig{
+			MetadataXml:       template.Metadata,
+			MetadataUrl:       template.MetadataURL,
+			WithSignedRequest: template.WithSignedRequest,
+		},
+	}
+}
+
+func oidcConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.OIDCIDPTemplate) {
+	providerConfig.Config = &idp_pb.ProviderConfig_Oidc{
+		Oidc: &idp_pb.GenericOIDCConfig{
+			Issuer:   template.Issuer,
+			ClientId: template.ClientID,
+			Scopes:   template.Scopes,
+		},
+	}
+}
+
+func azureADConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.AzureADIDPTemplate) {
+	providerConfig.Config = &idp_pb.ProviderConfig_AzureAd{
+		AzureAd: &idp_pb.AzureADConfig{
+			ClientId:      template.ClientID,
+			Scopes:        template.Scopes,
+			Tenant:        template.Tenant,
+			EmailVerified: template.IsEmailVerified,
+		},
+	}
+}
+
+func githubConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.GitHubIDPTemplate) {
+	providerConfig.Config = &idp_pb.ProviderConfig_Github{
+		Github: &idp_pb.GitHubConfig{
+			ClientId: template.ClientID,
+			Scopes:   template.Scopes,
+		},
+	}
+}
+
+func githubEnterpriseConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.GitHubEnterpriseIDPTemplate) {
+	providerConfig.Config = &idp_pb.ProviderConfig_GithubEs{
+		GithubEs: &idp_pb.GitHubEnterpriseServerConfig{
+			ClientId:              template.ClientID,
+			AuthorizationEndpoint: template.AuthorizationEndpoint,
+			TokenEndpoint:         template.TokenEndpoint,
+			UserEndpoint:          template.UserEndpoint,
+			Scopes:                template.Scopes,
+		},
+	}
+}
+
+func gitlabConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.GitLabIDPTemplate) {
+	providerConfig.Config = &idp_pb.ProviderConfig_Gitlab{
+		Gitlab: &idp_pb.GitLabConfig{
+			ClientId: template.ClientID,
+			Scopes:   template.Scopes,
+		},
+	}
+}
+
+func gitlabSelfHostedConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.GitLabSelfHostedIDPTemplate) {
+	providerConfig.Config = &idp_pb.ProviderConfig_GitlabSelfHosted{
+		GitlabSelfHosted: &idp_pb.GitLabSelfHostedConfig{
+			Issuer:   template.Issuer,
+			ClientId: template.ClientID,
+			Scopes:   template.Scopes,
 		},
 	}
 }
@@ -468,5 +615,20 @@ func ldapAttributesToPb(attributes idp.LDAPAttributes) *idp_pb.LDAPAttributes {
 		PreferredLanguageAttribute: attributes.PreferredLanguageAttribute,
 		AvatarUrlAttribute:         attributes.AvatarURLAttribute,
 		ProfileAttribute:           attributes.ProfileAttribute,
+		GroupsAttribute:            attributes.GroupsAttribute,
+		DisabledAttribute:          attributes.DisabledAttribute,
 	}
 }
+
+func ldapGroupMappingsToPb(mappings []idp.LDAPGroupMapping) []*idp_pb.LDAPGroupMapping {
+	list := make([]*idp_pb.LDAPGroupMapping, len(mappings))
+	for i, mapping := range mappings {
+		list[i] = &idp_pb.LDAPGroupMapping{
+			GroupDn:         mapping.GroupDN,
+			ProjectId:       mapping.ProjectID,
+			ProjectRoleKeys: mapping.ProjectRoleKeys,
+			OrgMemberRoles:  mapping.OrgMemberRoles,
+		}
+	}
+	return list
+}
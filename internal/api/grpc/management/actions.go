@@ -3,9 +3,13 @@ package management
 import (
 	"context"
 
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/zitadel/zitadel/internal/actions/dryrun"
 	"github.com/zitadel/zitadel/internal/api/authz"
 	action_grpc "github.com/zitadel/zitadel/internal/api/grpc/action"
 	obj_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/errors"
 	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
 )
 
@@ -96,3 +100,96 @@ func (s *Server) DeleteAction(ctx context.Context, req *mgmt_pb.DeleteActionRequ
 	_, err = s.command.DeleteAction(ctx, req.Id, authz.GetCtxData(ctx).OrgID, flowTypes...)
 	return &mgmt_pb.DeleteActionResponse{}, err
 }
+
+func (s *Server) ListActionVersions(ctx context.Context, req *mgmt_pb.ListActionVersionsRequest) (*mgmt_pb.ListActionVersionsResponse, error) {
+	versions, err := s.query.ActionVersions(ctx, req.Id, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListActionVersionsResponse{
+		Result: action_grpc.ActionVersionsToPb(versions),
+	}, nil
+}
+
+func (s *Server) RollbackAction(ctx context.Context, req *mgmt_pb.RollbackActionRequest) (*mgmt_pb.RollbackActionResponse, error) {
+	details, err := s.command.RollbackAction(ctx, req.Id, req.Version, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RollbackActionResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) TestAction(ctx context.Context, req *mgmt_pb.TestActionRequest) (*mgmt_pb.TestActionResponse, error) {
+	orgID := authz.GetCtxData(ctx).OrgID
+	action := testActionRequestToDomain(req, orgID)
+	if req.Id == "" && !action.IsValid() {
+		return nil, errors.ThrowInvalidArgument(nil, "MGMT-Ohg9u", "Errors.Action.Invalid")
+	}
+	if req.Id != "" {
+		existing, err := s.query.GetActionByID(ctx, req.Id, orgID, false)
+		if err != nil {
+			return nil, err
+		}
+		action = actionToDomain(existing)
+	}
+	var result *dryrun.Result
+	var err error
+	if req.Event != nil {
+		result, err = dryrun.RunEvent(ctx, action, testActionEventToDryRun(req.Event))
+	} else {
+		result, err = dryrun.Run(
+			ctx,
+			action,
+			action_grpc.FlowTypeToDomain(req.FlowType),
+			action_grpc.TriggerTypeToDomain(req.TriggerType),
+			testActionContextToDomain(req),
+		)
+	}
+	if err != nil {
+		return nil, err
+	}
+	mutatedFields, err := structpb.NewStruct(result.MutatedFields)
+	if err != nil {
+		return nil, err
+	}
+	res := &mgmt_pb.TestActionResponse{
+		Logs:          action_grpc.ActionLogsToPb(result.Logs),
+		MutatedFields: mutatedFields,
+	}
+	if result.Err != nil {
+		res.Error = result.Err.Error()
+	}
+	return res, nil
+}
+
+func (s *Server) ListActionSecrets(ctx context.Context, _ *mgmt_pb.ListActionSecretsRequest) (*mgmt_pb.ListActionSecretsResponse, error) {
+	secrets, err := s.query.ActionSecrets(ctx, authz.GetCtxData(ctx).OrgID, false)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListActionSecretsResponse{
+		Result: action_grpc.ActionSecretsToPb(secrets),
+	}, nil
+}
+
+func (s *Server) SetActionSecret(ctx context.Context, req *mgmt_pb.SetActionSecretRequest) (*mgmt_pb.SetActionSecretResponse, error) {
+	details, err := s.command.SetActionSecret(ctx, req.Name, req.Value, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetActionSecretResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveActionSecret(ctx context.Context, req *mgmt_pb.RemoveActionSecretRequest) (*mgmt_pb.RemoveActionSecretResponse, error) {
+	details, err := s.command.RemoveActionSecret(ctx, req.Name, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveActionSecretResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
@@ -1,8 +1,12 @@
 package management
 
 import (
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/actions/dryrun"
 	action_grpc "github.com/zitadel/zitadel/internal/api/grpc/action"
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	user_grpc "github.com/zitadel/zitadel/internal/api/grpc/user"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
@@ -31,6 +35,131 @@ func updateActionRequestToDomain(req *mgmt_pb.UpdateActionRequest) *domain.Actio
 	}
 }
 
+func testActionRequestToDomain(req *mgmt_pb.TestActionRequest, orgID string) *domain.Action {
+	return &domain.Action{
+		ObjectRoot: models.ObjectRoot{
+			ResourceOwner: orgID,
+		},
+		Name:    req.Name,
+		Script:  req.Script,
+		Timeout: req.Timeout.AsDuration(),
+	}
+}
+
+func actionToDomain(action *query.Action) *domain.Action {
+	return &domain.Action{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID:   action.ID,
+			ResourceOwner: action.ResourceOwner,
+		},
+		Name:          action.Name,
+		Script:        action.Script,
+		Timeout:       action.Timeout(),
+		AllowedToFail: action.AllowedToFail,
+	}
+}
+
+func testActionContextToDomain(req *mgmt_pb.TestActionRequest) *dryrun.Context {
+	return &dryrun.Context{
+		User:         testActionUserToDomain(req.User),
+		AuthRequest:  testActionAuthRequestToDomain(req.AuthRequest),
+		ExternalUser: testActionExternalUserToDomain(req.ExternalUser),
+		Metadata:     testActionMetadataToDomain(req.UserMetadata),
+	}
+}
+
+func testActionMetadataToDomain(metadata []*mgmt_pb.TestActionMetadata) []*domain.Metadata {
+	list := make([]*domain.Metadata, len(metadata))
+	for i, md := range metadata {
+		list[i] = &domain.Metadata{
+			Key:   md.Key,
+			Value: md.Value,
+		}
+	}
+	return list
+}
+
+func testActionEventToDryRun(event *mgmt_pb.TestActionEvent) *dryrun.Event {
+	return &dryrun.Event{
+		Type:          event.Type,
+		AggregateType: event.AggregateType,
+		AggregateID:   event.AggregateId,
+		Sequence:      event.Sequence,
+		Payload:       event.Payload,
+	}
+}
+
+func testActionUserToDomain(user *mgmt_pb.TestActionUser) *domain.Human {
+	if user == nil {
+		return nil
+	}
+	return &domain.Human{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: user.Id,
+		},
+		Username: user.Username,
+		Profile: &domain.Profile{
+			FirstName:         user.FirstName,
+			LastName:          user.LastName,
+			NickName:          user.NickName,
+			DisplayName:       user.DisplayName,
+			PreferredLanguage: language.Make(user.PreferredLanguage),
+			Gender:            user_grpc.GenderToDomain(user.Gender),
+		},
+		Email: &domain.Email{
+			EmailAddress:    user.Email,
+			IsEmailVerified: user.IsEmailVerified,
+		},
+		Phone: &domain.Phone{
+			PhoneNumber:     user.Phone,
+			IsPhoneVerified: user.IsPhoneVerified,
+		},
+	}
+}
+
+func testActionAuthRequestToDomain(authRequest *mgmt_pb.TestActionAuthRequest) *domain.AuthRequest {
+	if authRequest == nil {
+		return nil
+	}
+	return &domain.AuthRequest{
+		ID:                  authRequest.Id,
+		AgentID:             authRequest.AgentId,
+		ApplicationID:       authRequest.ApplicationId,
+		CallbackURI:         authRequest.CallbackUri,
+		UiLocales:           authRequest.UiLocales,
+		LoginHint:           authRequest.LoginHint,
+		Request:             &domain.AuthRequestOIDC{Scopes: authRequest.Scopes},
+		UserID:              authRequest.UserId,
+		UserName:            authRequest.UserName,
+		LoginName:           authRequest.LoginName,
+		DisplayName:         authRequest.DisplayName,
+		UserOrgID:           authRequest.UserOrgId,
+		RequestedOrgID:      authRequest.RequestedOrgId,
+		SelectedIDPConfigID: authRequest.SelectedIdpConfigId,
+		PasswordVerified:    authRequest.PasswordVerified,
+	}
+}
+
+func testActionExternalUserToDomain(user *mgmt_pb.TestActionExternalUser) *domain.ExternalUser {
+	if user == nil {
+		return nil
+	}
+	return &domain.ExternalUser{
+		IDPConfigID:       user.IdpConfigId,
+		ExternalUserID:    user.ExternalUserId,
+		PreferredUsername: user.PreferredUsername,
+		FirstName:         user.FirstName,
+		LastName:          user.LastName,
+		NickName:          user.NickName,
+		DisplayName:       user.DisplayName,
+		PreferredLanguage: language.Make(user.PreferredLanguage),
+		Email:             user.Email,
+		IsEmailVerified:   user.IsEmailVerified,
+		Phone:             user.Phone,
+		IsPhoneVerified:   user.IsPhoneVerified,
+	}
+}
+
 func listActionsToQuery(orgID string, req *mgmt_pb.ListActionsRequest) (_ *query.ActionSearchQueries, err error) {
 	offset, limit, asc := object.ListQueryToModel(req.Query)
 	queries := make([]query.SearchQuery, len(req.Queries)+1)
@@ -18,6 +18,9 @@ func (s *Server) ListFlowTypes(ctx context.Context, _ *mgmt_pb.ListFlowTypesRequ
 			action_grpc.FlowTypeToPb(domain.FlowTypeExternalAuthentication),
 			action_grpc.FlowTypeToPb(domain.FlowTypeCustomiseToken),
 			action_grpc.FlowTypeToPb(domain.FlowTypeInternalAuthentication),
+			action_grpc.FlowTypeToPb(domain.FlowTypeUserManagement),
+			action_grpc.FlowTypeToPb(domain.FlowTypePreAuthentication),
+			action_grpc.FlowTypeToPb(domain.FlowTypeCustomiseSAMLResponse),
 		},
 	}, nil
 }
@@ -71,3 +74,27 @@ func (s *Server) SetTriggerActions(ctx context.Context, req *mgmt_pb.SetTriggerA
 		),
 	}, nil
 }
+
+func (s *Server) ListEventActions(ctx context.Context, _ *mgmt_pb.ListEventActionsRequest) (*mgmt_pb.ListEventActionsResponse, error) {
+	eventActions, err := s.query.GetEventActions(ctx, authz.GetCtxData(ctx).OrgID, false)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListEventActionsResponse{
+		Result: action_grpc.EventActionsListToPb(eventActions),
+	}, nil
+}
+
+func (s *Server) SetEventActions(ctx context.Context, req *mgmt_pb.SetEventActionsRequest) (*mgmt_pb.SetEventActionsResponse, error) {
+	details, err := s.command.SetEventActions(ctx, req.EventType, req.ActionIds, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetEventActionsResponse{
+		Details: obj_grpc.AddToDetailsPb(
+			details.Sequence,
+			details.EventDate,
+			details.ResourceOwner,
+		),
+	}, nil
+}
@@ -226,6 +226,11 @@ func (s *Server) ListOrgMemberRoles(ctx context.Context, _ *mgmt_pb.ListOrgMembe
 		return nil, err
 	}
 	roles := s.query.GetOrgMemberRoles(authz.GetCtxData(ctx).OrgID == instance.DefaultOrgID)
+	customRoles, err := s.query.GetCustomMemberRoles(ctx, domain.OrgRolePrefix)
+	if err != nil {
+		return nil, err
+	}
+	roles = append(roles, customRoles...)
 	return &mgmt_pb.ListOrgMemberRolesResponse{
 		Result: roles,
 	}, nil
@@ -5,6 +5,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
 	policy_grpc "github.com/zitadel/zitadel/internal/api/grpc/policy"
 	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/query"
 	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
 )
@@ -64,6 +65,14 @@ func updateLoginPolicyToCommand(p *mgmt_pb.UpdateCustomLoginPolicyRequest) *comm
 	}
 }
 
+func setLoginPolicyRiskRulesToDomain(req *mgmt_pb.SetLoginPolicyRiskRulesRequest) domain.LoginPolicyRiskRules {
+	return domain.LoginPolicyRiskRules{
+		TrustedIPRanges:    req.TrustedIpRanges,
+		MFAOnNewUserAgent:  req.MfaOnNewUserAgent,
+		MFAOnCountryChange: req.MfaOnCountryChange,
+	}
+}
+
 func ListLoginPolicyIDPsRequestToQuery(req *mgmt_pb.ListLoginPolicyIDPsRequest) *query.IDPLoginPolicyLinksSearchQuery {
 	offset, limit, asc := object.ListQueryToModel(req.Query)
 	return &query.IDPLoginPolicyLinksSearchQuery{
@@ -7,16 +7,18 @@ import (
 
 func AddPrivacyPolicyToDomain(req *mgmt_pb.AddCustomPrivacyPolicyRequest) *domain.PrivacyPolicy {
 	return &domain.PrivacyPolicy{
-		TOSLink:     req.TosLink,
-		PrivacyLink: req.PrivacyLink,
-		HelpLink:    req.HelpLink,
+		TOSLink:             req.TosLink,
+		PrivacyLink:         req.PrivacyLink,
+		HelpLink:            req.HelpLink,
+		DeletionGracePeriod: req.DeletionGracePeriod.AsDuration(),
 	}
 }
 
 func UpdatePrivacyPolicyToDomain(req *mgmt_pb.UpdateCustomPrivacyPolicyRequest) *domain.PrivacyPolicy {
 	return &domain.PrivacyPolicy{
-		TOSLink:     req.TosLink,
-		PrivacyLink: req.PrivacyLink,
-		HelpLink:    req.HelpLink,
+		TOSLink:             req.TosLink,
+		PrivacyLink:         req.PrivacyLink,
+		HelpLink:            req.HelpLink,
+		DeletionGracePeriod: req.DeletionGracePeriod.AsDuration(),
 	}
 }
@@ -0,0 +1,64 @@
+package management
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	policy_grpc "github.com/zitadel/zitadel/internal/api/grpc/policy"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) GetTokenExchangePolicy(ctx context.Context, _ *mgmt_pb.GetTokenExchangePolicyRequest) (*mgmt_pb.GetTokenExchangePolicyResponse, error) {
+	policy, err := s.query.TokenExchangePolicyByOrg(ctx, true, authz.GetCtxData(ctx).OrgID, false)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetTokenExchangePolicyResponse{Policy: policy_grpc.ModelTokenExchangePolicyToPb(policy)}, nil
+}
+
+func (s *Server) GetDefaultTokenExchangePolicy(ctx context.Context, _ *mgmt_pb.GetDefaultTokenExchangePolicyRequest) (*mgmt_pb.GetDefaultTokenExchangePolicyResponse, error) {
+	policy, err := s.query.DefaultTokenExchangePolicy(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetDefaultTokenExchangePolicyResponse{Policy: policy_grpc.ModelTokenExchangePolicyToPb(policy)}, nil
+}
+
+func (s *Server) AddCustomTokenExchangePolicy(ctx context.Context, req *mgmt_pb.AddCustomTokenExchangePolicyRequest) (*mgmt_pb.AddCustomTokenExchangePolicyResponse, error) {
+	result, err := s.command.AddTokenExchangePolicy(ctx, authz.GetCtxData(ctx).OrgID, req.GetDelegationActors(), req.GetImpersonationActors())
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddCustomTokenExchangePolicyResponse{
+		Details: object.AddToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) UpdateCustomTokenExchangePolicy(ctx context.Context, req *mgmt_pb.UpdateCustomTokenExchangePolicyRequest) (*mgmt_pb.UpdateCustomTokenExchangePolicyResponse, error) {
+	result, err := s.command.ChangeTokenExchangePolicy(ctx, authz.GetCtxData(ctx).OrgID, req.GetDelegationActors(), req.GetImpersonationActors())
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.UpdateCustomTokenExchangePolicyResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetTokenExchangePolicyToDefault(ctx context.Context, _ *mgmt_pb.ResetTokenExchangePolicyToDefaultRequest) (*mgmt_pb.ResetTokenExchangePolicyToDefaultResponse, error) {
+	objectDetails, err := s.command.RemoveTokenExchangePolicy(ctx, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ResetTokenExchangePolicyToDefaultResponse{
+		Details: object.DomainToChangeDetailsPb(objectDetails),
+	}, nil
+}
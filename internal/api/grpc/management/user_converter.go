@@ -152,8 +152,9 @@ func ImportHumanUserRequestToDomain(req *mgmt_pb.ImportHumanUserRequest) (human
 		human.Password.ChangeRequired = req.PasswordChangeRequired
 	}
 
-	if req.HashedPassword != nil && req.HashedPassword.Value != "" && req.HashedPassword.Algorithm != "" {
+	if req.HashedPassword != nil && req.HashedPassword.Value != "" {
 		human.HashedPassword = domain.NewHashedPassword(req.HashedPassword.Value, req.HashedPassword.Algorithm)
+		human.HashedPassword.ChangeRequired = req.PasswordChangeRequired
 	}
 	links = make([]*domain.UserIDPLink, len(req.Idps))
 	for i, idp := range req.Idps {
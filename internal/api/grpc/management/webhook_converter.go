@@ -0,0 +1,96 @@
+package management
+
+import (
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	webhook_grpc "github.com/zitadel/zitadel/internal/api/grpc/webhook"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/query"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func addWebhookRequestToDomain(req *mgmt_pb.AddWebhookRequest) *domain.Webhook {
+	return &domain.Webhook{
+		Name:           req.Name,
+		URL:            req.Url,
+		EventTypes:     req.EventTypes,
+		AggregateTypes: req.AggregateTypes,
+	}
+}
+
+func updateWebhookRequestToDomain(req *mgmt_pb.UpdateWebhookRequest) *domain.Webhook {
+	return &domain.Webhook{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: req.Id,
+		},
+		Name:           req.Name,
+		URL:            req.Url,
+		EventTypes:     req.EventTypes,
+		AggregateTypes: req.AggregateTypes,
+	}
+}
+
+func listWebhooksToQuery(orgID string, req *mgmt_pb.ListWebhooksRequest) (_ *query.WebhookSearchQueries, err error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	queries := make([]query.SearchQuery, len(req.Queries)+1)
+	queries[0], err = query.NewWebhookResourceOwnerQuery(orgID)
+	if err != nil {
+		return nil, err
+	}
+	for i, webhookQuery := range req.Queries {
+		queries[i+1], err = WebhookQueryToQuery(webhookQuery.Query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &query.WebhookSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: queries,
+	}, nil
+}
+
+func WebhookQueryToQuery(query interface{}) (query.SearchQuery, error) {
+	switch q := query.(type) {
+	case *mgmt_pb.WebhookQuery_WebhookIdQuery:
+		return webhook_grpc.WebhookIDQuery(q.WebhookIdQuery)
+	case *mgmt_pb.WebhookQuery_WebhookNameQuery:
+		return webhook_grpc.WebhookNameQuery(q.WebhookNameQuery)
+	case *mgmt_pb.WebhookQuery_WebhookStateQuery:
+		return webhook_grpc.WebhookStateQuery(q.WebhookStateQuery)
+	}
+	return nil, errors.ThrowInvalidArgument(nil, "MGMT-Oow4e", "Errors.Query.InvalidRequest")
+}
+
+func listWebhookDeliveriesToQuery(req *mgmt_pb.ListWebhookDeliveriesRequest) (_ *query.WebhookDeliverySearchQueries, err error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	queries := make([]query.SearchQuery, len(req.Queries))
+	for i, deliveryQuery := range req.Queries {
+		queries[i], err = WebhookDeliveryQueryToQuery(deliveryQuery.Query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &query.WebhookDeliverySearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: queries,
+	}, nil
+}
+
+func WebhookDeliveryQueryToQuery(query interface{}) (query.SearchQuery, error) {
+	switch q := query.(type) {
+	case *mgmt_pb.WebhookDeliveryQuery_StateQuery:
+		return webhook_grpc.WebhookDeliveryStateQuery(q.StateQuery)
+	case *mgmt_pb.WebhookDeliveryQuery_EventTypeQuery:
+		return webhook_grpc.WebhookDeliveryEventTypeQuery(q.EventTypeQuery)
+	}
+	return nil, errors.ThrowInvalidArgument(nil, "MGMT-ahG6u", "Errors.Query.InvalidRequest")
+}
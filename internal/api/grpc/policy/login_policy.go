@@ -33,6 +33,11 @@ func ModelLoginPolicyToPb(policy *query.LoginPolicy) *policy_pb.LoginPolicy {
 		SecondFactors:              ModelSecondFactorTypesToPb(policy.SecondFactors),
 		MultiFactors:               ModelMultiFactorTypesToPb(policy.MultiFactors),
 		Idps:                       idp_grpc.IDPLoginPolicyLinksToPb(policy.IDPLinks),
+		RiskRules: &policy_pb.LoginPolicyRiskRules{
+			TrustedIpRanges:    policy.MFATrustedIPRanges,
+			MfaOnNewUserAgent:  policy.MFAOnNewUserAgent,
+			MfaOnCountryChange: policy.MFAOnCountryChange,
+		},
 		Details: &object.ObjectDetails{
 			Sequence:      policy.Sequence,
 			CreationDate:  timestamppb.New(policy.CreationDate),
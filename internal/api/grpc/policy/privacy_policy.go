@@ -1,6 +1,8 @@
 package policy
 
 import (
+	"google.golang.org/protobuf/types/known/durationpb"
+
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/query"
 	policy_pb "github.com/zitadel/zitadel/pkg/grpc/policy"
@@ -8,10 +10,11 @@ import (
 
 func ModelPrivacyPolicyToPb(policy *query.PrivacyPolicy) *policy_pb.PrivacyPolicy {
 	return &policy_pb.PrivacyPolicy{
-		IsDefault:   policy.IsDefault,
-		TosLink:     policy.TOSLink,
-		PrivacyLink: policy.PrivacyLink,
-		HelpLink:    policy.HelpLink,
+		IsDefault:           policy.IsDefault,
+		TosLink:             policy.TOSLink,
+		PrivacyLink:         policy.PrivacyLink,
+		HelpLink:            policy.HelpLink,
+		DeletionGracePeriod: durationpb.New(policy.DeletionGracePeriod),
 		Details: object.ToViewDetailsPb(
 			policy.Sequence,
 			policy.CreationDate,
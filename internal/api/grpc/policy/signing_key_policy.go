@@ -0,0 +1,25 @@
+package policy
+
+import (
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/query"
+	policy_pb "github.com/zitadel/zitadel/pkg/grpc/policy"
+)
+
+func ModelSigningKeyPolicyToPb(policy *query.SigningKeyPolicy) *policy_pb.SigningKeyPolicy {
+	return &policy_pb.SigningKeyPolicy{
+		Algorithm:            policy.Algorithm,
+		KeySize:              int32(policy.KeySize),
+		KeyLifetime:          durationpb.New(policy.KeyLifetime),
+		OverlapPeriod:        durationpb.New(policy.OverlapPeriod),
+		PrepublicationWindow: durationpb.New(policy.PrepublicationWindow),
+		Details: object.ToViewDetailsPb(
+			policy.Sequence,
+			policy.CreationDate,
+			policy.ChangeDate,
+			policy.ResourceOwner,
+		),
+	}
+}
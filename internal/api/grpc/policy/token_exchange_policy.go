@@ -0,0 +1,21 @@
+package policy
+
+import (
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/query"
+	policy_pb "github.com/zitadel/zitadel/pkg/grpc/policy"
+)
+
+func ModelTokenExchangePolicyToPb(policy *query.TokenExchangePolicy) *policy_pb.TokenExchangePolicy {
+	return &policy_pb.TokenExchangePolicy{
+		IsDefault:           policy.IsDefault,
+		DelegationActors:    policy.DelegationActors,
+		ImpersonationActors: policy.ImpersonationActors,
+		Details: object.ToViewDetailsPb(
+			policy.Sequence,
+			policy.CreationDate,
+			policy.ChangeDate,
+			policy.ResourceOwner,
+		),
+	}
+}
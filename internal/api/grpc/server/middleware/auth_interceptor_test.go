@@ -27,6 +27,9 @@ func (v *verifierMock) VerifyAccessToken(ctx context.Context, token, clientID, p
 func (v *verifierMock) SearchMyMemberships(ctx context.Context) ([]*authz.Membership, error) {
 	return nil, nil
 }
+func (v *verifierMock) CustomRoleMappings(ctx context.Context) ([]authz.RoleMapping, error) {
+	return nil, nil
+}
 
 func (v *verifierMock) ProjectIDAndOriginsByClientID(ctx context.Context, clientID string) (string, []string, error) {
 	return "", nil, nil
@@ -0,0 +1,158 @@
+package session
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	object_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/api/ui/login"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+	session_pb "github.com/zitadel/zitadel/pkg/grpc/session"
+)
+
+func (s *Server) CreateSession(ctx context.Context, req *session_pb.CreateSessionRequest) (*session_pb.CreateSessionResponse, error) {
+	checks, err := checksToCommand(req.Checks)
+	if err != nil {
+		return nil, err
+	}
+	changed, err := s.command.CreateSession(ctx, checks)
+	if err != nil {
+		return nil, err
+	}
+	return &session_pb.CreateSessionResponse{
+		Details:           object_grpc.DomainToAddDetailsPb(changed.ObjectDetails),
+		SessionId:         changed.ID,
+		SessionToken:      changed.NewToken,
+		WebauthnChallenge: webAuthNChallengeToPb(changed.WebAuthNChallenge),
+	}, nil
+}
+
+func (s *Server) SetSession(ctx context.Context, req *session_pb.SetSessionRequest) (*session_pb.SetSessionResponse, error) {
+	checks, err := checksToCommand(req.Checks)
+	if err != nil {
+		return nil, err
+	}
+	changed, err := s.command.UpdateSession(ctx, req.SessionId, req.SessionToken, checks)
+	if err != nil {
+		return nil, err
+	}
+	return &session_pb.SetSessionResponse{
+		Details:           object_grpc.DomainToChangeDetailsPb(changed.ObjectDetails),
+		SessionToken:      changed.NewToken,
+		WebauthnChallenge: webAuthNChallengeToPb(changed.WebAuthNChallenge),
+	}, nil
+}
+
+func (s *Server) GetSession(ctx context.Context, req *session_pb.GetSessionRequest) (*session_pb.GetSessionResponse, error) {
+	session, err := s.query.SessionByID(ctx, true, req.SessionId)
+	if err != nil {
+		return nil, err
+	}
+	if err = domain.VerifySessionToken(req.SessionToken, session.ID, session.TokenID, s.userCodeAlg); err != nil {
+		return nil, err
+	}
+	return &session_pb.GetSessionResponse{
+		Session: sessionToPb(session),
+	}, nil
+}
+
+func (s *Server) DeleteSession(ctx context.Context, req *session_pb.DeleteSessionRequest) (*session_pb.DeleteSessionResponse, error) {
+	details, err := s.command.TerminateSession(ctx, req.SessionId, req.SessionToken)
+	if err != nil {
+		return nil, err
+	}
+	return &session_pb.DeleteSessionResponse{
+		Details: object_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) StartIdentityProviderFlow(ctx context.Context, req *session_pb.StartIdentityProviderFlowRequest) (*session_pb.StartIdentityProviderFlowResponse, error) {
+	intent, details, err := s.command.StartSessionIDPFlow(ctx, req.SessionId, req.SessionToken, req.IdpId, req.SuccessUrl, req.FailureUrl)
+	if err != nil {
+		return nil, err
+	}
+	origin := http.BuildOrigin(authz.GetInstance(ctx).RequestedHost(), s.externalSecure)
+	return &session_pb.StartIdentityProviderFlowResponse{
+		Details: object_grpc.DomainToChangeDetailsPb(details),
+		AuthUrl: origin + login.HandlerPrefix + login.EndpointSessionExternalLogin + "?" + login.QueryIntent + "=" + url.QueryEscape(intent),
+	}, nil
+}
+
+func (s *Server) LinkSessionToAuthRequest(ctx context.Context, req *session_pb.LinkSessionToAuthRequestRequest) (*session_pb.LinkSessionToAuthRequestResponse, error) {
+	authReq, err := s.repo.LinkSessionToAuthRequest(ctx, req.AuthRequestId, req.SessionId, req.SessionToken)
+	if err != nil {
+		return nil, err
+	}
+	var callback string
+	switch authReq.Request.(type) {
+	case *domain.AuthRequestOIDC:
+		callback = s.oidcAuthCallbackURL(ctx, authReq.ID)
+	case *domain.AuthRequestSAML:
+		callback = s.samlAuthCallbackURL(ctx, authReq.ID)
+	default:
+		return nil, caos_errs.ThrowInvalidArgument(nil, "SESSION-Sfg3a", "Errors.Session.AuthRequest.TypeNotSupported")
+	}
+	return &session_pb.LinkSessionToAuthRequestResponse{
+		CallbackUrl: callback,
+	}, nil
+}
+
+func checksToCommand(checks *session_pb.Checks) (*command.SessionChecks, error) {
+	if checks == nil {
+		return nil, nil
+	}
+	sessionChecks := &command.SessionChecks{
+		UserID:            checks.UserId,
+		Password:          checks.Password,
+		OTPCode:           checks.OtpCode,
+		WebAuthNChallenge: checks.RequestWebauthnChallenge,
+	}
+	if checks.Webauthn != nil {
+		assertion, err := protojson.Marshal(checks.Webauthn)
+		if err != nil {
+			return nil, caos_errs.ThrowInvalidArgument(err, "SESSION-Dg3gs", "Errors.User.WebAuthN.ValidateLoginFailed")
+		}
+		sessionChecks.WebAuthN = assertion
+	}
+	return sessionChecks, nil
+}
+
+func webAuthNChallengeToPb(challenge *domain.WebAuthNLogin) *session_pb.WebAuthNChallenge {
+	if challenge == nil {
+		return nil
+	}
+	return &session_pb.WebAuthNChallenge{
+		PublicKeyCredentialRequestOptions: challenge.CredentialAssertionData,
+	}
+}
+
+func sessionToPb(session *query.Session) *session_pb.Session {
+	return &session_pb.Session{
+		Id:                session.ID,
+		Details:           object_grpc.ToViewDetailsPb(session.Sequence, session.CreationDate, session.ChangeDate, session.ResourceOwner),
+		UserId:            session.UserID,
+		UserResourceOwner: session.UserResourceOwner,
+		UserCheckedAt:     timestampToPb(session.UserCheckedAt),
+		PasswordCheckedAt: timestampToPb(session.PasswordCheckedAt),
+		OtpCheckedAt:      timestampToPb(session.OTPCheckedAt),
+		WebauthnCheckedAt: timestampToPb(session.WebAuthNCheckedAt),
+		IdpId:             session.IDPID,
+		IdpCheckedAt:      timestampToPb(session.IDPCheckedAt),
+	}
+}
+
+func timestampToPb(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
@@ -185,9 +185,22 @@ func AccessTokenTypeToPb(accessTokenType domain.OIDCTokenType) user_pb.AccessTok
 }
 
 func AuthMethodsToPb(mfas *query.AuthMethods) []*user_pb.AuthFactor {
-	factors := make([]*user_pb.AuthFactor, len(mfas.AuthMethods))
-	for i, mfa := range mfas.AuthMethods {
-		factors[i] = AuthMethodToPb(mfa)
+	factors := make([]*user_pb.AuthFactor, 0, len(mfas.AuthMethods))
+	var recoveryCodes *user_pb.AuthFactorRecoveryCodes
+	for _, mfa := range mfas.AuthMethods {
+		// every unused recovery code is stored separately, they are returned as one factor
+		if mfa.Type == domain.UserAuthMethodTypeRecoveryCode {
+			if recoveryCodes == nil {
+				recoveryCodes = new(user_pb.AuthFactorRecoveryCodes)
+				factors = append(factors, &user_pb.AuthFactor{
+					State: MFAStateToPb(mfa.State),
+					Type:  &user_pb.AuthFactor_RecoveryCodes{RecoveryCodes: recoveryCodes},
+				})
+			}
+			recoveryCodes.CodesLeft++
+			continue
+		}
+		factors = append(factors, AuthMethodToPb(mfa))
 	}
 	return factors
 }
@@ -208,6 +221,14 @@ func AuthMethodToPb(mfa *query.AuthMethod) *user_pb.AuthFactor {
 				Name: mfa.Name,
 			},
 		}
+	case domain.UserAuthMethodTypeOTPSMS:
+		factor.Type = &user_pb.AuthFactor_OtpSms{
+			OtpSms: &user_pb.AuthFactorOTPSMS{},
+		}
+	case domain.UserAuthMethodTypeOTPEmail:
+		factor.Type = &user_pb.AuthFactor_OtpEmail{
+			OtpEmail: &user_pb.AuthFactorOTPEmail{},
+		}
 	}
 	return factor
 }
@@ -0,0 +1,123 @@
+package webhook
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	object_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	webhook_pb "github.com/zitadel/zitadel/pkg/grpc/webhook"
+)
+
+func WebhooksToPb(webhooks []*query.Webhook) []*webhook_pb.Webhook {
+	list := make([]*webhook_pb.Webhook, len(webhooks))
+	for i, webhook := range webhooks {
+		list[i] = WebhookToPb(webhook)
+	}
+	return list
+}
+
+func WebhookToPb(webhook *query.Webhook) *webhook_pb.Webhook {
+	return &webhook_pb.Webhook{
+		Id:             webhook.ID,
+		Details:        object_grpc.ToViewDetailsPb(webhook.Sequence, webhook.CreationDate, webhook.ChangeDate, webhook.ResourceOwner),
+		State:          WebhookStateToPb(webhook.State),
+		Name:           webhook.Name,
+		Url:            webhook.URL,
+		EventTypes:     webhook.EventTypes,
+		AggregateTypes: webhook.AggregateTypes,
+	}
+}
+
+func WebhookStateToPb(state domain.WebhookState) webhook_pb.WebhookState {
+	switch state {
+	case domain.WebhookStateActive:
+		return webhook_pb.WebhookState_WEBHOOK_STATE_ACTIVE
+	case domain.WebhookStateInactive:
+		return webhook_pb.WebhookState_WEBHOOK_STATE_INACTIVE
+	default:
+		return webhook_pb.WebhookState_WEBHOOK_STATE_UNSPECIFIED
+	}
+}
+
+func WebhookStateToDomain(state webhook_pb.WebhookState) domain.WebhookState {
+	switch state {
+	case webhook_pb.WebhookState_WEBHOOK_STATE_ACTIVE:
+		return domain.WebhookStateActive
+	case webhook_pb.WebhookState_WEBHOOK_STATE_INACTIVE:
+		return domain.WebhookStateInactive
+	default:
+		return domain.WebhookStateUnspecified
+	}
+}
+
+func WebhookIDQuery(q *webhook_pb.WebhookIDQuery) (query.SearchQuery, error) {
+	return query.NewWebhookIDSearchQuery(q.Id)
+}
+
+func WebhookNameQuery(q *webhook_pb.WebhookNameQuery) (query.SearchQuery, error) {
+	return query.NewWebhookNameSearchQuery(object_grpc.TextMethodToQuery(q.Method), q.Name)
+}
+
+func WebhookStateQuery(q *webhook_pb.WebhookStateQuery) (query.SearchQuery, error) {
+	return query.NewWebhookStateSearchQuery(WebhookStateToDomain(q.State))
+}
+
+func WebhookDeliveriesToPb(deliveries []*query.WebhookDelivery) []*webhook_pb.WebhookDelivery {
+	list := make([]*webhook_pb.WebhookDelivery, len(deliveries))
+	for i, delivery := range deliveries {
+		list[i] = WebhookDeliveryToPb(delivery)
+	}
+	return list
+}
+
+func WebhookDeliveryToPb(delivery *query.WebhookDelivery) *webhook_pb.WebhookDelivery {
+	return &webhook_pb.WebhookDelivery{
+		WebhookId:         delivery.WebhookID,
+		EventSequence:     delivery.EventSequence,
+		AggregateType:     delivery.AggregateType,
+		AggregateId:       delivery.AggregateID,
+		EventType:         delivery.EventType,
+		EventCreationDate: timestamppb.New(delivery.EventCreationDate),
+		CreationDate:      timestamppb.New(delivery.CreationDate),
+		ChangeDate:        timestamppb.New(delivery.ChangeDate),
+		State:             WebhookDeliveryStateToPb(delivery.State),
+		Attempts:          delivery.Attempts,
+		StatusCode:        int32(delivery.StatusCode),
+		LastError:         delivery.LastError,
+	}
+}
+
+func WebhookDeliveryStateToPb(state domain.WebhookDeliveryState) webhook_pb.WebhookDeliveryState {
+	switch state {
+	case domain.WebhookDeliveryStateDelivered:
+		return webhook_pb.WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_DELIVERED
+	case domain.WebhookDeliveryStateFailed:
+		return webhook_pb.WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_FAILED
+	case domain.WebhookDeliveryStatePending:
+		return webhook_pb.WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_PENDING
+	default:
+		return webhook_pb.WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_UNSPECIFIED
+	}
+}
+
+func WebhookDeliveryStateToDomain(state webhook_pb.WebhookDeliveryState) domain.WebhookDeliveryState {
+	switch state {
+	case webhook_pb.WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_DELIVERED:
+		return domain.WebhookDeliveryStateDelivered
+	case webhook_pb.WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_FAILED:
+		return domain.WebhookDeliveryStateFailed
+	case webhook_pb.WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_PENDING:
+		return domain.WebhookDeliveryStatePending
+	default:
+		return domain.WebhookDeliveryStateUnspecified
+	}
+}
+
+func WebhookDeliveryStateQuery(q *webhook_pb.WebhookDeliveryStateQuery) (query.SearchQuery, error) {
+	return query.NewWebhookDeliveryStateSearchQuery(WebhookDeliveryStateToDomain(q.State))
+}
+
+func WebhookDeliveryEventTypeQuery(q *webhook_pb.WebhookDeliveryEventTypeQuery) (query.SearchQuery, error) {
+	return query.NewWebhookDeliveryEventTypeSearchQuery(object_grpc.TextMethodToQuery(q.Method), q.EventType)
+}
@@ -5,6 +5,9 @@ import (
 	"net"
 	"net/http"
 	"strings"
+	"sync"
+
+	"github.com/zitadel/logging"
 )
 
 const (
@@ -68,29 +71,20 @@ func OriginFromCtx(ctx context.Context) string {
 	return headers.Get(Origin)
 }
 
+// RemoteIPFromCtx returns the IP of the client of the request the context was created for,
+// see [ClientIP]
 func RemoteIPFromCtx(ctx context.Context) string {
-	ctxHeaders, ok := HeadersFromCtx(ctx)
-	if !ok {
-		return RemoteAddrFromCtx(ctx)
-	}
-	forwarded, ok := GetForwardedFor(ctxHeaders)
-	if ok {
-		return forwarded
-	}
-	return RemoteAddrFromCtx(ctx)
+	ctxHeaders, _ := HeadersFromCtx(ctx)
+	return ClientIP(RemoteAddrFromCtx(ctx), ctxHeaders)
 }
 
 func RemoteIPFromRequest(r *http.Request) net.IP {
 	return net.ParseIP(RemoteIPStringFromRequest(r))
 }
 
+// RemoteIPStringFromRequest returns the IP of the client of the request, see [ClientIP]
 func RemoteIPStringFromRequest(r *http.Request) string {
-	ip, ok := GetForwardedFor(r.Header)
-	if ok {
-		return ip
-	}
-	host, _, _ := net.SplitHostPort(r.RemoteAddr)
-	return host
+	return ClientIP(r.RemoteAddr, r.Header)
 }
 
 func GetAuthorization(r *http.Request) string {
@@ -101,15 +95,86 @@ func GetOrgID(r *http.Request) string {
 	return r.Header.Get(ZitadelOrgID)
 }
 
-func GetForwardedFor(headers http.Header) (string, bool) {
-	forwarded, ok := headers[ForwardedFor]
-	if ok {
-		ip := strings.TrimSpace(strings.Split(forwarded[0], ",")[0])
-		if ip != "" {
-			return ip, true
+// ClientIP returns the IP of the client.
+// The X-Forwarded-For header is only used, if the request was received from a trusted proxy (see [SetTrustedProxies]).
+// Its entries are then evaluated from right to left and the first one, which is not a trusted proxy, is returned,
+// so entries set by the client itself are never used.
+func ClientIP(remoteAddr string, headers http.Header) string {
+	ip, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		ip = remoteAddr
+	}
+	if !isTrustedProxy(net.ParseIP(ip)) {
+		warnForwardedForIgnored(headers)
+		return ip
+	}
+	hops := forwardedFor(headers)
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := net.ParseIP(hops[i])
+		if hop == nil {
+			// the entries left of an invalid one cannot be trusted
+			return ip
+		}
+		ip = hops[i]
+		if !isTrustedProxy(hop) {
+			return ip
+		}
+	}
+	return ip
+}
+
+// forwardedFor returns all entries of all X-Forwarded-For headers in the order they were added
+func forwardedFor(headers http.Header) []string {
+	hops := make([]string, 0)
+	for _, value := range headers.Values(ForwardedFor) {
+		for _, hop := range strings.Split(value, ",") {
+			hops = append(hops, strings.TrimSpace(hop))
+		}
+	}
+	return hops
+}
+
+var (
+	trustedProxies          []*net.IPNet
+	forwardedForIgnoredOnce sync.Once
+)
+
+// warnForwardedForIgnored logs once, if requests with an X-Forwarded-For header are received,
+// but no trusted proxies are configured, as ZITADEL is then probably run behind a proxy using its IP as client IP
+func warnForwardedForIgnored(headers http.Header) {
+	if len(trustedProxies) > 0 || headers.Get(ForwardedFor) == "" {
+		return
+	}
+	forwardedForIgnoredOnce.Do(func() {
+		logging.Warn("received request with X-Forwarded-For header, but no TrustedProxies are configured: the header is ignored and the IP of the proxy is used as client IP")
+	})
+}
+
+// SetTrustedProxies sets the IP ranges (CIDR) of the reverse proxies and load balancers in front of ZITADEL,
+// which are trusted to set the X-Forwarded-For header
+func SetTrustedProxies(cidrs []string) error {
+	proxies := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return err
+		}
+		proxies[i] = network
+	}
+	trustedProxies = proxies
+	return nil
+}
+
+func isTrustedProxy(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	for _, network := range trustedProxies {
+		if network.Contains(ip) {
+			return true
 		}
 	}
-	return "", false
+	return false
 }
 
 func RemoteAddrFromCtx(ctx context.Context) string {
@@ -0,0 +1,90 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientIP(t *testing.T) {
+	require.NoError(t, SetTrustedProxies([]string{"10.0.0.0/8"}))
+	t.Cleanup(func() {
+		trustedProxies = nil
+	})
+	type args struct {
+		remoteAddr    string
+		forwardedFors []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "no proxy",
+			args: args{
+				remoteAddr: "203.0.113.1:1234",
+			},
+			want: "203.0.113.1",
+		},
+		{
+			name: "untrusted remote addr, header ignored",
+			args: args{
+				remoteAddr:    "203.0.113.1:1234",
+				forwardedFors: []string{"198.51.100.1"},
+			},
+			want: "203.0.113.1",
+		},
+		{
+			name: "trusted proxy",
+			args: args{
+				remoteAddr:    "10.0.0.1:1234",
+				forwardedFors: []string{"198.51.100.1"},
+			},
+			want: "198.51.100.1",
+		},
+		{
+			name: "trusted proxy, spoofed entry of the client ignored",
+			args: args{
+				remoteAddr:    "10.0.0.1:1234",
+				forwardedFors: []string{"192.0.2.1, 198.51.100.1"},
+			},
+			want: "198.51.100.1",
+		},
+		{
+			name: "multiple trusted proxies and headers",
+			args: args{
+				remoteAddr:    "10.0.0.1:1234",
+				forwardedFors: []string{"192.0.2.1, 198.51.100.1", "10.0.0.2"},
+			},
+			want: "198.51.100.1",
+		},
+		{
+			name: "invalid entry, last valid hop",
+			args: args{
+				remoteAddr:    "10.0.0.1:1234",
+				forwardedFors: []string{"198.51.100.1, invalid, 10.0.0.2"},
+			},
+			want: "10.0.0.2",
+		},
+		{
+			name: "only trusted proxies",
+			args: args{
+				remoteAddr:    "10.0.0.1:1234",
+				forwardedFors: []string{"10.0.0.3, 10.0.0.2"},
+			},
+			want: "10.0.0.3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := make(http.Header)
+			for _, forwardedFor := range tt.args.forwardedFors {
+				headers.Add(ForwardedFor, forwardedFor)
+			}
+			assert.Equal(t, tt.want, ClientIP(tt.args.remoteAddr, headers))
+		})
+	}
+}
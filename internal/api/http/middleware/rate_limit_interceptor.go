@@ -0,0 +1,196 @@
+package middleware
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+)
+
+type RateLimitConfig struct {
+	// Limit is the maximum number of requests per remote IP in the window, 0 disables the limit
+	Limit uint64
+	// Window is the duration of the sliding window
+	Window time.Duration
+	// MaxKeys is the maximum number of remote IPs counted at the same time, 0 uses the default of 100000.
+	// Requests of further remote IPs share a single counter until the counters of others expire.
+	MaxKeys uint64
+}
+
+const defaultRateLimitMaxKeys = 100000
+
+func (c RateLimitConfig) enabled() bool {
+	return c.Limit > 0 && c.Window > 0
+}
+
+// RateLimitFilter returns true if the request counts against the limit
+type RateLimitFilter func(r *http.Request) bool
+
+// RateLimitMethods limits only requests with one of the methods
+func RateLimitMethods(methods ...string) RateLimitFilter {
+	return func(r *http.Request) bool {
+		for _, method := range methods {
+			if r.Method == method {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// RateLimitPaths limits only requests to one of the paths
+func RateLimitPaths(paths ...string) RateLimitFilter {
+	return func(r *http.Request) bool {
+		for _, path := range paths {
+			if r.URL.Path == path {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+type rateLimitInterceptor struct {
+	limiter *slidingWindowLimiter
+	filters []RateLimitFilter
+}
+
+// RateLimitHandler limits the requests per instance and remote IP using an in memory sliding window.
+// Requests exceeding the limit are answered with 429 Too Many Requests.
+// If filters are passed, only requests matching all of them are counted.
+func RateLimitHandler(config RateLimitConfig, filters ...RateLimitFilter) func(http.Handler) http.Handler {
+	if !config.enabled() {
+		return func(next http.Handler) http.Handler {
+			return next
+		}
+	}
+	interceptor := &rateLimitInterceptor{
+		limiter: newSlidingWindowLimiter(config.Limit, config.Window, config.MaxKeys, time.Now),
+		filters: filters,
+	}
+	return interceptor.Handler
+}
+
+func (i *rateLimitInterceptor) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, filter := range i.filters {
+			if !filter(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+		key := authz.GetInstance(r.Context()).InstanceID() + "|" + rateLimitIP(http_utils.ClientIP(r.RemoteAddr, r.Header))
+		if allowed, retryAfter := i.limiter.allow(key); !allowed {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// rateLimitIP returns the IPv6 /64 network of the client, as it's usually assigned to a single client,
+// or the IP itself otherwise
+func rateLimitIP(ip string) string {
+	parsed := net.ParseIP(ip)
+	if parsed == nil || parsed.To4() != nil {
+		return ip
+	}
+	return parsed.Mask(net.CIDRMask(64, 128)).String()
+}
+
+// slidingWindowLimiter approximates a sliding window by weighting the count of the previous fixed window
+// with its overlap of the sliding window
+type slidingWindowLimiter struct {
+	limit   uint64
+	window  time.Duration
+	maxKeys int
+	now     func() time.Time
+
+	mu          sync.Mutex
+	counters    map[string]*windowCounter
+	overflow    *windowCounter
+	lastCleanup time.Time
+}
+
+type windowCounter struct {
+	start    time.Time
+	current  uint64
+	previous uint64
+}
+
+func newSlidingWindowLimiter(limit uint64, window time.Duration, maxKeys uint64, now func() time.Time) *slidingWindowLimiter {
+	if maxKeys == 0 {
+		maxKeys = defaultRateLimitMaxKeys
+	}
+	return &slidingWindowLimiter{
+		limit:       limit,
+		window:      window,
+		maxKeys:     int(maxKeys),
+		now:         now,
+		counters:    make(map[string]*windowCounter),
+		overflow:    &windowCounter{start: now().Truncate(window)},
+		lastCleanup: now(),
+	}
+}
+
+// allow counts the request of the key and returns false and the duration until the next request is allowed
+// if the limit is exceeded
+func (l *slidingWindowLimiter) allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.cleanup(now)
+
+	windowStart := now.Truncate(l.window)
+	counter, ok := l.counters[key]
+	if !ok {
+		counter = l.overflow
+		// the memory is bounded, so further keys share the overflow counter until the cleanup removed expired counters
+		if len(l.counters) < l.maxKeys {
+			counter = &windowCounter{start: windowStart}
+			l.counters[key] = counter
+		}
+	}
+	counter.shift(windowStart, l.window)
+
+	elapsed := now.Sub(windowStart)
+	previousWeight := float64(l.window-elapsed) / float64(l.window)
+	if float64(counter.previous)*previousWeight+float64(counter.current) >= float64(l.limit) {
+		return false, l.window - elapsed
+	}
+	counter.current++
+	return true, 0
+}
+
+func (c *windowCounter) shift(windowStart time.Time, window time.Duration) {
+	switch windowStart.Sub(c.start) {
+	case 0:
+		return
+	case window:
+		c.previous = c.current
+	default:
+		c.previous = 0
+	}
+	c.current = 0
+	c.start = windowStart
+}
+
+// cleanup removes the counters which are no longer relevant for the sliding window
+func (l *slidingWindowLimiter) cleanup(now time.Time) {
+	if now.Sub(l.lastCleanup) < l.window {
+		return
+	}
+	l.lastCleanup = now
+	for key, counter := range l.counters {
+		if now.Sub(counter.start) >= 2*l.window {
+			delete(l.counters, key)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_slidingWindowLimiter_allow(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	type request struct {
+		key            string
+		offset         time.Duration
+		wantAllowed    bool
+		wantRetryAfter time.Duration
+	}
+	tests := []struct {
+		name     string
+		maxKeys  uint64
+		requests []request
+	}{
+		{
+			name: "within limit",
+			requests: []request{
+				{key: "ip1", offset: 0, wantAllowed: true},
+				{key: "ip1", offset: time.Second, wantAllowed: true},
+			},
+		},
+		{
+			name: "limit exceeded",
+			requests: []request{
+				{key: "ip1", offset: 0, wantAllowed: true},
+				{key: "ip1", offset: time.Second, wantAllowed: true},
+				{key: "ip1", offset: 2 * time.Second, wantAllowed: false, wantRetryAfter: 8 * time.Second},
+			},
+		},
+		{
+			name: "keys counted separately",
+			requests: []request{
+				{key: "ip1", offset: 0, wantAllowed: true},
+				{key: "ip1", offset: time.Second, wantAllowed: true},
+				{key: "ip2", offset: 2 * time.Second, wantAllowed: true},
+			},
+		},
+		{
+			name: "previous window weighted",
+			requests: []request{
+				{key: "ip1", offset: 0, wantAllowed: true},
+				{key: "ip1", offset: time.Second, wantAllowed: true},
+				{key: "ip1", offset: 12 * time.Second, wantAllowed: true},
+				{key: "ip1", offset: 13 * time.Second, wantAllowed: false, wantRetryAfter: 7 * time.Second},
+				{key: "ip1", offset: 18 * time.Second, wantAllowed: true},
+			},
+		},
+		{
+			name: "previous window expired",
+			requests: []request{
+				{key: "ip1", offset: 0, wantAllowed: true},
+				{key: "ip1", offset: time.Second, wantAllowed: true},
+				{key: "ip1", offset: 20 * time.Second, wantAllowed: true},
+				{key: "ip1", offset: 21 * time.Second, wantAllowed: true},
+			},
+		},
+		{
+			name:    "max keys reached, further keys share a counter",
+			maxKeys: 1,
+			requests: []request{
+				{key: "ip1", offset: 0, wantAllowed: true},
+				{key: "ip2", offset: time.Second, wantAllowed: true},
+				{key: "ip3", offset: 2 * time.Second, wantAllowed: true},
+				{key: "ip2", offset: 3 * time.Second, wantAllowed: false, wantRetryAfter: 7 * time.Second},
+				{key: "ip3", offset: 3 * time.Second, wantAllowed: false, wantRetryAfter: 7 * time.Second},
+				{key: "ip1", offset: 4 * time.Second, wantAllowed: true},
+				{key: "ip1", offset: 5 * time.Second, wantAllowed: false, wantRetryAfter: 5 * time.Second},
+			},
+		},
+		{
+			name:    "max keys reached, expired counters removed",
+			maxKeys: 1,
+			requests: []request{
+				{key: "ip1", offset: 0, wantAllowed: true},
+				{key: "ip2", offset: 20 * time.Second, wantAllowed: true},
+				{key: "ip2", offset: 21 * time.Second, wantAllowed: true},
+				{key: "ip2", offset: 22 * time.Second, wantAllowed: false, wantRetryAfter: 8 * time.Second},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := start
+			l := newSlidingWindowLimiter(2, 10*time.Second, tt.maxKeys, func() time.Time { return now })
+			for _, req := range tt.requests {
+				now = start.Add(req.offset)
+				allowed, retryAfter := l.allow(req.key)
+				assert.Equal(t, req.wantAllowed, allowed)
+				assert.Equal(t, req.wantRetryAfter, retryAfter)
+			}
+		})
+	}
+}
+
+func TestRateLimitHandler(t *testing.T) {
+	handler := RateLimitHandler(RateLimitConfig{Limit: 1, Window: time.Minute}, RateLimitMethods(http.MethodPost))(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+	)
+	tests := []struct {
+		name           string
+		method         string
+		wantStatus     int
+		wantRetryAfter bool
+	}{
+		{
+			name:       "first post allowed",
+			method:     http.MethodPost,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "get not limited",
+			method:     http.MethodGet,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:           "second post limited",
+			method:         http.MethodPost,
+			wantStatus:     http.StatusTooManyRequests,
+			wantRetryAfter: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, "/login", nil)
+			req.RemoteAddr = "10.0.0.1:1234"
+			handler.ServeHTTP(recorder, req)
+			assert.Equal(t, tt.wantStatus, recorder.Code)
+			assert.Equal(t, tt.wantRetryAfter, recorder.Header().Get("Retry-After") != "")
+		})
+	}
+}
+
+func TestRateLimitHandler_disabled(t *testing.T) {
+	handler := RateLimitHandler(RateLimitConfig{})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+	)
+	for i := 0; i < 3; i++ {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/login", nil))
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	}
+}
+
+func Test_rateLimitIP(t *testing.T) {
+	tests := []struct {
+		name string
+		ip   string
+		want string
+	}{
+		{
+			name: "ipv4",
+			ip:   "198.51.100.1",
+			want: "198.51.100.1",
+		},
+		{
+			name: "ipv6 network",
+			ip:   "2001:db8:1:2:3:4:5:6",
+			want: "2001:db8:1:2::",
+		},
+		{
+			name: "invalid",
+			ip:   "invalid",
+			want: "invalid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, rateLimitIP(tt.ip))
+		})
+	}
+}
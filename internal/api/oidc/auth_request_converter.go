@@ -23,6 +23,7 @@ const (
 	amrPWD          = "pwd"
 	amrMFA          = "mfa"
 	amrOTP          = "otp"
+	amrSMS          = "sms"
 	amrUserPresence = "user"
 )
 
@@ -88,6 +89,9 @@ func (a *AuthRequest) GetResponseMode() oidc.ResponseMode {
 }
 
 func (a *AuthRequest) GetScopes() []string {
+	if device, ok := a.Request.(*domain.AuthRequestDevice); ok {
+		return device.Scopes
+	}
 	return a.oidc().Scopes
 }
 
@@ -109,7 +113,12 @@ func (a *AuthRequest) Done() bool {
 }
 
 func (a *AuthRequest) oidc() *domain.AuthRequestOIDC {
-	return a.Request.(*domain.AuthRequestOIDC)
+	if oidcRequest, ok := a.Request.(*domain.AuthRequestOIDC); ok {
+		return oidcRequest
+	}
+	// requests of the device authorization grant have no oidc specific parameters
+	// and behave like a code flow without PKCE and nonce
+	return &domain.AuthRequestOIDC{ResponseType: domain.OIDCResponseTypeCode}
 }
 
 func AuthRequestFromBusiness(authReq *domain.AuthRequest) (_ op.AuthRequest, err error) {
@@ -279,8 +288,12 @@ func CodeChallengeToOIDC(challenge *domain.OIDCCodeChallenge) *oidc.CodeChalleng
 
 func AMRFromMFAType(mfaType domain.MFAType) string {
 	switch mfaType {
-	case domain.MFATypeOTP:
+	case domain.MFATypeOTP,
+		domain.MFATypeOTPEmail,
+		domain.MFATypeRecoveryCode:
 		return amrOTP
+	case domain.MFATypeOTPSMS:
+		return amrSMS
 	case domain.MFATypeU2F,
 		domain.MFATypeU2FUserVerification:
 		return amrUserPresence
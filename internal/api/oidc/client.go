@@ -3,18 +3,15 @@ package oidc
 import (
 	"context"
 	"encoding/base64"
-	"encoding/json"
 	"fmt"
 	"strings"
 
-	"github.com/dop251/goja"
-	"github.com/zitadel/logging"
 	"github.com/zitadel/oidc/v2/pkg/oidc"
 	"github.com/zitadel/oidc/v2/pkg/op"
 	"gopkg.in/square/go-jose.v2"
 
 	"github.com/zitadel/zitadel/internal/actions"
-	"github.com/zitadel/zitadel/internal/actions/object"
+	"github.com/zitadel/zitadel/internal/actions/trigger"
 	"github.com/zitadel/zitadel/internal/api/authz"
 	api_http "github.com/zitadel/zitadel/internal/api/http"
 	"github.com/zitadel/zitadel/internal/crypto"
@@ -181,6 +178,9 @@ func (o *OPStorage) SetIntrospectionFromToken(ctx context.Context, introspection
 			introspection.SetAudience(token.Audience)
 			introspection.SetIssuer(op.IssuerFromContext(ctx))
 			introspection.SetJWTID(token.ID)
+			if token.Actor != nil {
+				introspection.AppendClaims(ClaimActor, actorClaim(token.Actor))
+			}
 			return nil
 		}
 	}
@@ -348,31 +348,7 @@ func (o *OPStorage) userinfoFlows(ctx context.Context, resourceOwner string, use
 	}
 
 	ctxFields := actions.SetContextFields(
-		actions.SetFields("v1",
-			actions.SetFields("user",
-				actions.SetFields("getMetadata", func(c *actions.FieldConfig) interface{} {
-					return func(goja.FunctionCall) goja.Value {
-						resourceOwnerQuery, err := query.NewUserMetadataResourceOwnerSearchQuery(resourceOwner)
-						if err != nil {
-							logging.WithError(err).Debug("unable to create search query")
-							panic(err)
-						}
-						metadata, err := o.query.SearchUserMetadata(
-							ctx,
-							true,
-							userInfo.GetSubject(),
-							&query.UserMetadataSearchQueries{Queries: []query.SearchQuery{resourceOwnerQuery}},
-							false,
-						)
-						if err != nil {
-							logging.WithError(err).Info("unable to get md in action")
-							panic(err)
-						}
-						return object.UserMetadataListFromQuery(c, metadata)
-					}
-				}),
-			),
-		),
+		trigger.CustomiseTokenContextFields(o.userMetadataGetter(ctx, userInfo.GetSubject(), resourceOwner))...,
 	)
 
 	for _, action := range queriedActions {
@@ -380,45 +356,7 @@ func (o *OPStorage) userinfoFlows(ctx context.Context, resourceOwner string, use
 		claimLogs := []string{}
 
 		apiFields := actions.WithAPIFields(
-			actions.SetFields("v1",
-				actions.SetFields("userinfo",
-					actions.SetFields("setClaim", func(key string, value interface{}) {
-						if userInfo.GetClaim(key) == nil {
-							userInfo.AppendClaims(key, value)
-							return
-						}
-						claimLogs = append(claimLogs, fmt.Sprintf("key %q already exists", key))
-					}),
-					actions.SetFields("appendLogIntoClaims", func(entry string) {
-						claimLogs = append(claimLogs, entry)
-					}),
-				),
-				actions.SetFields("user",
-					actions.SetFields("setMetadata", func(call goja.FunctionCall) goja.Value {
-						if len(call.Arguments) != 2 {
-							panic("exactly 2 (key, value) arguments expected")
-						}
-						key := call.Arguments[0].Export().(string)
-						val := call.Arguments[1].Export()
-
-						value, err := json.Marshal(val)
-						if err != nil {
-							logging.WithError(err).Debug("unable to marshal")
-							panic(err)
-						}
-
-						metadata := &domain.Metadata{
-							Key:   key,
-							Value: value,
-						}
-						if _, err = o.command.SetUserMetadata(ctx, metadata, userInfo.GetSubject(), resourceOwner); err != nil {
-							logging.WithError(err).Info("unable to set md in action")
-							panic(err)
-						}
-						return nil
-					}),
-				),
-			),
+			trigger.PreUserinfoCreationAPIFields(userInfo, &claimLogs, o.metadataSetter(ctx, userInfo.GetSubject(), resourceOwner))...,
 		)
 
 		err = actions.Run(
@@ -503,33 +441,15 @@ func (o *OPStorage) privateClaimsFlows(ctx context.Context, userID string, claim
 	if err != nil {
 		return nil, err
 	}
+	if len(queriedActions) == 0 {
+		return claims, nil
+	}
+	if claims == nil {
+		claims = make(map[string]interface{})
+	}
 
 	ctxFields := actions.SetContextFields(
-		actions.SetFields("v1",
-			actions.SetFields("user",
-				actions.SetFields("getMetadata", func(c *actions.FieldConfig) interface{} {
-					return func(goja.FunctionCall) goja.Value {
-						resourceOwnerQuery, err := query.NewUserMetadataResourceOwnerSearchQuery(user.ResourceOwner)
-						if err != nil {
-							logging.WithError(err).Debug("unable to create search query")
-							panic(err)
-						}
-						metadata, err := o.query.SearchUserMetadata(
-							ctx,
-							true,
-							userID,
-							&query.UserMetadataSearchQueries{Queries: []query.SearchQuery{resourceOwnerQuery}},
-							false,
-						)
-						if err != nil {
-							logging.WithError(err).Info("unable to get md in action")
-							panic(err)
-						}
-						return object.UserMetadataListFromQuery(c, metadata)
-					}
-				}),
-			),
-		),
+		trigger.CustomiseTokenContextFields(o.userMetadataGetter(ctx, userID, user.ResourceOwner))...,
 	)
 
 	for _, action := range queriedActions {
@@ -537,44 +457,7 @@ func (o *OPStorage) privateClaimsFlows(ctx context.Context, userID string, claim
 		actionCtx, cancel := context.WithTimeout(ctx, action.Timeout())
 
 		apiFields := actions.WithAPIFields(
-			actions.SetFields("v1",
-				actions.SetFields("claims",
-					actions.SetFields("setClaim", func(key string, value interface{}) {
-						if _, ok := claims[key]; !ok {
-							claims = appendClaim(claims, key, value)
-							return
-						}
-						claimLogs = append(claimLogs, fmt.Sprintf("key %q already exists", key))
-					}),
-					actions.SetFields("appendLogIntoClaims", func(entry string) {
-						claimLogs = append(claimLogs, entry)
-					}),
-				),
-				actions.SetFields("user",
-					actions.SetFields("setMetadata", func(call goja.FunctionCall) {
-						if len(call.Arguments) != 2 {
-							panic("exactly 2 (key, value) arguments expected")
-						}
-						key := call.Arguments[0].Export().(string)
-						val := call.Arguments[1].Export()
-
-						value, err := json.Marshal(val)
-						if err != nil {
-							logging.WithError(err).Debug("unable to marshal")
-							panic(err)
-						}
-
-						metadata := &domain.Metadata{
-							Key:   key,
-							Value: value,
-						}
-						if _, err = o.command.SetUserMetadata(ctx, metadata, userID, user.ResourceOwner); err != nil {
-							logging.WithError(err).Info("unable to set md in action")
-							panic(err)
-						}
-					}),
-				),
-			),
+			trigger.PreAccessTokenCreationAPIFields(trigger.Claims(claims), &claimLogs, o.metadataSetter(ctx, userID, user.ResourceOwner))...,
 		)
 
 		err = actions.Run(
@@ -591,13 +474,36 @@ func (o *OPStorage) privateClaimsFlows(ctx context.Context, userID string, claim
 		}
 		if len(claimLogs) > 0 {
 			claims = appendClaim(claims, fmt.Sprintf(ClaimActionLogFormat, action.Name), claimLogs)
-			claimLogs = nil
 		}
 	}
 
 	return claims, nil
 }
 
+// userMetadataGetter queries the metadata of the user of the organisation, if an action calls `getMetadata`
+func (o *OPStorage) userMetadataGetter(ctx context.Context, userID, resourceOwner string) func() (*query.UserMetadataList, error) {
+	return func() (*query.UserMetadataList, error) {
+		resourceOwnerQuery, err := query.NewUserMetadataResourceOwnerSearchQuery(resourceOwner)
+		if err != nil {
+			return nil, err
+		}
+		return o.query.SearchUserMetadata(
+			ctx,
+			true,
+			userID,
+			&query.UserMetadataSearchQueries{Queries: []query.SearchQuery{resourceOwnerQuery}},
+			false,
+		)
+	}
+}
+
+func (o *OPStorage) metadataSetter(ctx context.Context, userID, resourceOwner string) func(*domain.Metadata) error {
+	return func(metadata *domain.Metadata) error {
+		_, err := o.command.SetUserMetadata(ctx, metadata, userID, resourceOwner)
+		return err
+	}
+}
+
 func (o *OPStorage) assertRoles(ctx context.Context, userID, applicationID string, requestedRoles []string) (map[string]map[string]string, error) {
 	projectID, err := o.query.ProjectIDFromClientID(ctx, applicationID, false)
 	if err != nil {
@@ -0,0 +1,209 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/zitadel/logging"
+	httphelper "github.com/zitadel/oidc/v2/pkg/http"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/api/ui/login"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+const (
+	// GrantTypeDeviceCode is the grant type of the OAuth 2.0 Device Authorization Grant (RFC 8628)
+	GrantTypeDeviceCode oidc.GrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+	defaultDeviceAuthorizationEndpoint = "oauth/v2/device_authorization"
+
+	deviceCodeLength = 32
+
+	errorAuthorizationPending = "authorization_pending"
+	errorAccessDenied         = "access_denied"
+	errorExpiredToken         = "expired_token"
+)
+
+var (
+	deviceCodeChars = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
+)
+
+type DeviceAuthorizationConfig struct {
+	Lifetime     time.Duration
+	PollInterval time.Duration
+	UserCode     *UserCodeConfig
+}
+
+type UserCodeConfig struct {
+	CharSet      string
+	CharAmount   int
+	DashInterval int
+}
+
+func isDeviceAccessTokenRequest(r *http.Request, _ *mux.RouteMatch) bool {
+	return r.Method == http.MethodPost && r.FormValue("grant_type") == string(GrantTypeDeviceCode)
+}
+
+type deviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               uint64 `json:"expires_in"`
+	Interval                uint64 `json:"interval,omitempty"`
+}
+
+func (p *Provider) deviceAuthorizationHandler(w http.ResponseWriter, r *http.Request) {
+	resp, err := p.deviceAuthorization(r)
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	httphelper.MarshalJSON(w, resp)
+}
+
+// deviceAuthorization handles the Device Authorization Request (RFC 8628, section 3.1)
+// and returns the codes the device has to display to the user
+func (p *Provider) deviceAuthorization(r *http.Request) (_ *deviceAuthorizationResponse, err error) {
+	ctx, span := tracing.NewSpan(r.Context())
+	defer func() { span.EndWithError(err) }()
+
+	if err = r.ParseForm(); err != nil {
+		return nil, oidc.ErrInvalidRequest().WithDescription("cannot parse form").WithParent(err)
+	}
+	client, err := p.clientFromRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	if !op.ValidateGrantType(client, GrantTypeDeviceCode) {
+		return nil, oidc.ErrUnauthorizedClient().WithDescription("client is not allowed to use the device authorization grant")
+	}
+	scopes, err := p.storage.assertProjectRoleScopes(ctx, client.GetID(), strings.Fields(r.FormValue("scope")))
+	if err != nil {
+		return nil, errors.ThrowPreconditionFailed(err, "OIDC-Ohx5o", "Errors.Internal")
+	}
+	scopes, err = op.ValidateAuthReqScopes(client, scopes)
+	if err != nil {
+		return nil, err
+	}
+	deviceCode, err := crypto.GenerateRandomString(deviceCodeLength, deviceCodeChars)
+	if err != nil {
+		return nil, err
+	}
+	userCode, err := crypto.GenerateRandomString(uint(p.deviceAuth.UserCode.CharAmount), []rune(p.deviceAuth.UserCode.CharSet))
+	if err != nil {
+		return nil, err
+	}
+	_, _, err = p.storage.command.AddDeviceAuth(setContextUserSystem(ctx), client.GetID(), deviceCode, userCode, time.Now().Add(p.deviceAuth.Lifetime), scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	displayedUserCode := formatDeviceUserCode(userCode, p.deviceAuth.UserCode.DashInterval)
+	verificationURI := strings.TrimSuffix(op.IssuerFromContext(ctx), "/") + login.HandlerPrefix + login.EndpointDeviceAuth
+	return &deviceAuthorizationResponse{
+		DeviceCode:              deviceCode,
+		UserCode:                displayedUserCode,
+		VerificationURI:         verificationURI,
+		VerificationURIComplete: verificationURI + "?" + url.Values{"user_code": {displayedUserCode}}.Encode(),
+		ExpiresIn:               uint64(p.deviceAuth.Lifetime.Seconds()),
+		Interval:                uint64(p.deviceAuth.PollInterval.Seconds()),
+	}, nil
+}
+
+func (p *Provider) deviceAccessTokenHandler(w http.ResponseWriter, r *http.Request) {
+	resp, err := p.deviceAccessToken(r)
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	httphelper.MarshalJSON(w, resp)
+}
+
+// deviceAccessToken handles the polling of the device on the token endpoint (RFC 8628, section 3.4)
+// and issues the tokens as soon as the user approved the device
+func (p *Provider) deviceAccessToken(r *http.Request) (_ *oidc.AccessTokenResponse, err error) {
+	ctx, span := tracing.NewSpan(r.Context())
+	defer func() { span.EndWithError(err) }()
+
+	client, err := p.clientFromRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	if !op.ValidateGrantType(client, GrantTypeDeviceCode) {
+		return nil, oidc.ErrUnauthorizedClient().WithDescription("client is not allowed to use the device authorization grant")
+	}
+	deviceCode := r.FormValue("device_code")
+	if deviceCode == "" {
+		return nil, oidc.ErrInvalidRequest().WithDescription("device_code missing")
+	}
+	deviceAuth, err := p.storage.query.DeviceAuthByDeviceCode(ctx, client.GetID(), deviceCode)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, oidc.ErrInvalidGrant().WithDescription("invalid device_code").WithParent(err)
+		}
+		return nil, err
+	}
+	switch deviceAuth.State {
+	case domain.DeviceAuthStateInitiated:
+		if deviceAuth.Expires.Before(time.Now()) {
+			_, err = p.storage.command.CancelDeviceAuth(setContextUserSystem(ctx), deviceAuth.ID, domain.DeviceAuthCanceledExpired)
+			logging.WithFields("deviceAuthID", deviceAuth.ID).OnError(err).Warn("unable to cancel expired device authorization")
+			return nil, &oidc.Error{ErrorType: errorExpiredToken, Description: "device_code has expired"}
+		}
+		return nil, &oidc.Error{ErrorType: errorAuthorizationPending}
+	case domain.DeviceAuthStateApproved:
+		// the device code must be exchanged before it expires, even if the user approved it in time
+		if deviceAuth.Expires.Before(time.Now()) {
+			return nil, &oidc.Error{ErrorType: errorExpiredToken, Description: "device_code has expired"}
+		}
+		return p.deviceTokenResponse(ctx, client, deviceAuth)
+	case domain.DeviceAuthStateDenied:
+		return nil, &oidc.Error{ErrorType: errorAccessDenied, Description: "the user denied the authorization request"}
+	case domain.DeviceAuthStateExpired:
+		return nil, &oidc.Error{ErrorType: errorExpiredToken, Description: "device_code has expired"}
+	default:
+		return nil, oidc.ErrInvalidGrant().WithDescription("invalid device_code")
+	}
+}
+
+// deviceTokenResponse consumes the device authorization, so the device code can only be exchanged once,
+// and creates the tokens based on the auth request of the login, where the user approved the device
+func (p *Provider) deviceTokenResponse(ctx context.Context, client op.Client, deviceAuth *query.DeviceAuth) (*oidc.AccessTokenResponse, error) {
+	authReq, err := p.storage.repo.AuthRequestByCode(ctx, deviceAuth.DeviceCode)
+	if err != nil {
+		return nil, oidc.ErrInvalidGrant().WithDescription("invalid device_code").WithParent(err)
+	}
+	if _, err = p.storage.command.ConsumeDeviceAuth(setContextUserSystem(ctx), deviceAuth.ID); err != nil {
+		if errors.IsNotFound(err) || errors.IsPreconditionFailed(err) || errors.IsErrorAlreadyExists(err) {
+			return nil, oidc.ErrInvalidGrant().WithDescription("invalid device_code").WithParent(err)
+		}
+		return nil, err
+	}
+	return op.CreateTokenResponse(ctx, &AuthRequest{authReq}, client, p, true, "", "")
+}
+
+// formatDeviceUserCode inserts a dash every interval characters to improve the readability for the user
+func formatDeviceUserCode(userCode string, interval int) string {
+	if interval <= 0 {
+		return userCode
+	}
+	var formatted strings.Builder
+	for i, char := range userCode {
+		if i > 0 && i%interval == 0 {
+			formatted.WriteRune('-')
+		}
+		formatted.WriteRune(char)
+	}
+	return formatted.String()
+}
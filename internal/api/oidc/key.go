@@ -71,6 +71,8 @@ func (s *PublicKey) ID() string {
 }
 
 // KeySet implements the op.Storage interface
+// it contains the public keys of the current signing key, the already prepublished next one
+// and the ones of previous keys during their overlap period
 func (o *OPStorage) KeySet(ctx context.Context) (keys []op.Key, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -89,13 +91,27 @@ func (o *OPStorage) KeySet(ctx context.Context) (keys []op.Key, err error) {
 }
 
 // SignatureAlgorithms implements the op.Storage interface
+// besides the algorithm of the current signing key, the one of a prepublished key is returned,
+// so relying parties accept the tokens after a change of the algorithm
 func (o *OPStorage) SignatureAlgorithms(ctx context.Context) ([]jose.SignatureAlgorithm, error) {
 	key, err := o.SigningKey(ctx)
 	if err != nil {
 		logging.WithError(err).Warn("unable to fetch signing key")
 		return nil, err
 	}
-	return []jose.SignatureAlgorithm{key.SignatureAlgorithm()}, nil
+	algorithms := []jose.SignatureAlgorithm{key.SignatureAlgorithm()}
+	keys, err := o.query.ActivePrivateSigningKey(ctx, time.Now().Add(gracefulPeriod))
+	if err != nil {
+		logging.WithError(err).Warn("unable to fetch upcoming signing keys")
+		return algorithms, nil
+	}
+	for _, upcoming := range keys.Keys {
+		algorithm := jose.SignatureAlgorithm(upcoming.Algorithm())
+		if !containsAlgorithm(algorithms, algorithm) {
+			algorithms = append(algorithms, algorithm)
+		}
+	}
+	return algorithms, nil
 }
 
 // SigningKey implements the op.Storage interface
@@ -118,13 +134,14 @@ func (o *OPStorage) getSigningKey(ctx context.Context) (op.SigningKey, error) {
 	if err != nil {
 		return nil, err
 	}
-	if len(keys.Keys) > 0 {
-		return o.privateKeyToSigningKey(selectSigningKey(keys.Keys))
-	}
 	var sequence uint64
 	if keys.LatestSequence != nil {
 		sequence = keys.LatestSequence.Sequence
 	}
+	if len(keys.Keys) > 0 {
+		o.prepublishSigningKey(ctx, keys.Keys, sequence)
+		return o.privateKeyToSigningKey(selectSigningKey(keys.Keys))
+	}
 	return nil, o.refreshSigningKey(ctx, o.signingKeyAlgorithm, sequence)
 }
 
@@ -133,13 +150,48 @@ func (o *OPStorage) refreshSigningKey(ctx context.Context, algorithm string, seq
 	if err != nil || !ok {
 		return errors.ThrowInternal(err, "OIDC-ASfh3", "cannot ensure that projection is up to date")
 	}
-	err = o.lockAndGenerateSigningKeyPair(ctx, algorithm)
+	err = o.lockAndGenerateSigningKeyPair(ctx, algorithm, time.Time{})
 	if err != nil {
 		return errors.ThrowInternal(err, "OIDC-ADh31", "could not create signing key")
 	}
 	return errors.ThrowInternal(nil, "OIDC-Df1bh", "")
 }
 
+// prepublishSigningKey generates the next signing key as soon as the current one reaches the prepublication window,
+// the public key is then published in the JWKS, but the key is only used for signing after the current one expired.
+// The current key is still returned if this fails, so it's only logged.
+func (o *OPStorage) prepublishSigningKey(ctx context.Context, keys []query.PrivateKey, sequence uint64) {
+	if len(keys) > 1 {
+		return
+	}
+	activation := keys[0].Expiry().Add(-gracefulPeriod)
+	window, err := o.signingKeyPrepublicationWindow(ctx)
+	if err != nil {
+		logging.WithError(err).Warn("unable to get signing key prepublication window")
+		return
+	}
+	if time.Now().Add(window).Before(activation) {
+		return
+	}
+	ok, err := o.ensureIsLatestKey(ctx, sequence)
+	if err != nil || !ok {
+		return
+	}
+	err = o.lockAndGenerateSigningKeyPair(ctx, o.signingKeyAlgorithm, activation)
+	logging.OnError(err).Warn("unable to prepublish signing key")
+}
+
+func (o *OPStorage) signingKeyPrepublicationWindow(ctx context.Context) (time.Duration, error) {
+	policy, err := o.query.SigningKeyPolicy(ctx)
+	if errors.IsNotFound(err) {
+		return o.defaultSigningKeyPrepublicationWindow, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return policy.PrepublicationWindow, nil
+}
+
 func (o *OPStorage) ensureIsLatestKey(ctx context.Context, sequence uint64) (bool, error) {
 	maxSequence, err := o.getMaxKeySequence(ctx)
 	if err != nil {
@@ -153,7 +205,7 @@ func (o *OPStorage) privateKeyToSigningKey(key query.PrivateKey) (_ op.SigningKe
 	if err != nil {
 		return nil, err
 	}
-	privateKey, err := crypto.BytesToPrivateKey(keyData)
+	privateKey, err := crypto.BytesToSigningKey(keyData)
 	if err != nil {
 		return nil, err
 	}
@@ -164,7 +216,7 @@ func (o *OPStorage) privateKeyToSigningKey(key query.PrivateKey) (_ op.SigningKe
 	}, nil
 }
 
-func (o *OPStorage) lockAndGenerateSigningKeyPair(ctx context.Context, algorithm string) error {
+func (o *OPStorage) lockAndGenerateSigningKeyPair(ctx context.Context, algorithm string, activation time.Time) error {
 	logging.Info("lock and generate signing key pair")
 
 	ctx, cancel := context.WithCancel(ctx)
@@ -180,7 +232,7 @@ func (o *OPStorage) lockAndGenerateSigningKeyPair(ctx context.Context, algorithm
 		return err
 	}
 
-	return o.command.GenerateSigningKeyPair(setOIDCCtx(ctx), algorithm)
+	return o.command.GenerateSigningKeyPair(setOIDCCtx(ctx), algorithm, activation)
 }
 
 func (o *OPStorage) getMaxKeySequence(ctx context.Context) (uint64, error) {
@@ -193,8 +245,18 @@ func (o *OPStorage) getMaxKeySequence(ctx context.Context) (uint64, error) {
 	)
 }
 
+// selectSigningKey returns the key expiring first, all others are prepublished and not yet active
 func selectSigningKey(keys []query.PrivateKey) query.PrivateKey {
-	return keys[len(keys)-1]
+	return keys[0]
+}
+
+func containsAlgorithm(algorithms []jose.SignatureAlgorithm, algorithm jose.SignatureAlgorithm) bool {
+	for _, alg := range algorithms {
+		if alg == algorithm {
+			return true
+		}
+	}
+	return false
 }
 
 func setOIDCCtx(ctx context.Context) context.Context {
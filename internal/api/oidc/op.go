@@ -27,19 +27,22 @@ import (
 )
 
 type Config struct {
-	CodeMethodS256                    bool
-	AuthMethodPost                    bool
-	AuthMethodPrivateKeyJWT           bool
-	GrantTypeRefreshToken             bool
-	RequestObjectSupported            bool
-	SigningKeyAlgorithm               string
-	DefaultAccessTokenLifetime        time.Duration
-	DefaultIdTokenLifetime            time.Duration
-	DefaultRefreshTokenIdleExpiration time.Duration
-	DefaultRefreshTokenExpiration     time.Duration
-	UserAgentCookieConfig             *middleware.UserAgentCookieConfig
-	Cache                             *middleware.CacheConfig
-	CustomEndpoints                   *EndpointConfig
+	CodeMethodS256                        bool
+	AuthMethodPost                        bool
+	AuthMethodPrivateKeyJWT               bool
+	GrantTypeRefreshToken                 bool
+	RequestObjectSupported                bool
+	SigningKeyAlgorithm                   string
+	DefaultSigningKeyPrepublicationWindow time.Duration
+	DefaultAccessTokenLifetime            time.Duration
+	DefaultIdTokenLifetime                time.Duration
+	DefaultRefreshTokenIdleExpiration     time.Duration
+	DefaultRefreshTokenExpiration         time.Duration
+	UserAgentCookieConfig                 *middleware.UserAgentCookieConfig
+	Cache                                 *middleware.CacheConfig
+	CustomEndpoints                       *EndpointConfig
+	DeviceAuth                            *DeviceAuthorizationConfig
+	TokenRateLimit                        middleware.RateLimitConfig
 }
 
 type EndpointConfig struct {
@@ -50,6 +53,7 @@ type EndpointConfig struct {
 	Revocation    *Endpoint
 	EndSession    *Endpoint
 	Keys          *Endpoint
+	DeviceAuth    *Endpoint
 }
 
 type Endpoint struct {
@@ -58,19 +62,20 @@ type Endpoint struct {
 }
 
 type OPStorage struct {
-	repo                              repository.Repository
-	command                           *command.Commands
-	query                             *query.Queries
-	eventstore                        *eventstore.Eventstore
-	defaultLoginURL                   string
-	defaultAccessTokenLifetime        time.Duration
-	defaultIdTokenLifetime            time.Duration
-	signingKeyAlgorithm               string
-	defaultRefreshTokenIdleExpiration time.Duration
-	defaultRefreshTokenExpiration     time.Duration
-	encAlg                            crypto.EncryptionAlgorithm
-	locker                            crdb.Locker
-	assetAPIPrefix                    func(ctx context.Context) string
+	repo                                  repository.Repository
+	command                               *command.Commands
+	query                                 *query.Queries
+	eventstore                            *eventstore.Eventstore
+	defaultLoginURL                       string
+	defaultAccessTokenLifetime            time.Duration
+	defaultIdTokenLifetime                time.Duration
+	signingKeyAlgorithm                   string
+	defaultSigningKeyPrepublicationWindow time.Duration
+	defaultRefreshTokenIdleExpiration     time.Duration
+	defaultRefreshTokenExpiration         time.Duration
+	encAlg                                crypto.EncryptionAlgorithm
+	locker                                crdb.Locker
+	assetAPIPrefix                        func(ctx context.Context) string
 }
 
 func NewProvider(ctx context.Context, config Config, defaultLogoutRedirectURI string, externalSecure bool, command *command.Commands, query *query.Queries, repo repository.Repository, encryptionAlg crypto.EncryptionAlgorithm, cryptoKey []byte, es *eventstore.Eventstore, projections *sql.DB, userAgentCookie, instanceHandler, accessHandler func(http.Handler) http.Handler) (op.OpenIDProvider, error) {
@@ -79,7 +84,8 @@ func NewProvider(ctx context.Context, config Config, defaultLogoutRedirectURI st
 		return nil, caos_errs.ThrowInternal(err, "OIDC-EGrqd", "cannot create op config: %w")
 	}
 	storage := newStorage(config, command, query, repo, encryptionAlg, es, projections, externalSecure)
-	options, err := createOptions(config, externalSecure, userAgentCookie, instanceHandler, accessHandler)
+	interceptors := httpInterceptors(config, userAgentCookie, instanceHandler, accessHandler)
+	options, err := createOptions(config, externalSecure, interceptors...)
 	if err != nil {
 		return nil, caos_errs.ThrowInternal(err, "OIDC-D3gq1", "cannot create options: %w")
 	}
@@ -93,7 +99,7 @@ func NewProvider(ctx context.Context, config Config, defaultLogoutRedirectURI st
 	if err != nil {
 		return nil, caos_errs.ThrowInternal(err, "OIDC-DAtg3", "cannot create provider")
 	}
-	return provider, nil
+	return newProvider(provider, storage, config, interceptors...), nil
 }
 
 func createOPConfig(config Config, defaultLogoutRedirectURI string, cryptoKey []byte) (*op.Config, error) {
@@ -117,18 +123,23 @@ func createOPConfig(config Config, defaultLogoutRedirectURI string, cryptoKey []
 	return opConfig, nil
 }
 
-func createOptions(config Config, externalSecure bool, userAgentCookie, instanceHandler, accessHandler func(http.Handler) http.Handler) ([]op.Option, error) {
+func httpInterceptors(config Config, userAgentCookie, instanceHandler, accessHandler func(http.Handler) http.Handler) []op.HttpInterceptor {
 	metricTypes := []metrics.MetricType{metrics.MetricTypeRequestCount, metrics.MetricTypeStatusCode, metrics.MetricTypeTotalCount}
+	return []op.HttpInterceptor{
+		middleware.MetricsHandler(metricTypes),
+		middleware.TelemetryHandler(),
+		middleware.NoCacheInterceptor().Handler,
+		instanceHandler,
+		middleware.RateLimitHandler(config.TokenRateLimit, middleware.RateLimitPaths(tokenEndpointPath(config.CustomEndpoints))),
+		userAgentCookie,
+		http_utils.CopyHeadersToContext,
+		accessHandler,
+	}
+}
+
+func createOptions(config Config, externalSecure bool, interceptors ...op.HttpInterceptor) ([]op.Option, error) {
 	options := []op.Option{
-		op.WithHttpInterceptors(
-			middleware.MetricsHandler(metricTypes),
-			middleware.TelemetryHandler(),
-			middleware.NoCacheInterceptor().Handler,
-			instanceHandler,
-			userAgentCookie,
-			http_utils.CopyHeadersToContext,
-			accessHandler,
-		),
+		op.WithHttpInterceptors(interceptors...),
 	}
 	if !externalSecure {
 		options = append(options, op.WithAllowInsecure())
@@ -140,6 +151,13 @@ func createOptions(config Config, externalSecure bool, userAgentCookie, instance
 	return options, nil
 }
 
+func tokenEndpointPath(endpointConfig *EndpointConfig) string {
+	if endpointConfig == nil || endpointConfig.Token == nil || endpointConfig.Token.Path == "" {
+		return op.DefaultEndpoints.Token.Relative()
+	}
+	return op.NewEndpoint(endpointConfig.Token.Path).Relative()
+}
+
 func customEndpoints(endpointConfig *EndpointConfig) []op.Option {
 	if endpointConfig == nil {
 		return nil
@@ -171,19 +189,20 @@ func customEndpoints(endpointConfig *EndpointConfig) []op.Option {
 
 func newStorage(config Config, command *command.Commands, query *query.Queries, repo repository.Repository, encAlg crypto.EncryptionAlgorithm, es *eventstore.Eventstore, projections *sql.DB, externalSecure bool) *OPStorage {
 	return &OPStorage{
-		repo:                              repo,
-		command:                           command,
-		query:                             query,
-		eventstore:                        es,
-		defaultLoginURL:                   fmt.Sprintf("%s%s?%s=", login.HandlerPrefix, login.EndpointLogin, login.QueryAuthRequestID),
-		signingKeyAlgorithm:               config.SigningKeyAlgorithm,
-		defaultAccessTokenLifetime:        config.DefaultAccessTokenLifetime,
-		defaultIdTokenLifetime:            config.DefaultIdTokenLifetime,
-		defaultRefreshTokenIdleExpiration: config.DefaultRefreshTokenIdleExpiration,
-		defaultRefreshTokenExpiration:     config.DefaultRefreshTokenExpiration,
-		encAlg:                            encAlg,
-		locker:                            crdb.NewLocker(projections, locksTable, signingKey),
-		assetAPIPrefix:                    assets.AssetAPI(externalSecure),
+		repo:                                  repo,
+		command:                               command,
+		query:                                 query,
+		eventstore:                            es,
+		defaultLoginURL:                       fmt.Sprintf("%s%s?%s=", login.HandlerPrefix, login.EndpointLogin, login.QueryAuthRequestID),
+		signingKeyAlgorithm:                   config.SigningKeyAlgorithm,
+		defaultSigningKeyPrepublicationWindow: config.DefaultSigningKeyPrepublicationWindow,
+		defaultAccessTokenLifetime:            config.DefaultAccessTokenLifetime,
+		defaultIdTokenLifetime:                config.DefaultIdTokenLifetime,
+		defaultRefreshTokenIdleExpiration:     config.DefaultRefreshTokenIdleExpiration,
+		defaultRefreshTokenExpiration:         config.DefaultRefreshTokenExpiration,
+		encAlg:                                encAlg,
+		locker:                                crdb.NewLocker(projections, locksTable, signingKey),
+		assetAPIPrefix:                        assets.AssetAPI(externalSecure),
 	}
 }
 
@@ -0,0 +1,176 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/cors"
+	httphelper "github.com/zitadel/oidc/v2/pkg/http"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+)
+
+var (
+	// corsOptions are the same as the defaults of the oidc library,
+	// so the endpoints served by the Provider behave the same as the others
+	corsOptions = cors.Options{
+		AllowCredentials: true,
+		AllowedHeaders: []string{
+			"Origin",
+			"Accept",
+			"Accept-Language",
+			"Authorization",
+			"Content-Type",
+			"X-Requested-With",
+		},
+		AllowedMethods: []string{
+			http.MethodGet,
+			http.MethodHead,
+			http.MethodPost,
+		},
+		ExposedHeaders: []string{
+			"Location",
+			"Content-Length",
+		},
+		AllowOriginFunc: func(_ string) bool {
+			return true
+		},
+	}
+)
+
+// Provider extends the OpenID Provider of the oidc library
+// with the OAuth 2.0 Device Authorization Grant (RFC 8628)
+// and the OAuth 2.0 Token Exchange (RFC 8693)
+type Provider struct {
+	*op.Provider
+	storage                     *OPStorage
+	deviceAuth                  *DeviceAuthorizationConfig
+	deviceAuthorizationEndpoint op.Endpoint
+	handler                     http.Handler
+}
+
+func newProvider(provider *op.Provider, storage *OPStorage, config Config, interceptors ...op.HttpInterceptor) *Provider {
+	p := &Provider{
+		Provider:                    provider,
+		storage:                     storage,
+		deviceAuth:                  config.DeviceAuth,
+		deviceAuthorizationEndpoint: op.NewEndpoint(defaultDeviceAuthorizationEndpoint),
+	}
+	if config.CustomEndpoints != nil && config.CustomEndpoints.DeviceAuth != nil {
+		p.deviceAuthorizationEndpoint = op.NewEndpointWithURL(config.CustomEndpoints.DeviceAuth.Path, config.CustomEndpoints.DeviceAuth.URL)
+	}
+	p.handler = p.createRouter(interceptors...)
+	return p
+}
+
+func (p *Provider) HttpHandler() http.Handler {
+	return p.handler
+}
+
+// IDTokenHintVerifier overwrites the verifier of the oidc library, which only accepts RS256
+func (p *Provider) IDTokenHintVerifier(ctx context.Context) op.IDTokenHintVerifier {
+	return authz.IDTokenHintVerifierWithSigningAlgorithms(p.Provider.IDTokenHintVerifier(ctx))
+}
+
+// AccessTokenVerifier overwrites the verifier of the oidc library, which only accepts RS256
+func (p *Provider) AccessTokenVerifier(ctx context.Context) op.AccessTokenVerifier {
+	return authz.AccessTokenVerifierWithSigningAlgorithms(p.Provider.AccessTokenVerifier(ctx))
+}
+
+// createRouter serves the endpoints and grant types, which are not (yet) implemented by the oidc library,
+// and passes all other requests to the handler of the oidc library
+func (p *Provider) createRouter(interceptors ...op.HttpInterceptor) http.Handler {
+	router := mux.NewRouter()
+	router.Use(providerIntercept(p.IssuerFromRequest, interceptors...))
+	router.HandleFunc(oidc.DiscoveryEndpoint, p.discoveryHandler)
+	router.HandleFunc(p.deviceAuthorizationEndpoint.Relative(), p.deviceAuthorizationHandler).Methods(http.MethodPost)
+	router.Path(p.TokenEndpoint().Relative()).MatcherFunc(isDeviceAccessTokenRequest).HandlerFunc(p.deviceAccessTokenHandler)
+	router.Path(p.TokenEndpoint().Relative()).MatcherFunc(isTokenExchangeRequest).HandlerFunc(p.tokenExchangeHandler)
+	// the endpoints verifying tokens are served with the Provider, so they accept all signing algorithms
+	router.HandleFunc(p.AuthorizationEndpoint().Relative(), func(w http.ResponseWriter, r *http.Request) { op.Authorize(w, r, p) })
+	router.HandleFunc(p.UserinfoEndpoint().Relative(), func(w http.ResponseWriter, r *http.Request) { op.Userinfo(w, r, p) })
+	router.HandleFunc(p.RevocationEndpoint().Relative(), func(w http.ResponseWriter, r *http.Request) { op.Revoke(w, r, p) })
+	router.HandleFunc(p.EndSessionEndpoint().Relative(), func(w http.ResponseWriter, r *http.Request) { op.EndSession(w, r, p) })
+	router.HandleFunc(p.IntrospectionEndpoint().Relative(), func(w http.ResponseWriter, r *http.Request) { op.Introspect(w, r, p) })
+	router.NotFoundHandler = p.Provider.HttpHandler()
+	return router
+}
+
+func providerIntercept(issuer op.IssuerFromRequest, interceptors ...op.HttpInterceptor) mux.MiddlewareFunc {
+	issuerInterceptor := op.NewIssuerInterceptor(issuer)
+	return func(handler http.Handler) http.Handler {
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			handler = interceptors[i](handler)
+		}
+		return cors.New(corsOptions).Handler(issuerInterceptor.Handler(handler))
+	}
+}
+
+type discoveryConfiguration struct {
+	*oidc.DiscoveryConfiguration
+	DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint,omitempty"`
+}
+
+func (p *Provider) discoveryHandler(w http.ResponseWriter, r *http.Request) {
+	config := op.CreateDiscoveryConfig(r, p, p.Storage())
+	config.GrantTypesSupported = append(config.GrantTypesSupported, GrantTypeDeviceCode, oidc.GrantTypeTokenExchange)
+	httphelper.MarshalJSON(w, &discoveryConfiguration{
+		DiscoveryConfiguration:      config,
+		DeviceAuthorizationEndpoint: p.deviceAuthorizationEndpoint.Absolute(config.Issuer),
+	})
+}
+
+// clientFromRequest authenticates the client the same way as the token endpoint does:
+// by client_secret (basic auth or post), by JWT (private_key_jwt) or only by client_id for public clients
+func (p *Provider) clientFromRequest(ctx context.Context, r *http.Request) (op.Client, error) {
+	if assertion := r.FormValue("client_assertion"); assertion != "" && r.FormValue("client_assertion_type") == oidc.ClientAssertionTypeJWTAssertion {
+		if !p.AuthMethodPrivateKeyJWTSupported() {
+			return nil, oidc.ErrInvalidClient().WithDescription("auth method private_key_jwt not supported")
+		}
+		return op.AuthorizePrivateJWTKey(ctx, assertion, p.Provider)
+	}
+	clientID, clientSecret, err := clientCredentialsFromRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	client, err := p.Storage().GetClientByClientID(ctx, clientID)
+	if err != nil {
+		return nil, oidc.ErrInvalidClient().WithParent(err)
+	}
+	switch client.AuthMethod() {
+	case oidc.AuthMethodNone:
+		return client, nil
+	case oidc.AuthMethodBasic, oidc.AuthMethodPost:
+		if err = op.AuthorizeClientIDSecret(ctx, clientID, clientSecret, p.Storage()); err != nil {
+			return nil, err
+		}
+		return client, nil
+	default:
+		return nil, oidc.ErrInvalidClient().WithDescription("client authentication missing")
+	}
+}
+
+// clientCredentialsFromRequest returns the client_id and client_secret
+// sent either as basic auth header or in the form body
+func clientCredentialsFromRequest(r *http.Request) (clientID, clientSecret string, err error) {
+	clientID, clientSecret, ok := r.BasicAuth()
+	if ok {
+		if clientID, err = url.QueryUnescape(clientID); err != nil {
+			return "", "", oidc.ErrInvalidClient().WithDescription("invalid basic auth header").WithParent(err)
+		}
+		if clientSecret, err = url.QueryUnescape(clientSecret); err != nil {
+			return "", "", oidc.ErrInvalidClient().WithDescription("invalid basic auth header").WithParent(err)
+		}
+	} else {
+		clientID = r.FormValue("client_id")
+		clientSecret = r.FormValue("client_secret")
+	}
+	if clientID == "" {
+		return "", "", oidc.ErrInvalidClient().WithDescription("client_id missing")
+	}
+	return clientID, clientSecret, nil
+}
@@ -0,0 +1,83 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+	"gopkg.in/square/go-jose.v2"
+)
+
+const testIssuer = "http://issuer.test"
+
+type testKey struct {
+	key *ecdsa.PublicKey
+}
+
+func (k *testKey) ID() string                         { return "key1" }
+func (k *testKey) Algorithm() jose.SignatureAlgorithm { return jose.ES256 }
+func (k *testKey) Use() string                        { return oidc.KeyUseSignature }
+func (k *testKey) Key() interface{}                   { return k.key }
+
+// testStorage only implements the methods used by the introspection endpoint
+type testStorage struct {
+	op.Storage
+	key *ecdsa.PublicKey
+}
+
+func (s *testStorage) KeySet(context.Context) ([]op.Key, error) {
+	return []op.Key{&testKey{key: s.key}}, nil
+}
+
+func (s *testStorage) AuthorizeClientIDSecret(context.Context, string, string) error {
+	return nil
+}
+
+func (s *testStorage) SetIntrospectionFromToken(_ context.Context, introspection oidc.IntrospectionResponse, _, subject, _ string) error {
+	introspection.SetSubject(subject)
+	return nil
+}
+
+func TestProvider_introspectES256(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	opProvider, err := op.NewOpenIDProvider(context.Background(), testIssuer, &op.Config{}, &testStorage{key: &key.PublicKey}, op.WithAllowInsecure())
+	require.NoError(t, err)
+	provider := newProvider(opProvider, nil, Config{})
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: key}, nil)
+	require.NoError(t, err)
+	payload, err := json.Marshal(oidc.NewAccessTokenClaims(testIssuer, "user", []string{"client"}, time.Now().Add(time.Hour), "token", "client", 0))
+	require.NoError(t, err)
+	signed, err := signer.Sign(payload)
+	require.NoError(t, err)
+	token, err := signed.CompactSerialize()
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, testIssuer+provider.IntrospectionEndpoint().Relative(), strings.NewReader(url.Values{"token": {token}}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth("client", "secret")
+	rec := httptest.NewRecorder()
+	provider.HttpHandler().ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var introspection struct {
+		Active  bool   `json:"active"`
+		Subject string `json:"sub"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &introspection))
+	assert.True(t, introspection.Active, "tokens signed with ES256 must be active")
+	assert.Equal(t, "user", introspection.Subject)
+}
@@ -0,0 +1,438 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/zitadel/oidc/v2/pkg/crypto"
+	httphelper "github.com/zitadel/oidc/v2/pkg/http"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/oidc/grants/tokenexchange"
+	"github.com/zitadel/oidc/v2/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+const (
+	// TokenTypeUserID is the (non standard) subject_token_type to impersonate a user by its id,
+	// which is only allowed for the impersonation actors of the token exchange policy
+	TokenTypeUserID = "urn:zitadel:params:oauth:token-type:user_id"
+
+	ClaimActor = "act"
+
+	errorInvalidTarget = "invalid_target"
+)
+
+type tokenExchangeResponse struct {
+	AccessToken     string `json:"access_token"`
+	IssuedTokenType string `json:"issued_token_type"`
+	TokenType       string `json:"token_type"`
+	ExpiresIn       uint64 `json:"expires_in,omitempty"`
+	Scope           string `json:"scope,omitempty"`
+}
+
+// exchangeSubject is the user represented by the subject_token of a token exchange request
+type exchangeSubject struct {
+	userID        string
+	resourceOwner string
+	audience      []string
+	scopes        []string
+	expiration    time.Time
+	actor         *domain.TokenActor
+	mayAct        string
+	impersonation bool
+}
+
+func isTokenExchangeRequest(r *http.Request, _ *mux.RouteMatch) bool {
+	return r.Method == http.MethodPost && r.FormValue("grant_type") == string(oidc.GrantTypeTokenExchange)
+}
+
+func (p *Provider) tokenExchangeHandler(w http.ResponseWriter, r *http.Request) {
+	resp, err := p.tokenExchange(r)
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	httphelper.MarshalJSON(w, resp)
+}
+
+// tokenExchange handles the Token Exchange Request (RFC 8693, section 2.1).
+// The authenticated machine user either acts on behalf of the subject (delegation)
+// or, by using the subject_token_type [TokenTypeUserID], impersonates the subject.
+// Both are governed by the token exchange policy of the organisation of the subject.
+func (p *Provider) tokenExchange(r *http.Request) (_ *tokenExchangeResponse, err error) {
+	ctx, span := tracing.NewSpan(r.Context())
+	defer func() { span.EndWithError(err) }()
+
+	if err = r.ParseForm(); err != nil {
+		return nil, oidc.ErrInvalidRequest().WithDescription("cannot parse form").WithParent(err)
+	}
+	actor, err := p.machineUserFromRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	subjectToken, subjectTokenType := r.FormValue("subject_token"), r.FormValue("subject_token_type")
+	if subjectToken == "" || subjectTokenType == "" {
+		return nil, oidc.ErrInvalidRequest().WithDescription("subject_token and subject_token_type are required")
+	}
+	var actorSubject *exchangeSubject
+	if actorToken := r.FormValue("actor_token"); actorToken != "" {
+		actorSubject, err = p.exchangeSubject(ctx, actorToken, r.FormValue("actor_token_type"))
+		if err != nil {
+			return nil, err
+		}
+		if actorSubject.impersonation || actorSubject.userID != actor.ID {
+			return nil, oidc.ErrInvalidGrant().WithDescription("actor_token must represent the authenticated client")
+		}
+	}
+	requestedTokenType := r.FormValue("requested_token_type")
+	if requestedTokenType == "" {
+		requestedTokenType = tokenexchange.AccessTokenType
+	}
+	if requestedTokenType != tokenexchange.AccessTokenType && requestedTokenType != tokenexchange.JWTTokenType {
+		return nil, oidc.ErrInvalidRequest().WithDescription("requested_token_type is not supported")
+	}
+	subject, err := p.exchangeSubject(ctx, subjectToken, subjectTokenType)
+	if err != nil {
+		return nil, err
+	}
+	if err = p.checkTokenExchangePolicy(ctx, actor.ID, subject); err != nil {
+		return nil, err
+	}
+	scopes, err := exchangeScopes(subject, strings.Fields(r.FormValue("scope")))
+	if err != nil {
+		return nil, err
+	}
+	audience, err := exchangeAudience(ctx, allowedExchangeAudience(subject, actorSubject), r.Form["audience"], scopes)
+	if err != nil {
+		return nil, err
+	}
+	lifetime, _, _, _, err := p.storage.getOIDCSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !subject.expiration.IsZero() && time.Until(subject.expiration) < lifetime {
+		lifetime = time.Until(subject.expiration)
+	}
+	tokenActor := subject.actor
+	if actor.ID != subject.userID {
+		tokenActor = &domain.TokenActor{
+			Actor:  subject.actor,
+			UserID: actor.ID,
+			Issuer: op.IssuerFromContext(ctx),
+		}
+	}
+	token, err := p.storage.command.AddUserToken(setContextUserSystem(ctx), subject.resourceOwner, "", "", subject.userID, audience, scopes, lifetime, tokenActor)
+	if err != nil {
+		return nil, err
+	}
+	var accessToken string
+	if requestedTokenType == tokenexchange.JWTTokenType {
+		accessToken, err = p.exchangedJWT(ctx, token, actor.PreferredLoginName)
+	} else {
+		accessToken, err = op.CreateBearerToken(token.TokenID, token.AggregateID, p.Crypto())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tokenExchangeResponse{
+		AccessToken:     accessToken,
+		IssuedTokenType: requestedTokenType,
+		TokenType:       oidc.BearerToken,
+		ExpiresIn:       uint64(time.Until(token.Expiration).Seconds()),
+		Scope:           strings.Join(token.Scopes, " "),
+	}, nil
+}
+
+// machineUserFromRequest authenticates the machine user requesting the token exchange
+// by its client secret (basic auth or post) or by a JWT signed with one of its keys (private_key_jwt)
+func (p *Provider) machineUserFromRequest(ctx context.Context, r *http.Request) (user *query.User, err error) {
+	if assertion := r.FormValue("client_assertion"); assertion != "" && r.FormValue("client_assertion_type") == oidc.ClientAssertionTypeJWTAssertion {
+		profile, err := op.VerifyJWTAssertion(ctx, assertion, p.JWTProfileVerifier(ctx))
+		if err != nil {
+			return nil, oidc.ErrInvalidClient().WithParent(err)
+		}
+		user, err = p.storage.query.GetUserByID(ctx, false, profile.GetSubject(), false)
+		if err != nil {
+			return nil, oidc.ErrInvalidClient().WithParent(err)
+		}
+	} else {
+		clientID, clientSecret, err := clientCredentialsFromRequest(r)
+		if err != nil {
+			return nil, err
+		}
+		loginName, err := query.NewUserLoginNamesSearchQuery(clientID)
+		if err != nil {
+			return nil, err
+		}
+		user, err = p.storage.query.GetUser(ctx, false, false, loginName)
+		if err != nil {
+			return nil, oidc.ErrInvalidClient().WithParent(err)
+		}
+		if _, err = p.storage.command.VerifyMachineSecret(ctx, user.ID, user.ResourceOwner, clientSecret); err != nil {
+			return nil, oidc.ErrInvalidClient().WithParent(err)
+		}
+	}
+	if user.Type != domain.UserTypeMachine {
+		return nil, oidc.ErrInvalidClient().WithDescription("only machine users can exchange tokens")
+	}
+	return user, nil
+}
+
+// exchangeSubject validates the token and returns the user it represents
+func (p *Provider) exchangeSubject(ctx context.Context, token, tokenType string) (*exchangeSubject, error) {
+	switch tokenType {
+	case tokenexchange.AccessTokenType, tokenexchange.JWTTokenType:
+		return p.exchangeSubjectFromAccessToken(ctx, token, tokenType)
+	case tokenexchange.IDTokenType:
+		claims, err := op.VerifyIDTokenHint(ctx, token, p.IDTokenHintVerifier(ctx))
+		if err != nil {
+			return nil, oidc.ErrInvalidGrant().WithDescription("invalid id_token").WithParent(err)
+		}
+		subject, err := p.exchangeSubjectFromUser(ctx, claims.GetSubject(), false)
+		if err != nil {
+			return nil, err
+		}
+		subject.audience = claims.GetAudience()
+		return subject, nil
+	case TokenTypeUserID:
+		subject, err := p.exchangeSubjectFromUser(ctx, token, true)
+		if err != nil {
+			return nil, err
+		}
+		subject.audience, err = p.grantedProjectIDs(ctx, subject.userID)
+		if err != nil {
+			return nil, err
+		}
+		return subject, nil
+	default:
+		return nil, oidc.ErrInvalidRequest().WithDescription("token type is not supported")
+	}
+}
+
+func (p *Provider) exchangeSubjectFromAccessToken(ctx context.Context, token, tokenType string) (*exchangeSubject, error) {
+	var tokenID, userID, mayAct string
+	if tokenType == tokenexchange.AccessTokenType {
+		if decrypted, err := p.Crypto().Decrypt(token); err == nil {
+			tokenID, userID, _ = strings.Cut(decrypted, ":")
+		}
+	}
+	if tokenID == "" {
+		claims, err := op.VerifyAccessToken(ctx, token, p.AccessTokenVerifier(ctx))
+		if err != nil {
+			return nil, oidc.ErrInvalidGrant().WithDescription("invalid token").WithParent(err)
+		}
+		tokenID, userID = claims.GetTokenID(), claims.GetSubject()
+		mayAct = mayActFromJWT(token)
+	}
+	tokenView, err := p.storage.repo.TokenByIDs(ctx, userID, tokenID)
+	if err != nil {
+		return nil, oidc.ErrInvalidGrant().WithDescription("token is not valid or has expired").WithParent(err)
+	}
+	return &exchangeSubject{
+		userID:        tokenView.UserID,
+		resourceOwner: tokenView.ResourceOwner,
+		audience:      tokenView.Audience,
+		scopes:        tokenView.Scopes,
+		expiration:    tokenView.Expiration,
+		actor:         tokenView.Actor,
+		mayAct:        mayAct,
+	}, nil
+}
+
+func (p *Provider) exchangeSubjectFromUser(ctx context.Context, userID string, impersonation bool) (*exchangeSubject, error) {
+	user, err := p.storage.query.GetUserByID(ctx, false, userID, false)
+	if err != nil {
+		return nil, oidc.ErrInvalidGrant().WithDescription("subject not found").WithParent(err)
+	}
+	if user.State != domain.UserStateActive && user.State != domain.UserStateInitial {
+		return nil, oidc.ErrInvalidGrant().WithDescription("subject is not active")
+	}
+	return &exchangeSubject{
+		userID:        user.ID,
+		resourceOwner: user.ResourceOwner,
+		impersonation: impersonation,
+	}, nil
+}
+
+// grantedProjectIDs returns the ids of all projects the user has a grant on,
+// which are the only possible audiences for impersonation
+func (p *Provider) grantedProjectIDs(ctx context.Context, userID string) ([]string, error) {
+	userIDQuery, err := query.NewUserGrantUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := p.storage.query.UserGrants(ctx, &query.UserGrantsQueries{Queries: []query.SearchQuery{userIDQuery}}, false)
+	if err != nil {
+		return nil, err
+	}
+	projectIDs := make([]string, 0, len(grants.UserGrants))
+	for _, grant := range grants.UserGrants {
+		if !containsString(projectIDs, grant.ProjectID) {
+			projectIDs = append(projectIDs, grant.ProjectID)
+		}
+	}
+	return projectIDs, nil
+}
+
+// mayActFromJWT returns the subject of the may_act claim (RFC 8693, section 4.4) of an already verified JWT,
+// which could have been set by an action
+func mayActFromJWT(token string) string {
+	claims := new(struct {
+		MayAct *struct {
+			Subject string `json:"sub"`
+		} `json:"may_act"`
+	})
+	if _, err := oidc.ParseToken(token, claims); err != nil || claims.MayAct == nil {
+		return ""
+	}
+	return claims.MayAct.Subject
+}
+
+// checkTokenExchangePolicy checks if the actor is allowed to act on behalf of (or impersonate) the subject:
+// users can always exchange their own tokens, may_act claims allow the specified actor to act on behalf of the subject
+// and all other actors must be listed in the token exchange policy of the organisation of the subject
+func (p *Provider) checkTokenExchangePolicy(ctx context.Context, actorID string, subject *exchangeSubject) error {
+	if !subject.impersonation && (actorID == subject.userID || actorID == subject.mayAct) {
+		return nil
+	}
+	policy, err := p.storage.query.TokenExchangePolicyByOrg(ctx, false, subject.resourceOwner, false)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	actors := make([]string, 0)
+	if policy != nil && subject.impersonation {
+		actors = policy.ImpersonationActors
+	} else if policy != nil {
+		actors = policy.DelegationActors
+	}
+	for _, allowed := range actors {
+		if allowed == actorID {
+			return nil
+		}
+	}
+	return &oidc.Error{ErrorType: errorAccessDenied, Description: "client is not allowed to exchange tokens of the subject"}
+}
+
+// exchangeScopes returns the scopes of the token to be issued:
+// on delegation they are restricted to the scopes of the subject_token (defaults to all of them),
+// if the subject has no scopes (id_token and user_id) only the user info, role and audience scopes can be requested
+func exchangeScopes(subject *exchangeSubject, requested []string) ([]string, error) {
+	if subject.scopes == nil {
+		if len(requested) == 0 {
+			return []string{oidc.ScopeOpenID}, nil
+		}
+		for _, scope := range requested {
+			if !isExchangeableScope(scope) {
+				return nil, oidc.ErrInvalidScope().WithDescription("scope %s cannot be requested for the subject_token_type", scope)
+			}
+		}
+		return requested, nil
+	}
+	if len(requested) == 0 {
+		return subject.scopes, nil
+	}
+	for _, scope := range requested {
+		if !containsString(subject.scopes, scope) {
+			return nil, oidc.ErrInvalidScope().WithDescription("scope %s was not granted to the subject_token", scope)
+		}
+	}
+	return requested, nil
+}
+
+func isExchangeableScope(scope string) bool {
+	switch scope {
+	case oidc.ScopeOpenID,
+		oidc.ScopeProfile,
+		oidc.ScopeEmail,
+		oidc.ScopePhone,
+		oidc.ScopeAddress,
+		ScopeUserMetaData,
+		ScopeResourceOwner:
+		return true
+	}
+	return strings.HasPrefix(scope, ScopeProjectRolePrefix) ||
+		strings.HasPrefix(scope, domain.ProjectIDScope) && strings.HasSuffix(scope, domain.AudSuffix)
+}
+
+// allowedExchangeAudience returns the audience the subject (and the actor_token if provided) is allowed to access:
+// the audience of the subject_token or for impersonation the projects the subject is granted
+func allowedExchangeAudience(subject, actor *exchangeSubject) []string {
+	if actor == nil || actor.audience == nil {
+		return subject.audience
+	}
+	allowed := make([]string, 0, len(subject.audience))
+	for _, aud := range subject.audience {
+		if containsString(actor.audience, aud) {
+			allowed = append(allowed, aud)
+		}
+	}
+	return allowed
+}
+
+// exchangeAudience returns the audience of the token to be issued (defaults to the allowed audience):
+// the requested audience as well as the projects of the audience scopes must be part of the allowed audience
+func exchangeAudience(ctx context.Context, allowed, requested, scopes []string) ([]string, error) {
+	audience := requested
+	if len(audience) == 0 {
+		audience = allowed
+	}
+	audience = domain.AddAudScopeToAudience(ctx, append([]string{}, audience...), scopes)
+	for _, aud := range audience {
+		if !containsString(allowed, aud) {
+			return nil, &oidc.Error{ErrorType: errorInvalidTarget, Description: "audience " + aud + " is not allowed for the subject"}
+		}
+	}
+	return audience, nil
+}
+
+// exchangedJWT creates a JWT access token for the issued token including the actor claim
+func (p *Provider) exchangedJWT(ctx context.Context, token *domain.Token, clientID string) (string, error) {
+	claims := oidc.NewAccessTokenClaims(op.IssuerFromContext(ctx), token.AggregateID, token.Audience, token.Expiration, token.TokenID, clientID, 0)
+	privateClaims, err := p.storage.GetPrivateClaimsFromScopes(ctx, token.AggregateID, "", token.Scopes)
+	if err != nil {
+		return "", err
+	}
+	if token.Actor != nil {
+		privateClaims = appendClaim(privateClaims, ClaimActor, actorClaim(token.Actor))
+	}
+	claims.SetPrivateClaims(privateClaims)
+	signingKey, err := p.storage.SigningKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	signer, err := op.SignerFromKey(signingKey)
+	if err != nil {
+		return "", err
+	}
+	return crypto.Sign(claims, signer)
+}
+
+// actorClaim maps the (nested) actor of a token to the act claim (RFC 8693, section 4.1)
+func actorClaim(actor *domain.TokenActor) map[string]interface{} {
+	claim := map[string]interface{}{
+		"sub": actor.UserID,
+	}
+	if actor.Issuer != "" {
+		claim["iss"] = actor.Issuer
+	}
+	if actor.Actor != nil {
+		claim[ClaimActor] = actorClaim(actor.Actor)
+	}
+	return claim
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
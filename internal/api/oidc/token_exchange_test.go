@@ -0,0 +1,212 @@
+package oidc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+)
+
+func Test_exchangeScopes(t *testing.T) {
+	type args struct {
+		subject   *exchangeSubject
+		requested []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "subject without scopes, default openid",
+			args: args{
+				subject: &exchangeSubject{},
+			},
+			want: []string{oidc.ScopeOpenID},
+		},
+		{
+			name: "subject without scopes, user info, role and audience scopes",
+			args: args{
+				subject:   &exchangeSubject{},
+				requested: []string{oidc.ScopeOpenID, oidc.ScopeEmail, ScopeUserMetaData, ScopeProjectRolePrefix + "admin", "urn:zitadel:iam:org:project:id:project1:aud"},
+			},
+			want: []string{oidc.ScopeOpenID, oidc.ScopeEmail, ScopeUserMetaData, ScopeProjectRolePrefix + "admin", "urn:zitadel:iam:org:project:id:project1:aud"},
+		},
+		{
+			name: "subject without scopes, offline_access not allowed",
+			args: args{
+				subject:   &exchangeSubject{},
+				requested: []string{oidc.ScopeOpenID, oidc.ScopeOfflineAccess},
+			},
+			wantErr: true,
+		},
+		{
+			name: "subject without scopes, org scope not allowed",
+			args: args{
+				subject:   &exchangeSubject{},
+				requested: []string{"urn:zitadel:iam:org:id:org1"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "subject with scopes, default all of them",
+			args: args{
+				subject: &exchangeSubject{scopes: []string{oidc.ScopeOpenID, oidc.ScopeProfile}},
+			},
+			want: []string{oidc.ScopeOpenID, oidc.ScopeProfile},
+		},
+		{
+			name: "subject with scopes, subset",
+			args: args{
+				subject:   &exchangeSubject{scopes: []string{oidc.ScopeOpenID, oidc.ScopeProfile}},
+				requested: []string{oidc.ScopeProfile},
+			},
+			want: []string{oidc.ScopeProfile},
+		},
+		{
+			name: "subject with scopes, not granted",
+			args: args{
+				subject:   &exchangeSubject{scopes: []string{oidc.ScopeOpenID}},
+				requested: []string{oidc.ScopeOpenID, oidc.ScopeEmail},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := exchangeScopes(tt.args.subject, tt.args.requested)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_allowedExchangeAudience(t *testing.T) {
+	type args struct {
+		subject *exchangeSubject
+		actor   *exchangeSubject
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{
+			name: "no actor token",
+			args: args{
+				subject: &exchangeSubject{audience: []string{"project1", "client1"}},
+			},
+			want: []string{"project1", "client1"},
+		},
+		{
+			name: "actor token, intersection",
+			args: args{
+				subject: &exchangeSubject{audience: []string{"project1", "client1"}},
+				actor:   &exchangeSubject{audience: []string{"project1", "project2"}},
+			},
+			want: []string{"project1"},
+		},
+		{
+			name: "actor token without audience",
+			args: args{
+				subject: &exchangeSubject{audience: []string{"project1"}},
+				actor:   &exchangeSubject{},
+			},
+			want: []string{"project1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, allowedExchangeAudience(tt.args.subject, tt.args.actor))
+		})
+	}
+}
+
+func Test_exchangeAudience(t *testing.T) {
+	type args struct {
+		allowed   []string
+		requested []string
+		scopes    []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "default allowed audience",
+			args: args{
+				allowed: []string{"project1", "client1"},
+			},
+			want: []string{"project1", "client1"},
+		},
+		{
+			name: "requested subset",
+			args: args{
+				allowed:   []string{"project1", "client1"},
+				requested: []string{"project1"},
+			},
+			want: []string{"project1"},
+		},
+		{
+			name: "requested audience not allowed",
+			args: args{
+				allowed:   []string{"project1"},
+				requested: []string{"project2"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "audience scope not allowed",
+			args: args{
+				allowed: []string{"project1"},
+				scopes:  []string{"urn:zitadel:iam:org:project:id:project2:aud"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "zitadel audience scope not allowed",
+			args: args{
+				allowed: []string{"project1"},
+				scopes:  []string{"urn:zitadel:iam:org:project:id:zitadel:aud"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "zitadel audience scope allowed by subject",
+			args: args{
+				allowed: []string{"project1", "zitadelProject"},
+				scopes:  []string{"urn:zitadel:iam:org:project:id:zitadel:aud"},
+			},
+			want: []string{"project1", "zitadelProject"},
+		},
+		{
+			name: "no allowed audience",
+			args: args{
+				requested: []string{"project1"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := authz.WithConsole(context.Background(), "zitadelProject", "consoleApp")
+			got, err := exchangeAudience(ctx, tt.args.allowed, tt.args.requested, tt.args.scopes)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -162,6 +162,8 @@ func (p *Storage) getMaxKeySequence(ctx context.Context) (uint64, error) {
 	)
 }
 
+// certificateToCertificateAndKey only supports RSA keys, as the saml library only signs with them.
+// The signing key policy only applies to the OIDC signing keys, so the certificates are always generated with RSA keys.
 func (p *Storage) certificateToCertificateAndKey(certificate query.Certificate) (_ *key.CertificateAndKey, err error) {
 	keyData, err := crypto.Decrypt(certificate.Key(), p.encAlg)
 	if err != nil {
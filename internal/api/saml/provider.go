@@ -74,7 +74,6 @@ func NewProvider(
 	}
 
 	return provider.NewProvider(
-		ctx,
 		provStorage,
 		HandlerPrefix,
 		conf.ProviderConfig,
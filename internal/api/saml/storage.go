@@ -10,6 +10,8 @@ import (
 	"github.com/zitadel/saml/pkg/provider/serviceprovider"
 	"github.com/zitadel/saml/pkg/provider/xml/samlp"
 
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/actions/trigger"
 	"github.com/zitadel/zitadel/internal/api/http/middleware"
 	"github.com/zitadel/zitadel/internal/auth/repository"
 	"github.com/zitadel/zitadel/internal/command"
@@ -118,7 +120,7 @@ func (p *Storage) AuthRequestByID(ctx context.Context, id string) (_ models.Auth
 	return AuthRequestFromBusiness(resp)
 }
 
-func (p *Storage) SetUserinfoWithUserID(ctx context.Context, userinfo models.AttributeSetter, userID string, attributes []int) (err error) {
+func (p *Storage) SetUserinfoWithUserID(ctx context.Context, applicationID string, userinfo models.AttributeSetter, userID string, attributes []int) (err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 	user, err := p.query.GetUserByID(ctx, true, userID, false)
@@ -126,8 +128,7 @@ func (p *Storage) SetUserinfoWithUserID(ctx context.Context, userinfo models.Att
 		return err
 	}
 
-	setUserinfo(user, userinfo, attributes)
-	return nil
+	return p.setUserinfo(ctx, user, userinfo, attributes)
 }
 
 func (p *Storage) SetUserinfoWithLoginName(ctx context.Context, userinfo models.AttributeSetter, loginName string, attributes []int) (err error) {
@@ -143,11 +144,15 @@ func (p *Storage) SetUserinfoWithLoginName(ctx context.Context, userinfo models.
 		return err
 	}
 
-	setUserinfo(user, userinfo, attributes)
-	return nil
+	return p.setUserinfo(ctx, user, userinfo, attributes)
+}
+
+func (p *Storage) setUserinfo(ctx context.Context, user *query.User, userinfo models.AttributeSetter, attributes []int) error {
+	setUserinfoAttributes(user, userinfo, attributes)
+	return p.customiseResponseFlow(ctx, user, userinfo)
 }
 
-func setUserinfo(user *query.User, userinfo models.AttributeSetter, attributes []int) {
+func setUserinfoAttributes(user *query.User, userinfo models.AttributeSetter, attributes []int) {
 	if len(attributes) == 0 {
 		userinfo.SetUsername(user.PreferredLoginName)
 		userinfo.SetUserID(user.ID)
@@ -185,3 +190,59 @@ func setUserinfo(user *query.User, userinfo models.AttributeSetter, attributes [
 		}
 	}
 }
+
+func (p *Storage) customiseResponseFlow(ctx context.Context, user *query.User, userinfo models.AttributeSetter) error {
+	queriedActions, err := p.query.GetActiveActionsByFlowAndTriggerType(ctx, domain.FlowTypeCustomiseSAMLResponse, domain.TriggerTypePreSAMLResponseCreation, user.ResourceOwner, false)
+	if err != nil {
+		return err
+	}
+	if len(queriedActions) == 0 {
+		return nil
+	}
+
+	ctxFields := actions.SetContextFields(
+		trigger.PreSAMLResponseCreationContextFields(
+			func() (*query.User, error) {
+				return user, nil
+			},
+			func() (*query.UserMetadataList, error) {
+				resourceOwnerQuery, err := query.NewUserMetadataResourceOwnerSearchQuery(user.ResourceOwner)
+				if err != nil {
+					return nil, err
+				}
+				return p.query.SearchUserMetadata(
+					ctx,
+					true,
+					user.ID,
+					&query.UserMetadataSearchQueries{Queries: []query.SearchQuery{resourceOwnerQuery}},
+					false,
+				)
+			},
+			func() (*query.UserGrants, error) {
+				userIDQuery, err := query.NewUserGrantUserIDSearchQuery(user.ID)
+				if err != nil {
+					return nil, err
+				}
+				return p.query.UserGrants(ctx, &query.UserGrantsQueries{Queries: []query.SearchQuery{userIDQuery}}, false)
+			},
+		)...,
+	)
+
+	for _, action := range queriedActions {
+		actionCtx, cancel := context.WithTimeout(ctx, action.Timeout())
+
+		err = actions.Run(
+			actionCtx,
+			ctxFields,
+			actions.WithAPIFields(trigger.PreSAMLResponseCreationAPIFields(userinfo)...),
+			action.Script,
+			action.Name,
+			append(actions.ActionToOptions(action), actions.WithHTTP(actionCtx))...,
+		)
+		cancel()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
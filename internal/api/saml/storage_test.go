@@ -0,0 +1,79 @@
+package saml
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/saml/pkg/provider"
+	"github.com/zitadel/saml/pkg/provider/xml/saml"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/actions/trigger"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func Test_customiseResponseAttributes(t *testing.T) {
+	actions.SetLogstoreService(logstore.New(nil, nil, nil))
+	tests := []struct {
+		name   string
+		script string
+		want   []*saml.AttributeType
+	}{
+		{
+			name:   "no attributes set, default attributes",
+			script: `function customise(ctx, api) {}`,
+			want: []*saml.AttributeType{
+				{Name: "Email", NameFormat: "urn:oasis:names:tc:SAML:2.0:attrname-format:basic", AttributeValue: []string{"email@test.ch"}},
+				{Name: "SurName", NameFormat: "urn:oasis:names:tc:SAML:2.0:attrname-format:basic", AttributeValue: []string{"lastname"}},
+				{Name: "FirstName", NameFormat: "urn:oasis:names:tc:SAML:2.0:attrname-format:basic", AttributeValue: []string{"firstname"}},
+				{Name: "FullName", NameFormat: "urn:oasis:names:tc:SAML:2.0:attrname-format:basic", AttributeValue: []string{"display name"}},
+				{Name: "UserName", NameFormat: "urn:oasis:names:tc:SAML:2.0:attrname-format:basic", AttributeValue: []string{"username"}},
+				{Name: "UserID", NameFormat: "urn:oasis:names:tc:SAML:2.0:attrname-format:basic", AttributeValue: []string{"user1"}},
+			},
+		},
+		{
+			name: "attributes overridden and custom attribute set, in response",
+			script: `function customise(ctx, api) {
+	api.v1.attributes.setEmail('changed@test.ch');
+	api.v1.attributes.setCustomAttribute('roles', 'urn:oasis:names:tc:SAML:2.0:attrname-format:basic', 'admin');
+	api.v1.attributes.setCustomAttribute('groups', 'urn:oasis:names:tc:SAML:2.0:attrname-format:uri', 'a');
+	api.v1.attributes.setCustomAttribute('groups', 'urn:oasis:names:tc:SAML:2.0:attrname-format:uri', 'b', 'c');
+}`,
+			want: []*saml.AttributeType{
+				{Name: "Email", NameFormat: "urn:oasis:names:tc:SAML:2.0:attrname-format:basic", AttributeValue: []string{"changed@test.ch"}},
+				{Name: "SurName", NameFormat: "urn:oasis:names:tc:SAML:2.0:attrname-format:basic", AttributeValue: []string{"lastname"}},
+				{Name: "FirstName", NameFormat: "urn:oasis:names:tc:SAML:2.0:attrname-format:basic", AttributeValue: []string{"firstname"}},
+				{Name: "FullName", NameFormat: "urn:oasis:names:tc:SAML:2.0:attrname-format:basic", AttributeValue: []string{"display name"}},
+				{Name: "UserName", NameFormat: "urn:oasis:names:tc:SAML:2.0:attrname-format:basic", AttributeValue: []string{"username"}},
+				{Name: "UserID", NameFormat: "urn:oasis:names:tc:SAML:2.0:attrname-format:basic", AttributeValue: []string{"user1"}},
+				{Name: "roles", NameFormat: "urn:oasis:names:tc:SAML:2.0:attrname-format:basic", AttributeValue: []string{"admin"}},
+				{Name: "groups", NameFormat: "urn:oasis:names:tc:SAML:2.0:attrname-format:uri", AttributeValue: []string{"b", "c"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attributes := &provider.Attributes{}
+			setUserinfoAttributes(&query.User{
+				ID:                 "user1",
+				PreferredLoginName: "username",
+				Human: &query.Human{
+					FirstName:   "firstname",
+					LastName:    "lastname",
+					DisplayName: "display name",
+					Email:       "email@test.ch",
+				},
+			}, attributes, nil)
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			err := actions.Run(ctx, actions.SetContextFields(), actions.WithAPIFields(trigger.PreSAMLResponseCreationAPIFields(attributes)...), tt.script, "customise")
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.want, attributes.GetSAML())
+		})
+	}
+}
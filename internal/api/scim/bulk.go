@@ -0,0 +1,179 @@
+package scim
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const maxBulkOperations = 1000
+
+var bulkIDReference = regexp.MustCompile(`bulkId:([^"/\s]+)`)
+
+type bulkRequest struct {
+	Schemas      []string         `json:"schemas"`
+	FailOnErrors int              `json:"failOnErrors"`
+	Operations   []*bulkOperation `json:"Operations"`
+}
+
+type bulkOperation struct {
+	Method  string          `json:"method"`
+	BulkID  string          `json:"bulkId,omitempty"`
+	Version string          `json:"version,omitempty"`
+	Path    string          `json:"path"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+type bulkResponse struct {
+	Schemas    []string                 `json:"schemas"`
+	Operations []*bulkOperationResponse `json:"Operations"`
+}
+
+type bulkOperationResponse struct {
+	Method   string          `json:"method"`
+	BulkID   string          `json:"bulkId,omitempty"`
+	Version  string          `json:"version,omitempty"`
+	Location string          `json:"location,omitempty"`
+	Response json.RawMessage `json:"response,omitempty"`
+	Status   string          `json:"status"`
+}
+
+func (b *bulkRequest) validate() error {
+	if !containsSchema(b.Schemas, schemaBulkRequest) {
+		return newError(nil, "SCIM-Vai2a", "schema "+schemaBulkRequest+" missing", scimTypeInvalidSyntax)
+	}
+	if len(b.Operations) > maxBulkOperations {
+		return newError(nil, "SCIM-oNg6e", "too many operations, the maximum is "+strconv.Itoa(maxBulkOperations), scimTypeTooMany)
+	}
+	return nil
+}
+
+// bulk executes the operations one after another on the router of the handler (RFC 7644, section 3.7)
+// so each operation is authorized and validated like a single request
+// references to resources created in the same request (`bulkId:<id>`) are replaced by the id of the created resource
+func (h *Handler) bulk(r *http.Request) (interface{}, int, error) {
+	request := new(bulkRequest)
+	if err := readJSON(r, request); err != nil {
+		return nil, 0, err
+	}
+	if err := request.validate(); err != nil {
+		return nil, 0, err
+	}
+	response := &bulkResponse{
+		Schemas:    []string{schemaBulkResponse},
+		Operations: make([]*bulkOperationResponse, 0, len(request.Operations)),
+	}
+	createdIDs := make(map[string]string)
+	errorCount := 0
+	for _, operation := range request.Operations {
+		result := h.bulkOperation(r, operation, createdIDs)
+		response.Operations = append(response.Operations, result)
+		if status, _ := strconv.Atoi(result.Status); status >= http.StatusBadRequest {
+			errorCount++
+		}
+		if request.FailOnErrors > 0 && errorCount >= request.FailOnErrors {
+			break
+		}
+	}
+	return response, http.StatusOK, nil
+}
+
+func (h *Handler) bulkOperation(r *http.Request, operation *bulkOperation, createdIDs map[string]string) *bulkOperationResponse {
+	result := &bulkOperationResponse{
+		Method:  operation.Method,
+		BulkID:  operation.BulkID,
+		Version: operation.Version,
+	}
+	method := strings.ToUpper(operation.Method)
+	if method == http.MethodPost && operation.BulkID == "" {
+		return bulkError(result, newError(nil, "SCIM-Ohg4i", "bulkId is required for POST operations", scimTypeInvalidValue))
+	}
+	if !strings.HasPrefix(operation.Path, "/Users") && !strings.HasPrefix(operation.Path, "/Groups") {
+		return bulkError(result, newError(nil, "SCIM-iaN5o", "invalid path "+operation.Path, scimTypeInvalidPath))
+	}
+	path, err := resolveBulkIDs(operation.Path, createdIDs)
+	if err != nil {
+		return bulkError(result, err)
+	}
+	data, err := resolveBulkIDs(string(operation.Data), createdIDs)
+	if err != nil {
+		return bulkError(result, err)
+	}
+	subRequest, err := http.NewRequestWithContext(r.Context(), method, "/"+orgID(r)+path, strings.NewReader(data))
+	if err != nil {
+		return bulkError(result, newError(err, "SCIM-ooR1e", "invalid operation", scimTypeInvalidSyntax))
+	}
+	subRequest.Header = r.Header.Clone()
+	subRequest.Host = r.Host
+	subRequest.RequestURI = HandlerPrefix + subRequest.URL.Path
+
+	recorder := newBulkResponseWriter()
+	h.router.ServeHTTP(recorder, subRequest)
+
+	result.Status = strconv.Itoa(recorder.status)
+	result.Location = recorder.header.Get("Location")
+	if recorder.status >= http.StatusBadRequest {
+		result.Response = recorder.body.Bytes()
+		return result
+	}
+	if method == http.MethodPost {
+		created := struct {
+			ID string `json:"id"`
+		}{}
+		if err = json.Unmarshal(recorder.body.Bytes(), &created); err == nil {
+			createdIDs[operation.BulkID] = created.ID
+		}
+	}
+	return result
+}
+
+// resolveBulkIDs replaces all references to resources created in the same bulk request
+func resolveBulkIDs(value string, createdIDs map[string]string) (string, error) {
+	var err error
+	resolved := bulkIDReference.ReplaceAllStringFunc(value, func(reference string) string {
+		id, ok := createdIDs[strings.TrimPrefix(reference, "bulkId:")]
+		if !ok {
+			err = newError(nil, "SCIM-Eir0o", "unresolved reference "+reference, scimTypeInvalidValue)
+			return reference
+		}
+		return id
+	})
+	return resolved, err
+}
+
+func bulkError(result *bulkOperationResponse, err error) *bulkOperationResponse {
+	response, status := errorToResponse(err)
+	result.Status = strconv.Itoa(status)
+	result.Response, _ = json.Marshal(response)
+	return result
+}
+
+// bulkResponseWriter captures the response of a single operation of a bulk request
+type bulkResponseWriter struct {
+	header http.Header
+	status int
+	body   *bytes.Buffer
+}
+
+func newBulkResponseWriter() *bulkResponseWriter {
+	return &bulkResponseWriter{
+		header: make(http.Header),
+		status: http.StatusOK,
+		body:   new(bytes.Buffer),
+	}
+}
+
+func (w *bulkResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *bulkResponseWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bulkResponseWriter) WriteHeader(status int) {
+	w.status = status
+}
@@ -0,0 +1,92 @@
+package scim
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/zitadel/logging"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	scimTypeInvalidFilter = "invalidFilter"
+	scimTypeInvalidSyntax = "invalidSyntax"
+	scimTypeInvalidPath   = "invalidPath"
+	scimTypeInvalidValue  = "invalidValue"
+	scimTypeNoTarget      = "noTarget"
+	scimTypeUniqueness    = "uniqueness"
+	scimTypeMutability    = "mutability"
+	scimTypeTooMany       = "tooMany"
+)
+
+// scimError is an invalid argument error which carries the scimType of RFC 7644, section 3.12
+type scimError struct {
+	*caos_errs.InvalidArgumentError
+	scimType string
+}
+
+func newError(parent error, id, detail, scimType string) error {
+	return &scimError{
+		InvalidArgumentError: caos_errs.ThrowInvalidArgument(parent, id, detail).(*caos_errs.InvalidArgumentError),
+		scimType:             scimType,
+	}
+}
+
+type errorResponse struct {
+	Schemas  []string `json:"schemas"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+	Status   string   `json:"status"`
+}
+
+func errorToResponse(err error) (*errorResponse, int) {
+	status, scimType := errorStatus(err)
+	detail := err.Error()
+	caosErr := new(caos_errs.CaosError)
+	if errors.As(err, &caosErr) {
+		detail = caosErr.GetMessage()
+	}
+	return &errorResponse{
+		Schemas:  []string{schemaError},
+		ScimType: scimType,
+		Detail:   detail,
+		Status:   strconv.Itoa(status),
+	}, status
+}
+
+func errorStatus(err error) (int, string) {
+	scimErr := new(scimError)
+	if errors.As(err, &scimErr) {
+		return http.StatusBadRequest, scimErr.scimType
+	}
+	switch {
+	case caos_errs.IsNotFound(err):
+		return http.StatusNotFound, ""
+	case caos_errs.IsErrorAlreadyExists(err):
+		return http.StatusConflict, scimTypeUniqueness
+	case caos_errs.IsErrorInvalidArgument(err):
+		return http.StatusBadRequest, scimTypeInvalidValue
+	case caos_errs.IsPreconditionFailed(err):
+		return http.StatusBadRequest, scimTypeInvalidValue
+	case caos_errs.IsUnauthenticated(err):
+		return http.StatusUnauthorized, ""
+	case caos_errs.IsPermissionDenied(err):
+		return http.StatusForbidden, ""
+	case caos_errs.IsUnimplemented(err):
+		return http.StatusNotImplemented, ""
+	case caos_errs.IsResourceExhausted(err):
+		return http.StatusTooManyRequests, ""
+	default:
+		return http.StatusInternalServerError, ""
+	}
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	response, status := errorToResponse(err)
+	if status == http.StatusInternalServerError {
+		logging.WithFields("uri", r.RequestURI).WithError(err).Warn("error occurred on scim api")
+	}
+	writeJSON(w, response, status)
+}
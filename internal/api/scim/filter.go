@@ -0,0 +1,476 @@
+package scim
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+type compareOperator string
+
+const (
+	compareEqual          compareOperator = "eq"
+	compareNotEqual       compareOperator = "ne"
+	compareContains       compareOperator = "co"
+	compareStartsWith     compareOperator = "sw"
+	compareEndsWith       compareOperator = "ew"
+	compareGreater        compareOperator = "gt"
+	compareGreaterOrEqual compareOperator = "ge"
+	compareLess           compareOperator = "lt"
+	compareLessOrEqual    compareOperator = "le"
+	comparePresent        compareOperator = "pr"
+)
+
+func (o compareOperator) valid() bool {
+	switch o {
+	case compareEqual, compareNotEqual, compareContains, compareStartsWith, compareEndsWith,
+		compareGreater, compareGreaterOrEqual, compareLess, compareLessOrEqual, comparePresent:
+		return true
+	}
+	return false
+}
+
+type logicalOperator string
+
+const (
+	logicalAnd logicalOperator = "and"
+	logicalOr  logicalOperator = "or"
+	logicalNot logicalOperator = "not"
+)
+
+// filterExpression is the parsed form of a SCIM filter (RFC 7644, section 3.4.2.2)
+// it's either a logicalExpression or an attributeExpression
+type filterExpression interface {
+	isFilterExpression()
+}
+
+// logicalExpression combines two expressions with `and` / `or`
+// or negates the left expression with `not`
+type logicalExpression struct {
+	operator logicalOperator
+	left     filterExpression
+	right    filterExpression
+}
+
+func (*logicalExpression) isFilterExpression() {}
+
+// attributeExpression compares the value of an attribute
+// the attribute is lower cased and contains the sub attribute separated by a dot (e.g. name.givenname)
+type attributeExpression struct {
+	attribute string
+	operator  compareOperator
+	value     interface{}
+}
+
+func (*attributeExpression) isFilterExpression() {}
+
+func invalidFilter(parent error, id, detail string) error {
+	return newError(parent, id, detail, scimTypeInvalidFilter)
+}
+
+// parseFilter parses a SCIM filter expression like `userName eq "gigi" and not (emails co "zitadel.ch")`
+func parseFilter(filter string) (filterExpression, error) {
+	tokens, err := tokenizeFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, invalidFilter(nil, "SCIM-Cu8ie", "filter is empty")
+	}
+	p := &filterParser{tokens: tokens}
+	expression, err := p.parseOr("")
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, invalidFilter(nil, "SCIM-Oo0ai", "unexpected token "+p.peek().value)
+	}
+	return expression, nil
+}
+
+type filterTokenType int
+
+const (
+	filterTokenWord filterTokenType = iota
+	filterTokenString
+	filterTokenOpenParenthesis
+	filterTokenCloseParenthesis
+	filterTokenOpenBracket
+	filterTokenCloseBracket
+)
+
+type filterToken struct {
+	typ   filterTokenType
+	value string
+}
+
+func tokenizeFilter(filter string) ([]*filterToken, error) {
+	tokens := make([]*filterToken, 0)
+	for i := 0; i < len(filter); {
+		switch c := filter[i]; c {
+		case ' ', '\t', '\n', '\r':
+			i++
+		case '(':
+			tokens = append(tokens, &filterToken{typ: filterTokenOpenParenthesis, value: "("})
+			i++
+		case ')':
+			tokens = append(tokens, &filterToken{typ: filterTokenCloseParenthesis, value: ")"})
+			i++
+		case '[':
+			tokens = append(tokens, &filterToken{typ: filterTokenOpenBracket, value: "["})
+			i++
+		case ']':
+			tokens = append(tokens, &filterToken{typ: filterTokenCloseBracket, value: "]"})
+			i++
+		case '"':
+			end := i + 1
+			for ; end < len(filter); end++ {
+				if filter[end] == '\\' {
+					end++
+					continue
+				}
+				if filter[end] == '"' {
+					break
+				}
+			}
+			if end >= len(filter) {
+				return nil, invalidFilter(nil, "SCIM-ahP8e", "unterminated string")
+			}
+			var value string
+			if err := json.Unmarshal([]byte(filter[i:end+1]), &value); err != nil {
+				return nil, invalidFilter(err, "SCIM-iek3O", "invalid string")
+			}
+			tokens = append(tokens, &filterToken{typ: filterTokenString, value: value})
+			i = end + 1
+		default:
+			end := i
+			for ; end < len(filter) && !strings.ContainsRune(" \t\n\r()[]\"", rune(filter[end])); end++ {
+			}
+			tokens = append(tokens, &filterToken{typ: filterTokenWord, value: filter[i:end]})
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens   []*filterToken
+	position int
+}
+
+func (p *filterParser) done() bool {
+	return p.position >= len(p.tokens)
+}
+
+func (p *filterParser) peek() *filterToken {
+	if p.done() {
+		return nil
+	}
+	return p.tokens[p.position]
+}
+
+func (p *filterParser) next() *filterToken {
+	token := p.peek()
+	p.position++
+	return token
+}
+
+func (p *filterParser) nextIsKeyword(keyword logicalOperator) bool {
+	token := p.peek()
+	return token != nil && token.typ == filterTokenWord && strings.EqualFold(token.value, string(keyword))
+}
+
+func (p *filterParser) expect(typ filterTokenType) error {
+	token := p.next()
+	if token == nil {
+		return invalidFilter(nil, "SCIM-Ohz5u", "unexpected end of filter")
+	}
+	if token.typ != typ {
+		return invalidFilter(nil, "SCIM-eiX9a", "unexpected token "+token.value)
+	}
+	return nil
+}
+
+// parseOr parses expressions with the lowest precedence
+// prefix is set for expressions inside a value path (e.g. emails[type eq "work"])
+func (p *filterParser) parseOr(prefix string) (filterExpression, error) {
+	left, err := p.parseAnd(prefix)
+	if err != nil {
+		return nil, err
+	}
+	for p.nextIsKeyword(logicalOr) {
+		p.next()
+		right, err := p.parseAnd(prefix)
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalExpression{operator: logicalOr, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd(prefix string) (filterExpression, error) {
+	left, err := p.parseNot(prefix)
+	if err != nil {
+		return nil, err
+	}
+	for p.nextIsKeyword(logicalAnd) {
+		p.next()
+		right, err := p.parseNot(prefix)
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalExpression{operator: logicalAnd, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseNot(prefix string) (filterExpression, error) {
+	if !p.nextIsKeyword(logicalNot) {
+		return p.parseTerm(prefix)
+	}
+	p.next()
+	if err := p.expect(filterTokenOpenParenthesis); err != nil {
+		return nil, err
+	}
+	expression, err := p.parseOr(prefix)
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expect(filterTokenCloseParenthesis); err != nil {
+		return nil, err
+	}
+	return &logicalExpression{operator: logicalNot, left: expression}, nil
+}
+
+func (p *filterParser) parseTerm(prefix string) (filterExpression, error) {
+	token := p.next()
+	if token == nil {
+		return nil, invalidFilter(nil, "SCIM-ooS4a", "unexpected end of filter")
+	}
+	switch token.typ {
+	case filterTokenOpenParenthesis:
+		expression, err := p.parseOr(prefix)
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expect(filterTokenCloseParenthesis); err != nil {
+			return nil, err
+		}
+		return expression, nil
+	case filterTokenWord:
+		attribute := prefix + normalizeAttribute(token.value)
+		if next := p.peek(); next != nil && next.typ == filterTokenOpenBracket {
+			p.next()
+			expression, err := p.parseOr(attribute + ".")
+			if err != nil {
+				return nil, err
+			}
+			if err = p.expect(filterTokenCloseBracket); err != nil {
+				return nil, err
+			}
+			return expression, nil
+		}
+		return p.parseComparison(attribute)
+	default:
+		return nil, invalidFilter(nil, "SCIM-Ieph4", "unexpected token "+token.value)
+	}
+}
+
+func (p *filterParser) parseComparison(attribute string) (filterExpression, error) {
+	token := p.next()
+	if token == nil || token.typ != filterTokenWord {
+		return nil, invalidFilter(nil, "SCIM-uG5ae", "missing operator for "+attribute)
+	}
+	operator := compareOperator(strings.ToLower(token.value))
+	if !operator.valid() {
+		return nil, invalidFilter(nil, "SCIM-yoo1A", "unknown operator "+token.value)
+	}
+	if operator == comparePresent {
+		return &attributeExpression{attribute: attribute, operator: operator}, nil
+	}
+	token = p.next()
+	if token == nil {
+		return nil, invalidFilter(nil, "SCIM-Pai4i", "missing value for "+attribute)
+	}
+	value, err := filterValue(token)
+	if err != nil {
+		return nil, err
+	}
+	return &attributeExpression{attribute: attribute, operator: operator, value: value}, nil
+}
+
+func filterValue(token *filterToken) (interface{}, error) {
+	switch token.typ {
+	case filterTokenString:
+		return token.value, nil
+	case filterTokenWord:
+		switch strings.ToLower(token.value) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+		number, err := strconv.ParseFloat(token.value, 64)
+		if err != nil {
+			return nil, invalidFilter(err, "SCIM-ohB2e", "invalid value "+token.value)
+		}
+		return number, nil
+	default:
+		return nil, invalidFilter(nil, "SCIM-Ka6oo", "invalid value "+token.value)
+	}
+}
+
+// normalizeAttribute lower cases the attribute and removes the urn of the core schemas
+func normalizeAttribute(attribute string) string {
+	attribute = strings.ToLower(attribute)
+	for _, schema := range []string{schemaUser, schemaGroup} {
+		attribute = strings.TrimPrefix(attribute, strings.ToLower(schema)+":")
+	}
+	return attribute
+}
+
+// matches evaluates the expression against the JSON representation of a resource
+func matches(expression filterExpression, resource map[string]interface{}) bool {
+	switch e := expression.(type) {
+	case *logicalExpression:
+		switch e.operator {
+		case logicalAnd:
+			return matches(e.left, resource) && matches(e.right, resource)
+		case logicalOr:
+			return matches(e.left, resource) || matches(e.right, resource)
+		case logicalNot:
+			return !matches(e.left, resource)
+		}
+	case *attributeExpression:
+		values := attributeValues(resource, attributePath(e.attribute))
+		if len(values) == 0 {
+			return e.operator == compareNotEqual && e.value != nil
+		}
+		for _, value := range values {
+			if compare(value, e.operator, e.value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// attributePath splits the attribute into its segments
+// the urn of an extension (e.g. urn:zitadel:params:scim:schemas:extension:2.0:group:projectid) is kept as first segment
+func attributePath(attribute string) []string {
+	if !strings.HasPrefix(attribute, "urn:") {
+		return strings.Split(attribute, ".")
+	}
+	separator := strings.LastIndex(attribute, ":")
+	return append([]string{attribute[:separator]}, strings.Split(attribute[separator+1:], ".")...)
+}
+
+// attributeValues returns all values of the (sub) attribute
+// multi valued attributes are flattened and the `value` sub attribute is used if no sub attribute is provided
+func attributeValues(resource map[string]interface{}, path []string) []interface{} {
+	value, ok := lookupAttribute(resource, path[0])
+	if !ok {
+		return nil
+	}
+	switch v := value.(type) {
+	case []interface{}:
+		values := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			object, ok := item.(map[string]interface{})
+			if !ok {
+				values = append(values, item)
+				continue
+			}
+			subPath := path[1:]
+			if len(subPath) == 0 {
+				subPath = []string{"value"}
+			}
+			values = append(values, attributeValues(object, subPath)...)
+		}
+		return values
+	case map[string]interface{}:
+		if len(path) == 1 {
+			return nil
+		}
+		return attributeValues(v, path[1:])
+	default:
+		if len(path) > 1 {
+			return nil
+		}
+		return []interface{}{v}
+	}
+}
+
+func lookupAttribute(resource map[string]interface{}, name string) (interface{}, bool) {
+	for key, value := range resource {
+		if strings.EqualFold(key, name) {
+			return value, value != nil
+		}
+	}
+	return nil, false
+}
+
+func compare(value interface{}, operator compareOperator, expected interface{}) bool {
+	if operator == comparePresent {
+		return value != nil && value != ""
+	}
+	switch v := value.(type) {
+	case string:
+		e, ok := expected.(string)
+		if !ok {
+			return operator == compareNotEqual
+		}
+		v, e = strings.ToLower(v), strings.ToLower(e)
+		switch operator {
+		case compareEqual:
+			return v == e
+		case compareNotEqual:
+			return v != e
+		case compareContains:
+			return strings.Contains(v, e)
+		case compareStartsWith:
+			return strings.HasPrefix(v, e)
+		case compareEndsWith:
+			return strings.HasSuffix(v, e)
+		case compareGreater:
+			return v > e
+		case compareGreaterOrEqual:
+			return v >= e
+		case compareLess:
+			return v < e
+		case compareLessOrEqual:
+			return v <= e
+		}
+	case bool:
+		e, ok := expected.(bool)
+		switch operator {
+		case compareEqual:
+			return ok && v == e
+		case compareNotEqual:
+			return !ok || v != e
+		}
+	case float64:
+		e, ok := expected.(float64)
+		if !ok {
+			return operator == compareNotEqual
+		}
+		switch operator {
+		case compareEqual:
+			return v == e
+		case compareNotEqual:
+			return v != e
+		case compareGreater:
+			return v > e
+		case compareGreaterOrEqual:
+			return v >= e
+		case compareLess:
+			return v < e
+		case compareLessOrEqual:
+			return v <= e
+		}
+	}
+	return false
+}
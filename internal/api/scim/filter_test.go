@@ -0,0 +1,176 @@
+package scim
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseFilter(t *testing.T) {
+	type args struct {
+		filter string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    filterExpression
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			args:    args{filter: ""},
+			wantErr: true,
+		},
+		{
+			name: "equals",
+			args: args{filter: `userName eq "gigi"`},
+			want: &attributeExpression{attribute: "username", operator: compareEqual, value: "gigi"},
+		},
+		{
+			name: "operator case insensitive",
+			args: args{filter: `userName EQ "gigi"`},
+			want: &attributeExpression{attribute: "username", operator: compareEqual, value: "gigi"},
+		},
+		{
+			name: "core schema urn",
+			args: args{filter: `urn:ietf:params:scim:schemas:core:2.0:User:name.givenName sw "gi"`},
+			want: &attributeExpression{attribute: "name.givenname", operator: compareStartsWith, value: "gi"},
+		},
+		{
+			name: "boolean",
+			args: args{filter: `active eq true`},
+			want: &attributeExpression{attribute: "active", operator: compareEqual, value: true},
+		},
+		{
+			name: "present",
+			args: args{filter: `phoneNumbers pr`},
+			want: &attributeExpression{attribute: "phonenumbers", operator: comparePresent},
+		},
+		{
+			name: "and binds stronger than or",
+			args: args{filter: `userName eq "a" or userName eq "b" and active eq false`},
+			want: &logicalExpression{
+				operator: logicalOr,
+				left:     &attributeExpression{attribute: "username", operator: compareEqual, value: "a"},
+				right: &logicalExpression{
+					operator: logicalAnd,
+					left:     &attributeExpression{attribute: "username", operator: compareEqual, value: "b"},
+					right:    &attributeExpression{attribute: "active", operator: compareEqual, value: false},
+				},
+			},
+		},
+		{
+			name: "not with parenthesis",
+			args: args{filter: `not (emails co "zitadel.ch")`},
+			want: &logicalExpression{
+				operator: logicalNot,
+				left:     &attributeExpression{attribute: "emails", operator: compareContains, value: "zitadel.ch"},
+			},
+		},
+		{
+			name: "value path",
+			args: args{filter: `emails[type eq "work" and value ew "@zitadel.ch"]`},
+			want: &logicalExpression{
+				operator: logicalAnd,
+				left:     &attributeExpression{attribute: "emails.type", operator: compareEqual, value: "work"},
+				right:    &attributeExpression{attribute: "emails.value", operator: compareEndsWith, value: "@zitadel.ch"},
+			},
+		},
+		{
+			name:    "unknown operator",
+			args:    args{filter: `userName is "gigi"`},
+			wantErr: true,
+		},
+		{
+			name:    "missing value",
+			args:    args{filter: `userName eq`},
+			wantErr: true,
+		},
+		{
+			name:    "unterminated string",
+			args:    args{filter: `userName eq "gigi`},
+			wantErr: true,
+		},
+		{
+			name:    "missing parenthesis",
+			args:    args{filter: `(userName eq "gigi"`},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFilter(tt.args.filter)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_matches(t *testing.T) {
+	resource := make(map[string]interface{})
+	err := json.Unmarshal([]byte(`{
+		"displayName": "Admins",
+		"members": [{"value": "user1", "display": "Gigi"}, {"value": "user2"}],
+		"urn:zitadel:params:scim:schemas:extension:2.0:Group": {"projectId": "project1", "roleKey": "admin"}
+	}`), &resource)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		filter string
+		want   bool
+	}{
+		{
+			name:   "equals ignores case",
+			filter: `displayName eq "admins"`,
+			want:   true,
+		},
+		{
+			name:   "not equals",
+			filter: `displayName ne "admins"`,
+			want:   false,
+		},
+		{
+			name:   "multi valued attribute",
+			filter: `members eq "user2"`,
+			want:   true,
+		},
+		{
+			name:   "sub attribute",
+			filter: `members.display sw "gi"`,
+			want:   true,
+		},
+		{
+			name:   "value path",
+			filter: `members[value eq "user3"]`,
+			want:   false,
+		},
+		{
+			name:   "extension",
+			filter: `urn:zitadel:params:scim:schemas:extension:2.0:Group:projectId eq "project1"`,
+			want:   true,
+		},
+		{
+			name:   "missing attribute present",
+			filter: `externalId pr`,
+			want:   false,
+		},
+		{
+			name:   "not",
+			filter: `not (displayName co "user")`,
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expression, err := parseFilter(tt.filter)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, matches(expression, resource))
+		})
+	}
+}
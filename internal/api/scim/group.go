@@ -0,0 +1,515 @@
+package scim
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+type Group struct {
+	Schemas     []string        `json:"schemas"`
+	ID          string          `json:"id,omitempty"`
+	DisplayName string          `json:"displayName"`
+	Members     []*Member       `json:"members,omitempty"`
+	Zitadel     *GroupExtension `json:"urn:zitadel:params:scim:schemas:extension:2.0:Group,omitempty"`
+	Meta        *Meta           `json:"meta,omitempty"`
+}
+
+type Member struct {
+	Value   string `json:"value"`
+	Ref     string `json:"$ref,omitempty"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+}
+
+// GroupExtension identifies the project role the group is mapped onto
+type GroupExtension struct {
+	ProjectID string `json:"projectId"`
+	RoleKey   string `json:"roleKey,omitempty"`
+	Group     string `json:"group,omitempty"`
+}
+
+func (g *Group) location() string {
+	return g.Meta.Location
+}
+
+func (g *Group) validate() error {
+	if !containsSchema(g.Schemas, schemaGroup) {
+		return newError(nil, "SCIM-Gah2i", "schema "+schemaGroup+" missing", scimTypeInvalidSyntax)
+	}
+	if g.DisplayName == "" {
+		return newError(nil, "SCIM-Ooz8e", "displayName is required", scimTypeInvalidValue)
+	}
+	return nil
+}
+
+func (g *Group) roleKey() string {
+	if g.Zitadel != nil && g.Zitadel.RoleKey != "" {
+		return g.Zitadel.RoleKey
+	}
+	return g.DisplayName
+}
+
+func (g *Group) group() string {
+	if g.Zitadel == nil {
+		return ""
+	}
+	return g.Zitadel.Group
+}
+
+func (g *Group) memberIDs() map[string]bool {
+	ids := make(map[string]bool, len(g.Members))
+	for _, member := range g.Members {
+		ids[member.Value] = true
+	}
+	return ids
+}
+
+// groupID identifies a project role, as role keys are only unique inside a project
+func groupID(projectID, roleKey string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(projectID + ":" + roleKey))
+}
+
+func parseGroupID(id string) (projectID, roleKey string, err error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(id)
+	if err != nil {
+		return "", "", caos_errs.ThrowNotFound(err, "SCIM-Phoh4", "group not found")
+	}
+	projectID, roleKey, ok := strings.Cut(string(decoded), ":")
+	if !ok || projectID == "" || roleKey == "" {
+		return "", "", caos_errs.ThrowNotFound(nil, "SCIM-eiH7a", "group not found")
+	}
+	return projectID, roleKey, nil
+}
+
+func (h *Handler) listGroups(r *http.Request) (interface{}, int, error) {
+	request, err := listRequestFromQuery(r)
+	if err != nil {
+		return nil, 0, err
+	}
+	return h.searchGroupsByRequest(r, request)
+}
+
+func (h *Handler) searchGroups(r *http.Request) (interface{}, int, error) {
+	request, err := listRequestFromBody(r)
+	if err != nil {
+		return nil, 0, err
+	}
+	return h.searchGroupsByRequest(r, request)
+}
+
+// searchGroupsByRequest filters, sorts and pages the project roles of the organisation in memory
+// as the filter can contain attributes of the role and its members
+func (h *Handler) searchGroupsByRequest(r *http.Request, request *listRequest) (interface{}, int, error) {
+	ctx := r.Context()
+	ownerQuery, err := query.NewProjectRoleResourceOwnerSearchQuery(orgID(r))
+	if err != nil {
+		return nil, 0, err
+	}
+	roles, err := h.queries.SearchProjectRoles(ctx, true, &query.ProjectRoleSearchQueries{Queries: []query.SearchQuery{ownerQuery}}, false)
+	if err != nil {
+		return nil, 0, err
+	}
+	var grants []*query.UserGrant
+	if !request.excludes("members") {
+		if grants, err = h.userGrants(ctx, orgID(r), ""); err != nil {
+			return nil, 0, err
+		}
+	}
+	groups := make([]*Group, 0, len(roles.ProjectRoles))
+	sortValues := make(map[*Group]string, len(roles.ProjectRoles))
+	sortPath := attributePath(normalizeAttribute(request.SortBy))
+	for _, role := range roles.ProjectRoles {
+		group := roleToResource(h.baseURL(r), role, grants)
+		resource, err := toMap(group)
+		if err != nil {
+			return nil, 0, err
+		}
+		if request.filter != nil && !matches(request.filter, resource) {
+			continue
+		}
+		if request.SortBy != "" {
+			if values := attributeValues(resource, sortPath); len(values) > 0 {
+				sortValues[group] = strings.ToLower(fmt.Sprint(values[0]))
+			}
+		}
+		groups = append(groups, group)
+	}
+	if request.SortBy != "" {
+		sort.SliceStable(groups, func(i, j int) bool {
+			if request.ascending() {
+				return sortValues[groups[i]] < sortValues[groups[j]]
+			}
+			return sortValues[groups[i]] > sortValues[groups[j]]
+		})
+	}
+	total := uint64(len(groups))
+	start := request.offset()
+	if start > total {
+		start = total
+	}
+	end := start + request.Count
+	if end > total {
+		end = total
+	}
+	groups = groups[start:end]
+	return newListResponse(total, request, groups, len(groups)), http.StatusOK, nil
+}
+
+// userGrants returns the user grants of the organisation, restricted to the project if provided
+func (h *Handler) userGrants(ctx context.Context, orgID, projectID string) ([]*query.UserGrant, error) {
+	ownerQuery, err := query.NewUserGrantResourceOwnerSearchQuery(orgID)
+	if err != nil {
+		return nil, err
+	}
+	queries := []query.SearchQuery{ownerQuery}
+	if projectID != "" {
+		projectQuery, err := query.NewUserGrantProjectIDSearchQuery(projectID)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, projectQuery)
+	}
+	grants, err := h.queries.UserGrants(ctx, &query.UserGrantsQueries{Queries: queries}, false)
+	if err != nil {
+		return nil, err
+	}
+	return grants.UserGrants, nil
+}
+
+func (h *Handler) getGroup(r *http.Request) (interface{}, int, error) {
+	group, _, err := h.groupByID(r, resourceID(r))
+	if err != nil {
+		return nil, 0, err
+	}
+	return group, http.StatusOK, nil
+}
+
+// groupByID returns the SCIM representation of the project role and the user grants of the project
+func (h *Handler) groupByID(r *http.Request, id string) (*Group, []*query.UserGrant, error) {
+	ctx := r.Context()
+	projectID, roleKey, err := parseGroupID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	role, err := h.projectRole(ctx, orgID(r), projectID, roleKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	grants, err := h.userGrants(ctx, orgID(r), projectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return roleToResource(h.baseURL(r), role, grants), grants, nil
+}
+
+func (h *Handler) projectRole(ctx context.Context, orgID, projectID, roleKey string) (*query.ProjectRole, error) {
+	ownerQuery, err := query.NewProjectRoleResourceOwnerSearchQuery(orgID)
+	if err != nil {
+		return nil, err
+	}
+	projectQuery, err := query.NewProjectRoleProjectIDSearchQuery(projectID)
+	if err != nil {
+		return nil, err
+	}
+	keyQuery, err := query.NewProjectRoleKeySearchQuery(query.TextEquals, roleKey)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := h.queries.SearchProjectRoles(ctx, true, &query.ProjectRoleSearchQueries{Queries: []query.SearchQuery{ownerQuery, projectQuery, keyQuery}}, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(roles.ProjectRoles) != 1 {
+		return nil, caos_errs.ThrowNotFound(nil, "SCIM-Yoh0u", "group not found")
+	}
+	return roles.ProjectRoles[0], nil
+}
+
+func (h *Handler) createGroup(r *http.Request) (interface{}, int, error) {
+	ctx := r.Context()
+	group := new(Group)
+	if err := readJSON(r, group); err != nil {
+		return nil, 0, err
+	}
+	if err := group.validate(); err != nil {
+		return nil, 0, err
+	}
+	if group.Zitadel == nil || group.Zitadel.ProjectID == "" {
+		return nil, 0, newError(nil, "SCIM-Sho1a", schemaZitadelGroup+":projectId is required", scimTypeInvalidValue)
+	}
+	grants, err := h.userGrants(ctx, orgID(r), group.Zitadel.ProjectID)
+	if err != nil {
+		return nil, 0, err
+	}
+	members := newMemberChanges(group.Zitadel.ProjectID, group.roleKey(), grants, group.memberIDs())
+	if err = members.authorize(ctx); err != nil {
+		return nil, 0, err
+	}
+	role, err := h.commands.AddProjectRole(ctx, &domain.ProjectRole{
+		ObjectRoot:  models.ObjectRoot{AggregateID: group.Zitadel.ProjectID},
+		Key:         group.roleKey(),
+		DisplayName: group.DisplayName,
+		Group:       group.group(),
+	}, orgID(r))
+	if err != nil {
+		return nil, 0, err
+	}
+	if err = h.updateMembers(ctx, orgID(r), members); err != nil {
+		return nil, 0, err
+	}
+	created, _, err := h.groupByID(r, groupID(role.AggregateID, role.Key))
+	if err != nil {
+		return nil, 0, err
+	}
+	return created, http.StatusCreated, nil
+}
+
+func (h *Handler) replaceGroup(r *http.Request) (interface{}, int, error) {
+	desired := new(Group)
+	if err := readJSON(r, desired); err != nil {
+		return nil, 0, err
+	}
+	if err := desired.validate(); err != nil {
+		return nil, 0, err
+	}
+	return h.updateGroup(r, desired)
+}
+
+func (h *Handler) patchGroup(r *http.Request) (interface{}, int, error) {
+	request := new(patchRequest)
+	if err := readJSON(r, request); err != nil {
+		return nil, 0, err
+	}
+	if err := request.validate(); err != nil {
+		return nil, 0, err
+	}
+	current, _, err := h.groupByID(r, resourceID(r))
+	if err != nil {
+		return nil, 0, err
+	}
+	desired := new(Group)
+	if err = applyPatch(current, request.Operations, desired); err != nil {
+		return nil, 0, err
+	}
+	if err = desired.validate(); err != nil {
+		return nil, 0, err
+	}
+	return h.updateGroup(r, desired)
+}
+
+// updateGroup changes the project role and the user grants so they match the desired state of the group
+// the project and the key of the role are immutable
+func (h *Handler) updateGroup(r *http.Request, desired *Group) (interface{}, int, error) {
+	ctx := r.Context()
+	current, grants, err := h.groupByID(r, resourceID(r))
+	if err != nil {
+		return nil, 0, err
+	}
+	if desired.Zitadel != nil && desired.Zitadel.ProjectID != "" && desired.Zitadel.ProjectID != current.Zitadel.ProjectID {
+		return nil, 0, newError(nil, "SCIM-ahB4o", "projectId is immutable", scimTypeMutability)
+	}
+	if desired.Zitadel != nil && desired.Zitadel.RoleKey != "" && desired.Zitadel.RoleKey != current.Zitadel.RoleKey {
+		return nil, 0, newError(nil, "SCIM-Eeg5u", "roleKey is immutable", scimTypeMutability)
+	}
+	members := newMemberChanges(current.Zitadel.ProjectID, current.Zitadel.RoleKey, grants, desired.memberIDs())
+	if err = members.authorize(ctx); err != nil {
+		return nil, 0, err
+	}
+	group := current.Zitadel.Group
+	if desired.Zitadel != nil {
+		group = desired.Zitadel.Group
+	}
+	if desired.DisplayName != current.DisplayName || group != current.Zitadel.Group {
+		_, err = h.commands.ChangeProjectRole(ctx, &domain.ProjectRole{
+			ObjectRoot:  models.ObjectRoot{AggregateID: current.Zitadel.ProjectID},
+			Key:         current.Zitadel.RoleKey,
+			DisplayName: desired.DisplayName,
+			Group:       group,
+		}, orgID(r))
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	if err = h.updateMembers(ctx, orgID(r), members); err != nil {
+		return nil, 0, err
+	}
+	updated, _, err := h.groupByID(r, current.ID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return updated, http.StatusOK, nil
+}
+
+// memberChanges are the changes of the user grants needed, so the members of a group match the desired members
+type memberChanges struct {
+	projectID string
+	roleKey   string
+	changes   map[*query.UserGrant][]string
+	removals  []*query.UserGrant
+	additions []string
+}
+
+// newMemberChanges grants the role to the desired members and removes it from all other users of the project
+// a user grant is removed if the role was its last one
+func newMemberChanges(projectID, roleKey string, grants []*query.UserGrant, members map[string]bool) *memberChanges {
+	m := &memberChanges{
+		projectID: projectID,
+		roleKey:   roleKey,
+		changes:   make(map[*query.UserGrant][]string),
+		removals:  make([]*query.UserGrant, 0),
+		additions: make([]string, 0),
+	}
+	granted := make(map[string]bool, len(grants))
+	for _, grant := range grants {
+		if grant.ProjectID != projectID || grant.GrantID != "" {
+			continue
+		}
+		granted[grant.UserID] = true
+		hasRole := containsRole(grant.Roles, roleKey)
+		switch {
+		case members[grant.UserID] && !hasRole:
+			m.changes[grant] = append(grant.Roles, roleKey)
+		case !members[grant.UserID] && hasRole && len(grant.Roles) == 1:
+			m.removals = append(m.removals, grant)
+		case !members[grant.UserID] && hasRole:
+			m.changes[grant] = removeRole(grant.Roles, roleKey)
+		}
+	}
+	for userID := range members {
+		if !granted[userID] {
+			m.additions = append(m.additions, userID)
+		}
+	}
+	return m
+}
+
+// authorize checks the permissions on the user grants,
+// as the routes of the groups only require the permissions on the project roles
+func (m *memberChanges) authorize(ctx context.Context) error {
+	if len(m.changes) > 0 || len(m.additions) > 0 {
+		if err := checkProjectPermission(ctx, permissionUserGrantWrite, m.projectID); err != nil {
+			return err
+		}
+	}
+	if len(m.removals) > 0 {
+		return checkProjectPermission(ctx, permissionUserGrantDelete, m.projectID)
+	}
+	return nil
+}
+
+func (h *Handler) updateMembers(ctx context.Context, orgID string, m *memberChanges) error {
+	for grant, roles := range m.changes {
+		if err := h.changeUserGrantRoles(ctx, orgID, grant, roles); err != nil {
+			return err
+		}
+	}
+	for _, grant := range m.removals {
+		if _, err := h.commands.RemoveUserGrant(ctx, grant.ID, orgID); err != nil {
+			return err
+		}
+	}
+	for _, userID := range m.additions {
+		_, err := h.commands.AddUserGrant(ctx, &domain.UserGrant{
+			UserID:    userID,
+			ProjectID: m.projectID,
+			RoleKeys:  []string{m.roleKey},
+		}, orgID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *Handler) changeUserGrantRoles(ctx context.Context, orgID string, grant *query.UserGrant, roles []string) error {
+	_, err := h.commands.ChangeUserGrant(ctx, &domain.UserGrant{
+		ObjectRoot: models.ObjectRoot{AggregateID: grant.ID, ResourceOwner: grant.ResourceOwner},
+		RoleKeys:   roles,
+	}, orgID)
+	return err
+}
+
+func (h *Handler) deleteGroup(r *http.Request) (interface{}, int, error) {
+	ctx := r.Context()
+	current, grants, err := h.groupByID(r, resourceID(r))
+	if err != nil {
+		return nil, 0, err
+	}
+	projectID, roleKey := current.Zitadel.ProjectID, current.Zitadel.RoleKey
+	grantIDs := make([]string, 0, len(grants))
+	for _, grant := range grants {
+		if containsRole(grant.Roles, roleKey) {
+			grantIDs = append(grantIDs, grant.ID)
+		}
+	}
+	projectGrants, err := h.queries.SearchProjectGrantsByProjectIDAndRoleKey(ctx, projectID, roleKey, false)
+	if err != nil {
+		return nil, 0, err
+	}
+	projectGrantIDs := make([]string, len(projectGrants.ProjectGrants))
+	for i, grant := range projectGrants.ProjectGrants {
+		projectGrantIDs[i] = grant.GrantID
+	}
+	if _, err = h.commands.RemoveProjectRole(ctx, projectID, roleKey, orgID(r), projectGrantIDs, grantIDs...); err != nil {
+		return nil, 0, err
+	}
+	return nil, http.StatusNoContent, nil
+}
+
+func containsRole(roles []string, roleKey string) bool {
+	for _, role := range roles {
+		if role == roleKey {
+			return true
+		}
+	}
+	return false
+}
+
+func removeRole(roles []string, roleKey string) []string {
+	result := make([]string, 0, len(roles))
+	for _, role := range roles {
+		if role != roleKey {
+			result = append(result, role)
+		}
+	}
+	return result
+}
+
+func roleToResource(baseURL string, role *query.ProjectRole, grants []*query.UserGrant) *Group {
+	group := &Group{
+		Schemas:     []string{schemaGroup, schemaZitadelGroup},
+		ID:          groupID(role.ProjectID, role.Key),
+		DisplayName: role.DisplayName,
+		Zitadel: &GroupExtension{
+			ProjectID: role.ProjectID,
+			RoleKey:   role.Key,
+			Group:     role.Group,
+		},
+	}
+	group.Meta = newMeta(resourceTypeGroup, role.CreationDate, role.ChangeDate, role.Sequence, baseURL+"/Groups/"+group.ID)
+	for _, grant := range grants {
+		if grant.ProjectID != role.ProjectID || grant.GrantID != "" || !containsRole(grant.Roles, role.Key) {
+			continue
+		}
+		display := grant.DisplayName
+		if display == "" {
+			display = grant.Username
+		}
+		group.Members = append(group.Members, &Member{
+			Value:   grant.UserID,
+			Ref:     baseURL + "/Users/" + grant.UserID,
+			Display: display,
+			Type:    resourceTypeUser,
+		})
+	}
+	return group
+}
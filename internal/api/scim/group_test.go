@@ -0,0 +1,91 @@
+package scim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func Test_newMemberChanges(t *testing.T) {
+	grants := []*query.UserGrant{
+		{ID: "grant1", UserID: "user1", ProjectID: "project1", Roles: []string{"role1"}},
+		{ID: "grant2", UserID: "user2", ProjectID: "project1", Roles: []string{"role1", "role2"}},
+		{ID: "grant3", UserID: "user3", ProjectID: "project1", Roles: []string{"role2"}},
+		{ID: "grant4", UserID: "user4", ProjectID: "project1", GrantID: "projectgrant1", Roles: []string{"role1"}},
+	}
+	got := newMemberChanges("project1", "role1", grants, map[string]bool{"user3": true, "user5": true})
+	assert.Equal(t, []*query.UserGrant{grants[0]}, got.removals)
+	assert.Equal(t, map[*query.UserGrant][]string{
+		grants[1]: {"role2"},
+		grants[2]: {"role2", "role1"},
+	}, got.changes)
+	assert.Equal(t, []string{"user5"}, got.additions)
+}
+
+func Test_memberChanges_authorize(t *testing.T) {
+	grants := []*query.UserGrant{
+		{ID: "grant1", UserID: "user1", ProjectID: "project1", Roles: []string{"role1"}},
+		{ID: "grant2", UserID: "user2", ProjectID: "project1", Roles: []string{"role2"}},
+	}
+	tests := []struct {
+		name        string
+		members     map[string]bool
+		permissions []string
+		wantErr     func(error) bool
+	}{
+		{
+			name:        "members unchanged, role permission only, ok",
+			members:     map[string]bool{"user1": true},
+			permissions: []string{"project.role.write"},
+		},
+		{
+			name:        "member added, role permission only, error",
+			members:     map[string]bool{"user1": true, "user3": true},
+			permissions: []string{"project.role.write"},
+			wantErr:     caos_errs.IsPermissionDenied,
+		},
+		{
+			name:        "role added to grant, role permission only, error",
+			members:     map[string]bool{"user1": true, "user2": true},
+			permissions: []string{"project.role.write"},
+			wantErr:     caos_errs.IsPermissionDenied,
+		},
+		{
+			name:        "member removed, grant write permission only, error",
+			members:     map[string]bool{},
+			permissions: []string{"project.role.write", "user.grant.write"},
+			wantErr:     caos_errs.IsPermissionDenied,
+		},
+		{
+			name:        "member removed, grant delete permission, ok",
+			members:     map[string]bool{},
+			permissions: []string{"project.role.write", "user.grant.delete"},
+		},
+		{
+			name:        "member added, grant write permission on other project, error",
+			members:     map[string]bool{"user1": true, "user3": true},
+			permissions: []string{"project.role.write:project1", "user.grant.write:project2"},
+			wantErr:     caos_errs.IsPermissionDenied,
+		},
+		{
+			name:        "member added, grant write permission on project, ok",
+			members:     map[string]bool{"user1": true, "user3": true},
+			permissions: []string{"project.role.write:project1", "user.grant.write:project1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := authz.NewMockContextWithAllPermissions("instance1", "org1", "user1", nil, tt.permissions)
+			err := newMemberChanges("project1", "role1", grants, tt.members).authorize(ctx)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+		})
+	}
+}
@@ -0,0 +1,337 @@
+package scim
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	patchOpAdd     = "add"
+	patchOpReplace = "replace"
+	patchOpRemove  = "remove"
+)
+
+type patchRequest struct {
+	Schemas    []string          `json:"schemas"`
+	Operations []*patchOperation `json:"Operations"`
+}
+
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+func (p *patchRequest) validate() error {
+	if !containsSchema(p.Schemas, schemaPatchOp) {
+		return newError(nil, "SCIM-ahC6e", "schema "+schemaPatchOp+" missing", scimTypeInvalidSyntax)
+	}
+	if len(p.Operations) == 0 {
+		return newError(nil, "SCIM-Ooy1i", "no operations provided", scimTypeInvalidSyntax)
+	}
+	return nil
+}
+
+// patchPath is the parsed form of an attribute path of a patch operation (RFC 7644, section 3.5.2)
+// e.g. `emails[type eq "work"].value` results in attributes: [emails], filter: `type eq "work"`, subAttribute: value
+type patchPath struct {
+	attributes   []string
+	filter       filterExpression
+	subAttribute string
+}
+
+func parsePatchPath(path string) (_ *patchPath, err error) {
+	p := new(patchPath)
+	attributePath := path
+	if start := strings.Index(path, "["); start >= 0 {
+		end := strings.LastIndex(path, "]")
+		if end < start {
+			return nil, newError(nil, "SCIM-eeT1u", "invalid path "+path, scimTypeInvalidPath)
+		}
+		if p.filter, err = parseFilter(path[start+1 : end]); err != nil {
+			return nil, err
+		}
+		attributePath = path[:start]
+		p.subAttribute = strings.TrimPrefix(path[end+1:], ".")
+	}
+	attributePath = p.trimSchema(attributePath)
+	if attributePath != "" {
+		p.attributes = append(p.attributes, strings.Split(attributePath, ".")...)
+	}
+	if len(p.attributes) == 0 {
+		return nil, newError(nil, "SCIM-Ooph3", "invalid path "+path, scimTypeInvalidPath)
+	}
+	return p, nil
+}
+
+// trimSchema removes the urn of the core schemas and adds the urn of extensions as first attribute
+func (p *patchPath) trimSchema(path string) string {
+	lowerPath := strings.ToLower(path)
+	for _, extension := range []string{schemaZitadelUser, schemaZitadelGroup} {
+		if lowerPath == strings.ToLower(extension) {
+			p.attributes = append(p.attributes, extension)
+			return ""
+		}
+		if strings.HasPrefix(lowerPath, strings.ToLower(extension)+":") {
+			p.attributes = append(p.attributes, extension)
+			return path[len(extension)+1:]
+		}
+	}
+	if !strings.HasPrefix(lowerPath, "urn:") {
+		return path
+	}
+	separator := strings.LastIndex(path, ":")
+	if schema := path[:separator]; !strings.EqualFold(schema, schemaUser) && !strings.EqualFold(schema, schemaGroup) {
+		p.attributes = append(p.attributes, schema)
+	}
+	return path[separator+1:]
+}
+
+// applyPatch applies the operations on the JSON representation of the resource
+// and decodes the patched representation into target
+func applyPatch(resource interface{}, operations []*patchOperation, target interface{}) error {
+	patched, err := toMap(resource)
+	if err != nil {
+		return err
+	}
+	for _, operation := range operations {
+		if err = applyOperation(patched, operation); err != nil {
+			return err
+		}
+	}
+	data, err := json.Marshal(patched)
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "SCIM-eiN0e", "unable to marshal patched resource")
+	}
+	if err = json.Unmarshal(data, target); err != nil {
+		return newError(err, "SCIM-Ieg1e", "invalid value", scimTypeInvalidValue)
+	}
+	return nil
+}
+
+// toMap returns the JSON representation of the resource
+func toMap(resource interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "SCIM-ooK5i", "unable to marshal resource")
+	}
+	object := make(map[string]interface{})
+	if err = json.Unmarshal(data, &object); err != nil {
+		return nil, caos_errs.ThrowInternal(err, "SCIM-Aiz5u", "unable to unmarshal resource")
+	}
+	return object, nil
+}
+
+func applyOperation(resource map[string]interface{}, operation *patchOperation) error {
+	op := strings.ToLower(operation.Op)
+	if op != patchOpAdd && op != patchOpReplace && op != patchOpRemove {
+		return newError(nil, "SCIM-Quah4", "invalid operation "+operation.Op, scimTypeInvalidSyntax)
+	}
+	var value interface{}
+	if len(operation.Value) > 0 {
+		if err := json.Unmarshal(operation.Value, &value); err != nil {
+			return newError(err, "SCIM-uT2ee", "invalid value", scimTypeInvalidValue)
+		}
+	}
+	if operation.Path != "" {
+		path, err := parsePatchPath(operation.Path)
+		if err != nil {
+			return err
+		}
+		return applyPath(resource, op, path, value)
+	}
+	if op == patchOpRemove {
+		return newError(nil, "SCIM-ieL9o", "path is required for remove operations", scimTypeNoTarget)
+	}
+	values, ok := value.(map[string]interface{})
+	if !ok {
+		return newError(nil, "SCIM-Wai1y", "value must be an object if no path is provided", scimTypeInvalidValue)
+	}
+	for key, v := range values {
+		path, err := parsePatchPath(key)
+		if err != nil {
+			return err
+		}
+		if err = applyPath(resource, op, path, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyPath(resource map[string]interface{}, op string, path *patchPath, value interface{}) error {
+	parent := resource
+	for _, attribute := range path.attributes[:len(path.attributes)-1] {
+		key := findKey(parent, attribute)
+		child, ok := parent[key].(map[string]interface{})
+		if !ok {
+			if op == patchOpRemove {
+				return nil
+			}
+			child = make(map[string]interface{})
+			parent[key] = child
+		}
+		parent = child
+	}
+	attribute := path.attributes[len(path.attributes)-1]
+	key := findKey(parent, attribute)
+	if path.subAttribute != "" {
+		attribute = path.subAttribute
+	}
+	value = normalizeValue(attribute, value)
+	if path.filter != nil {
+		return applyFiltered(parent, key, op, path, value)
+	}
+	switch op {
+	case patchOpAdd:
+		parent[key] = addValue(parent[key], value)
+	case patchOpReplace:
+		parent[key] = value
+	case patchOpRemove:
+		existing, isList := parent[key].([]interface{})
+		remove, hasValues := value.([]interface{})
+		if isList && hasValues {
+			parent[key] = removeValues(existing, remove)
+			return nil
+		}
+		delete(parent, key)
+	}
+	return nil
+}
+
+// applyFiltered applies the operation on all elements of a multi valued attribute matching the filter
+func applyFiltered(parent map[string]interface{}, key, op string, path *patchPath, value interface{}) error {
+	elements, _ := parent[key].([]interface{})
+	result := make([]interface{}, 0, len(elements))
+	matched := false
+	for _, element := range elements {
+		object, ok := element.(map[string]interface{})
+		if !ok || !matches(path.filter, object) {
+			result = append(result, element)
+			continue
+		}
+		matched = true
+		switch {
+		case op == patchOpRemove && path.subAttribute == "":
+			continue
+		case op == patchOpRemove:
+			delete(object, findKey(object, path.subAttribute))
+		case path.subAttribute != "":
+			object[findKey(object, path.subAttribute)] = value
+		default:
+			values, ok := value.(map[string]interface{})
+			if !ok {
+				return newError(nil, "SCIM-Ohn3u", "value must be an object", scimTypeInvalidValue)
+			}
+			for k, v := range values {
+				object[findKey(object, k)] = normalizeValue(k, v)
+			}
+		}
+		result = append(result, object)
+	}
+	if !matched && op == patchOpReplace {
+		return newError(nil, "SCIM-Eiw4a", "no element matches the filter", scimTypeNoTarget)
+	}
+	parent[key] = result
+	return nil
+}
+
+func addValue(existing, value interface{}) interface{} {
+	switch e := existing.(type) {
+	case []interface{}:
+		values, ok := value.([]interface{})
+		if !ok {
+			values = []interface{}{value}
+		}
+		for _, v := range values {
+			if !containsValue(e, v) {
+				e = append(e, v)
+			}
+		}
+		return e
+	case map[string]interface{}:
+		values, ok := value.(map[string]interface{})
+		if !ok {
+			return value
+		}
+		for k, v := range values {
+			key := findKey(e, k)
+			e[key] = addValue(e[key], normalizeValue(k, v))
+		}
+		return e
+	}
+	return value
+}
+
+func removeValues(existing, remove []interface{}) []interface{} {
+	result := make([]interface{}, 0, len(existing))
+	for _, element := range existing {
+		if !containsValue(remove, element) {
+			result = append(result, element)
+		}
+	}
+	return result
+}
+
+// containsValue checks if the list contains the value
+// complex values are compared by their `value` sub attribute if present
+func containsValue(list []interface{}, value interface{}) bool {
+	for _, element := range list {
+		if reflect.DeepEqual(element, value) {
+			return true
+		}
+		elementObject, ok := element.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		valueObject, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		elementValue, ok := lookupAttribute(elementObject, "value")
+		if !ok {
+			continue
+		}
+		if v, ok := lookupAttribute(valueObject, "value"); ok && reflect.DeepEqual(elementValue, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func findKey(object map[string]interface{}, name string) string {
+	for key := range object {
+		if strings.EqualFold(key, name) {
+			return key
+		}
+	}
+	return name
+}
+
+// normalizeValue converts boolean attributes sent as strings (e.g. "False" by Azure AD) to booleans
+func normalizeValue(attribute string, value interface{}) interface{} {
+	s, ok := value.(string)
+	if !ok {
+		return value
+	}
+	switch strings.ToLower(attribute) {
+	case "active", "primary", "machine":
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b
+		}
+	}
+	return value
+}
+
+func containsSchema(schemas []string, schema string) bool {
+	for _, s := range schemas {
+		if strings.EqualFold(s, schema) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+package scim
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_applyPatch(t *testing.T) {
+	active := true
+	inactive := false
+	current := &User{
+		Schemas:  []string{schemaUser, schemaZitadelUser},
+		ID:       "user1",
+		UserName: "gigi",
+		Name:     &UserName{GivenName: "Gigi", FamilyName: "Giraffe"},
+		Active:   &active,
+		Emails:   []*MultiValue{{Value: "gigi@zitadel.ch", Type: "work", Primary: true}},
+		Zitadel:  &UserExtension{Metadata: map[string]string{"department": "it"}},
+	}
+	type args struct {
+		operations string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *User
+		wantErr bool
+	}{
+		{
+			name: "replace attribute",
+			args: args{operations: `[{"op": "replace", "path": "userName", "value": "gigi2"}]`},
+			want: &User{
+				Schemas:  current.Schemas,
+				ID:       "user1",
+				UserName: "gigi2",
+				Name:     current.Name,
+				Active:   &active,
+				Emails:   current.Emails,
+				Zitadel:  current.Zitadel,
+			},
+		},
+		{
+			name: "replace without path and boolean as string",
+			args: args{operations: `[{"op": "Replace", "value": {"active": "False", "name.givenName": "Gigi2"}}]`},
+			want: &User{
+				Schemas:  current.Schemas,
+				ID:       "user1",
+				UserName: "gigi",
+				Name:     &UserName{GivenName: "Gigi2", FamilyName: "Giraffe"},
+				Active:   &inactive,
+				Emails:   current.Emails,
+				Zitadel:  current.Zitadel,
+			},
+		},
+		{
+			name: "replace filtered sub attribute",
+			args: args{operations: `[{"op": "replace", "path": "emails[type eq \"work\"].value", "value": "gigi@zitadel.com"}]`},
+			want: &User{
+				Schemas:  current.Schemas,
+				ID:       "user1",
+				UserName: "gigi",
+				Name:     current.Name,
+				Active:   &active,
+				Emails:   []*MultiValue{{Value: "gigi@zitadel.com", Type: "work", Primary: true}},
+				Zitadel:  current.Zitadel,
+			},
+		},
+		{
+			name: "add extension attribute",
+			args: args{operations: `[{"op": "add", "path": "urn:zitadel:params:scim:schemas:extension:2.0:User:metadata.location", "value": "zurich"}]`},
+			want: &User{
+				Schemas:  current.Schemas,
+				ID:       "user1",
+				UserName: "gigi",
+				Name:     current.Name,
+				Active:   &active,
+				Emails:   current.Emails,
+				Zitadel:  &UserExtension{Metadata: map[string]string{"department": "it", "location": "zurich"}},
+			},
+		},
+		{
+			name: "remove filtered element",
+			args: args{operations: `[{"op": "remove", "path": "emails[value eq \"gigi@zitadel.ch\"]"}]`},
+			want: &User{
+				Schemas:  current.Schemas,
+				ID:       "user1",
+				UserName: "gigi",
+				Name:     current.Name,
+				Active:   &active,
+				Emails:   []*MultiValue{},
+				Zitadel:  current.Zitadel,
+			},
+		},
+		{
+			name:    "replace filtered without match",
+			args:    args{operations: `[{"op": "replace", "path": "emails[type eq \"home\"].value", "value": "gigi@zitadel.com"}]`},
+			wantErr: true,
+		},
+		{
+			name:    "remove without path",
+			args:    args{operations: `[{"op": "remove"}]`},
+			wantErr: true,
+		},
+		{
+			name:    "invalid operation",
+			args:    args{operations: `[{"op": "move", "path": "userName"}]`},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var operations []*patchOperation
+			assert.NoError(t, json.Unmarshal([]byte(tt.args.operations), &operations))
+			got := new(User)
+			err := applyPatch(current, operations, got)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
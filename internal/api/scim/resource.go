@@ -0,0 +1,148 @@
+package scim
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	schemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	schemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	schemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	schemaResourceType          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	schemaSchema                = "urn:ietf:params:scim:schemas:core:2.0:Schema"
+	schemaZitadelUser           = "urn:zitadel:params:scim:schemas:extension:2.0:User"
+	schemaZitadelGroup          = "urn:zitadel:params:scim:schemas:extension:2.0:Group"
+
+	schemaListResponse  = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	schemaSearchRequest = "urn:ietf:params:scim:api:messages:2.0:SearchRequest"
+	schemaPatchOp       = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	schemaBulkRequest   = "urn:ietf:params:scim:api:messages:2.0:BulkRequest"
+	schemaBulkResponse  = "urn:ietf:params:scim:api:messages:2.0:BulkResponse"
+	schemaError         = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+	resourceTypeUser  = "User"
+	resourceTypeGroup = "Group"
+
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+type Meta struct {
+	ResourceType string     `json:"resourceType"`
+	Created      *time.Time `json:"created,omitempty"`
+	LastModified *time.Time `json:"lastModified,omitempty"`
+	Location     string     `json:"location,omitempty"`
+	Version      string     `json:"version,omitempty"`
+}
+
+func newMeta(resourceType string, created, changed time.Time, sequence uint64, location string) *Meta {
+	return &Meta{
+		ResourceType: resourceType,
+		Created:      &created,
+		LastModified: &changed,
+		Location:     location,
+		Version:      `W/"` + strconv.FormatUint(sequence, 10) + `"`,
+	}
+}
+
+type ListResponse struct {
+	Schemas      []string    `json:"schemas"`
+	TotalResults uint64      `json:"totalResults"`
+	StartIndex   uint64      `json:"startIndex"`
+	ItemsPerPage uint64      `json:"itemsPerPage"`
+	Resources    interface{} `json:"Resources"`
+}
+
+func newListResponse(total uint64, request *listRequest, resources interface{}, count int) *ListResponse {
+	return &ListResponse{
+		Schemas:      []string{schemaListResponse},
+		TotalResults: total,
+		StartIndex:   request.StartIndex,
+		ItemsPerPage: uint64(count),
+		Resources:    resources,
+	}
+}
+
+// listRequest contains the query parameters of a list request (RFC 7644, section 3.4.2)
+// or the body of a POST to the .search endpoint (RFC 7644, section 3.4.3)
+type listRequest struct {
+	Schemas            []string `json:"schemas"`
+	Filter             string   `json:"filter"`
+	SortBy             string   `json:"sortBy"`
+	SortOrder          string   `json:"sortOrder"`
+	StartIndex         uint64   `json:"startIndex"`
+	Count              uint64   `json:"count"`
+	ExcludedAttributes []string `json:"excludedAttributes"`
+
+	filter filterExpression
+}
+
+func listRequestFromQuery(r *http.Request) (*listRequest, error) {
+	values := r.URL.Query()
+	request := &listRequest{
+		Filter:    values.Get("filter"),
+		SortBy:    values.Get("sortBy"),
+		SortOrder: values.Get("sortOrder"),
+	}
+	var err error
+	if startIndex := values.Get("startIndex"); startIndex != "" {
+		if request.StartIndex, err = strconv.ParseUint(startIndex, 10, 64); err != nil {
+			return nil, newError(err, "SCIM-Ohv8e", "invalid startIndex", scimTypeInvalidValue)
+		}
+	}
+	if count := values.Get("count"); count != "" {
+		if request.Count, err = strconv.ParseUint(count, 10, 64); err != nil {
+			return nil, newError(err, "SCIM-aeX4i", "invalid count", scimTypeInvalidValue)
+		}
+	}
+	if excluded := values.Get("excludedAttributes"); excluded != "" {
+		request.ExcludedAttributes = strings.Split(excluded, ",")
+	}
+	return request, request.validate()
+}
+
+func listRequestFromBody(r *http.Request) (*listRequest, error) {
+	request := new(listRequest)
+	if err := readJSON(r, request); err != nil {
+		return nil, err
+	}
+	return request, request.validate()
+}
+
+func (r *listRequest) validate() (err error) {
+	// as defined in the RFC a start index lower than 1 is interpreted as 1
+	if r.StartIndex < 1 {
+		r.StartIndex = 1
+	}
+	if r.Count == 0 {
+		r.Count = defaultPageSize
+	}
+	if r.Count > maxPageSize {
+		r.Count = maxPageSize
+	}
+	if r.Filter == "" {
+		return nil
+	}
+	r.filter, err = parseFilter(r.Filter)
+	return err
+}
+
+func (r *listRequest) offset() uint64 {
+	return r.StartIndex - 1
+}
+
+func (r *listRequest) ascending() bool {
+	return !strings.EqualFold(r.SortOrder, "descending")
+}
+
+func (r *listRequest) excludes(attribute string) bool {
+	for _, excluded := range r.ExcludedAttributes {
+		if normalizeAttribute(strings.TrimSpace(excluded)) == strings.ToLower(attribute) {
+			return true
+		}
+	}
+	return false
+}
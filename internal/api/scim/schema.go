@@ -0,0 +1,268 @@
+package scim
+
+import (
+	"net/http"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+type serviceProviderConfig struct {
+	Schemas               []string               `json:"schemas"`
+	DocumentationURI      string                 `json:"documentationUri"`
+	Patch                 supported              `json:"patch"`
+	Bulk                  bulkSupported          `json:"bulk"`
+	Filter                filterSupported        `json:"filter"`
+	ChangePassword        supported              `json:"changePassword"`
+	Sort                  supported              `json:"sort"`
+	ETag                  supported              `json:"etag"`
+	AuthenticationSchemes []authenticationScheme `json:"authenticationSchemes"`
+	Meta                  *Meta                  `json:"meta"`
+}
+
+type supported struct {
+	Supported bool `json:"supported"`
+}
+
+type bulkSupported struct {
+	Supported      bool `json:"supported"`
+	MaxOperations  int  `json:"maxOperations"`
+	MaxPayloadSize int  `json:"maxPayloadSize"`
+}
+
+type filterSupported struct {
+	Supported  bool `json:"supported"`
+	MaxResults int  `json:"maxResults"`
+}
+
+type authenticationScheme struct {
+	Type             string `json:"type"`
+	Name             string `json:"name"`
+	Description      string `json:"description"`
+	SpecURI          string `json:"specUri"`
+	DocumentationURI string `json:"documentationUri"`
+	Primary          bool   `json:"primary"`
+}
+
+func (h *Handler) getServiceProviderConfig(r *http.Request) (interface{}, int, error) {
+	return &serviceProviderConfig{
+		Schemas:          []string{schemaServiceProviderConfig},
+		DocumentationURI: "https://zitadel.com/docs/apis/scim",
+		Patch:            supported{Supported: true},
+		Bulk: bulkSupported{
+			Supported:      true,
+			MaxOperations:  maxBulkOperations,
+			MaxPayloadSize: maxBodySize,
+		},
+		Filter:         filterSupported{Supported: true, MaxResults: maxPageSize},
+		ChangePassword: supported{Supported: true},
+		Sort:           supported{Supported: true},
+		ETag:           supported{Supported: false},
+		AuthenticationSchemes: []authenticationScheme{
+			{
+				Type:             "oauthbearertoken",
+				Name:             "OAuth Bearer Token",
+				Description:      "Authentication using an access token or personal access token of a (service) user",
+				SpecURI:          "https://www.rfc-editor.org/info/rfc6750",
+				DocumentationURI: "https://zitadel.com/docs/guides/integrate/access-zitadel-apis",
+				Primary:          true,
+			},
+		},
+		Meta: &Meta{
+			ResourceType: "ServiceProviderConfig",
+			Location:     h.baseURL(r) + "/ServiceProviderConfig",
+		},
+	}, http.StatusOK, nil
+}
+
+type resourceType struct {
+	Schemas          []string          `json:"schemas"`
+	ID               string            `json:"id"`
+	Name             string            `json:"name"`
+	Endpoint         string            `json:"endpoint"`
+	Description      string            `json:"description"`
+	Schema           string            `json:"schema"`
+	SchemaExtensions []schemaExtension `json:"schemaExtensions"`
+	Meta             *Meta             `json:"meta"`
+}
+
+type schemaExtension struct {
+	Schema   string `json:"schema"`
+	Required bool   `json:"required"`
+}
+
+func (h *Handler) resourceTypes(r *http.Request) []*resourceType {
+	return []*resourceType{
+		{
+			Schemas:          []string{schemaResourceType},
+			ID:               resourceTypeUser,
+			Name:             resourceTypeUser,
+			Endpoint:         "/Users",
+			Description:      "Human and machine users of the organisation",
+			Schema:           schemaUser,
+			SchemaExtensions: []schemaExtension{{Schema: schemaZitadelUser}},
+			Meta:             &Meta{ResourceType: "ResourceType", Location: h.baseURL(r) + "/ResourceTypes/" + resourceTypeUser},
+		},
+		{
+			Schemas:          []string{schemaResourceType},
+			ID:               resourceTypeGroup,
+			Name:             resourceTypeGroup,
+			Endpoint:         "/Groups",
+			Description:      "Roles of the projects of the organisation, members are granted the role",
+			Schema:           schemaGroup,
+			SchemaExtensions: []schemaExtension{{Schema: schemaZitadelGroup}},
+			Meta:             &Meta{ResourceType: "ResourceType", Location: h.baseURL(r) + "/ResourceTypes/" + resourceTypeGroup},
+		},
+	}
+}
+
+func (h *Handler) listResourceTypes(r *http.Request) (interface{}, int, error) {
+	types := h.resourceTypes(r)
+	return newListResponse(uint64(len(types)), &listRequest{StartIndex: 1}, types, len(types)), http.StatusOK, nil
+}
+
+func (h *Handler) getResourceType(r *http.Request) (interface{}, int, error) {
+	for _, typ := range h.resourceTypes(r) {
+		if typ.ID == resourceID(r) {
+			return typ, http.StatusOK, nil
+		}
+	}
+	return nil, 0, caos_errs.ThrowNotFound(nil, "SCIM-aiP7u", "resource type not found")
+}
+
+type schema struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Attributes  []*attribute `json:"attributes"`
+	Meta        *Meta        `json:"meta"`
+}
+
+type attribute struct {
+	Name          string       `json:"name"`
+	Type          string       `json:"type"`
+	MultiValued   bool         `json:"multiValued"`
+	Description   string       `json:"description,omitempty"`
+	Required      bool         `json:"required"`
+	CaseExact     bool         `json:"caseExact"`
+	Mutability    string       `json:"mutability"`
+	Returned      string       `json:"returned"`
+	Uniqueness    string       `json:"uniqueness"`
+	SubAttributes []*attribute `json:"subAttributes,omitempty"`
+}
+
+func stringAttribute(name, description string, required bool) *attribute {
+	return &attribute{Name: name, Type: "string", Description: description, Required: required, Mutability: "readWrite", Returned: "default", Uniqueness: "none"}
+}
+
+func multiValuedAttribute(name, description string) *attribute {
+	return &attribute{
+		Name:        name,
+		Type:        "complex",
+		MultiValued: true,
+		Description: description,
+		Mutability:  "readWrite",
+		Returned:    "default",
+		Uniqueness:  "none",
+		SubAttributes: []*attribute{
+			stringAttribute("value", "", false),
+			stringAttribute("type", "", false),
+			{Name: "primary", Type: "boolean", Mutability: "readWrite", Returned: "default", Uniqueness: "none"},
+		},
+	}
+}
+
+func (h *Handler) schemas(r *http.Request) []*schema {
+	userName := stringAttribute("userName", "Unique identifier of the user, used as login name", true)
+	userName.Uniqueness = "server"
+	password := stringAttribute("password", "Sets the password of the user", false)
+	password.Mutability = "writeOnly"
+	password.Returned = "never"
+	members := multiValuedAttribute("members", "Users granted the role")
+	members.SubAttributes = []*attribute{
+		stringAttribute("value", "ID of the user", false),
+		{Name: "$ref", Type: "reference", Mutability: "immutable", Returned: "default", Uniqueness: "none"},
+		stringAttribute("display", "", false),
+		stringAttribute("type", "", false),
+	}
+	return []*schema{
+		{
+			Schemas:     []string{schemaSchema},
+			ID:          schemaUser,
+			Name:        resourceTypeUser,
+			Description: "User Account",
+			Attributes: []*attribute{
+				userName,
+				{
+					Name:       "name",
+					Type:       "complex",
+					Mutability: "readWrite",
+					Returned:   "default",
+					Uniqueness: "none",
+					SubAttributes: []*attribute{
+						stringAttribute("formatted", "", false),
+						stringAttribute("familyName", "", false),
+						stringAttribute("givenName", "", false),
+					},
+				},
+				stringAttribute("displayName", "", false),
+				stringAttribute("nickName", "", false),
+				stringAttribute("preferredLanguage", "", false),
+				{Name: "active", Type: "boolean", Mutability: "readWrite", Returned: "default", Uniqueness: "none"},
+				password,
+				multiValuedAttribute("emails", "Only the primary email is stored"),
+				multiValuedAttribute("phoneNumbers", "Only the primary phone number is stored"),
+			},
+			Meta: &Meta{ResourceType: "Schema", Location: h.baseURL(r) + "/Schemas/" + schemaUser},
+		},
+		{
+			Schemas:     []string{schemaSchema},
+			ID:          schemaGroup,
+			Name:        resourceTypeGroup,
+			Description: "Project role",
+			Attributes: []*attribute{
+				stringAttribute("displayName", "Display name of the role", true),
+				members,
+			},
+			Meta: &Meta{ResourceType: "Schema", Location: h.baseURL(r) + "/Schemas/" + schemaGroup},
+		},
+		{
+			Schemas:     []string{schemaSchema},
+			ID:          schemaZitadelUser,
+			Name:        "ZITADEL User",
+			Description: "ZITADEL specific attributes of a user",
+			Attributes: []*attribute{
+				{Name: "machine", Type: "boolean", Description: "Creates a machine user instead of a human user", Mutability: "immutable", Returned: "default", Uniqueness: "none"},
+				stringAttribute("description", "Description of a machine user", false),
+				{Name: "metadata", Type: "complex", Description: "Metadata of the user as key value pairs", Mutability: "readWrite", Returned: "default", Uniqueness: "none"},
+			},
+			Meta: &Meta{ResourceType: "Schema", Location: h.baseURL(r) + "/Schemas/" + schemaZitadelUser},
+		},
+		{
+			Schemas:     []string{schemaSchema},
+			ID:          schemaZitadelGroup,
+			Name:        "ZITADEL Group",
+			Description: "ZITADEL specific attributes of a group",
+			Attributes: []*attribute{
+				{Name: "projectId", Type: "string", Description: "Project of the role", Required: true, CaseExact: true, Mutability: "immutable", Returned: "default", Uniqueness: "none"},
+				{Name: "roleKey", Type: "string", Description: "Key of the role, defaults to the displayName", CaseExact: true, Mutability: "immutable", Returned: "default", Uniqueness: "none"},
+				stringAttribute("group", "Group of the role", false),
+			},
+			Meta: &Meta{ResourceType: "Schema", Location: h.baseURL(r) + "/Schemas/" + schemaZitadelGroup},
+		},
+	}
+}
+
+func (h *Handler) listSchemas(r *http.Request) (interface{}, int, error) {
+	schemas := h.schemas(r)
+	return newListResponse(uint64(len(schemas)), &listRequest{StartIndex: 1}, schemas, len(schemas)), http.StatusOK, nil
+}
+
+func (h *Handler) getSchema(r *http.Request) (interface{}, int, error) {
+	for _, s := range h.schemas(r) {
+		if s.ID == resourceID(r) {
+			return s, http.StatusOK, nil
+		}
+	}
+	return nil, 0, caos_errs.ThrowNotFound(nil, "SCIM-Ahf2o", "schema not found")
+}
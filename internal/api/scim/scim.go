@@ -0,0 +1,190 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_util "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/command"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+const (
+	HandlerPrefix = "/scim/v2"
+
+	contentTypeSCIM = "application/scim+json"
+	maxBodySize     = 1 << 20
+
+	varOrgID = "orgID"
+	varID    = "id"
+
+	permissionUserRead        = "user.read"
+	permissionUserWrite       = "user.write"
+	permissionUserDelete      = "user.delete"
+	permissionProjectRoleRead = "project.role.read"
+	permissionProjectRoleEdit = "project.role.write"
+	permissionProjectRoleDel  = "project.role.delete"
+	permissionUserGrantWrite  = "user.grant.write"
+	permissionUserGrantDelete = "user.grant.delete"
+)
+
+// Handler serves the SCIM 2.0 protocol (RFC 7643 / RFC 7644) for an organisation
+// users are mapped onto human and machine users, groups onto project roles and their user grants
+type Handler struct {
+	commands       *command.Commands
+	queries        *query.Queries
+	verifier       *authz.TokenVerifier
+	authConfig     authz.Config
+	externalSecure bool
+	router         *mux.Router
+}
+
+// handlerFunc returns the resource to be rendered as JSON and the status code of the response
+// a nil resource results in an empty body
+type handlerFunc func(r *http.Request) (interface{}, int, error)
+
+func NewHandler(
+	commands *command.Commands,
+	queries *query.Queries,
+	verifier *authz.TokenVerifier,
+	authConfig authz.Config,
+	externalSecure bool,
+	instanceInterceptor,
+	accessInterceptor func(handler http.Handler) http.Handler,
+) http.Handler {
+	h := &Handler{
+		commands:       commands,
+		queries:        queries,
+		verifier:       verifier,
+		authConfig:     authConfig,
+		externalSecure: externalSecure,
+	}
+	h.router = mux.NewRouter()
+	org := h.router.PathPrefix("/{" + varOrgID + "}").Subrouter()
+
+	org.HandleFunc("/ServiceProviderConfig", h.handle("", h.getServiceProviderConfig)).Methods(http.MethodGet)
+	org.HandleFunc("/ResourceTypes", h.handle("", h.listResourceTypes)).Methods(http.MethodGet)
+	org.HandleFunc("/ResourceTypes/{"+varID+"}", h.handle("", h.getResourceType)).Methods(http.MethodGet)
+	org.HandleFunc("/Schemas", h.handle("", h.listSchemas)).Methods(http.MethodGet)
+	org.HandleFunc("/Schemas/{"+varID+"}", h.handle("", h.getSchema)).Methods(http.MethodGet)
+
+	org.HandleFunc("/Users", h.handle(permissionUserRead, h.listUsers)).Methods(http.MethodGet)
+	org.HandleFunc("/Users/.search", h.handle(permissionUserRead, h.searchUsers)).Methods(http.MethodPost)
+	org.HandleFunc("/Users", h.handle(permissionUserWrite, h.createUser)).Methods(http.MethodPost)
+	org.HandleFunc("/Users/{"+varID+"}", h.handle(permissionUserRead, h.getUser)).Methods(http.MethodGet)
+	org.HandleFunc("/Users/{"+varID+"}", h.handle(permissionUserWrite, h.replaceUser)).Methods(http.MethodPut)
+	org.HandleFunc("/Users/{"+varID+"}", h.handle(permissionUserWrite, h.patchUser)).Methods(http.MethodPatch)
+	org.HandleFunc("/Users/{"+varID+"}", h.handle(permissionUserDelete, h.deleteUser)).Methods(http.MethodDelete)
+
+	org.HandleFunc("/Groups", h.handle(permissionProjectRoleRead, h.listGroups)).Methods(http.MethodGet)
+	org.HandleFunc("/Groups/.search", h.handle(permissionProjectRoleRead, h.searchGroups)).Methods(http.MethodPost)
+	org.HandleFunc("/Groups", h.handle(permissionProjectRoleEdit, h.createGroup)).Methods(http.MethodPost)
+	org.HandleFunc("/Groups/{"+varID+"}", h.handle(permissionProjectRoleRead, h.getGroup)).Methods(http.MethodGet)
+	org.HandleFunc("/Groups/{"+varID+"}", h.handle(permissionProjectRoleEdit, h.replaceGroup)).Methods(http.MethodPut)
+	org.HandleFunc("/Groups/{"+varID+"}", h.handle(permissionProjectRoleEdit, h.patchGroup)).Methods(http.MethodPatch)
+	org.HandleFunc("/Groups/{"+varID+"}", h.handle(permissionProjectRoleDel, h.deleteGroup)).Methods(http.MethodDelete)
+
+	// the operations of a bulk request are authorized one by one
+	org.HandleFunc("/Bulk", h.handle("", h.bulk)).Methods(http.MethodPost)
+
+	// the interceptors are not part of the router, so the operations of a bulk request are not intercepted again
+	return http_util.CopyHeadersToContext(instanceInterceptor(accessInterceptor(h.router)))
+}
+
+// handle authorizes the request (if a permission is required) and renders the result of the handlerFunc
+func (h *Handler) handle(permission string, handle handlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if permission != "" {
+			ctx, err := h.authorize(r, permission)
+			if err != nil {
+				writeError(w, r, err)
+				return
+			}
+			r = r.WithContext(ctx)
+		}
+		resource, status, err := handle(r)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+		if located, ok := resource.(interface{ location() string }); ok && status == http.StatusCreated {
+			w.Header().Set("Location", located.location())
+		}
+		if resource == nil {
+			w.WriteHeader(status)
+			return
+		}
+		writeJSON(w, resource, status)
+	}
+}
+
+func (h *Handler) authorize(r *http.Request, permission string) (_ context.Context, err error) {
+	ctx := r.Context()
+	authCtx, span := tracing.NewServerInterceptorSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	authToken := http_util.GetAuthorization(r)
+	if authToken == "" {
+		return nil, caos_errs.ThrowUnauthenticated(nil, "SCIM-Mie6h", "auth header missing")
+	}
+	ctxSetter, err := authz.CheckUserAuthorization(authCtx, nil, authToken, mux.Vars(r)[varOrgID], h.verifier, h.authConfig, authz.Option{Permission: permission}, r.RequestURI)
+	if err != nil {
+		return nil, err
+	}
+	return ctxSetter(ctx), nil
+}
+
+// checkProjectPermission checks if the caller has the permission on the instance, the organisation or the project.
+// It's used by the handlers changing resources other than the ones the permission of the route covers
+// (e.g. the user grants of the members of a group)
+func checkProjectPermission(ctx context.Context, permission, projectID string) error {
+	permissions := authz.GetAllPermissionsFromCtx(ctx)
+	if authz.ExistsPerm(permissions, permission) || authz.ExistsPerm(permissions, permission+":"+projectID) {
+		return nil
+	}
+	return caos_errs.ThrowPermissionDenied(nil, "SCIM-ieB5a", "No matching permissions found")
+}
+
+// baseURL returns the url of the organisations SCIM endpoint (e.g. https://my.zitadel.cloud/scim/v2/123)
+func (h *Handler) baseURL(r *http.Request) string {
+	return http_util.BuildOrigin(authz.GetInstance(r.Context()).RequestedHost(), h.externalSecure) + HandlerPrefix + "/" + mux.Vars(r)[varOrgID]
+}
+
+func orgID(r *http.Request) string {
+	return mux.Vars(r)[varOrgID]
+}
+
+func resourceID(r *http.Request) string {
+	return mux.Vars(r)[varID]
+}
+
+func readJSON(r *http.Request, v interface{}) error {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "SCIM-ohH7e", "unable to read body")
+	}
+	if err = json.Unmarshal(body, v); err != nil {
+		return newError(err, "SCIM-Xie7o", "invalid JSON body", scimTypeInvalidSyntax)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}, status int) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("content-type", contentTypeSCIM)
+	w.WriteHeader(status)
+	_, err = w.Write(body)
+	logging.OnError(err).Error("error writing scim response")
+}
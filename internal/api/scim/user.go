@@ -0,0 +1,743 @@
+package scim
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// metadataKeyExternalID is the key of the user metadata the externalId of the provisioning client is stored in
+const metadataKeyExternalID = "scim.externalId"
+
+type User struct {
+	Schemas           []string       `json:"schemas"`
+	ID                string         `json:"id,omitempty"`
+	ExternalID        string         `json:"externalId,omitempty"`
+	UserName          string         `json:"userName"`
+	Name              *UserName      `json:"name,omitempty"`
+	DisplayName       string         `json:"displayName,omitempty"`
+	NickName          string         `json:"nickName,omitempty"`
+	PreferredLanguage string         `json:"preferredLanguage,omitempty"`
+	Active            *bool          `json:"active,omitempty"`
+	Password          string         `json:"password,omitempty"`
+	Emails            []*MultiValue  `json:"emails,omitempty"`
+	PhoneNumbers      []*MultiValue  `json:"phoneNumbers,omitempty"`
+	Zitadel           *UserExtension `json:"urn:zitadel:params:scim:schemas:extension:2.0:User,omitempty"`
+	Meta              *Meta          `json:"meta,omitempty"`
+}
+
+type UserName struct {
+	Formatted  string `json:"formatted,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+}
+
+type MultiValue struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// UserExtension contains the attributes of a user which are not part of the SCIM core schema
+type UserExtension struct {
+	Machine     bool              `json:"machine,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+}
+
+func (u *User) location() string {
+	return u.Meta.Location
+}
+
+func (u *User) validate() error {
+	if !containsSchema(u.Schemas, schemaUser) {
+		return newError(nil, "SCIM-ooN3e", "schema "+schemaUser+" missing", scimTypeInvalidSyntax)
+	}
+	if u.UserName == "" {
+		return newError(nil, "SCIM-Ied6a", "userName is required", scimTypeInvalidValue)
+	}
+	return nil
+}
+
+func (u *User) isMachine() bool {
+	return u.Zitadel != nil && u.Zitadel.Machine
+}
+
+func (u *User) isActive() bool {
+	return u.Active == nil || *u.Active
+}
+
+func (u *User) givenName() string {
+	if u.Name != nil && u.Name.GivenName != "" {
+		return u.Name.GivenName
+	}
+	return u.fallbackName()
+}
+
+func (u *User) familyName() string {
+	if u.Name != nil && u.Name.FamilyName != "" {
+		return u.Name.FamilyName
+	}
+	return u.fallbackName()
+}
+
+// fallbackName is used for the required names of a human if the client does not provide them
+func (u *User) fallbackName() string {
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
+	return u.UserName
+}
+
+// machineName is the name of a machine user, which is provided as displayName
+func (u *User) machineName() string {
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
+	if u.Name != nil && u.Name.Formatted != "" {
+		return u.Name.Formatted
+	}
+	return u.UserName
+}
+
+func (u *User) description() string {
+	if u.Zitadel == nil {
+		return ""
+	}
+	return u.Zitadel.Description
+}
+
+// primaryEmail returns the primary email or the first one if none is marked as primary
+// if no email is provided the userName is used as it's often the email address of the user
+func (u *User) primaryEmail() string {
+	if email := primaryValue(u.Emails); email != "" {
+		return email
+	}
+	if strings.Contains(u.UserName, "@") {
+		return u.UserName
+	}
+	return ""
+}
+
+func (u *User) primaryPhone() string {
+	return primaryValue(u.PhoneNumbers)
+}
+
+func (u *User) preferredLanguage() language.Tag {
+	tag, err := language.Parse(u.PreferredLanguage)
+	if err != nil {
+		return language.Und
+	}
+	return tag
+}
+
+// metadata returns the user metadata managed by the resource
+// the metadata of the extension is only managed if the client provides the extension
+func (u *User) metadata() (metadata map[string]string, managed func(key string) bool) {
+	metadata = make(map[string]string)
+	if u.ExternalID != "" {
+		metadata[metadataKeyExternalID] = u.ExternalID
+	}
+	if u.Zitadel == nil || u.Zitadel.Metadata == nil {
+		return metadata, func(key string) bool {
+			return key == metadataKeyExternalID
+		}
+	}
+	for key, value := range u.Zitadel.Metadata {
+		metadata[key] = value
+	}
+	return metadata, func(string) bool {
+		return true
+	}
+}
+
+func primaryValue(values []*MultiValue) string {
+	for _, value := range values {
+		if value.Primary {
+			return value.Value
+		}
+	}
+	if len(values) > 0 {
+		return values[0].Value
+	}
+	return ""
+}
+
+func (h *Handler) listUsers(r *http.Request) (interface{}, int, error) {
+	request, err := listRequestFromQuery(r)
+	if err != nil {
+		return nil, 0, err
+	}
+	return h.searchUsersByRequest(r, request)
+}
+
+func (h *Handler) searchUsers(r *http.Request) (interface{}, int, error) {
+	request, err := listRequestFromBody(r)
+	if err != nil {
+		return nil, 0, err
+	}
+	return h.searchUsersByRequest(r, request)
+}
+
+func (h *Handler) searchUsersByRequest(r *http.Request, request *listRequest) (interface{}, int, error) {
+	ownerQuery, err := query.NewUserResourceOwnerSearchQuery(orgID(r), query.TextEquals)
+	if err != nil {
+		return nil, 0, err
+	}
+	queries := []query.SearchQuery{ownerQuery}
+	if request.filter != nil {
+		filterQuery, err := userFilterToQuery(request.filter)
+		if err != nil {
+			return nil, 0, err
+		}
+		queries = append(queries, filterQuery)
+	}
+	sortingColumn, err := userSortingColumn(request.SortBy)
+	if err != nil {
+		return nil, 0, err
+	}
+	users, err := h.queries.SearchUsers(r.Context(), &query.UserSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset:        request.offset(),
+			Limit:         request.Count,
+			SortingColumn: sortingColumn,
+			Asc:           request.ascending(),
+		},
+		Queries: queries,
+	}, false)
+	if err != nil {
+		return nil, 0, err
+	}
+	resources := make([]*User, len(users.Users))
+	for i, user := range users.Users {
+		metadata, err := h.userMetadata(r.Context(), user.ID)
+		if err != nil {
+			return nil, 0, err
+		}
+		resources[i] = userToResource(h.baseURL(r), user, metadata)
+	}
+	return newListResponse(users.Count, request, resources, len(resources)), http.StatusOK, nil
+}
+
+func userSortingColumn(sortBy string) (query.Column, error) {
+	switch normalizeAttribute(sortBy) {
+	case "":
+		return query.Column{}, nil
+	case "id":
+		return query.UserIDCol, nil
+	case "username":
+		return query.UserUsernameCol, nil
+	case "name.givenname":
+		return query.HumanFirstNameCol, nil
+	case "name.familyname":
+		return query.HumanLastNameCol, nil
+	case "displayname":
+		return query.HumanDisplayNameCol, nil
+	case "nickname":
+		return query.HumanNickNameCol, nil
+	case "emails", "emails.value":
+		return query.HumanEmailCol, nil
+	case "phonenumbers", "phonenumbers.value":
+		return query.HumanPhoneCol, nil
+	case "meta.created":
+		return query.UserCreationDateCol, nil
+	case "meta.lastmodified":
+		return query.UserChangeDateCol, nil
+	default:
+		return query.Column{}, newError(nil, "SCIM-Je4ai", "sorting by "+sortBy+" is not supported", scimTypeInvalidValue)
+	}
+}
+
+// userFilterToQuery maps the filter onto the user search queries
+// `not` and the ordering operators (gt, ge, lt, le) are not supported
+func userFilterToQuery(expression filterExpression) (query.SearchQuery, error) {
+	switch e := expression.(type) {
+	case *logicalExpression:
+		if e.operator == logicalNot {
+			return nil, invalidFilter(nil, "SCIM-iu7Ee", "not is not supported")
+		}
+		left, err := userFilterToQuery(e.left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := userFilterToQuery(e.right)
+		if err != nil {
+			return nil, err
+		}
+		if e.operator == logicalOr {
+			return query.Or(left, right), nil
+		}
+		return query.And(left, right), nil
+	case *attributeExpression:
+		return userAttributeToQuery(e)
+	}
+	return nil, invalidFilter(nil, "SCIM-eiM4u", "unknown filter expression")
+}
+
+func userAttributeToQuery(expression *attributeExpression) (query.SearchQuery, error) {
+	switch expression.attribute {
+	case "id":
+		return textQuery(query.UserIDCol, expression)
+	case "username":
+		return textQuery(query.UserUsernameCol, expression)
+	case "name.givenname":
+		return textQuery(query.HumanFirstNameCol, expression)
+	case "name.familyname":
+		return textQuery(query.HumanLastNameCol, expression)
+	case "displayname":
+		return textQuery(query.HumanDisplayNameCol, expression)
+	case "nickname":
+		return textQuery(query.HumanNickNameCol, expression)
+	case "emails", "emails.value":
+		return textQuery(query.HumanEmailCol, expression)
+	case "phonenumbers", "phonenumbers.value":
+		return textQuery(query.HumanPhoneCol, expression)
+	case "externalid":
+		value, ok := expression.value.(string)
+		if !ok || expression.operator != compareEqual {
+			return nil, invalidFilter(nil, "SCIM-Ohd4e", "externalId only supports eq with a string")
+		}
+		return query.NewUserMetadataExistsQuery(metadataKeyExternalID, []byte(value))
+	case "active":
+		active, ok := expression.value.(bool)
+		if !ok || (expression.operator != compareEqual && expression.operator != compareNotEqual) {
+			return nil, invalidFilter(nil, "SCIM-oGh3a", "active only supports eq and ne with a boolean")
+		}
+		comparison := query.NumberNotEquals
+		if active != (expression.operator == compareEqual) {
+			comparison = query.NumberEquals
+		}
+		return query.NewNumberQuery(query.UserStateCol, domain.UserStateInactive, comparison)
+	}
+	return nil, invalidFilter(nil, "SCIM-ieG0o", "filtering by "+expression.attribute+" is not supported")
+}
+
+func textQuery(column query.Column, expression *attributeExpression) (query.SearchQuery, error) {
+	if expression.operator == comparePresent {
+		return query.NewNotNullQuery(column)
+	}
+	value, ok := expression.value.(string)
+	if !ok {
+		return nil, invalidFilter(nil, "SCIM-Eex3o", expression.attribute+" must be compared with a string")
+	}
+	var comparison query.TextComparison
+	switch expression.operator {
+	case compareEqual:
+		comparison = query.TextEqualsIgnoreCase
+	case compareNotEqual:
+		comparison = query.TextNotEquals
+	case compareContains:
+		comparison = query.TextContainsIgnoreCase
+	case compareStartsWith:
+		comparison = query.TextStartsWithIgnoreCase
+	case compareEndsWith:
+		comparison = query.TextEndsWithIgnoreCase
+	default:
+		return nil, invalidFilter(nil, "SCIM-Chai7", "operator "+string(expression.operator)+" is not supported")
+	}
+	return query.NewTextQuery(column, value, comparison)
+}
+
+func (h *Handler) getUser(r *http.Request) (interface{}, int, error) {
+	user, _, err := h.userByID(r, resourceID(r))
+	if err != nil {
+		return nil, 0, err
+	}
+	return user, http.StatusOK, nil
+}
+
+// userByID returns the SCIM representation of the user and the user itself
+func (h *Handler) userByID(r *http.Request, userID string) (*User, *query.User, error) {
+	ownerQuery, err := query.NewUserResourceOwnerSearchQuery(orgID(r), query.TextEquals)
+	if err != nil {
+		return nil, nil, err
+	}
+	user, err := h.queries.GetUserByID(r.Context(), true, userID, false, ownerQuery)
+	if err != nil {
+		return nil, nil, err
+	}
+	metadata, err := h.userMetadata(r.Context(), userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return userToResource(h.baseURL(r), user, metadata), user, nil
+}
+
+func (h *Handler) userMetadata(ctx context.Context, userID string) ([]*query.UserMetadata, error) {
+	metadata, err := h.queries.SearchUserMetadata(ctx, false, userID, &query.UserMetadataSearchQueries{}, false)
+	if err != nil {
+		return nil, err
+	}
+	return metadata.Metadata, nil
+}
+
+func (h *Handler) createUser(r *http.Request) (interface{}, int, error) {
+	ctx := r.Context()
+	user := new(User)
+	if err := readJSON(r, user); err != nil {
+		return nil, 0, err
+	}
+	if err := user.validate(); err != nil {
+		return nil, 0, err
+	}
+	var userID string
+	if user.isMachine() {
+		machine := &command.Machine{
+			ObjectRoot:  models.ObjectRoot{ResourceOwner: orgID(r)},
+			Username:    user.UserName,
+			Name:        user.machineName(),
+			Description: user.description(),
+		}
+		if _, err := h.commands.AddMachine(ctx, machine); err != nil {
+			return nil, 0, err
+		}
+		userID = machine.AggregateID
+	} else {
+		details, err := h.commands.AddHuman(ctx, orgID(r), userToAddHuman(user))
+		if err != nil {
+			return nil, 0, err
+		}
+		userID = details.ID
+	}
+	metadata, _ := user.metadata()
+	if err := h.updateUserMetadata(ctx, userID, orgID(r), nil, metadata, nil); err != nil {
+		return nil, 0, err
+	}
+	if !user.isActive() {
+		if _, err := h.commands.DeactivateUser(ctx, userID, orgID(r)); err != nil {
+			return nil, 0, err
+		}
+	}
+	created, _, err := h.userByID(r, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return created, http.StatusCreated, nil
+}
+
+func userToAddHuman(user *User) *command.AddHuman {
+	return &command.AddHuman{
+		Username:          user.UserName,
+		FirstName:         user.givenName(),
+		LastName:          user.familyName(),
+		NickName:          user.NickName,
+		DisplayName:       user.DisplayName,
+		PreferredLanguage: user.preferredLanguage(),
+		Email: command.Email{
+			Address:  user.primaryEmail(),
+			Verified: true,
+		},
+		Phone: command.Phone{
+			Number:   user.primaryPhone(),
+			Verified: true,
+		},
+		Password: user.Password,
+	}
+}
+
+func (h *Handler) replaceUser(r *http.Request) (interface{}, int, error) {
+	desired := new(User)
+	if err := readJSON(r, desired); err != nil {
+		return nil, 0, err
+	}
+	if err := desired.validate(); err != nil {
+		return nil, 0, err
+	}
+	return h.updateUser(r, desired)
+}
+
+func (h *Handler) patchUser(r *http.Request) (interface{}, int, error) {
+	request := new(patchRequest)
+	if err := readJSON(r, request); err != nil {
+		return nil, 0, err
+	}
+	if err := request.validate(); err != nil {
+		return nil, 0, err
+	}
+	current, _, err := h.userByID(r, resourceID(r))
+	if err != nil {
+		return nil, 0, err
+	}
+	desired := new(User)
+	if err = applyPatch(current, request.Operations, desired); err != nil {
+		return nil, 0, err
+	}
+	if err = desired.validate(); err != nil {
+		return nil, 0, err
+	}
+	return h.updateUser(r, desired)
+}
+
+// updateUser executes the commands needed to change the current state of the user into the desired
+func (h *Handler) updateUser(r *http.Request, desired *User) (interface{}, int, error) {
+	ctx := r.Context()
+	current, user, err := h.userByID(r, resourceID(r))
+	if err != nil {
+		return nil, 0, err
+	}
+	if desired.Zitadel != nil && desired.isMachine() != current.isMachine() {
+		return nil, 0, newError(nil, "SCIM-Ahs3u", "machine is immutable", scimTypeMutability)
+	}
+	if desired.UserName != current.UserName {
+		if _, err = h.commands.ChangeUsername(ctx, user.ResourceOwner, user.ID, desired.UserName); err != nil {
+			return nil, 0, err
+		}
+	}
+	if user.Machine != nil {
+		err = h.updateMachine(ctx, user, desired)
+	} else {
+		err = h.updateHuman(ctx, user, current, desired)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	if desired.isActive() != current.isActive() {
+		if desired.isActive() {
+			_, err = h.commands.ReactivateUser(ctx, user.ID, user.ResourceOwner)
+		} else {
+			_, err = h.commands.DeactivateUser(ctx, user.ID, user.ResourceOwner)
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	currentMetadata, _ := current.metadata()
+	desiredMetadata, managed := desired.metadata()
+	if err = h.updateUserMetadata(ctx, user.ID, user.ResourceOwner, currentMetadata, desiredMetadata, managed); err != nil {
+		return nil, 0, err
+	}
+	updated, _, err := h.userByID(r, user.ID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return updated, http.StatusOK, nil
+}
+
+func (h *Handler) updateMachine(ctx context.Context, user *query.User, desired *User) error {
+	description := user.Machine.Description
+	if desired.Zitadel != nil {
+		description = desired.Zitadel.Description
+	}
+	if desired.machineName() == user.Machine.Name && description == user.Machine.Description {
+		return nil
+	}
+	_, err := h.commands.ChangeMachine(ctx, &command.Machine{
+		ObjectRoot:      models.ObjectRoot{AggregateID: user.ID, ResourceOwner: user.ResourceOwner},
+		Name:            desired.machineName(),
+		Description:     description,
+		AccessTokenType: user.Machine.AccessTokenType,
+	})
+	return err
+}
+
+func (h *Handler) updateHuman(ctx context.Context, user *query.User, current, desired *User) (err error) {
+	profile := &domain.Profile{
+		ObjectRoot:        models.ObjectRoot{AggregateID: user.ID, ResourceOwner: user.ResourceOwner},
+		FirstName:         desired.givenName(),
+		LastName:          desired.familyName(),
+		NickName:          desired.NickName,
+		DisplayName:       desired.DisplayName,
+		PreferredLanguage: desired.preferredLanguage(),
+		Gender:            user.Human.Gender,
+	}
+	if desired.PreferredLanguage == "" {
+		profile.PreferredLanguage = user.Human.PreferredLanguage
+	}
+	if profile.DisplayName == "" {
+		profile.DisplayName = user.Human.DisplayName
+	}
+	if profileChanged(user.Human, profile) {
+		if _, err = h.commands.ChangeHumanProfile(ctx, profile); err != nil {
+			return err
+		}
+	}
+	if email := desired.primaryEmail(); email != "" && email != current.primaryEmail() {
+		_, err = h.commands.ChangeHumanEmail(ctx, &domain.Email{
+			ObjectRoot:      models.ObjectRoot{AggregateID: user.ID, ResourceOwner: user.ResourceOwner},
+			EmailAddress:    email,
+			IsEmailVerified: true,
+		}, nil)
+		if err != nil {
+			return err
+		}
+	}
+	if phone := desired.primaryPhone(); phone != current.primaryPhone() {
+		if phone == "" {
+			_, err = h.commands.RemoveHumanPhone(ctx, user.ID, user.ResourceOwner)
+		} else {
+			_, err = h.commands.ChangeHumanPhone(ctx, &domain.Phone{
+				ObjectRoot:      models.ObjectRoot{AggregateID: user.ID},
+				PhoneNumber:     phone,
+				IsPhoneVerified: true,
+			}, user.ResourceOwner, nil)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if desired.Password != "" {
+		_, err = h.commands.SetPassword(ctx, user.ResourceOwner, user.ID, desired.Password, false)
+	}
+	return err
+}
+
+func profileChanged(human *query.Human, profile *domain.Profile) bool {
+	return human.FirstName != profile.FirstName ||
+		human.LastName != profile.LastName ||
+		human.NickName != profile.NickName ||
+		human.DisplayName != profile.DisplayName ||
+		human.PreferredLanguage != profile.PreferredLanguage
+}
+
+// updateUserMetadata sets the changed metadata and removes the managed keys which are not desired anymore
+// if managed is nil, no metadata is removed
+func (h *Handler) updateUserMetadata(ctx context.Context, userID, resourceOwner string, current, desired map[string]string, managed func(key string) bool) (err error) {
+	set := make([]*domain.Metadata, 0, len(desired))
+	for key, value := range desired {
+		if currentValue, ok := current[key]; !ok || currentValue != value {
+			set = append(set, &domain.Metadata{Key: key, Value: []byte(value)})
+		}
+	}
+	remove := make([]string, 0, len(current))
+	for key := range current {
+		if _, ok := desired[key]; !ok && managed != nil && managed(key) {
+			remove = append(remove, key)
+		}
+	}
+	if len(set) > 0 {
+		if _, err = h.commands.BulkSetUserMetadata(ctx, userID, resourceOwner, set...); err != nil {
+			return err
+		}
+	}
+	if len(remove) > 0 {
+		_, err = h.commands.BulkRemoveUserMetadata(ctx, userID, resourceOwner, remove...)
+	}
+	return err
+}
+
+func (h *Handler) deleteUser(r *http.Request) (interface{}, int, error) {
+	ctx := r.Context()
+	userID := resourceID(r)
+	if _, _, err := h.userByID(r, userID); err != nil {
+		return nil, 0, err
+	}
+	memberships, grants, err := h.removeUserDependencies(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if _, err = h.commands.RemoveUser(ctx, userID, orgID(r), memberships, grants...); err != nil {
+		return nil, 0, err
+	}
+	return nil, http.StatusNoContent, nil
+}
+
+func (h *Handler) removeUserDependencies(ctx context.Context, userID string) ([]*command.CascadingMembership, []string, error) {
+	userGrantUserQuery, err := query.NewUserGrantUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	grants, err := h.queries.UserGrants(ctx, &query.UserGrantsQueries{
+		Queries: []query.SearchQuery{userGrantUserQuery},
+	}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	membershipsUserQuery, err := query.NewMembershipUserIDQuery(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	memberships, err := h.queries.Memberships(ctx, &query.MembershipSearchQuery{
+		Queries: []query.SearchQuery{membershipsUserQuery},
+	}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cascadingMemberships(memberships.Memberships), userGrantsToIDs(grants.UserGrants), nil
+}
+
+func cascadingMemberships(memberships []*query.Membership) []*command.CascadingMembership {
+	cascades := make([]*command.CascadingMembership, len(memberships))
+	for i, membership := range memberships {
+		cascade := &command.CascadingMembership{
+			UserID:        membership.UserID,
+			ResourceOwner: membership.ResourceOwner,
+		}
+		if membership.IAM != nil {
+			cascade.IAM = &command.CascadingIAMMembership{IAMID: membership.IAM.IAMID}
+		}
+		if membership.Org != nil {
+			cascade.Org = &command.CascadingOrgMembership{OrgID: membership.Org.OrgID}
+		}
+		if membership.Project != nil {
+			cascade.Project = &command.CascadingProjectMembership{ProjectID: membership.Project.ProjectID}
+		}
+		if membership.ProjectGrant != nil {
+			cascade.ProjectGrant = &command.CascadingProjectGrantMembership{ProjectID: membership.ProjectGrant.ProjectID, GrantID: membership.ProjectGrant.GrantID}
+		}
+		cascades[i] = cascade
+	}
+	return cascades
+}
+
+func userGrantsToIDs(userGrants []*query.UserGrant) []string {
+	ids := make([]string, len(userGrants))
+	for i, grant := range userGrants {
+		ids[i] = grant.ID
+	}
+	return ids
+}
+
+func userToResource(baseURL string, user *query.User, metadata []*query.UserMetadata) *User {
+	active := user.State != domain.UserStateInactive
+	resource := &User{
+		Schemas:  []string{schemaUser, schemaZitadelUser},
+		ID:       user.ID,
+		UserName: user.Username,
+		Active:   &active,
+		Zitadel:  new(UserExtension),
+		Meta:     newMeta(resourceTypeUser, user.CreationDate, user.ChangeDate, user.Sequence, baseURL+"/Users/"+user.ID),
+	}
+	for _, data := range metadata {
+		if data.Key == metadataKeyExternalID {
+			resource.ExternalID = string(data.Value)
+			continue
+		}
+		if resource.Zitadel.Metadata == nil {
+			resource.Zitadel.Metadata = make(map[string]string)
+		}
+		resource.Zitadel.Metadata[data.Key] = string(data.Value)
+	}
+	if user.Machine != nil {
+		resource.DisplayName = user.Machine.Name
+		resource.Zitadel.Machine = true
+		resource.Zitadel.Description = user.Machine.Description
+		return resource
+	}
+	if user.Human == nil {
+		return resource
+	}
+	resource.Name = &UserName{
+		Formatted:  strings.TrimSpace(user.Human.FirstName + " " + user.Human.LastName),
+		FamilyName: user.Human.LastName,
+		GivenName:  user.Human.FirstName,
+	}
+	resource.DisplayName = user.Human.DisplayName
+	resource.NickName = user.Human.NickName
+	if !user.Human.PreferredLanguage.IsRoot() {
+		resource.PreferredLanguage = user.Human.PreferredLanguage.String()
+	}
+	if user.Human.Email != "" {
+		resource.Emails = []*MultiValue{{Value: user.Human.Email, Type: "work", Primary: true}}
+	}
+	if user.Human.Phone != "" {
+		resource.PhoneNumbers = []*MultiValue{{Value: user.Human.Phone, Type: "work", Primary: true}}
+	}
+	return resource
+}
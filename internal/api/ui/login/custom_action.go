@@ -2,18 +2,17 @@ package login
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 
-	"github.com/dop251/goja"
 	"github.com/zitadel/oidc/v2/pkg/oidc"
-	"golang.org/x/text/language"
 
 	"github.com/zitadel/zitadel/internal/actions"
 	"github.com/zitadel/zitadel/internal/actions/object"
+	"github.com/zitadel/zitadel/internal/actions/trigger"
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/domain"
 	iam_model "github.com/zitadel/zitadel/internal/iam/model"
+	"github.com/zitadel/zitadel/internal/query"
 )
 
 func (l *Login) runPostExternalAuthenticationActions(
@@ -40,66 +39,12 @@ func (l *Login) runPostExternalAuthenticationActions(
 	}
 
 	metadataList := object.MetadataListFromDomain(user.Metadatas)
-	apiFields := actions.WithAPIFields(
-		actions.SetFields("setFirstName", func(firstName string) {
-			user.FirstName = firstName
-		}),
-		actions.SetFields("setLastName", func(lastName string) {
-			user.LastName = lastName
-		}),
-		actions.SetFields("setNickName", func(nickName string) {
-			user.NickName = nickName
-		}),
-		actions.SetFields("setDisplayName", func(displayName string) {
-			user.DisplayName = displayName
-		}),
-		actions.SetFields("setPreferredLanguage", func(preferredLanguage string) {
-			user.PreferredLanguage = language.Make(preferredLanguage)
-		}),
-		actions.SetFields("setPreferredUsername", func(username string) {
-			user.PreferredUsername = username
-		}),
-		actions.SetFields("setEmail", func(email string) {
-			user.Email = email
-		}),
-		actions.SetFields("setEmailVerified", func(verified bool) {
-			user.IsEmailVerified = verified
-		}),
-		actions.SetFields("setPhone", func(phone string) {
-			user.Phone = phone
-		}),
-		actions.SetFields("setPhoneVerified", func(verified bool) {
-			user.IsPhoneVerified = verified
-		}),
-		actions.SetFields("metadata", &metadataList.Metadata),
-		actions.SetFields("v1",
-			actions.SetFields("user",
-				actions.SetFields("appendMetadata", metadataList.AppendMetadataFunc),
-			),
-		),
-	)
-
-	authErrStr := "none"
-	if authenticationError != nil {
-		authErrStr = authenticationError.Error()
-	}
+	apiFields := actions.WithAPIFields(trigger.PostExternalAuthenticationAPIFields(user, metadataList)...)
+	ctxFields := actions.SetContextFields(trigger.PostExternalAuthenticationContextFields(tokens, user, authRequest, httpRequest, authenticationError)...)
 
 	for _, a := range triggerActions {
 		actionCtx, cancel := context.WithTimeout(ctx, a.Timeout())
 
-		ctxFieldOptions := append(tokenCtxFields(tokens),
-			actions.SetFields("v1",
-				actions.SetFields("externalUser", func(c *actions.FieldConfig) interface{} {
-					return object.UserFromExternalUser(c, user)
-				}),
-				actions.SetFields("authRequest", object.AuthRequestField(authRequest)),
-				actions.SetFields("httpRequest", object.HTTPRequestField(httpRequest)),
-				actions.SetFields("authError", authErrStr),
-			),
-		)
-
-		ctxFields := actions.SetContextFields(ctxFieldOptions...)
-
 		err = actions.Run(
 			actionCtx,
 			ctxFields,
@@ -124,6 +69,8 @@ const (
 	authMethodOTP          authMethod = "OTP"
 	authMethodU2F          authMethod = "U2F"
 	authMethodPasswordless authMethod = "passwordless"
+	authMethodExternal     authMethod = "external"
+	authMethodLDAP         authMethod = "LDAP"
 )
 
 func (l *Login) runPostInternalAuthenticationActions(
@@ -145,44 +92,75 @@ func (l *Login) runPostInternalAuthenticationActions(
 	}
 
 	metadataList := object.MetadataListFromDomain(nil)
-	apiFields := actions.WithAPIFields(
-		actions.SetFields("metadata", &metadataList.Metadata),
-		actions.SetFields("v1",
-			actions.SetFields("user",
-				actions.SetFields("appendMetadata", metadataList.AppendMetadataFunc),
-			),
-		),
-	)
+	apiFields := actions.WithAPIFields(trigger.MetadataAPIFields(metadataList)...)
+	ctxFields := actions.SetContextFields(trigger.PostInternalAuthenticationContextFields(string(authMethod), authRequest, httpRequest, authenticationError)...)
+
 	for _, a := range triggerActions {
 		actionCtx, cancel := context.WithTimeout(ctx, a.Timeout())
 
-		authErrStr := "none"
-		if authenticationError != nil {
-			authErrStr = authenticationError.Error()
+		err = actions.Run(
+			actionCtx,
+			ctxFields,
+			apiFields,
+			a.Script,
+			a.Name,
+			append(actions.ActionToOptions(a), actions.WithHTTP(actionCtx))...,
+		)
+		cancel()
+		if err != nil {
+			return nil, err
 		}
+	}
+	return object.MetadataListToDomain(metadataList), err
+}
+
+// runPreAuthenticationActions runs the actions of the pre authentication flow before the first factor is checked
+// (password, passwordless, LDAP or before redirecting to an external identity provider).
+// An action can block the authentication by throwing an error.
+func (l *Login) runPreAuthenticationActions(
+	authRequest *domain.AuthRequest,
+	httpRequest *http.Request,
+	authMethod authMethod,
+) error {
+	ctx := httpRequest.Context()
+
+	resourceOwner := authRequest.RequestedOrgID
+	if resourceOwner == "" {
+		resourceOwner = authRequest.UserOrgID
+	}
+
+	triggerActions, err := l.query.GetActiveActionsByFlowAndTriggerType(ctx, domain.FlowTypePreAuthentication, domain.TriggerTypePreAuthentication, resourceOwner, false)
+	if err != nil {
+		return err
+	}
+
+	for _, a := range triggerActions {
+		actionCtx, cancel := context.WithTimeout(ctx, a.Timeout())
+
 		ctxFields := actions.SetContextFields(
-			actions.SetFields("v1",
-				actions.SetFields("authMethod", authMethod),
-				actions.SetFields("authError", authErrStr),
-				actions.SetFields("authRequest", object.AuthRequestField(authRequest)),
-				actions.SetFields("httpRequest", object.HTTPRequestField(httpRequest)),
-			),
+			trigger.PreAuthenticationContextFields(string(authMethod), func() (*query.User, error) {
+				// the user is not known yet, if an external identity provider was selected before entering the login name
+				if authRequest.UserID == "" {
+					return nil, nil
+				}
+				return l.query.GetUserByID(actionCtx, false, authRequest.UserID, false)
+			}, authRequest, httpRequest)...,
 		)
 
 		err = actions.Run(
 			actionCtx,
 			ctxFields,
-			apiFields,
+			nil,
 			a.Script,
 			a.Name,
 			append(actions.ActionToOptions(a), actions.WithHTTP(actionCtx))...,
 		)
 		cancel()
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
-	return object.MetadataListToDomain(metadataList), err
+	return nil
 }
 
 func (l *Login) runPreCreationActions(
@@ -201,76 +179,15 @@ func (l *Login) runPreCreationActions(
 	}
 
 	metadataList := object.MetadataListFromDomain(metadata)
-	apiFields := actions.WithAPIFields(
-		actions.SetFields("setFirstName", func(firstName string) {
-			user.FirstName = firstName
-		}),
-		actions.SetFields("setLastName", func(lastName string) {
-			user.LastName = lastName
-		}),
-		actions.SetFields("setNickName", func(nickName string) {
-			user.NickName = nickName
-		}),
-		actions.SetFields("setDisplayName", func(displayName string) {
-			user.DisplayName = displayName
-		}),
-		actions.SetFields("setPreferredLanguage", func(preferredLanguage string) {
-			user.PreferredLanguage = language.Make(preferredLanguage)
-		}),
-		actions.SetFields("setGender", func(gender domain.Gender) {
-			user.Gender = gender
-		}),
-		actions.SetFields("setUsername", func(username string) {
-			user.Username = username
-		}),
-		actions.SetFields("setEmail", func(email string) {
-			if user.Email == nil {
-				user.Email = &domain.Email{}
-			}
-			user.Email.EmailAddress = email
-		}),
-		actions.SetFields("setEmailVerified", func(verified bool) {
-			if user.Email == nil {
-				return
-			}
-			user.Email.IsEmailVerified = verified
-		}),
-		actions.SetFields("setPhone", func(email string) {
-			if user.Phone == nil {
-				user.Phone = &domain.Phone{}
-			}
-			user.Phone.PhoneNumber = email
-		}),
-		actions.SetFields("setPhoneVerified", func(verified bool) {
-			if user.Phone == nil {
-				return
-			}
-			user.Phone.IsPhoneVerified = verified
-		}),
-		actions.SetFields("metadata", &metadataList.Metadata),
-		actions.SetFields("v1",
-			actions.SetFields("user",
-				actions.SetFields("appendMetadata", metadataList.AppendMetadataFunc),
-			),
-		),
-	)
+	apiFields := actions.WithAPIFields(trigger.PreCreationAPIFields(user, metadataList)...)
+	ctxFields := actions.SetContextFields(trigger.PreCreationContextFields(user, authRequest, httpRequest)...)
 
 	for _, a := range triggerActions {
 		actionCtx, cancel := context.WithTimeout(ctx, a.Timeout())
 
-		ctxOpts := actions.SetContextFields(
-			actions.SetFields("v1",
-				actions.SetFields("user", func(c *actions.FieldConfig) interface{} {
-					return object.UserFromHuman(c, user)
-				}),
-				actions.SetFields("authRequest", object.AuthRequestField(authRequest)),
-				actions.SetFields("httpRequest", object.HTTPRequestField(httpRequest)),
-			),
-		)
-
 		err = actions.Run(
 			actionCtx,
-			ctxOpts,
+			ctxFields,
 			apiFields,
 			a.Script,
 			a.Name,
@@ -300,30 +217,15 @@ func (l *Login) runPostCreationActions(
 
 	mutableUserGrants := &object.UserGrants{UserGrants: make([]object.UserGrant, 0)}
 
-	apiFields := actions.WithAPIFields(
-		actions.SetFields("userGrants", &mutableUserGrants.UserGrants),
-		actions.SetFields("v1",
-			actions.SetFields("appendUserGrant", object.AppendGrantFunc(mutableUserGrants)),
-		),
-	)
+	apiFields := actions.WithAPIFields(trigger.UserGrantAPIFields(mutableUserGrants)...)
 
 	for _, a := range triggerActions {
 		actionCtx, cancel := context.WithTimeout(ctx, a.Timeout())
 
 		ctxFields := actions.SetContextFields(
-			actions.SetFields("v1",
-				actions.SetFields("getUser", func(c *actions.FieldConfig) interface{} {
-					return func(call goja.FunctionCall) goja.Value {
-						user, err := l.query.GetUserByID(actionCtx, true, userID, false)
-						if err != nil {
-							panic(err)
-						}
-						return object.UserFromQuery(c, user)
-					}
-				}),
-				actions.SetFields("authRequest", object.AuthRequestField(authRequest)),
-				actions.SetFields("httpRequest", object.HTTPRequestField(httpRequest)),
-			),
+			trigger.PostCreationContextFields(func() (*query.User, error) {
+				return l.query.GetUserByID(actionCtx, true, userID, false)
+			}, authRequest, httpRequest)...,
 		)
 
 		err = actions.Run(
@@ -341,20 +243,3 @@ func (l *Login) runPostCreationActions(
 	}
 	return object.UserGrantsToDomain(userID, mutableUserGrants.UserGrants), err
 }
-
-func tokenCtxFields(tokens *oidc.Tokens) []actions.FieldOption {
-	return []actions.FieldOption{
-		actions.SetFields("accessToken", tokens.AccessToken),
-		actions.SetFields("idToken", tokens.IDToken),
-		actions.SetFields("getClaim", func(claim string) interface{} {
-			return tokens.IDTokenClaims.GetClaim(claim)
-		}),
-		actions.SetFields("claimsJSON", func() (string, error) {
-			c, err := json.Marshal(tokens.IDTokenClaims)
-			if err != nil {
-				return "", err
-			}
-			return string(c), nil
-		}),
-	}
-}
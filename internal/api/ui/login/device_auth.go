@@ -0,0 +1,208 @@
+package login
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	tmplDeviceAuthUserCode = "device-usercode"
+	tmplDeviceAuthAction   = "device-action"
+	tmplDeviceAuthDone     = "device-done"
+
+	queryDeviceAuthUserCode = "user_code"
+
+	deviceAuthActionAllow = "allow"
+	deviceAuthActionDeny  = "deny"
+)
+
+type deviceAuthUserCodeFormData struct {
+	UserCode string `schema:"user_code"`
+}
+
+type deviceAuthUserCodeData struct {
+	baseData
+	UserCode string
+}
+
+type deviceAuthActionFormData struct {
+	Action string `schema:"action"`
+}
+
+type deviceAuthActionData struct {
+	userData
+	AppName  string
+	UserCode string
+}
+
+type deviceAuthDoneData struct {
+	baseData
+	Approved bool
+}
+
+// handleDeviceAuthUserCode renders the page, where the user enters the code displayed on the device.
+// The code is prefilled if the device displayed the verification_uri_complete.
+func (l *Login) handleDeviceAuthUserCode(w http.ResponseWriter, r *http.Request) {
+	l.renderDeviceAuthUserCode(w, r, r.FormValue(queryDeviceAuthUserCode), nil)
+}
+
+func (l *Login) renderDeviceAuthUserCode(w http.ResponseWriter, r *http.Request, userCode string, err error) {
+	var errID, errMessage string
+	if err != nil {
+		errID, errMessage = l.getErrorMessage(r, err)
+	}
+	data := &deviceAuthUserCodeData{
+		baseData: l.getBaseData(r, nil, "DeviceAuth.Title", "DeviceAuth.UserCode.Description", errID, errMessage),
+		UserCode: userCode,
+	}
+	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), nil), l.renderer.Templates[tmplDeviceAuthUserCode], data, nil)
+}
+
+// handleDeviceAuthUserCodeCheck creates an auth request for the device authorization of the entered code
+// and starts the login of the user
+func (l *Login) handleDeviceAuthUserCodeCheck(w http.ResponseWriter, r *http.Request) {
+	data := new(deviceAuthUserCodeFormData)
+	if err := l.getParseData(r, data); err != nil {
+		l.renderDeviceAuthUserCode(w, r, "", err)
+		return
+	}
+	userCode := NormalizeDeviceUserCode(data.UserCode)
+	deviceAuth, err := l.query.DeviceAuthByUserCode(r.Context(), userCode)
+	if err != nil {
+		l.renderDeviceAuthUserCode(w, r, data.UserCode, caos_errs.ThrowNotFound(err, "LOGIN-Oe1oo", "Errors.DeviceAuth.NotFound"))
+		return
+	}
+	if deviceAuth.State != domain.DeviceAuthStateInitiated || deviceAuth.Expires.Before(time.Now()) {
+		l.renderDeviceAuthUserCode(w, r, data.UserCode, caos_errs.ThrowNotFound(nil, "LOGIN-ahm4U", "Errors.DeviceAuth.NotFound"))
+		return
+	}
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	authReq, err := l.authRepo.CreateAuthRequest(r.Context(), &domain.AuthRequest{
+		CreationDate:  time.Now(),
+		AgentID:       userAgentID,
+		BrowserInfo:   domain.BrowserInfoFromRequest(r),
+		ApplicationID: deviceAuth.ClientID,
+		InstanceID:    authz.GetInstance(r.Context()).InstanceID(),
+		Request: &domain.AuthRequestDevice{
+			ID:         deviceAuth.ID,
+			DeviceCode: deviceAuth.DeviceCode,
+			UserCode:   deviceAuth.UserCode,
+			Scopes:     deviceAuth.Scopes,
+		},
+	})
+	if err != nil {
+		l.renderDeviceAuthUserCode(w, r, data.UserCode, err)
+		return
+	}
+	http.Redirect(w, r, l.renderer.pathPrefix+EndpointLogin+"?"+QueryAuthRequestID+"="+authReq.ID, http.StatusFound)
+}
+
+// renderDeviceAuthAction asks the logged in user to approve or deny the device
+func (l *Login) renderDeviceAuthAction(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, err error) {
+	var errID, errMessage string
+	if err != nil {
+		errID, errMessage = l.getErrorMessage(r, err)
+	}
+	device, ok := authReq.Request.(*domain.AuthRequestDevice)
+	if !ok {
+		l.renderInternalError(w, r, authReq, caos_errs.ThrowInvalidArgument(nil, "LOGIN-Eu9ee", "Errors.AuthRequest.RequestTypeNotSupported"))
+		return
+	}
+	data := &deviceAuthActionData{
+		userData: l.getUserData(r, authReq, "DeviceAuth.Title", "DeviceAuth.Action.Description", errID, errMessage),
+		UserCode: device.UserCode,
+	}
+	if authReq.ApplicationID != "" {
+		app, err := l.query.AppByOIDCClientID(r.Context(), authReq.ApplicationID, false)
+		logging.WithFields("clientID", authReq.ApplicationID).OnError(err).Warn("unable to get app name for device authorization")
+		if err == nil {
+			data.AppName = app.Name
+		}
+	}
+	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), authReq), l.renderer.Templates[tmplDeviceAuthAction], data, nil)
+}
+
+// handleDeviceAuthAction approves or denies the device authorization after the user has logged in.
+// On approval the device code is stored as code of the auth request,
+// so the token endpoint is able to issue the tokens based on the login of the user.
+func (l *Login) handleDeviceAuthAction(w http.ResponseWriter, r *http.Request) {
+	data := new(deviceAuthActionFormData)
+	authReq, err := l.getAuthRequestAndParseData(r, data)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	if authReq == nil {
+		l.renderInternalError(w, r, nil, caos_errs.ThrowInvalidArgument(nil, "LOGIN-ieX1a", "Errors.AuthRequest.NotFound"))
+		return
+	}
+	device, ok := authReq.Request.(*domain.AuthRequestDevice)
+	if !ok {
+		l.renderInternalError(w, r, authReq, caos_errs.ThrowInvalidArgument(nil, "LOGIN-ooK4o", "Errors.AuthRequest.RequestTypeNotSupported"))
+		return
+	}
+	if !authRequestDone(authReq) {
+		l.renderNextStep(w, r, authReq)
+		return
+	}
+	switch data.Action {
+	case deviceAuthActionAllow:
+		err = l.authRepo.SaveAuthCode(r.Context(), authReq.ID, device.DeviceCode, authReq.AgentID)
+		if err != nil {
+			l.renderDeviceAuthAction(w, r, authReq, err)
+			return
+		}
+		_, err = l.command.ApproveDeviceAuth(setContext(r.Context(), authReq.UserOrgID), device.ID, authReq.UserID)
+		if err != nil {
+			l.renderDeviceAuthAction(w, r, authReq, err)
+			return
+		}
+		l.renderDeviceAuthDone(w, r, authReq, true)
+	case deviceAuthActionDeny:
+		_, err = l.command.CancelDeviceAuth(setContext(r.Context(), authReq.UserOrgID), device.ID, domain.DeviceAuthCanceledDenied)
+		if err != nil {
+			l.renderDeviceAuthAction(w, r, authReq, err)
+			return
+		}
+		err = l.authRepo.DeleteAuthRequest(r.Context(), authReq.ID)
+		logging.WithFields("authRequestID", authReq.ID).OnError(err).Warn("unable to delete auth request of denied device authorization")
+		l.renderDeviceAuthDone(w, r, authReq, false)
+	default:
+		l.renderDeviceAuthAction(w, r, authReq, caos_errs.ThrowInvalidArgument(nil, "LOGIN-Thai1", "Errors.DeviceAuth.InvalidAction"))
+	}
+}
+
+func (l *Login) renderDeviceAuthDone(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, approved bool) {
+	description := "DeviceAuth.Done.Denied"
+	if approved {
+		description = "DeviceAuth.Done.Approved"
+	}
+	data := &deviceAuthDoneData{
+		baseData: l.getBaseData(r, authReq, "DeviceAuth.Title", description, "", ""),
+		Approved: approved,
+	}
+	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), authReq), l.renderer.Templates[tmplDeviceAuthDone], data, nil)
+}
+
+func authRequestDone(authReq *domain.AuthRequest) bool {
+	for _, step := range authReq.PossibleSteps {
+		if step.Type() == domain.NextStepRedirectToCallback {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeDeviceUserCode removes the separators and ignores the case of the user code,
+// as the user might enter it differently than it was displayed (RFC 8628, section 6.1)
+func NormalizeDeviceUserCode(userCode string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(userCode))
+}
@@ -86,10 +86,18 @@ func (l *Login) handleExternalLogin(w http.ResponseWriter, r *http.Request) {
 
 func (l *Login) handleIDP(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, selectedIDPConfigID string) {
 	idpConfig, err := l.getIDPConfigByID(r, selectedIDPConfigID)
+	if errors.IsNotFound(err) {
+		l.handleIDPTemplate(w, r, authReq, selectedIDPConfigID)
+		return
+	}
 	if err != nil {
 		l.renderError(w, r, authReq, err)
 		return
 	}
+	if err = l.runPreAuthenticationActions(authReq, r, authMethodExternal); err != nil {
+		l.renderLogin(w, r, authReq, err)
+		return
+	}
 	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
 	err = l.authRepo.SelectExternalIDP(r.Context(), authReq.ID, idpConfig.IDPConfigID, userAgentID)
 	if err != nil {
@@ -142,6 +150,10 @@ func (l *Login) handleExternalLoginCallback(w http.ResponseWriter, r *http.Reque
 		l.renderError(w, r, nil, err)
 		return
 	}
+	if strings.HasPrefix(data.State, sessionStatePrefix) {
+		l.handleSessionExternalLoginCallback(w, r, data.State, data.Code)
+		return
+	}
 	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
 	authReq, err := l.authRepo.AuthRequestByID(r.Context(), data.State, userAgentID)
 	if err != nil {
@@ -149,6 +161,10 @@ func (l *Login) handleExternalLoginCallback(w http.ResponseWriter, r *http.Reque
 		return
 	}
 	idpConfig, err := l.authRepo.GetIDPConfigByID(r.Context(), authReq.SelectedIDPConfigID)
+	if errors.IsNotFound(err) {
+		l.handleOAuthTemplateCallback(w, r, authReq, userAgentID, data.Code)
+		return
+	}
 	if err != nil {
 		l.renderError(w, r, authReq, err)
 		return
@@ -284,7 +300,7 @@ func (l *Login) renderExternalNotFoundOption(w http.ResponseWriter, r *http.Requ
 	}
 
 	if human == nil || externalIDP == nil {
-		idpConfig, err := l.authRepo.GetIDPConfigByID(r.Context(), authReq.SelectedIDPConfigID)
+		idpConfig, err := l.getIDPConfigOrTemplateByID(r, authReq.SelectedIDPConfigID)
 		if err != nil {
 			l.renderError(w, r, authReq, err)
 			return
@@ -376,7 +392,7 @@ func (l *Login) handleAutoRegister(w http.ResponseWriter, r *http.Request, authR
 		return
 	}
 
-	idpConfig, err := l.authRepo.GetIDPConfigByID(r.Context(), authReq.SelectedIDPConfigID)
+	idpConfig, err := l.getIDPConfigOrTemplateByID(r, authReq.SelectedIDPConfigID)
 	if err != nil {
 		l.renderExternalNotFoundOption(w, r, authReq, orgIamPolicy, nil, nil, err)
 		return
@@ -0,0 +1,240 @@
+package login
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/providers/azuread"
+	"github.com/zitadel/zitadel/internal/idp/providers/github"
+	"github.com/zitadel/zitadel/internal/idp/providers/gitlab"
+	"github.com/zitadel/zitadel/internal/idp/providers/google"
+	"github.com/zitadel/zitadel/internal/idp/providers/oauth"
+	"github.com/zitadel/zitadel/internal/idp/providers/oidc"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// handleOAuthTemplate starts the authentication on an OAuth 2.0 / OIDC based identity provider template.
+// The auth request id is used as state, so the callback can be handled by the existing external login callback.
+func (l *Login) handleOAuthTemplate(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, template *query.IDPTemplate) {
+	provider, err := l.templateProvider(r.Context(), template)
+	if err != nil {
+		l.renderLogin(w, r, authReq, err)
+		return
+	}
+	session, err := provider.BeginAuth(r.Context(), authReq.ID)
+	if err != nil {
+		l.renderLogin(w, r, authReq, err)
+		return
+	}
+	http.Redirect(w, r, session.GetAuthURL(), http.StatusFound)
+}
+
+// handleOAuthTemplateCallback exchanges the code and retrieves the user information
+// of an OAuth 2.0 / OIDC based identity provider template
+func (l *Login) handleOAuthTemplateCallback(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, userAgentID, code string) {
+	template, err := l.query.IDPTemplateByID(r.Context(), false, authReq.SelectedIDPConfigID, false)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	provider, err := l.templateProvider(r.Context(), template)
+	if err != nil {
+		l.renderLogin(w, r, authReq, err)
+		return
+	}
+	session, err := templateSession(provider, code)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	user, err := session.FetchUser(r.Context())
+	if err != nil {
+		l.renderLogin(w, r, authReq, err)
+		return
+	}
+	l.handleExternalTemplateUser(w, r, authReq, userAgentID, template, user)
+}
+
+// templateSession creates the [idp.Session] of the OAuth 2.0 / OIDC based provider for the code returned in the callback
+func templateSession(provider idp.Provider, code string) (idp.Session, error) {
+	switch p := provider.(type) {
+	case *azuread.Provider:
+		return &oauth.Session{Provider: p.Provider, Code: code}, nil
+	case *github.Provider:
+		return &oauth.Session{Provider: p.Provider, Code: code}, nil
+	case *oidc.Provider:
+		return &oidc.Session{Provider: p, Code: code}, nil
+	case *google.Provider:
+		return &oidc.Session{Provider: p.Provider, Code: code}, nil
+	case *gitlab.Provider:
+		return &oidc.Session{Provider: p.Provider, Code: code}, nil
+	default:
+		return nil, errors.ThrowPreconditionFailed(nil, "LOGIN-Xn3rf", "Errors.ExternalIDP.IDPTypeNotImplemented")
+	}
+}
+
+// handleExternalTemplateUser checks the user authenticated on an identity provider template
+// and redirects to the login, which will render the next step
+func (l *Login) handleExternalTemplateUser(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, userAgentID string, template *query.IDPTemplate, user idp.User) {
+	externalUser := &domain.ExternalUser{
+		IDPConfigID:       template.ID,
+		ExternalUserID:    user.GetID(),
+		PreferredUsername: user.GetPreferredUsername(),
+		DisplayName:       user.GetDisplayName(),
+		FirstName:         user.GetFirstName(),
+		LastName:          user.GetLastName(),
+		NickName:          user.GetNickname(),
+		Email:             user.GetEmail(),
+		IsEmailVerified:   user.IsEmailVerified(),
+		Phone:             user.GetPhone(),
+		IsPhoneVerified:   user.IsPhoneVerified(),
+		PreferredLanguage: user.GetPreferredLanguage(),
+	}
+	if externalUser.DisplayName == "" {
+		externalUser.DisplayName = externalUser.PreferredUsername
+	}
+	if externalUser.DisplayName == "" {
+		externalUser.DisplayName = externalUser.Email
+	}
+	err := l.authRepo.CheckExternalUserLogin(setContext(r.Context(), ""), authReq.ID, userAgentID, externalUser, domain.BrowserInfoFromRequest(r))
+	if err != nil && !errors.IsNotFound(err) {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	if err == nil {
+		if err = l.applyLDAPGroupMappings(r.Context(), authReq.ID, userAgentID, template, user); err != nil {
+			l.renderError(w, r, authReq, err)
+			return
+		}
+	}
+	// the next step is rendered after a redirect, so that the user agent and csrf cookies are (sent and) checked again
+	http.Redirect(w, r, l.renderer.pathPrefix+EndpointLogin+"?"+QueryAuthRequestID+"="+authReq.ID, http.StatusFound)
+}
+
+// templateProvider creates the OAuth 2.0 / OIDC based [idp.Provider] of the template
+// using the external login callback as redirect
+func (l *Login) templateProvider(ctx context.Context, template *query.IDPTemplate) (idp.Provider, error) {
+	redirectURI := l.baseURL(ctx) + EndpointExternalLoginCallback
+	switch {
+	case template.GoogleIDPTemplate != nil:
+		secret, err := crypto.DecryptString(template.GoogleIDPTemplate.ClientSecret, l.idpConfigAlg)
+		if err != nil {
+			return nil, err
+		}
+		return google.New(template.GoogleIDPTemplate.ClientID, secret, redirectURI, oidcOptions(template, template.GoogleIDPTemplate.Scopes)...)
+	case template.OIDCIDPTemplate != nil:
+		secret, err := crypto.DecryptString(template.OIDCIDPTemplate.ClientSecret, l.idpConfigAlg)
+		if err != nil {
+			return nil, err
+		}
+		return oidc.New(
+			template.Name,
+			template.OIDCIDPTemplate.Issuer,
+			template.OIDCIDPTemplate.ClientID,
+			secret,
+			redirectURI,
+			oidc.DefaultMapper,
+			oidcOptions(template, template.OIDCIDPTemplate.Scopes)...,
+		)
+	case template.AzureADIDPTemplate != nil:
+		secret, err := crypto.DecryptString(template.AzureADIDPTemplate.ClientSecret, l.idpConfigAlg)
+		if err != nil {
+			return nil, err
+		}
+		opts := []azuread.ProviderOptions{azuread.WithOAuthOptions(oauthOptions(template)...)}
+		if template.AzureADIDPTemplate.Tenant != "" {
+			opts = append(opts, azuread.WithTenant(azuread.TenantType(template.AzureADIDPTemplate.Tenant)))
+		}
+		if template.AzureADIDPTemplate.IsEmailVerified {
+			opts = append(opts, azuread.WithEmailVerified())
+		}
+		if len(template.AzureADIDPTemplate.Scopes) > 0 {
+			opts = append(opts, azuread.WithScopes(template.AzureADIDPTemplate.Scopes...))
+		}
+		return azuread.New(template.Name, template.AzureADIDPTemplate.ClientID, secret, redirectURI, opts...)
+	case template.GitHubIDPTemplate != nil:
+		secret, err := crypto.DecryptString(template.GitHubIDPTemplate.ClientSecret, l.idpConfigAlg)
+		if err != nil {
+			return nil, err
+		}
+		return github.New(template.GitHubIDPTemplate.ClientID, secret, redirectURI, template.GitHubIDPTemplate.Scopes, oauthOptions(template)...)
+	case template.GitHubEnterpriseIDPTemplate != nil:
+		secret, err := crypto.DecryptString(template.GitHubEnterpriseIDPTemplate.ClientSecret, l.idpConfigAlg)
+		if err != nil {
+			return nil, err
+		}
+		return github.NewCustomURL(
+			template.Name,
+			template.GitHubEnterpriseIDPTemplate.ClientID,
+			secret,
+			redirectURI,
+			template.GitHubEnterpriseIDPTemplate.AuthorizationEndpoint,
+			template.GitHubEnterpriseIDPTemplate.TokenEndpoint,
+			template.GitHubEnterpriseIDPTemplate.UserEndpoint,
+			template.GitHubEnterpriseIDPTemplate.Scopes,
+			oauthOptions(template)...,
+		)
+	case template.GitLabIDPTemplate != nil:
+		secret, err := crypto.DecryptString(template.GitLabIDPTemplate.ClientSecret, l.idpConfigAlg)
+		if err != nil {
+			return nil, err
+		}
+		return gitlab.New(template.GitLabIDPTemplate.ClientID, secret, redirectURI, oidcOptions(template, template.GitLabIDPTemplate.Scopes)...)
+	case template.GitLabSelfHostedIDPTemplate != nil:
+		secret, err := crypto.DecryptString(template.GitLabSelfHostedIDPTemplate.ClientSecret, l.idpConfigAlg)
+		if err != nil {
+			return nil, err
+		}
+		return gitlab.NewCustomIssuer(
+			template.Name,
+			template.GitLabSelfHostedIDPTemplate.Issuer,
+			template.GitLabSelfHostedIDPTemplate.ClientID,
+			secret,
+			redirectURI,
+			oidcOptions(template, template.GitLabSelfHostedIDPTemplate.Scopes)...,
+		)
+	default:
+		return nil, errors.ThrowPreconditionFailed(nil, "LOGIN-Rg4ca", "Errors.ExternalIDP.IDPTypeNotImplemented")
+	}
+}
+
+func oauthOptions(template *query.IDPTemplate) []oauth.ProviderOpts {
+	opts := make([]oauth.ProviderOpts, 0, 4)
+	if template.IsLinkingAllowed {
+		opts = append(opts, oauth.WithLinkingAllowed())
+	}
+	if template.IsCreationAllowed {
+		opts = append(opts, oauth.WithCreationAllowed())
+	}
+	if template.IsAutoCreation {
+		opts = append(opts, oauth.WithAutoCreation())
+	}
+	if template.IsAutoUpdate {
+		opts = append(opts, oauth.WithAutoUpdate())
+	}
+	return opts
+}
+
+func oidcOptions(template *query.IDPTemplate, scopes []string) []oidc.ProviderOpts {
+	opts := make([]oidc.ProviderOpts, 0, 5)
+	if template.IsLinkingAllowed {
+		opts = append(opts, oidc.WithLinkingAllowed())
+	}
+	if template.IsCreationAllowed {
+		opts = append(opts, oidc.WithCreationAllowed())
+	}
+	if template.IsAutoCreation {
+		opts = append(opts, oidc.WithAutoCreation())
+	}
+	if template.IsAutoUpdate {
+		opts = append(opts, oidc.WithAutoUpdate())
+	}
+	if len(scopes) > 0 {
+		opts = append(opts, oidc.WithScopes(scopes...))
+	}
+	return opts
+}
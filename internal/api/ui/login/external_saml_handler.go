@@ -0,0 +1,235 @@
+package login
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	iam_model "github.com/zitadel/zitadel/internal/iam/model"
+	"github.com/zitadel/zitadel/internal/idp/providers/saml"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	varIDPID            = "idpID"
+	relayStateSeparator = ":"
+)
+
+type samlACSData struct {
+	SAMLResponse string `schema:"SAMLResponse"`
+	RelayState   string `schema:"RelayState"`
+}
+
+// handleIDPTemplate starts the authentication on an identity provider created as template
+// (e.g. SAML, OIDC or GitHub), which are not part of the (old) idp config view
+func (l *Login) handleIDPTemplate(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, idpID string) {
+	userAgentID, ok := http_mw.UserAgentIDFromCtx(r.Context())
+	if !ok {
+		l.renderLogin(w, r, authReq, errors.ThrowPreconditionFailed(nil, "LOGIN-Gm2rq", "Errors.AuthRequest.UserAgentNotFound"))
+		return
+	}
+	err := l.authRepo.SelectExternalIDP(r.Context(), authReq.ID, idpID, userAgentID)
+	if err != nil {
+		l.renderLogin(w, r, authReq, err)
+		return
+	}
+	template, err := l.query.IDPTemplateByID(r.Context(), false, idpID, false)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	// the pre authentication actions of LDAP are run, as soon as the credentials are entered
+	if template.LDAPIDPTemplate != nil {
+		l.renderLDAPLogin(w, r, authReq, nil)
+		return
+	}
+	if err = l.runPreAuthenticationActions(authReq, r, authMethodExternal); err != nil {
+		l.renderLogin(w, r, authReq, err)
+		return
+	}
+	if template.SAMLIDPTemplate == nil {
+		l.handleOAuthTemplate(w, r, authReq, template)
+		return
+	}
+	provider, err := l.samlProvider(r.Context(), template)
+	if err != nil {
+		l.renderLogin(w, r, authReq, err)
+		return
+	}
+	relayState, err := l.samlRelayState(authReq.ID, userAgentID)
+	if err != nil {
+		l.renderLogin(w, r, authReq, err)
+		return
+	}
+	session, err := provider.BeginAuth(r.Context(), relayState)
+	if err != nil {
+		l.renderLogin(w, r, authReq, err)
+		return
+	}
+	http.Redirect(w, r, session.GetAuthURL(), http.StatusFound)
+}
+
+// handleSAMLMetadata returns the service provider metadata of ZITADEL for the requested SAML identity provider
+func (l *Login) handleSAMLMetadata(w http.ResponseWriter, r *http.Request) {
+	template, err := l.query.IDPTemplateByID(r.Context(), false, mux.Vars(r)[varIDPID], false)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if template.SAMLIDPTemplate == nil {
+		http.Error(w, "idp is not of type SAML", http.StatusNotFound)
+		return
+	}
+	provider, err := l.samlProvider(r.Context(), template)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	metadata, err := provider.Metadata()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/xml")
+	_, err = w.Write(metadata)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// handleSAMLACS handles the response (HTTP-POST binding) of the SAML identity provider.
+// As the request is sent cross-site, neither the user agent cookie nor the csrf cookie are available,
+// which is why the auth request and user agent are taken from the (encrypted) RelayState.
+func (l *Login) handleSAMLACS(w http.ResponseWriter, r *http.Request) {
+	data := new(samlACSData)
+	err := l.getParseData(r, data)
+	if err != nil {
+		l.renderError(w, r, nil, err)
+		return
+	}
+	authReqID, userAgentID, err := l.samlRelayStateValues(data.RelayState)
+	if err != nil {
+		l.renderError(w, r, nil, err)
+		return
+	}
+	authReq, err := l.authRepo.AuthRequestByID(r.Context(), authReqID, userAgentID)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	idpID := mux.Vars(r)[varIDPID]
+	if authReq.SelectedIDPConfigID != idpID {
+		l.renderError(w, r, authReq, errors.ThrowInvalidArgument(nil, "LOGIN-Hs3rt", "Errors.ExternalIDP.NotAllowed"))
+		return
+	}
+	template, err := l.query.IDPTemplateByID(r.Context(), false, idpID, false)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	if template.SAMLIDPTemplate == nil {
+		l.renderError(w, r, authReq, errors.ThrowPreconditionFailed(nil, "LOGIN-Pw2qd", "Errors.ExternalIDP.IDPTypeNotImplemented"))
+		return
+	}
+	provider, err := l.samlProvider(r.Context(), template)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	session := &saml.Session{
+		RequestID: saml.RequestID(data.RelayState),
+		Response:  data.SAMLResponse,
+		Provider:  provider,
+	}
+	user, err := session.FetchUser(r.Context())
+	if err != nil {
+		l.renderError(w, r, authReq, errors.ThrowInvalidArgument(err, "LOGIN-Ud3ka", "Errors.ExternalIDP.SAMLResponseInvalid"))
+		return
+	}
+	l.handleExternalTemplateUser(w, r, authReq, userAgentID, template, user)
+}
+
+func (l *Login) samlProvider(ctx context.Context, template *query.IDPTemplate) (*saml.Provider, error) {
+	key, err := crypto.Decrypt(template.SAMLIDPTemplate.Key, l.idpConfigAlg)
+	if err != nil {
+		return nil, err
+	}
+	opts := make([]saml.ProviderOpts, 0, 5)
+	if template.IsLinkingAllowed {
+		opts = append(opts, saml.WithLinkingAllowed())
+	}
+	if template.IsCreationAllowed {
+		opts = append(opts, saml.WithCreationAllowed())
+	}
+	if template.IsAutoCreation {
+		opts = append(opts, saml.WithAutoCreation())
+	}
+	if template.IsAutoUpdate {
+		opts = append(opts, saml.WithAutoUpdate())
+	}
+	if template.WithSignedRequest {
+		opts = append(opts, saml.WithSignedRequest())
+	}
+	return saml.New(
+		template.Name,
+		l.baseURL(ctx)+EndpointSAMLMetadata+"/"+template.ID,
+		l.baseURL(ctx)+EndpointSAMLACS+"/"+template.ID,
+		template.Metadata,
+		template.Certificate,
+		key,
+		opts...,
+	)
+}
+
+// samlRelayState encrypts the auth request and user agent id, so they can be restored on the ACS
+// (the RelayState must not exceed 80 bytes)
+func (l *Login) samlRelayState(authReqID, userAgentID string) (string, error) {
+	state, err := l.idpConfigAlg.Encrypt([]byte(authReqID + relayStateSeparator + userAgentID))
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(state), nil
+}
+
+func (l *Login) samlRelayStateValues(relayState string) (authReqID, userAgentID string, err error) {
+	state, err := base64.RawURLEncoding.DecodeString(relayState)
+	if err != nil {
+		return "", "", errors.ThrowInvalidArgument(err, "LOGIN-Ks2ra", "Errors.AuthRequest.MissingParameters")
+	}
+	decrypted, err := l.idpConfigAlg.DecryptString(state, l.idpConfigAlg.EncryptionKeyID())
+	if err != nil {
+		return "", "", errors.ThrowInvalidArgument(err, "LOGIN-Bw4fe", "Errors.AuthRequest.MissingParameters")
+	}
+	authReqID, userAgentID, ok := strings.Cut(decrypted, relayStateSeparator)
+	if !ok || authReqID == "" || userAgentID == "" {
+		return "", "", errors.ThrowInvalidArgument(nil, "LOGIN-Lq4ne", "Errors.AuthRequest.MissingParameters")
+	}
+	return authReqID, userAgentID, nil
+}
+
+// getIDPConfigOrTemplateByID returns the config of the (old) idp config view
+// or maps the idp template to it, so it can be used for the linking and registration of external users
+func (l *Login) getIDPConfigOrTemplateByID(r *http.Request, idpID string) (*iam_model.IDPConfigView, error) {
+	idpConfig, err := l.authRepo.GetIDPConfigByID(r.Context(), idpID)
+	if err == nil || !errors.IsNotFound(err) {
+		return idpConfig, err
+	}
+	template, err := l.query.IDPTemplateByID(r.Context(), false, idpID, false)
+	if err != nil {
+		return nil, err
+	}
+	return &iam_model.IDPConfigView{
+		AggregateID:  template.ResourceOwner,
+		IDPConfigID:  template.ID,
+		Name:         template.Name,
+		AutoRegister: template.IsAutoCreation,
+		State:        iam_model.IDPConfigStateActive,
+	}, nil
+}
@@ -0,0 +1,162 @@
+package login
+
+import (
+	"context"
+	"net/http"
+
+	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	tmplLDAPLogin = "ldap_login"
+)
+
+type ldapFormData struct {
+	Username string `schema:"ldapusername"`
+	Password string `schema:"ldappassword"`
+}
+
+func (l *Login) renderLDAPLogin(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, err error) {
+	var errID, errMessage string
+	if err != nil {
+		errID, errMessage = l.getErrorMessage(r, err)
+	}
+	data := l.getBaseData(r, authReq, "LDAP.Title", "LDAP.Description", errID, errMessage)
+	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), authReq), l.renderer.Templates[tmplLDAPLogin], data, nil)
+}
+
+// handleLDAPCallback authenticates the user with the entered username and password on the selected LDAP identity provider
+func (l *Login) handleLDAPCallback(w http.ResponseWriter, r *http.Request) {
+	data := new(ldapFormData)
+	authReq, err := l.getAuthRequestAndParseData(r, data)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	template, err := l.query.IDPTemplateByID(r.Context(), false, authReq.SelectedIDPConfigID, false)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	if template.LDAPIDPTemplate == nil {
+		l.renderError(w, r, authReq, errors.ThrowPreconditionFailed(nil, "LOGIN-Aeb5o", "Errors.ExternalIDP.IDPTypeNotImplemented"))
+		return
+	}
+	if err = l.runPreAuthenticationActions(authReq, r, authMethodLDAP); err != nil {
+		l.renderLDAPLogin(w, r, authReq, err)
+		return
+	}
+	provider, err := l.ldapProvider(r.Context(), template)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	session := &ldap.Session{Provider: provider, User: data.Username, Password: data.Password}
+	user, err := session.FetchUser(r.Context())
+	if err != nil {
+		l.renderLDAPLogin(w, r, authReq, errors.ThrowInvalidArgument(err, "LOGIN-ohF4v", "Errors.User.Password.Invalid"))
+		return
+	}
+	l.handleExternalTemplateUser(w, r, authReq, userAgentID, template, user)
+}
+
+// ldapProvider creates the [ldap.Provider] of the template including the mapping of all attributes
+func (l *Login) ldapProvider(ctx context.Context, template *query.IDPTemplate) (*ldap.Provider, error) {
+	password, err := crypto.DecryptString(template.LDAPIDPTemplate.Password, l.idpConfigAlg)
+	if err != nil {
+		return nil, err
+	}
+	return ldap.New(
+		template.Name,
+		template.LDAPIDPTemplate.Host,
+		template.LDAPIDPTemplate.BaseDN,
+		template.LDAPIDPTemplate.UserObjectClass,
+		template.LDAPIDPTemplate.UserUniqueAttribute,
+		template.LDAPIDPTemplate.Admin,
+		password,
+		l.baseURL(ctx)+EndpointLDAPLogin,
+		ldapOptions(template)...,
+	), nil
+}
+
+func ldapOptions(template *query.IDPTemplate) []ldap.ProviderOpts {
+	opts := make([]ldap.ProviderOpts, 0, 20)
+	if template.IsLinkingAllowed {
+		opts = append(opts, ldap.WithLinkingAllowed())
+	}
+	if template.IsCreationAllowed {
+		opts = append(opts, ldap.WithCreationAllowed())
+	}
+	if template.IsAutoCreation {
+		opts = append(opts, ldap.WithAutoCreation())
+	}
+	if template.IsAutoUpdate {
+		opts = append(opts, ldap.WithAutoUpdate())
+	}
+	if template.LDAPIDPTemplate.Port != "" {
+		opts = append(opts, ldap.WithCustomPort(template.LDAPIDPTemplate.Port))
+	}
+	if !template.LDAPIDPTemplate.TLS {
+		opts = append(opts, ldap.Insecure())
+	}
+	attributes := template.LDAPIDPTemplate.LDAPAttributes
+	for _, attribute := range []struct {
+		name string
+		opt  func(string) ldap.ProviderOpts
+	}{
+		{attributes.IDAttribute, ldap.WithCustomIDAttribute},
+		{attributes.FirstNameAttribute, ldap.WithFirstNameAttribute},
+		{attributes.LastNameAttribute, ldap.WithLastNameAttribute},
+		{attributes.DisplayNameAttribute, ldap.WithDisplayNameAttribute},
+		{attributes.NickNameAttribute, ldap.WithNickNameAttribute},
+		{attributes.PreferredUsernameAttribute, ldap.WithPreferredUsernameAttribute},
+		{attributes.EmailAttribute, ldap.WithEmailAttribute},
+		{attributes.EmailVerifiedAttribute, ldap.WithEmailVerifiedAttribute},
+		{attributes.PhoneAttribute, ldap.WithPhoneAttribute},
+		{attributes.PhoneVerifiedAttribute, ldap.WithPhoneVerifiedAttribute},
+		{attributes.PreferredLanguageAttribute, ldap.WithPreferredLanguageAttribute},
+		{attributes.AvatarURLAttribute, ldap.WithAvatarURLAttribute},
+		{attributes.ProfileAttribute, ldap.WithProfileAttribute},
+		{attributes.GroupsAttribute, ldap.WithGroupsAttribute},
+	} {
+		if attribute.name != "" {
+			opts = append(opts, attribute.opt(attribute.name))
+		}
+	}
+	return opts
+}
+
+// applyLDAPGroupMappings grants the roles of the mapped groups to the linked user of an LDAP identity provider template
+func (l *Login) applyLDAPGroupMappings(ctx context.Context, authReqID, userAgentID string, template *query.IDPTemplate, user idp.User) error {
+	groups, ok := ldapGroups(template, user)
+	if !ok {
+		return nil
+	}
+	authReq, err := l.authRepo.AuthRequestByID(ctx, authReqID, userAgentID)
+	if err != nil {
+		return err
+	}
+	if authReq.UserID == "" {
+		return nil
+	}
+	return l.command.ApplyLDAPGroupMappings(setContext(ctx, authReq.UserOrgID), authReq.UserID, authReq.UserOrgID, template.ID, template.LDAPIDPTemplate.GroupMappings, groups)
+}
+
+// ldapGroups returns the groups of the user authenticated on an LDAP identity provider template
+func ldapGroups(template *query.IDPTemplate, user idp.User) ([]string, bool) {
+	if template.LDAPIDPTemplate == nil {
+		return nil, false
+	}
+	ldapUser, ok := user.(*ldap.User)
+	if !ok {
+		return nil, false
+	}
+	return ldapUser.GetGroups(), true
+}
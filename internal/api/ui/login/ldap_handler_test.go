@@ -0,0 +1,75 @@
+package login
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
+	"github.com/zitadel/zitadel/internal/idp/providers/oidc"
+	"github.com/zitadel/zitadel/internal/query"
+	idp_repo "github.com/zitadel/zitadel/internal/repository/idp"
+)
+
+func Test_ldapGroups(t *testing.T) {
+	groups := []string{"cn=admins,dc=example,dc=com"}
+	ldapUser := ldap.NewUser("id", "first", "last", "display", "nick", "username", "email", true, "phone", false, language.English, "", "", groups)
+	ldapTemplate := &query.IDPTemplate{
+		LDAPIDPTemplate: &query.LDAPIDPTemplate{
+			GroupMappings: idp_repo.LDAPGroupMappings{
+				{GroupDN: "cn=admins,dc=example,dc=com", OrgMemberRoles: []string{"ORG_USER_MANAGER"}},
+			},
+		},
+	}
+	tests := []struct {
+		name       string
+		template   *query.IDPTemplate
+		user       idp.User
+		wantGroups []string
+		wantOK     bool
+	}{
+		{
+			name:     "no ldap template",
+			template: &query.IDPTemplate{OIDCIDPTemplate: &query.OIDCIDPTemplate{}},
+			user:     ldapUser,
+		},
+		{
+			name:     "no ldap user",
+			template: ldapTemplate,
+			user:     &oidc.User{},
+		},
+		{
+			name:       "ldap user",
+			template:   ldapTemplate,
+			user:       ldapUser,
+			wantGroups: groups,
+			wantOK:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotGroups, gotOK := ldapGroups(tt.template, tt.user)
+			assert.Equal(t, tt.wantOK, gotOK)
+			assert.Equal(t, tt.wantGroups, gotGroups)
+		})
+	}
+}
+
+func Test_ldapOptions(t *testing.T) {
+	template := &query.IDPTemplate{
+		IsLinkingAllowed: true,
+		LDAPIDPTemplate: &query.LDAPIDPTemplate{
+			Port: "636",
+			TLS:  true,
+			LDAPAttributes: idp_repo.LDAPAttributes{
+				IDAttribute:     "uid",
+				EmailAttribute:  "mail",
+				GroupsAttribute: "memberOf",
+			},
+		},
+	}
+	// linking allowed, custom port and the three mapped attributes
+	assert.Len(t, ldapOptions(template), 5)
+}
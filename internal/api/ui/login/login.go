@@ -47,6 +47,7 @@ type Config struct {
 	CSRFCookieName     string
 	Cache              middleware.CacheConfig
 	AssetCache         middleware.CacheConfig
+	RateLimit          middleware.RateLimitConfig
 }
 
 const (
@@ -93,9 +94,10 @@ func CreateLogin(config Config,
 
 	csrfInterceptor := createCSRFInterceptor(config.CSRFCookieName, csrfCookieKey, externalSecure, login.csrfErrorHandler())
 	cacheInterceptor := createCacheInterceptor(config.Cache.MaxAge, config.Cache.SharedMaxAge, assetCache)
+	rateLimitInterceptor := middleware.RateLimitHandler(config.RateLimit, middleware.RateLimitMethods(http.MethodPost))
 	security := middleware.SecurityHeaders(csp(), login.cspErrorHandler)
 
-	login.router = CreateRouter(login, statikFS, middleware.TelemetryHandler(IgnoreInstanceEndpoints...), oidcInstanceHandler, samlInstanceHandler, csrfInterceptor, cacheInterceptor, security, userAgentCookie, issuerInterceptor, accessHandler)
+	login.router = CreateRouter(login, statikFS, middleware.TelemetryHandler(IgnoreInstanceEndpoints...), oidcInstanceHandler, samlInstanceHandler, rateLimitInterceptor, csrfInterceptor, cacheInterceptor, security, userAgentCookie, issuerInterceptor, accessHandler)
 	login.renderer = CreateRenderer(HandlerPrefix, statikFS, staticStorage, config.LanguageCookieName)
 	login.parser = form.NewParser()
 	return login, nil
@@ -113,7 +115,7 @@ func createCSRFInterceptor(cookieName string, csrfCookieKey []byte, externalSecu
 	path := "/"
 	return func(handler http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			if strings.HasPrefix(r.URL.Path, EndpointResources) {
+			if strings.HasPrefix(r.URL.Path, EndpointResources) || strings.HasPrefix(r.URL.Path, EndpointSAMLACS) {
 				handler.ServeHTTP(w, r)
 				return
 			}
@@ -44,6 +44,10 @@ func (l *Login) renderSuccessAndCallback(w http.ResponseWriter, r *http.Request,
 		userData: l.getUserData(r, authReq, "LoginSuccess.Title", "", errID, errMessage),
 	}
 	if authReq != nil {
+		if _, ok := authReq.Request.(*domain.AuthRequestDevice); ok {
+			l.renderDeviceAuthAction(w, r, authReq, err)
+			return
+		}
 		//the id will be set via the html (maybe change this with the login refactoring)
 		if _, ok := authReq.Request.(*domain.AuthRequestOIDC); ok {
 			data.RedirectURI = l.oidcAuthCallbackURL(r.Context(), "")
@@ -61,6 +65,10 @@ func (l *Login) redirectToCallback(w http.ResponseWriter, r *http.Request, authR
 		callback = l.oidcAuthCallbackURL(r.Context(), authReq.ID)
 	case *domain.AuthRequestSAML:
 		callback = l.samlAuthCallbackURL(r.Context(), authReq.ID)
+	case *domain.AuthRequestDevice:
+		// the device receives the tokens by polling, so the user has to approve it instead of being redirected
+		l.renderDeviceAuthAction(w, r, authReq, nil)
+		return
 	default:
 		l.renderInternalError(w, r, authReq, caos_errs.ThrowInternal(nil, "LOGIN-rhjQF", "Errors.AuthRequest.RequestTypeNotSupported"))
 		return
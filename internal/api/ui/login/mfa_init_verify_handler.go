@@ -9,6 +9,8 @@ import (
 	svg "github.com/ajstarks/svgo"
 	"github.com/boombuler/barcode/qr"
 
+	"github.com/zitadel/logging"
+
 	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
 	"github.com/zitadel/zitadel/internal/qrcode"
 )
@@ -45,9 +47,23 @@ func (l *Login) handleMFAInitVerify(w http.ResponseWriter, r *http.Request) {
 	done := &mfaDoneData{
 		MFAType: data.MFAType,
 	}
+	if data.MFAType == domain.MFATypeOTP {
+		done.RecoveryCodes = l.generateRecoveryCodes(r, authReq)
+	}
 	l.renderMFAInitDone(w, r, authReq, done)
 }
 
+// generateRecoveryCodes returns the new recovery codes of the user,
+// the setup of the OTP must not fail if they could not be generated as they can be regenerated later
+func (l *Login) generateRecoveryCodes(r *http.Request, authReq *domain.AuthRequest) []string {
+	recoveryCodes, err := l.command.GenerateHumanRecoveryCodes(setContext(r.Context(), authReq.UserOrgID), authReq.UserID, authReq.UserOrgID)
+	if err != nil {
+		logging.WithError(err).Warn("unable to generate recovery codes")
+		return nil
+	}
+	return recoveryCodes.Codes
+}
+
 func (l *Login) handleOTPVerify(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, data *mfaInitVerifyData) *mfaVerifyData {
 	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
 	_, err := l.command.HumanCheckMFAOTPSetup(setContext(r.Context(), authReq.UserOrgID), authReq.UserID, data.Code, userAgentID, authReq.UserOrgID)
@@ -86,10 +86,30 @@ func (l *Login) handleMFACreation(w http.ResponseWriter, r *http.Request, authRe
 	case domain.MFATypeU2F:
 		l.renderRegisterU2F(w, r, authReq, nil)
 		return
+	case domain.MFATypeOTPSMS, domain.MFATypeOTPEmail:
+		l.handleOTPCodeCreation(w, r, authReq, data)
+		return
 	}
 	l.renderError(w, r, authReq, caos_errs.ThrowPreconditionFailed(nil, "APP-Or3HO", "Errors.User.MFA.NoProviders"))
 }
 
+// handleOTPCodeCreation adds the OTP sent by SMS or email to the user,
+// no verification is needed as the phone or email is already verified
+func (l *Login) handleOTPCodeCreation(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, data *mfaVerifyData) {
+	ctx := setContext(r.Context(), authReq.UserOrgID)
+	var err error
+	if data.MFAType == domain.MFATypeOTPSMS {
+		_, err = l.command.AddHumanOTPSMS(ctx, authReq.UserID, authReq.UserOrgID)
+	} else {
+		_, err = l.command.AddHumanOTPEmail(ctx, authReq.UserID, authReq.UserOrgID)
+	}
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	l.renderMFAInitDone(w, r, authReq, &mfaDoneData{MFAType: data.MFAType})
+}
+
 func (l *Login) handleOTPCreation(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, data *mfaVerifyData) {
 	otp, err := l.command.AddHumanOTP(setContext(r.Context(), authReq.UserOrgID), authReq.UserID, authReq.UserOrgID)
 	if err != nil {
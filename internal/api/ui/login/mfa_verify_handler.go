@@ -30,12 +30,15 @@ func (l *Login) handleMFAVerify(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if data.Code == "" {
-		l.renderMFAVerifySelected(w, r, authReq, step, data.SelectedProvider, nil)
+		// the provider was explicitly selected or the code requested again by the user
+		err = l.sendMFACode(r, authReq, data.SelectedProvider)
+		l.renderMFAVerifySelected(w, r, authReq, step, data.SelectedProvider, err)
 		return
 	}
-	if data.MFAType == domain.MFATypeOTP {
+	switch data.MFAType {
+	case domain.MFATypeOTP, domain.MFATypeOTPSMS, domain.MFATypeOTPEmail, domain.MFATypeRecoveryCode:
 		userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
-		err = l.authRepo.VerifyMFAOTP(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, authReq.UserOrgID, data.Code, userAgentID, domain.BrowserInfoFromRequest(r))
+		err = l.verifyMFACode(r, authReq, data.MFAType, data.Code, userAgentID)
 
 		metadata, actionErr := l.runPostInternalAuthenticationActions(authReq, r, authMethodOTP, err)
 		if err == nil && actionErr == nil && len(metadata) > 0 {
@@ -45,18 +48,47 @@ func (l *Login) handleMFAVerify(w http.ResponseWriter, r *http.Request) {
 		}
 
 		if err != nil {
-			l.renderMFAVerifySelected(w, r, authReq, step, domain.MFATypeOTP, err)
+			l.renderMFAVerifySelected(w, r, authReq, step, data.MFAType, err)
 			return
 		}
 	}
 	l.renderNextStep(w, r, authReq)
 }
 
+// sendMFACode sends a new code to the user if the selected provider is based on a code sent by SMS or email.
+// Codes cannot be requested again before the cooldown of the previous code has passed.
+func (l *Login) sendMFACode(r *http.Request, authReq *domain.AuthRequest, provider domain.MFAType) error {
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	ctx := setContext(r.Context(), authReq.UserOrgID)
+	switch provider {
+	case domain.MFATypeOTPSMS:
+		return l.authRepo.SendMFAOTPSMS(ctx, authReq.ID, authReq.UserID, authReq.UserOrgID, userAgentID, domain.BrowserInfoFromRequest(r))
+	case domain.MFATypeOTPEmail:
+		return l.authRepo.SendMFAOTPEmail(ctx, authReq.ID, authReq.UserID, authReq.UserOrgID, userAgentID, domain.BrowserInfoFromRequest(r))
+	}
+	return nil
+}
+
+func (l *Login) verifyMFACode(r *http.Request, authReq *domain.AuthRequest, mfaType domain.MFAType, code, userAgentID string) error {
+	ctx := setContext(r.Context(), authReq.UserOrgID)
+	switch mfaType {
+	case domain.MFATypeOTPSMS:
+		return l.authRepo.VerifyMFAOTPSMS(ctx, authReq.ID, authReq.UserID, authReq.UserOrgID, code, userAgentID, domain.BrowserInfoFromRequest(r))
+	case domain.MFATypeOTPEmail:
+		return l.authRepo.VerifyMFAOTPEmail(ctx, authReq.ID, authReq.UserID, authReq.UserOrgID, code, userAgentID, domain.BrowserInfoFromRequest(r))
+	case domain.MFATypeRecoveryCode:
+		return l.authRepo.VerifyMFARecoveryCode(ctx, authReq.ID, authReq.UserID, authReq.UserOrgID, code, userAgentID, domain.BrowserInfoFromRequest(r))
+	default:
+		return l.authRepo.VerifyMFAOTP(ctx, authReq.ID, authReq.UserID, authReq.UserOrgID, code, userAgentID, domain.BrowserInfoFromRequest(r))
+	}
+}
+
 func (l *Login) renderMFAVerify(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, verificationStep *domain.MFAVerificationStep, err error) {
 	if verificationStep == nil {
 		l.renderError(w, r, authReq, err)
 		return
 	}
+	// no code is sent when (re)rendering the page, the user has to request it explicitly
 	provider := verificationStep.MFAProviders[len(verificationStep.MFAProviders)-1]
 	l.renderMFAVerifySelected(w, r, authReq, verificationStep, provider, err)
 }
@@ -84,11 +116,30 @@ func (l *Login) renderMFAVerifySelected(w http.ResponseWriter, r *http.Request,
 		data.SelectedMFAProvider = domain.MFATypeOTP
 		data.Title = translator.LocalizeWithoutArgs("VerifyMFAOTP.Title")
 		data.Description = translator.LocalizeWithoutArgs("VerifyMFAOTP.Description")
+	case domain.MFATypeOTPSMS:
+		data.MFAProviders = removeSelectedProviderFromList(verificationStep.MFAProviders, domain.MFATypeOTPSMS)
+		data.SelectedMFAProvider = domain.MFATypeOTPSMS
+		data.Title = translator.LocalizeWithoutArgs("VerifyMFAOTPSMS.Title")
+		data.Description = translator.LocalizeWithoutArgs("VerifyMFAOTPSMS.Description")
+	case domain.MFATypeOTPEmail:
+		data.MFAProviders = removeSelectedProviderFromList(verificationStep.MFAProviders, domain.MFATypeOTPEmail)
+		data.SelectedMFAProvider = domain.MFATypeOTPEmail
+		data.Title = translator.LocalizeWithoutArgs("VerifyMFAOTPEmail.Title")
+		data.Description = translator.LocalizeWithoutArgs("VerifyMFAOTPEmail.Description")
+	case domain.MFATypeRecoveryCode:
+		data.MFAProviders = removeSelectedProviderFromList(verificationStep.MFAProviders, domain.MFATypeRecoveryCode)
+		data.SelectedMFAProvider = domain.MFATypeRecoveryCode
+		data.Title = translator.LocalizeWithoutArgs("VerifyMFARecoveryCode.Title")
+		data.Description = translator.LocalizeWithoutArgs("VerifyMFARecoveryCode.Description")
 	default:
 		l.renderError(w, r, authReq, err)
 		return
 	}
-	l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplMFAVerify], data, nil)
+	verifyData := &mfaVerifyCodeData{
+		userData:   data,
+		ResendCode: selectedProvider == domain.MFATypeOTPSMS || selectedProvider == domain.MFATypeOTPEmail,
+	}
+	l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplMFAVerify], verifyData, nil)
 }
 
 func removeSelectedProviderFromList(providers []domain.MFAType, selected domain.MFAType) []domain.MFAType {
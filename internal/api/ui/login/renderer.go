@@ -77,6 +77,10 @@ func CreateRenderer(pathPrefix string, staticDir http.FileSystem, staticStorage
 		tmplLinkUsersDone:                "link_users_done.html",
 		tmplExternalNotFoundOption:       "external_not_found_option.html",
 		tmplLoginSuccess:                 "login_success.html",
+		tmplDeviceAuthUserCode:           "device_usercode.html",
+		tmplDeviceAuthAction:             "device_action.html",
+		tmplLDAPLogin:                    "ldap_login.html",
+		tmplDeviceAuthDone:               "device_done.html",
 	}
 	funcs := map[string]interface{}{
 		"resourceUrl": func(file string) string {
@@ -124,6 +128,9 @@ func CreateRenderer(pathPrefix string, staticDir http.FileSystem, staticStorage
 		"externalIDPAuthURL": func(authReqID, idpConfigID string) string {
 			return path.Join(r.pathPrefix, fmt.Sprintf("%s?%s=%s&%s=%s", EndpointExternalLogin, QueryAuthRequestID, authReqID, queryIDPConfigID, idpConfigID))
 		},
+		"ldapUrl": func() string {
+			return path.Join(r.pathPrefix, EndpointLDAPLogin)
+		},
 		"externalIDPRegisterURL": func(authReqID, idpConfigID string) string {
 			return path.Join(r.pathPrefix, fmt.Sprintf("%s?%s=%s&%s=%s", EndpointExternalRegister, QueryAuthRequestID, authReqID, queryIDPConfigID, idpConfigID))
 		},
@@ -199,6 +206,12 @@ func CreateRenderer(pathPrefix string, staticDir http.FileSystem, staticStorage
 		"changeUsernameUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointChangeUsername)
 		},
+		"deviceAuthUrl": func() string {
+			return path.Join(r.pathPrefix, EndpointDeviceAuth)
+		},
+		"deviceAuthActionUrl": func() string {
+			return path.Join(r.pathPrefix, EndpointDeviceAuthAction)
+		},
 		"externalNotFoundOptionUrl": func(action string) string {
 			return path.Join(r.pathPrefix, EndpointExternalNotFoundOption+"?"+action+"=true")
 		},
@@ -625,6 +638,12 @@ type userData struct {
 	Linking             bool
 }
 
+type mfaVerifyCodeData struct {
+	userData
+	// ResendCode is set if the code was sent to the user and can be requested again
+	ResendCode bool
+}
+
 type profileData struct {
 	LoginName   string
 	UserName    string
@@ -666,6 +685,8 @@ type mfaDoneData struct {
 	baseData
 	profileData
 	MFAType domain.MFAType
+	// RecoveryCodes are only shown once after the setup of the OTP
+	RecoveryCodes []string
 }
 
 type otpData struct {
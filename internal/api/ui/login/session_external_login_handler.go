@@ -0,0 +1,118 @@
+package login
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	QueryIntent = "intent"
+
+	// sessionStatePrefix marks the state of an identity provider flow started by the session API,
+	// so the callback can be distinguished from the one of an auth request
+	sessionStatePrefix = "session_"
+)
+
+type sessionExternalIDPData struct {
+	Intent string `schema:"intent"`
+}
+
+// handleSessionExternalLogin starts the authentication on the identity provider of the intent
+// created by the session API
+func (l *Login) handleSessionExternalLogin(w http.ResponseWriter, r *http.Request) {
+	data := new(sessionExternalIDPData)
+	err := l.getParseData(r, data)
+	if err != nil {
+		l.renderError(w, r, nil, err)
+		return
+	}
+	intent, err := l.command.SessionIDPIntentByID(r.Context(), data.Intent)
+	if err != nil {
+		l.renderError(w, r, nil, err)
+		return
+	}
+	template, err := l.query.IDPTemplateByID(r.Context(), false, intent.IDPID, false)
+	if err != nil {
+		http.Redirect(w, r, intent.FailureURL, http.StatusFound)
+		return
+	}
+	provider, err := l.templateProvider(r.Context(), template)
+	if err != nil {
+		http.Redirect(w, r, intent.FailureURL, http.StatusFound)
+		return
+	}
+	session, err := provider.BeginAuth(r.Context(), sessionStatePrefix+data.Intent)
+	if err != nil {
+		http.Redirect(w, r, intent.FailureURL, http.StatusFound)
+		return
+	}
+	http.Redirect(w, r, session.GetAuthURL(), http.StatusFound)
+}
+
+// handleSessionExternalLoginCallback checks the linked user of the identity provider on the session
+// and redirects to the success or failure url of the intent
+func (l *Login) handleSessionExternalLoginCallback(w http.ResponseWriter, r *http.Request, state, code string) {
+	intentID := strings.TrimPrefix(state, sessionStatePrefix)
+	intent, err := l.command.SessionIDPIntentByID(r.Context(), intentID)
+	if err != nil {
+		l.renderError(w, r, nil, err)
+		return
+	}
+	if err = l.checkSessionExternalUser(r.Context(), intentID, intent.IDPID, code); err != nil {
+		logging.WithError(err).WithField("session", intent.SessionID).Info("identity provider check of session failed")
+		http.Redirect(w, r, intent.FailureURL, http.StatusFound)
+		return
+	}
+	http.Redirect(w, r, intent.SuccessURL, http.StatusFound)
+}
+
+func (l *Login) checkSessionExternalUser(ctx context.Context, intentID, idpID, code string) error {
+	template, err := l.query.IDPTemplateByID(ctx, false, idpID, false)
+	if err != nil {
+		return err
+	}
+	provider, err := l.templateProvider(ctx, template)
+	if err != nil {
+		return err
+	}
+	session, err := templateSession(provider, code)
+	if err != nil {
+		return err
+	}
+	user, err := session.FetchUser(ctx)
+	if err != nil {
+		return err
+	}
+	link, err := l.idpUserLink(ctx, idpID, user.GetID())
+	if err != nil {
+		return err
+	}
+	_, err = l.command.CheckSessionIDP(setContext(ctx, link.ResourceOwner), intentID, link.UserID, link.ResourceOwner, link.ProvidedUserID)
+	return err
+}
+
+// idpUserLink returns the link of the external user to a ZITADEL user
+func (l *Login) idpUserLink(ctx context.Context, idpID, externalUserID string) (*query.IDPUserLink, error) {
+	idpQuery, err := query.NewIDPUserLinkIDPIDSearchQuery(idpID)
+	if err != nil {
+		return nil, err
+	}
+	externalIDQuery, err := query.NewIDPUserLinksExternalUserIDSearchQuery(externalUserID)
+	if err != nil {
+		return nil, err
+	}
+	links, err := l.query.IDPUserLinks(ctx, &query.IDPUserLinksSearchQuery{Queries: []query.SearchQuery{idpQuery, externalIDQuery}}, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(links.Links) != 1 {
+		return nil, errors.ThrowNotFound(nil, "LOGIN-Bgr3s", "Errors.User.ExternalIDP.NotFound")
+	}
+	return links.Links[0], nil
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/zitadel/zitadel/internal/eventstore"
 	v1 "github.com/zitadel/zitadel/internal/eventstore/v1"
 	es_models "github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/geoip"
 	iam_model "github.com/zitadel/zitadel/internal/iam/model"
 	iam_view_model "github.com/zitadel/zitadel/internal/iam/repository/view/model"
 	"github.com/zitadel/zitadel/internal/id"
@@ -52,7 +53,10 @@ type AuthRequestRepo struct {
 	UserGrantProvider         userGrantProvider
 	ProjectProvider           projectProvider
 	ApplicationProvider       applicationProvider
+	LoginRiskProvider         loginRiskProvider
+	UserDeletionProvider      userDeletionProvider
 
+	GeoIP       *geoip.Database
 	IdGenerator id.Generator
 }
 
@@ -96,6 +100,14 @@ type userCommandProvider interface {
 	BulkAddedUserIDPLinks(ctx context.Context, userID, resourceOwner string, externalIDPs []*domain.UserIDPLink) error
 }
 
+type loginRiskProvider interface {
+	HumanEvaluateLoginRisk(ctx context.Context, userID, resourceOwner, country string, rules domain.LoginPolicyRiskRules, authRequest *domain.AuthRequest) (*domain.LoginRiskDecision, error)
+}
+
+type userDeletionProvider interface {
+	CancelUserDeletion(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error)
+}
+
 type orgViewProvider interface {
 	OrgByID(context.Context, bool, string) (*query.Org, error)
 	OrgByPrimaryDomain(context.Context, string) (*query.Org, error)
@@ -345,6 +357,7 @@ func lockoutPolicyToDomain(policy *query.LockoutPolicy) *domain.LockoutPolicy {
 		},
 		Default:             policy.IsDefault,
 		MaxPasswordAttempts: policy.MaxPasswordAttempts,
+		MaxOTPAttempts:      policy.MaxOTPAttempts,
 		ShowLockOutFailures: policy.ShowFailures,
 	}
 }
@@ -359,6 +372,56 @@ func (repo *AuthRequestRepo) VerifyMFAOTP(ctx context.Context, authRequestID, us
 	return repo.Command.HumanCheckMFAOTP(ctx, userID, code, resourceOwner, request.WithCurrentInfo(info))
 }
 
+func (repo *AuthRequestRepo) SendMFAOTPSMS(ctx context.Context, authRequestID, userID, resourceOwner, userAgentID string, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanSendOTPSMS(ctx, userID, resourceOwner, request.WithCurrentInfo(info))
+}
+
+func (repo *AuthRequestRepo) VerifyMFAOTPSMS(ctx context.Context, authRequestID, userID, resourceOwner, code, userAgentID string, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanCheckOTPSMS(ctx, userID, code, resourceOwner, request.WithCurrentInfo(info))
+}
+
+func (repo *AuthRequestRepo) SendMFAOTPEmail(ctx context.Context, authRequestID, userID, resourceOwner, userAgentID string, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanSendOTPEmail(ctx, userID, resourceOwner, request.WithCurrentInfo(info))
+}
+
+func (repo *AuthRequestRepo) VerifyMFAOTPEmail(ctx context.Context, authRequestID, userID, resourceOwner, code, userAgentID string, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanCheckOTPEmail(ctx, userID, code, resourceOwner, request.WithCurrentInfo(info))
+}
+
+func (repo *AuthRequestRepo) VerifyMFARecoveryCode(ctx context.Context, authRequestID, userID, resourceOwner, code, userAgentID string, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanCheckRecoveryCode(ctx, userID, code, resourceOwner, request.WithCurrentInfo(info))
+}
+
 func (repo *AuthRequestRepo) BeginMFAU2FLogin(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID string) (login *domain.WebAuthNLogin, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -503,6 +566,84 @@ func (repo *AuthRequestRepo) AutoRegisterExternalUser(ctx context.Context, regis
 	return repo.AuthRequests.UpdateAuthRequest(ctx, request)
 }
 
+// LinkSessionToAuthRequest sets the checked user of the session on the auth request
+// and marks the checked factors of the session as checked for the auth request.
+// The agent of the auth request is not checked, as the session is used by a custom login UI instead of the user agent.
+func (repo *AuthRequestRepo) LinkSessionToAuthRequest(ctx context.Context, authReqID, sessionID, sessionToken string) (_ *domain.AuthRequest, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.AuthRequests.GetAuthRequestByID(ctx, authReqID)
+	if err != nil {
+		return nil, err
+	}
+	if err = repo.fillPolicies(ctx, request); err != nil {
+		return nil, err
+	}
+	checks, err := repo.Command.SessionChecksForAuthRequest(ctx, sessionID, sessionToken, request)
+	if err != nil {
+		return nil, err
+	}
+	user, err := activeUserByID(ctx, repo.UserViewProvider, repo.UserEventProvider, repo.OrgViewProvider, repo.LockoutPolicyViewProvider, checks.UserID, false)
+	if err != nil {
+		return nil, err
+	}
+	username := user.UserName
+	if request.RequestedOrgID == "" {
+		username = user.PreferredLoginName
+	}
+	request.SetUserInfo(user.ID, username, user.PreferredLoginName, user.DisplayName, user.AvatarKey, user.ResourceOwner)
+	if user.PreferredLoginName != "" {
+		request.LoginName = user.PreferredLoginName
+	}
+	userSession, err := userSessionByIDs(ctx, repo.UserSessionViewProvider, repo.UserEventProvider, request.AgentID, user)
+	if err != nil {
+		return nil, err
+	}
+	// the steps are computed with the checks of the session, which will only be recorded on the user
+	// if they are sufficient, so nothing is changed for incomplete sessions
+	applySessionChecks(userSession, checks)
+	steps, err := repo.userNextSteps(ctx, request, user, userSession)
+	if err != nil {
+		return nil, err
+	}
+	for _, step := range steps {
+		switch step.(type) {
+		case *domain.RedirectToCallbackStep, *domain.LoginSucceededStep:
+		default:
+			return nil, errors.ThrowPreconditionFailed(nil, "EVENT-Sg3gq", "Errors.Session.AuthRequest.NotCompleted")
+		}
+	}
+	if err = repo.Command.LinkSessionToAuthRequest(ctx, checks, request); err != nil {
+		return nil, err
+	}
+	if err = repo.AuthRequests.UpdateAuthRequest(ctx, request); err != nil {
+		return nil, err
+	}
+	request.PossibleSteps = steps
+	return request, nil
+}
+
+// applySessionChecks sets the checks of the session on the user session,
+// the same way the check succeeded events of the user would
+func applySessionChecks(userSession *user_model.UserSessionView, checks *command.SessionAuthRequestChecks) {
+	if !checks.PasswordCheckedAt.IsZero() {
+		userSession.PasswordVerification = checks.PasswordCheckedAt
+	}
+	if !checks.OTPCheckedAt.IsZero() {
+		userSession.SecondFactorVerification = checks.OTPCheckedAt
+		userSession.SecondFactorVerificationType = domain.MFATypeOTP
+	}
+	if !checks.WebAuthNCheckedAt.IsZero() {
+		userSession.PasswordlessVerification = checks.WebAuthNCheckedAt
+		userSession.MultiFactorVerification = checks.WebAuthNCheckedAt
+		userSession.MultiFactorVerificationType = domain.MFATypeU2FUserVerification
+	}
+	if !checks.IDPCheckedAt.IsZero() {
+		userSession.ExternalLoginVerification = checks.IDPCheckedAt
+		userSession.SelectedIDPConfigID = checks.IDPID
+	}
+}
+
 func (repo *AuthRequestRepo) getAuthRequestNextSteps(ctx context.Context, id, userAgentID string, checkLoggedIn bool) (*domain.AuthRequest, error) {
 	request, err := repo.getAuthRequest(ctx, id, userAgentID)
 	if err != nil {
@@ -658,8 +799,8 @@ func (repo *AuthRequestRepo) checkLoginName(ctx context.Context, request *domain
 	if err != nil && !errors.IsNotFound(err) {
 		return err
 	}
-	// if there's an active (human) user, let's use it
-	if user != nil && !user.HumanView.IsZero() && domain.UserState(user.State).NotDisabled() {
+	// if there's an active (human) user or one who requested its deletion (which will be canceled by the login), let's use it
+	if user != nil && !user.HumanView.IsZero() && (domain.UserState(user.State).NotDisabled() || user.State == int32(domain.UserStateInactive) && isUserDeletionPending(ctx, repo.UserEventProvider, user.ID)) {
 		request.SetUserInfo(user.ID, loginName, user.PreferredLoginName, "", "", user.ResourceOwner)
 		return nil
 	}
@@ -836,6 +977,11 @@ func queryLoginPolicyToDomain(policy *query.LoginPolicy) *domain.LoginPolicy {
 		MultiFactorCheckLifetime:   policy.MultiFactorCheckLifetime,
 		DisableLoginWithEmail:      policy.DisableLoginWithEmail,
 		DisableLoginWithPhone:      policy.DisableLoginWithPhone,
+		RiskRules: domain.LoginPolicyRiskRules{
+			TrustedIPRanges:    policy.MFATrustedIPRanges,
+			MFAOnNewUserAgent:  policy.MFAOnNewUserAgent,
+			MFAOnCountryChange: policy.MFAOnCountryChange,
+		},
 	}
 }
 
@@ -916,7 +1062,12 @@ func (repo *AuthRequestRepo) nextSteps(ctx context.Context, request *domain.Auth
 	if err != nil {
 		return nil, err
 	}
+	return repo.userNextSteps(ctx, request, user, userSession)
+}
 
+// userNextSteps returns the steps of the (already selected) user with the checks of its user session
+func (repo *AuthRequestRepo) userNextSteps(ctx context.Context, request *domain.AuthRequest, user *user_model.UserView, userSession *user_model.UserSessionView) ([]domain.NextStep, error) {
+	steps := make([]domain.NextStep, 0)
 	isInternalLogin := request.SelectedIDPConfigID == "" && userSession.SelectedIDPConfigID == ""
 	idps, err := checkExternalIDPsOfUser(ctx, repo.IDPUserLinksProvider, user.ID)
 	if err != nil {
@@ -939,6 +1090,9 @@ func (repo *AuthRequestRepo) nextSteps(ctx context.Context, request *domain.Auth
 		}
 	}
 
+	if err = repo.checkLoginRisk(ctx, request, user); err != nil {
+		return nil, err
+	}
 	step, ok, err := repo.mfaChecked(userSession, request, user)
 	if err != nil {
 		return nil, err
@@ -946,6 +1100,11 @@ func (repo *AuthRequestRepo) nextSteps(ctx context.Context, request *domain.Auth
 	if !ok {
 		return append(steps, step), nil
 	}
+	if user.State == user_model.UserStateInactive {
+		if err = repo.cancelUserDeletion(ctx, user); err != nil {
+			return nil, err
+		}
+	}
 
 	if user.PasswordChangeRequired {
 		steps = append(steps, &domain.ChangePasswordStep{})
@@ -1058,6 +1217,25 @@ func (repo *AuthRequestRepo) firstFactorChecked(request *domain.AuthRequest, use
 	return &domain.PasswordStep{}
 }
 
+// checkLoginRisk forces a second factor for the request if the risk rules of the login policy consider it risky
+func (repo *AuthRequestRepo) checkLoginRisk(ctx context.Context, request *domain.AuthRequest, user *user_model.UserView) error {
+	if request.LoginPolicy.ForceMFA || request.LoginPolicy.RiskRules.IsEmpty() {
+		return nil
+	}
+	var country string
+	if request.BrowserInfo != nil {
+		country = repo.GeoIP.Country(request.BrowserInfo.RemoteIP)
+	}
+	decision, err := repo.LoginRiskProvider.HumanEvaluateLoginRisk(ctx, user.ID, user.ResourceOwner, country, request.LoginPolicy.RiskRules, request)
+	if err != nil {
+		return err
+	}
+	if decision.MFARequired() {
+		request.LoginPolicy.ForceMFA = true
+	}
+	return nil
+}
+
 func (repo *AuthRequestRepo) mfaChecked(userSession *user_model.UserSessionView, request *domain.AuthRequest, user *user_model.UserView) (domain.NextStep, bool, error) {
 	mfaLevel := request.MFALevel()
 	allowedProviders, required := user.MFATypesAllowed(mfaLevel, request.LoginPolicy)
@@ -1132,11 +1310,12 @@ func privacyPolicyToDomain(p *query.PrivacyPolicy) *domain.PrivacyPolicy {
 			CreationDate:  p.CreationDate,
 			ChangeDate:    p.ChangeDate,
 		},
-		State:       p.State,
-		Default:     p.IsDefault,
-		TOSLink:     p.TOSLink,
-		PrivacyLink: p.PrivacyLink,
-		HelpLink:    p.HelpLink,
+		State:               p.State,
+		Default:             p.IsDefault,
+		TOSLink:             p.TOSLink,
+		PrivacyLink:         p.PrivacyLink,
+		HelpLink:            p.HelpLink,
+		DeletionGracePeriod: p.DeletionGracePeriod,
 	}
 }
 
@@ -1298,6 +1477,12 @@ func userSessionByIDs(ctx context.Context, provider userSessionViewProvider, eve
 			user_repo.UserIDPLoginCheckSucceededType,
 			user_repo.HumanMFAOTPCheckSucceededType,
 			user_repo.HumanMFAOTPCheckFailedType,
+			user_repo.HumanOTPSMSCheckSucceededType,
+			user_repo.HumanOTPSMSCheckFailedType,
+			user_repo.HumanOTPEmailCheckSucceededType,
+			user_repo.HumanOTPEmailCheckFailedType,
+			user_repo.HumanRecoveryCodeCheckSucceededType,
+			user_repo.HumanRecoveryCodeCheckFailedType,
 			user_repo.HumanSignedOutType,
 			user_repo.HumanPasswordlessTokenCheckSucceededType,
 			user_repo.HumanPasswordlessTokenCheckFailedType,
@@ -1339,7 +1524,8 @@ func activeUserByID(ctx context.Context, userViewProvider userViewProvider, user
 	if user.State == user_model.UserStateLocked || user.State == user_model.UserStateSuspend {
 		return nil, errors.ThrowPreconditionFailed(nil, "EVENT-FJ262", "Errors.User.Locked")
 	}
-	if !(user.State == user_model.UserStateActive || user.State == user_model.UserStateInitial) {
+	if !(user.State == user_model.UserStateActive || user.State == user_model.UserStateInitial ||
+		user.State == user_model.UserStateInactive && isUserDeletionPending(ctx, userEventProvider, user.ID)) {
 		return nil, errors.ThrowPreconditionFailed(nil, "EVENT-FJ262", "Errors.User.NotActive")
 	}
 	org, err := queries.OrgByID(ctx, false, user.ResourceOwner)
@@ -1382,6 +1568,40 @@ func userByID(ctx context.Context, viewProvider userViewProvider, eventProvider
 	return user_view_model.UserToModel(&userCopy), nil
 }
 
+// isUserDeletionPending checks if the (inactive) user requested its own deletion,
+// such users are still allowed to login, which cancels the deletion
+func isUserDeletionPending(ctx context.Context, userEventProvider userEventProvider, userID string) bool {
+	events, err := userEventProvider.UserEventsByID(ctx, userID, 0)
+	if err != nil {
+		logging.WithFields("traceID", tracing.TraceIDFromCtx(ctx)).WithError(err).Debug("error retrieving user events")
+		return false
+	}
+	pending := false
+	for _, event := range events {
+		switch event.Type {
+		case es_models.EventType(user_repo.UserDeletionRequestedType):
+			pending = true
+		case es_models.EventType(user_repo.UserDeletionCanceledType),
+			es_models.EventType(user_repo.UserReactivatedType):
+			pending = false
+		}
+	}
+	return pending
+}
+
+func (repo *AuthRequestRepo) cancelUserDeletion(ctx context.Context, user *user_model.UserView) error {
+	data := authz.CtxData{
+		UserID: "LOGIN",
+		OrgID:  user.ResourceOwner,
+	}
+	_, err := repo.UserDeletionProvider.CancelUserDeletion(authz.SetCtxData(ctx, data), user.ID, user.ResourceOwner)
+	if err != nil {
+		return err
+	}
+	user.State = user_model.UserStateActive
+	return nil
+}
+
 func linkExternalIDPs(ctx context.Context, userCommandProvider userCommandProvider, request *domain.AuthRequest) error {
 	externalIDPs := make([]*domain.UserIDPLink, len(request.LinkingUsers))
 	for i, linkingUser := range request.LinkingUsers {
@@ -1419,7 +1639,7 @@ func linkingIDPConfigExistingInAllowedIDPs(linkingUsers []*domain.ExternalUser,
 func userGrantRequired(ctx context.Context, request *domain.AuthRequest, user *user_model.UserView, userGrantProvider userGrantProvider) (_ bool, err error) {
 	var project *query.Project
 	switch request.Request.Type() {
-	case domain.AuthRequestTypeOIDC, domain.AuthRequestTypeSAML:
+	case domain.AuthRequestTypeOIDC, domain.AuthRequestTypeSAML, domain.AuthRequestTypeDevice:
 		project, err = userGrantProvider.ProjectByClientID(ctx, request.ApplicationID, false)
 		if err != nil {
 			return false, err
@@ -1440,7 +1660,7 @@ func userGrantRequired(ctx context.Context, request *domain.AuthRequest, user *u
 func projectRequired(ctx context.Context, request *domain.AuthRequest, projectProvider projectProvider) (_ bool, err error) {
 	var project *query.Project
 	switch request.Request.Type() {
-	case domain.AuthRequestTypeOIDC, domain.AuthRequestTypeSAML:
+	case domain.AuthRequestTypeOIDC, domain.AuthRequestTypeSAML, domain.AuthRequestTypeDevice:
 		project, err = projectProvider.ProjectByClientID(ctx, request.ApplicationID, false)
 		if err != nil {
 			return false, err
@@ -115,6 +115,14 @@ func (m *mockEventErrUser) BulkAddExternalIDPs(ctx context.Context, userID strin
 	return errors.ThrowInternal(nil, "id", "internal error")
 }
 
+type mockEventUserEvents struct {
+	Events []*es_models.Event
+}
+
+func (m *mockEventUserEvents) UserEventsByID(ctx context.Context, id string, sequence uint64) ([]*es_models.Event, error) {
+	return m.Events, nil
+}
+
 type mockViewUser struct {
 	InitRequired             bool
 	PasswordInitRequired     bool
@@ -242,6 +250,14 @@ func (m *mockIDPUserLinks) IDPUserLinks(ctx context.Context, queries *query.IDPU
 	return &query.IDPUserLinks{Links: m.idps}, nil
 }
 
+type mockLoginRisk struct {
+	reasons []domain.LoginRiskReason
+}
+
+func (m *mockLoginRisk) HumanEvaluateLoginRisk(_ context.Context, _, _, _ string, _ domain.LoginPolicyRiskRules, _ *domain.AuthRequest) (*domain.LoginRiskDecision, error) {
+	return &domain.LoginRiskDecision{Reasons: m.reasons}, nil
+}
+
 func TestAuthRequestRepo_nextSteps(t *testing.T) {
 	type fields struct {
 		AuthRequests            *cache.AuthRequestCache
@@ -256,6 +272,7 @@ func TestAuthRequestRepo_nextSteps(t *testing.T) {
 		loginPolicyProvider     loginPolicyViewProvider
 		lockoutPolicyProvider   lockoutPolicyViewProvider
 		idpUserLinksProvider    idpUserLinksProvider
+		loginRiskProvider       loginRiskProvider
 	}
 	type args struct {
 		request       *domain.AuthRequest
@@ -919,6 +936,45 @@ func TestAuthRequestRepo_nextSteps(t *testing.T) {
 			}},
 			nil,
 		},
+		{
+			"risky login, mfa not set up, required mfa prompt step",
+			fields{
+				userSessionViewProvider: &mockViewUserSession{
+					PasswordVerification: testNow.Add(-5 * time.Minute),
+				},
+				userViewProvider: &mockViewUser{
+					PasswordSet:     true,
+					IsEmailVerified: true,
+				},
+				userEventProvider: &mockEventUser{},
+				orgViewProvider:   &mockViewOrg{State: domain.OrgStateActive},
+				lockoutPolicyProvider: &mockLockoutPolicy{
+					policy: &query.LockoutPolicy{
+						ShowFailures: true,
+					},
+				},
+				idpUserLinksProvider: &mockIDPUserLinks{},
+				loginRiskProvider: &mockLoginRisk{
+					reasons: []domain.LoginRiskReason{domain.LoginRiskReasonNewUserAgent},
+				},
+			},
+			args{
+				&domain.AuthRequest{
+					UserID: "UserID",
+					LoginPolicy: &domain.LoginPolicy{
+						SecondFactors:         []domain.SecondFactorType{domain.SecondFactorTypeOTP},
+						PasswordCheckLifetime: 10 * 24 * time.Hour,
+						RiskRules: domain.LoginPolicyRiskRules{
+							MFAOnNewUserAgent: true,
+						},
+					},
+				}, false},
+			[]domain.NextStep{&domain.MFAPromptStep{
+				Required:     true,
+				MFAProviders: []domain.MFAType{domain.MFATypeOTP},
+			}},
+			nil,
+		},
 		{
 			"external user, mfa not verified, mfa check step",
 			fields{
@@ -1407,6 +1463,7 @@ func TestAuthRequestRepo_nextSteps(t *testing.T) {
 				LoginPolicyViewProvider:   tt.fields.loginPolicyProvider,
 				LockoutPolicyViewProvider: tt.fields.lockoutPolicyProvider,
 				IDPUserLinksProvider:      tt.fields.idpUserLinksProvider,
+				LoginRiskProvider:         tt.fields.loginRiskProvider,
 			}
 			got, err := repo.nextSteps(context.Background(), tt.args.request, tt.args.checkLoggedIn)
 			if (err != nil && tt.wantErr == nil) || (tt.wantErr != nil && !tt.wantErr(err)) {
@@ -1950,3 +2007,66 @@ func Test_userByID(t *testing.T) {
 		})
 	}
 }
+
+func Test_isUserDeletionPending(t *testing.T) {
+	tests := []struct {
+		name          string
+		eventProvider userEventProvider
+		want          bool
+	}{
+		{
+			"error events, false",
+			&mockEventErrUser{},
+			false,
+		},
+		{
+			"no deletion requested, false",
+			&mockEventUserEvents{
+				Events: []*es_models.Event{
+					{Type: es_models.EventType(user_repo.UserDeactivatedType)},
+				},
+			},
+			false,
+		},
+		{
+			"deletion requested, true",
+			&mockEventUserEvents{
+				Events: []*es_models.Event{
+					{Type: es_models.EventType(user_repo.UserDeactivatedType)},
+					{Type: es_models.EventType(user_repo.UserDeletionRequestedType)},
+				},
+			},
+			true,
+		},
+		{
+			"deletion canceled, false",
+			&mockEventUserEvents{
+				Events: []*es_models.Event{
+					{Type: es_models.EventType(user_repo.UserDeactivatedType)},
+					{Type: es_models.EventType(user_repo.UserDeletionRequestedType)},
+					{Type: es_models.EventType(user_repo.UserDeletionCanceledType)},
+					{Type: es_models.EventType(user_repo.UserReactivatedType)},
+					{Type: es_models.EventType(user_repo.UserDeactivatedType)},
+				},
+			},
+			false,
+		},
+		{
+			"user reactivated, false",
+			&mockEventUserEvents{
+				Events: []*es_models.Event{
+					{Type: es_models.EventType(user_repo.UserDeactivatedType)},
+					{Type: es_models.EventType(user_repo.UserDeletionRequestedType)},
+					{Type: es_models.EventType(user_repo.UserReactivatedType)},
+				},
+			},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := isUserDeletionPending(context.Background(), tt.eventProvider, "userID")
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/config/systemdefaults"
 	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	v1 "github.com/zitadel/zitadel/internal/eventstore/v1"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
@@ -159,6 +160,14 @@ func (i *IDPProvider) fillData(provider *iam_view_model.IDPProviderView) (err er
 	} else {
 		config, err = i.getOrgIDPConfig(provider.InstanceID, provider.AggregateID, provider.IDPConfigID)
 	}
+	if caos_errs.IsNotFound(err) {
+		template, err := i.getIDPTemplate(provider.InstanceID, provider.AggregateID, provider.IDPConfigID)
+		if err != nil {
+			return err
+		}
+		i.fillTemplateData(provider, template)
+		return nil
+	}
 	if err != nil {
 		return err
 	}
@@ -166,6 +175,18 @@ func (i *IDPProvider) fillData(provider *iam_view_model.IDPProviderView) (err er
 	return nil
 }
 
+func (i *IDPProvider) fillTemplateData(provider *iam_view_model.IDPProviderView, template *query2.IDPTemplate) {
+	provider.Name = template.Name
+	provider.StylingType = int32(domain.IDPConfigStylingTypeUnspecified)
+	if template.SAMLIDPTemplate != nil {
+		provider.IDPConfigType = int32(domain.IDPConfigTypeSAML)
+	}
+	provider.IDPState = int32(iam_model.IDPConfigStateActive)
+	if template.State == domain.IDPStateRemoved {
+		provider.IDPState = int32(iam_model.IDPConfigStateRemoved)
+	}
+}
+
 func (i *IDPProvider) fillConfigData(provider *iam_view_model.IDPProviderView, config *query2.IDP) {
 	provider.Name = config.Name
 	provider.StylingType = int32(config.StylingType)
@@ -202,3 +223,7 @@ func (i *IDPProvider) getOrgIDPConfig(instanceID, aggregateID, idpConfigID strin
 func (i *IDPProvider) getDefaultIDPConfig(instanceID, idpConfigID string) (*query2.IDP, error) {
 	return i.queries.IDPByIDAndResourceOwner(withInstanceID(context.Background(), instanceID), false, idpConfigID, instanceID, false)
 }
+
+func (i *IDPProvider) getIDPTemplate(instanceID, aggregateID, idpConfigID string) (*query2.IDPTemplate, error) {
+	return i.queries.IDPTemplateByIDAndResourceOwner(withInstanceID(context.Background(), instanceID), false, idpConfigID, aggregateID, false)
+}
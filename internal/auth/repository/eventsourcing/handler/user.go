@@ -138,6 +138,12 @@ func (u *User) ProcessUser(event *es_models.Event) (err error) {
 		user_repo.HumanMFAOTPAddedType,
 		user_repo.HumanMFAOTPVerifiedType,
 		user_repo.HumanMFAOTPRemovedType,
+		user_repo.HumanOTPSMSAddedType,
+		user_repo.HumanOTPSMSRemovedType,
+		user_repo.HumanOTPEmailAddedType,
+		user_repo.HumanOTPEmailRemovedType,
+		user_repo.HumanRecoveryCodesAddedType,
+		user_repo.HumanRecoveryCodeCheckSucceededType,
 		user_repo.HumanU2FTokenAddedType,
 		user_repo.HumanU2FTokenVerifiedType,
 		user_repo.HumanU2FTokenRemovedType,
@@ -165,6 +165,14 @@ func (i *ExternalIDP) fillData(externalIDP *usr_view_model.ExternalIDPView) erro
 	if caos_errs.IsNotFound(err) {
 		config, err = i.getDefaultIDPConfig(externalIDP.InstanceID, externalIDP.IDPConfigID)
 	}
+	if caos_errs.IsNotFound(err) {
+		template, err := i.getIDPTemplate(externalIDP.InstanceID, externalIDP.ResourceOwner, externalIDP.IDPConfigID)
+		if err != nil {
+			return err
+		}
+		externalIDP.IDPName = template.Name
+		return nil
+	}
 	if err != nil {
 		return err
 	}
@@ -192,3 +200,7 @@ func (i *ExternalIDP) getOrgIDPConfig(instanceID, aggregateID, idpConfigID strin
 func (i *ExternalIDP) getDefaultIDPConfig(instanceID, idpConfigID string) (*query2.IDP, error) {
 	return i.queries.IDPByIDAndResourceOwner(withInstanceID(context.Background(), instanceID), false, idpConfigID, instanceID, false)
 }
+
+func (i *ExternalIDP) getIDPTemplate(instanceID, resourceOwner, idpConfigID string) (*query2.IDPTemplate, error) {
+	return i.queries.IDPTemplateByIDAndResourceOwner(withInstanceID(context.Background(), instanceID), false, idpConfigID, resourceOwner, false)
+}
@@ -14,6 +14,7 @@ import (
 	eventstore2 "github.com/zitadel/zitadel/internal/eventstore"
 	v1 "github.com/zitadel/zitadel/internal/eventstore/v1"
 	es_spol "github.com/zitadel/zitadel/internal/eventstore/v1/spooler"
+	"github.com/zitadel/zitadel/internal/geoip"
 	"github.com/zitadel/zitadel/internal/id"
 	"github.com/zitadel/zitadel/internal/query"
 )
@@ -21,6 +22,7 @@ import (
 type Config struct {
 	SearchLimit uint64
 	Spooler     spooler.SpoolerConfig
+	GeoIP       geoip.Config
 }
 
 type EsRepository struct {
@@ -35,7 +37,7 @@ type EsRepository struct {
 }
 
 func Start(ctx context.Context, conf Config, systemDefaults sd.SystemDefaults, command *command.Commands, queries *query.Queries, dbClient *sql.DB, esV2 *eventstore2.Eventstore, oidcEncryption crypto.EncryptionAlgorithm, userEncryption crypto.EncryptionAlgorithm) (*EsRepository, error) {
-	es, err := v1.Start(dbClient)
+	es, err := v1.Start(dbClient, esV2)
 	if err != nil {
 		return nil, err
 	}
@@ -48,6 +50,11 @@ func Start(ctx context.Context, conf Config, systemDefaults sd.SystemDefaults, c
 
 	authReq := cache.Start(dbClient)
 
+	geoIP, err := geoip.Load(conf.GeoIP)
+	if err != nil {
+		return nil, err
+	}
+
 	spool := spooler.StartSpooler(ctx, conf.Spooler, es, esV2, view, dbClient, systemDefaults, queries)
 
 	userRepo := eventstore.UserRepo{
@@ -87,6 +94,9 @@ func Start(ctx context.Context, conf Config, systemDefaults sd.SystemDefaults, c
 			UserGrantProvider:         queryView,
 			ProjectProvider:           queryView,
 			ApplicationProvider:       queries,
+			LoginRiskProvider:         command,
+			UserDeletionProvider:      command,
+			GeoIP:                     geoIP,
 			IdGenerator:               idGenerator,
 		},
 		eventstore.TokenRepo{
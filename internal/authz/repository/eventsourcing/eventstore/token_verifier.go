@@ -169,7 +169,7 @@ func (repo *TokenVerifierRepo) getTokenIDAndSubject(ctx context.Context, accessT
 func (repo *TokenVerifierRepo) jwtTokenVerifier(ctx context.Context) op.AccessTokenVerifier {
 	keySet := &openIDKeySet{repo.Query}
 	issuer := http_util.BuildOrigin(authz.GetInstance(ctx).RequestedHost(), repo.ExternalSecure)
-	return op.NewAccessTokenVerifier(issuer, keySet)
+	return authz.AccessTokenVerifierWithSigningAlgorithms(op.NewAccessTokenVerifier(issuer, keySet))
 }
 
 func (repo *TokenVerifierRepo) decryptAccessToken(token string) (string, error) {
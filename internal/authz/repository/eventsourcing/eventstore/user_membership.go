@@ -22,6 +22,16 @@ func (repo *UserMembershipRepo) SearchMyMemberships(ctx context.Context) (_ []*a
 	return userMembershipsToMemberships(memberships), nil
 }
 
+func (repo *UserMembershipRepo) CustomRoleMappings(ctx context.Context) (_ []authz.RoleMapping, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	customRoles, err := repo.Queries.SearchCustomRoles(ctx, &query.CustomRoleSearchQueries{})
+	if err != nil {
+		return nil, err
+	}
+	return customRoles.CustomRoleMappings(), nil
+}
+
 func (repo *UserMembershipRepo) searchUserMemberships(ctx context.Context) (_ []*query.Membership, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
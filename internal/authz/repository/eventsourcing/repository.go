@@ -19,7 +19,7 @@ type EsRepository struct {
 }
 
 func Start(queries *query.Queries, dbClient *sql.DB, keyEncryptionAlgorithm crypto.EncryptionAlgorithm, externalSecure bool) (repository.Repository, error) {
-	es, err := v1.Start(dbClient)
+	es, err := v1.Start(dbClient, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -15,13 +15,16 @@ import (
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/id"
 	"github.com/zitadel/zitadel/internal/repository/action"
+	"github.com/zitadel/zitadel/internal/repository/deviceauth"
 	instance_repo "github.com/zitadel/zitadel/internal/repository/instance"
 	"github.com/zitadel/zitadel/internal/repository/keypair"
 	"github.com/zitadel/zitadel/internal/repository/org"
 	proj_repo "github.com/zitadel/zitadel/internal/repository/project"
 	"github.com/zitadel/zitadel/internal/repository/quota"
+	"github.com/zitadel/zitadel/internal/repository/session"
 	usr_repo "github.com/zitadel/zitadel/internal/repository/user"
 	usr_grant_repo "github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/repository/webhook"
 	"github.com/zitadel/zitadel/internal/static"
 	webauthn_helper "github.com/zitadel/zitadel/internal/webauthn"
 )
@@ -47,16 +50,28 @@ type Commands struct {
 	domainVerificationAlg       crypto.EncryptionAlgorithm
 	domainVerificationGenerator crypto.Generator
 	domainVerificationValidator func(domain, token, verifier string, checkType api_http.CheckType) error
+	webhookSigningKeyGenerator  crypto.Generator
+	webhookURLValidator         func(webhookURL string) error
+	actionsSecretEncryption     crypto.EncryptionAlgorithm
+	actionsKeyValueQuota        sd.ActionsKeyValue
 
-	multifactors         domain.MultifactorConfigs
-	webauthnConfig       *webauthn_helper.Config
-	keySize              int
-	keyAlgorithm         crypto.EncryptionAlgorithm
-	certificateAlgorithm crypto.EncryptionAlgorithm
-	certKeySize          int
-	privateKeyLifetime   time.Duration
-	publicKeyLifetime    time.Duration
-	certificateLifetime  time.Duration
+	samlCertificateAndKeyGenerator func(id string) ([]byte, []byte, error)
+
+	multifactors            domain.MultifactorConfigs
+	defaultSecretGenerators *SecretGenerators
+	// newEncryptedCodeWithDefault is a field to be able to mock the generated codes in tests
+	newEncryptedCodeWithDefault encryptedCodeWithDefaultFunc
+	webauthnConfig              *webauthn_helper.Config
+	keySize                     int
+	keyAlgorithm                crypto.EncryptionAlgorithm
+	certificateAlgorithm        crypto.EncryptionAlgorithm
+	certKeySize                 int
+	privateKeyLifetime          time.Duration
+	publicKeyLifetime           time.Duration
+	certificateLifetime         time.Duration
+
+	userManagementActions UserManagementActions
+	actionKeyValueUsage   ActionKeyValueUsageQuerier
 }
 
 func StartCommands(es *eventstore.Eventstore,
@@ -74,8 +89,13 @@ func StartCommands(es *eventstore.Eventstore,
 	userEncryption,
 	domainVerificationEncryption,
 	oidcEncryption,
-	samlEncryption crypto.EncryptionAlgorithm,
+	samlEncryption,
+	webhookEncryption,
+	actionsSecretEncryption crypto.EncryptionAlgorithm,
 	httpClient *http.Client,
+	userManagementActions UserManagementActions,
+	actionKeyValueUsage ActionKeyValueUsageQuerier,
+	webhookURLValidator func(webhookURL string) error,
 ) (repo *Commands, err error) {
 	if externalDomain == "" {
 		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Df21s", "no external domain specified")
@@ -102,6 +122,9 @@ func StartCommands(es *eventstore.Eventstore,
 		certificateAlgorithm:  samlEncryption,
 		webauthnConfig:        webAuthN,
 		httpClient:            httpClient,
+		userManagementActions: userManagementActions,
+		actionKeyValueUsage:   actionKeyValueUsage,
+		webhookURLValidator:   webhookURLValidator,
 	}
 
 	instance_repo.RegisterEventMappers(repo.eventstore)
@@ -112,20 +135,40 @@ func StartCommands(es *eventstore.Eventstore,
 	keypair.RegisterEventMappers(repo.eventstore)
 	action.RegisterEventMappers(repo.eventstore)
 	quota.RegisterEventMappers(repo.eventstore)
+	deviceauth.RegisterEventMappers(repo.eventstore)
+	webhook.RegisterEventMappers(repo.eventstore)
+	session.RegisterEventMappers(repo.eventstore)
 
-	repo.userPasswordAlg = crypto.NewBCrypt(defaults.SecretGenerators.PasswordSaltCost)
+	repo.userPasswordAlg, err = defaults.PasswordHasher.NewHasher()
+	if err != nil {
+		return nil, err
+	}
 	repo.machineKeySize = int(defaults.SecretGenerators.MachineKeySize)
 	repo.applicationKeySize = int(defaults.SecretGenerators.ApplicationKeySize)
 
+	repo.defaultSecretGenerators = &SecretGenerators{
+		OTPSMS:   defaults.SecretGenerators.OTPSMS,
+		OTPEmail: defaults.SecretGenerators.OTPEmail,
+	}
+	repo.newEncryptedCodeWithDefault = newEncryptedCodeWithDefaultConfig
+
 	repo.multifactors = domain.MultifactorConfigs{
 		OTP: domain.OTPConfig{
 			CryptoMFA: otpEncryption,
 			Issuer:    defaults.Multifactors.OTP.Issuer,
 		},
+		RecoveryCodes: domain.RecoveryCodesConfig{
+			Count:     defaults.Multifactors.RecoveryCodes.Count,
+			Generator: crypto.NewHashGenerator(defaults.Multifactors.RecoveryCodes.Generator, repo.userPasswordAlg),
+		},
 	}
 
 	repo.domainVerificationGenerator = crypto.NewEncryptionGenerator(defaults.DomainVerification.VerificationGenerator, repo.domainVerificationAlg)
 	repo.domainVerificationValidator = api_http.ValidateDomain
+	repo.webhookSigningKeyGenerator = crypto.NewEncryptionGenerator(defaults.Webhooks.SigningKeyGenerator, webhookEncryption)
+	repo.actionsSecretEncryption = actionsSecretEncryption
+	repo.actionsKeyValueQuota = defaults.ActionsKeyValue
+	repo.samlCertificateAndKeyGenerator = samlCertificateAndKeyGenerator(defaults.KeyConfig.CertificateSize, defaults.KeyConfig.CertificateLifetime)
 	return repo, nil
 }
 
@@ -10,6 +10,13 @@ import (
 	"github.com/zitadel/zitadel/internal/errors"
 )
 
+// SecretGenerators are the default generator configs,
+// used if the instance has no config of the type
+type SecretGenerators struct {
+	OTPSMS   *crypto.GeneratorConfig
+	OTPEmail *crypto.GeneratorConfig
+}
+
 func newCryptoCodeWithExpiry(ctx context.Context, filter preparation.FilterToQueryReducer, typ domain.SecretGeneratorType, alg crypto.Crypto) (value *crypto.CryptoValue, expiry time.Duration, err error) {
 	config, err := secretGeneratorConfig(ctx, filter, typ)
 	if err != nil {
@@ -30,6 +37,22 @@ func newCryptoCodeWithExpiry(ctx context.Context, filter preparation.FilterToQue
 	return value, config.Expiry, nil
 }
 
+type encryptedCodeWithDefaultFunc func(ctx context.Context, filter preparation.FilterToQueryReducer, typ domain.SecretGeneratorType, alg crypto.EncryptionAlgorithm, defaultConfig *crypto.GeneratorConfig) (*crypto.CryptoValue, time.Duration, error)
+
+// newEncryptedCodeWithDefaultConfig creates an encrypted code based on the secret generator config of the instance,
+// if the instance has no config of the type, the defaultConfig is used
+func newEncryptedCodeWithDefaultConfig(ctx context.Context, filter preparation.FilterToQueryReducer, typ domain.SecretGeneratorType, alg crypto.EncryptionAlgorithm, defaultConfig *crypto.GeneratorConfig) (value *crypto.CryptoValue, expiry time.Duration, err error) {
+	config, err := secretGeneratorConfigWithDefault(ctx, filter, typ, defaultConfig)
+	if err != nil {
+		return nil, -1, err
+	}
+	value, _, err = crypto.NewCode(crypto.NewEncryptionGenerator(*config, alg))
+	if err != nil {
+		return nil, -1, err
+	}
+	return value, config.Expiry, nil
+}
+
 func newCryptoCodeWithPlain(ctx context.Context, filter preparation.FilterToQueryReducer, typ domain.SecretGeneratorType, alg crypto.Crypto) (value *crypto.CryptoValue, plain string, err error) {
 	config, err := secretGeneratorConfig(ctx, filter, typ)
 	if err != nil {
@@ -47,6 +70,10 @@ func newCryptoCodeWithPlain(ctx context.Context, filter preparation.FilterToQuer
 }
 
 func secretGeneratorConfig(ctx context.Context, filter preparation.FilterToQueryReducer, typ domain.SecretGeneratorType) (*crypto.GeneratorConfig, error) {
+	return secretGeneratorConfigWithDefault(ctx, filter, typ, nil)
+}
+
+func secretGeneratorConfigWithDefault(ctx context.Context, filter preparation.FilterToQueryReducer, typ domain.SecretGeneratorType, defaultConfig *crypto.GeneratorConfig) (*crypto.GeneratorConfig, error) {
 	wm := NewInstanceSecretGeneratorConfigWriteModel(ctx, typ)
 	events, err := filter(ctx, wm.Query())
 	if err != nil {
@@ -56,6 +83,9 @@ func secretGeneratorConfig(ctx context.Context, filter preparation.FilterToQuery
 	if err := wm.Reduce(); err != nil {
 		return nil, err
 	}
+	if wm.State != domain.SecretGeneratorStateActive && defaultConfig != nil {
+		return defaultConfig, nil
+	}
 	return &crypto.GeneratorConfig{
 		Length:              wm.Length,
 		Expiry:              wm.Expiry,
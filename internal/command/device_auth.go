@@ -0,0 +1,150 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/deviceauth"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// AddDeviceAuth starts a new device authorization (RFC 8628) of the client
+// and returns the id of the created aggregate
+func (c *Commands) AddDeviceAuth(ctx context.Context, clientID, deviceCode, userCode string, expires time.Time, scopes []string) (string, *domain.ObjectDetails, error) {
+	if clientID == "" || deviceCode == "" || userCode == "" {
+		return "", nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Eic6o", "Errors.DeviceAuth.Invalid")
+	}
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return "", nil, err
+	}
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	aggregate := deviceauth.NewAggregate(id, instanceID)
+	writeModel := NewDeviceAuthWriteModel(id, instanceID)
+
+	pushedEvents, err := c.eventstore.Push(ctx, deviceauth.NewAddedEvent(
+		ctx,
+		&aggregate.Aggregate,
+		clientID,
+		deviceCode,
+		userCode,
+		expires,
+		scopes,
+	))
+	if err != nil {
+		return "", nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return "", nil, err
+	}
+	return id, writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// ApproveDeviceAuth is called after the user has successfully logged in and approved the device
+func (c *Commands) ApproveDeviceAuth(ctx context.Context, id, subject string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if subject == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ri6ah", "Errors.User.UserIDMissing")
+	}
+	writeModel, err := c.pendingDeviceAuthWriteModel(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, deviceauth.NewApprovedEvent(ctx, DeviceAuthAggregateFromWriteModel(&writeModel.WriteModel), subject))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// CancelDeviceAuth is called if the user denies the device or the device authorization expired
+func (c *Commands) CancelDeviceAuth(ctx context.Context, id string, reason domain.DeviceAuthCanceled) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if !reason.State().Valid() || reason.State() == domain.DeviceAuthStateUndefined {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ooc3u", "Errors.DeviceAuth.Invalid")
+	}
+	writeModel, err := c.pendingDeviceAuthWriteModel(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, deviceauth.NewCanceledEvent(ctx, DeviceAuthAggregateFromWriteModel(&writeModel.WriteModel), reason))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// ConsumeDeviceAuth removes the approved device authorization before the tokens are issued
+// so the device code and user code can't be used again.
+// The state is checked on the events and not on the projection, which might not be up-to-date yet.
+// As the events of an aggregate are pushed in a serializable transaction, a concurrent exchange of the same device code
+// would be the previous event of the removed event, which is rejected
+func (c *Commands) ConsumeDeviceAuth(ctx context.Context, id string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel, err := c.getDeviceAuthWriteModel(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	switch writeModel.State {
+	case domain.DeviceAuthStateApproved:
+	case domain.DeviceAuthStateUndefined:
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Ohb7u", "Errors.DeviceAuth.NotFound")
+	case domain.DeviceAuthStateInitiated:
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-ieX5a", "Errors.DeviceAuth.Invalid")
+	default:
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ua0ph", "Errors.DeviceAuth.AlreadyHandled")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, deviceauth.NewRemovedEvent(ctx, DeviceAuthAggregateFromWriteModel(&writeModel.WriteModel), writeModel.DeviceCode, writeModel.UserCode))
+	if err != nil {
+		return nil, err
+	}
+	if pushedEvents[0].PreviousAggregateSequence() != writeModel.ProcessedSequence {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Oe3ai", "Errors.DeviceAuth.AlreadyHandled")
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func (c *Commands) pendingDeviceAuthWriteModel(ctx context.Context, id string) (*DeviceAuthWriteModel, error) {
+	writeModel, err := c.getDeviceAuthWriteModel(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Aeph1", "Errors.DeviceAuth.NotFound")
+	}
+	if writeModel.State != domain.DeviceAuthStateInitiated {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-xoo6P", "Errors.DeviceAuth.AlreadyHandled")
+	}
+	return writeModel, nil
+}
+
+func (c *Commands) getDeviceAuthWriteModel(ctx context.Context, id string) (*DeviceAuthWriteModel, error) {
+	writeModel := NewDeviceAuthWriteModel(id, authz.GetInstance(ctx).InstanceID())
+	err := c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
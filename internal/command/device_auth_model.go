@@ -0,0 +1,73 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/deviceauth"
+)
+
+type DeviceAuthWriteModel struct {
+	eventstore.WriteModel
+
+	ClientID   string
+	DeviceCode string
+	UserCode   string
+	Expires    time.Time
+	Scopes     []string
+	Subject    string
+	State      domain.DeviceAuthState
+}
+
+func NewDeviceAuthWriteModel(id, instanceID string) *DeviceAuthWriteModel {
+	return &DeviceAuthWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   id,
+			InstanceID:    instanceID,
+			ResourceOwner: instanceID,
+		},
+	}
+}
+
+func (wm *DeviceAuthWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *deviceauth.AddedEvent:
+			wm.ClientID = e.ClientID
+			wm.DeviceCode = e.DeviceCode
+			wm.UserCode = e.UserCode
+			wm.Expires = e.Expires
+			wm.Scopes = e.Scopes
+			wm.State = domain.DeviceAuthStateInitiated
+		case *deviceauth.ApprovedEvent:
+			wm.Subject = e.Subject
+			wm.State = domain.DeviceAuthStateApproved
+		case *deviceauth.CanceledEvent:
+			wm.State = e.Reason.State()
+		case *deviceauth.RemovedEvent:
+			wm.State = domain.DeviceAuthStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *DeviceAuthWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		InstanceID(wm.InstanceID).
+		AddQuery().
+		AggregateTypes(deviceauth.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			deviceauth.AddedEventType,
+			deviceauth.ApprovedEventType,
+			deviceauth.CanceledEventType,
+			deviceauth.RemovedEventType,
+		).
+		Builder()
+}
+
+func DeviceAuthAggregateFromWriteModel(wm *eventstore.WriteModel) *eventstore.Aggregate {
+	return eventstore.AggregateFromWriteModel(wm, deviceauth.AggregateType, deviceauth.AggregateVersion)
+}
@@ -0,0 +1,632 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/deviceauth"
+)
+
+func TestCommands_AddDeviceAuth(t *testing.T) {
+	expires := time.Date(2022, 12, 1, 10, 0, 0, 0, time.UTC)
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx        context.Context
+		clientID   string
+		deviceCode string
+		userCode   string
+		expires    time.Time
+		scopes     []string
+	}
+	type res struct {
+		id      string
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing user code, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:        authz.WithInstanceID(context.Background(), "instance1"),
+				clientID:   "client1",
+				deviceCode: "device1",
+				expires:    expires,
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "code already exists, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectPushFailed(
+						caos_errs.ThrowAlreadyExists(nil, "id", "code already exists"),
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID("instance1",
+								deviceauth.NewAddedEvent(context.Background(),
+									&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+									"client1",
+									"device1",
+									"BCDF-GHJK",
+									expires,
+									[]string{"openid"},
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraintWithInstanceID("instance1", deviceauth.NewAddUniqueConstraints("device1", "BCDF-GHJK")[0]),
+						uniqueConstraintsFromEventConstraintWithInstanceID("instance1", deviceauth.NewAddUniqueConstraints("device1", "BCDF-GHJK")[1]),
+					),
+				),
+				idGenerator: id_mock.ExpectID(t, "id1"),
+			},
+			args: args{
+				ctx:        authz.WithInstanceID(context.Background(), "instance1"),
+				clientID:   "client1",
+				deviceCode: "device1",
+				userCode:   "BCDF-GHJK",
+				expires:    expires,
+				scopes:     []string{"openid"},
+			},
+			res: res{
+				err: caos_errs.IsErrorAlreadyExists,
+			},
+		},
+		{
+			name: "ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID("instance1",
+								deviceauth.NewAddedEvent(context.Background(),
+									&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+									"client1",
+									"device1",
+									"BCDF-GHJK",
+									expires,
+									[]string{"openid"},
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraintWithInstanceID("instance1", deviceauth.NewAddUniqueConstraints("device1", "BCDF-GHJK")[0]),
+						uniqueConstraintsFromEventConstraintWithInstanceID("instance1", deviceauth.NewAddUniqueConstraints("device1", "BCDF-GHJK")[1]),
+					),
+				),
+				idGenerator: id_mock.ExpectID(t, "id1"),
+			},
+			args: args{
+				ctx:        authz.WithInstanceID(context.Background(), "instance1"),
+				clientID:   "client1",
+				deviceCode: "device1",
+				userCode:   "BCDF-GHJK",
+				expires:    expires,
+				scopes:     []string{"openid"},
+			},
+			res: res{
+				id: "id1",
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:  tt.fields.eventstore,
+				idGenerator: tt.fields.idGenerator,
+			}
+			id, details, err := c.AddDeviceAuth(tt.args.ctx, tt.args.clientID, tt.args.deviceCode, tt.args.userCode, tt.args.expires, tt.args.scopes)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.id, id)
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_ApproveDeviceAuth(t *testing.T) {
+	expires := time.Date(2022, 12, 1, 10, 0, 0, 0, time.UTC)
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx     context.Context
+		id      string
+		subject string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing subject, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "not found, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				id:      "id1",
+				subject: "user1",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "already denied, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							deviceauth.NewAddedEvent(context.Background(),
+								&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+								"client1",
+								"device1",
+								"BCDF-GHJK",
+								expires,
+								[]string{"openid"},
+							),
+						),
+						eventFromEventPusher(
+							deviceauth.NewCanceledEvent(context.Background(),
+								&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+								domain.DeviceAuthCanceledDenied,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				id:      "id1",
+				subject: "user1",
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							deviceauth.NewAddedEvent(context.Background(),
+								&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+								"client1",
+								"device1",
+								"BCDF-GHJK",
+								expires,
+								[]string{"openid"},
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID("instance1",
+								deviceauth.NewApprovedEvent(context.Background(),
+									&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+									"user1",
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				id:      "id1",
+				subject: "user1",
+			},
+			res: res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			details, err := c.ApproveDeviceAuth(tt.args.ctx, tt.args.id, tt.args.subject)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_CancelDeviceAuth(t *testing.T) {
+	expires := time.Date(2022, 12, 1, 10, 0, 0, 0, time.UTC)
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx    context.Context
+		id     string
+		reason domain.DeviceAuthCanceled
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "invalid reason, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:    authz.WithInstanceID(context.Background(), "instance1"),
+				id:     "id1",
+				reason: "unknown",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "already approved, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							deviceauth.NewAddedEvent(context.Background(),
+								&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+								"client1",
+								"device1",
+								"BCDF-GHJK",
+								expires,
+								[]string{"openid"},
+							),
+						),
+						eventFromEventPusher(
+							deviceauth.NewApprovedEvent(context.Background(),
+								&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+								"user1",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:    authz.WithInstanceID(context.Background(), "instance1"),
+				id:     "id1",
+				reason: domain.DeviceAuthCanceledDenied,
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							deviceauth.NewAddedEvent(context.Background(),
+								&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+								"client1",
+								"device1",
+								"BCDF-GHJK",
+								expires,
+								[]string{"openid"},
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID("instance1",
+								deviceauth.NewCanceledEvent(context.Background(),
+									&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+									domain.DeviceAuthCanceledExpired,
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:    authz.WithInstanceID(context.Background(), "instance1"),
+				id:     "id1",
+				reason: domain.DeviceAuthCanceledExpired,
+			},
+			res: res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			details, err := c.CancelDeviceAuth(tt.args.ctx, tt.args.id, tt.args.reason)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_ConsumeDeviceAuth(t *testing.T) {
+	expires := time.Date(2022, 12, 1, 10, 0, 0, 0, time.UTC)
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx context.Context
+		id  string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "not found, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "not approved, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							deviceauth.NewAddedEvent(context.Background(),
+								&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+								"client1",
+								"device1",
+								"BCDF-GHJK",
+								expires,
+								[]string{"openid"},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "already consumed, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							deviceauth.NewAddedEvent(context.Background(),
+								&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+								"client1",
+								"device1",
+								"BCDF-GHJK",
+								expires,
+								[]string{"openid"},
+							),
+						),
+						eventFromEventPusher(
+							deviceauth.NewApprovedEvent(context.Background(),
+								&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+								"user1",
+							),
+						),
+						eventFromEventPusher(
+							deviceauth.NewRemovedEvent(context.Background(),
+								&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+								"device1",
+								"BCDF-GHJK",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "consumed concurrently, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithSequence(1,
+							deviceauth.NewAddedEvent(context.Background(),
+								&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+								"client1",
+								"device1",
+								"BCDF-GHJK",
+								expires,
+								[]string{"openid"},
+							),
+						),
+						eventFromEventPusherWithSequence(2,
+							deviceauth.NewApprovedEvent(context.Background(),
+								&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+								"user1",
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID("instance1",
+								deviceauth.NewRemovedEvent(context.Background(),
+									&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+									"device1",
+									"BCDF-GHJK",
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraintWithInstanceID("instance1", deviceauth.NewRemoveUniqueConstraints("device1", "BCDF-GHJK")[0]),
+						uniqueConstraintsFromEventConstraintWithInstanceID("instance1", deviceauth.NewRemoveUniqueConstraints("device1", "BCDF-GHJK")[1]),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							deviceauth.NewAddedEvent(context.Background(),
+								&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+								"client1",
+								"device1",
+								"BCDF-GHJK",
+								expires,
+								[]string{"openid"},
+							),
+						),
+						eventFromEventPusher(
+							deviceauth.NewApprovedEvent(context.Background(),
+								&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+								"user1",
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID("instance1",
+								deviceauth.NewRemovedEvent(context.Background(),
+									&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+									"device1",
+									"BCDF-GHJK",
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraintWithInstanceID("instance1", deviceauth.NewRemoveUniqueConstraints("device1", "BCDF-GHJK")[0]),
+						uniqueConstraintsFromEventConstraintWithInstanceID("instance1", deviceauth.NewRemoveUniqueConstraints("device1", "BCDF-GHJK")[1]),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+			},
+			res: res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			details, err := c.ConsumeDeviceAuth(tt.args.ctx, tt.args.id)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
@@ -32,5 +32,69 @@ type LDAPProvider struct {
 	Admin               string
 	Password            string
 	LDAPAttributes      idp.LDAPAttributes
+	GroupMappings       []idp.LDAPGroupMapping
 	IDPOptions          idp.Options
 }
+
+type SAMLProvider struct {
+	Name              string
+	Metadata          []byte
+	MetadataURL       string
+	WithSignedRequest bool
+	IDPOptions        idp.Options
+}
+
+type OIDCProvider struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	IDPOptions   idp.Options
+}
+
+type AzureADProvider struct {
+	Name            string
+	ClientID        string
+	ClientSecret    string
+	Scopes          []string
+	Tenant          string
+	IsEmailVerified bool
+	IDPOptions      idp.Options
+}
+
+type GitHubProvider struct {
+	Name         string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	IDPOptions   idp.Options
+}
+
+type GitHubEnterpriseProvider struct {
+	Name                  string
+	ClientID              string
+	ClientSecret          string
+	AuthorizationEndpoint string
+	TokenEndpoint         string
+	UserEndpoint          string
+	Scopes                []string
+	IDPOptions            idp.Options
+}
+
+type GitLabProvider struct {
+	Name         string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	IDPOptions   idp.Options
+}
+
+type GitLabSelfHostedProvider struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	IDPOptions   idp.Options
+}
@@ -0,0 +1,306 @@
+package command
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// validateLDAPGroupMappings checks that every mapping references a group
+// and maps it to roles of a project and / or to organisation member roles
+func (c *Commands) validateLDAPGroupMappings(mappings []idp.LDAPGroupMapping) error {
+	for _, mapping := range mappings {
+		if strings.TrimSpace(mapping.GroupDN) == "" {
+			return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Gm3la", "Errors.Invalid.Argument")
+		}
+		if mapping.ProjectID == "" && (len(mapping.ProjectRoleKeys) > 0 || len(mapping.OrgMemberRoles) == 0) {
+			return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Pq2nf", "Errors.Invalid.Argument")
+		}
+		if len(mapping.OrgMemberRoles) > 0 && len(domain.CheckForInvalidRoles(mapping.OrgMemberRoles, domain.OrgRolePrefix, c.zitadelRoles)) > 0 {
+			return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Wd4kg", "Errors.Org.MemberInvalid")
+		}
+	}
+	return nil
+}
+
+// ApplyLDAPGroupMappings grants the user the project roles (as user grant) and the organisation member roles
+// of the mapped LDAP groups they are member of.
+// The roles granted by the mappings are stored on the user, so that only these are revoked
+// if the user is no longer member of a group. Roles granted otherwise (e.g. ORG_OWNER) are kept.
+func (c *Commands) ApplyLDAPGroupMappings(ctx context.Context, userID, resourceOwner, idpID string, mappings []idp.LDAPGroupMapping, groups []string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" || resourceOwner == "" || idpID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Rj3fs", "Errors.IDMissing")
+	}
+	applied := NewLDAPRolesAppliedWriteModel(userID, resourceOwner, idpID)
+	err = c.eventstore.FilterToQueryReducer(ctx, applied)
+	if err != nil {
+		return err
+	}
+	roles := ldapGroupMappingRoles(mappings, groups)
+	cmds, appliedProjectRoles, err := c.ldapGroupUserGrantCommands(ctx, userID, roles, applied.ProjectRoles)
+	if err != nil {
+		return err
+	}
+	appliedOrgMemberRoles := applied.OrgMemberRoles
+	if roles.manageOrgMember || len(applied.OrgMemberRoles) > 0 {
+		var cmd eventstore.Command
+		cmd, appliedOrgMemberRoles, err = c.ldapGroupOrgMemberCommand(ctx, userID, resourceOwner, roles.orgMemberRoles, applied.OrgMemberRoles)
+		if err != nil {
+			return err
+		}
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+	if !equalProjectRoles(applied.ProjectRoles, appliedProjectRoles) || !equalRoles(applied.OrgMemberRoles, appliedOrgMemberRoles) {
+		cmds = append(cmds, user.NewUserIDPLDAPRolesAppliedEvent(ctx, UserAggregateFromWriteModel(&applied.WriteModel), idpID, appliedProjectRoles, appliedOrgMemberRoles))
+	}
+	if len(cmds) == 0 {
+		return nil
+	}
+	_, err = c.eventstore.Push(ctx, cmds...)
+	return err
+}
+
+// ldapGroupUserGrantCommands returns the commands to add, change or remove the user grants
+// of all mapped projects and of the projects with roles previously granted by the mappings,
+// as well as the project roles granted by the mappings afterwards
+func (c *Commands) ldapGroupUserGrantCommands(ctx context.Context, userID string, roles *ldapGroupRoles, previous map[string][]string) ([]eventstore.Command, map[string][]string, error) {
+	projectIDs := append([]string{}, roles.projectIDs...)
+	previousProjectIDs := make([]string, 0, len(previous))
+	for projectID := range previous {
+		previousProjectIDs = append(previousProjectIDs, projectID)
+	}
+	sort.Strings(previousProjectIDs)
+	projectIDs = appendMissing(projectIDs, previousProjectIDs...)
+	if len(projectIDs) == 0 {
+		return nil, nil, nil
+	}
+	grants := NewUserGrantsOfUserReadModel(userID, projectIDs)
+	err := c.eventstore.FilterToQueryReducer(ctx, grants)
+	if err != nil {
+		return nil, nil, err
+	}
+	cmds := make([]eventstore.Command, 0, len(projectIDs))
+	applied := make(map[string][]string)
+	for _, projectID := range projectIDs {
+		existing, err := c.existingUserGrant(ctx, grants.GrantIDs[projectID])
+		if err != nil {
+			return nil, nil, err
+		}
+		var existingRoles []string
+		if existing != nil {
+			existingRoles = existing.RoleKeys
+		}
+		roleKeys, appliedRoles := mergeLDAPRoles(existingRoles, previous[projectID], roles.projectRoles[projectID])
+		if len(appliedRoles) > 0 {
+			applied[projectID] = appliedRoles
+		}
+		switch {
+		case existing == nil && len(roleKeys) > 0:
+			cmd, err := c.addLDAPGroupUserGrant(ctx, userID, projectID, roleKeys)
+			if err != nil {
+				return nil, nil, err
+			}
+			if cmd == nil {
+				delete(applied, projectID)
+				continue
+			}
+			cmds = append(cmds, cmd)
+		case existing == nil:
+			continue
+		case len(roleKeys) == 0 && len(existing.RoleKeys) > 0:
+			// all roles of the grant were granted by the mappings
+			cmds = append(cmds, usergrant.NewUserGrantRemovedEvent(ctx, UserGrantAggregateFromWriteModel(&existing.WriteModel), userID, projectID, ""))
+		case !equalRoles(existing.RoleKeys, roleKeys):
+			cmds = append(cmds, usergrant.NewUserGrantChangedEvent(ctx, UserGrantAggregateFromWriteModel(&existing.WriteModel), roleKeys))
+		}
+	}
+	return cmds, applied, nil
+}
+
+// existingUserGrant returns the first of the grants, which was not removed
+func (c *Commands) existingUserGrant(ctx context.Context, grantIDs []string) (*UserGrantWriteModel, error) {
+	for _, grantID := range grantIDs {
+		grant, err := c.userGrantWriteModelByID(ctx, grantID, "")
+		if err != nil {
+			return nil, err
+		}
+		if grant.State == domain.UserGrantStateActive || grant.State == domain.UserGrantStateInactive {
+			return grant, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *Commands) addLDAPGroupUserGrant(ctx context.Context, userID, projectID string, roleKeys []string) (eventstore.Command, error) {
+	project, err := c.getProjectWriteModelByID(ctx, projectID, "")
+	if err != nil {
+		return nil, err
+	}
+	if project.State == domain.ProjectStateUnspecified || project.State == domain.ProjectStateRemoved {
+		logging.WithFields("COMMAND-Hs2ng", "projectID", projectID).Warn("project of ldap group mapping does not exist")
+		return nil, nil
+	}
+	userGrant := &domain.UserGrant{
+		UserID:    userID,
+		ProjectID: projectID,
+		RoleKeys:  roleKeys,
+	}
+	err = c.checkUserGrantPreCondition(ctx, userGrant, project.ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+	return usergrant.NewUserGrantAddedEvent(ctx, &usergrant.NewAggregate(id, project.ResourceOwner).Aggregate, userID, projectID, "", roleKeys), nil
+}
+
+// ldapGroupOrgMemberCommand returns the command to add, change or remove the organisation membership
+// and the member roles granted by the mappings afterwards
+func (c *Commands) ldapGroupOrgMemberCommand(ctx context.Context, userID, orgID string, roles, previous []string) (eventstore.Command, []string, error) {
+	member := NewOrgMemberWriteModel(orgID, userID)
+	err := c.eventstore.FilterToQueryReducer(ctx, member)
+	if err != nil {
+		return nil, nil, err
+	}
+	isMember := member.State == domain.MemberStateActive
+	var existingRoles []string
+	if isMember {
+		existingRoles = member.Roles
+	}
+	memberRoles, applied := mergeLDAPRoles(existingRoles, previous, roles)
+	orgAgg := &org.NewAggregate(orgID).Aggregate
+	switch {
+	case len(memberRoles) > 0 && !isMember:
+		return org.NewMemberAddedEvent(ctx, orgAgg, userID, memberRoles...), applied, nil
+	case !isMember:
+		return nil, applied, nil
+	case len(memberRoles) == 0 && len(member.Roles) > 0:
+		// all roles of the member were granted by the mappings
+		return org.NewMemberRemovedEvent(ctx, orgAgg, userID), applied, nil
+	case !equalRoles(member.Roles, memberRoles):
+		return org.NewMemberChangedEvent(ctx, orgAgg, userID, memberRoles...), applied, nil
+	}
+	return nil, applied, nil
+}
+
+// mergeLDAPRoles returns the roles after applying the mapped roles to the existing ones
+// and the roles granted by the mappings afterwards.
+// Previously granted roles, which are no longer mapped, are revoked.
+// Existing roles, which were not granted by the mappings, are kept and not taken over by them.
+func mergeLDAPRoles(existing, previous, mapped []string) (roles, applied []string) {
+	roles = make([]string, 0, len(existing)+len(mapped))
+	for _, role := range existing {
+		if listContainsID(previous, role) && !listContainsID(mapped, role) {
+			continue
+		}
+		roles = append(roles, role)
+	}
+	for _, role := range mapped {
+		if !listContainsID(existing, role) || listContainsID(previous, role) {
+			applied = append(applied, role)
+		}
+		roles = appendMissing(roles, role)
+	}
+	return roles, applied
+}
+
+type ldapGroupRoles struct {
+	// projectIDs of all mappings
+	projectIDs []string
+	// projectRoles of the groups the user is member of
+	projectRoles    map[string][]string
+	manageOrgMember bool
+	orgMemberRoles  []string
+}
+
+// ldapGroupMappingRoles combines the roles of all mappings of the groups (DNs are compared case-insensitive)
+func ldapGroupMappingRoles(mappings []idp.LDAPGroupMapping, groups []string) *ldapGroupRoles {
+	roles := &ldapGroupRoles{
+		projectRoles: make(map[string][]string),
+	}
+	for _, mapping := range mappings {
+		if mapping.ProjectID != "" && !listContainsID(roles.projectIDs, mapping.ProjectID) {
+			roles.projectIDs = append(roles.projectIDs, mapping.ProjectID)
+		}
+		if len(mapping.OrgMemberRoles) > 0 {
+			roles.manageOrgMember = true
+		}
+		if !isLDAPGroupMember(groups, mapping.GroupDN) {
+			continue
+		}
+		if mapping.ProjectID != "" {
+			roles.projectRoles[mapping.ProjectID] = appendMissing(roles.projectRoles[mapping.ProjectID], mapping.ProjectRoleKeys...)
+		}
+		roles.orgMemberRoles = appendMissing(roles.orgMemberRoles, mapping.OrgMemberRoles...)
+	}
+	return roles
+}
+
+func isLDAPGroupMember(groups []string, groupDN string) bool {
+	for _, group := range groups {
+		if strings.EqualFold(strings.TrimSpace(group), strings.TrimSpace(groupDN)) {
+			return true
+		}
+	}
+	return false
+}
+
+func appendMissing(list []string, values ...string) []string {
+	if list == nil {
+		list = make([]string, 0, len(values))
+	}
+	for _, value := range values {
+		if !listContainsID(list, value) {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
+// equalProjectRoles compares the roles of every project regardless of their order
+func equalProjectRoles(a, b map[string][]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for projectID, roles := range a {
+		if !equalRoles(roles, b[projectID]) {
+			return false
+		}
+	}
+	return true
+}
+
+// equalRoles compares the roles regardless of their order
+func equalRoles(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA := append([]string{}, a...)
+	sortedB := append([]string{}, b...)
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+	for i := range sortedA {
+		if sortedA[i] != sortedB[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,111 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+)
+
+// UserGrantsOfUserReadModel collects the ids of the user grants added for a user
+// (per project, without project grant), the current state has to be checked on the [UserGrantWriteModel]
+type UserGrantsOfUserReadModel struct {
+	eventstore.WriteModel
+
+	UserID     string
+	ProjectIDs []string
+	// GrantIDs maps the project id to the ids of the user grants
+	GrantIDs map[string][]string
+}
+
+func NewUserGrantsOfUserReadModel(userID string, projectIDs []string) *UserGrantsOfUserReadModel {
+	return &UserGrantsOfUserReadModel{
+		UserID:     userID,
+		ProjectIDs: projectIDs,
+		GrantIDs:   make(map[string][]string),
+	}
+}
+
+func (rm *UserGrantsOfUserReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		e, ok := event.(*usergrant.UserGrantAddedEvent)
+		if !ok || e.UserID != rm.UserID || e.ProjectGrantID != "" {
+			continue
+		}
+		for _, projectID := range rm.ProjectIDs {
+			if e.ProjectID == projectID {
+				rm.GrantIDs[projectID] = append(rm.GrantIDs[projectID], e.Aggregate().ID)
+			}
+		}
+	}
+	return rm.WriteModel.Reduce()
+}
+
+func (rm *UserGrantsOfUserReadModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(usergrant.AggregateType).
+		EventTypes(usergrant.UserGrantAddedType).
+		EventData(map[string]interface{}{"userId": rm.UserID}).
+		Builder()
+}
+
+// LDAPRolesAppliedWriteModel holds the roles last granted to the user by the group mappings of an LDAP identity provider
+type LDAPRolesAppliedWriteModel struct {
+	eventstore.WriteModel
+
+	IDPConfigID string
+	// ProjectRoles maps the project id to the granted role keys
+	ProjectRoles   map[string][]string
+	OrgMemberRoles []string
+}
+
+func NewLDAPRolesAppliedWriteModel(userID, resourceOwner, idpConfigID string) *LDAPRolesAppliedWriteModel {
+	return &LDAPRolesAppliedWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+		IDPConfigID: idpConfigID,
+	}
+}
+
+func (wm *LDAPRolesAppliedWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *user.UserIDPLDAPRolesAppliedEvent:
+			if e.IDPConfigID != wm.IDPConfigID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *user.UserRemovedEvent:
+			wm.WriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *LDAPRolesAppliedWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.UserIDPLDAPRolesAppliedEvent:
+			wm.ProjectRoles = e.ProjectRoles
+			wm.OrgMemberRoles = e.OrgMemberRoles
+		case *user.UserRemovedEvent:
+			wm.ProjectRoles = nil
+			wm.OrgMemberRoles = nil
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *LDAPRolesAppliedWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			user.UserIDPLDAPRolesAppliedType,
+			user.UserRemovedType,
+		).
+		Builder()
+}
@@ -0,0 +1,514 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/member"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+)
+
+func TestCommandSide_ApplyLDAPGroupMappings(t *testing.T) {
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx           context.Context
+		userID        string
+		resourceOwner string
+		idpID         string
+		mappings      []idp.LDAPGroupMapping
+		groups        []string
+	}
+	type res struct {
+		err func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing user id, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				idpID:         "idp1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "no mappings, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					// applied roles
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				idpID:         "idp1",
+				groups:        []string{"cn=admins,dc=example,dc=com"},
+			},
+		},
+		{
+			name: "member of groups, grant and membership added",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					// applied roles
+					expectFilter(),
+					// existing user grants
+					expectFilter(),
+					// project
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org2").Aggregate,
+								"projectname1", true, true, true,
+								domain.PrivateLabelingSettingUnspecified,
+							),
+						),
+					),
+					// user grant pre condition
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username1",
+								"firstname1",
+								"lastname1",
+								"nickname1",
+								"displayname1",
+								language.German,
+								domain.GenderMale,
+								"email1",
+								true,
+							),
+						),
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org2").Aggregate,
+								"projectname1", true, true, true,
+								domain.PrivateLabelingSettingUnspecified,
+							),
+						),
+						eventFromEventPusher(
+							project.NewRoleAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org2").Aggregate,
+								"admin",
+								"admin",
+								"",
+							),
+						),
+						eventFromEventPusher(
+							project.NewRoleAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org2").Aggregate,
+								"viewer",
+								"viewer",
+								"",
+							),
+						),
+					),
+					// org member
+					expectFilter(),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(usergrant.NewUserGrantAddedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant1", "org2").Aggregate,
+								"user1",
+								"project1",
+								"",
+								[]string{"admin", "viewer"},
+							)),
+							eventFromEventPusher(org.NewMemberAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"user1",
+								"ORG_USER_MANAGER",
+							)),
+							eventFromEventPusher(user.NewUserIDPLDAPRolesAppliedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"idp1",
+								map[string][]string{"project1": {"admin", "viewer"}},
+								[]string{"ORG_USER_MANAGER"},
+							)),
+						},
+						uniqueConstraintsFromEventConstraint(usergrant.NewAddUserGrantUniqueConstraint("org2", "user1", "project1", "")),
+						uniqueConstraintsFromEventConstraint(member.NewAddMemberUniqueConstraint("org1", "user1")),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "usergrant1"),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				idpID:         "idp1",
+				mappings: []idp.LDAPGroupMapping{
+					{
+						GroupDN:         "cn=admins,dc=example,dc=com",
+						ProjectID:       "project1",
+						ProjectRoleKeys: []string{"admin"},
+						OrgMemberRoles:  []string{"ORG_USER_MANAGER"},
+					},
+					{
+						GroupDN:         "cn=users,dc=example,dc=com",
+						ProjectID:       "project1",
+						ProjectRoleKeys: []string{"viewer"},
+					},
+					{
+						GroupDN:         "cn=others,dc=example,dc=com",
+						ProjectID:       "project1",
+						ProjectRoleKeys: []string{"other"},
+					},
+				},
+				groups: []string{"CN=Admins,DC=example,DC=com", "cn=users,dc=example,dc=com"},
+			},
+		},
+		{
+			name: "no longer member of groups, grant and membership removed",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					// applied roles
+					expectFilter(
+						eventFromEventPusher(user.NewUserIDPLDAPRolesAppliedEvent(context.Background(),
+							&user.NewAggregate("user1", "org1").Aggregate,
+							"idp1",
+							map[string][]string{"project1": {"admin"}},
+							[]string{"ORG_USER_MANAGER"},
+						)),
+					),
+					// existing user grants
+					expectFilter(
+						eventFromEventPusher(usergrant.NewUserGrantAddedEvent(context.Background(),
+							&usergrant.NewAggregate("usergrant1", "org2").Aggregate,
+							"user1",
+							"project1",
+							"",
+							[]string{"admin"},
+						)),
+					),
+					// user grant
+					expectFilter(
+						eventFromEventPusher(usergrant.NewUserGrantAddedEvent(context.Background(),
+							&usergrant.NewAggregate("usergrant1", "org2").Aggregate,
+							"user1",
+							"project1",
+							"",
+							[]string{"admin"},
+						)),
+					),
+					// org member
+					expectFilter(
+						eventFromEventPusher(org.NewMemberAddedEvent(context.Background(),
+							&org.NewAggregate("org1").Aggregate,
+							"user1",
+							"ORG_USER_MANAGER",
+						)),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(usergrant.NewUserGrantRemovedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant1", "org2").Aggregate,
+								"user1",
+								"project1",
+								"",
+							)),
+							eventFromEventPusher(org.NewMemberRemovedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"user1",
+							)),
+							eventFromEventPusher(user.NewUserIDPLDAPRolesAppliedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"idp1",
+								map[string][]string{},
+								nil,
+							)),
+						},
+						uniqueConstraintsFromEventConstraint(usergrant.NewRemoveUserGrantUniqueConstraint("org2", "user1", "project1", "")),
+						uniqueConstraintsFromEventConstraint(member.NewRemoveMemberUniqueConstraint("org1", "user1")),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				idpID:         "idp1",
+				mappings: []idp.LDAPGroupMapping{
+					{
+						GroupDN:         "cn=admins,dc=example,dc=com",
+						ProjectID:       "project1",
+						ProjectRoleKeys: []string{"admin"},
+						OrgMemberRoles:  []string{"ORG_USER_MANAGER"},
+					},
+				},
+				groups: []string{"cn=users,dc=example,dc=com"},
+			},
+		},
+		{
+			name: "roles changed, grant changed",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					// applied roles
+					expectFilter(
+						eventFromEventPusher(user.NewUserIDPLDAPRolesAppliedEvent(context.Background(),
+							&user.NewAggregate("user1", "org1").Aggregate,
+							"idp1",
+							map[string][]string{"project1": {"admin"}},
+							nil,
+						)),
+					),
+					// existing user grants
+					expectFilter(
+						eventFromEventPusher(usergrant.NewUserGrantAddedEvent(context.Background(),
+							&usergrant.NewAggregate("usergrant1", "org2").Aggregate,
+							"user1",
+							"project1",
+							"",
+							[]string{"admin"},
+						)),
+					),
+					// user grant
+					expectFilter(
+						eventFromEventPusher(usergrant.NewUserGrantAddedEvent(context.Background(),
+							&usergrant.NewAggregate("usergrant1", "org2").Aggregate,
+							"user1",
+							"project1",
+							"",
+							[]string{"admin"},
+						)),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(usergrant.NewUserGrantChangedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant1", "org2").Aggregate,
+								[]string{"viewer"},
+							)),
+							eventFromEventPusher(user.NewUserIDPLDAPRolesAppliedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"idp1",
+								map[string][]string{"project1": {"viewer"}},
+								nil,
+							)),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				idpID:         "idp1",
+				mappings: []idp.LDAPGroupMapping{
+					{
+						GroupDN:         "cn=admins,dc=example,dc=com",
+						ProjectID:       "project1",
+						ProjectRoleKeys: []string{"admin"},
+					},
+					{
+						GroupDN:         "cn=users,dc=example,dc=com",
+						ProjectID:       "project1",
+						ProjectRoleKeys: []string{"viewer"},
+					},
+				},
+				groups: []string{"cn=users,dc=example,dc=com"},
+			},
+		},
+		{
+			name: "unchanged, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					// applied roles
+					expectFilter(
+						eventFromEventPusher(user.NewUserIDPLDAPRolesAppliedEvent(context.Background(),
+							&user.NewAggregate("user1", "org1").Aggregate,
+							"idp1",
+							map[string][]string{"project1": {"admin", "viewer"}},
+							nil,
+						)),
+					),
+					// existing user grants
+					expectFilter(
+						eventFromEventPusher(usergrant.NewUserGrantAddedEvent(context.Background(),
+							&usergrant.NewAggregate("usergrant1", "org2").Aggregate,
+							"user1",
+							"project1",
+							"",
+							[]string{"viewer", "admin"},
+						)),
+					),
+					// user grant
+					expectFilter(
+						eventFromEventPusher(usergrant.NewUserGrantAddedEvent(context.Background(),
+							&usergrant.NewAggregate("usergrant1", "org2").Aggregate,
+							"user1",
+							"project1",
+							"",
+							[]string{"viewer", "admin"},
+						)),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				idpID:         "idp1",
+				mappings: []idp.LDAPGroupMapping{
+					{
+						GroupDN:         "cn=admins,dc=example,dc=com",
+						ProjectID:       "project1",
+						ProjectRoleKeys: []string{"admin", "viewer"},
+					},
+				},
+				groups: []string{"cn=admins,dc=example,dc=com"},
+			},
+		},
+		{
+			name: "no longer member of groups, manual roles kept",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					// applied roles
+					expectFilter(
+						eventFromEventPusher(user.NewUserIDPLDAPRolesAppliedEvent(context.Background(),
+							&user.NewAggregate("user1", "org1").Aggregate,
+							"idp1",
+							map[string][]string{"project1": {"admin"}},
+							[]string{"ORG_USER_MANAGER"},
+						)),
+					),
+					// existing user grants
+					expectFilter(
+						eventFromEventPusher(usergrant.NewUserGrantAddedEvent(context.Background(),
+							&usergrant.NewAggregate("usergrant1", "org2").Aggregate,
+							"user1",
+							"project1",
+							"",
+							[]string{"admin", "viewer"},
+						)),
+					),
+					// user grant
+					expectFilter(
+						eventFromEventPusher(usergrant.NewUserGrantAddedEvent(context.Background(),
+							&usergrant.NewAggregate("usergrant1", "org2").Aggregate,
+							"user1",
+							"project1",
+							"",
+							[]string{"admin", "viewer"},
+						)),
+					),
+					// org member
+					expectFilter(
+						eventFromEventPusher(org.NewMemberAddedEvent(context.Background(),
+							&org.NewAggregate("org1").Aggregate,
+							"user1",
+							"ORG_OWNER",
+							"ORG_USER_MANAGER",
+						)),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(usergrant.NewUserGrantChangedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant1", "org2").Aggregate,
+								[]string{"viewer"},
+							)),
+							eventFromEventPusher(org.NewMemberChangedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"user1",
+								"ORG_OWNER",
+							)),
+							eventFromEventPusher(user.NewUserIDPLDAPRolesAppliedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"idp1",
+								map[string][]string{},
+								nil,
+							)),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				idpID:         "idp1",
+				mappings: []idp.LDAPGroupMapping{
+					{
+						GroupDN:         "cn=admins,dc=example,dc=com",
+						ProjectID:       "project1",
+						ProjectRoleKeys: []string{"admin"},
+						OrgMemberRoles:  []string{"ORG_USER_MANAGER"},
+					},
+				},
+				groups: []string{"cn=users,dc=example,dc=com"},
+			},
+		},
+		{
+			name: "manually granted roles not taken over, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					// applied roles
+					expectFilter(),
+					// org member
+					expectFilter(
+						eventFromEventPusher(org.NewMemberAddedEvent(context.Background(),
+							&org.NewAggregate("org1").Aggregate,
+							"user1",
+							"ORG_OWNER",
+						)),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				idpID:         "idp1",
+				mappings: []idp.LDAPGroupMapping{
+					{
+						GroupDN:        "cn=admins,dc=example,dc=com",
+						OrgMemberRoles: []string{"ORG_OWNER"},
+					},
+				},
+				groups: []string{"cn=admins,dc=example,dc=com"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:  tt.fields.eventstore,
+				idGenerator: tt.fields.idGenerator,
+			}
+			err := r.ApplyLDAPGroupMappings(tt.args.ctx, tt.args.userID, tt.args.resourceOwner, tt.args.idpID, tt.args.mappings, tt.args.groups)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
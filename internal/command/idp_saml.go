@@ -0,0 +1,94 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"encoding/xml"
+	"io"
+	"math"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/zitadel/saml/pkg/provider/xml/md"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+// samlCertificateAndKeyGenerator returns a function, which generates the PEM encoded private key
+// and self-signed certificate ZITADEL uses as service provider towards a SAML identity provider
+func samlCertificateAndKeyGenerator(keySize int, lifetime time.Duration) func(id string) ([]byte, []byte, error) {
+	return func(id string) ([]byte, []byte, error) {
+		privateKey, publicKey, err := crypto.GenerateKeyPair(keySize)
+		if err != nil {
+			return nil, nil, err
+		}
+		serial, err := rand.Int(rand.Reader, big.NewInt(math.MaxInt64))
+		if err != nil {
+			return nil, nil, err
+		}
+		now := time.Now()
+		template := x509.Certificate{
+			SerialNumber: serial,
+			Subject: pkix.Name{
+				Organization: []string{"ZITADEL"},
+				SerialNumber: id,
+			},
+			NotBefore:             now,
+			NotAfter:              now.Add(lifetime),
+			KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+			BasicConstraintsValid: true,
+		}
+		certificate, err := x509.CreateCertificate(rand.Reader, &template, &template, publicKey, privateKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		return crypto.PrivateKeyToBytes(privateKey), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate}), nil
+	}
+}
+
+// samlMetadata returns the provided metadata or retrieves it from the metadataURL
+// and checks that it describes a SAML identity provider
+func (c *Commands) samlMetadata(ctx context.Context, metadata []byte, metadataURL string) ([]byte, error) {
+	if len(metadata) == 0 {
+		var err error
+		metadata, err = c.fetchSAMLMetadata(ctx, metadataURL)
+		if err != nil {
+			return nil, err
+		}
+	}
+	entity := new(md.EntityDescriptorType)
+	if err := xml.Unmarshal(metadata, entity); err != nil {
+		return nil, caos_errs.ThrowInvalidArgument(err, "COMMAND-Ahx3e", "Errors.IDPConfig.SAML.MetadataInvalid")
+	}
+	if entity.IDPSSODescriptor == nil || len(entity.IDPSSODescriptor.SingleSignOnService) == 0 {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Oa8ie", "Errors.IDPConfig.SAML.MetadataInvalid")
+	}
+	return metadata, nil
+}
+
+func (c *Commands) fetchSAMLMetadata(ctx context.Context, metadataURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metadataURL, nil)
+	if err != nil {
+		return nil, caos_errs.ThrowInvalidArgument(err, "COMMAND-Ik3oh", "Errors.IDPConfig.SAML.MetadataInvalid")
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, caos_errs.ThrowPreconditionFailed(err, "COMMAND-ahZ0e", "Errors.IDPConfig.SAML.MetadataNotReachable")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-gaeP4", "Errors.IDPConfig.SAML.MetadataNotReachable")
+	}
+	metadata, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, caos_errs.ThrowPreconditionFailed(err, "COMMAND-Eeri1", "Errors.IDPConfig.SAML.MetadataNotReachable")
+	}
+	return bytes.TrimSpace(metadata), nil
+}
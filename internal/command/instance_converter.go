@@ -41,6 +41,7 @@ func writeModelToLoginPolicy(wm *LoginPolicyWriteModel) *domain.LoginPolicy {
 		MFAInitSkipLifetime:        wm.MFAInitSkipLifetime,
 		SecondFactorCheckLifetime:  wm.SecondFactorCheckLifetime,
 		MultiFactorCheckLifetime:   wm.MultiFactorCheckLifetime,
+		RiskRules:                  wm.RiskRules,
 	}
 }
 
@@ -117,16 +118,18 @@ func writeModelToLockoutPolicy(wm *LockoutPolicyWriteModel) *domain.LockoutPolic
 	return &domain.LockoutPolicy{
 		ObjectRoot:          writeModelToObjectRoot(wm.WriteModel),
 		MaxPasswordAttempts: wm.MaxPasswordAttempts,
+		MaxOTPAttempts:      wm.MaxOTPAttempts,
 		ShowLockOutFailures: wm.ShowLockOutFailures,
 	}
 }
 
 func writeModelToPrivacyPolicy(wm *PrivacyPolicyWriteModel) *domain.PrivacyPolicy {
 	return &domain.PrivacyPolicy{
-		ObjectRoot:  writeModelToObjectRoot(wm.WriteModel),
-		TOSLink:     wm.TOSLink,
-		PrivacyLink: wm.PrivacyLink,
-		HelpLink:    wm.HelpLink,
+		ObjectRoot:          writeModelToObjectRoot(wm.WriteModel),
+		TOSLink:             wm.TOSLink,
+		PrivacyLink:         wm.PrivacyLink,
+		HelpLink:            wm.HelpLink,
+		DeletionGracePeriod: wm.DeletionGracePeriod,
 	}
 }
 
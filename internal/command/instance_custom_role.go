@@ -0,0 +1,150 @@
+package command
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command/preparation"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+func (c *Commands) AddCustomRole(ctx context.Context, role *domain.CustomRole) (*domain.ObjectDetails, error) {
+	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, c.prepareAddCustomRole(instanceAgg, role))
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+func (c *Commands) ChangeCustomRole(ctx context.Context, role *domain.CustomRole) (*domain.ObjectDetails, error) {
+	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, c.prepareChangeCustomRole(instanceAgg, role))
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+// RemoveCustomRole removes the role from the instance,
+// existing memberships still contain the key, but it doesn't grant any permissions anymore
+func (c *Commands) RemoveCustomRole(ctx context.Context, key string) (*domain.ObjectDetails, error) {
+	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareRemoveCustomRole(instanceAgg, key))
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+func (c *Commands) prepareAddCustomRole(a *instance.Aggregate, role *domain.CustomRole) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if err := role.IsValid(c.zitadelRoles); err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			writeModel, err := getInstanceCustomRoleWriteModel(ctx, filter, role.Key)
+			if err != nil {
+				return nil, err
+			}
+			if writeModel.State.Exists() {
+				return nil, caos_errs.ThrowAlreadyExists(nil, "INSTANCE-Cs8wq", "Errors.IAM.CustomRole.AlreadyExists")
+			}
+			return []eventstore.Command{
+				instance.NewCustomRoleAddedEvent(ctx, &a.Aggregate, role.Key, role.DisplayName, role.Permissions),
+			}, nil
+		}, nil
+	}
+}
+
+func (c *Commands) prepareChangeCustomRole(a *instance.Aggregate, role *domain.CustomRole) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if err := role.IsValid(c.zitadelRoles); err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			writeModel, err := getInstanceCustomRoleWriteModel(ctx, filter, role.Key)
+			if err != nil {
+				return nil, err
+			}
+			if !writeModel.State.Exists() {
+				return nil, caos_errs.ThrowNotFound(nil, "INSTANCE-Wq2mf", "Errors.IAM.CustomRole.NotFound")
+			}
+			if writeModel.DisplayName == role.DisplayName && reflect.DeepEqual(writeModel.Permissions, role.Permissions) {
+				return nil, caos_errs.ThrowPreconditionFailed(nil, "INSTANCE-Lm29s", "Errors.IAM.CustomRole.NotChanged")
+			}
+			return []eventstore.Command{
+				instance.NewCustomRoleChangedEvent(ctx, &a.Aggregate, role.Key, role.DisplayName, role.Permissions),
+			}, nil
+		}, nil
+	}
+}
+
+func prepareRemoveCustomRole(a *instance.Aggregate, key string) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if key == "" {
+			return nil, caos_errs.ThrowInvalidArgument(nil, "INSTANCE-Np3ks", "Errors.IAM.CustomRole.KeyInvalid")
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			writeModel, err := getInstanceCustomRoleWriteModel(ctx, filter, key)
+			if err != nil {
+				return nil, err
+			}
+			if !writeModel.State.Exists() {
+				return nil, caos_errs.ThrowNotFound(nil, "INSTANCE-Xk20s", "Errors.IAM.CustomRole.NotFound")
+			}
+			return []eventstore.Command{
+				instance.NewCustomRoleRemovedEvent(ctx, &a.Aggregate, key),
+			}, nil
+		}, nil
+	}
+}
+
+func getInstanceCustomRoleWriteModel(ctx context.Context, filter preparation.FilterToQueryReducer, key string) (*InstanceCustomRoleWriteModel, error) {
+	writeModel := NewInstanceCustomRoleWriteModel(ctx, key)
+	events, err := filter(ctx, writeModel.Query())
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return writeModel, nil
+	}
+	writeModel.AppendEvents(events...)
+	err = writeModel.Reduce()
+	return writeModel, err
+}
+
+// checkForInvalidMemberRoles returns the roles which are neither one of the zitadelRoles nor a custom role of the instance.
+// The custom roles are only filtered if any role is not part of the zitadelRoles.
+func checkForInvalidMemberRoles(ctx context.Context, filter preparation.FilterToQueryReducer, roles []string, rolePrefix string, zitadelRoles []authz.RoleMapping) ([]string, error) {
+	invalidRoles := domain.CheckForInvalidRoles(roles, rolePrefix, zitadelRoles)
+	if len(invalidRoles) == 0 {
+		return nil, nil
+	}
+	readModel := NewInstanceCustomRolesReadModel(ctx)
+	events, err := filter(ctx, readModel.Query())
+	if err != nil {
+		return nil, err
+	}
+	readModel.AppendEvents(events...)
+	if err = readModel.Reduce(); err != nil {
+		return nil, err
+	}
+	return domain.CheckForInvalidRoles(invalidRoles, rolePrefix, readModel.Roles), nil
+}
@@ -0,0 +1,131 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+type InstanceCustomRoleWriteModel struct {
+	eventstore.WriteModel
+
+	Key         string
+	DisplayName string
+	Permissions []string
+	State       domain.CustomRoleState
+}
+
+func NewInstanceCustomRoleWriteModel(ctx context.Context, key string) *InstanceCustomRoleWriteModel {
+	return &InstanceCustomRoleWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   authz.GetInstance(ctx).InstanceID(),
+			ResourceOwner: authz.GetInstance(ctx).InstanceID(),
+		},
+		Key: key,
+	}
+}
+
+func (wm *InstanceCustomRoleWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.CustomRoleAddedEvent:
+			if e.Key == wm.Key {
+				wm.WriteModel.AppendEvents(e)
+			}
+		case *instance.CustomRoleChangedEvent:
+			if e.Key == wm.Key {
+				wm.WriteModel.AppendEvents(e)
+			}
+		case *instance.CustomRoleRemovedEvent:
+			if e.Key == wm.Key {
+				wm.WriteModel.AppendEvents(e)
+			}
+		}
+	}
+}
+
+func (wm *InstanceCustomRoleWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *instance.CustomRoleAddedEvent:
+			wm.DisplayName = e.DisplayName
+			wm.Permissions = e.Permissions
+			wm.State = domain.CustomRoleStateActive
+		case *instance.CustomRoleChangedEvent:
+			wm.DisplayName = e.DisplayName
+			wm.Permissions = e.Permissions
+		case *instance.CustomRoleRemovedEvent:
+			wm.State = domain.CustomRoleStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *InstanceCustomRoleWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			instance.CustomRoleAddedEventType,
+			instance.CustomRoleChangedEventType,
+			instance.CustomRoleRemovedEventType,
+		).
+		Builder()
+}
+
+// InstanceCustomRolesReadModel contains all active custom roles of the instance
+type InstanceCustomRolesReadModel struct {
+	eventstore.WriteModel
+
+	Roles []authz.RoleMapping
+}
+
+func NewInstanceCustomRolesReadModel(ctx context.Context) *InstanceCustomRolesReadModel {
+	return &InstanceCustomRolesReadModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   authz.GetInstance(ctx).InstanceID(),
+			ResourceOwner: authz.GetInstance(ctx).InstanceID(),
+		},
+	}
+}
+
+func (rm *InstanceCustomRolesReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *instance.CustomRoleAddedEvent:
+			rm.Roles = append(rm.Roles, authz.RoleMapping{Role: e.Key, Permissions: e.Permissions})
+		case *instance.CustomRoleChangedEvent:
+			for i := range rm.Roles {
+				if rm.Roles[i].Role == e.Key {
+					rm.Roles[i].Permissions = e.Permissions
+				}
+			}
+		case *instance.CustomRoleRemovedEvent:
+			for i := len(rm.Roles) - 1; i >= 0; i-- {
+				if rm.Roles[i].Role == e.Key {
+					rm.Roles = append(rm.Roles[:i], rm.Roles[i+1:]...)
+				}
+			}
+		}
+	}
+	return rm.WriteModel.Reduce()
+}
+
+func (rm *InstanceCustomRolesReadModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(rm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(rm.AggregateID).
+		EventTypes(
+			instance.CustomRoleAddedEventType,
+			instance.CustomRoleChangedEventType,
+			instance.CustomRoleRemovedEventType,
+		).
+		Builder()
+}
@@ -42,6 +42,9 @@ func (c *Commands) AddIDPProviderToDefaultLoginPolicy(ctx context.Context, idpPr
 	}
 
 	_, err = c.getInstanceIDPConfigByID(ctx, idpProvider.IDPConfigID)
+	if caos_errs.IsNotFound(err) {
+		_, err = c.getInstanceIDPTemplateByID(ctx, idpProvider.IDPConfigID)
+	}
 	if err != nil {
 		return nil, caos_errs.ThrowPreconditionFailed(err, "INSTANCE-m8fsd", "Errors.IDPConfig.NotExisting")
 	}
@@ -194,6 +197,19 @@ func (c *Commands) RemoveMultiFactorFromDefaultLoginPolicy(ctx context.Context,
 	return writeModelToObjectDetails(&multiFactorModel.WriteModel), nil
 }
 
+func (c *Commands) SetDefaultLoginPolicyRiskRules(ctx context.Context, rules domain.LoginPolicyRiskRules) (*domain.ObjectDetails, error) {
+	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareSetDefaultLoginPolicyRiskRules(instanceAgg, rules))
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
 func (c *Commands) defaultLoginPolicyWriteModelByID(ctx context.Context, writeModel *InstanceLoginPolicyWriteModel) (err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -254,6 +270,29 @@ func prepareChangeDefaultLoginPolicy(a *instance.Aggregate, policy *ChangeLoginP
 	}
 }
 
+func prepareSetDefaultLoginPolicyRiskRules(a *instance.Aggregate, rules domain.LoginPolicyRiskRules) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if !rules.IsValid() {
+			return nil, caos_errs.ThrowInvalidArgument(nil, "INSTANCE-Rr2ds", "Errors.IAM.LoginPolicy.IPRangeInvalid")
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			wm := NewInstanceLoginPolicyWriteModel(ctx)
+			if err := queryAndReduce(ctx, filter, wm); err != nil {
+				return nil, err
+			}
+			if !wm.State.Exists() {
+				return nil, caos_errs.ThrowNotFound(nil, "INSTANCE-Rr3fg", "Errors.IAM.LoginPolicy.NotFound")
+			}
+			if wm.RiskRules.Equal(rules) {
+				return nil, caos_errs.ThrowPreconditionFailed(nil, "INSTANCE-Rr4hs", "Errors.IAM.LoginPolicy.NotChanged")
+			}
+			return []eventstore.Command{
+				instance.NewLoginPolicyRiskRulesSetEvent(ctx, &a.Aggregate, rules.TrustedIPRanges, rules.MFAOnNewUserAgent, rules.MFAOnCountryChange),
+			}, nil
+		}, nil
+	}
+}
+
 func prepareAddDefaultLoginPolicy(
 	a *instance.Aggregate,
 	allowUsernamePassword bool,
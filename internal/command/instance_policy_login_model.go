@@ -34,6 +34,8 @@ func (wm *InstanceLoginPolicyWriteModel) AppendEvents(events ...eventstore.Event
 			wm.LoginPolicyWriteModel.AppendEvents(&e.LoginPolicyAddedEvent)
 		case *instance.LoginPolicyChangedEvent:
 			wm.LoginPolicyWriteModel.AppendEvents(&e.LoginPolicyChangedEvent)
+		case *instance.LoginPolicyRiskRulesSetEvent:
+			wm.LoginPolicyWriteModel.AppendEvents(&e.RiskRulesSetEvent)
 		}
 	}
 }
@@ -54,7 +56,8 @@ func (wm *InstanceLoginPolicyWriteModel) Query() *eventstore.SearchQueryBuilder
 		AggregateIDs(wm.LoginPolicyWriteModel.AggregateID).
 		EventTypes(
 			instance.LoginPolicyAddedEventType,
-			instance.LoginPolicyChangedEventType).
+			instance.LoginPolicyChangedEventType,
+			instance.LoginPolicyRiskRulesSetEventType).
 		Builder()
 }
 
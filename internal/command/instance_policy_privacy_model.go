@@ -2,6 +2,7 @@ package command
 
 import (
 	"context"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -57,6 +58,7 @@ func (wm *InstancePrivacyPolicyWriteModel) NewChangedEvent(
 	tosLink,
 	privacyLink,
 	helpLink string,
+	deletionGracePeriod time.Duration,
 ) (*instance.PrivacyPolicyChangedEvent, bool) {
 
 	changes := make([]policy.PrivacyPolicyChanges, 0)
@@ -69,6 +71,9 @@ func (wm *InstancePrivacyPolicyWriteModel) NewChangedEvent(
 	if wm.HelpLink != helpLink {
 		changes = append(changes, policy.ChangeHelpLink(helpLink))
 	}
+	if wm.DeletionGracePeriod != deletionGracePeriod {
+		changes = append(changes, policy.ChangeDeletionGracePeriod(deletionGracePeriod))
+	}
 	if len(changes) == 0 {
 		return nil, false
 	}
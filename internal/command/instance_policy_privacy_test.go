@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -22,10 +23,11 @@ func TestCommandSide_AddDefaultPrivacyPolicy(t *testing.T) {
 		eventstore *eventstore.Eventstore
 	}
 	type args struct {
-		ctx         context.Context
-		tosLink     string
-		privacyLink string
-		helpLink    string
+		ctx                 context.Context
+		tosLink             string
+		privacyLink         string
+		helpLink            string
+		deletionGracePeriod time.Duration
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -49,6 +51,7 @@ func TestCommandSide_AddDefaultPrivacyPolicy(t *testing.T) {
 								"TOSLink",
 								"PrivacyLink",
 								"HelpLink",
+								720*time.Hour,
 							),
 						),
 					),
@@ -79,6 +82,7 @@ func TestCommandSide_AddDefaultPrivacyPolicy(t *testing.T) {
 									"TOSLink",
 									"PrivacyLink",
 									"HelpLink",
+									720*time.Hour,
 								),
 							),
 						},
@@ -86,10 +90,11 @@ func TestCommandSide_AddDefaultPrivacyPolicy(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
-				tosLink:     "TOSLink",
-				privacyLink: "PrivacyLink",
-				helpLink:    "HelpLink",
+				ctx:                 authz.WithInstanceID(context.Background(), "INSTANCE"),
+				tosLink:             "TOSLink",
+				privacyLink:         "PrivacyLink",
+				helpLink:            "HelpLink",
+				deletionGracePeriod: 720 * time.Hour,
 			},
 			res: res{
 				want: &domain.ObjectDetails{
@@ -112,6 +117,7 @@ func TestCommandSide_AddDefaultPrivacyPolicy(t *testing.T) {
 									"",
 									"",
 									"",
+									0,
 								),
 							),
 						},
@@ -136,7 +142,7 @@ func TestCommandSide_AddDefaultPrivacyPolicy(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.AddDefaultPrivacyPolicy(tt.args.ctx, tt.args.tosLink, tt.args.privacyLink, tt.args.helpLink)
+			got, err := r.AddDefaultPrivacyPolicy(tt.args.ctx, tt.args.tosLink, tt.args.privacyLink, tt.args.helpLink, tt.args.deletionGracePeriod)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -179,9 +185,10 @@ func TestCommandSide_ChangeDefaultPrivacyPolicy(t *testing.T) {
 			args: args{
 				ctx: context.Background(),
 				policy: &domain.PrivacyPolicy{
-					TOSLink:     "TOSLink",
-					PrivacyLink: "PrivacyLink",
-					HelpLink:    "HelpLink",
+					TOSLink:             "TOSLink",
+					PrivacyLink:         "PrivacyLink",
+					HelpLink:            "HelpLink",
+					DeletionGracePeriod: 720 * time.Hour,
 				},
 			},
 			res: res{
@@ -200,6 +207,7 @@ func TestCommandSide_ChangeDefaultPrivacyPolicy(t *testing.T) {
 								"TOSLink",
 								"PrivacyLink",
 								"HelpLink",
+								720*time.Hour,
 							),
 						),
 					),
@@ -208,9 +216,10 @@ func TestCommandSide_ChangeDefaultPrivacyPolicy(t *testing.T) {
 			args: args{
 				ctx: context.Background(),
 				policy: &domain.PrivacyPolicy{
-					TOSLink:     "TOSLink",
-					PrivacyLink: "PrivacyLink",
-					HelpLink:    "HelpLink",
+					TOSLink:             "TOSLink",
+					PrivacyLink:         "PrivacyLink",
+					HelpLink:            "HelpLink",
+					DeletionGracePeriod: 720 * time.Hour,
 				},
 			},
 			res: res{
@@ -229,6 +238,7 @@ func TestCommandSide_ChangeDefaultPrivacyPolicy(t *testing.T) {
 								"TOSLink",
 								"PrivacyLink",
 								"HelpLink",
+								720*time.Hour,
 							),
 						),
 					),
@@ -239,6 +249,7 @@ func TestCommandSide_ChangeDefaultPrivacyPolicy(t *testing.T) {
 									"TOSLinkChanged",
 									"PrivacyLinkChanged",
 									"HelpLinkChanged",
+									1440*time.Hour,
 								),
 							),
 						},
@@ -248,9 +259,10 @@ func TestCommandSide_ChangeDefaultPrivacyPolicy(t *testing.T) {
 			args: args{
 				ctx: context.Background(),
 				policy: &domain.PrivacyPolicy{
-					TOSLink:     "TOSLinkChanged",
-					PrivacyLink: "PrivacyLinkChanged",
-					HelpLink:    "HelpLinkChanged",
+					TOSLink:             "TOSLinkChanged",
+					PrivacyLink:         "PrivacyLinkChanged",
+					HelpLink:            "HelpLinkChanged",
+					DeletionGracePeriod: 1440 * time.Hour,
 				},
 			},
 			res: res{
@@ -259,9 +271,10 @@ func TestCommandSide_ChangeDefaultPrivacyPolicy(t *testing.T) {
 						AggregateID:   "INSTANCE",
 						ResourceOwner: "INSTANCE",
 					},
-					TOSLink:     "TOSLinkChanged",
-					PrivacyLink: "PrivacyLinkChanged",
-					HelpLink:    "HelpLinkChanged",
+					TOSLink:             "TOSLinkChanged",
+					PrivacyLink:         "PrivacyLinkChanged",
+					HelpLink:            "HelpLinkChanged",
+					DeletionGracePeriod: 1440 * time.Hour,
 				},
 			},
 		},
@@ -285,13 +298,14 @@ func TestCommandSide_ChangeDefaultPrivacyPolicy(t *testing.T) {
 	}
 }
 
-func newDefaultPrivacyPolicyChangedEvent(ctx context.Context, tosLink, privacyLink, helpLink string) *instance.PrivacyPolicyChangedEvent {
+func newDefaultPrivacyPolicyChangedEvent(ctx context.Context, tosLink, privacyLink, helpLink string, deletionGracePeriod time.Duration) *instance.PrivacyPolicyChangedEvent {
 	event, _ := instance.NewPrivacyPolicyChangedEvent(ctx,
 		&instance.NewAggregate("INSTANCE").Aggregate,
 		[]policy.PrivacyPolicyChanges{
 			policy.ChangeTOSLink(tosLink),
 			policy.ChangePrivacyLink(privacyLink),
 			policy.ChangeHelpLink(helpLink),
+			policy.ChangeDeletionGracePeriod(deletionGracePeriod),
 		},
 	)
 	return event
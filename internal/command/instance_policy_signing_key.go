@@ -0,0 +1,107 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command/preparation"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+func (c *Commands) AddDefaultSigningKeyPolicy(ctx context.Context, policy *domain.SigningKeyPolicy) (*domain.ObjectDetails, error) {
+	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareAddDefaultSigningKeyPolicy(instanceAgg, policy))
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+func (c *Commands) ChangeDefaultSigningKeyPolicy(ctx context.Context, policy *domain.SigningKeyPolicy) (*domain.ObjectDetails, error) {
+	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareChangeDefaultSigningKeyPolicy(instanceAgg, policy))
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+func prepareAddDefaultSigningKeyPolicy(a *instance.Aggregate, policy *domain.SigningKeyPolicy) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if err := policy.IsValid(); err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			writeModel, err := getInstanceSigningKeyPolicyWriteModel(ctx, filter)
+			if err != nil {
+				return nil, err
+			}
+			if writeModel.State == domain.PolicyStateActive {
+				return nil, caos_errs.ThrowAlreadyExists(nil, "INSTANCE-Ks8wm", "Errors.IAM.SigningKeyPolicy.AlreadyExists")
+			}
+			return []eventstore.Command{
+				instance.NewSigningKeyPolicyAddedEvent(
+					ctx,
+					&a.Aggregate,
+					policy.Algorithm,
+					policy.KeySizeOfAlgorithm(),
+					policy.KeyLifetime,
+					policy.OverlapPeriod,
+					policy.PrepublicationWindow,
+				),
+			}, nil
+		}, nil
+	}
+}
+
+func prepareChangeDefaultSigningKeyPolicy(a *instance.Aggregate, policy *domain.SigningKeyPolicy) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if err := policy.IsValid(); err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			writeModel, err := getInstanceSigningKeyPolicyWriteModel(ctx, filter)
+			if err != nil {
+				return nil, err
+			}
+			if !writeModel.State.Exists() {
+				return nil, caos_errs.ThrowNotFound(nil, "INSTANCE-Ms92k", "Errors.IAM.SigningKeyPolicy.NotFound")
+			}
+			changedEvent, hasChanged, err := writeModel.NewChangedEvent(ctx, &a.Aggregate, policy)
+			if err != nil {
+				return nil, err
+			}
+			if !hasChanged {
+				return nil, caos_errs.ThrowPreconditionFailed(nil, "INSTANCE-Nw8sl", "Errors.IAM.SigningKeyPolicy.NotChanged")
+			}
+			return []eventstore.Command{
+				changedEvent,
+			}, nil
+		}, nil
+	}
+}
+
+func getInstanceSigningKeyPolicyWriteModel(ctx context.Context, filter preparation.FilterToQueryReducer) (*InstanceSigningKeyPolicyWriteModel, error) {
+	writeModel := NewInstanceSigningKeyPolicyWriteModel(ctx)
+	events, err := filter(ctx, writeModel.Query())
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return writeModel, nil
+	}
+	writeModel.AppendEvents(events...)
+	err = writeModel.Reduce()
+	return writeModel, err
+}
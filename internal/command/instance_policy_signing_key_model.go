@@ -0,0 +1,105 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+type InstanceSigningKeyPolicyWriteModel struct {
+	eventstore.WriteModel
+
+	Algorithm            string
+	KeySize              int
+	KeyLifetime          time.Duration
+	OverlapPeriod        time.Duration
+	PrepublicationWindow time.Duration
+	State                domain.PolicyState
+}
+
+func NewInstanceSigningKeyPolicyWriteModel(ctx context.Context) *InstanceSigningKeyPolicyWriteModel {
+	return &InstanceSigningKeyPolicyWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   authz.GetInstance(ctx).InstanceID(),
+			ResourceOwner: authz.GetInstance(ctx).InstanceID(),
+		},
+	}
+}
+
+func (wm *InstanceSigningKeyPolicyWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *instance.SigningKeyPolicyAddedEvent:
+			wm.Algorithm = e.Algorithm
+			wm.KeySize = e.KeySize
+			wm.KeyLifetime = e.KeyLifetime
+			wm.OverlapPeriod = e.OverlapPeriod
+			wm.PrepublicationWindow = e.PrepublicationWindow
+			wm.State = domain.PolicyStateActive
+		case *instance.SigningKeyPolicyChangedEvent:
+			if e.Algorithm != nil {
+				wm.Algorithm = *e.Algorithm
+			}
+			if e.KeySize != nil {
+				wm.KeySize = *e.KeySize
+			}
+			if e.KeyLifetime != nil {
+				wm.KeyLifetime = *e.KeyLifetime
+			}
+			if e.OverlapPeriod != nil {
+				wm.OverlapPeriod = *e.OverlapPeriod
+			}
+			if e.PrepublicationWindow != nil {
+				wm.PrepublicationWindow = *e.PrepublicationWindow
+			}
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *InstanceSigningKeyPolicyWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			instance.SigningKeyPolicyAddedEventType,
+			instance.SigningKeyPolicyChangedEventType).
+		Builder()
+}
+
+func (wm *InstanceSigningKeyPolicyWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	policy *domain.SigningKeyPolicy,
+) (*instance.SigningKeyPolicyChangedEvent, bool, error) {
+	changes := make([]instance.SigningKeyPolicyChanges, 0, 5)
+	if wm.Algorithm != policy.Algorithm {
+		changes = append(changes, instance.ChangeSigningKeyPolicyAlgorithm(policy.Algorithm))
+	}
+	if wm.KeySize != policy.KeySizeOfAlgorithm() {
+		changes = append(changes, instance.ChangeSigningKeyPolicyKeySize(policy.KeySizeOfAlgorithm()))
+	}
+	if wm.KeyLifetime != policy.KeyLifetime {
+		changes = append(changes, instance.ChangeSigningKeyPolicyKeyLifetime(policy.KeyLifetime))
+	}
+	if wm.OverlapPeriod != policy.OverlapPeriod {
+		changes = append(changes, instance.ChangeSigningKeyPolicyOverlapPeriod(policy.OverlapPeriod))
+	}
+	if wm.PrepublicationWindow != policy.PrepublicationWindow {
+		changes = append(changes, instance.ChangeSigningKeyPolicyPrepublicationWindow(policy.PrepublicationWindow))
+	}
+	if len(changes) == 0 {
+		return nil, false, nil
+	}
+	changeEvent, err := instance.NewSigningKeyPolicyChangedEvent(ctx, aggregate, changes)
+	if err != nil {
+		return nil, false, err
+	}
+	return changeEvent, true, nil
+}
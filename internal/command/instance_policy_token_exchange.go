@@ -0,0 +1,100 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/command/preparation"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+func (c *Commands) AddDefaultTokenExchangePolicy(ctx context.Context, resourceOwner string, delegationActors, impersonationActors []string) (*domain.ObjectDetails, error) {
+	instanceAgg := instance.NewAggregate(resourceOwner)
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareAddDefaultTokenExchangePolicy(instanceAgg, delegationActors, impersonationActors))
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+func (c *Commands) ChangeDefaultTokenExchangePolicy(ctx context.Context, resourceOwner string, delegationActors, impersonationActors []string) (*domain.ObjectDetails, error) {
+	instanceAgg := instance.NewAggregate(resourceOwner)
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareChangeDefaultTokenExchangePolicy(instanceAgg, delegationActors, impersonationActors))
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+func prepareAddDefaultTokenExchangePolicy(
+	a *instance.Aggregate,
+	delegationActors,
+	impersonationActors []string,
+) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			writeModel := NewInstanceTokenExchangePolicyWriteModel(ctx)
+			events, err := filter(ctx, writeModel.Query())
+			if err != nil {
+				return nil, err
+			}
+			writeModel.AppendEvents(events...)
+			if err = writeModel.Reduce(); err != nil {
+				return nil, err
+			}
+			if writeModel.State == domain.PolicyStateActive {
+				return nil, caos_errs.ThrowAlreadyExists(nil, "INSTANCE-2u2xk", "Errors.IAM.TokenExchangePolicy.AlreadyExists")
+			}
+			if err = checkTokenExchangeActors(ctx, filter, delegationActors, impersonationActors); err != nil {
+				return nil, err
+			}
+			return []eventstore.Command{
+				instance.NewTokenExchangePolicyAddedEvent(ctx, &a.Aggregate, delegationActors, impersonationActors),
+			}, nil
+		}, nil
+	}
+}
+
+func prepareChangeDefaultTokenExchangePolicy(
+	a *instance.Aggregate,
+	delegationActors,
+	impersonationActors []string,
+) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			writeModel := NewInstanceTokenExchangePolicyWriteModel(ctx)
+			events, err := filter(ctx, writeModel.Query())
+			if err != nil {
+				return nil, err
+			}
+			writeModel.AppendEvents(events...)
+			if err = writeModel.Reduce(); err != nil {
+				return nil, err
+			}
+
+			if writeModel.State == domain.PolicyStateUnspecified || writeModel.State == domain.PolicyStateRemoved {
+				return nil, caos_errs.ThrowNotFound(nil, "INSTANCE-qz44C", "Errors.IAM.TokenExchangePolicy.NotFound")
+			}
+			change, hasChanged := writeModel.NewChangedEvent(ctx, &a.Aggregate, delegationActors, impersonationActors)
+			if !hasChanged {
+				return nil, caos_errs.ThrowPreconditionFailed(nil, "INSTANCE-XiScM", "Errors.IAM.TokenExchangePolicy.NotChanged")
+			}
+			if err = checkTokenExchangeActors(ctx, filter, delegationActors, impersonationActors); err != nil {
+				return nil, err
+			}
+			return []eventstore.Command{
+				change,
+			}, nil
+		}, nil
+	}
+}
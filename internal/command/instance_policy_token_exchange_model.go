@@ -0,0 +1,76 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+type InstanceTokenExchangePolicyWriteModel struct {
+	TokenExchangePolicyWriteModel
+}
+
+func NewInstanceTokenExchangePolicyWriteModel(ctx context.Context) *InstanceTokenExchangePolicyWriteModel {
+	return &InstanceTokenExchangePolicyWriteModel{
+		TokenExchangePolicyWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   authz.GetInstance(ctx).InstanceID(),
+				ResourceOwner: authz.GetInstance(ctx).InstanceID(),
+			},
+		},
+	}
+}
+
+func (wm *InstanceTokenExchangePolicyWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.TokenExchangePolicyAddedEvent:
+			wm.TokenExchangePolicyWriteModel.AppendEvents(&e.TokenExchangePolicyAddedEvent)
+		case *instance.TokenExchangePolicyChangedEvent:
+			wm.TokenExchangePolicyWriteModel.AppendEvents(&e.TokenExchangePolicyChangedEvent)
+		}
+	}
+}
+
+func (wm *InstanceTokenExchangePolicyWriteModel) Reduce() error {
+	return wm.TokenExchangePolicyWriteModel.Reduce()
+}
+
+func (wm *InstanceTokenExchangePolicyWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.TokenExchangePolicyWriteModel.AggregateID).
+		EventTypes(
+			instance.TokenExchangePolicyAddedEventType,
+			instance.TokenExchangePolicyChangedEventType).
+		Builder()
+}
+
+func (wm *InstanceTokenExchangePolicyWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	delegationActors,
+	impersonationActors []string,
+) (*instance.TokenExchangePolicyChangedEvent, bool) {
+
+	changes := make([]policy.TokenExchangePolicyChanges, 0)
+	if tokenExchangeActorsChanged(wm.DelegationActors, delegationActors) {
+		changes = append(changes, policy.ChangeDelegationActors(delegationActors))
+	}
+	if tokenExchangeActorsChanged(wm.ImpersonationActors, impersonationActors) {
+		changes = append(changes, policy.ChangeImpersonationActors(impersonationActors))
+	}
+	if len(changes) == 0 {
+		return nil, false
+	}
+	changedEvent, err := instance.NewTokenExchangePolicyChangedEvent(ctx, aggregate, changes)
+	if err != nil {
+		return nil, false
+	}
+	return changedEvent, true
+}
@@ -14,8 +14,16 @@ import (
 	"github.com/zitadel/zitadel/internal/repository/keypair"
 )
 
-func (c *Commands) GenerateSigningKeyPair(ctx context.Context, algorithm string) error {
-	privateCrypto, publicCrypto, err := crypto.GenerateEncryptedKeyPair(c.keySize, c.keyAlgorithm)
+// GenerateSigningKeyPair generates a signing key pair according to the signing key policy of the instance,
+// if there is none, the provided algorithm and the key config are used.
+// The key will be used for signing from the activation (but not before now) until the end of its lifetime,
+// the public key is published right away and until the end of the overlap period.
+func (c *Commands) GenerateSigningKeyPair(ctx context.Context, algorithm string, activation time.Time) error {
+	policy, err := c.signingKeyPolicy(ctx, algorithm)
+	if err != nil {
+		return err
+	}
+	privateCrypto, publicCrypto, err := crypto.GenerateEncryptedSigningKeyPair(policy.Algorithm, policy.KeySizeOfAlgorithm(), c.keyAlgorithm)
 	if err != nil {
 		return err
 	}
@@ -24,8 +32,12 @@ func (c *Commands) GenerateSigningKeyPair(ctx context.Context, algorithm string)
 		return err
 	}
 
-	privateKeyExp := time.Now().UTC().Add(c.privateKeyLifetime)
-	publicKeyExp := time.Now().UTC().Add(c.publicKeyLifetime)
+	now := time.Now().UTC()
+	if activation.Before(now) {
+		activation = now
+	}
+	privateKeyExp := activation.UTC().Add(policy.KeyLifetime)
+	publicKeyExp := privateKeyExp.Add(policy.OverlapPeriod)
 
 	keyPairWriteModel := NewKeyPairWriteModel(keyID, authz.GetInstance(ctx).InstanceID())
 	keyAgg := KeyPairAggregateFromWriteModel(&keyPairWriteModel.WriteModel)
@@ -33,12 +45,34 @@ func (c *Commands) GenerateSigningKeyPair(ctx context.Context, algorithm string)
 		ctx,
 		keyAgg,
 		domain.KeyUsageSigning,
-		algorithm,
+		policy.Algorithm,
 		privateCrypto, publicCrypto,
 		privateKeyExp, publicKeyExp))
 	return err
 }
 
+func (c *Commands) signingKeyPolicy(ctx context.Context, defaultAlgorithm string) (*domain.SigningKeyPolicy, error) {
+	writeModel, err := getInstanceSigningKeyPolicyWriteModel(ctx, c.eventstore.Filter)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() {
+		return &domain.SigningKeyPolicy{
+			Algorithm:     defaultAlgorithm,
+			KeySize:       c.keySize,
+			KeyLifetime:   c.privateKeyLifetime,
+			OverlapPeriod: c.publicKeyLifetime - c.privateKeyLifetime,
+		}, nil
+	}
+	return &domain.SigningKeyPolicy{
+		Algorithm:            writeModel.Algorithm,
+		KeySize:              writeModel.KeySize,
+		KeyLifetime:          writeModel.KeyLifetime,
+		OverlapPeriod:        writeModel.OverlapPeriod,
+		PrepublicationWindow: writeModel.PrepublicationWindow,
+	}, nil
+}
+
 func (c *Commands) GenerateSAMLCACertificate(ctx context.Context, algorithm string) error {
 	now := time.Now().UTC()
 	after := now.Add(c.certificateLifetime)
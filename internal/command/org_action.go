@@ -4,6 +4,7 @@ import (
 	"context"
 	"sort"
 
+	"github.com/zitadel/zitadel/internal/actions/script"
 	"github.com/zitadel/zitadel/internal/domain"
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -27,6 +28,9 @@ func (c *Commands) AddAction(ctx context.Context, addAction *domain.Action, reso
 	if !addAction.IsValid() {
 		return "", nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-eg2gf", "Errors.Action.Invalid")
 	}
+	if err := script.Validate(addAction.Script, addAction.Name); err != nil {
+		return "", nil, err
+	}
 
 	actionID, err := c.idGenerator.Next()
 	if err != nil {
@@ -63,6 +67,9 @@ func (c *Commands) ChangeAction(ctx context.Context, actionChange *domain.Action
 	if !actionChange.IsValid() || actionChange.AggregateID == "" {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Df2f3", "Errors.Action.Invalid")
 	}
+	if err := script.Validate(actionChange.Script, actionChange.Name); err != nil {
+		return nil, err
+	}
 
 	existingAction, err := c.getActionWriteModelByID(ctx, actionChange.AggregateID, resourceOwner)
 	if err != nil {
@@ -94,6 +101,46 @@ func (c *Commands) ChangeAction(ctx context.Context, actionChange *domain.Action
 	return writeModelToObjectDetails(&existingAction.WriteModel), nil
 }
 
+// RollbackAction changes the action back to the name, script, timeout and allowed to fail of the version,
+// which results in a new version of the action
+func (c *Commands) RollbackAction(ctx context.Context, actionID string, version uint64, resourceOwner string) (*domain.ObjectDetails, error) {
+	if actionID == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Uu0ae", "Errors.IDMissing")
+	}
+
+	existingAction, err := c.getActionVersionWriteModelByID(ctx, actionID, resourceOwner, version)
+	if err != nil {
+		return nil, err
+	}
+	if !existingAction.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-ooH7a", "Errors.Action.NotFound")
+	}
+	if !existingAction.VersionExists {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Xie9u", "Errors.Action.Version.NotFound")
+	}
+
+	actionAgg := ActionAggregateFromWriteModel(&existingAction.WriteModel)
+	changedEvent, err := existingAction.NewChangedEvent(
+		ctx,
+		actionAgg,
+		existingAction.VersionName,
+		existingAction.VersionScript,
+		existingAction.VersionTimeout,
+		existingAction.VersionAllowedToFail)
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingAction, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingAction.WriteModel), nil
+}
+
 func (c *Commands) DeactivateAction(ctx context.Context, actionID string, resourceOwner string) (*domain.ObjectDetails, error) {
 	if actionID == "" || resourceOwner == "" {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-DAhk5", "Errors.IDMissing")
@@ -236,6 +283,15 @@ func (c *Commands) getActionWriteModelByID(ctx context.Context, actionID string,
 	return actionWriteModel, nil
 }
 
+func (c *Commands) getActionVersionWriteModelByID(ctx context.Context, actionID, resourceOwner string, version uint64) (*ActionVersionWriteModel, error) {
+	actionWriteModel := NewActionVersionWriteModel(actionID, resourceOwner, version)
+	err := c.eventstore.FilterToQueryReducer(ctx, actionWriteModel)
+	if err != nil {
+		return nil, err
+	}
+	return actionWriteModel, nil
+}
+
 func (c *Commands) getActionsByOrgWriteModelByID(ctx context.Context, resourceOwner string) (*ActionsListByOrgModel, error) {
 	actionWriteModel := NewActionsListByOrgModel(resourceOwner)
 	err := c.eventstore.FilterToQueryReducer(ctx, actionWriteModel)
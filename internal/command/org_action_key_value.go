@@ -0,0 +1,76 @@
+package command
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+// ActionKeyValueUsageQuerier provides the usage of the key-value storage of the actions of an organisation,
+// it's read from the projection, as replaying the whole history of the storage on every change does not scale
+type ActionKeyValueUsageQuerier interface {
+	ActionKeyValueUsage(ctx context.Context, orgID, key string) (*domain.ActionKeyValueUsage, error)
+}
+
+// SetActionKeyValue sets the value of the key in the key-value storage of the actions of the organisation.
+// The quotas (key length, value size, number of entries and total size) of the storage are checked.
+// The number of entries and the total size are soft limits: they're checked against the projection,
+// which might not contain the latest (or concurrent) changes, so they can be exceeded by a few entries.
+func (c *Commands) SetActionKeyValue(ctx context.Context, key string, value []byte, resourceOwner string) (*domain.ObjectDetails, error) {
+	quota := c.actionsKeyValueQuota
+	if key == "" || resourceOwner == "" || exceeds(len(key), quota.MaxKeyLength) {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-iu8Ae", "Errors.Action.KeyValue.KeyInvalid")
+	}
+	if len(value) == 0 {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Zah2u", "Errors.Action.KeyValue.ValueMissing")
+	}
+	if exceeds(len(value), quota.MaxValueBytes) {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ohv2i", "Errors.Action.KeyValue.ValueTooLarge")
+	}
+	usage, err := c.actionKeyValueUsage.ActionKeyValueUsage(ctx, resourceOwner, key)
+	if err != nil {
+		return nil, err
+	}
+	if usage.Value != nil && bytes.Equal(usage.Value, value) {
+		return &domain.ObjectDetails{
+			Sequence:      usage.Sequence,
+			EventDate:     usage.ChangeDate,
+			ResourceOwner: resourceOwner,
+		}, nil
+	}
+	if usage.Value == nil && exceeds(usage.Entries+1, quota.MaxEntries) ||
+		exceeds(usage.TotalBytes-len(usage.Value)+len(value), quota.MaxTotalBytes) {
+		return nil, caos_errs.ThrowResourceExhausted(nil, "COMMAND-ieW8o", "Errors.Action.KeyValue.QuotaExceeded")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewActionKeyValueSetEvent(ctx, &org.NewAggregate(resourceOwner).Aggregate, key, value))
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+func (c *Commands) RemoveActionKeyValue(ctx context.Context, key, resourceOwner string) (*domain.ObjectDetails, error) {
+	if key == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Eek3a", "Errors.Action.KeyValue.KeyInvalid")
+	}
+	usage, err := c.actionKeyValueUsage.ActionKeyValueUsage(ctx, resourceOwner, key)
+	if err != nil {
+		return nil, err
+	}
+	if usage.Value == nil {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-ohB4e", "Errors.Action.KeyValue.NotFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewActionKeyValueRemovedEvent(ctx, &org.NewAggregate(resourceOwner).Aggregate, key))
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+// exceeds checks the value against the limit, a limit of 0 is unlimited
+func exceeds(value, limit int) bool {
+	return limit > 0 && value > limit
+}
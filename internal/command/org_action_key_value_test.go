@@ -0,0 +1,322 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	sd "github.com/zitadel/zitadel/internal/config/systemdefaults"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+// mockActionKeyValueUsage returns the usage, the key and organisation are checked
+type mockActionKeyValueUsage struct {
+	usage *domain.ActionKeyValueUsage
+	err   error
+}
+
+func (m *mockActionKeyValueUsage) ActionKeyValueUsage(_ context.Context, orgID, key string) (*domain.ActionKeyValueUsage, error) {
+	if orgID != "org1" || key != "key1" {
+		return nil, errors.ThrowInvalidArgument(nil, "id", "wrong key")
+	}
+	return m.usage, m.err
+}
+
+func TestCommands_SetActionKeyValue(t *testing.T) {
+	quota := sd.ActionsKeyValue{
+		MaxKeyLength:  10,
+		MaxValueBytes: 5,
+		MaxEntries:    2,
+		MaxTotalBytes: 8,
+	}
+	changeDate := time.Now()
+	type fields struct {
+		eventstore *eventstore.Eventstore
+		usage      *mockActionKeyValueUsage
+	}
+	type args struct {
+		ctx           context.Context
+		key           string
+		value         []byte
+		resourceOwner string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"key too long, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+				usage:      &mockActionKeyValueUsage{},
+			},
+			args{
+				ctx:           context.Background(),
+				key:           "keyTooLong1",
+				value:         []byte("1"),
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"value too large, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+				usage:      &mockActionKeyValueUsage{},
+			},
+			args{
+				ctx:           context.Background(),
+				key:           "key1",
+				value:         []byte(`"large"`),
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"usage query failed, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+				usage: &mockActionKeyValueUsage{
+					err: errors.ThrowInternal(nil, "id", "query failed"),
+				},
+			},
+			args{
+				ctx:           context.Background(),
+				key:           "key1",
+				value:         []byte("1"),
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsInternal,
+			},
+		},
+		{
+			"max entries, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+				usage: &mockActionKeyValueUsage{
+					usage: &domain.ActionKeyValueUsage{
+						Entries:    2,
+						TotalBytes: 2,
+					},
+				},
+			},
+			args{
+				ctx:           context.Background(),
+				key:           "key1",
+				value:         []byte("3"),
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsResourceExhausted,
+			},
+		},
+		{
+			"max total bytes, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+				usage: &mockActionKeyValueUsage{
+					usage: &domain.ActionKeyValueUsage{
+						Entries:    1,
+						TotalBytes: 5,
+					},
+				},
+			},
+			args{
+				ctx:           context.Background(),
+				key:           "key1",
+				value:         []byte("1234"),
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsResourceExhausted,
+			},
+		},
+		{
+			"unchanged, ok",
+			fields{
+				eventstore: eventstoreExpect(t),
+				usage: &mockActionKeyValueUsage{
+					usage: &domain.ActionKeyValueUsage{
+						Entries:    2,
+						TotalBytes: 8,
+						Value:      []byte("1"),
+						Sequence:   10,
+						ChangeDate: changeDate,
+					},
+				},
+			},
+			args{
+				ctx:           context.Background(),
+				key:           "key1",
+				value:         []byte("1"),
+				resourceOwner: "org1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					Sequence:      10,
+					EventDate:     changeDate,
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			"overwrite within quota, ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectPush(
+						eventPusherToEvents(
+							org.NewActionKeyValueSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"key1",
+								[]byte("54321"),
+							),
+						),
+					),
+				),
+				usage: &mockActionKeyValueUsage{
+					usage: &domain.ActionKeyValueUsage{
+						Entries:    2,
+						TotalBytes: 8,
+						Value:      []byte("12345"),
+					},
+				},
+			},
+			args{
+				ctx:           context.Background(),
+				key:           "key1",
+				value:         []byte("54321"),
+				resourceOwner: "org1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:           tt.fields.eventstore,
+				actionKeyValueUsage:  tt.fields.usage,
+				actionsKeyValueQuota: quota,
+			}
+			details, err := c.SetActionKeyValue(tt.args.ctx, tt.args.key, tt.args.value, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_RemoveActionKeyValue(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+		usage      *mockActionKeyValueUsage
+	}
+	type args struct {
+		ctx           context.Context
+		key           string
+		resourceOwner string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"not found, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+				usage: &mockActionKeyValueUsage{
+					usage: &domain.ActionKeyValueUsage{},
+				},
+			},
+			args{
+				ctx:           context.Background(),
+				key:           "key1",
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			"remove ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectPush(
+						eventPusherToEvents(
+							org.NewActionKeyValueRemovedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"key1",
+							),
+						),
+					),
+				),
+				usage: &mockActionKeyValueUsage{
+					usage: &domain.ActionKeyValueUsage{
+						Entries:    1,
+						TotalBytes: 1,
+						Value:      []byte("1"),
+					},
+				},
+			},
+			args{
+				ctx:           context.Background(),
+				key:           "key1",
+				resourceOwner: "org1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:          tt.fields.eventstore,
+				actionKeyValueUsage: tt.fields.usage,
+			}
+			details, err := c.RemoveActionKeyValue(tt.args.ctx, tt.args.key, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package command
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+const actionSecretNameMaxLength = 200
+
+// the name must be usable as identifier in javascript (ctx.secrets.name)
+var actionSecretNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// SetActionSecret sets the value of the secret, which is provided to the actions of the organisation as `ctx.secrets.<name>`.
+// The value is stored encrypted and can not be read by the API.
+func (c *Commands) SetActionSecret(ctx context.Context, name, value, resourceOwner string) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" || len(name) > actionSecretNameMaxLength || !actionSecretNameRegex.MatchString(name) {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ahz4e", "Errors.Action.Secret.NameInvalid")
+	}
+	if value == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-eiX7u", "Errors.Action.Secret.ValueMissing")
+	}
+	existing, err := c.getOrgActionSecretWriteModel(ctx, name, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := crypto.Encrypt([]byte(value), c.actionsSecretEncryption)
+	if err != nil {
+		return nil, err
+	}
+	orgAgg := OrgAggregateFromWriteModel(&existing.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewActionSecretSetEvent(ctx, orgAgg, name, encrypted))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existing, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
+
+func (c *Commands) RemoveActionSecret(ctx context.Context, name, resourceOwner string) (*domain.ObjectDetails, error) {
+	if name == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Rai9u", "Errors.Action.Secret.NameInvalid")
+	}
+	existing, err := c.getOrgActionSecretWriteModel(ctx, name, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existing.Exists {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Ooz1e", "Errors.Action.Secret.NotFound")
+	}
+	orgAgg := OrgAggregateFromWriteModel(&existing.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewActionSecretRemovedEvent(ctx, orgAgg, name))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existing, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
+
+func (c *Commands) getOrgActionSecretWriteModel(ctx context.Context, name, resourceOwner string) (*OrgActionSecretWriteModel, error) {
+	writeModel := NewOrgActionSecretWriteModel(name, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
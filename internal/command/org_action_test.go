@@ -54,6 +54,40 @@ func TestCommands_AddAction(t *testing.T) {
 				err: errors.IsErrorInvalidArgument,
 			},
 		},
+		{
+			"script invalid, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx: context.Background(),
+				addAction: &domain.Action{
+					Name:   "name",
+					Script: "function name() {",
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"function missing, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx: context.Background(),
+				addAction: &domain.Action{
+					Name:   "name",
+					Script: "function other() {};",
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
 		{
 			"unique constraint failed, error",
 			fields{
@@ -65,7 +99,7 @@ func TestCommands_AddAction(t *testing.T) {
 								action.NewAddedEvent(context.Background(),
 									&action.NewAggregate("id1", "org1").Aggregate,
 									"name",
-									"name() {};",
+									"function name() {};",
 									0,
 									false,
 								),
@@ -80,7 +114,7 @@ func TestCommands_AddAction(t *testing.T) {
 				ctx: context.Background(),
 				addAction: &domain.Action{
 					Name:   "name",
-					Script: "name() {};",
+					Script: "function name() {};",
 				},
 				resourceOwner: "org1",
 			},
@@ -98,7 +132,7 @@ func TestCommands_AddAction(t *testing.T) {
 								action.NewAddedEvent(context.Background(),
 									&action.NewAggregate("id2", "org1").Aggregate,
 									"name2",
-									"name2() {};",
+									"function name2() {};",
 									0,
 									false,
 								),
@@ -113,7 +147,7 @@ func TestCommands_AddAction(t *testing.T) {
 				ctx: context.Background(),
 				addAction: &domain.Action{
 					Name:   "name2",
-					Script: "name2() {};",
+					Script: "function name2() {};",
 				},
 				resourceOwner: "org1",
 			},
@@ -174,7 +208,27 @@ func TestCommands_ChangeAction(t *testing.T) {
 				ctx: context.Background(),
 				changeAction: &domain.Action{
 					Name:   "name",
-					Script: "name() {};",
+					Script: "function name() {};",
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"function missing, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx: context.Background(),
+				changeAction: &domain.Action{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "id1",
+					},
+					Name:   "name2",
+					Script: "function name() {};",
 				},
 				resourceOwner: "org1",
 			},
@@ -196,7 +250,7 @@ func TestCommands_ChangeAction(t *testing.T) {
 						AggregateID: "id1",
 					},
 					Name:   "name",
-					Script: "name() {};",
+					Script: "function name() {};",
 				},
 				resourceOwner: "org1",
 			},
@@ -213,7 +267,7 @@ func TestCommands_ChangeAction(t *testing.T) {
 							action.NewAddedEvent(context.Background(),
 								&action.NewAggregate("id1", "org1").Aggregate,
 								"name",
-								"name() {};",
+								"function name() {};",
 								0,
 								false,
 							),
@@ -228,7 +282,7 @@ func TestCommands_ChangeAction(t *testing.T) {
 						AggregateID: "id1",
 					},
 					Name:   "name",
-					Script: "name() {};",
+					Script: "function name() {};",
 				},
 				resourceOwner: "org1",
 			},
@@ -245,7 +299,7 @@ func TestCommands_ChangeAction(t *testing.T) {
 							action.NewAddedEvent(context.Background(),
 								&action.NewAggregate("id1", "org1").Aggregate,
 								"name",
-								"name() {};",
+								"function name() {};",
 								0,
 								false,
 							),
@@ -260,7 +314,7 @@ func TestCommands_ChangeAction(t *testing.T) {
 										&action.NewAggregate("id1", "org1").Aggregate,
 										[]action.ActionChanges{
 											action.ChangeName("name2", "name"),
-											action.ChangeScript("name2() {};"),
+											action.ChangeScript("function name2() {};"),
 										},
 									)
 									return event
@@ -279,7 +333,7 @@ func TestCommands_ChangeAction(t *testing.T) {
 						AggregateID: "id1",
 					},
 					Name:   "name2",
-					Script: "name2() {};",
+					Script: "function name2() {};",
 				},
 				resourceOwner: "org1",
 			},
@@ -296,7 +350,7 @@ func TestCommands_ChangeAction(t *testing.T) {
 							action.NewAddedEvent(context.Background(),
 								&action.NewAggregate("id1", "org1").Aggregate,
 								"name",
-								"name() {};",
+								"function name() {};",
 								0,
 								false,
 							),
@@ -310,7 +364,7 @@ func TestCommands_ChangeAction(t *testing.T) {
 										&action.NewAggregate("id1", "org1").Aggregate,
 										[]action.ActionChanges{
 											action.ChangeName("name2", "name"),
-											action.ChangeScript("name2() {};"),
+											action.ChangeScript("function name2() {};"),
 										},
 									)
 									return event
@@ -329,7 +383,7 @@ func TestCommands_ChangeAction(t *testing.T) {
 						AggregateID: "id1",
 					},
 					Name:   "name2",
-					Script: "name2() {};",
+					Script: "function name2() {};",
 				},
 				resourceOwner: "org1",
 			},
@@ -359,6 +413,198 @@ func TestCommands_ChangeAction(t *testing.T) {
 	}
 }
 
+func TestCommands_RollbackAction(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		actionID      string
+		version       uint64
+		resourceOwner string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"id missing, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:           context.Background(),
+				version:       1,
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"not found, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				actionID:      "id1",
+				version:       1,
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			"version not found, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							action.NewAddedEvent(context.Background(),
+								&action.NewAggregate("id1", "org1").Aggregate,
+								"name",
+								"function name() {};",
+								0,
+								false,
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				actionID:      "id1",
+				version:       2,
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			"current version, no changes",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							action.NewAddedEvent(context.Background(),
+								&action.NewAggregate("id1", "org1").Aggregate,
+								"name",
+								"function name() {};",
+								0,
+								false,
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				actionID:      "id1",
+				version:       1,
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			"rollback to first version, ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							action.NewAddedEvent(context.Background(),
+								&action.NewAggregate("id1", "org1").Aggregate,
+								"name",
+								"function name() {};",
+								0,
+								false,
+							),
+						),
+						eventFromEventPusher(
+							func() *action.ChangedEvent {
+								event, _ := action.NewChangedEvent(context.Background(),
+									&action.NewAggregate("id1", "org1").Aggregate,
+									[]action.ActionChanges{
+										action.ChangeScript("function name() { throw 'broken' };"),
+									},
+								)
+								return event
+							}(),
+						),
+						eventFromEventPusher(
+							func() *action.ChangedEvent {
+								event, _ := action.NewChangedEvent(context.Background(),
+									&action.NewAggregate("id1", "org1").Aggregate,
+									[]action.ActionChanges{
+										action.ChangeAllowedToFail(true),
+									},
+								)
+								return event
+							}(),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								func() *action.ChangedEvent {
+									event, _ := action.NewChangedEvent(context.Background(),
+										&action.NewAggregate("id1", "org1").Aggregate,
+										[]action.ActionChanges{
+											action.ChangeScript("function name() {};"),
+											action.ChangeAllowedToFail(false),
+										},
+									)
+									return event
+								}(),
+							),
+						},
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				actionID:      "id1",
+				version:       1,
+				resourceOwner: "org1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			details, err := c.RollbackAction(tt.args.ctx, tt.args.actionID, tt.args.version, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
 func TestCommands_DeactivateAction(t *testing.T) {
 	type fields struct {
 		eventstore *eventstore.Eventstore
@@ -417,7 +663,7 @@ func TestCommands_DeactivateAction(t *testing.T) {
 							action.NewAddedEvent(context.Background(),
 								&action.NewAggregate("id1", "org1").Aggregate,
 								"name",
-								"name() {};",
+								"function name() {};",
 								0,
 								false,
 							),
@@ -448,7 +694,7 @@ func TestCommands_DeactivateAction(t *testing.T) {
 							action.NewAddedEvent(context.Background(),
 								&action.NewAggregate("id1", "org1").Aggregate,
 								"name",
-								"name() {};",
+								"function name() {};",
 								0,
 								false,
 							),
@@ -554,7 +800,7 @@ func TestCommands_ReactivateAction(t *testing.T) {
 							action.NewAddedEvent(context.Background(),
 								&action.NewAggregate("id1", "org1").Aggregate,
 								"name",
-								"name() {};",
+								"function name() {};",
 								0,
 								false,
 							),
@@ -580,7 +826,7 @@ func TestCommands_ReactivateAction(t *testing.T) {
 							action.NewAddedEvent(context.Background(),
 								&action.NewAggregate("id1", "org1").Aggregate,
 								"name",
-								"name() {};",
+								"function name() {};",
 								0,
 								false,
 							),
@@ -692,7 +938,7 @@ func TestCommands_DeleteAction(t *testing.T) {
 							action.NewAddedEvent(context.Background(),
 								&action.NewAggregate("id1", "org1").Aggregate,
 								"name",
-								"name() {};",
+								"function name() {};",
 								0,
 								false,
 							),
@@ -731,7 +977,7 @@ func TestCommands_DeleteAction(t *testing.T) {
 							action.NewAddedEvent(context.Background(),
 								&action.NewAggregate("id1", "org1").Aggregate,
 								"name",
-								"name() {};",
+								"function name() {};",
 								0,
 								false,
 							),
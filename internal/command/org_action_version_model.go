@@ -0,0 +1,66 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/repository/action"
+)
+
+// ActionVersionWriteModel reduces the current state of the action and its state at the requested version.
+// Every added and changed event creates a new version of the action, starting with version 1.
+type ActionVersionWriteModel struct {
+	*ActionWriteModel
+
+	Version uint64
+
+	VersionExists        bool
+	VersionName          string
+	VersionScript        string
+	VersionTimeout       time.Duration
+	VersionAllowedToFail bool
+
+	currentVersion uint64
+}
+
+func NewActionVersionWriteModel(actionID, resourceOwner string, version uint64) *ActionVersionWriteModel {
+	return &ActionVersionWriteModel{
+		ActionWriteModel: NewActionWriteModel(actionID, resourceOwner),
+		Version:          version,
+	}
+}
+
+func (wm *ActionVersionWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		if wm.VersionExists {
+			break
+		}
+		switch e := event.(type) {
+		case *action.AddedEvent:
+			wm.currentVersion++
+			wm.VersionName = e.Name
+			wm.VersionScript = e.Script
+			wm.VersionTimeout = e.Timeout
+			wm.VersionAllowedToFail = e.AllowedToFail
+		case *action.ChangedEvent:
+			wm.currentVersion++
+			if e.Name != nil {
+				wm.VersionName = *e.Name
+			}
+			if e.Script != nil {
+				wm.VersionScript = *e.Script
+			}
+			if e.Timeout != nil {
+				wm.VersionTimeout = *e.Timeout
+			}
+			if e.AllowedToFail != nil {
+				wm.VersionAllowedToFail = *e.AllowedToFail
+			}
+		default:
+			continue
+		}
+		if wm.currentVersion == wm.Version {
+			wm.VersionExists = true
+		}
+	}
+	return wm.ActionWriteModel.Reduce()
+}
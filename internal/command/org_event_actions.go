@@ -0,0 +1,54 @@
+package command
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+// SetEventActions sets the actions which are executed asynchronously after an event of the event type
+// was created in the organisation. An empty list of action ids removes the actions of the event type.
+func (c *Commands) SetEventActions(ctx context.Context, eventType string, actionIDs []string, resourceOwner string) (*domain.ObjectDetails, error) {
+	if eventType = strings.TrimSpace(eventType); eventType == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-ahT8u", "Errors.Flow.EventTypeMissing")
+	}
+	existing, err := c.getOrgEventActionsWriteModel(ctx, eventType, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing.ActionIDs) == 0 && len(actionIDs) == 0 || reflect.DeepEqual(existing.ActionIDs, actionIDs) {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Oov9i", "Errors.Flow.NoChanges")
+	}
+	if len(actionIDs) > 0 {
+		exists, err := c.actionsIDsExist(ctx, actionIDs, resourceOwner)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-ieN5a", "Errors.Flow.ActionIDsNotExist")
+		}
+	}
+	orgAgg := OrgAggregateFromWriteModel(&existing.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewEventActionsSetEvent(ctx, orgAgg, eventType, actionIDs))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existing, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
+
+func (c *Commands) getOrgEventActionsWriteModel(ctx context.Context, eventType, resourceOwner string) (*OrgEventActionsWriteModel, error) {
+	writeModel := NewOrgEventActionsWriteModel(eventType, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
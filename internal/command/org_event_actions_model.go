@@ -0,0 +1,52 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+type OrgEventActionsWriteModel struct {
+	eventstore.WriteModel
+
+	EventType string
+	ActionIDs []string
+}
+
+func NewOrgEventActionsWriteModel(eventType, resourceOwner string) *OrgEventActionsWriteModel {
+	return &OrgEventActionsWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   resourceOwner,
+			ResourceOwner: resourceOwner,
+		},
+		EventType: eventType,
+	}
+}
+
+func (wm *OrgEventActionsWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		e, ok := event.(*org.EventActionsSetEvent)
+		if !ok || e.EventType != wm.EventType {
+			continue
+		}
+		wm.WriteModel.AppendEvents(e)
+	}
+}
+
+func (wm *OrgEventActionsWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		if e, ok := event.(*org.EventActionsSetEvent); ok {
+			wm.ActionIDs = e.ActionIDs
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *OrgEventActionsWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(org.EventActionsSetEventType).
+		Builder()
+}
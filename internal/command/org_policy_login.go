@@ -107,6 +107,22 @@ func (c *Commands) ChangeLoginPolicy(ctx context.Context, resourceOwner string,
 	return pushedEventsToObjectDetails(pushedEvents), nil
 }
 
+func (c *Commands) SetLoginPolicyRiskRules(ctx context.Context, resourceOwner string, rules domain.LoginPolicyRiskRules) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-Rr5sd", "Errors.ResourceOwnerMissing")
+	}
+	orgAgg := org.NewAggregate(resourceOwner)
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareSetLoginPolicyRiskRules(orgAgg, rules))
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
 func (c *Commands) RemoveLoginPolicy(ctx context.Context, orgID string) (*domain.ObjectDetails, error) {
 	if orgID == "" {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-55Mg9", "Errors.ResourceOwnerMissing")
@@ -148,8 +164,14 @@ func (c *Commands) AddIDPToLoginPolicy(ctx context.Context, resourceOwner string
 
 	if idpProvider.Type == domain.IdentityProviderTypeOrg {
 		_, err = c.getOrgIDPConfigByID(ctx, idpProvider.IDPConfigID, resourceOwner)
+		if caos_errs.IsNotFound(err) {
+			_, err = c.getOrgIDPTemplateByID(ctx, idpProvider.IDPConfigID, resourceOwner)
+		}
 	} else {
 		_, err = c.getInstanceIDPConfigByID(ctx, idpProvider.IDPConfigID)
+		if caos_errs.IsNotFound(err) {
+			_, err = c.getInstanceIDPTemplateByID(ctx, idpProvider.IDPConfigID)
+		}
 	}
 	if err != nil {
 		return nil, caos_errs.ThrowPreconditionFailed(err, "Org-3N9fs", "Errors.IDPConfig.NotExisting")
@@ -489,6 +511,29 @@ func prepareChangeLoginPolicy(a *org.Aggregate, policy *ChangeLoginPolicy) prepa
 	}
 }
 
+func prepareSetLoginPolicyRiskRules(a *org.Aggregate, rules domain.LoginPolicyRiskRules) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if !rules.IsValid() {
+			return nil, caos_errs.ThrowInvalidArgument(nil, "Org-Rr6gs", "Errors.Org.LoginPolicy.IPRangeInvalid")
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			wm := NewOrgLoginPolicyWriteModel(a.ID)
+			if err := queryAndReduce(ctx, filter, wm); err != nil {
+				return nil, err
+			}
+			if !wm.State.Exists() {
+				return nil, caos_errs.ThrowNotFound(nil, "Org-Rr7fe", "Errors.Org.LoginPolicy.NotFound")
+			}
+			if wm.RiskRules.Equal(rules) {
+				return nil, caos_errs.ThrowPreconditionFailed(nil, "Org-Rr8hd", "Errors.Org.LoginPolicy.NotChanged")
+			}
+			return []eventstore.Command{
+				org.NewLoginPolicyRiskRulesSetEvent(ctx, &a.Aggregate, rules.TrustedIPRanges, rules.MFAOnNewUserAgent, rules.MFAOnCountryChange),
+			}, nil
+		}, nil
+	}
+}
+
 func idpExists(ctx context.Context, filter preparation.FilterToQueryReducer, idp *AddLoginPolicyIDP) (bool, error) {
 	if idp.Type == domain.IdentityProviderTypeSystem {
 		return exists(ctx, filter, NewInstanceIDPConfigWriteModel(ctx, idp.ConfigID))
@@ -33,6 +33,8 @@ func (wm *OrgLoginPolicyWriteModel) AppendEvents(events ...eventstore.Event) {
 			wm.LoginPolicyWriteModel.AppendEvents(&e.LoginPolicyAddedEvent)
 		case *org.LoginPolicyChangedEvent:
 			wm.LoginPolicyWriteModel.AppendEvents(&e.LoginPolicyChangedEvent)
+		case *org.LoginPolicyRiskRulesSetEvent:
+			wm.LoginPolicyWriteModel.AppendEvents(&e.RiskRulesSetEvent)
 		case *org.LoginPolicyRemovedEvent:
 			wm.LoginPolicyWriteModel.AppendEvents(&e.LoginPolicyRemovedEvent)
 		}
@@ -56,6 +58,7 @@ func (wm *OrgLoginPolicyWriteModel) Query() *eventstore.SearchQueryBuilder {
 		EventTypes(
 			org.LoginPolicyAddedEventType,
 			org.LoginPolicyChangedEventType,
+			org.LoginPolicyRiskRulesSetEventType,
 			org.LoginPolicyRemovedEventType).
 		Builder()
 }
@@ -620,6 +620,189 @@ func TestCommandSide_ChangeLoginPolicy(t *testing.T) {
 	}
 }
 
+func TestCommandSide_SetLoginPolicyRiskRules(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx   context.Context
+		orgID string
+		rules domain.LoginPolicyRiskRules
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "invalid ip range, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				rules: domain.LoginPolicyRiskRules{
+					TrustedIPRanges: []string{"10.0.0.1"},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "loginpolicy not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				rules: domain.LoginPolicyRiskRules{
+					TrustedIPRanges: []string{"10.0.0.0/8"},
+				},
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "no changes, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewLoginPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								true,
+								true,
+								true,
+								true,
+								true,
+								true,
+								true,
+								true,
+								true,
+								domain.PasswordlessTypeAllowed,
+								"",
+								time.Hour*1,
+								time.Hour*2,
+								time.Hour*3,
+								time.Hour*4,
+								time.Hour*5,
+							),
+						),
+						eventFromEventPusher(
+							org.NewLoginPolicyRiskRulesSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								[]string{"10.0.0.0/8"},
+								true,
+								false,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				rules: domain.LoginPolicyRiskRules{
+					TrustedIPRanges:   []string{"10.0.0.0/8"},
+					MFAOnNewUserAgent: true,
+				},
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "set, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewLoginPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								true,
+								true,
+								true,
+								true,
+								true,
+								true,
+								true,
+								true,
+								true,
+								domain.PasswordlessTypeAllowed,
+								"",
+								time.Hour*1,
+								time.Hour*2,
+								time.Hour*3,
+								time.Hour*4,
+								time.Hour*5,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								org.NewLoginPolicyRiskRulesSetEvent(context.Background(),
+									&org.NewAggregate("org1").Aggregate,
+									[]string{"10.0.0.0/8"},
+									true,
+									true,
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				rules: domain.LoginPolicyRiskRules{
+					TrustedIPRanges:    []string{"10.0.0.0/8"},
+					MFAOnNewUserAgent:  true,
+					MFAOnCountryChange: true,
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.SetLoginPolicyRiskRules(tt.args.ctx, tt.args.orgID, tt.args.rules)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
 func TestCommandSide_RemoveLoginPolicy(t *testing.T) {
 	type fields struct {
 		eventstore *eventstore.Eventstore
@@ -840,6 +1023,7 @@ func TestCommandSide_AddIDPProviderLoginPolicy(t *testing.T) {
 						),
 					),
 					expectFilter(),
+					expectFilter(),
 				),
 			},
 			args: args{
@@ -2,6 +2,7 @@ package command
 
 import (
 	"context"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/eventstore"
 
@@ -59,6 +60,7 @@ func (wm *OrgPrivacyPolicyWriteModel) NewChangedEvent(
 	tosLink,
 	privacyLink,
 	helpLink string,
+	deletionGracePeriod time.Duration,
 ) (*org.PrivacyPolicyChangedEvent, bool) {
 
 	changes := make([]policy.PrivacyPolicyChanges, 0)
@@ -71,6 +73,9 @@ func (wm *OrgPrivacyPolicyWriteModel) NewChangedEvent(
 	if wm.HelpLink != helpLink {
 		changes = append(changes, policy.ChangeHelpLink(helpLink))
 	}
+	if wm.DeletionGracePeriod != deletionGracePeriod {
+		changes = append(changes, policy.ChangeDeletionGracePeriod(deletionGracePeriod))
+	}
 	if len(changes) == 0 {
 		return nil, false
 	}
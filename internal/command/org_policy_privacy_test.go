@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -44,9 +45,10 @@ func TestCommandSide_AddPrivacyPolicy(t *testing.T) {
 			args: args{
 				ctx: context.Background(),
 				policy: &domain.PrivacyPolicy{
-					TOSLink:     "TOSLink",
-					PrivacyLink: "PrivacyLink",
-					HelpLink:    "HelpLink",
+					TOSLink:             "TOSLink",
+					PrivacyLink:         "PrivacyLink",
+					HelpLink:            "HelpLink",
+					DeletionGracePeriod: 720 * time.Hour,
 				},
 			},
 			res: res{
@@ -65,6 +67,7 @@ func TestCommandSide_AddPrivacyPolicy(t *testing.T) {
 								"TOSLink",
 								"PrivacyLink",
 								"HelpLink",
+								720*time.Hour,
 							),
 						),
 					),
@@ -74,9 +77,10 @@ func TestCommandSide_AddPrivacyPolicy(t *testing.T) {
 				ctx:   context.Background(),
 				orgID: "org1",
 				policy: &domain.PrivacyPolicy{
-					TOSLink:     "TOSLink",
-					PrivacyLink: "PrivacyLink",
-					HelpLink:    "HelpLink",
+					TOSLink:             "TOSLink",
+					PrivacyLink:         "PrivacyLink",
+					HelpLink:            "HelpLink",
+					DeletionGracePeriod: 720 * time.Hour,
 				},
 			},
 			res: res{
@@ -97,6 +101,7 @@ func TestCommandSide_AddPrivacyPolicy(t *testing.T) {
 									"TOSLink",
 									"PrivacyLink",
 									"HelpLink",
+									720*time.Hour,
 								),
 							),
 						},
@@ -107,9 +112,10 @@ func TestCommandSide_AddPrivacyPolicy(t *testing.T) {
 				ctx:   context.Background(),
 				orgID: "org1",
 				policy: &domain.PrivacyPolicy{
-					TOSLink:     "TOSLink",
-					PrivacyLink: "PrivacyLink",
-					HelpLink:    "HelpLink",
+					TOSLink:             "TOSLink",
+					PrivacyLink:         "PrivacyLink",
+					HelpLink:            "HelpLink",
+					DeletionGracePeriod: 720 * time.Hour,
 				},
 			},
 			res: res{
@@ -118,9 +124,10 @@ func TestCommandSide_AddPrivacyPolicy(t *testing.T) {
 						AggregateID:   "org1",
 						ResourceOwner: "org1",
 					},
-					TOSLink:     "TOSLink",
-					PrivacyLink: "PrivacyLink",
-					HelpLink:    "HelpLink",
+					TOSLink:             "TOSLink",
+					PrivacyLink:         "PrivacyLink",
+					HelpLink:            "HelpLink",
+					DeletionGracePeriod: 720 * time.Hour,
 				},
 			},
 		},
@@ -138,6 +145,7 @@ func TestCommandSide_AddPrivacyPolicy(t *testing.T) {
 									"",
 									"",
 									"",
+									0,
 								),
 							),
 						},
@@ -214,9 +222,10 @@ func TestCommandSide_ChangePrivacyPolicy(t *testing.T) {
 			args: args{
 				ctx: context.Background(),
 				policy: &domain.PrivacyPolicy{
-					TOSLink:     "TOSLink",
-					PrivacyLink: "PrivacyLink",
-					HelpLink:    "HelpLink",
+					TOSLink:             "TOSLink",
+					PrivacyLink:         "PrivacyLink",
+					HelpLink:            "HelpLink",
+					DeletionGracePeriod: 720 * time.Hour,
 				},
 			},
 			res: res{
@@ -235,9 +244,10 @@ func TestCommandSide_ChangePrivacyPolicy(t *testing.T) {
 				ctx:   context.Background(),
 				orgID: "org1",
 				policy: &domain.PrivacyPolicy{
-					TOSLink:     "TOSLink",
-					PrivacyLink: "PrivacyLink",
-					HelpLink:    "HelpLink",
+					TOSLink:             "TOSLink",
+					PrivacyLink:         "PrivacyLink",
+					HelpLink:            "HelpLink",
+					DeletionGracePeriod: 720 * time.Hour,
 				},
 			},
 			res: res{
@@ -256,6 +266,7 @@ func TestCommandSide_ChangePrivacyPolicy(t *testing.T) {
 								"TOSLink",
 								"PrivacyLink",
 								"HelpLink",
+								720*time.Hour,
 							),
 						),
 					),
@@ -265,9 +276,10 @@ func TestCommandSide_ChangePrivacyPolicy(t *testing.T) {
 				ctx:   context.Background(),
 				orgID: "org1",
 				policy: &domain.PrivacyPolicy{
-					TOSLink:     "TOSLink",
-					PrivacyLink: "PrivacyLink",
-					HelpLink:    "HelpLink",
+					TOSLink:             "TOSLink",
+					PrivacyLink:         "PrivacyLink",
+					HelpLink:            "HelpLink",
+					DeletionGracePeriod: 720 * time.Hour,
 				},
 			},
 			res: res{
@@ -286,13 +298,14 @@ func TestCommandSide_ChangePrivacyPolicy(t *testing.T) {
 								"TOSLink",
 								"PrivacyLink",
 								"HelpLink",
+								720*time.Hour,
 							),
 						),
 					),
 					expectPush(
 						[]*repository.Event{
 							eventFromEventPusher(
-								newPrivacyPolicyChangedEvent(context.Background(), "org1", "TOSLinkChange", "PrivacyLinkChange", "HelpLinkChange"),
+								newPrivacyPolicyChangedEvent(context.Background(), "org1", "TOSLinkChange", "PrivacyLinkChange", "HelpLinkChange", 1440*time.Hour),
 							),
 						},
 					),
@@ -302,9 +315,10 @@ func TestCommandSide_ChangePrivacyPolicy(t *testing.T) {
 				ctx:   context.Background(),
 				orgID: "org1",
 				policy: &domain.PrivacyPolicy{
-					TOSLink:     "TOSLinkChange",
-					PrivacyLink: "PrivacyLinkChange",
-					HelpLink:    "HelpLinkChange",
+					TOSLink:             "TOSLinkChange",
+					PrivacyLink:         "PrivacyLinkChange",
+					HelpLink:            "HelpLinkChange",
+					DeletionGracePeriod: 1440 * time.Hour,
 				},
 			},
 			res: res{
@@ -313,9 +327,10 @@ func TestCommandSide_ChangePrivacyPolicy(t *testing.T) {
 						AggregateID:   "org1",
 						ResourceOwner: "org1",
 					},
-					TOSLink:     "TOSLinkChange",
-					PrivacyLink: "PrivacyLinkChange",
-					HelpLink:    "HelpLinkChange",
+					TOSLink:             "TOSLinkChange",
+					PrivacyLink:         "PrivacyLinkChange",
+					HelpLink:            "HelpLinkChange",
+					DeletionGracePeriod: 1440 * time.Hour,
 				},
 			},
 		},
@@ -331,13 +346,14 @@ func TestCommandSide_ChangePrivacyPolicy(t *testing.T) {
 								"TOSLink",
 								"PrivacyLink",
 								"HelpLink",
+								720*time.Hour,
 							),
 						),
 					),
 					expectPush(
 						[]*repository.Event{
 							eventFromEventPusher(
-								newPrivacyPolicyChangedEvent(context.Background(), "org1", "", "", ""),
+								newPrivacyPolicyChangedEvent(context.Background(), "org1", "", "", "", 0),
 							),
 						},
 					),
@@ -444,6 +460,7 @@ func TestCommandSide_RemovePrivacyPolicy(t *testing.T) {
 								"TOSLink",
 								"PrivacyLink",
 								"HelpLink",
+								720*time.Hour,
 							),
 						),
 					),
@@ -487,13 +504,14 @@ func TestCommandSide_RemovePrivacyPolicy(t *testing.T) {
 	}
 }
 
-func newPrivacyPolicyChangedEvent(ctx context.Context, orgID string, tosLink, privacyLink, helpLink string) *org.PrivacyPolicyChangedEvent {
+func newPrivacyPolicyChangedEvent(ctx context.Context, orgID string, tosLink, privacyLink, helpLink string, deletionGracePeriod time.Duration) *org.PrivacyPolicyChangedEvent {
 	event, _ := org.NewPrivacyPolicyChangedEvent(ctx,
 		&org.NewAggregate(orgID).Aggregate,
 		[]policy.PrivacyPolicyChanges{
 			policy.ChangeTOSLink(tosLink),
 			policy.ChangePrivacyLink(privacyLink),
 			policy.ChangeHelpLink(helpLink),
+			policy.ChangeDeletionGracePeriod(deletionGracePeriod),
 		},
 	)
 	return event
@@ -0,0 +1,166 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/command/preparation"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+func (c *Commands) AddTokenExchangePolicy(ctx context.Context, resourceOwner string, delegationActors, impersonationActors []string) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-biVoO", "Errors.ResourceOwnerMissing")
+	}
+	orgAgg := org.NewAggregate(resourceOwner)
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareAddTokenExchangePolicy(orgAgg, delegationActors, impersonationActors))
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+func prepareAddTokenExchangePolicy(
+	a *org.Aggregate,
+	delegationActors,
+	impersonationActors []string,
+) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			writeModel := NewOrgTokenExchangePolicyWriteModel(a.Aggregate.ID)
+			events, err := filter(ctx, writeModel.Query())
+			if err != nil {
+				return nil, err
+			}
+			writeModel.AppendEvents(events...)
+			if err = writeModel.Reduce(); err != nil {
+				return nil, err
+			}
+			if writeModel.State == domain.PolicyStateActive {
+				return nil, caos_errs.ThrowAlreadyExists(nil, "Org-lgWlj", "Errors.Org.TokenExchangePolicy.AlreadyExists")
+			}
+			if err = checkTokenExchangeActors(ctx, filter, delegationActors, impersonationActors); err != nil {
+				return nil, err
+			}
+			return []eventstore.Command{
+				org.NewTokenExchangePolicyAddedEvent(ctx, &a.Aggregate, delegationActors, impersonationActors),
+			}, nil
+		}, nil
+	}
+}
+
+func (c *Commands) ChangeTokenExchangePolicy(ctx context.Context, resourceOwner string, delegationActors, impersonationActors []string) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-OXpOE", "Errors.ResourceOwnerMissing")
+	}
+	orgAgg := org.NewAggregate(resourceOwner)
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareChangeTokenExchangePolicy(orgAgg, delegationActors, impersonationActors))
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+func prepareChangeTokenExchangePolicy(
+	a *org.Aggregate,
+	delegationActors,
+	impersonationActors []string,
+) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			writeModel := NewOrgTokenExchangePolicyWriteModel(a.Aggregate.ID)
+			events, err := filter(ctx, writeModel.Query())
+			if err != nil {
+				return nil, err
+			}
+			writeModel.AppendEvents(events...)
+			if err = writeModel.Reduce(); err != nil {
+				return nil, err
+			}
+
+			if writeModel.State == domain.PolicyStateUnspecified || writeModel.State == domain.PolicyStateRemoved {
+				return nil, caos_errs.ThrowNotFound(nil, "ORG-HzJhT", "Errors.Org.TokenExchangePolicy.NotFound")
+			}
+			change, hasChanged := writeModel.NewChangedEvent(ctx, &a.Aggregate, delegationActors, impersonationActors)
+			if !hasChanged {
+				return nil, caos_errs.ThrowPreconditionFailed(nil, "Org-eXhgU", "Errors.Org.TokenExchangePolicy.NotChanged")
+			}
+			if err = checkTokenExchangeActors(ctx, filter, delegationActors, impersonationActors); err != nil {
+				return nil, err
+			}
+			return []eventstore.Command{
+				change,
+			}, nil
+		}, nil
+	}
+}
+
+func (c *Commands) RemoveTokenExchangePolicy(ctx context.Context, resourceOwner string) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-Ip7VE", "Errors.ResourceOwnerMissing")
+	}
+	orgAgg := org.NewAggregate(resourceOwner)
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareRemoveTokenExchangePolicy(orgAgg))
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+func prepareRemoveTokenExchangePolicy(
+	a *org.Aggregate,
+) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			writeModel := NewOrgTokenExchangePolicyWriteModel(a.Aggregate.ID)
+			events, err := filter(ctx, writeModel.Query())
+			if err != nil {
+				return nil, err
+			}
+			writeModel.AppendEvents(events...)
+			if err = writeModel.Reduce(); err != nil {
+				return nil, err
+			}
+
+			if writeModel.State == domain.PolicyStateUnspecified || writeModel.State == domain.PolicyStateRemoved {
+				return nil, caos_errs.ThrowNotFound(nil, "ORG-kDRfs", "Errors.Org.TokenExchangePolicy.NotFound")
+			}
+			return []eventstore.Command{
+				org.NewTokenExchangePolicyRemovedEvent(ctx, &a.Aggregate),
+			}, nil
+		}, nil
+	}
+}
+
+// checkTokenExchangeActors ensures all actors of a token exchange policy are existing machine users
+func checkTokenExchangeActors(ctx context.Context, filter preparation.FilterToQueryReducer, actorLists ...[]string) error {
+	for _, actors := range actorLists {
+		for _, actorID := range actors {
+			actor, err := userWriteModelByID(ctx, filter, actorID, "")
+			if err != nil {
+				return err
+			}
+			if !isUserStateExists(actor.UserState) {
+				return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ga4ie", "Errors.User.NotFound")
+			}
+			if actor.UserType != domain.UserTypeMachine {
+				return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-aiJ2e", "Errors.User.NotMachine")
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+type OrgTokenExchangePolicyWriteModel struct {
+	TokenExchangePolicyWriteModel
+}
+
+func NewOrgTokenExchangePolicyWriteModel(orgID string) *OrgTokenExchangePolicyWriteModel {
+	return &OrgTokenExchangePolicyWriteModel{
+		TokenExchangePolicyWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   orgID,
+				ResourceOwner: orgID,
+			},
+		},
+	}
+}
+
+func (wm *OrgTokenExchangePolicyWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *org.TokenExchangePolicyAddedEvent:
+			wm.TokenExchangePolicyWriteModel.AppendEvents(&e.TokenExchangePolicyAddedEvent)
+		case *org.TokenExchangePolicyChangedEvent:
+			wm.TokenExchangePolicyWriteModel.AppendEvents(&e.TokenExchangePolicyChangedEvent)
+		case *org.TokenExchangePolicyRemovedEvent:
+			wm.TokenExchangePolicyWriteModel.AppendEvents(&e.TokenExchangePolicyRemovedEvent)
+		}
+	}
+}
+
+func (wm *OrgTokenExchangePolicyWriteModel) Reduce() error {
+	return wm.TokenExchangePolicyWriteModel.Reduce()
+}
+
+func (wm *OrgTokenExchangePolicyWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateIDs(wm.TokenExchangePolicyWriteModel.AggregateID).
+		AggregateTypes(org.AggregateType).
+		EventTypes(org.TokenExchangePolicyAddedEventType,
+			org.TokenExchangePolicyChangedEventType,
+			org.TokenExchangePolicyRemovedEventType).
+		Builder()
+}
+
+func (wm *OrgTokenExchangePolicyWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	delegationActors,
+	impersonationActors []string,
+) (*org.TokenExchangePolicyChangedEvent, bool) {
+
+	changes := make([]policy.TokenExchangePolicyChanges, 0)
+	if tokenExchangeActorsChanged(wm.DelegationActors, delegationActors) {
+		changes = append(changes, policy.ChangeDelegationActors(delegationActors))
+	}
+	if tokenExchangeActorsChanged(wm.ImpersonationActors, impersonationActors) {
+		changes = append(changes, policy.ChangeImpersonationActors(impersonationActors))
+	}
+	if len(changes) == 0 {
+		return nil, false
+	}
+	changedEvent, err := org.NewTokenExchangePolicyChangedEvent(ctx, aggregate, changes)
+	if err != nil {
+		return nil, false
+	}
+	return changedEvent, true
+}
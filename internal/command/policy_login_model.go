@@ -27,6 +27,7 @@ type LoginPolicyWriteModel struct {
 	MFAInitSkipLifetime        time.Duration
 	SecondFactorCheckLifetime  time.Duration
 	MultiFactorCheckLifetime   time.Duration
+	RiskRules                  domain.LoginPolicyRiskRules
 	State                      domain.PolicyState
 }
 
@@ -100,8 +101,15 @@ func (wm *LoginPolicyWriteModel) Reduce() error {
 			if e.DisableLoginWithPhone != nil {
 				wm.DisableLoginWithPhone = *e.DisableLoginWithPhone
 			}
+		case *policy.RiskRulesSetEvent:
+			wm.RiskRules = domain.LoginPolicyRiskRules{
+				TrustedIPRanges:    e.TrustedIPRanges,
+				MFAOnNewUserAgent:  e.MFAOnNewUserAgent,
+				MFAOnCountryChange: e.MFAOnCountryChange,
+			}
 		case *policy.LoginPolicyRemovedEvent:
 			wm.State = domain.PolicyStateRemoved
+			wm.RiskRules = domain.LoginPolicyRiskRules{}
 		}
 	}
 	return wm.WriteModel.Reduce()
@@ -1,6 +1,8 @@
 package command
 
 import (
+	"time"
+
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/repository/policy"
@@ -9,10 +11,11 @@ import (
 type PrivacyPolicyWriteModel struct {
 	eventstore.WriteModel
 
-	TOSLink     string
-	PrivacyLink string
-	HelpLink    string
-	State       domain.PolicyState
+	TOSLink             string
+	PrivacyLink         string
+	HelpLink            string
+	DeletionGracePeriod time.Duration
+	State               domain.PolicyState
 }
 
 func (wm *PrivacyPolicyWriteModel) Reduce() error {
@@ -22,6 +25,7 @@ func (wm *PrivacyPolicyWriteModel) Reduce() error {
 			wm.TOSLink = e.TOSLink
 			wm.PrivacyLink = e.PrivacyLink
 			wm.HelpLink = e.HelpLink
+			wm.DeletionGracePeriod = e.DeletionGracePeriod
 			wm.State = domain.PolicyStateActive
 		case *policy.PrivacyPolicyChangedEvent:
 			if e.PrivacyLink != nil {
@@ -33,6 +37,9 @@ func (wm *PrivacyPolicyWriteModel) Reduce() error {
 			if e.HelpLink != nil {
 				wm.HelpLink = *e.HelpLink
 			}
+			if e.DeletionGracePeriod != nil {
+				wm.DeletionGracePeriod = *e.DeletionGracePeriod
+			}
 		case *policy.PrivacyPolicyRemovedEvent:
 			wm.State = domain.PolicyStateRemoved
 		}
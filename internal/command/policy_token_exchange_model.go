@@ -0,0 +1,46 @@
+package command
+
+import (
+	"reflect"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+type TokenExchangePolicyWriteModel struct {
+	eventstore.WriteModel
+
+	DelegationActors    []string
+	ImpersonationActors []string
+	State               domain.PolicyState
+}
+
+func (wm *TokenExchangePolicyWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *policy.TokenExchangePolicyAddedEvent:
+			wm.DelegationActors = e.DelegationActors
+			wm.ImpersonationActors = e.ImpersonationActors
+			wm.State = domain.PolicyStateActive
+		case *policy.TokenExchangePolicyChangedEvent:
+			if e.DelegationActors != nil {
+				wm.DelegationActors = *e.DelegationActors
+			}
+			if e.ImpersonationActors != nil {
+				wm.ImpersonationActors = *e.ImpersonationActors
+			}
+		case *policy.TokenExchangePolicyRemovedEvent:
+			wm.State = domain.PolicyStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+// tokenExchangeActorsChanged treats nil and empty actor lists as equal
+func tokenExchangeActorsChanged(current, actors []string) bool {
+	if len(current) == 0 && len(actors) == 0 {
+		return false
+	}
+	return !reflect.DeepEqual(current, actors)
+}
@@ -0,0 +1,448 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// SessionChecks are the checks to be executed on a session.
+// The user has to be checked before (or together with) the other factors.
+type SessionChecks struct {
+	UserID   string
+	Password string
+	OTPCode  string
+	// WebAuthN is the assertion of the (passwordless) challenge requested previously
+	WebAuthN []byte
+	// WebAuthNChallenge requests a new (passwordless) challenge
+	WebAuthNChallenge bool
+	BrowserInfo       *domain.BrowserInfo
+}
+
+func (c *SessionChecks) hasFactorChecks() bool {
+	return c.Password != "" || c.OTPCode != "" || len(c.WebAuthN) > 0 || c.WebAuthNChallenge
+}
+
+type SessionChanged struct {
+	*domain.ObjectDetails
+	ID string
+	// NewToken replaces the previous token of the session
+	NewToken          string
+	WebAuthNChallenge *domain.WebAuthNLogin
+}
+
+// SessionIDPIntent is a started identity provider flow of a session
+type SessionIDPIntent struct {
+	SessionID  string
+	IDPID      string
+	SuccessURL string
+	FailureURL string
+}
+
+func (c *Commands) CreateSession(ctx context.Context, checks *SessionChecks) (_ *SessionChanged, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	sessionID, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+	sessionWriteModel := NewSessionWriteModel(sessionID, authz.GetInstance(ctx).InstanceID())
+	sessionAgg := SessionAggregateFromWriteModel(&sessionWriteModel.WriteModel)
+	return c.updateSession(ctx, sessionWriteModel, checks, session.NewAddedEvent(ctx, sessionAgg))
+}
+
+func (c *Commands) UpdateSession(ctx context.Context, sessionID, sessionToken string, checks *SessionChecks) (_ *SessionChanged, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	sessionWriteModel, err := c.activeSessionWriteModel(ctx, sessionID, sessionToken)
+	if err != nil {
+		return nil, err
+	}
+	return c.updateSession(ctx, sessionWriteModel, checks)
+}
+
+func (c *Commands) TerminateSession(ctx context.Context, sessionID, sessionToken string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	sessionWriteModel, err := c.activeSessionWriteModel(ctx, sessionID, sessionToken)
+	if err != nil {
+		return nil, err
+	}
+	sessionAgg := SessionAggregateFromWriteModel(&sessionWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, session.NewTerminateEvent(ctx, sessionAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(sessionWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&sessionWriteModel.WriteModel), nil
+}
+
+// StartSessionIDPFlow stores the identity provider and the urls the user is redirected to after the authentication on it.
+// The returned intent has to be passed to the login, which will start the authentication on the identity provider.
+func (c *Commands) StartSessionIDPFlow(ctx context.Context, sessionID, sessionToken, idpID, successURL, failureURL string) (_ string, _ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if idpID == "" || successURL == "" || failureURL == "" {
+		return "", nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Sgr32", "Errors.Session.IDP.Invalid")
+	}
+	sessionWriteModel, err := c.activeSessionWriteModel(ctx, sessionID, sessionToken)
+	if err != nil {
+		return "", nil, err
+	}
+	intent, err := domain.NewSessionIDPIntent(sessionID, idpID, c.userEncryption)
+	if err != nil {
+		return "", nil, err
+	}
+	sessionAgg := SessionAggregateFromWriteModel(&sessionWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, session.NewIDPStartedEvent(ctx, sessionAgg, idpID, successURL, failureURL))
+	if err != nil {
+		return "", nil, err
+	}
+	err = AppendAndReduce(sessionWriteModel, pushedEvents...)
+	if err != nil {
+		return "", nil, err
+	}
+	return intent, writeModelToObjectDetails(&sessionWriteModel.WriteModel), nil
+}
+
+// SessionIDPIntentByID returns the started identity provider flow of the intent,
+// which is not checked yet
+func (c *Commands) SessionIDPIntentByID(ctx context.Context, intent string) (_ *SessionIDPIntent, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	sessionID, idpID, err := domain.FromSessionIDPIntent(intent, c.userEncryption)
+	if err != nil {
+		return nil, err
+	}
+	sessionWriteModel, err := c.sessionIDPIntentWriteModel(ctx, sessionID, idpID)
+	if err != nil {
+		return nil, err
+	}
+	return &SessionIDPIntent{
+		SessionID:  sessionID,
+		IDPID:      idpID,
+		SuccessURL: sessionWriteModel.IDPSuccessURL,
+		FailureURL: sessionWriteModel.IDPFailureURL,
+	}, nil
+}
+
+// CheckSessionIDP checks the user of the session by the link to the user of the identity provider,
+// the user authenticated on (in the started flow of the intent)
+func (c *Commands) CheckSessionIDP(ctx context.Context, intent, userID, resourceOwner, externalUserID string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" || externalUserID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Jg3sa", "Errors.User.ExternalIDP.NotFound")
+	}
+	sessionID, idpID, err := domain.FromSessionIDPIntent(intent, c.userEncryption)
+	if err != nil {
+		return nil, err
+	}
+	sessionWriteModel, err := c.sessionIDPIntentWriteModel(ctx, sessionID, idpID)
+	if err != nil {
+		return nil, err
+	}
+	if sessionWriteModel.UserID != "" && sessionWriteModel.UserID != userID {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Hsf3g", "Errors.Session.User.Changed")
+	}
+	link, err := c.userIDPLinkWriteModelByID(ctx, userID, idpID, externalUserID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if link.State != domain.UserIDPLinkStateActive {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Bsg2d", "Errors.User.ExternalIDP.NotFound")
+	}
+	err = c.UserIDPLoginChecked(ctx, resourceOwner, userID, sessionAuthRequest(sessionID, nil))
+	if err != nil {
+		return nil, err
+	}
+	sessionAgg := SessionAggregateFromWriteModel(&sessionWriteModel.WriteModel)
+	cmds := make([]eventstore.Command, 0, 2)
+	if sessionWriteModel.UserID == "" {
+		cmds = append(cmds, session.NewUserCheckedEvent(ctx, sessionAgg, userID, resourceOwner))
+	}
+	cmds = append(cmds, session.NewIDPCheckedEvent(ctx, sessionAgg, idpID, externalUserID))
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(sessionWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&sessionWriteModel.WriteModel), nil
+}
+
+// SessionAuthRequestChecks are the checks of a session, which are still valid for the login policy of an auth request.
+// Expired checks are zero.
+type SessionAuthRequestChecks struct {
+	SessionID         string
+	UserID            string
+	ResourceOwner     string
+	PasswordCheckedAt time.Time
+	OTPCheckedAt      time.Time
+	WebAuthNCheckedAt time.Time
+	IDPID             string
+	IDPCheckedAt      time.Time
+
+	sessionWriteModel *SessionWriteModel
+	userWriteModel    *UserWriteModel
+}
+
+// SessionChecksForAuthRequest returns the checks of the session, which can be used for the auth request.
+// It does not change the session, so the caller can verify the checks are sufficient before linking them.
+// Sessions already linked to an auth request and sessions without a valid first factor are rejected.
+func (c *Commands) SessionChecksForAuthRequest(ctx context.Context, sessionID, sessionToken string, authRequest *domain.AuthRequest) (_ *SessionAuthRequestChecks, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if authRequest == nil || authRequest.ID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Gsg2e", "Errors.AuthRequest.NotFound")
+	}
+	if authRequest.LoginPolicy == nil {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Kfe2s", "Errors.Org.LoginPolicy.NotFound")
+	}
+	sessionWriteModel, err := c.activeSessionWriteModel(ctx, sessionID, sessionToken)
+	if err != nil {
+		return nil, err
+	}
+	if sessionWriteModel.AuthRequestID != "" {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Hw3ga", "Errors.Session.AuthRequest.AlreadyLinked")
+	}
+	if sessionWriteModel.UserID == "" {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Dg3sh", "Errors.Session.User.Missing")
+	}
+	if authRequest.RequestedOrgID != "" && authRequest.RequestedOrgID != sessionWriteModel.UserResourceOwner {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Sfe3h", "Errors.User.NotAllowedOrg")
+	}
+	existingUser, err := c.userWriteModelByID(ctx, sessionWriteModel.UserID, sessionWriteModel.UserResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existingUser.UserState != domain.UserStateActive && existingUser.UserState != domain.UserStateInitial {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Bf3ga", "Errors.User.NotFound")
+	}
+	policy := authRequest.LoginPolicy
+	checks := &SessionAuthRequestChecks{
+		SessionID:         sessionWriteModel.AggregateID,
+		UserID:            sessionWriteModel.UserID,
+		ResourceOwner:     sessionWriteModel.UserResourceOwner,
+		PasswordCheckedAt: validCheck(sessionWriteModel.PasswordCheckedAt, policy.PasswordCheckLifetime, authRequest),
+		OTPCheckedAt:      validCheck(sessionWriteModel.OTPCheckedAt, policy.SecondFactorCheckLifetime, authRequest),
+		WebAuthNCheckedAt: validCheck(sessionWriteModel.WebAuthNCheckedAt, policy.MultiFactorCheckLifetime, authRequest),
+		IDPCheckedAt:      validCheck(sessionWriteModel.IDPCheckedAt, policy.ExternalLoginCheckLifetime, authRequest),
+		sessionWriteModel: sessionWriteModel,
+		userWriteModel:    existingUser,
+	}
+	if !checks.IDPCheckedAt.IsZero() {
+		checks.IDPID = sessionWriteModel.IDPID
+	}
+	if checks.PasswordCheckedAt.IsZero() && checks.WebAuthNCheckedAt.IsZero() && checks.IDPCheckedAt.IsZero() {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Jg3gs", "Errors.Session.Expired")
+	}
+	return checks, nil
+}
+
+// validCheck returns the time of the check, if it's still valid for the lifetime of the login policy
+// and the max age of the auth request, or else the zero time
+func validCheck(checkedAt time.Time, lifetime time.Duration, authRequest *domain.AuthRequest) time.Time {
+	if checkedAt.IsZero() || !checkedAt.Add(lifetime).After(time.Now()) {
+		return time.Time{}
+	}
+	if authRequest.MaxAuthAge != nil && !checkedAt.After(authRequest.CreationDate.Add(-*authRequest.MaxAuthAge)) {
+		return time.Time{}
+	}
+	return checkedAt
+}
+
+// LinkSessionToAuthRequest marks the valid factors of the session as checked for the auth request,
+// so the (OIDC / SAML) flow of the auth request can be finished.
+// The original time of the checks is kept, so the lifetimes of the login policy are not extended.
+// A session can only be linked once and an auth request only to one session.
+func (c *Commands) LinkSessionToAuthRequest(ctx context.Context, checks *SessionAuthRequestChecks, authRequest *domain.AuthRequest) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if checks == nil || checks.sessionWriteModel == nil || checks.userWriteModel == nil {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Sg4gq", "Errors.Session.NotFound")
+	}
+	if authRequest == nil || authRequest.ID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Bgw2e", "Errors.AuthRequest.NotFound")
+	}
+	userAgg := UserAggregateFromWriteModel(&checks.userWriteModel.WriteModel)
+	cmds := make([]eventstore.Command, 0, 5)
+	if !checks.PasswordCheckedAt.IsZero() {
+		cmds = append(cmds, user.NewHumanPasswordCheckSucceededEvent(ctx, userAgg, checkedAuthRequestInfo(authRequest, checks.PasswordCheckedAt)))
+	}
+	if !checks.OTPCheckedAt.IsZero() {
+		cmds = append(cmds, user.NewHumanOTPCheckSucceededEvent(ctx, userAgg, checkedAuthRequestInfo(authRequest, checks.OTPCheckedAt)))
+	}
+	if !checks.WebAuthNCheckedAt.IsZero() {
+		cmds = append(cmds, user.NewHumanPasswordlessCheckSucceededEvent(ctx, userAgg, checkedAuthRequestInfo(authRequest, checks.WebAuthNCheckedAt)))
+	}
+	if !checks.IDPCheckedAt.IsZero() {
+		info := checkedAuthRequestInfo(authRequest, checks.IDPCheckedAt)
+		info.SelectedIDPConfigID = checks.IDPID
+		cmds = append(cmds, user.NewUserIDPCheckSucceededEvent(ctx, userAgg, info))
+	}
+	sessionAgg := SessionAggregateFromWriteModel(&checks.sessionWriteModel.WriteModel)
+	cmds = append(cmds, session.NewAuthRequestLinkedEvent(ctx, sessionAgg, authRequest.ID))
+	_, err = c.eventstore.Push(ctx, cmds...)
+	return err
+}
+
+func checkedAuthRequestInfo(authRequest *domain.AuthRequest, checkedAt time.Time) *user.AuthRequestInfo {
+	info := authRequestDomainToAuthRequestInfo(authRequest)
+	info.CheckedAt = &checkedAt
+	return info
+}
+
+func (c *Commands) updateSession(ctx context.Context, sessionWriteModel *SessionWriteModel, checks *SessionChecks, cmds ...eventstore.Command) (*SessionChanged, error) {
+	if checks == nil {
+		checks = new(SessionChecks)
+	}
+	sessionAgg := SessionAggregateFromWriteModel(&sessionWriteModel.WriteModel)
+	changed := &SessionChanged{ID: sessionWriteModel.AggregateID}
+
+	userID, resourceOwner := sessionWriteModel.UserID, sessionWriteModel.UserResourceOwner
+	if checks.UserID != "" && checks.UserID != userID {
+		if userID != "" {
+			return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Vgw3a", "Errors.Session.User.Changed")
+		}
+		existingUser, err := c.userWriteModelByID(ctx, checks.UserID, "")
+		if err != nil {
+			return nil, err
+		}
+		if existingUser.UserState != domain.UserStateActive && existingUser.UserState != domain.UserStateInitial {
+			return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Df4b3", "Errors.User.NotFound")
+		}
+		userID, resourceOwner = existingUser.AggregateID, existingUser.ResourceOwner
+		cmds = append(cmds, session.NewUserCheckedEvent(ctx, sessionAgg, userID, resourceOwner))
+	}
+	if checks.hasFactorChecks() && userID == "" {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Mh3sa", "Errors.Session.User.Missing")
+	}
+
+	// the session is used as auth request, so the checks are recorded on the user as for the login
+	authRequest := sessionAuthRequest(sessionWriteModel.AggregateID, checks.BrowserInfo)
+	if checks.Password != "" {
+		lockoutPolicy, err := c.getLockoutPolicy(ctx, resourceOwner)
+		if err != nil {
+			return nil, err
+		}
+		if err = c.HumanCheckPassword(ctx, resourceOwner, userID, checks.Password, authRequest, lockoutPolicy); err != nil {
+			return nil, err
+		}
+		cmds = append(cmds, session.NewPasswordCheckedEvent(ctx, sessionAgg))
+	}
+	if checks.OTPCode != "" {
+		if err := c.HumanCheckMFAOTP(ctx, userID, checks.OTPCode, resourceOwner, authRequest); err != nil {
+			return nil, err
+		}
+		cmds = append(cmds, session.NewOTPCheckedEvent(ctx, sessionAgg))
+	}
+	if len(checks.WebAuthN) > 0 {
+		if err := c.HumanFinishPasswordlessLogin(ctx, userID, resourceOwner, checks.WebAuthN, authRequest); err != nil {
+			return nil, err
+		}
+		cmds = append(cmds, session.NewWebAuthNCheckedEvent(ctx, sessionAgg, true))
+	}
+	if checks.WebAuthNChallenge {
+		challenge, err := c.HumanBeginPasswordlessLogin(ctx, userID, resourceOwner, authRequest)
+		if err != nil {
+			return nil, err
+		}
+		changed.WebAuthNChallenge = challenge
+	}
+
+	tokenID, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+	changed.NewToken, err = domain.NewSessionToken(sessionWriteModel.AggregateID, tokenID, c.userEncryption)
+	if err != nil {
+		return nil, err
+	}
+	cmds = append(cmds, session.NewTokenSetEvent(ctx, sessionAgg, tokenID))
+
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(sessionWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	changed.ObjectDetails = writeModelToObjectDetails(&sessionWriteModel.WriteModel)
+	return changed, nil
+}
+
+func (c *Commands) activeSessionWriteModel(ctx context.Context, sessionID, sessionToken string) (*SessionWriteModel, error) {
+	if sessionID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ss3gq", "Errors.IDMissing")
+	}
+	sessionWriteModel, err := c.sessionWriteModelByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if sessionWriteModel.State != domain.SessionStateActive {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Gb3sa", "Errors.Session.NotFound")
+	}
+	if err = domain.VerifySessionToken(sessionToken, sessionID, sessionWriteModel.TokenID, c.userEncryption); err != nil {
+		return nil, err
+	}
+	return sessionWriteModel, nil
+}
+
+func (c *Commands) sessionIDPIntentWriteModel(ctx context.Context, sessionID, idpID string) (*SessionWriteModel, error) {
+	sessionWriteModel, err := c.sessionWriteModelByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if sessionWriteModel.State != domain.SessionStateActive {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Hrs3a", "Errors.Session.NotFound")
+	}
+	// an intent can only be used once and only for the latest started flow
+	if sessionWriteModel.IDPID != idpID || !sessionWriteModel.IDPCheckedAt.IsZero() || sessionWriteModel.IDPSuccessURL == "" {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ms2ga", "Errors.Session.IDP.NotStarted")
+	}
+	return sessionWriteModel, nil
+}
+
+func (c *Commands) sessionWriteModelByID(ctx context.Context, sessionID string) (writeModel *SessionWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewSessionWriteModel(sessionID, authz.GetInstance(ctx).InstanceID())
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
+
+// sessionAuthRequest represents the session as auth request for the checks of the user,
+// the session id is used as user agent as well, so the checks are not mixed with the ones of the login
+func sessionAuthRequest(sessionID string, browserInfo *domain.BrowserInfo) *domain.AuthRequest {
+	return &domain.AuthRequest{
+		ID:          sessionID,
+		AgentID:     sessionID,
+		BrowserInfo: browserInfo,
+	}
+}
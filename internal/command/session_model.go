@@ -0,0 +1,95 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/session"
+)
+
+type SessionWriteModel struct {
+	eventstore.WriteModel
+
+	State             domain.SessionState
+	UserID            string
+	UserResourceOwner string
+	UserCheckedAt     time.Time
+	PasswordCheckedAt time.Time
+	OTPCheckedAt      time.Time
+	WebAuthNCheckedAt time.Time
+	IDPID             string
+	IDPSuccessURL     string
+	IDPFailureURL     string
+	IDPCheckedAt      time.Time
+	TokenID           string
+	AuthRequestID     string
+}
+
+func NewSessionWriteModel(sessionID string, resourceOwner string) *SessionWriteModel {
+	return &SessionWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   sessionID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *SessionWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *session.AddedEvent:
+			wm.State = domain.SessionStateActive
+		case *session.UserCheckedEvent:
+			wm.UserID = e.UserID
+			wm.UserResourceOwner = e.UserResourceOwner
+			wm.UserCheckedAt = e.CreationDate()
+		case *session.PasswordCheckedEvent:
+			wm.PasswordCheckedAt = e.CreationDate()
+		case *session.OTPCheckedEvent:
+			wm.OTPCheckedAt = e.CreationDate()
+		case *session.WebAuthNCheckedEvent:
+			wm.WebAuthNCheckedAt = e.CreationDate()
+		case *session.IDPStartedEvent:
+			wm.IDPID = e.IDPID
+			wm.IDPSuccessURL = e.SuccessURL
+			wm.IDPFailureURL = e.FailureURL
+			wm.IDPCheckedAt = time.Time{}
+		case *session.IDPCheckedEvent:
+			wm.IDPID = e.IDPID
+			wm.IDPCheckedAt = e.CreationDate()
+		case *session.TokenSetEvent:
+			wm.TokenID = e.TokenID
+		case *session.AuthRequestLinkedEvent:
+			wm.AuthRequestID = e.AuthRequestID
+		case *session.TerminateEvent:
+			wm.State = domain.SessionStateTerminated
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *SessionWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(session.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			session.AddedType,
+			session.UserCheckedType,
+			session.PasswordCheckedType,
+			session.OTPCheckedType,
+			session.WebAuthNCheckedType,
+			session.IDPStartedType,
+			session.IDPCheckedType,
+			session.TokenSetType,
+			session.AuthRequestLinkedType,
+			session.TerminateType,
+		).
+		Builder()
+}
+
+func SessionAggregateFromWriteModel(wm *eventstore.WriteModel) *eventstore.Aggregate {
+	return eventstore.AggregateFromWriteModel(wm, session.AggregateType, session.AggregateVersion)
+}
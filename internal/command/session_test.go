@@ -0,0 +1,1174 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestCommands_CreateSession(t *testing.T) {
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx    context.Context
+		checks *SessionChecks
+	}
+	type res struct {
+		want *SessionChanged
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "without checks, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID("instance1",
+								session.NewAddedEvent(context.Background(),
+									&session.NewAggregate("session1", "instance1").Aggregate,
+								),
+							),
+							eventFromEventPusherWithInstanceID("instance1",
+								session.NewTokenSetEvent(context.Background(),
+									&session.NewAggregate("session1", "instance1").Aggregate,
+									"token1",
+								),
+							),
+						},
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "session1", "token1"),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+			},
+			res: res{
+				want: &SessionChanged{
+					ObjectDetails: &domain.ObjectDetails{
+						ResourceOwner: "instance1",
+					},
+					ID:       "session1",
+					NewToken: testSessionToken(t, "session1", "token1"),
+				},
+			},
+		},
+		{
+			name: "user not found, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "session1"),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				checks: &SessionChecks{
+					UserID: "user1",
+				},
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "password without user, precondition error",
+			fields: fields{
+				eventstore:  eventstoreExpect(t),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "session1"),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				checks: &SessionChecks{
+					Password: "password",
+				},
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "user checked, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(testHumanAddedEvent()),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID("instance1",
+								session.NewAddedEvent(context.Background(),
+									&session.NewAggregate("session1", "instance1").Aggregate,
+								),
+							),
+							eventFromEventPusherWithInstanceID("instance1",
+								session.NewUserCheckedEvent(context.Background(),
+									&session.NewAggregate("session1", "instance1").Aggregate,
+									"user1",
+									"org1",
+								),
+							),
+							eventFromEventPusherWithInstanceID("instance1",
+								session.NewTokenSetEvent(context.Background(),
+									&session.NewAggregate("session1", "instance1").Aggregate,
+									"token1",
+								),
+							),
+						},
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "session1", "token1"),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				checks: &SessionChecks{
+					UserID: "user1",
+				},
+			},
+			res: res{
+				want: &SessionChanged{
+					ObjectDetails: &domain.ObjectDetails{
+						ResourceOwner: "instance1",
+					},
+					ID:       "session1",
+					NewToken: testSessionToken(t, "session1", "token1"),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:     tt.fields.eventstore,
+				idGenerator:    tt.fields.idGenerator,
+				userEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			got, err := c.CreateSession(tt.args.ctx, tt.args.checks)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommands_UpdateSession(t *testing.T) {
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx          context.Context
+		sessionID    string
+		sessionToken string
+		checks       *SessionChecks
+	}
+	type res struct {
+		want *SessionChanged
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "session not found, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:          authz.WithInstanceID(context.Background(), "instance1"),
+				sessionID:    "session1",
+				sessionToken: testSessionToken(t, "session1", "token1"),
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "session terminated, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						testSessionEvents("session1", "token1", testSessionTerminated())...,
+					),
+				),
+			},
+			args: args{
+				ctx:          authz.WithInstanceID(context.Background(), "instance1"),
+				sessionID:    "session1",
+				sessionToken: testSessionToken(t, "session1", "token1"),
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "outdated token, permission denied error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						testSessionEvents("session1", "token2")...,
+					),
+				),
+			},
+			args: args{
+				ctx:          authz.WithInstanceID(context.Background(), "instance1"),
+				sessionID:    "session1",
+				sessionToken: testSessionToken(t, "session1", "token1"),
+			},
+			res: res{
+				err: caos_errs.IsPermissionDenied,
+			},
+		},
+		{
+			name: "other user, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						testSessionEvents("session1", "token1", testSessionUserChecked("user1", "org1"))...,
+					),
+				),
+			},
+			args: args{
+				ctx:          authz.WithInstanceID(context.Background(), "instance1"),
+				sessionID:    "session1",
+				sessionToken: testSessionToken(t, "session1", "token1"),
+				checks: &SessionChecks{
+					UserID: "user2",
+				},
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "token rotated, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						testSessionEvents("session1", "token1", testSessionUserChecked("user1", "org1"))...,
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID("instance1",
+								session.NewTokenSetEvent(context.Background(),
+									&session.NewAggregate("session1", "instance1").Aggregate,
+									"token2",
+								),
+							),
+						},
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "token2"),
+			},
+			args: args{
+				ctx:          authz.WithInstanceID(context.Background(), "instance1"),
+				sessionID:    "session1",
+				sessionToken: testSessionToken(t, "session1", "token1"),
+				checks: &SessionChecks{
+					UserID: "user1",
+				},
+			},
+			res: res{
+				want: &SessionChanged{
+					ObjectDetails: &domain.ObjectDetails{
+						ResourceOwner: "instance1",
+					},
+					ID:       "session1",
+					NewToken: testSessionToken(t, "session1", "token2"),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:     tt.fields.eventstore,
+				idGenerator:    tt.fields.idGenerator,
+				userEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			got, err := c.UpdateSession(tt.args.ctx, tt.args.sessionID, tt.args.sessionToken, tt.args.checks)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommands_TerminateSession(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx          context.Context
+		sessionID    string
+		sessionToken string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing id, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "invalid token, permission denied error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						testSessionEvents("session1", "token1")...,
+					),
+				),
+			},
+			args: args{
+				ctx:          authz.WithInstanceID(context.Background(), "instance1"),
+				sessionID:    "session1",
+				sessionToken: "invalid",
+			},
+			res: res{
+				err: caos_errs.IsPermissionDenied,
+			},
+		},
+		{
+			name: "terminated, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						testSessionEvents("session1", "token1")...,
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID("instance1",
+								session.NewTerminateEvent(context.Background(),
+									&session.NewAggregate("session1", "instance1").Aggregate,
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:          authz.WithInstanceID(context.Background(), "instance1"),
+				sessionID:    "session1",
+				sessionToken: testSessionToken(t, "session1", "token1"),
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:     tt.fields.eventstore,
+				userEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			got, err := c.TerminateSession(tt.args.ctx, tt.args.sessionID, tt.args.sessionToken)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommands_StartSessionIDPFlow(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx          context.Context
+		sessionID    string
+		sessionToken string
+		idpID        string
+		successURL   string
+		failureURL   string
+	}
+	type res struct {
+		intent string
+		err    func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing urls, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:          authz.WithInstanceID(context.Background(), "instance1"),
+				sessionID:    "session1",
+				sessionToken: testSessionToken(t, "session1", "token1"),
+				idpID:        "idp1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "started, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						testSessionEvents("session1", "token1")...,
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID("instance1",
+								session.NewIDPStartedEvent(context.Background(),
+									&session.NewAggregate("session1", "instance1").Aggregate,
+									"idp1",
+									"https://login.example.com/success",
+									"https://login.example.com/failure",
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:          authz.WithInstanceID(context.Background(), "instance1"),
+				sessionID:    "session1",
+				sessionToken: testSessionToken(t, "session1", "token1"),
+				idpID:        "idp1",
+				successURL:   "https://login.example.com/success",
+				failureURL:   "https://login.example.com/failure",
+			},
+			res: res{
+				intent: testSessionIDPIntent(t, "session1", "idp1"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:     tt.fields.eventstore,
+				userEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			intent, _, err := c.StartSessionIDPFlow(tt.args.ctx, tt.args.sessionID, tt.args.sessionToken, tt.args.idpID, tt.args.successURL, tt.args.failureURL)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.intent, intent)
+			}
+		})
+	}
+}
+
+func TestCommands_CheckSessionIDP(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx            context.Context
+		intent         string
+		userID         string
+		resourceOwner  string
+		externalUserID string
+	}
+	type res struct {
+		err func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "invalid intent, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:            authz.WithInstanceID(context.Background(), "instance1"),
+				intent:         "invalid",
+				userID:         "user1",
+				resourceOwner:  "org1",
+				externalUserID: "external1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "flow not started, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						testSessionEvents("session1", "token1")...,
+					),
+				),
+			},
+			args: args{
+				ctx:            authz.WithInstanceID(context.Background(), "instance1"),
+				intent:         testSessionIDPIntent(t, "session1", "idp1"),
+				userID:         "user1",
+				resourceOwner:  "org1",
+				externalUserID: "external1",
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "flow already checked, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						testSessionEvents("session1", "token1",
+							testSessionIDPStarted("idp1"),
+							eventFromEventPusherWithCreationDateNow(
+								session.NewIDPCheckedEvent(context.Background(),
+									&session.NewAggregate("session1", "instance1").Aggregate,
+									"idp1",
+									"external1",
+								),
+							),
+						)...,
+					),
+				),
+			},
+			args: args{
+				ctx:            authz.WithInstanceID(context.Background(), "instance1"),
+				intent:         testSessionIDPIntent(t, "session1", "idp1"),
+				userID:         "user1",
+				resourceOwner:  "org1",
+				externalUserID: "external1",
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "user not linked, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						testSessionEvents("session1", "token1", testSessionIDPStarted("idp1"))...,
+					),
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:            authz.WithInstanceID(context.Background(), "instance1"),
+				intent:         testSessionIDPIntent(t, "session1", "idp1"),
+				userID:         "user1",
+				resourceOwner:  "org1",
+				externalUserID: "external1",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "user checked, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						testSessionEvents("session1", "token1", testSessionIDPStarted("idp1"))...,
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserIDPLinkAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"idp1",
+								"name",
+								"external1",
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(testHumanAddedEvent()),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID("instance1",
+								user.NewUserIDPCheckSucceededEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									&user.AuthRequestInfo{
+										ID:          "session1",
+										UserAgentID: "session1",
+									},
+								),
+							),
+						},
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID("instance1",
+								session.NewUserCheckedEvent(context.Background(),
+									&session.NewAggregate("session1", "instance1").Aggregate,
+									"user1",
+									"org1",
+								),
+							),
+							eventFromEventPusherWithInstanceID("instance1",
+								session.NewIDPCheckedEvent(context.Background(),
+									&session.NewAggregate("session1", "instance1").Aggregate,
+									"idp1",
+									"external1",
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:            authz.WithInstanceID(context.Background(), "instance1"),
+				intent:         testSessionIDPIntent(t, "session1", "idp1"),
+				userID:         "user1",
+				resourceOwner:  "org1",
+				externalUserID: "external1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:     tt.fields.eventstore,
+				userEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			_, err := c.CheckSessionIDP(tt.args.ctx, tt.args.intent, tt.args.userID, tt.args.resourceOwner, tt.args.externalUserID)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
+
+func TestCommands_SessionChecksForAuthRequest(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx          context.Context
+		sessionID    string
+		sessionToken string
+		authRequest  *domain.AuthRequest
+	}
+	type res struct {
+		userID        string
+		resourceOwner string
+		err           func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing auth request, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:          authz.WithInstanceID(context.Background(), "instance1"),
+				sessionID:    "session1",
+				sessionToken: testSessionToken(t, "session1", "token1"),
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "user not checked, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						testSessionEvents("session1", "token1")...,
+					),
+				),
+			},
+			args: args{
+				ctx:          authz.WithInstanceID(context.Background(), "instance1"),
+				sessionID:    "session1",
+				sessionToken: testSessionToken(t, "session1", "token1"),
+				authRequest:  testSessionAuthRequest(""),
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "other requested org, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						testSessionEvents("session1", "token1", testSessionUserChecked("user1", "org1"))...,
+					),
+				),
+			},
+			args: args{
+				ctx:          authz.WithInstanceID(context.Background(), "instance1"),
+				sessionID:    "session1",
+				sessionToken: testSessionToken(t, "session1", "token1"),
+				authRequest:  testSessionAuthRequest("org2"),
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "already linked, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						testSessionEvents("session1", "token1",
+							testSessionUserChecked("user1", "org1"),
+							testSessionPasswordChecked(time.Now()),
+							eventFromEventPusher(
+								session.NewAuthRequestLinkedEvent(context.Background(),
+									&session.NewAggregate("session1", "instance1").Aggregate,
+									"authRequest0",
+								),
+							),
+						)...,
+					),
+				),
+			},
+			args: args{
+				ctx:          authz.WithInstanceID(context.Background(), "instance1"),
+				sessionID:    "session1",
+				sessionToken: testSessionToken(t, "session1", "token1"),
+				authRequest:  testSessionAuthRequest(""),
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "password check expired, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						testSessionEvents("session1", "token1",
+							testSessionUserChecked("user1", "org1"),
+							testSessionPasswordChecked(time.Now().Add(-2*time.Hour)),
+						)...,
+					),
+					expectFilter(
+						eventFromEventPusher(testHumanAddedEvent()),
+					),
+				),
+			},
+			args: args{
+				ctx:          authz.WithInstanceID(context.Background(), "instance1"),
+				sessionID:    "session1",
+				sessionToken: testSessionToken(t, "session1", "token1"),
+				authRequest:  testSessionAuthRequest(""),
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "password checked, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						testSessionEvents("session1", "token1",
+							testSessionUserChecked("user1", "org1"),
+							testSessionPasswordChecked(time.Now()),
+						)...,
+					),
+					expectFilter(
+						eventFromEventPusher(testHumanAddedEvent()),
+					),
+				),
+			},
+			args: args{
+				ctx:          authz.WithInstanceID(context.Background(), "instance1"),
+				sessionID:    "session1",
+				sessionToken: testSessionToken(t, "session1", "token1"),
+				authRequest:  testSessionAuthRequest(""),
+			},
+			res: res{
+				userID:        "user1",
+				resourceOwner: "org1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:     tt.fields.eventstore,
+				userEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			checks, err := c.SessionChecksForAuthRequest(tt.args.ctx, tt.args.sessionID, tt.args.sessionToken, tt.args.authRequest)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.userID, checks.UserID)
+				assert.Equal(t, tt.res.resourceOwner, checks.ResourceOwner)
+				assert.False(t, checks.PasswordCheckedAt.IsZero())
+			}
+		})
+	}
+}
+
+func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
+	checkedAt := time.Now().Add(-time.Minute)
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx         context.Context
+		checks      *SessionAuthRequestChecks
+		authRequest *domain.AuthRequest
+	}
+	type res struct {
+		err func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing checks, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:         authz.WithInstanceID(context.Background(), "instance1"),
+				authRequest: testSessionAuthRequest(""),
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "linked concurrently, already exists error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectPushFailed(
+						caos_errs.ThrowAlreadyExists(nil, "id", "Errors.Session.AuthRequest.AlreadyLinked"),
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID("instance1",
+								user.NewHumanPasswordCheckSucceededEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									&user.AuthRequestInfo{
+										ID:          "authRequest1",
+										UserAgentID: "agent1",
+										CheckedAt:   &checkedAt,
+									},
+								),
+							),
+							eventFromEventPusherWithInstanceID("instance1",
+								session.NewAuthRequestLinkedEvent(context.Background(),
+									&session.NewAggregate("session1", "instance1").Aggregate,
+									"authRequest1",
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraintWithInstanceID("instance1",
+							eventstore.NewAddEventUniqueConstraint(session.UniqueAuthRequestLinkedType, "session1", "Errors.Session.AuthRequest.AlreadyLinked"),
+						),
+						uniqueConstraintsFromEventConstraintWithInstanceID("instance1",
+							eventstore.NewAddEventUniqueConstraint(session.UniqueAuthRequestType, "authRequest1", "Errors.Session.AuthRequest.AlreadyHandled"),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:         authz.WithInstanceID(context.Background(), "instance1"),
+				checks:      testSessionAuthRequestChecks(checkedAt),
+				authRequest: testSessionAuthRequest(""),
+			},
+			res: res{
+				err: caos_errs.IsErrorAlreadyExists,
+			},
+		},
+		{
+			name: "auth request linked to another session, already exists error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectPushFailed(
+						caos_errs.ThrowAlreadyExists(nil, "id", "Errors.Session.AuthRequest.AlreadyHandled"),
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID("instance1",
+								user.NewHumanPasswordCheckSucceededEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									&user.AuthRequestInfo{
+										ID:          "authRequest1",
+										UserAgentID: "agent1",
+										CheckedAt:   &checkedAt,
+									},
+								),
+							),
+							eventFromEventPusherWithInstanceID("instance1",
+								session.NewAuthRequestLinkedEvent(context.Background(),
+									&session.NewAggregate("session1", "instance1").Aggregate,
+									"authRequest1",
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraintWithInstanceID("instance1",
+							eventstore.NewAddEventUniqueConstraint(session.UniqueAuthRequestLinkedType, "session1", "Errors.Session.AuthRequest.AlreadyLinked"),
+						),
+						uniqueConstraintsFromEventConstraintWithInstanceID("instance1",
+							eventstore.NewAddEventUniqueConstraint(session.UniqueAuthRequestType, "authRequest1", "Errors.Session.AuthRequest.AlreadyHandled"),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:         authz.WithInstanceID(context.Background(), "instance1"),
+				checks:      testSessionAuthRequestChecks(checkedAt),
+				authRequest: testSessionAuthRequest(""),
+			},
+			res: res{
+				err: caos_errs.IsErrorAlreadyExists,
+			},
+		},
+		{
+			name: "linked with original check time, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID("instance1",
+								user.NewHumanPasswordCheckSucceededEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									&user.AuthRequestInfo{
+										ID:          "authRequest1",
+										UserAgentID: "agent1",
+										CheckedAt:   &checkedAt,
+									},
+								),
+							),
+							eventFromEventPusherWithInstanceID("instance1",
+								session.NewAuthRequestLinkedEvent(context.Background(),
+									&session.NewAggregate("session1", "instance1").Aggregate,
+									"authRequest1",
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraintWithInstanceID("instance1",
+							eventstore.NewAddEventUniqueConstraint(session.UniqueAuthRequestLinkedType, "session1", "Errors.Session.AuthRequest.AlreadyLinked"),
+						),
+						uniqueConstraintsFromEventConstraintWithInstanceID("instance1",
+							eventstore.NewAddEventUniqueConstraint(session.UniqueAuthRequestType, "authRequest1", "Errors.Session.AuthRequest.AlreadyHandled"),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:         authz.WithInstanceID(context.Background(), "instance1"),
+				checks:      testSessionAuthRequestChecks(checkedAt),
+				authRequest: testSessionAuthRequest(""),
+			},
+			res: res{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			err := c.LinkSessionToAuthRequest(tt.args.ctx, tt.args.checks, tt.args.authRequest)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
+
+func testSessionAuthRequest(requestedOrgID string) *domain.AuthRequest {
+	return &domain.AuthRequest{
+		ID:             "authRequest1",
+		AgentID:        "agent1",
+		RequestedOrgID: requestedOrgID,
+		CreationDate:   time.Now(),
+		LoginPolicy: &domain.LoginPolicy{
+			PasswordCheckLifetime:      time.Hour,
+			ExternalLoginCheckLifetime: time.Hour,
+			SecondFactorCheckLifetime:  time.Hour,
+			MultiFactorCheckLifetime:   time.Hour,
+		},
+	}
+}
+
+func testSessionAuthRequestChecks(passwordCheckedAt time.Time) *SessionAuthRequestChecks {
+	sessionWriteModel := NewSessionWriteModel("session1", "instance1")
+	sessionWriteModel.UserID = "user1"
+	sessionWriteModel.UserResourceOwner = "org1"
+	userWriteModel := NewUserWriteModel("user1", "org1")
+	userWriteModel.UserState = domain.UserStateActive
+	return &SessionAuthRequestChecks{
+		SessionID:         "session1",
+		UserID:            "user1",
+		ResourceOwner:     "org1",
+		PasswordCheckedAt: passwordCheckedAt,
+		sessionWriteModel: sessionWriteModel,
+		userWriteModel:    userWriteModel,
+	}
+}
+
+func testSessionPasswordChecked(checkedAt time.Time) *repository.Event {
+	e := eventFromEventPusher(
+		session.NewPasswordCheckedEvent(context.Background(),
+			&session.NewAggregate("session1", "instance1").Aggregate,
+		),
+	)
+	e.CreationDate = checkedAt
+	return e
+}
+
+func testSessionToken(t *testing.T, sessionID, tokenID string) string {
+	token, err := domain.NewSessionToken(sessionID, tokenID, crypto.CreateMockEncryptionAlg(gomock.NewController(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func testSessionIDPIntent(t *testing.T, sessionID, idpID string) string {
+	intent, err := domain.NewSessionIDPIntent(sessionID, idpID, crypto.CreateMockEncryptionAlg(gomock.NewController(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return intent
+}
+
+// testSessionEvents returns the events of an added session with the token and the additional events
+func testSessionEvents(sessionID, tokenID string, events ...*repository.Event) []*repository.Event {
+	return append([]*repository.Event{
+		eventFromEventPusher(
+			session.NewAddedEvent(context.Background(),
+				&session.NewAggregate(sessionID, "instance1").Aggregate,
+			),
+		),
+		eventFromEventPusher(
+			session.NewTokenSetEvent(context.Background(),
+				&session.NewAggregate(sessionID, "instance1").Aggregate,
+				tokenID,
+			),
+		),
+	}, events...)
+}
+
+func testSessionUserChecked(userID, resourceOwner string) *repository.Event {
+	return eventFromEventPusherWithCreationDateNow(
+		session.NewUserCheckedEvent(context.Background(),
+			&session.NewAggregate("session1", "instance1").Aggregate,
+			userID,
+			resourceOwner,
+		),
+	)
+}
+
+func testSessionIDPStarted(idpID string) *repository.Event {
+	return eventFromEventPusher(
+		session.NewIDPStartedEvent(context.Background(),
+			&session.NewAggregate("session1", "instance1").Aggregate,
+			idpID,
+			"https://login.example.com/success",
+			"https://login.example.com/failure",
+		),
+	)
+}
+
+func testSessionTerminated() *repository.Event {
+	return eventFromEventPusher(
+		session.NewTerminateEvent(context.Background(),
+			&session.NewAggregate("session1", "instance1").Aggregate,
+		),
+	)
+}
+
+func testHumanAddedEvent() eventstore.Command {
+	return user.NewHumanAddedEvent(context.Background(),
+		&user.NewAggregate("user1", "org1").Aggregate,
+		"username",
+		"firstname",
+		"lastname",
+		"nickname",
+		"displayname",
+		language.German,
+		domain.GenderUnspecified,
+		"email@test.ch",
+		true,
+	)
+}
@@ -0,0 +1,120 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+// UserManagementActions runs the actions of the organisation set on the triggers of the user management flow.
+// It's implemented by the actions (see usermanagement.Actions), as the command side must not depend on the queries.
+// An error of an action which is not allowed to fail is returned.
+type UserManagementActions interface {
+	PreCreation(ctx context.Context, user *domain.Human) error
+	PostCreation(ctx context.Context, userID, resourceOwner string) error
+	PreUpdate(ctx context.Context, profile *domain.Profile) error
+	PostUpdate(ctx context.Context, profile *domain.Profile) error
+	PreDeactivation(ctx context.Context, userID, resourceOwner string) error
+	PostDeactivation(ctx context.Context, userID, resourceOwner string) error
+}
+
+func (c *Commands) runPreHumanCreationActions(ctx context.Context, userID, resourceOwner string, human *AddHuman) error {
+	if c.userManagementActions == nil {
+		return nil
+	}
+	user := human.toDomain(userID, resourceOwner)
+	if err := c.userManagementActions.PreCreation(ctx, user); err != nil {
+		return err
+	}
+	human.fromDomain(user)
+	return nil
+}
+
+// runPostHumanCreationActions only logs failing actions as the user is already created
+func (c *Commands) runPostHumanCreationActions(ctx context.Context, userID, resourceOwner string) {
+	if c.userManagementActions == nil {
+		return
+	}
+	err := c.userManagementActions.PostCreation(ctx, userID, resourceOwner)
+	logging.WithFields("userid", userID).OnError(err).Warn("post creation actions failed")
+}
+
+func (c *Commands) runPreProfileUpdateActions(ctx context.Context, profile *domain.Profile) error {
+	if c.userManagementActions == nil {
+		return nil
+	}
+	return c.userManagementActions.PreUpdate(ctx, profile)
+}
+
+// runPostProfileUpdateActions only logs failing actions as the profile is already changed
+func (c *Commands) runPostProfileUpdateActions(ctx context.Context, profile *domain.Profile) {
+	if c.userManagementActions == nil {
+		return
+	}
+	err := c.userManagementActions.PostUpdate(ctx, profile)
+	logging.WithFields("userid", profile.AggregateID).OnError(err).Warn("post update actions failed")
+}
+
+func (c *Commands) runPreDeactivationActions(ctx context.Context, userID, resourceOwner string) error {
+	if c.userManagementActions == nil {
+		return nil
+	}
+	return c.userManagementActions.PreDeactivation(ctx, userID, resourceOwner)
+}
+
+// runPostDeactivationActions only logs failing actions as the user is already deactivated
+func (c *Commands) runPostDeactivationActions(ctx context.Context, userID, resourceOwner string) {
+	if c.userManagementActions == nil {
+		return
+	}
+	err := c.userManagementActions.PostDeactivation(ctx, userID, resourceOwner)
+	logging.WithFields("userid", userID).OnError(err).Warn("post deactivation actions failed")
+}
+
+func (h *AddHuman) toDomain(userID, resourceOwner string) *domain.Human {
+	return &domain.Human{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+		Username: h.Username,
+		State:    domain.UserStateUnspecified,
+		Profile: &domain.Profile{
+			FirstName:         h.FirstName,
+			LastName:          h.LastName,
+			NickName:          h.NickName,
+			DisplayName:       h.DisplayName,
+			PreferredLanguage: h.PreferredLanguage,
+			Gender:            h.Gender,
+		},
+		Email: &domain.Email{
+			EmailAddress:    h.Email.Address,
+			IsEmailVerified: h.Email.Verified,
+		},
+		Phone: &domain.Phone{
+			PhoneNumber:     h.Phone.Number,
+			IsPhoneVerified: h.Phone.Verified,
+		},
+	}
+}
+
+func (h *AddHuman) fromDomain(user *domain.Human) {
+	h.Username = user.Username
+	h.FirstName = user.FirstName
+	h.LastName = user.LastName
+	h.NickName = user.NickName
+	h.DisplayName = user.DisplayName
+	h.PreferredLanguage = user.PreferredLanguage
+	h.Gender = user.Gender
+	h.Email = Email{
+		Address:  user.EmailAddress,
+		Verified: user.IsEmailVerified,
+	}
+	h.Phone = Phone{
+		Number:   user.PhoneNumber,
+		Verified: user.IsPhoneVerified,
+	}
+}
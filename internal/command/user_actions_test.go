@@ -0,0 +1,156 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// mockUserManagementActions runs the functions set for the trigger types
+type mockUserManagementActions struct {
+	preCreation     func(user *domain.Human) error
+	preDeactivation func(userID, resourceOwner string) error
+}
+
+func (m *mockUserManagementActions) PreCreation(_ context.Context, user *domain.Human) error {
+	if m.preCreation == nil {
+		return nil
+	}
+	return m.preCreation(user)
+}
+
+func (m *mockUserManagementActions) PostCreation(context.Context, string, string) error {
+	return nil
+}
+
+func (m *mockUserManagementActions) PreUpdate(context.Context, *domain.Profile) error {
+	return nil
+}
+
+func (m *mockUserManagementActions) PostUpdate(context.Context, *domain.Profile) error {
+	return nil
+}
+
+func (m *mockUserManagementActions) PreDeactivation(_ context.Context, userID, resourceOwner string) error {
+	if m.preDeactivation == nil {
+		return nil
+	}
+	return m.preDeactivation(userID, resourceOwner)
+}
+
+func (m *mockUserManagementActions) PostDeactivation(context.Context, string, string) error {
+	return nil
+}
+
+func TestCommands_runPreHumanCreationActions(t *testing.T) {
+	type fields struct {
+		userManagementActions UserManagementActions
+	}
+	type args struct {
+		human *AddHuman
+	}
+	type res struct {
+		want *AddHuman
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name:   "no actions, unchanged",
+			fields: fields{},
+			args: args{
+				human: &AddHuman{
+					Username:  "username",
+					FirstName: "firstname",
+				},
+			},
+			res: res{
+				want: &AddHuman{
+					Username:  "username",
+					FirstName: "firstname",
+				},
+			},
+		},
+		{
+			name: "actions change user, changed",
+			fields: fields{
+				userManagementActions: &mockUserManagementActions{
+					preCreation: func(user *domain.Human) error {
+						if user.AggregateID != "user1" || user.ResourceOwner != "org1" {
+							return errors.ThrowInvalidArgument(nil, "id", "wrong user")
+						}
+						user.FirstName += "-changed"
+						user.PreferredLanguage = language.German
+						user.EmailAddress = "changed@test.ch"
+						user.IsEmailVerified = true
+						return nil
+					},
+				},
+			},
+			args: args{
+				human: &AddHuman{
+					Username:  "username",
+					FirstName: "firstname",
+					Email: Email{
+						Address: "email@test.ch",
+					},
+				},
+			},
+			res: res{
+				want: &AddHuman{
+					Username:          "username",
+					FirstName:         "firstname-changed",
+					PreferredLanguage: language.German,
+					Email: Email{
+						Address:  "changed@test.ch",
+						Verified: true,
+					},
+				},
+			},
+		},
+		{
+			name: "actions fail, error",
+			fields: fields{
+				userManagementActions: &mockUserManagementActions{
+					preCreation: func(*domain.Human) error {
+						return errors.ThrowPreconditionFailed(nil, "id", "not allowed")
+					},
+				},
+			},
+			args: args{
+				human: &AddHuman{
+					Username: "username",
+				},
+			},
+			res: res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				userManagementActions: tt.fields.userManagementActions,
+			}
+			err := c.runPreHumanCreationActions(context.Background(), "user1", "org1", tt.args.human)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, tt.args.human)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// RequestUserDeletion deactivates the (human) user and schedules its removal
+// after the deletion grace period of the privacy policy.
+// Until then the user can cancel the deletion by logging in.
+func (c *Commands) RequestUserDeletion(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Gk3sd", "Errors.User.UserIDMissing")
+	}
+	existingUser, err := c.userDeletionWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !isUserStateExists(existingUser.UserState) {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-Bm2fs", "Errors.User.NotFound")
+	}
+	if existingUser.UserType != domain.UserTypeHuman {
+		return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-Pw9sd", "Errors.User.NotHuman")
+	}
+	if existingUser.DeletionRequested {
+		return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-Ls0ds", "Errors.User.Deletion.AlreadyRequested")
+	}
+	if existingUser.UserState != domain.UserStateActive {
+		return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-Ox2nd", "Errors.User.Deletion.UserNotActive")
+	}
+	privacyPolicy, err := c.getOrgPrivacyPolicy(ctx, existingUser.ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	userAgg := UserAggregateFromWriteModel(&existingUser.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx,
+		user.NewUserDeactivatedEvent(ctx, userAgg),
+		user.NewUserDeletionRequestedEvent(ctx, userAgg, privacyPolicy.DeletionGracePeriod),
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingUser, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingUser.WriteModel), nil
+}
+
+// CancelUserDeletion cancels a pending deletion and reactivates the user
+func (c *Commands) CancelUserDeletion(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Hs9fw", "Errors.User.UserIDMissing")
+	}
+	existingUser, err := c.userDeletionWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !isUserStateExists(existingUser.UserState) {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-Wp2ns", "Errors.User.NotFound")
+	}
+	if !existingUser.DeletionRequested {
+		return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-Zk3fs", "Errors.User.Deletion.NotRequested")
+	}
+	userAgg := UserAggregateFromWriteModel(&existingUser.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx,
+		user.NewUserDeletionCanceledEvent(ctx, userAgg),
+		user.NewUserReactivatedEvent(ctx, userAgg),
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingUser, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingUser.WriteModel), nil
+}
+
+// DueUserDeletions returns the users of the instance whose deletion grace period is over
+func (c *Commands) DueUserDeletions(ctx context.Context) ([]*domain.ScheduledUserDeletion, error) {
+	deletions := NewScheduledUserDeletionsReadModel()
+	err := c.eventstore.FilterToQueryReducer(ctx, deletions)
+	if err != nil {
+		return nil, err
+	}
+	return deletions.due(time.Now()), nil
+}
+
+// ExecuteUserDeletion removes the user if the deletion is still pending and the grace period is over
+func (c *Commands) ExecuteUserDeletion(ctx context.Context, userID, resourceOwner string, cascadingUserMemberships []*CascadingMembership, cascadingGrantIDs ...string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Jd8sf", "Errors.User.UserIDMissing")
+	}
+	existingUser, err := c.userDeletionWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !isUserStateExists(existingUser.UserState) {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-Vb3sg", "Errors.User.NotFound")
+	}
+	if !existingUser.isDue(time.Now()) {
+		return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-Rm2fd", "Errors.User.Deletion.NotDue")
+	}
+	return c.RemoveUser(ctx, userID, existingUser.ResourceOwner, cascadingUserMemberships, cascadingGrantIDs...)
+}
+
+func (c *Commands) userDeletionWriteModelByID(ctx context.Context, userID, resourceOwner string) (*UserDeletionWriteModel, error) {
+	writeModel := NewUserDeletionWriteModel(userID, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -0,0 +1,117 @@
+package command
+
+import (
+	"sort"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// UserDeletionWriteModel extends the user with the state of a self requested deletion.
+// Reactivating the user cancels the deletion as well.
+type UserDeletionWriteModel struct {
+	UserWriteModel
+
+	DeletionRequested bool
+	ScheduledDate     time.Time
+}
+
+func NewUserDeletionWriteModel(userID, resourceOwner string) *UserDeletionWriteModel {
+	return &UserDeletionWriteModel{
+		UserWriteModel: *NewUserWriteModel(userID, resourceOwner),
+	}
+}
+
+func (wm *UserDeletionWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.UserDeletionRequestedEvent:
+			wm.DeletionRequested = true
+			wm.ScheduledDate = e.ScheduledDate()
+		case *user.UserDeletionCanceledEvent,
+			*user.UserReactivatedEvent,
+			*user.UserRemovedEvent:
+			wm.DeletionRequested = false
+			wm.ScheduledDate = time.Time{}
+		}
+	}
+	return wm.UserWriteModel.Reduce()
+}
+
+func (wm *UserDeletionWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return wm.UserWriteModel.Query().
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			user.UserDeletionRequestedType,
+			user.UserDeletionCanceledType).
+		Builder()
+}
+
+// isDue returns true if the deletion was requested and the grace period is over
+func (wm *UserDeletionWriteModel) isDue(now time.Time) bool {
+	return wm.DeletionRequested && !wm.ScheduledDate.After(now)
+}
+
+// ScheduledUserDeletionsReadModel collects the pending deletions of all users of the instance
+type ScheduledUserDeletionsReadModel struct {
+	eventstore.WriteModel
+
+	Deletions map[string]*domain.ScheduledUserDeletion
+}
+
+func NewScheduledUserDeletionsReadModel() *ScheduledUserDeletionsReadModel {
+	return &ScheduledUserDeletionsReadModel{
+		Deletions: make(map[string]*domain.ScheduledUserDeletion),
+	}
+}
+
+func (rm *ScheduledUserDeletionsReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *user.UserDeletionRequestedEvent:
+			rm.Deletions[e.Aggregate().ID] = &domain.ScheduledUserDeletion{
+				UserID:        e.Aggregate().ID,
+				ResourceOwner: e.Aggregate().ResourceOwner,
+				ScheduledDate: e.ScheduledDate(),
+			}
+		case *user.UserDeletionCanceledEvent:
+			delete(rm.Deletions, e.Aggregate().ID)
+		case *user.UserReactivatedEvent:
+			delete(rm.Deletions, e.Aggregate().ID)
+		case *user.UserRemovedEvent:
+			delete(rm.Deletions, e.Aggregate().ID)
+		}
+	}
+	return rm.WriteModel.Reduce()
+}
+
+func (rm *ScheduledUserDeletionsReadModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		EventTypes(
+			user.UserDeletionRequestedType,
+			user.UserDeletionCanceledType,
+			user.UserReactivatedType,
+			user.UserRemovedType).
+		Builder()
+}
+
+// due returns the deletions whose grace period is over
+func (rm *ScheduledUserDeletionsReadModel) due(now time.Time) []*domain.ScheduledUserDeletion {
+	deletions := make([]*domain.ScheduledUserDeletion, 0, len(rm.Deletions))
+	for _, deletion := range rm.Deletions {
+		if deletion.ScheduledDate.After(now) {
+			continue
+		}
+		deletions = append(deletions, deletion)
+	}
+	sort.Slice(deletions, func(i, j int) bool {
+		return deletions[i].ScheduledDate.Before(deletions[j].ScheduledDate)
+	})
+	return deletions
+}
@@ -69,6 +69,9 @@ func (c *Commands) AddHumanWithID(ctx context.Context, resourceOwner string, use
 }
 
 func (c *Commands) addHumanWithID(ctx context.Context, resourceOwner string, userID string, human *AddHuman) (*domain.HumanDetails, error) {
+	if err := c.runPreHumanCreationActions(ctx, userID, resourceOwner, human); err != nil {
+		return nil, err
+	}
 	agg := user.NewAggregate(userID, resourceOwner)
 	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, AddHumanCommand(agg, human, c.userPasswordAlg, c.userEncryption))
 	if err != nil {
@@ -79,6 +82,7 @@ func (c *Commands) addHumanWithID(ctx context.Context, resourceOwner string, use
 	if err != nil {
 		return nil, err
 	}
+	c.runPostHumanCreationActions(ctx, userID, resourceOwner)
 
 	return &domain.HumanDetails{
 		ID: userID,
@@ -401,6 +405,11 @@ func (c *Commands) importHuman(ctx context.Context, orgID string, human *domain.
 	if orgID == "" || !human.IsValid() {
 		return nil, nil, nil, "", errors.ThrowInvalidArgument(nil, "COMMAND-00p2b", "Errors.User.Invalid")
 	}
+	if human.HashedPassword != nil {
+		if err := human.HashedPassword.Validate(); err != nil {
+			return nil, nil, nil, "", err
+		}
+	}
 	events, humanWriteModel, err = c.createHuman(ctx, orgID, human, links, false, passwordless, domainPolicy, pwPolicy, initCodeGenerator, emailCodeGenerator, phoneCodeGenerator)
 	if err != nil {
 		return nil, nil, nil, "", err
@@ -479,6 +488,10 @@ func (c *Commands) createHuman(ctx context.Context, orgID string, human *domain.
 	} else {
 		events = append(events, createAddHumanEvent(ctx, userAgg, human, domainPolicy.UserLoginMustBeDomain))
 	}
+	// imported hashes are stored as they are and verified on the first login
+	if human.HashedPassword != nil && human.HashedPassword.SecretCrypto != nil {
+		events = append(events, user.NewHumanPasswordChangedEvent(ctx, userAgg, human.HashedPassword.SecretCrypto, human.HashedPassword.ChangeRequired, ""))
+	}
 
 	for _, link := range links {
 		event, err := c.addUserIDPLink(ctx, userAgg, link)
@@ -566,9 +579,6 @@ func createAddHumanEvent(ctx context.Context, aggregate *eventstore.Aggregate, h
 	if human.Password != nil {
 		addEvent.AddPasswordData(human.Password.SecretCrypto, human.Password.ChangeRequired)
 	}
-	if human.HashedPassword != nil {
-		addEvent.AddPasswordData(human.HashedPassword.SecretCrypto, false)
-	}
 	return addEvent
 }
 
@@ -600,9 +610,6 @@ func createRegisterHumanEvent(ctx context.Context, aggregate *eventstore.Aggrega
 	if human.Password != nil {
 		addEvent.AddPasswordData(human.Password.SecretCrypto, human.Password.ChangeRequired)
 	}
-	if human.HashedPassword != nil {
-		addEvent.AddPasswordData(human.HashedPassword.SecretCrypto, false)
-	}
 	return addEvent
 }
 
@@ -0,0 +1,44 @@
+package command
+
+import (
+	"context"
+	"net"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// HumanEvaluateLoginRisk evaluates the risk rules of the login policy once per auth request.
+// The decision is recorded on the user for auditing and returned as is on further calls.
+func (c *Commands) HumanEvaluateLoginRisk(ctx context.Context, userID, resourceOwner, country string, rules domain.LoginPolicyRiskRules, authRequest *domain.AuthRequest) (_ *domain.LoginRiskDecision, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Rk3fs", "Errors.User.UserIDMissing")
+	}
+	if authRequest == nil {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Rk4gd", "Errors.Internal")
+	}
+	riskWriteModel := NewHumanLoginRiskWriteModel(userID, resourceOwner, authRequest.ID)
+	err = c.eventstore.FilterToQueryReducer(ctx, riskWriteModel)
+	if err != nil {
+		return nil, err
+	}
+	if riskWriteModel.Decision != nil {
+		return riskWriteModel.Decision, nil
+	}
+	var remoteIP net.IP
+	if authRequest.BrowserInfo != nil {
+		remoteIP = authRequest.BrowserInfo.RemoteIP
+	}
+	reasons := rules.Evaluate(remoteIP, riskWriteModel.KnownUserAgents[authRequest.AgentID], country, riskWriteModel.LastCountry)
+	userAgg := UserAggregateFromWriteModel(&riskWriteModel.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewHumanLoginRiskEvaluatedEvent(ctx, userAgg, country, reasons, authRequestDomainToAuthRequestInfo(authRequest)))
+	if err != nil {
+		return nil, err
+	}
+	return &domain.LoginRiskDecision{Reasons: reasons}, nil
+}
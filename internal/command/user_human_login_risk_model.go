@@ -0,0 +1,114 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// HumanLoginRiskWriteModel collects the previous risk decisions of the user.
+// A user agent is only known (and its country the last one)
+// after a login passed the decision, either without requiring a second factor
+// or by checking one afterwards.
+type HumanLoginRiskWriteModel struct {
+	eventstore.WriteModel
+
+	authRequestID string
+	// Decision of the current auth request, nil if not yet evaluated
+	Decision *domain.LoginRiskDecision
+
+	KnownUserAgents map[string]bool
+	LastCountry     string
+	// pendingCountries of user agents which still have to pass a second factor
+	pendingCountries map[string]string
+}
+
+func NewHumanLoginRiskWriteModel(userID, resourceOwner, authRequestID string) *HumanLoginRiskWriteModel {
+	return &HumanLoginRiskWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+		authRequestID:    authRequestID,
+		KnownUserAgents:  make(map[string]bool),
+		pendingCountries: make(map[string]string),
+	}
+}
+
+func (wm *HumanLoginRiskWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanLoginRiskEvaluatedEvent:
+			wm.reduceRiskEvaluated(e)
+		case *user.HumanOTPCheckSucceededEvent:
+			wm.reduceSecondFactorChecked(e.AuthRequestInfo)
+		case *user.HumanOTPSMSCheckSucceededEvent:
+			wm.reduceSecondFactorChecked(e.AuthRequestInfo)
+		case *user.HumanOTPEmailCheckSucceededEvent:
+			wm.reduceSecondFactorChecked(e.AuthRequestInfo)
+		case *user.HumanRecoveryCodeCheckSucceededEvent:
+			wm.reduceSecondFactorChecked(e.AuthRequestInfo)
+		case *user.HumanU2FCheckSucceededEvent:
+			wm.reduceSecondFactorChecked(e.AuthRequestInfo)
+		case *user.HumanPasswordlessCheckSucceededEvent:
+			wm.reduceSecondFactorChecked(e.AuthRequestInfo)
+		case *user.UserRemovedEvent:
+			wm.KnownUserAgents = make(map[string]bool)
+			wm.pendingCountries = make(map[string]string)
+			wm.LastCountry = ""
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *HumanLoginRiskWriteModel) reduceRiskEvaluated(e *user.HumanLoginRiskEvaluatedEvent) {
+	if e.AuthRequestInfo == nil {
+		return
+	}
+	if e.AuthRequestInfo.ID == wm.authRequestID {
+		wm.Decision = &domain.LoginRiskDecision{Reasons: e.Reasons}
+	}
+	if e.MFARequired() {
+		wm.pendingCountries[e.UserAgentID] = e.Country
+		return
+	}
+	wm.trust(e.UserAgentID, e.Country)
+}
+
+func (wm *HumanLoginRiskWriteModel) reduceSecondFactorChecked(info *user.AuthRequestInfo) {
+	if info == nil {
+		return
+	}
+	country, ok := wm.pendingCountries[info.UserAgentID]
+	if !ok {
+		return
+	}
+	delete(wm.pendingCountries, info.UserAgentID)
+	wm.trust(info.UserAgentID, country)
+}
+
+func (wm *HumanLoginRiskWriteModel) trust(userAgentID, country string) {
+	wm.KnownUserAgents[userAgentID] = true
+	if country != "" {
+		wm.LastCountry = country
+	}
+}
+
+func (wm *HumanLoginRiskWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			user.HumanLoginRiskEvaluatedType,
+			user.HumanMFAOTPCheckSucceededType,
+			user.HumanOTPSMSCheckSucceededType,
+			user.HumanOTPEmailCheckSucceededType,
+			user.HumanRecoveryCodeCheckSucceededType,
+			user.HumanU2FTokenCheckSucceededType,
+			user.HumanPasswordlessTokenCheckSucceededType,
+			user.UserRemovedType,
+		).
+		Builder()
+}
@@ -0,0 +1,274 @@
+package command
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestCommandSide_HumanEvaluateLoginRisk(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type (
+		args struct {
+			ctx           context.Context
+			userID        string
+			resourceOwner string
+			country       string
+			rules         domain.LoginPolicyRiskRules
+			authRequest   *domain.AuthRequest
+		}
+	)
+	type res struct {
+		want *domain.LoginRiskDecision
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "userid missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "",
+				resourceOwner: "org1",
+				authRequest:   &domain.AuthRequest{ID: "authRequestID", AgentID: "agent1"},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "already evaluated, recorded decision",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanLoginRiskEvaluatedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"CH",
+								[]domain.LoginRiskReason{domain.LoginRiskReasonNewUserAgent},
+								&user.AuthRequestInfo{ID: "authRequestID", UserAgentID: "agent1"},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				country:       "CH",
+				rules:         domain.LoginPolicyRiskRules{MFAOnNewUserAgent: true},
+				authRequest:   &domain.AuthRequest{ID: "authRequestID", AgentID: "agent1"},
+			},
+			res: res{
+				want: &domain.LoginRiskDecision{Reasons: []domain.LoginRiskReason{domain.LoginRiskReasonNewUserAgent}},
+			},
+		},
+		{
+			name: "new user agent, mfa required",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanLoginRiskEvaluatedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"CH",
+									[]domain.LoginRiskReason{domain.LoginRiskReasonNewUserAgent},
+									&user.AuthRequestInfo{
+										ID:          "authRequestID",
+										UserAgentID: "agent1",
+										BrowserInfo: &user.BrowserInfo{RemoteIP: net.IPv4(10, 0, 0, 1)},
+									},
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				country:       "CH",
+				rules:         domain.LoginPolicyRiskRules{TrustedIPRanges: []string{"10.0.0.0/8"}, MFAOnNewUserAgent: true},
+				authRequest: &domain.AuthRequest{
+					ID:          "authRequestID",
+					AgentID:     "agent1",
+					BrowserInfo: &domain.BrowserInfo{RemoteIP: net.IPv4(10, 0, 0, 1)},
+				},
+			},
+			res: res{
+				want: &domain.LoginRiskDecision{Reasons: []domain.LoginRiskReason{domain.LoginRiskReasonNewUserAgent}},
+			},
+		},
+		{
+			name: "user agent known after second factor, country changed",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanLoginRiskEvaluatedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"CH",
+								[]domain.LoginRiskReason{domain.LoginRiskReasonNewUserAgent},
+								&user.AuthRequestInfo{ID: "authRequestID1", UserAgentID: "agent1"},
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPCheckSucceededEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								&user.AuthRequestInfo{ID: "authRequestID1", UserAgentID: "agent1"},
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanLoginRiskEvaluatedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"DE",
+									[]domain.LoginRiskReason{domain.LoginRiskReasonCountryChanged},
+									&user.AuthRequestInfo{ID: "authRequestID2", UserAgentID: "agent1"},
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				country:       "DE",
+				rules:         domain.LoginPolicyRiskRules{MFAOnNewUserAgent: true, MFAOnCountryChange: true},
+				authRequest:   &domain.AuthRequest{ID: "authRequestID2", AgentID: "agent1"},
+			},
+			res: res{
+				want: &domain.LoginRiskDecision{Reasons: []domain.LoginRiskReason{domain.LoginRiskReasonCountryChanged}},
+			},
+		},
+		{
+			name: "second factor not checked, user agent still new",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanLoginRiskEvaluatedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"CH",
+								[]domain.LoginRiskReason{domain.LoginRiskReasonNewUserAgent},
+								&user.AuthRequestInfo{ID: "authRequestID1", UserAgentID: "agent1"},
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanLoginRiskEvaluatedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"CH",
+									[]domain.LoginRiskReason{domain.LoginRiskReasonNewUserAgent},
+									&user.AuthRequestInfo{ID: "authRequestID2", UserAgentID: "agent1"},
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				country:       "CH",
+				rules:         domain.LoginPolicyRiskRules{MFAOnNewUserAgent: true},
+				authRequest:   &domain.AuthRequest{ID: "authRequestID2", AgentID: "agent1"},
+			},
+			res: res{
+				want: &domain.LoginRiskDecision{Reasons: []domain.LoginRiskReason{domain.LoginRiskReasonNewUserAgent}},
+			},
+		},
+		{
+			name: "known user agent, no reasons",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanLoginRiskEvaluatedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"CH",
+								nil,
+								&user.AuthRequestInfo{ID: "authRequestID1", UserAgentID: "agent1"},
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanLoginRiskEvaluatedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"CH",
+									[]domain.LoginRiskReason{},
+									&user.AuthRequestInfo{ID: "authRequestID2", UserAgentID: "agent1"},
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				country:       "CH",
+				rules:         domain.LoginPolicyRiskRules{MFAOnNewUserAgent: true, MFAOnCountryChange: true},
+				authRequest:   &domain.AuthRequest{ID: "authRequestID2", AgentID: "agent1"},
+			},
+			res: res{
+				want: &domain.LoginRiskDecision{Reasons: []domain.LoginRiskReason{}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.HumanEvaluateLoginRisk(tt.args.ctx, tt.args.userID, tt.args.resourceOwner, tt.args.country, tt.args.rules, tt.args.authRequest)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
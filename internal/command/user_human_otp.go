@@ -2,17 +2,23 @@ package command
 
 import (
 	"context"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/crypto"
 
 	"github.com/zitadel/logging"
 	"github.com/zitadel/zitadel/internal/domain"
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 	"github.com/zitadel/zitadel/internal/repository/user"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 )
 
+// otpCodeResendCooldown is the minimum time between two codes sent by SMS or email,
+// so the user cannot be flooded with messages
+const otpCodeResendCooldown = 30 * time.Second
+
 func (c *Commands) ImportHumanOTP(ctx context.Context, userID, userAgentID, resourceowner string, key string) error {
 	encryptedSecret, err := crypto.Encrypt([]byte(key), c.multifactors.OTP.CryptoMFA)
 	if err != nil {
@@ -137,8 +143,7 @@ func (c *Commands) HumanCheckMFAOTP(ctx context.Context, userID, code, resourceo
 		_, err = c.eventstore.Push(ctx, user.NewHumanOTPCheckSucceededEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
 		return err
 	}
-	_, pushErr := c.eventstore.Push(ctx, user.NewHumanOTPCheckFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
-	logging.Log("COMMAND-9fj7s").OnError(pushErr).Error("error create password check failed event")
+	c.pushSecondFactorCheckFailed(ctx, userAgg, existingOTP.CheckFailedCount, user.NewHumanOTPCheckFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
 	return err
 }
 
@@ -177,3 +182,304 @@ func (c *Commands) otpWriteModelByID(ctx context.Context, userID, resourceOwner
 	}
 	return writeModel, nil
 }
+
+// AddHumanOTPSMS adds the one time password sent by SMS as second factor of the user,
+// the phone number of the user must be verified
+func (c *Commands) AddHumanOTPSMS(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-QSF2s", "Errors.User.UserIDMissing")
+	}
+	otpWriteModel, err := c.otpSMSWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if otpWriteModel.State == domain.MFAStateReady {
+		return nil, caos_errs.ThrowAlreadyExists(nil, "COMMAND-Ad3g2", "Errors.User.MFA.OTPSMS.AlreadyReady")
+	}
+	phoneWriteModel, err := c.phoneWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !phoneWriteModel.IsPhoneVerified {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Q54j2", "Errors.User.MFA.OTPSMS.PhoneNotVerified")
+	}
+	userAgg := UserAggregateFromWriteModel(&otpWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanOTPSMSAddedEvent(ctx, userAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(otpWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&otpWriteModel.WriteModel), nil
+}
+
+func (c *Commands) RemoveHumanOTPSMS(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-S3br2", "Errors.User.UserIDMissing")
+	}
+	otpWriteModel, err := c.otpSMSWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if otpWriteModel.State != domain.MFAStateReady {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Sr3h3", "Errors.User.MFA.OTPSMS.NotExisting")
+	}
+	userAgg := UserAggregateFromWriteModel(&otpWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanOTPSMSRemovedEvent(ctx, userAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(otpWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&otpWriteModel.WriteModel), nil
+}
+
+// HumanSendOTPSMS creates a new code, which will be sent to the user by the notification handler
+func (c *Commands) HumanSendOTPSMS(ctx context.Context, userID, resourceOwner string, authRequest *domain.AuthRequest) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Fg3sf", "Errors.User.UserIDMissing")
+	}
+	otpWriteModel, err := c.otpSMSWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if otpWriteModel.State != domain.MFAStateReady {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Hf32s", "Errors.User.MFA.OTPSMS.NotReady")
+	}
+	if !otpWriteModel.PhoneVerified {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ghe2s", "Errors.User.MFA.OTPSMS.PhoneNotVerified")
+	}
+	if otpWriteModel.Code != nil && time.Since(otpWriteModel.CodeCreationDate) < otpCodeResendCooldown {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Bw3gs", "Errors.User.Code.TooEarly")
+	}
+	code, expiry, err := c.newEncryptedCodeWithDefault(ctx, c.eventstore.Filter, domain.SecretGeneratorTypeOTPSMS, c.userEncryption, c.defaultSecretGenerators.OTPSMS)
+	if err != nil {
+		return err
+	}
+	userAgg := UserAggregateFromWriteModel(&otpWriteModel.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewHumanOTPSMSCodeAddedEvent(ctx, userAgg, code, expiry, authRequestDomainToAuthRequestInfo(authRequest)))
+	return err
+}
+
+func (c *Commands) HumanOTPSMSCodeSent(ctx context.Context, userID, resourceOwner string) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Dsf3a", "Errors.User.UserIDMissing")
+	}
+	otpWriteModel, err := c.otpSMSWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if otpWriteModel.State != domain.MFAStateReady {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Gd3ff", "Errors.User.MFA.OTPSMS.NotReady")
+	}
+	userAgg := UserAggregateFromWriteModel(&otpWriteModel.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewHumanOTPSMSCodeSentEvent(ctx, userAgg))
+	return err
+}
+
+func (c *Commands) HumanCheckOTPSMS(ctx context.Context, userID, code, resourceOwner string, authRequest *domain.AuthRequest) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-VDrh3", "Errors.User.UserIDMissing")
+	}
+	if code == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Fgh3s", "Errors.User.Code.Empty")
+	}
+	otpWriteModel, err := c.otpSMSWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if otpWriteModel.State != domain.MFAStateReady {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Sd3gs", "Errors.User.MFA.OTPSMS.NotReady")
+	}
+	userAgg := UserAggregateFromWriteModel(&otpWriteModel.WriteModel)
+	info := authRequestDomainToAuthRequestInfo(authRequest)
+	return c.humanCheckOTPCode(ctx, userAgg, code, otpWriteModel.Code, otpWriteModel.CodeCreationDate, otpWriteModel.CodeExpiry, otpWriteModel.CheckFailedCount,
+		user.NewHumanOTPSMSCheckSucceededEvent(ctx, userAgg, info),
+		user.NewHumanOTPSMSCheckFailedEvent(ctx, userAgg, info),
+	)
+}
+
+// AddHumanOTPEmail adds the one time password sent by email as second factor of the user,
+// the email address of the user must be verified
+func (c *Commands) AddHumanOTPEmail(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Sg1hz", "Errors.User.UserIDMissing")
+	}
+	otpWriteModel, err := c.otpEmailWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if otpWriteModel.State == domain.MFAStateReady {
+		return nil, caos_errs.ThrowAlreadyExists(nil, "COMMAND-MKL2s", "Errors.User.MFA.OTPEmail.AlreadyReady")
+	}
+	emailWriteModel, err := c.emailWriteModel(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !emailWriteModel.IsEmailVerified {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Gf2sj", "Errors.User.MFA.OTPEmail.EmailNotVerified")
+	}
+	userAgg := UserAggregateFromWriteModel(&otpWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanOTPEmailAddedEvent(ctx, userAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(otpWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&otpWriteModel.WriteModel), nil
+}
+
+func (c *Commands) RemoveHumanOTPEmail(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-S2h11", "Errors.User.UserIDMissing")
+	}
+	otpWriteModel, err := c.otpEmailWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if otpWriteModel.State != domain.MFAStateReady {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-b312D", "Errors.User.MFA.OTPEmail.NotExisting")
+	}
+	userAgg := UserAggregateFromWriteModel(&otpWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanOTPEmailRemovedEvent(ctx, userAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(otpWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&otpWriteModel.WriteModel), nil
+}
+
+// HumanSendOTPEmail creates a new code, which will be sent to the user by the notification handler
+func (c *Commands) HumanSendOTPEmail(ctx context.Context, userID, resourceOwner string, authRequest *domain.AuthRequest) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Jq3s2", "Errors.User.UserIDMissing")
+	}
+	otpWriteModel, err := c.otpEmailWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if otpWriteModel.State != domain.MFAStateReady {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ag2f1", "Errors.User.MFA.OTPEmail.NotReady")
+	}
+	if !otpWriteModel.EmailVerified {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Jf3sb", "Errors.User.MFA.OTPEmail.EmailNotVerified")
+	}
+	if otpWriteModel.Code != nil && time.Since(otpWriteModel.CodeCreationDate) < otpCodeResendCooldown {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Mw2fa", "Errors.User.Code.TooEarly")
+	}
+	code, expiry, err := c.newEncryptedCodeWithDefault(ctx, c.eventstore.Filter, domain.SecretGeneratorTypeOTPEmail, c.userEncryption, c.defaultSecretGenerators.OTPEmail)
+	if err != nil {
+		return err
+	}
+	userAgg := UserAggregateFromWriteModel(&otpWriteModel.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewHumanOTPEmailCodeAddedEvent(ctx, userAgg, code, expiry, authRequestDomainToAuthRequestInfo(authRequest)))
+	return err
+}
+
+func (c *Commands) HumanOTPEmailCodeSent(ctx context.Context, userID, resourceOwner string) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Kh3s1", "Errors.User.UserIDMissing")
+	}
+	otpWriteModel, err := c.otpEmailWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if otpWriteModel.State != domain.MFAStateReady {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Fgb3s", "Errors.User.MFA.OTPEmail.NotReady")
+	}
+	userAgg := UserAggregateFromWriteModel(&otpWriteModel.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewHumanOTPEmailCodeSentEvent(ctx, userAgg))
+	return err
+}
+
+func (c *Commands) HumanCheckOTPEmail(ctx context.Context, userID, code, resourceOwner string, authRequest *domain.AuthRequest) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Fg22s", "Errors.User.UserIDMissing")
+	}
+	if code == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ga3ee", "Errors.User.Code.Empty")
+	}
+	otpWriteModel, err := c.otpEmailWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if otpWriteModel.State != domain.MFAStateReady {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Dfg2s", "Errors.User.MFA.OTPEmail.NotReady")
+	}
+	userAgg := UserAggregateFromWriteModel(&otpWriteModel.WriteModel)
+	info := authRequestDomainToAuthRequestInfo(authRequest)
+	return c.humanCheckOTPCode(ctx, userAgg, code, otpWriteModel.Code, otpWriteModel.CodeCreationDate, otpWriteModel.CodeExpiry, otpWriteModel.CheckFailedCount,
+		user.NewHumanOTPEmailCheckSucceededEvent(ctx, userAgg, info),
+		user.NewHumanOTPEmailCheckFailedEvent(ctx, userAgg, info),
+	)
+}
+
+// humanCheckOTPCode verifies the code sent to the user and pushes the succeeded or failed event,
+// the user is locked if the failed checks reach the max OTP attempts of the lockout policy
+func (c *Commands) humanCheckOTPCode(
+	ctx context.Context,
+	userAgg *eventstore.Aggregate,
+	code string,
+	storedCode *crypto.CryptoValue,
+	creationDate time.Time,
+	expiry time.Duration,
+	failedCount uint64,
+	succeededEvent, failedEvent eventstore.Command,
+) error {
+	if storedCode == nil {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-S34gh", "Errors.User.Code.NotFound")
+	}
+	err := crypto.VerifyCode(creationDate, expiry, storedCode, code, crypto.NewEncryptionGenerator(crypto.GeneratorConfig{}, c.userEncryption))
+	if err == nil {
+		_, err = c.eventstore.Push(ctx, succeededEvent)
+		return err
+	}
+	c.pushSecondFactorCheckFailed(ctx, userAgg, failedCount, failedEvent)
+	return caos_errs.ThrowInvalidArgument(err, "COMMAND-Ksf3g", "Errors.User.MFA.OTP.InvalidCode")
+}
+
+// pushSecondFactorCheckFailed pushes the failed event of a second factor check (OTP, U2F, passwordless, ...),
+// the user is locked as well if the failed checks reach the max OTP attempts of the lockout policy
+func (c *Commands) pushSecondFactorCheckFailed(ctx context.Context, userAgg *eventstore.Aggregate, failedCount uint64, failedEvent eventstore.Command) {
+	lockoutPolicy, lockoutErr := c.getLockoutPolicy(ctx, userAgg.ResourceOwner)
+	logging.OnError(lockoutErr).Error("unable to get lockout policy")
+	events := []eventstore.Command{failedEvent}
+	if lockoutPolicy != nil && lockoutPolicy.MaxOTPAttempts > 0 && failedCount+1 >= lockoutPolicy.MaxOTPAttempts {
+		events = append(events, user.NewUserLockedEvent(ctx, userAgg))
+	}
+	_, pushErr := c.eventstore.Push(ctx, events...)
+	logging.OnError(pushErr).Error("error create second factor check failed event")
+}
+
+func (c *Commands) otpSMSWriteModelByID(ctx context.Context, userID, resourceOwner string) (writeModel *HumanOTPSMSWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanOTPSMSWriteModel(userID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
+
+func (c *Commands) otpEmailWriteModelByID(ctx context.Context, userID, resourceOwner string) (writeModel *HumanOTPEmailWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanOTPEmailWriteModel(userID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -1,6 +1,8 @@
 package command
 
 import (
+	"time"
+
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -10,8 +12,9 @@ import (
 type HumanOTPWriteModel struct {
 	eventstore.WriteModel
 
-	State  domain.MFAState
-	Secret *crypto.CryptoValue
+	State            domain.MFAState
+	Secret           *crypto.CryptoValue
+	CheckFailedCount uint64
 }
 
 func NewHumanOTPWriteModel(userID, resourceOwner string) *HumanOTPWriteModel {
@@ -33,6 +36,12 @@ func (wm *HumanOTPWriteModel) Reduce() error {
 			wm.State = domain.MFAStateReady
 		case *user.HumanOTPRemovedEvent:
 			wm.State = domain.MFAStateRemoved
+		case *user.HumanOTPCheckSucceededEvent:
+			wm.CheckFailedCount = 0
+		case *user.HumanOTPCheckFailedEvent:
+			wm.CheckFailedCount += 1
+		case *user.UserUnlockedEvent:
+			wm.CheckFailedCount = 0
 		case *user.UserRemovedEvent:
 			wm.State = domain.MFAStateRemoved
 		}
@@ -48,10 +57,176 @@ func (wm *HumanOTPWriteModel) Query() *eventstore.SearchQueryBuilder {
 		EventTypes(user.HumanMFAOTPAddedType,
 			user.HumanMFAOTPVerifiedType,
 			user.HumanMFAOTPRemovedType,
+			user.HumanMFAOTPCheckSucceededType,
+			user.HumanMFAOTPCheckFailedType,
+			user.UserUnlockedType,
 			user.UserRemovedType,
 			user.UserV1MFAOTPAddedType,
 			user.UserV1MFAOTPVerifiedType,
-			user.UserV1MFAOTPRemovedType).
+			user.UserV1MFAOTPRemovedType,
+			user.UserV1MFAOTPCheckSucceededType,
+			user.UserV1MFAOTPCheckFailedType).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
+
+type HumanOTPSMSWriteModel struct {
+	eventstore.WriteModel
+
+	State            domain.MFAState
+	Code             *crypto.CryptoValue
+	CodeCreationDate time.Time
+	CodeExpiry       time.Duration
+	CheckFailedCount uint64
+	// PhoneVerified is required for sending codes,
+	// so they are not sent to an unverified (changed) phone number
+	PhoneVerified bool
+}
+
+func NewHumanOTPSMSWriteModel(userID, resourceOwner string) *HumanOTPSMSWriteModel {
+	return &HumanOTPSMSWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *HumanOTPSMSWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanOTPSMSAddedEvent:
+			wm.State = domain.MFAStateReady
+		case *user.HumanOTPSMSRemovedEvent:
+			wm.State = domain.MFAStateRemoved
+			wm.Code = nil
+		case *user.HumanOTPSMSCodeAddedEvent:
+			wm.Code = e.Code
+			wm.CodeCreationDate = e.CreationDate()
+			wm.CodeExpiry = e.Expiry
+		case *user.HumanOTPSMSCheckSucceededEvent:
+			wm.Code = nil
+			wm.CheckFailedCount = 0
+		case *user.HumanOTPSMSCheckFailedEvent:
+			wm.CheckFailedCount += 1
+		case *user.HumanPhoneChangedEvent:
+			wm.PhoneVerified = false
+			wm.Code = nil
+		case *user.HumanPhoneVerifiedEvent:
+			wm.PhoneVerified = true
+		case *user.HumanPhoneRemovedEvent:
+			// without a phone number no code can be sent
+			wm.State = domain.MFAStateRemoved
+			wm.PhoneVerified = false
+			wm.Code = nil
+		case *user.UserUnlockedEvent:
+			wm.CheckFailedCount = 0
+		case *user.UserRemovedEvent:
+			wm.State = domain.MFAStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *HumanOTPSMSWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(user.HumanOTPSMSAddedType,
+			user.HumanOTPSMSRemovedType,
+			user.HumanOTPSMSCodeAddedType,
+			user.HumanOTPSMSCheckSucceededType,
+			user.HumanOTPSMSCheckFailedType,
+			user.HumanPhoneChangedType,
+			user.HumanPhoneVerifiedType,
+			user.HumanPhoneRemovedType,
+			user.UserV1PhoneChangedType,
+			user.UserV1PhoneVerifiedType,
+			user.UserV1PhoneRemovedType,
+			user.UserUnlockedType,
+			user.UserRemovedType).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
+
+type HumanOTPEmailWriteModel struct {
+	eventstore.WriteModel
+
+	State            domain.MFAState
+	Code             *crypto.CryptoValue
+	CodeCreationDate time.Time
+	CodeExpiry       time.Duration
+	CheckFailedCount uint64
+	// EmailVerified is required for sending codes,
+	// so they are not sent to an unverified (changed) email address
+	EmailVerified bool
+}
+
+func NewHumanOTPEmailWriteModel(userID, resourceOwner string) *HumanOTPEmailWriteModel {
+	return &HumanOTPEmailWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *HumanOTPEmailWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanOTPEmailAddedEvent:
+			wm.State = domain.MFAStateReady
+		case *user.HumanOTPEmailRemovedEvent:
+			wm.State = domain.MFAStateRemoved
+			wm.Code = nil
+		case *user.HumanOTPEmailCodeAddedEvent:
+			wm.Code = e.Code
+			wm.CodeCreationDate = e.CreationDate()
+			wm.CodeExpiry = e.Expiry
+		case *user.HumanOTPEmailCheckSucceededEvent:
+			wm.Code = nil
+			wm.CheckFailedCount = 0
+		case *user.HumanOTPEmailCheckFailedEvent:
+			wm.CheckFailedCount += 1
+		case *user.HumanEmailChangedEvent:
+			wm.EmailVerified = false
+			wm.Code = nil
+		case *user.HumanEmailVerifiedEvent:
+			wm.EmailVerified = true
+		case *user.UserUnlockedEvent:
+			wm.CheckFailedCount = 0
+		case *user.UserRemovedEvent:
+			wm.State = domain.MFAStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *HumanOTPEmailWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(user.HumanOTPEmailAddedType,
+			user.HumanOTPEmailRemovedType,
+			user.HumanOTPEmailCodeAddedType,
+			user.HumanOTPEmailCheckSucceededType,
+			user.HumanOTPEmailCheckFailedType,
+			user.HumanEmailChangedType,
+			user.HumanEmailVerifiedType,
+			user.UserV1EmailChangedType,
+			user.UserV1EmailVerifiedType,
+			user.UserUnlockedType,
+			user.UserRemovedType).
 		Builder()
 
 	if wm.ResourceOwner != "" {
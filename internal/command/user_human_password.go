@@ -249,7 +249,11 @@ func (c *Commands) HumanCheckPassword(ctx context.Context, orgID, userID, passwo
 	err = crypto.CompareHash(existingPassword.Secret, []byte(password), c.userPasswordAlg)
 	spanPasswordComparison.EndWithError(err)
 	if err == nil {
-		_, err = c.eventstore.Push(ctx, user.NewHumanPasswordCheckSucceededEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+		events := []eventstore.Command{user.NewHumanPasswordCheckSucceededEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest))}
+		if crypto.NeedsRehash(existingPassword.Secret, c.userPasswordAlg) {
+			events = append(events, c.rehashPassword(ctx, userAgg, password)...)
+		}
+		_, err = c.eventstore.Push(ctx, events...)
 		return err
 	}
 	events := make([]eventstore.Command, 0)
@@ -265,6 +269,19 @@ func (c *Commands) HumanCheckPassword(ctx context.Context, orgID, userID, passwo
 	return caos_errs.ThrowInvalidArgument(nil, "COMMAND-452ad", "Errors.User.Password.Invalid")
 }
 
+// rehashPassword hashes the password with the current password algorithm,
+// a failure must not prevent the login as the password was already verified
+func (c *Commands) rehashPassword(ctx context.Context, userAgg *eventstore.Aggregate, password string) []eventstore.Command {
+	ctx, span := tracing.NewNamedSpan(ctx, "crypto.Hash")
+	secret, err := crypto.Hash([]byte(password), c.userPasswordAlg)
+	span.EndWithError(err)
+	if err != nil {
+		logging.WithFields("userID", userAgg.ID).WithError(err).Warn("unable to rehash password")
+		return nil
+	}
+	return []eventstore.Command{user.NewHumanPasswordHashUpdatedEvent(ctx, userAgg, secret)}
+}
+
 func (c *Commands) passwordWriteModel(ctx context.Context, userID, resourceOwner string) (writeModel *HumanPasswordWriteModel, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
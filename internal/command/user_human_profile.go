@@ -20,6 +20,10 @@ func (c *Commands) ChangeHumanProfile(ctx context.Context, profile *domain.Profi
 	if existingProfile.UserState == domain.UserStateUnspecified || existingProfile.UserState == domain.UserStateDeleted {
 		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-3M9sd", "Errors.User.Profile.NotFound")
 	}
+	profile.ResourceOwner = existingProfile.ResourceOwner
+	if err = c.runPreProfileUpdateActions(ctx, profile); err != nil {
+		return nil, err
+	}
 	userAgg := UserAggregateFromWriteModel(&existingProfile.WriteModel)
 	changedEvent, hasChanged, err := existingProfile.NewChangedEvent(ctx, userAgg, profile.FirstName, profile.LastName, profile.NickName, profile.DisplayName, profile.PreferredLanguage, profile.Gender)
 	if err != nil {
@@ -37,8 +41,9 @@ func (c *Commands) ChangeHumanProfile(ctx context.Context, profile *domain.Profi
 	if err != nil {
 		return nil, err
 	}
-
-	return writeModelToProfile(existingProfile), nil
+	changedProfile := writeModelToProfile(existingProfile)
+	c.runPostProfileUpdateActions(ctx, changedProfile)
+	return changedProfile, nil
 }
 
 func (c *Commands) profileWriteModelByID(ctx context.Context, userID, resourceOwner string) (writeModel *HumanProfileWriteModel, err error) {
@@ -0,0 +1,101 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// GenerateHumanRecoveryCodes replaces the recovery codes of the user with new ones,
+// the user must have a ready OTP authenticator.
+// The plain codes are only returned once, they are stored hashed.
+func (c *Commands) GenerateHumanRecoveryCodes(ctx context.Context, userID, resourceOwner string) (*domain.RecoveryCodes, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Rc8fa", "Errors.User.UserIDMissing")
+	}
+	existingCodes, err := c.recoveryCodesWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existingCodes.OTPState != domain.MFAStateReady {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Rc2gs", "Errors.User.MFA.RecoveryCodes.OTPNotReady")
+	}
+	hashedCodes, plainCodes, err := newRecoveryCodes(c.multifactors.RecoveryCodes)
+	if err != nil {
+		return nil, err
+	}
+	userAgg := UserAggregateFromWriteModel(&existingCodes.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanRecoveryCodesAddedEvent(ctx, userAgg, hashedCodes, existingCodes.ConsumedCodeIndexes))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingCodes, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.RecoveryCodes{
+		ObjectDetails: writeModelToObjectDetails(&existingCodes.WriteModel),
+		Codes:         plainCodes,
+	}, nil
+}
+
+// HumanCheckRecoveryCode checks the code against the unused recovery codes of the user,
+// on success the code is consumed and can't be used again, even by a concurrent check
+func (c *Commands) HumanCheckRecoveryCode(ctx context.Context, userID, code, resourceOwner string, authRequest *domain.AuthRequest) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Rc5sd", "Errors.User.UserIDMissing")
+	}
+	if code == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Rc3fw", "Errors.User.Code.Empty")
+	}
+	existingCodes, err := c.recoveryCodesWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if existingCodes.UnusedCodesCount() == 0 {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Rc9gk", "Errors.User.MFA.RecoveryCodes.NotExisting")
+	}
+	userAgg := UserAggregateFromWriteModel(&existingCodes.WriteModel)
+	info := authRequestDomainToAuthRequestInfo(authRequest)
+	for i, hashedCode := range existingCodes.Codes {
+		if existingCodes.UsedCodes[i] {
+			continue
+		}
+		// recovery codes don't expire
+		if crypto.VerifyCode(time.Time{}, 0, hashedCode, code, c.multifactors.RecoveryCodes.Generator) == nil {
+			_, err = c.eventstore.Push(ctx, user.NewHumanRecoveryCodeCheckSucceededEvent(ctx, userAgg, i, info))
+			return err
+		}
+	}
+	c.pushSecondFactorCheckFailed(ctx, userAgg, existingCodes.CheckFailedCount, user.NewHumanRecoveryCodeCheckFailedEvent(ctx, userAgg, info))
+	return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Rc4hd", "Errors.User.MFA.RecoveryCodes.InvalidCode")
+}
+
+func newRecoveryCodes(config domain.RecoveryCodesConfig) (hashedCodes []*crypto.CryptoValue, plainCodes []string, err error) {
+	hashedCodes = make([]*crypto.CryptoValue, config.Count)
+	plainCodes = make([]string, config.Count)
+	for i := 0; i < config.Count; i++ {
+		hashedCodes[i], plainCodes[i], err = crypto.NewCode(config.Generator)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return hashedCodes, plainCodes, nil
+}
+
+func (c *Commands) recoveryCodesWriteModelByID(ctx context.Context, userID, resourceOwner string) (writeModel *HumanRecoveryCodesWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanRecoveryCodesWriteModel(userID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
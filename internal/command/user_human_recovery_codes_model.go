@@ -0,0 +1,95 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+type HumanRecoveryCodesWriteModel struct {
+	eventstore.WriteModel
+
+	OTPState         domain.MFAState
+	Codes            []*crypto.CryptoValue
+	UsedCodes        map[int]bool
+	CheckFailedCount uint64
+	// ConsumedCodeIndexes are the indexes of the unique constraints of the consumed codes,
+	// which are only released when new codes are added
+	ConsumedCodeIndexes []int
+}
+
+func NewHumanRecoveryCodesWriteModel(userID, resourceOwner string) *HumanRecoveryCodesWriteModel {
+	return &HumanRecoveryCodesWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+		UsedCodes: make(map[int]bool),
+	}
+}
+
+func (wm *HumanRecoveryCodesWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanOTPAddedEvent:
+			wm.OTPState = domain.MFAStateNotReady
+		case *user.HumanOTPVerifiedEvent:
+			wm.OTPState = domain.MFAStateReady
+		case *user.HumanOTPRemovedEvent:
+			// the recovery codes replace the OTP, so they are removed with it
+			wm.OTPState = domain.MFAStateRemoved
+			wm.resetCodes()
+		case *user.HumanRecoveryCodesAddedEvent:
+			wm.resetCodes()
+			wm.Codes = e.Codes
+			wm.ConsumedCodeIndexes = nil
+		case *user.HumanRecoveryCodeCheckSucceededEvent:
+			wm.UsedCodes[e.CodeIndex] = true
+			wm.ConsumedCodeIndexes = append(wm.ConsumedCodeIndexes, e.CodeIndex)
+			wm.CheckFailedCount = 0
+		case *user.HumanRecoveryCodeCheckFailedEvent:
+			wm.CheckFailedCount += 1
+		case *user.UserUnlockedEvent:
+			wm.CheckFailedCount = 0
+		case *user.UserRemovedEvent:
+			wm.OTPState = domain.MFAStateRemoved
+			wm.resetCodes()
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *HumanRecoveryCodesWriteModel) resetCodes() {
+	wm.Codes = nil
+	wm.UsedCodes = make(map[int]bool)
+}
+
+// UnusedCodesCount returns the number of recovery codes which can still be used
+func (wm *HumanRecoveryCodesWriteModel) UnusedCodesCount() int {
+	return len(wm.Codes) - len(wm.UsedCodes)
+}
+
+func (wm *HumanRecoveryCodesWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(user.HumanMFAOTPAddedType,
+			user.HumanMFAOTPVerifiedType,
+			user.HumanMFAOTPRemovedType,
+			user.UserV1MFAOTPAddedType,
+			user.UserV1MFAOTPVerifiedType,
+			user.UserV1MFAOTPRemovedType,
+			user.HumanRecoveryCodesAddedType,
+			user.HumanRecoveryCodeCheckSucceededType,
+			user.HumanRecoveryCodeCheckFailedType,
+			user.UserUnlockedType,
+			user.UserRemovedType).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
@@ -0,0 +1,589 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestCommandSide_GenerateHumanRecoveryCodes(t *testing.T) {
+	type fields struct {
+		eventstore    *eventstore.Eventstore
+		recoveryCodes domain.RecoveryCodesConfig
+	}
+	type (
+		args struct {
+			ctx           context.Context
+			userID        string
+			resourceOwner string
+		}
+	)
+	type res struct {
+		want *domain.RecoveryCodes
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "userid missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "otp not ready, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanOTPAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								nil,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "otp removed, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanOTPAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								nil,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPVerifiedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"agent1",
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPRemovedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "generate codes, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanOTPAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								nil,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPVerifiedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"agent1",
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanRecoveryCodesAddedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									[]*crypto.CryptoValue{hashedRecoveryCode("a"), hashedRecoveryCode("a")},
+									nil,
+								),
+							),
+						},
+					),
+				),
+				recoveryCodes: mockRecoveryCodesConfig(t, 2),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.RecoveryCodes{
+					ObjectDetails: &domain.ObjectDetails{
+						ResourceOwner: "org1",
+					},
+					Codes: []string{"a", "a"},
+				},
+			},
+		},
+		{
+			name: "regenerate codes, consumed codes released",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanOTPAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								nil,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPVerifiedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"agent1",
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanRecoveryCodesAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								[]*crypto.CryptoValue{hashedRecoveryCode("code1"), hashedRecoveryCode("code2")},
+								nil,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanRecoveryCodeCheckSucceededEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								1,
+								nil,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanRecoveryCodesAddedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									[]*crypto.CryptoValue{hashedRecoveryCode("a"), hashedRecoveryCode("a")},
+									[]int{1},
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(user.NewRemoveRecoveryCodeUniqueConstraint("user1", 1)),
+					),
+				),
+				recoveryCodes: mockRecoveryCodesConfig(t, 2),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.RecoveryCodes{
+					ObjectDetails: &domain.ObjectDetails{
+						ResourceOwner: "org1",
+					},
+					Codes: []string{"a", "a"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+				multifactors: domain.MultifactorConfigs{
+					RecoveryCodes: tt.fields.recoveryCodes,
+				},
+			}
+			got, err := r.GenerateHumanRecoveryCodes(tt.args.ctx, tt.args.userID, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_HumanCheckRecoveryCode(t *testing.T) {
+	type fields struct {
+		eventstore    *eventstore.Eventstore
+		recoveryCodes domain.RecoveryCodesConfig
+	}
+	type (
+		args struct {
+			ctx           context.Context
+			userID        string
+			code          string
+			resourceOwner string
+			authRequest   *domain.AuthRequest
+		}
+	)
+	type res struct {
+		err func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "code missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				code:          "",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "all codes used, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanRecoveryCodesAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								[]*crypto.CryptoValue{hashedRecoveryCode("code1")},
+								nil,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanRecoveryCodeCheckSucceededEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								0,
+								nil,
+							),
+						),
+					),
+				),
+				recoveryCodes: mockRecoveryCodesConfig(t, 1),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				code:          "code1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "codes removed with otp, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanRecoveryCodesAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								[]*crypto.CryptoValue{hashedRecoveryCode("code1")},
+								nil,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPRemovedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+							),
+						),
+					),
+				),
+				recoveryCodes: mockRecoveryCodesConfig(t, 1),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				code:          "code1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "used code, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanRecoveryCodesAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								[]*crypto.CryptoValue{hashedRecoveryCode("code1"), hashedRecoveryCode("code2")},
+								nil,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanRecoveryCodeCheckSucceededEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								0,
+								nil,
+							),
+						),
+					),
+					expectFilter(),
+					expectFilter(),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanRecoveryCodeCheckFailedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									nil,
+								),
+							),
+						},
+					),
+				),
+				recoveryCodes: mockRecoveryCodesConfig(t, 2),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				code:          "code1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "unused code, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanRecoveryCodesAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								[]*crypto.CryptoValue{hashedRecoveryCode("code1"), hashedRecoveryCode("code2")},
+								nil,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanRecoveryCodeCheckSucceededEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								0,
+								nil,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanRecoveryCodeCheckSucceededEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									1,
+									&user.AuthRequestInfo{
+										ID:          "request1",
+										UserAgentID: "agent1",
+									},
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(user.NewAddRecoveryCodeUniqueConstraint("user1", 1)),
+					),
+				),
+				recoveryCodes: mockRecoveryCodesConfig(t, 2),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				code:          "code2",
+				resourceOwner: "org1",
+				authRequest: &domain.AuthRequest{
+					ID:      "request1",
+					AgentID: "agent1",
+				},
+			},
+			res: res{},
+		},
+		{
+			name: "code consumed concurrently, already exists error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanRecoveryCodesAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								[]*crypto.CryptoValue{hashedRecoveryCode("code1")},
+								nil,
+							),
+						),
+					),
+					expectPushFailed(caos_errs.ThrowAlreadyExists(nil, "ERROR", "Errors.User.MFA.RecoveryCodes.InvalidCode"),
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanRecoveryCodeCheckSucceededEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									0,
+									nil,
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(user.NewAddRecoveryCodeUniqueConstraint("user1", 0)),
+					),
+				),
+				recoveryCodes: mockRecoveryCodesConfig(t, 1),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				code:          "code1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsErrorAlreadyExists,
+			},
+		},
+		{
+			name: "code invalid, max attempts reached - user locked, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanRecoveryCodesAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								[]*crypto.CryptoValue{hashedRecoveryCode("code1")},
+								nil,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanRecoveryCodeCheckFailedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								nil,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewLockoutPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								0,
+								2,
+								false,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanRecoveryCodeCheckFailedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									nil,
+								),
+							),
+							eventFromEventPusher(
+								user.NewUserLockedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+								),
+							),
+						},
+					),
+				),
+				recoveryCodes: mockRecoveryCodesConfig(t, 1),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				code:          "wrong",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+				multifactors: domain.MultifactorConfigs{
+					RecoveryCodes: tt.fields.recoveryCodes,
+				},
+			}
+			err := r.HumanCheckRecoveryCode(tt.args.ctx, tt.args.userID, tt.args.code, tt.args.resourceOwner, tt.args.authRequest)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
+
+// mockRecoveryCodesConfig generates count codes "a", the mocked hash algorithm doesn't change the value
+func mockRecoveryCodesConfig(t *testing.T, count int) domain.RecoveryCodesConfig {
+	ctrl := gomock.NewController(t)
+	generator := crypto.NewMockGenerator(ctrl)
+	generator.EXPECT().Length().Return(uint(1)).AnyTimes()
+	generator.EXPECT().Runes().Return([]rune("aa")).AnyTimes()
+	generator.EXPECT().Alg().Return(crypto.CreateMockHashAlg(ctrl)).AnyTimes()
+	generator.EXPECT().Expiry().Return(time.Duration(0)).AnyTimes()
+	return domain.RecoveryCodesConfig{
+		Count:     count,
+		Generator: generator,
+	}
+}
+
+func hashedRecoveryCode(code string) *crypto.CryptoValue {
+	return &crypto.CryptoValue{
+		CryptoType: crypto.TypeHash,
+		Algorithm:  "hash",
+		Crypted:    []byte(code),
+	}
+}
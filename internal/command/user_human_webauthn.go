@@ -16,6 +16,14 @@ import (
 )
 
 func (c *Commands) getHumanU2FTokens(ctx context.Context, userID, resourceowner string) ([]*domain.WebAuthNToken, error) {
+	tokenReadModel, err := c.humanU2FTokensReadModel(ctx, userID, resourceowner)
+	if err != nil {
+		return nil, err
+	}
+	return readModelToU2FTokens(tokenReadModel), nil
+}
+
+func (c *Commands) humanU2FTokensReadModel(ctx context.Context, userID, resourceowner string) (*HumanU2FTokensReadModel, error) {
 	tokenReadModel := NewHumanU2FTokensReadModel(userID, resourceowner)
 	err := c.eventstore.FilterToQueryReducer(ctx, tokenReadModel)
 	if err != nil {
@@ -24,10 +32,18 @@ func (c *Commands) getHumanU2FTokens(ctx context.Context, userID, resourceowner
 	if tokenReadModel.UserState == domain.UserStateDeleted {
 		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-4M0ds", "Errors.User.NotFound")
 	}
-	return readModelToU2FTokens(tokenReadModel), nil
+	return tokenReadModel, nil
 }
 
 func (c *Commands) getHumanPasswordlessTokens(ctx context.Context, userID, resourceowner string) ([]*domain.WebAuthNToken, error) {
+	tokenReadModel, err := c.humanPasswordlessTokensReadModel(ctx, userID, resourceowner)
+	if err != nil {
+		return nil, err
+	}
+	return readModelToPasswordlessTokens(tokenReadModel), nil
+}
+
+func (c *Commands) humanPasswordlessTokensReadModel(ctx context.Context, userID, resourceowner string) (*HumanPasswordlessTokensReadModel, error) {
 	tokenReadModel := NewHumanPasswordlessTokensReadModel(userID, resourceowner)
 	err := c.eventstore.FilterToQueryReducer(ctx, tokenReadModel)
 	if err != nil {
@@ -36,7 +52,7 @@ func (c *Commands) getHumanPasswordlessTokens(ctx context.Context, userID, resou
 	if tokenReadModel.UserState == domain.UserStateDeleted {
 		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Mv9sd", "Errors.User.NotFound")
 	}
-	return readModelToPasswordlessTokens(tokenReadModel), nil
+	return tokenReadModel, nil
 }
 
 func (c *Commands) getHumanU2FLogin(ctx context.Context, userID, authReqID, resourceowner string) (*domain.WebAuthNLogin, error) {
@@ -362,25 +378,24 @@ func (c *Commands) HumanFinishU2FLogin(ctx context.Context, userID, resourceOwne
 	if err != nil {
 		return err
 	}
-	u2fTokens, err := c.getHumanU2FTokens(ctx, userID, resourceOwner)
+	tokenReadModel, err := c.humanU2FTokensReadModel(ctx, userID, resourceOwner)
 	if err != nil {
 		return err
 	}
 
-	userAgg, token, signCount, err := c.finishWebAuthNLogin(ctx, userID, resourceOwner, credentialData, webAuthNLogin, u2fTokens)
+	userAgg, token, signCount, err := c.finishWebAuthNLogin(ctx, userID, resourceOwner, credentialData, webAuthNLogin, readModelToU2FTokens(tokenReadModel))
 	if err != nil {
 		if userAgg == nil {
 			logging.WithFields("userID", userID, "resourceOwner", resourceOwner).WithError(err).Warn("missing userAggregate for pushing failed u2f check event")
 			return err
 		}
-		_, pushErr := c.eventstore.Push(ctx,
+		c.pushSecondFactorCheckFailed(ctx, userAgg, tokenReadModel.CheckFailedCount,
 			usr_repo.NewHumanU2FCheckFailedEvent(
 				ctx,
 				userAgg,
 				authRequestDomainToAuthRequestInfo(authRequest),
 			),
 		)
-		logging.WithFields("userID", userID, "resourceOwner", resourceOwner).OnError(pushErr).Warn("could not push failed u2f check event")
 		return err
 	}
 
@@ -407,25 +422,24 @@ func (c *Commands) HumanFinishPasswordlessLogin(ctx context.Context, userID, res
 		return err
 	}
 
-	passwordlessTokens, err := c.getHumanPasswordlessTokens(ctx, userID, resourceOwner)
+	tokenReadModel, err := c.humanPasswordlessTokensReadModel(ctx, userID, resourceOwner)
 	if err != nil {
 		return err
 	}
 
-	userAgg, token, signCount, err := c.finishWebAuthNLogin(ctx, userID, resourceOwner, credentialData, webAuthNLogin, passwordlessTokens)
+	userAgg, token, signCount, err := c.finishWebAuthNLogin(ctx, userID, resourceOwner, credentialData, webAuthNLogin, readModelToPasswordlessTokens(tokenReadModel))
 	if err != nil {
 		if userAgg == nil {
 			logging.WithFields("userID", userID, "resourceOwner", resourceOwner).WithError(err).Warn("missing userAggregate for pushing failed passwordless check event")
 			return err
 		}
-		_, pushErr := c.eventstore.Push(ctx,
+		c.pushSecondFactorCheckFailed(ctx, userAgg, tokenReadModel.CheckFailedCount,
 			usr_repo.NewHumanPasswordlessCheckFailedEvent(
 				ctx,
 				userAgg,
 				authRequestDomainToAuthRequestInfo(authRequest),
 			),
 		)
-		logging.WithFields("userID", userID, "resourceOwner", resourceOwner).OnError(pushErr).Warn("could not push failed passwordless check event")
 		return err
 	}
 
@@ -147,8 +147,9 @@ func (wm *HumanWebAuthNWriteModel) Query() *eventstore.SearchQueryBuilder {
 type HumanU2FTokensReadModel struct {
 	eventstore.WriteModel
 
-	WebAuthNTokens []*HumanWebAuthNWriteModel
-	UserState      domain.UserState
+	WebAuthNTokens   []*HumanWebAuthNWriteModel
+	UserState        domain.UserState
+	CheckFailedCount uint64
 }
 
 func NewHumanU2FTokensReadModel(userID, resourceOwner string) *HumanU2FTokensReadModel {
@@ -197,6 +198,12 @@ func (wm *HumanU2FTokensReadModel) Reduce() error {
 			copy(wm.WebAuthNTokens[idx:], wm.WebAuthNTokens[idx+1:])
 			wm.WebAuthNTokens[len(wm.WebAuthNTokens)-1] = nil
 			wm.WebAuthNTokens = wm.WebAuthNTokens[:len(wm.WebAuthNTokens)-1]
+		case *user.HumanU2FCheckSucceededEvent:
+			wm.CheckFailedCount = 0
+		case *user.HumanU2FCheckFailedEvent:
+			wm.CheckFailedCount += 1
+		case *user.UserUnlockedEvent:
+			wm.CheckFailedCount = 0
 		case *user.UserRemovedEvent:
 			wm.UserState = domain.UserStateDeleted
 		}
@@ -213,7 +220,10 @@ func (rm *HumanU2FTokensReadModel) Query() *eventstore.SearchQueryBuilder {
 		EventTypes(
 			user.HumanU2FTokenAddedType,
 			user.HumanU2FTokenVerifiedType,
-			user.HumanU2FTokenRemovedType).
+			user.HumanU2FTokenRemovedType,
+			user.HumanU2FTokenCheckSucceededType,
+			user.HumanU2FTokenCheckFailedType,
+			user.UserUnlockedType).
 		Builder()
 
 }
@@ -230,8 +240,9 @@ func (wm *HumanU2FTokensReadModel) WebAuthNTokenByID(id string) (idx int, token
 type HumanPasswordlessTokensReadModel struct {
 	eventstore.WriteModel
 
-	WebAuthNTokens []*HumanWebAuthNWriteModel
-	UserState      domain.UserState
+	WebAuthNTokens   []*HumanWebAuthNWriteModel
+	UserState        domain.UserState
+	CheckFailedCount uint64
 }
 
 func NewHumanPasswordlessTokensReadModel(userID, resourceOwner string) *HumanPasswordlessTokensReadModel {
@@ -280,6 +291,12 @@ func (wm *HumanPasswordlessTokensReadModel) Reduce() error {
 			copy(wm.WebAuthNTokens[idx:], wm.WebAuthNTokens[idx+1:])
 			wm.WebAuthNTokens[len(wm.WebAuthNTokens)-1] = nil
 			wm.WebAuthNTokens = wm.WebAuthNTokens[:len(wm.WebAuthNTokens)-1]
+		case *user.HumanPasswordlessCheckSucceededEvent:
+			wm.CheckFailedCount = 0
+		case *user.HumanPasswordlessCheckFailedEvent:
+			wm.CheckFailedCount += 1
+		case *user.UserUnlockedEvent:
+			wm.CheckFailedCount = 0
 		case *user.UserRemovedEvent:
 			wm.UserState = domain.UserStateDeleted
 		}
@@ -296,7 +313,10 @@ func (rm *HumanPasswordlessTokensReadModel) Query() *eventstore.SearchQueryBuild
 		EventTypes(
 			user.HumanPasswordlessTokenAddedType,
 			user.HumanPasswordlessTokenVerifiedType,
-			user.HumanPasswordlessTokenRemovedType).
+			user.HumanPasswordlessTokenRemovedType,
+			user.HumanPasswordlessTokenCheckSucceededType,
+			user.HumanPasswordlessTokenCheckFailedType,
+			user.UserUnlockedType).
 		Builder()
 
 }
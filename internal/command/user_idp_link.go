@@ -70,6 +70,12 @@ func (c *Commands) addUserIDPLink(ctx context.Context, human *eventstore.Aggrega
 	if caos_errs.IsNotFound(err) {
 		_, err = c.getInstanceIDPConfigByID(ctx, link.IDPConfigID)
 	}
+	if caos_errs.IsNotFound(err) {
+		_, err = c.getOrgIDPTemplateByID(ctx, link.IDPConfigID, human.ResourceOwner)
+	}
+	if caos_errs.IsNotFound(err) {
+		_, err = c.getInstanceIDPTemplateByID(ctx, link.IDPConfigID)
+	}
 	if err != nil {
 		return nil, caos_errs.ThrowPreconditionFailed(err, "COMMAND-39nfs", "Errors.IDPConfig.NotExisting")
 	}
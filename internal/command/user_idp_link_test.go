@@ -182,6 +182,8 @@ func TestCommandSide_BulkAddUserIDPLinks(t *testing.T) {
 					),
 					expectFilter(),
 					expectFilter(),
+					expectFilter(),
+					expectFilter(),
 				),
 			},
 			args: args{
@@ -414,7 +414,8 @@ func TestCommandSide_UsernameChange(t *testing.T) {
 
 func TestCommandSide_DeactivateUser(t *testing.T) {
 	type fields struct {
-		eventstore *eventstore.Eventstore
+		eventstore            *eventstore.Eventstore
+		userManagementActions UserManagementActions
 	}
 	type (
 		args struct {
@@ -503,6 +504,43 @@ func TestCommandSide_DeactivateUser(t *testing.T) {
 				err: errors.IsPreconditionFailed,
 			},
 		},
+		{
+			name: "pre deactivation action fails, error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+					),
+				),
+				userManagementActions: &mockUserManagementActions{
+					preDeactivation: func(string, string) error {
+						return errors.ThrowPreconditionFailed(nil, "id", "not allowed")
+					},
+				},
+			},
+			args: args{
+				ctx:    context.Background(),
+				orgID:  "org1",
+				userID: "user1",
+			},
+			res: res{
+				err: func(err error) bool { return err != nil },
+			},
+		},
 		{
 			name: "deactivate user, ok",
 			fields: fields{
@@ -550,7 +588,8 @@ func TestCommandSide_DeactivateUser(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &Commands{
-				eventstore: tt.fields.eventstore,
+				eventstore:            tt.fields.eventstore,
+				userManagementActions: tt.fields.userManagementActions,
 			}
 			got, err := r.DeactivateUser(tt.args.ctx, tt.args.userID, tt.args.orgID)
 			if tt.res.err == nil {
@@ -1396,6 +1435,7 @@ func TestCommandSide_AddUserToken(t *testing.T) {
 			audience []string
 			scopes   []string
 			lifetime time.Duration
+			actor    *domain.TokenActor
 		}
 	)
 	type res struct {
@@ -1448,7 +1488,7 @@ func TestCommandSide_AddUserToken(t *testing.T) {
 				eventstore:  tt.fields.eventstore,
 				idGenerator: tt.fields.idGenerator,
 			}
-			got, err := r.AddUserToken(tt.args.ctx, tt.args.orgID, tt.args.agentID, tt.args.clientID, tt.args.userID, tt.args.audience, tt.args.scopes, tt.args.lifetime)
+			got, err := r.AddUserToken(tt.args.ctx, tt.args.orgID, tt.args.agentID, tt.args.clientID, tt.args.userID, tt.args.audience, tt.args.scopes, tt.args.lifetime, tt.args.actor)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -1514,6 +1554,7 @@ func TestCommands_RevokeAccessToken(t *testing.T) {
 								[]string{"clientID"},
 								[]string{"openid"},
 								time.Now(),
+								nil,
 							),
 						),
 					),
@@ -1546,6 +1587,7 @@ func TestCommands_RevokeAccessToken(t *testing.T) {
 								[]string{"clientID"},
 								[]string{"openid"},
 								time.Now().Add(5*time.Hour),
+								nil,
 							),
 						),
 					),
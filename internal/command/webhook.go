@@ -0,0 +1,281 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/webhook"
+)
+
+// AddWebhook adds a webhook for the resource owner (organization or instance)
+// and returns the generated signing key, which is only returned once
+func (c *Commands) AddWebhook(ctx context.Context, addWebhook *domain.Webhook, resourceOwner string) (_ string, _ string, _ *domain.ObjectDetails, err error) {
+	if resourceOwner == "" {
+		return "", "", nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-ooNg3", "Errors.ResourceOwnerMissing")
+	}
+	if !addWebhook.IsValid() {
+		return "", "", nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Eing6", "Errors.Webhook.Invalid")
+	}
+	if err = c.checkWebhookURL(addWebhook.URL); err != nil {
+		return "", "", nil, err
+	}
+	webhookID, err := c.idGenerator.Next()
+	if err != nil {
+		return "", "", nil, err
+	}
+	signingKey, plainSigningKey, err := crypto.NewCode(c.webhookSigningKeyGenerator)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	webhookModel := NewWebhookWriteModel(webhookID, resourceOwner)
+	webhookAgg := WebhookAggregateFromWriteModel(&webhookModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, webhook.NewAddedEvent(
+		ctx,
+		webhookAgg,
+		addWebhook.Name,
+		addWebhook.URL,
+		addWebhook.EventTypes,
+		addWebhook.AggregateTypes,
+		signingKey,
+	))
+	if err != nil {
+		return "", "", nil, err
+	}
+	err = AppendAndReduce(webhookModel, pushedEvents...)
+	if err != nil {
+		return "", "", nil, err
+	}
+	return webhookModel.AggregateID, plainSigningKey, writeModelToObjectDetails(&webhookModel.WriteModel), nil
+}
+
+func (c *Commands) ChangeWebhook(ctx context.Context, webhookChange *domain.Webhook, resourceOwner string) (*domain.ObjectDetails, error) {
+	if !webhookChange.IsValid() || webhookChange.AggregateID == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Thae5", "Errors.Webhook.Invalid")
+	}
+	if err := c.checkWebhookURL(webhookChange.URL); err != nil {
+		return nil, err
+	}
+
+	existingWebhook, err := c.getWebhookWriteModelByID(ctx, webhookChange.AggregateID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existingWebhook.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-ieG8a", "Errors.Webhook.NotFound")
+	}
+
+	webhookAgg := WebhookAggregateFromWriteModel(&existingWebhook.WriteModel)
+	changedEvent, err := existingWebhook.NewChangedEvent(
+		ctx,
+		webhookAgg,
+		webhookChange.Name,
+		webhookChange.URL,
+		webhookChange.EventTypes,
+		webhookChange.AggregateTypes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingWebhook, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingWebhook.WriteModel), nil
+}
+
+// RegenerateWebhookSigningKey replaces the signing key of the webhook
+// and returns the new key, which is only returned once
+func (c *Commands) RegenerateWebhookSigningKey(ctx context.Context, webhookID, resourceOwner string) (_ string, _ *domain.ObjectDetails, err error) {
+	if webhookID == "" || resourceOwner == "" {
+		return "", nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-vaeJ3", "Errors.IDMissing")
+	}
+
+	existingWebhook, err := c.getWebhookWriteModelByID(ctx, webhookID, resourceOwner)
+	if err != nil {
+		return "", nil, err
+	}
+	if !existingWebhook.State.Exists() {
+		return "", nil, caos_errs.ThrowNotFound(nil, "COMMAND-oot4O", "Errors.Webhook.NotFound")
+	}
+	signingKey, plainSigningKey, err := crypto.NewCode(c.webhookSigningKeyGenerator)
+	if err != nil {
+		return "", nil, err
+	}
+	webhookAgg := WebhookAggregateFromWriteModel(&existingWebhook.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, webhook.NewSigningKeyChangedEvent(ctx, webhookAgg, signingKey))
+	if err != nil {
+		return "", nil, err
+	}
+	err = AppendAndReduce(existingWebhook, pushedEvents...)
+	if err != nil {
+		return "", nil, err
+	}
+	return plainSigningKey, writeModelToObjectDetails(&existingWebhook.WriteModel), nil
+}
+
+func (c *Commands) DeactivateWebhook(ctx context.Context, webhookID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if webhookID == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Oe9ai", "Errors.IDMissing")
+	}
+
+	existingWebhook, err := c.getWebhookWriteModelByID(ctx, webhookID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existingWebhook.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Xah4i", "Errors.Webhook.NotFound")
+	}
+	if existingWebhook.State != domain.WebhookStateActive {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-ua8Ei", "Errors.Webhook.NotActive")
+	}
+	webhookAgg := WebhookAggregateFromWriteModel(&existingWebhook.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, webhook.NewDeactivatedEvent(ctx, webhookAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingWebhook, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingWebhook.WriteModel), nil
+}
+
+func (c *Commands) ReactivateWebhook(ctx context.Context, webhookID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if webhookID == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-ahQu6", "Errors.IDMissing")
+	}
+
+	existingWebhook, err := c.getWebhookWriteModelByID(ctx, webhookID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existingWebhook.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Aeng2", "Errors.Webhook.NotFound")
+	}
+	if existingWebhook.State != domain.WebhookStateInactive {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-eeK2a", "Errors.Webhook.NotInactive")
+	}
+	webhookAgg := WebhookAggregateFromWriteModel(&existingWebhook.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, webhook.NewReactivatedEvent(ctx, webhookAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingWebhook, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingWebhook.WriteModel), nil
+}
+
+func (c *Commands) RemoveWebhook(ctx context.Context, webhookID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if webhookID == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Joh2u", "Errors.IDMissing")
+	}
+
+	existingWebhook, err := c.getWebhookWriteModelByID(ctx, webhookID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existingWebhook.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Ahb0o", "Errors.Webhook.NotFound")
+	}
+	webhookAgg := WebhookAggregateFromWriteModel(&existingWebhook.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, webhook.NewRemovedEvent(ctx, webhookAgg, existingWebhook.Name))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingWebhook, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingWebhook.WriteModel), nil
+}
+
+// ReplayWebhookDelivery requests another delivery of the event (identified by its aggregate type and sequence)
+// to the webhook, regardless of the state of the previous delivery
+func (c *Commands) ReplayWebhookDelivery(ctx context.Context, webhookID, resourceOwner string, eventSequence uint64, eventAggregateType eventstore.AggregateType) (*domain.ObjectDetails, error) {
+	if webhookID == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-iuF4u", "Errors.IDMissing")
+	}
+	if eventSequence == 0 || eventAggregateType == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Pho3e", "Errors.Webhook.Delivery.Invalid")
+	}
+
+	existingWebhook, err := c.getWebhookWriteModelByID(ctx, webhookID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existingWebhook.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-ohX6a", "Errors.Webhook.NotFound")
+	}
+	if existingWebhook.State != domain.WebhookStateActive {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-ain5E", "Errors.Webhook.NotActive")
+	}
+	webhookAgg := WebhookAggregateFromWriteModel(&existingWebhook.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, webhook.NewDeliveryReplayRequestedEvent(ctx, webhookAgg, eventSequence, eventAggregateType))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingWebhook, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingWebhook.WriteModel), nil
+}
+
+// FailWebhookDelivery dead-letters the delivery of the event to the webhook after all attempts failed,
+// the delivery of removed webhooks is not dead-lettered
+func (c *Commands) FailWebhookDelivery(ctx context.Context, webhookID, resourceOwner string, eventSequence uint64, eventAggregateType eventstore.AggregateType, eventType eventstore.EventType, attempts uint64, statusCode int, lastError string) (*domain.ObjectDetails, error) {
+	if webhookID == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ju2ee", "Errors.IDMissing")
+	}
+	if eventSequence == 0 || eventAggregateType == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Eiph5", "Errors.Webhook.Delivery.Invalid")
+	}
+
+	existingWebhook, err := c.getWebhookWriteModelByID(ctx, webhookID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existingWebhook.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Voh7a", "Errors.Webhook.NotFound")
+	}
+	webhookAgg := WebhookAggregateFromWriteModel(&existingWebhook.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, webhook.NewDeliveryFailedEvent(ctx, webhookAgg, eventSequence, eventAggregateType, eventType, attempts, statusCode, lastError))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingWebhook, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingWebhook.WriteModel), nil
+}
+
+// checkWebhookURL prevents webhooks targeting denied (e.g. internal) addresses
+func (c *Commands) checkWebhookURL(webhookURL string) error {
+	if c.webhookURLValidator == nil {
+		return nil
+	}
+	if err := c.webhookURLValidator(webhookURL); err != nil {
+		return caos_errs.ThrowInvalidArgument(err, "COMMAND-Ahgh3", "Errors.Webhook.URLDenied")
+	}
+	return nil
+}
+
+func (c *Commands) getWebhookWriteModelByID(ctx context.Context, webhookID string, resourceOwner string) (*WebhookWriteModel, error) {
+	webhookWriteModel := NewWebhookWriteModel(webhookID, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, webhookWriteModel)
+	if err != nil {
+		return nil, err
+	}
+	return webhookWriteModel, nil
+}
@@ -0,0 +1,913 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/webhook"
+)
+
+func TestCommands_AddWebhook(t *testing.T) {
+	type fields struct {
+		eventstore          *eventstore.Eventstore
+		idGenerator         id.Generator
+		signingKeyGenerator crypto.Generator
+		urlValidator        func(string) error
+	}
+	type args struct {
+		ctx           context.Context
+		addWebhook    *domain.Webhook
+		resourceOwner string
+	}
+	type res struct {
+		id         string
+		signingKey string
+		details    *domain.ObjectDetails
+		err        func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"no resource owner, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx: context.Background(),
+				addWebhook: &domain.Webhook{
+					Name: "name",
+					URL:  "https://example.com/hook",
+				},
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"invalid url, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx: context.Background(),
+				addWebhook: &domain.Webhook{
+					Name: "name",
+					URL:  "example.com/hook",
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"denied url, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+				urlValidator: func(string) error {
+					return errors.ThrowInvalidArgument(nil, "TEST-Ohz4i", "host is denied")
+				},
+			},
+			args{
+				ctx: context.Background(),
+				addWebhook: &domain.Webhook{
+					Name: "name",
+					URL:  "http://localhost/hook",
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"unique constraint failed, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectPushFailed(
+						errors.ThrowPreconditionFailed(nil, "id", "name already exists"),
+						[]*repository.Event{
+							eventFromEventPusher(
+								webhook.NewAddedEvent(context.Background(),
+									&webhook.NewAggregate("id1", "org1").Aggregate,
+									"name",
+									"https://example.com/hook",
+									nil,
+									nil,
+									&crypto.CryptoValue{
+										CryptoType: crypto.TypeEncryption,
+										Algorithm:  "enc",
+										KeyID:      "id",
+										Crypted:    []byte("a"),
+									},
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(webhook.NewAddWebhookNameUniqueConstraint("name", "org1")),
+					),
+				),
+				idGenerator:         mock.ExpectID(t, "id1"),
+				signingKeyGenerator: GetMockSecretGenerator(t),
+			},
+			args{
+				ctx: context.Background(),
+				addWebhook: &domain.Webhook{
+					Name: "name",
+					URL:  "https://example.com/hook",
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			"push ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								webhook.NewAddedEvent(context.Background(),
+									&webhook.NewAggregate("id1", "org1").Aggregate,
+									"name",
+									"https://example.com/hook",
+									[]string{string(user.HumanAddedType)},
+									[]string{user.AggregateType},
+									&crypto.CryptoValue{
+										CryptoType: crypto.TypeEncryption,
+										Algorithm:  "enc",
+										KeyID:      "id",
+										Crypted:    []byte("a"),
+									},
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(webhook.NewAddWebhookNameUniqueConstraint("name", "org1")),
+					),
+				),
+				idGenerator:         mock.ExpectID(t, "id1"),
+				signingKeyGenerator: GetMockSecretGenerator(t),
+			},
+			args{
+				ctx: context.Background(),
+				addWebhook: &domain.Webhook{
+					Name:           "name",
+					URL:            "https://example.com/hook",
+					EventTypes:     []string{string(user.HumanAddedType)},
+					AggregateTypes: []string{user.AggregateType},
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				id:         "id1",
+				signingKey: "a",
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:                 tt.fields.eventstore,
+				idGenerator:                tt.fields.idGenerator,
+				webhookSigningKeyGenerator: tt.fields.signingKeyGenerator,
+				webhookURLValidator:        tt.fields.urlValidator,
+			}
+			id, signingKey, details, err := c.AddWebhook(tt.args.ctx, tt.args.addWebhook, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.id, id)
+				assert.Equal(t, tt.res.signingKey, signingKey)
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_ChangeWebhook(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		changeWebhook *domain.Webhook
+		resourceOwner string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"id missing, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx: context.Background(),
+				changeWebhook: &domain.Webhook{
+					Name: "name",
+					URL:  "https://example.com/hook",
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"not found, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				changeWebhook: &domain.Webhook{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "id1",
+					},
+					Name: "name",
+					URL:  "https://example.com/hook",
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			"no changes, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							webhookAddedEvent("id1", "org1", "name"),
+						),
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				changeWebhook: &domain.Webhook{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "id1",
+					},
+					Name:       "name",
+					URL:        "https://example.com/hook",
+					EventTypes: []string{},
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			"push ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							webhookAddedEvent("id1", "org1", "name"),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								func() eventstore.Command {
+									event, _ := webhook.NewChangedEvent(context.Background(),
+										&webhook.NewAggregate("id1", "org1").Aggregate,
+										[]webhook.WebhookChanges{
+											webhook.ChangeName("name2", "name"),
+											webhook.ChangeEventTypes([]string{string(user.HumanAddedType)}),
+										},
+									)
+									return event
+								}(),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(webhook.NewRemoveWebhookNameUniqueConstraint("name", "org1")),
+						uniqueConstraintsFromEventConstraint(webhook.NewAddWebhookNameUniqueConstraint("name2", "org1")),
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				changeWebhook: &domain.Webhook{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "id1",
+					},
+					Name:       "name2",
+					URL:        "https://example.com/hook",
+					EventTypes: []string{string(user.HumanAddedType)},
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			details, err := c.ChangeWebhook(tt.args.ctx, tt.args.changeWebhook, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_RegenerateWebhookSigningKey(t *testing.T) {
+	type fields struct {
+		eventstore          *eventstore.Eventstore
+		signingKeyGenerator crypto.Generator
+	}
+	type args struct {
+		ctx           context.Context
+		webhookID     string
+		resourceOwner string
+	}
+	type res struct {
+		signingKey string
+		details    *domain.ObjectDetails
+		err        func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"not found, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				webhookID:     "id1",
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			"push ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							webhookAddedEvent("id1", "org1", "name"),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								webhook.NewSigningKeyChangedEvent(context.Background(),
+									&webhook.NewAggregate("id1", "org1").Aggregate,
+									&crypto.CryptoValue{
+										CryptoType: crypto.TypeEncryption,
+										Algorithm:  "enc",
+										KeyID:      "id",
+										Crypted:    []byte("a"),
+									},
+								),
+							),
+						},
+					),
+				),
+				signingKeyGenerator: GetMockSecretGenerator(t),
+			},
+			args{
+				ctx:           context.Background(),
+				webhookID:     "id1",
+				resourceOwner: "org1",
+			},
+			res{
+				signingKey: "a",
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:                 tt.fields.eventstore,
+				webhookSigningKeyGenerator: tt.fields.signingKeyGenerator,
+			}
+			signingKey, details, err := c.RegenerateWebhookSigningKey(tt.args.ctx, tt.args.webhookID, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.signingKey, signingKey)
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_DeactivateWebhook(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		webhookID     string
+		resourceOwner string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"id missing, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"not active, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							webhookAddedEvent("id1", "org1", "name"),
+						),
+						eventFromEventPusher(
+							webhook.NewDeactivatedEvent(context.Background(),
+								&webhook.NewAggregate("id1", "org1").Aggregate,
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				webhookID:     "id1",
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			"push ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							webhookAddedEvent("id1", "org1", "name"),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								webhook.NewDeactivatedEvent(context.Background(),
+									&webhook.NewAggregate("id1", "org1").Aggregate,
+								),
+							),
+						},
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				webhookID:     "id1",
+				resourceOwner: "org1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			details, err := c.DeactivateWebhook(tt.args.ctx, tt.args.webhookID, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_RemoveWebhook(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		webhookID     string
+		resourceOwner string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"not found, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				webhookID:     "id1",
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			"push ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							webhookAddedEvent("id1", "org1", "name"),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								webhook.NewRemovedEvent(context.Background(),
+									&webhook.NewAggregate("id1", "org1").Aggregate,
+									"name",
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(webhook.NewRemoveWebhookNameUniqueConstraint("name", "org1")),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				webhookID:     "id1",
+				resourceOwner: "org1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			details, err := c.RemoveWebhook(tt.args.ctx, tt.args.webhookID, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_ReplayWebhookDelivery(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx                context.Context
+		webhookID          string
+		resourceOwner      string
+		eventSequence      uint64
+		eventAggregateType eventstore.AggregateType
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"sequence missing, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:                context.Background(),
+				webhookID:          "id1",
+				resourceOwner:      "org1",
+				eventAggregateType: user.AggregateType,
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"inactive, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							webhookAddedEvent("id1", "org1", "name"),
+						),
+						eventFromEventPusher(
+							webhook.NewDeactivatedEvent(context.Background(),
+								&webhook.NewAggregate("id1", "org1").Aggregate,
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:                context.Background(),
+				webhookID:          "id1",
+				resourceOwner:      "org1",
+				eventSequence:      42,
+				eventAggregateType: user.AggregateType,
+			},
+			res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			"push ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							webhookAddedEvent("id1", "org1", "name"),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								webhook.NewDeliveryReplayRequestedEvent(context.Background(),
+									&webhook.NewAggregate("id1", "org1").Aggregate,
+									42,
+									user.AggregateType,
+								),
+							),
+						},
+					),
+				),
+			},
+			args{
+				ctx:                context.Background(),
+				webhookID:          "id1",
+				resourceOwner:      "org1",
+				eventSequence:      42,
+				eventAggregateType: user.AggregateType,
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			details, err := c.ReplayWebhookDelivery(tt.args.ctx, tt.args.webhookID, tt.args.resourceOwner, tt.args.eventSequence, tt.args.eventAggregateType)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_FailWebhookDelivery(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx                context.Context
+		webhookID          string
+		resourceOwner      string
+		eventSequence      uint64
+		eventAggregateType eventstore.AggregateType
+		eventType          eventstore.EventType
+		attempts           uint64
+		statusCode         int
+		lastError          string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"sequence missing, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:                context.Background(),
+				webhookID:          "id1",
+				resourceOwner:      "org1",
+				eventAggregateType: user.AggregateType,
+				eventType:          user.UserV1AddedType,
+				attempts:           3,
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"not found, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args{
+				ctx:                context.Background(),
+				webhookID:          "id1",
+				resourceOwner:      "org1",
+				eventSequence:      42,
+				eventAggregateType: user.AggregateType,
+				eventType:          user.UserV1AddedType,
+				attempts:           3,
+			},
+			res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			"push ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							webhookAddedEvent("id1", "org1", "name"),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								webhook.NewDeliveryFailedEvent(context.Background(),
+									&webhook.NewAggregate("id1", "org1").Aggregate,
+									42,
+									user.AggregateType,
+									user.UserV1AddedType,
+									3,
+									500,
+									"internal server error",
+								),
+							),
+						},
+					),
+				),
+			},
+			args{
+				ctx:                context.Background(),
+				webhookID:          "id1",
+				resourceOwner:      "org1",
+				eventSequence:      42,
+				eventAggregateType: user.AggregateType,
+				eventType:          user.UserV1AddedType,
+				attempts:           3,
+				statusCode:         500,
+				lastError:          "internal server error",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			details, err := c.FailWebhookDelivery(tt.args.ctx, tt.args.webhookID, tt.args.resourceOwner, tt.args.eventSequence, tt.args.eventAggregateType, tt.args.eventType, tt.args.attempts, tt.args.statusCode, tt.args.lastError)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func webhookAddedEvent(id, resourceOwner, name string) *webhook.AddedEvent {
+	return webhook.NewAddedEvent(context.Background(),
+		&webhook.NewAggregate(id, resourceOwner).Aggregate,
+		name,
+		"https://example.com/hook",
+		nil,
+		nil,
+		&crypto.CryptoValue{
+			CryptoType: crypto.TypeEncryption,
+			Algorithm:  "enc",
+			KeyID:      "id",
+			Crypted:    []byte("a"),
+		},
+	)
+}
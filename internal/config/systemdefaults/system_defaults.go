@@ -8,26 +8,39 @@ import (
 
 type SystemDefaults struct {
 	SecretGenerators   SecretGenerators
+	PasswordHasher     crypto.PasswordHashConfig
 	Multifactors       MultifactorConfig
 	DomainVerification DomainVerification
 	Notifications      Notifications
 	KeyConfig          KeyConfig
+	Webhooks           Webhooks
+	EventActions       EventActions
+	ActionsKeyValue    ActionsKeyValue
 }
 
 type SecretGenerators struct {
 	PasswordSaltCost   int
 	MachineKeySize     uint32
 	ApplicationKeySize uint32
+	// OTPSMS and OTPEmail are used for instances without a configured generator of the type
+	OTPSMS   *crypto.GeneratorConfig
+	OTPEmail *crypto.GeneratorConfig
 }
 
 type MultifactorConfig struct {
-	OTP OTPConfig
+	OTP           OTPConfig
+	RecoveryCodes RecoveryCodesConfig
 }
 
 type OTPConfig struct {
 	Issuer string
 }
 
+type RecoveryCodesConfig struct {
+	Count     int
+	Generator crypto.GeneratorConfig
+}
+
 type DomainVerification struct {
 	VerificationGenerator crypto.GeneratorConfig
 }
@@ -43,3 +56,38 @@ type KeyConfig struct {
 	CertificateSize     int
 	CertificateLifetime time.Duration
 }
+
+type Webhooks struct {
+	SigningKeyGenerator crypto.GeneratorConfig
+	DeliveryTimeout     time.Duration
+	// DeliveryInterval is the interval the pending deliveries are checked
+	DeliveryInterval time.Duration
+	// DeliveryBulkLimit is the maximum amount of deliveries sent per interval
+	DeliveryBulkLimit uint64
+	// MaxDeliveryAttempts is the amount of attempts until a delivery is marked as failed
+	MaxDeliveryAttempts uint64
+	// RetryDelay is doubled on every failed attempt up to MaxRetryDelay
+	RetryDelay    time.Duration
+	MaxRetryDelay time.Duration
+}
+
+// ActionsKeyValue are the quotas of the key-value storage of the actions per organisation,
+// a limit of 0 is unlimited
+type EventActions struct {
+	// ExecutionInterval is the interval the pending executions are checked
+	ExecutionInterval time.Duration
+	// ExecutionBulkLimit is the maximum amount of executions run per interval
+	ExecutionBulkLimit uint64
+	// MaxExecutionAttempts is the amount of attempts until an execution is marked as failed
+	MaxExecutionAttempts uint64
+	// RetryDelay is doubled on every failed attempt up to MaxRetryDelay
+	RetryDelay    time.Duration
+	MaxRetryDelay time.Duration
+}
+
+type ActionsKeyValue struct {
+	MaxKeyLength  int
+	MaxValueBytes int
+	MaxEntries    int
+	MaxTotalBytes int
+}
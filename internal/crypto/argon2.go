@@ -0,0 +1,108 @@
+package crypto
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"strconv"
+
+	"golang.org/x/crypto/argon2"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+var _ HashAlgorithm = (*Argon2)(nil)
+
+const (
+	argon2idID     = "argon2id"
+	argon2iID      = "argon2i"
+	argon2KeyLen   = 32
+	argon2Encoding = "$%s$v=%d$m=%d,t=%d,p=%d$%s$%s"
+
+	// argon2MaxMemory is the maximum memory (in KiB) of hashes to be verified
+	argon2MaxMemory  = 1 << 20
+	argon2MaxTime    = 16
+	argon2MaxThreads = 16
+)
+
+// Argon2 hashes values using argon2id.
+// Hashes of argon2i and argon2id in the PHC string format can be verified.
+type Argon2 struct {
+	time    uint32
+	memory  uint32
+	threads uint8
+}
+
+// NewArgon2 creates an argon2id hasher, memory is defined in KiB
+func NewArgon2(time, memory uint32, threads uint8) *Argon2 {
+	return &Argon2{
+		time:    time,
+		memory:  memory,
+		threads: threads,
+	}
+}
+
+func (a *Argon2) Algorithm() string {
+	return "argon2"
+}
+
+func (a *Argon2) Hash(value []byte) ([]byte, error) {
+	salt, err := newSalt()
+	if err != nil {
+		return nil, err
+	}
+	hash := argon2.IDKey(value, salt, a.time, a.memory, a.threads, argon2KeyLen)
+	return []byte(fmt.Sprintf(argon2Encoding, argon2idID, argon2.Version, a.memory, a.time, a.threads, encodePHCBase64(salt), encodePHCBase64(hash))), nil
+}
+
+func (a *Argon2) CompareHash(hashed, value []byte) error {
+	parsed, params, err := parseArgon2(hashed)
+	if err != nil {
+		return err
+	}
+	var hash []byte
+	switch parsed.id {
+	case argon2idID:
+		hash = argon2.IDKey(value, parsed.salt, params.time, params.memory, params.threads, uint32(len(parsed.hash)))
+	case argon2iID:
+		hash = argon2.Key(value, parsed.salt, params.time, params.memory, params.threads, uint32(len(parsed.hash)))
+	}
+	if subtle.ConstantTimeCompare(hash, parsed.hash) != 1 {
+		return errors.ThrowInvalidArgument(nil, "CRYPT-Ohc4u", "hash and value do not match")
+	}
+	return nil
+}
+
+// NeedsRehash returns true if the value was not hashed with argon2id or other parameters
+func (a *Argon2) NeedsRehash(hashed []byte) bool {
+	parsed, params, err := parseArgon2(hashed)
+	if err != nil {
+		return true
+	}
+	return parsed.id != argon2idID || *params != *a
+}
+
+func parseArgon2(hashed []byte) (*phcHash, *Argon2, error) {
+	parsed, err := parsePHC(hashed)
+	if err != nil {
+		return nil, nil, err
+	}
+	if parsed.id != argon2idID && parsed.id != argon2iID {
+		return nil, nil, errors.ThrowInvalidArgument(nil, "CRYPT-Zah1e", "hash is not an argon2 hash")
+	}
+	if version, ok := parsed.params["v"]; ok && version != strconv.Itoa(argon2.Version) {
+		return nil, nil, errors.ThrowInvalidArgument(nil, "CRYPT-Eek8a", "unsupported argon2 version")
+	}
+	memory, err := parsed.boundedIntParam("m", argon2MaxMemory)
+	if err != nil {
+		return nil, nil, err
+	}
+	time, err := parsed.boundedIntParam("t", argon2MaxTime)
+	if err != nil {
+		return nil, nil, err
+	}
+	threads, err := parsed.boundedIntParam("p", argon2MaxThreads)
+	if err != nil {
+		return nil, nil, err
+	}
+	return parsed, NewArgon2(uint32(time), uint32(memory), uint8(threads)), nil
+}
@@ -25,3 +25,15 @@ func (b *BCrypt) Hash(value []byte) ([]byte, error) {
 func (b *BCrypt) CompareHash(hashed, value []byte) error {
 	return bcrypt.CompareHashAndPassword(hashed, value)
 }
+
+// NeedsRehash returns true if the value was hashed with another cost
+func (b *BCrypt) NeedsRehash(hashed []byte) bool {
+	cost, err := bcrypt.Cost(hashed)
+	if err != nil {
+		return true
+	}
+	if b.cost < bcrypt.MinCost {
+		return cost != bcrypt.DefaultCost
+	}
+	return cost != b.cost
+}
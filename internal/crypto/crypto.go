@@ -119,12 +119,29 @@ func Hash(value []byte, alg HashAlgorithm) (*CryptoValue, error) {
 }
 
 func CompareHash(value *CryptoValue, comparer []byte, alg HashAlgorithm) error {
-	if value.Algorithm != alg.Algorithm() {
+	if value.Algorithm != alg.Algorithm() && !verifies(alg, value.Algorithm) {
 		return errors.ThrowInvalidArgument(nil, "CRYPT-HF32f", "value was hashed with a different algorithm")
 	}
 	return alg.CompareHash(value.Crypted, comparer)
 }
 
+// NeedsRehash returns true if the value was not hashed by the algorithm,
+// or the algorithm would hash it with other parameters
+func NeedsRehash(value *CryptoValue, alg HashAlgorithm) bool {
+	if value.Algorithm != alg.Algorithm() {
+		return true
+	}
+	if rehasher, ok := alg.(rehasher); ok {
+		return rehasher.NeedsRehash(value.Crypted)
+	}
+	return false
+}
+
+func verifies(alg HashAlgorithm, algorithm string) bool {
+	v, ok := alg.(verifier)
+	return ok && v.Verifies(algorithm)
+}
+
 func FillHash(value []byte, alg HashAlgorithm) *CryptoValue {
 	return &CryptoValue{
 		CryptoType: TypeHash,
@@ -2,6 +2,8 @@ package database
 
 import (
 	"database/sql"
+	"errors"
+	"sort"
 
 	sq "github.com/Masterminds/squirrel"
 
@@ -34,6 +36,24 @@ func NewKeyStorage(client *sql.DB, masterKey string) (*database, error) {
 	}, nil
 }
 
+// NewWrappedKeyStorage stores the keys in the database,
+// but (instead of the master key) uses the wrapper (e.g. an external KMS) to encrypt them
+func NewWrappedKeyStorage(client *sql.DB, wrapper crypto.KeyWrapper) *database {
+	d := &database{client: client}
+	d.useWrapper(wrapper)
+	return d
+}
+
+func (d *database) useWrapper(wrapper crypto.KeyWrapper) {
+	d.masterKey = ""
+	d.encrypt = func(key, _ string) (string, error) {
+		return wrapper.WrapKey(key)
+	}
+	d.decrypt = func(encryptedKey, _ string) (string, error) {
+		return wrapper.UnwrapKey(encryptedKey)
+	}
+}
+
 func (d *database) ReadKeys() (crypto.Keys, error) {
 	keys := make(map[string]string)
 	stmt, args, err := sq.Select(encryptionKeysIDCol, encryptionKeysKeyCol).
@@ -82,6 +102,9 @@ func (d *database) ReadKey(id string) (*crypto.Key, error) {
 	}
 	var encryptionKey string
 	err = row.Scan(&encryptionKey)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, caos_errs.ThrowNotFound(err, "", "key not found")
+	}
 	if err != nil {
 		return nil, caos_errs.ThrowInternal(err, "", "unable to read key")
 	}
@@ -125,6 +148,68 @@ func (d *database) CreateKeys(keys ...*crypto.Key) error {
 	return nil
 }
 
+func (d *database) DeleteKeys(ids ...string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	stmt, args, err := sq.Delete(EncryptionKeysTable).
+		Where(sq.Eq{encryptionKeysIDCol: ids}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "", "unable to delete keys")
+	}
+	_, err = d.client.Exec(stmt, args...)
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "", "unable to delete keys")
+	}
+	return nil
+}
+
+// RewrapKeys re-encrypts all stored keys with the wrapper (e.g. a new master key or a rotated KMS key),
+// which is used for all further reads and writes
+func (d *database) RewrapKeys(wrapper crypto.KeyWrapper) error {
+	keys, err := d.ReadKeys()
+	if err != nil {
+		return err
+	}
+	ids := make([]string, 0, len(keys))
+	for id := range keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	tx, err := d.client.Begin()
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "", "unable to rewrap keys")
+	}
+	for _, id := range ids {
+		wrappedKey, err := wrapper.WrapKey(keys[id])
+		if err != nil {
+			tx.Rollback()
+			return caos_errs.ThrowInternal(err, "", "unable to encrypt key")
+		}
+		stmt, args, err := sq.Update(EncryptionKeysTable).
+			Set(encryptionKeysKeyCol, wrappedKey).
+			Where(sq.Eq{encryptionKeysIDCol: id}).
+			PlaceholderFormat(sq.Dollar).
+			ToSql()
+		if err != nil {
+			tx.Rollback()
+			return caos_errs.ThrowInternal(err, "", "unable to rewrap keys")
+		}
+		if _, err = tx.Exec(stmt, args...); err != nil {
+			tx.Rollback()
+			return caos_errs.ThrowInternal(err, "", "unable to rewrap keys")
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return caos_errs.ThrowInternal(err, "", "unable to rewrap keys")
+	}
+	d.useWrapper(wrapper)
+	return nil
+}
+
 func checkMasterKeyLength(masterKey string) error {
 	if length := len([]byte(masterKey)); length != 32 {
 		return caos_errs.ThrowInternalf(nil, "", "masterkey must be 32 bytes, but is %d", length)
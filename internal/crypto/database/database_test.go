@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -186,7 +187,7 @@ func Test_database_ReadKey(t *testing.T) {
 				id: "id1",
 			},
 			res{
-				err: caos_errs.IsInternal,
+				err: caos_errs.IsNotFound,
 			},
 		},
 		{
@@ -407,6 +408,192 @@ func Test_database_CreateKeys(t *testing.T) {
 	}
 }
 
+func Test_database_DeleteKeys(t *testing.T) {
+	type fields struct {
+		client db
+	}
+	type args struct {
+		ids []string
+	}
+	type res struct {
+		err func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"no ids, ok",
+			fields{
+				client: dbMock(t),
+			},
+			args{},
+			res{
+				err: nil,
+			},
+		},
+		{
+			"delete fails, error",
+			fields{
+				client: dbMock(t,
+					expectExec("DELETE FROM system.encryption_keys WHERE id IN ($1)", sql.ErrConnDone, "id1"),
+				),
+			},
+			args{
+				ids: []string{"id1"},
+			},
+			res{
+				err: func(err error) bool {
+					return errors.Is(err, sql.ErrConnDone)
+				},
+			},
+		},
+		{
+			"delete multiple keys, ok",
+			fields{
+				client: dbMock(t,
+					expectExec("DELETE FROM system.encryption_keys WHERE id IN ($1,$2)", nil, "id1", "id2"),
+				),
+			},
+			args{
+				ids: []string{"id1", "id2"},
+			},
+			res{
+				err: nil,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &database{
+				client: tt.fields.client.db,
+			}
+			err := d.DeleteKeys(tt.args.ids...)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			} else if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v", err)
+			}
+			if err := tt.fields.client.mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// testWrapper wraps the keys by adding a prefix
+type testWrapper struct{}
+
+func (testWrapper) WrapKey(key string) (string, error) {
+	return "wrapped:" + key, nil
+}
+
+func (testWrapper) UnwrapKey(wrappedKey string) (string, error) {
+	return strings.TrimPrefix(wrappedKey, "wrapped:"), nil
+}
+
+func Test_database_RewrapKeys(t *testing.T) {
+	type fields struct {
+		client db
+	}
+	type res struct {
+		err func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		res    res
+	}{
+		{
+			"query fails, error",
+			fields{
+				client: dbMock(t, expectQueryErr("SELECT id, key FROM system.encryption_keys", sql.ErrConnDone)),
+			},
+			res{
+				err: func(err error) bool {
+					return errors.Is(err, sql.ErrConnDone)
+				},
+			},
+		},
+		{
+			"update fails, error",
+			fields{
+				client: dbMock(t,
+					expectQuery(
+						"SELECT id, key FROM system.encryption_keys",
+						[]string{"id", "key"},
+						[][]driver.Value{
+							{
+								"id1",
+								"key1",
+							},
+						}),
+					expectBegin(nil),
+					expectExec("UPDATE system.encryption_keys SET key = $1 WHERE id = $2", sql.ErrTxDone, "wrapped:key1", "id1"),
+					expectRollback(nil),
+				),
+			},
+			res{
+				err: func(err error) bool {
+					return errors.Is(err, sql.ErrTxDone)
+				},
+			},
+		},
+		{
+			"rewrap keys, ok",
+			fields{
+				client: dbMock(t,
+					expectQuery(
+						"SELECT id, key FROM system.encryption_keys",
+						[]string{"id", "key"},
+						[][]driver.Value{
+							{
+								"id2",
+								"key2",
+							},
+							{
+								"id1",
+								"key1",
+							},
+						}),
+					expectBegin(nil),
+					expectExec("UPDATE system.encryption_keys SET key = $1 WHERE id = $2", nil, "wrapped:key1", "id1"),
+					expectExec("UPDATE system.encryption_keys SET key = $1 WHERE id = $2", nil, "wrapped:key2", "id2"),
+					expectCommit(nil),
+				),
+			},
+			res{
+				err: nil,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &database{
+				client:    tt.fields.client.db,
+				masterKey: "masterKey",
+				decrypt: func(encryptedKey, masterKey string) (key string, err error) {
+					return encryptedKey, nil
+				},
+			}
+			err := d.RewrapKeys(testWrapper{})
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+				encrypted, err := d.encrypt("key", d.masterKey)
+				assert.NoError(t, err)
+				assert.Equal(t, "wrapped:key", encrypted)
+			} else if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v", err)
+			}
+			if err := tt.fields.client.mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
 func Test_checkMasterKeyLength(t *testing.T) {
 	type args struct {
 		masterKey string
@@ -0,0 +1,39 @@
+package crypto
+
+import (
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// KeyWrapper encrypts (wraps) the encryption keys before they are stored and decrypts (unwraps) them after reading
+// this allows the keys to be protected by an external key management system (envelope encryption)
+type KeyWrapper interface {
+	WrapKey(key string) (wrappedKey string, err error)
+	UnwrapKey(wrappedKey string) (key string, err error)
+}
+
+// RotatableKeyWrapper is a KeyWrapper which is able to create a new version of its own key
+// keys wrapped by a previous version must still be unwrappable
+type RotatableKeyWrapper interface {
+	KeyWrapper
+	RotateKey() error
+}
+
+type masterKeyWrapper struct {
+	masterKey string
+}
+
+// NewMasterKeyWrapper returns a KeyWrapper encrypting the keys with the master key using AES
+func NewMasterKeyWrapper(masterKey string) (KeyWrapper, error) {
+	if length := len([]byte(masterKey)); length != 32 {
+		return nil, errors.ThrowInternalf(nil, "CRYPT-Ks82m", "masterkey must be 32 bytes, but is %d", length)
+	}
+	return &masterKeyWrapper{masterKey: masterKey}, nil
+}
+
+func (w *masterKeyWrapper) WrapKey(key string) (string, error) {
+	return EncryptAESString(key, w.masterKey)
+}
+
+func (w *masterKeyWrapper) UnwrapKey(wrappedKey string) (string, error) {
+	return DecryptAESString(wrappedKey, w.masterKey)
+}
@@ -0,0 +1,23 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMasterKeyWrapper(t *testing.T) {
+	_, err := NewMasterKeyWrapper("tooshort")
+	assert.Error(t, err)
+
+	wrapper, err := NewMasterKeyWrapper("!themasterkeywhichis32byteslong!")
+	require.NoError(t, err)
+	wrapped, err := wrapper.WrapKey("encryptionkey")
+	require.NoError(t, err)
+	assert.NotEqual(t, "encryptionkey", wrapped)
+
+	key, err := wrapper.UnwrapKey(wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, "encryptionkey", key)
+}
@@ -0,0 +1,206 @@
+package crypto
+
+import (
+	"bytes"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+var _ HashAlgorithm = (*PasswordHasher)(nil)
+
+const (
+	HashNameBCrypt = "bcrypt"
+	HashNameArgon2 = "argon2"
+	HashNameScrypt = "scrypt"
+	HashNamePBKDF2 = "pbkdf2"
+	// HashNameSaltedSHA is only supported for verification
+	HashNameSaltedSHA = "salted_sha"
+)
+
+// hashPrefixes maps the algorithm names to the prefixes of their encoded hashes
+var hashPrefixes = map[string][]string{
+	HashNameBCrypt:    {"$2a$", "$2b$", "$2y$"},
+	HashNameArgon2:    {"$" + argon2idID + "$", "$" + argon2iID + "$"},
+	HashNameScrypt:    {"$" + scryptID + "$"},
+	HashNamePBKDF2:    {"$" + pbkdf2IDPrefix + string(PBKDF2SHA256) + "$", "$" + pbkdf2IDPrefix + string(PBKDF2SHA512) + "$"},
+	HashNameSaltedSHA: {"{SSHA}", "{SSHA256}", "{SSHA512}"},
+}
+
+type PasswordHashConfig struct {
+	// Algorithm used to hash new passwords: bcrypt, argon2, scrypt or pbkdf2
+	Algorithm string
+	BCrypt    BCryptConfig
+	Argon2    Argon2Config
+	Scrypt    ScryptConfig
+	PBKDF2    PBKDF2Config
+}
+
+type BCryptConfig struct {
+	Cost int
+}
+
+type Argon2Config struct {
+	Time uint32
+	// Memory in KiB
+	Memory  uint32
+	Threads uint8
+}
+
+type ScryptConfig struct {
+	// Cost is the base 2 logarithm of the CPU/memory cost parameter N
+	Cost        int
+	BlockSize   int
+	Parallelism int
+}
+
+type PBKDF2Config struct {
+	Rounds int
+	// Hash is either sha256 or sha512
+	Hash PBKDF2Hash
+}
+
+// NewHasher creates a PasswordHasher, which hashes with the configured algorithm
+// and verifies hashes of all supported algorithms
+func (c *PasswordHashConfig) NewHasher() (*PasswordHasher, error) {
+	verifiers := map[string]HashAlgorithm{
+		HashNameBCrypt:    NewBCrypt(c.BCrypt.Cost),
+		HashNameArgon2:    NewArgon2(c.Argon2.Time, c.Argon2.Memory, c.Argon2.Threads),
+		HashNameScrypt:    NewScrypt(c.Scrypt.Cost, c.Scrypt.BlockSize, c.Scrypt.Parallelism),
+		HashNamePBKDF2:    NewPBKDF2(c.PBKDF2.Rounds, c.PBKDF2.Hash),
+		HashNameSaltedSHA: NewSaltedSHA(),
+	}
+	algorithm := c.Algorithm
+	if algorithm == "" {
+		algorithm = HashNameBCrypt
+	}
+	preferred, ok := verifiers[algorithm]
+	if !ok || algorithm == HashNameSaltedSHA {
+		return nil, errors.ThrowInvalidArgumentf(nil, "CRYPT-eiP2e", "unsupported password hash algorithm %s", c.Algorithm)
+	}
+	if !c.withinBounds(algorithm) {
+		return nil, errors.ThrowInvalidArgumentf(nil, "CRYPT-Gai3k", "parameters of password hash algorithm %s exceed the maximum", algorithm)
+	}
+	return &PasswordHasher{
+		preferred: preferred,
+		verifiers: verifiers,
+	}, nil
+}
+
+// withinBounds returns false if the parameters of the algorithm exceed the maximum of hashes to be verified,
+// otherwise the hashes of the algorithm could not be verified anymore
+func (c *PasswordHashConfig) withinBounds(algorithm string) bool {
+	switch algorithm {
+	case HashNameArgon2:
+		return c.Argon2.Memory <= argon2MaxMemory && c.Argon2.Time <= argon2MaxTime && c.Argon2.Threads <= argon2MaxThreads
+	case HashNameScrypt:
+		return c.Scrypt.Cost <= scryptMaxCost && c.Scrypt.BlockSize <= scryptMaxBlockSize && c.Scrypt.Parallelism <= scryptMaxParallelism &&
+			128*c.Scrypt.BlockSize<<c.Scrypt.Cost <= scryptMaxMemory
+	case HashNamePBKDF2:
+		return c.PBKDF2.Rounds <= pbkdf2MaxRounds
+	default:
+		return true
+	}
+}
+
+// PasswordHasher hashes values with the preferred algorithm
+// and verifies hashes of all of its algorithms based on the encoding of the hash.
+type PasswordHasher struct {
+	preferred HashAlgorithm
+	verifiers map[string]HashAlgorithm
+}
+
+func NewPasswordHasher(preferred HashAlgorithm, verifiers ...HashAlgorithm) *PasswordHasher {
+	hasher := &PasswordHasher{
+		preferred: preferred,
+		verifiers: map[string]HashAlgorithm{preferred.Algorithm(): preferred},
+	}
+	for _, verifier := range verifiers {
+		hasher.verifiers[verifier.Algorithm()] = verifier
+	}
+	return hasher
+}
+
+func (h *PasswordHasher) Algorithm() string {
+	return h.preferred.Algorithm()
+}
+
+func (h *PasswordHasher) Hash(value []byte) ([]byte, error) {
+	return h.preferred.Hash(value)
+}
+
+func (h *PasswordHasher) CompareHash(hashed, value []byte) error {
+	algorithm, ok := EncodedHashAlgorithm(hashed)
+	if !ok {
+		return errors.ThrowInvalidArgument(nil, "CRYPT-Ooy4o", "unknown hash encoding")
+	}
+	verifier, ok := h.verifiers[algorithm]
+	if !ok {
+		return errors.ThrowInvalidArgumentf(nil, "CRYPT-Thoh1", "hash algorithm %s not supported", algorithm)
+	}
+	return verifier.CompareHash(hashed, value)
+}
+
+// Verifies returns true if the hasher is able to verify hashes of the algorithm
+func (h *PasswordHasher) Verifies(algorithm string) bool {
+	_, ok := h.verifiers[algorithm]
+	return ok
+}
+
+// NeedsRehash returns true if the value was not hashed with the preferred algorithm and its parameters
+func (h *PasswordHasher) NeedsRehash(hashed []byte) bool {
+	algorithm, ok := EncodedHashAlgorithm(hashed)
+	if !ok || algorithm != h.preferred.Algorithm() {
+		return true
+	}
+	if rehasher, ok := h.preferred.(rehasher); ok {
+		return rehasher.NeedsRehash(hashed)
+	}
+	return false
+}
+
+// EncodedHashAlgorithm returns the name of the algorithm of the encoded hash (e.g. bcrypt for $2a$...)
+func EncodedHashAlgorithm(hashed []byte) (string, bool) {
+	for algorithm, prefixes := range hashPrefixes {
+		for _, prefix := range prefixes {
+			if bytes.HasPrefix(hashed, []byte(prefix)) {
+				return algorithm, true
+			}
+		}
+	}
+	return "", false
+}
+
+// ValidateHash checks if the encoded hash (e.g. imported from another system) is of a supported algorithm
+// and returns the name of the algorithm
+func ValidateHash(hashed []byte) (algorithm string, err error) {
+	algorithm, ok := EncodedHashAlgorithm(hashed)
+	if !ok {
+		return "", errors.ThrowInvalidArgument(nil, "CRYPT-Ahx2e", "unknown hash encoding")
+	}
+	switch algorithm {
+	case HashNameBCrypt:
+		_, err = bcrypt.Cost(hashed)
+	case HashNameArgon2:
+		_, _, err = parseArgon2(hashed)
+	case HashNameScrypt:
+		_, _, err = parseScrypt(hashed)
+	case HashNamePBKDF2:
+		_, _, err = parsePBKDF2(hashed)
+	case HashNameSaltedSHA:
+		_, _, _, err = parseSaltedSHA(hashed)
+	}
+	if err != nil {
+		return "", errors.ThrowInvalidArgument(err, "CRYPT-Eet0o", "invalid hash encoding")
+	}
+	return algorithm, nil
+}
+
+type rehasher interface {
+	NeedsRehash(hashed []byte) bool
+}
+
+type verifier interface {
+	Verifies(algorithm string) bool
+}
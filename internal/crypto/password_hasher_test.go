@@ -0,0 +1,428 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// reference vector of the argon2 implementation
+	testArgon2idHash = "$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc"
+	// generated with python hashlib
+	testScryptHash       = "$scrypt$ln=10,r=8,p=1$c29tZXNhbHQ$wdXoWEig5T693O7BJbufEPRk+qarG40BYOh1xe9tMAc"
+	testPBKDF2SHA256Hash = "$pbkdf2-sha256$29000$c29tZXNhbHQ$XUeGYQ3sIaVdge7New8jE56qGaNGNR.lgvAWUWJP6HQ"
+	testPBKDF2SHA512Hash = "$pbkdf2-sha512$i=25000$c29tZXNhbHQ$dPhAiisJTJeyqwZoZeRGNm/W4bjnC+xprSa+lbi1m4MXDwlS13djpFsZluRygeAfCn9yl3wsfyAhvMIr/dKULg"
+	testSSHAHash         = "{SSHA}SOafmtUa/yFPHXiPgrkr9Wb4bBNzb21lc2FsdA=="
+	testSSHA256Hash      = "{SSHA256}a8621T1RoRw73nfoyv4fFSeCxeUqE+UU2hKp41sMK8tzb21lc2FsdA=="
+	testSSHA512Hash      = "{SSHA512}U9QofRytkquBdYprmfnR4BWgjYUdOQXMqOGsX45Na6Vby7qIrgoARc/hxEpeG1zH2Xd6hG5vg8cz2PNgCYtUTXNvbWVzYWx0"
+	testBCryptHash       = "$2a$04$zDEuMyP7AJsC/5i8PYFEROvfNGFo9p.Ari2RPWPuK1sj3SjvHBKPG"
+)
+
+func TestHashAlgorithms_CompareHash(t *testing.T) {
+	tests := []struct {
+		name    string
+		alg     HashAlgorithm
+		hashed  string
+		value   string
+		wantErr bool
+	}{
+		{
+			name:   "argon2id",
+			alg:    NewArgon2(1, 64, 1),
+			hashed: testArgon2idHash,
+			value:  "password",
+		},
+		{
+			name:    "argon2id wrong password",
+			alg:     NewArgon2(1, 64, 1),
+			hashed:  testArgon2idHash,
+			value:   "wrong",
+			wantErr: true,
+		},
+		{
+			name:   "scrypt",
+			alg:    NewScrypt(15, 8, 1),
+			hashed: testScryptHash,
+			value:  "password",
+		},
+		{
+			name:    "scrypt wrong password",
+			alg:     NewScrypt(15, 8, 1),
+			hashed:  testScryptHash,
+			value:   "wrong",
+			wantErr: true,
+		},
+		{
+			name:   "pbkdf2 sha256 modular crypt format",
+			alg:    NewPBKDF2(1000, PBKDF2SHA256),
+			hashed: testPBKDF2SHA256Hash,
+			value:  "password",
+		},
+		{
+			name:   "pbkdf2 sha512 phc format",
+			alg:    NewPBKDF2(1000, PBKDF2SHA256),
+			hashed: testPBKDF2SHA512Hash,
+			value:  "password",
+		},
+		{
+			name:    "pbkdf2 wrong password",
+			alg:     NewPBKDF2(1000, PBKDF2SHA256),
+			hashed:  testPBKDF2SHA256Hash,
+			value:   "wrong",
+			wantErr: true,
+		},
+		{
+			name:    "pbkdf2 unsupported hash",
+			alg:     NewPBKDF2(1000, PBKDF2SHA256),
+			hashed:  "$pbkdf2-md5$1000$c29tZXNhbHQ$c29tZXNhbHQ",
+			value:   "password",
+			wantErr: true,
+		},
+		{
+			name:   "salted sha1",
+			alg:    NewSaltedSHA(),
+			hashed: testSSHAHash,
+			value:  "password",
+		},
+		{
+			name:   "salted sha256",
+			alg:    NewSaltedSHA(),
+			hashed: testSSHA256Hash,
+			value:  "password",
+		},
+		{
+			name:   "salted sha512",
+			alg:    NewSaltedSHA(),
+			hashed: testSSHA512Hash,
+			value:  "password",
+		},
+		{
+			name:    "salted sha wrong password",
+			alg:     NewSaltedSHA(),
+			hashed:  testSSHA256Hash,
+			value:   "wrong",
+			wantErr: true,
+		},
+		{
+			name:    "invalid encoding",
+			alg:     NewArgon2(1, 64, 1),
+			hashed:  "argon2id",
+			value:   "password",
+			wantErr: true,
+		},
+		{
+			name:    "other algorithm",
+			alg:     NewScrypt(15, 8, 1),
+			hashed:  testArgon2idHash,
+			value:   "password",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.alg.CompareHash([]byte(tt.hashed), []byte(tt.value))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestHashAlgorithms_Hash(t *testing.T) {
+	tests := []struct {
+		name string
+		alg  interface {
+			HashAlgorithm
+			rehasher
+		}
+	}{
+		{
+			name: "bcrypt",
+			alg:  NewBCrypt(4),
+		},
+		{
+			name: "argon2",
+			alg:  NewArgon2(1, 64, 1),
+		},
+		{
+			name: "scrypt",
+			alg:  NewScrypt(4, 8, 1),
+		},
+		{
+			name: "pbkdf2 sha256",
+			alg:  NewPBKDF2(1000, PBKDF2SHA256),
+		},
+		{
+			name: "pbkdf2 sha512",
+			alg:  NewPBKDF2(1000, PBKDF2SHA512),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hashed, err := tt.alg.Hash([]byte("password"))
+			require.NoError(t, err)
+
+			algorithm, ok := EncodedHashAlgorithm(hashed)
+			assert.True(t, ok)
+			assert.Equal(t, tt.alg.Algorithm(), algorithm)
+			assert.NoError(t, tt.alg.CompareHash(hashed, []byte("password")))
+			assert.Error(t, tt.alg.CompareHash(hashed, []byte("wrong")))
+			assert.False(t, tt.alg.NeedsRehash(hashed))
+		})
+	}
+}
+
+func TestHashAlgorithms_NeedsRehash(t *testing.T) {
+	tests := []struct {
+		name   string
+		alg    rehasher
+		hashed string
+		want   bool
+	}{
+		{
+			name:   "argon2 same parameters",
+			alg:    NewArgon2(2, 65536, 1),
+			hashed: testArgon2idHash,
+			want:   false,
+		},
+		{
+			name:   "argon2 other parameters",
+			alg:    NewArgon2(3, 65536, 1),
+			hashed: testArgon2idHash,
+			want:   true,
+		},
+		{
+			name:   "scrypt same parameters",
+			alg:    NewScrypt(10, 8, 1),
+			hashed: testScryptHash,
+			want:   false,
+		},
+		{
+			name:   "scrypt other parameters",
+			alg:    NewScrypt(15, 8, 1),
+			hashed: testScryptHash,
+			want:   true,
+		},
+		{
+			name:   "pbkdf2 same parameters",
+			alg:    NewPBKDF2(29000, PBKDF2SHA256),
+			hashed: testPBKDF2SHA256Hash,
+			want:   false,
+		},
+		{
+			name:   "pbkdf2 other hash",
+			alg:    NewPBKDF2(29000, PBKDF2SHA512),
+			hashed: testPBKDF2SHA256Hash,
+			want:   true,
+		},
+		{
+			name:   "invalid encoding",
+			alg:    NewPBKDF2(29000, PBKDF2SHA256),
+			hashed: "hash",
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.alg.NeedsRehash([]byte(tt.hashed)))
+		})
+	}
+}
+
+func TestPasswordHashConfig_NewHasher(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        PasswordHashConfig
+		wantAlgorithm string
+		wantErr       bool
+	}{
+		{
+			name:          "default bcrypt",
+			config:        PasswordHashConfig{},
+			wantAlgorithm: HashNameBCrypt,
+		},
+		{
+			name: "argon2",
+			config: PasswordHashConfig{
+				Algorithm: HashNameArgon2,
+				Argon2:    Argon2Config{Time: 1, Memory: 64, Threads: 1},
+			},
+			wantAlgorithm: HashNameArgon2,
+		},
+		{
+			name: "argon2 memory exceeds maximum",
+			config: PasswordHashConfig{
+				Algorithm: HashNameArgon2,
+				Argon2:    Argon2Config{Time: 1, Memory: argon2MaxMemory + 1, Threads: 1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "pbkdf2 rounds exceed maximum",
+			config: PasswordHashConfig{
+				Algorithm: HashNamePBKDF2,
+				PBKDF2:    PBKDF2Config{Rounds: pbkdf2MaxRounds + 1, Hash: PBKDF2SHA256},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported",
+			config: PasswordHashConfig{
+				Algorithm: "md5",
+			},
+			wantErr: true,
+		},
+		{
+			name: "verification only",
+			config: PasswordHashConfig{
+				Algorithm: HashNameSaltedSHA,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hasher, err := tt.config.NewHasher()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAlgorithm, hasher.Algorithm())
+		})
+	}
+}
+
+func TestPasswordHasher(t *testing.T) {
+	hasher := NewPasswordHasher(
+		NewArgon2(1, 64, 1),
+		NewBCrypt(4),
+		NewScrypt(4, 8, 1),
+		NewPBKDF2(1000, PBKDF2SHA256),
+	)
+	legacy, err := Hash([]byte("password"), NewBCrypt(4))
+	require.NoError(t, err)
+
+	assert.NoError(t, CompareHash(legacy, []byte("password"), hasher), "legacy hash must be verified")
+	assert.Error(t, CompareHash(legacy, []byte("wrong"), hasher))
+	assert.True(t, NeedsRehash(legacy, hasher), "legacy hash must be rehashed")
+
+	for _, hashed := range []string{testScryptHash, testPBKDF2SHA256Hash} {
+		assert.NoError(t, hasher.CompareHash([]byte(hashed), []byte("password")))
+		assert.True(t, hasher.NeedsRehash([]byte(hashed)))
+	}
+
+	rehashed, err := Hash([]byte("password"), hasher)
+	require.NoError(t, err)
+	assert.Equal(t, HashNameArgon2, rehashed.Algorithm)
+	assert.NoError(t, CompareHash(rehashed, []byte("password"), hasher))
+	assert.False(t, NeedsRehash(rehashed, hasher))
+
+	assert.Error(t, CompareHash(&CryptoValue{Algorithm: "md5", Crypted: []byte("hash")}, []byte("password"), hasher))
+	assert.Error(t, hasher.CompareHash([]byte("$unknown$hash"), []byte("password")))
+}
+
+func TestValidateHash(t *testing.T) {
+	tests := []struct {
+		name          string
+		hashed        string
+		wantAlgorithm string
+		wantErr       bool
+	}{
+		{
+			name:          "bcrypt",
+			hashed:        testBCryptHash,
+			wantAlgorithm: HashNameBCrypt,
+		},
+		{
+			name:          "argon2",
+			hashed:        testArgon2idHash,
+			wantAlgorithm: HashNameArgon2,
+		},
+		{
+			name:          "scrypt",
+			hashed:        testScryptHash,
+			wantAlgorithm: HashNameScrypt,
+		},
+		{
+			name:          "pbkdf2",
+			hashed:        testPBKDF2SHA512Hash,
+			wantAlgorithm: HashNamePBKDF2,
+		},
+		{
+			name:          "salted sha",
+			hashed:        testSSHA512Hash,
+			wantAlgorithm: HashNameSaltedSHA,
+		},
+		{
+			name:    "unknown encoding",
+			hashed:  "5f4dcc3b5aa765d61d8327deb882cf99",
+			wantErr: true,
+		},
+		{
+			name:    "invalid bcrypt",
+			hashed:  "$2a$04$tooshort",
+			wantErr: true,
+		},
+		{
+			name:    "invalid argon2 parameters",
+			hashed:  "$argon2id$v=19$m=abc,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc",
+			wantErr: true,
+		},
+		{
+			name:    "argon2 memory exceeds maximum",
+			hashed:  "$argon2id$v=19$m=4194304,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc",
+			wantErr: true,
+		},
+		{
+			name:    "argon2 time exceeds maximum",
+			hashed:  "$argon2id$v=19$m=64,t=100000,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc",
+			wantErr: true,
+		},
+		{
+			name:    "argon2 threads exceed maximum",
+			hashed:  "$argon2id$v=19$m=64,t=2,p=255$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc",
+			wantErr: true,
+		},
+		{
+			name:    "scrypt cost exceeds maximum",
+			hashed:  "$scrypt$ln=31,r=8,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc",
+			wantErr: true,
+		},
+		{
+			name:    "scrypt memory exceeds maximum",
+			hashed:  "$scrypt$ln=20,r=32,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc",
+			wantErr: true,
+		},
+		{
+			name:    "scrypt parallelism exceeds maximum",
+			hashed:  "$scrypt$ln=4,r=8,p=10000$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc",
+			wantErr: true,
+		},
+		{
+			name:    "pbkdf2 rounds exceed maximum",
+			hashed:  "$pbkdf2-sha512$i=2000000000$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc",
+			wantErr: true,
+		},
+		{
+			name:    "invalid salted sha",
+			hashed:  "{SSHA256}c29tZXNhbHQ=",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			algorithm, err := ValidateHash([]byte(tt.hashed))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAlgorithm, algorithm)
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package crypto
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"fmt"
+	"hash"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+var _ HashAlgorithm = (*PBKDF2)(nil)
+
+type PBKDF2Hash string
+
+const (
+	PBKDF2SHA256 PBKDF2Hash = "sha256"
+	PBKDF2SHA512 PBKDF2Hash = "sha512"
+
+	pbkdf2IDPrefix = "pbkdf2-"
+	pbkdf2Encoding = "$" + pbkdf2IDPrefix + "%s$i=%d$%s$%s"
+
+	pbkdf2MaxRounds = 5_000_000
+)
+
+func (h PBKDF2Hash) new() (func() hash.Hash, int, bool) {
+	switch h {
+	case PBKDF2SHA256:
+		return sha256.New, sha256.Size, true
+	case PBKDF2SHA512:
+		return sha512.New, sha512.Size, true
+	default:
+		return nil, 0, false
+	}
+}
+
+// PBKDF2 hashes values using PBKDF2 with HMAC-SHA256 or HMAC-SHA512 in the PHC string format:
+// $pbkdf2-<hash>$i=<rounds>$<salt>$<hash>
+// Hashes in the modular crypt format of passlib ($pbkdf2-<hash>$<rounds>$<salt>$<hash>) can be verified.
+type PBKDF2 struct {
+	rounds int
+	hash   PBKDF2Hash
+}
+
+func NewPBKDF2(rounds int, hash PBKDF2Hash) *PBKDF2 {
+	return &PBKDF2{
+		rounds: rounds,
+		hash:   hash,
+	}
+}
+
+func (p *PBKDF2) Algorithm() string {
+	return "pbkdf2"
+}
+
+func (p *PBKDF2) Hash(value []byte) ([]byte, error) {
+	hashFunc, size, ok := p.hash.new()
+	if !ok {
+		return nil, errors.ThrowInternalf(nil, "CRYPT-Fai3i", "unsupported pbkdf2 hash %s", p.hash)
+	}
+	salt, err := newSalt()
+	if err != nil {
+		return nil, err
+	}
+	hash := pbkdf2.Key(value, salt, p.rounds, size, hashFunc)
+	return []byte(fmt.Sprintf(pbkdf2Encoding, p.hash, p.rounds, encodePHCBase64(salt), encodePHCBase64(hash))), nil
+}
+
+func (p *PBKDF2) CompareHash(hashed, value []byte) error {
+	parsed, params, err := parsePBKDF2(hashed)
+	if err != nil {
+		return err
+	}
+	hashFunc, _, _ := params.hash.new()
+	hash := pbkdf2.Key(value, parsed.salt, params.rounds, len(parsed.hash), hashFunc)
+	if subtle.ConstantTimeCompare(hash, parsed.hash) != 1 {
+		return errors.ThrowInvalidArgument(nil, "CRYPT-Ohj3e", "hash and value do not match")
+	}
+	return nil
+}
+
+// NeedsRehash returns true if the value was hashed with other parameters
+func (p *PBKDF2) NeedsRehash(hashed []byte) bool {
+	_, params, err := parsePBKDF2(hashed)
+	if err != nil {
+		return true
+	}
+	return *params != *p
+}
+
+func parsePBKDF2(hashed []byte) (*phcHash, *PBKDF2, error) {
+	parsed, err := parsePHC(hashed)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !strings.HasPrefix(parsed.id, pbkdf2IDPrefix) {
+		return nil, nil, errors.ThrowInvalidArgument(nil, "CRYPT-Que9a", "hash is not a pbkdf2 hash")
+	}
+	params := &PBKDF2{hash: PBKDF2Hash(strings.TrimPrefix(parsed.id, pbkdf2IDPrefix))}
+	if _, _, ok := params.hash.new(); !ok {
+		return nil, nil, errors.ThrowInvalidArgumentf(nil, "CRYPT-ahL4i", "unsupported pbkdf2 hash %s", params.hash)
+	}
+	if params.rounds, err = parsed.boundedIntParam("i", pbkdf2MaxRounds); err != nil {
+		return nil, nil, err
+	}
+	return parsed, params, nil
+}
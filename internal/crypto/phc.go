@@ -0,0 +1,96 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const saltLength = 16
+
+// phcHash is the parsed representation of a hash in the PHC string format:
+// $<id>[$v=<version>][$<param>=<value>(,<param>=<value>)*]$<salt>$<hash>
+//
+// The modular crypt format of passlib (e.g. $pbkdf2-sha256$<rounds>$<salt>$<hash>)
+// is parsed as well, a parameter without a name is stored as rounds parameter ("i").
+type phcHash struct {
+	id     string
+	params map[string]string
+	salt   []byte
+	hash   []byte
+}
+
+func parsePHC(encoded []byte) (*phcHash, error) {
+	parts := strings.Split(string(encoded), "$")
+	if len(parts) < 4 || parts[0] != "" {
+		return nil, errors.ThrowInvalidArgument(nil, "CRYPT-ohT2u", "invalid hash encoding")
+	}
+	parsed := &phcHash{
+		id:     parts[1],
+		params: make(map[string]string),
+	}
+	for _, part := range parts[2 : len(parts)-2] {
+		for _, param := range strings.Split(part, ",") {
+			key, value, ok := strings.Cut(param, "=")
+			if !ok {
+				key, value = "i", param
+			}
+			parsed.params[key] = value
+		}
+	}
+	var err error
+	if parsed.salt, err = decodePHCBase64(parts[len(parts)-2]); err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "CRYPT-Ieb7o", "invalid salt encoding")
+	}
+	if parsed.hash, err = decodePHCBase64(parts[len(parts)-1]); err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "CRYPT-aeX4g", "invalid hash encoding")
+	}
+	if len(parsed.hash) == 0 {
+		return nil, errors.ThrowInvalidArgument(nil, "CRYPT-Ohs5u", "invalid hash encoding")
+	}
+	return parsed, nil
+}
+
+func (p *phcHash) intParam(key string) (int, error) {
+	value, err := strconv.Atoi(p.params[key])
+	if err != nil || value <= 0 {
+		return 0, errors.ThrowInvalidArgumentf(err, "CRYPT-Iey1o", "invalid hash parameter %s", key)
+	}
+	return value, nil
+}
+
+// boundedIntParam returns the positive integer parameter, which must not exceed max,
+// so that hashes imported from other systems cannot exhaust the resources on verification
+func (p *phcHash) boundedIntParam(key string, max int) (int, error) {
+	value, err := p.intParam(key)
+	if err != nil {
+		return 0, err
+	}
+	if value > max {
+		return 0, errors.ThrowInvalidArgumentf(nil, "CRYPT-ieR7a", "hash parameter %s exceeds the maximum of %d", key, max)
+	}
+	return value, nil
+}
+
+func encodePHCBase64(value []byte) string {
+	return base64.RawStdEncoding.EncodeToString(value)
+}
+
+// decodePHCBase64 decodes the unpadded standard base64 encoding of the PHC format
+// as well as the adapted base64 encoding of passlib, which uses "." instead of "+"
+func decodePHCBase64(value string) ([]byte, error) {
+	value = strings.TrimRight(value, "=")
+	value = strings.ReplaceAll(value, ".", "+")
+	return base64.RawStdEncoding.DecodeString(value)
+}
+
+func newSalt() ([]byte, error) {
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, errors.ThrowInternal(err, "CRYPT-Ohph3", "unable to generate salt")
+	}
+	return salt, nil
+}
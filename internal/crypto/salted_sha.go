@@ -0,0 +1,69 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"hash"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+var _ HashAlgorithm = (*SaltedSHA)(nil)
+
+var saltedSHAVariants = []struct {
+	prefix string
+	new    func() hash.Hash
+	size   int
+}{
+	{prefix: "{SSHA512}", new: sha512.New, size: sha512.Size},
+	{prefix: "{SSHA256}", new: sha256.New, size: sha256.Size},
+	{prefix: "{SSHA}", new: sha1.New, size: sha1.Size},
+}
+
+// SaltedSHA verifies salted SHA hashes in the LDAP format: {SSHA|SSHA256|SSHA512}base64(hash(value + salt) + salt)
+// Values cannot be hashed with it, as the algorithm is not suitable for passwords anymore.
+type SaltedSHA struct{}
+
+func NewSaltedSHA() *SaltedSHA {
+	return &SaltedSHA{}
+}
+
+func (s *SaltedSHA) Algorithm() string {
+	return HashNameSaltedSHA
+}
+
+func (s *SaltedSHA) Hash([]byte) ([]byte, error) {
+	return nil, errors.ThrowInternal(nil, "CRYPT-oi1Sh", "salted sha is only supported for verification")
+}
+
+func (s *SaltedSHA) CompareHash(hashed, value []byte) error {
+	hashFunc, digest, salt, err := parseSaltedSHA(hashed)
+	if err != nil {
+		return err
+	}
+	h := hashFunc()
+	h.Write(value)
+	h.Write(salt)
+	if subtle.ConstantTimeCompare(h.Sum(nil), digest) != 1 {
+		return errors.ThrowInvalidArgument(nil, "CRYPT-Iu5ae", "hash and value do not match")
+	}
+	return nil
+}
+
+func parseSaltedSHA(hashed []byte) (hashFunc func() hash.Hash, digest, salt []byte, err error) {
+	for _, variant := range saltedSHAVariants {
+		if !bytes.HasPrefix(hashed, []byte(variant.prefix)) {
+			continue
+		}
+		decoded, err := base64.StdEncoding.DecodeString(string(hashed[len(variant.prefix):]))
+		if err != nil || len(decoded) <= variant.size {
+			return nil, nil, nil, errors.ThrowInvalidArgument(err, "CRYPT-ahN3u", "invalid hash encoding")
+		}
+		return variant.new, decoded[:variant.size], decoded[variant.size:], nil
+	}
+	return nil, nil, nil, errors.ThrowInvalidArgument(nil, "CRYPT-Eiz6a", "hash is not a salted sha hash")
+}
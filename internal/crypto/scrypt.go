@@ -0,0 +1,107 @@
+package crypto
+
+import (
+	"crypto/subtle"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+var _ HashAlgorithm = (*Scrypt)(nil)
+
+const (
+	scryptID       = "scrypt"
+	scryptKeyLen   = 32
+	scryptEncoding = "$" + scryptID + "$ln=%d,r=%d,p=%d$%s$%s"
+
+	scryptMaxCost        = 20
+	scryptMaxBlockSize   = 32
+	scryptMaxParallelism = 16
+	// scryptMaxMemory is the maximum memory (128 * N * r bytes) of hashes to be verified
+	scryptMaxMemory = 1 << 30
+)
+
+// Scrypt hashes values using scrypt in the PHC string format of passlib:
+// $scrypt$ln=<log2(N)>,r=<block size>,p=<parallelism>$<salt>$<hash>
+type Scrypt struct {
+	cost        int
+	blockSize   int
+	parallelism int
+}
+
+// NewScrypt creates a scrypt hasher, cost is the base 2 logarithm of the CPU/memory cost parameter N
+func NewScrypt(cost, blockSize, parallelism int) *Scrypt {
+	return &Scrypt{
+		cost:        cost,
+		blockSize:   blockSize,
+		parallelism: parallelism,
+	}
+}
+
+func (s *Scrypt) Algorithm() string {
+	return scryptID
+}
+
+func (s *Scrypt) Hash(value []byte) ([]byte, error) {
+	salt, err := newSalt()
+	if err != nil {
+		return nil, err
+	}
+	hash, err := scrypt.Key(value, salt, 1<<s.cost, s.blockSize, s.parallelism, scryptKeyLen)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "CRYPT-ooG3a", "unable to hash value")
+	}
+	return []byte(fmt.Sprintf(scryptEncoding, s.cost, s.blockSize, s.parallelism, encodePHCBase64(salt), encodePHCBase64(hash))), nil
+}
+
+func (s *Scrypt) CompareHash(hashed, value []byte) error {
+	parsed, params, err := parseScrypt(hashed)
+	if err != nil {
+		return err
+	}
+	hash, err := scrypt.Key(value, parsed.salt, 1<<params.cost, params.blockSize, params.parallelism, len(parsed.hash))
+	if err != nil {
+		return errors.ThrowInvalidArgument(err, "CRYPT-ceiY7", "unable to hash value")
+	}
+	if subtle.ConstantTimeCompare(hash, parsed.hash) != 1 {
+		return errors.ThrowInvalidArgument(nil, "CRYPT-ahW6i", "hash and value do not match")
+	}
+	return nil
+}
+
+// NeedsRehash returns true if the value was hashed with other parameters
+func (s *Scrypt) NeedsRehash(hashed []byte) bool {
+	_, params, err := parseScrypt(hashed)
+	if err != nil {
+		return true
+	}
+	return *params != *s
+}
+
+func parseScrypt(hashed []byte) (*phcHash, *Scrypt, error) {
+	parsed, err := parsePHC(hashed)
+	if err != nil {
+		return nil, nil, err
+	}
+	if parsed.id != scryptID {
+		return nil, nil, errors.ThrowInvalidArgument(nil, "CRYPT-Ga6ie", "hash is not a scrypt hash")
+	}
+	cost, err := parsed.boundedIntParam("ln", scryptMaxCost)
+	if err != nil {
+		return nil, nil, err
+	}
+	blockSize, err := parsed.boundedIntParam("r", scryptMaxBlockSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	parallelism, err := parsed.boundedIntParam("p", scryptMaxParallelism)
+	if err != nil {
+		return nil, nil, err
+	}
+	if 128*blockSize<<cost > scryptMaxMemory {
+		return nil, nil, errors.ThrowInvalidArgument(nil, "CRYPT-xoo7E", "hash parameters exceed the maximum memory")
+	}
+	return parsed, NewScrypt(cost, blockSize, parallelism), nil
+}
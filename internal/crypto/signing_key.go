@@ -0,0 +1,191 @@
+package crypto
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+// supported algorithms (JWA names) of the signing keys
+// the curve of the ECDSA keys is defined by the algorithm
+const (
+	SigningAlgorithmRS256 = "RS256"
+	SigningAlgorithmRS384 = "RS384"
+	SigningAlgorithmRS512 = "RS512"
+	SigningAlgorithmES256 = "ES256"
+	SigningAlgorithmES384 = "ES384"
+	SigningAlgorithmES512 = "ES512"
+	SigningAlgorithmEdDSA = "EdDSA"
+)
+
+var ErrUnsupportedSigningAlgorithm = errors.New("unsupported signing algorithm")
+
+// SigningAlgorithms returns all supported algorithms,
+// which must therefore be accepted when verifying tokens signed by a (current or previous) signing key
+func SigningAlgorithms() []string {
+	return []string{
+		SigningAlgorithmRS256, SigningAlgorithmRS384, SigningAlgorithmRS512,
+		SigningAlgorithmES256, SigningAlgorithmES384, SigningAlgorithmES512,
+		SigningAlgorithmEdDSA,
+	}
+}
+
+// IsSigningAlgorithmSupported checks if keys for the algorithm can be generated
+func IsSigningAlgorithmSupported(algorithm string) bool {
+	for _, supported := range SigningAlgorithms() {
+		if algorithm == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRSASigningAlgorithm is true if the algorithm uses RSA keys, which is the only key type with a configurable size
+func IsRSASigningAlgorithm(algorithm string) bool {
+	switch algorithm {
+	case SigningAlgorithmRS256, SigningAlgorithmRS384, SigningAlgorithmRS512:
+		return true
+	default:
+		return false
+	}
+}
+
+// GenerateSigningKeyPair generates a key pair for the algorithm
+// rsaBits is only used for RSA keys
+func GenerateSigningKeyPair(algorithm string, rsaBits int) (crypto.Signer, crypto.PublicKey, error) {
+	switch algorithm {
+	case SigningAlgorithmRS256, SigningAlgorithmRS384, SigningAlgorithmRS512:
+		return GenerateKeyPair(rsaBits)
+	case SigningAlgorithmES256:
+		return generateECDSAKeyPair(elliptic.P256())
+	case SigningAlgorithmES384:
+		return generateECDSAKeyPair(elliptic.P384())
+	case SigningAlgorithmES512:
+		return generateECDSAKeyPair(elliptic.P521())
+	case SigningAlgorithmEdDSA:
+		publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, nil, err
+		}
+		return privateKey, publicKey, nil
+	default:
+		return nil, nil, fmt.Errorf("%w: %s", ErrUnsupportedSigningAlgorithm, algorithm)
+	}
+}
+
+func generateECDSAKeyPair(curve elliptic.Curve) (crypto.Signer, crypto.PublicKey, error) {
+	privateKey, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	return privateKey, &privateKey.PublicKey, nil
+}
+
+// GenerateEncryptedSigningKeyPair generates a key pair for the algorithm and returns the PEM encoded keys
+// the private key is encrypted with the provided EncryptionAlgorithm
+func GenerateEncryptedSigningKeyPair(algorithm string, rsaBits int, alg EncryptionAlgorithm) (*CryptoValue, *CryptoValue, error) {
+	privateKey, publicKey, err := GenerateSigningKeyPair(algorithm, rsaBits)
+	if err != nil {
+		return nil, nil, err
+	}
+	privateKeyBytes, err := SigningKeyToBytes(privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	encryptedPrivateKey, err := Encrypt(privateKeyBytes, alg)
+	if err != nil {
+		return nil, nil, err
+	}
+	publicKeyBytes, err := PublicSigningKeyToBytes(publicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	encryptedPublicKey, err := Encrypt(publicKeyBytes, alg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return encryptedPrivateKey, encryptedPublicKey, nil
+}
+
+// SigningKeyToBytes returns the PEM encoded private key
+// RSA keys are still encoded as PKCS #1 to stay compatible with the existing keys, all others as PKCS #8
+func SigningKeyToBytes(key crypto.Signer) ([]byte, error) {
+	if rsaKey, ok := key.(*rsa.PrivateKey); ok {
+		return PrivateKeyToBytes(rsaKey), nil
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "PRIVATE KEY",
+		Bytes: der,
+	}), nil
+}
+
+// PublicSigningKeyToBytes returns the PEM encoded (PKIX) public key
+func PublicSigningKeyToBytes(key crypto.PublicKey) ([]byte, error) {
+	if rsaKey, ok := key.(*rsa.PublicKey); ok {
+		return PublicKeyToBytes(rsaKey)
+	}
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "PUBLIC KEY",
+		Bytes: der,
+	}), nil
+}
+
+// BytesToSigningKey parses a PEM encoded RSA (PKCS #1 or #8), ECDSA (SEC 1 or PKCS #8) or Ed25519 (PKCS #8) private key
+func BytesToSigningKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrEmpty
+	}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return BytesToPrivateKey(data)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	switch key.(type) {
+	case *rsa.PrivateKey, *ecdsa.PrivateKey, ed25519.PrivateKey:
+		return key.(crypto.Signer), nil
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedSigningAlgorithm, key)
+	}
+}
+
+// BytesToPublicSigningKey parses a PEM encoded (PKIX) RSA, ECDSA or Ed25519 public key
+func BytesToPublicSigningKey(data []byte) (crypto.PublicKey, error) {
+	if data == nil {
+		return nil, ErrEmpty
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrEmpty
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	switch key.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedSigningAlgorithm, key)
+	}
+}
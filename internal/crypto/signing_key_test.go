@@ -0,0 +1,101 @@
+package crypto
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateSigningKeyPair(t *testing.T) {
+	tests := []struct {
+		algorithm string
+		wantKey   interface{}
+		wantCurve string
+	}{
+		{SigningAlgorithmRS256, &rsa.PrivateKey{}, ""},
+		{SigningAlgorithmRS512, &rsa.PrivateKey{}, ""},
+		{SigningAlgorithmES256, &ecdsa.PrivateKey{}, "P-256"},
+		{SigningAlgorithmES384, &ecdsa.PrivateKey{}, "P-384"},
+		{SigningAlgorithmES512, &ecdsa.PrivateKey{}, "P-521"},
+		{SigningAlgorithmEdDSA, ed25519.PrivateKey{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			privateKey, publicKey, err := GenerateSigningKeyPair(tt.algorithm, 1024)
+			require.NoError(t, err)
+			assert.IsType(t, tt.wantKey, privateKey)
+			if ecKey, ok := privateKey.(*ecdsa.PrivateKey); ok {
+				assert.Equal(t, tt.wantCurve, ecKey.Curve.Params().Name)
+			}
+
+			privateKeyBytes, err := SigningKeyToBytes(privateKey)
+			require.NoError(t, err)
+			parsedPrivateKey, err := BytesToSigningKey(privateKeyBytes)
+			require.NoError(t, err)
+			publicKeyBytes, err := PublicSigningKeyToBytes(publicKey)
+			require.NoError(t, err)
+			parsedPublicKey, err := BytesToPublicSigningKey(publicKeyBytes)
+			require.NoError(t, err)
+			assert.True(t, parsedPublicKey.(interface{ Equal(crypto.PublicKey) bool }).Equal(publicKey))
+
+			digest := sha256.Sum256([]byte("payload"))
+			var opts crypto.SignerOpts = crypto.SHA256
+			if tt.algorithm == SigningAlgorithmEdDSA {
+				opts = crypto.Hash(0)
+			}
+			signature, err := parsedPrivateKey.Sign(rand.Reader, digest[:], opts)
+			require.NoError(t, err)
+			switch key := parsedPublicKey.(type) {
+			case *rsa.PublicKey:
+				assert.NoError(t, rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature))
+			case *ecdsa.PublicKey:
+				assert.True(t, ecdsa.VerifyASN1(key, digest[:], signature))
+			case ed25519.PublicKey:
+				assert.True(t, ed25519.Verify(key, digest[:], signature))
+			}
+		})
+	}
+}
+
+func TestGenerateSigningKeyPair_unsupported(t *testing.T) {
+	_, _, err := GenerateSigningKeyPair("HS256", 1024)
+	assert.True(t, errors.Is(err, ErrUnsupportedSigningAlgorithm))
+	assert.False(t, IsSigningAlgorithmSupported("HS256"))
+}
+
+func TestBytesToSigningKey_existingRSAKey(t *testing.T) {
+	privateKey, publicKey, err := GenerateKeyPair(1024)
+	require.NoError(t, err)
+	publicKeyBytes, err := PublicKeyToBytes(publicKey)
+	require.NoError(t, err)
+
+	parsedPrivateKey, err := BytesToSigningKey(PrivateKeyToBytes(privateKey))
+	require.NoError(t, err)
+	assert.True(t, privateKey.Equal(parsedPrivateKey))
+	parsedPublicKey, err := BytesToPublicSigningKey(publicKeyBytes)
+	require.NoError(t, err)
+	assert.True(t, publicKey.Equal(parsedPublicKey))
+}
+
+func TestGenerateEncryptedSigningKeyPair(t *testing.T) {
+	privateKey, publicKey, err := GenerateEncryptedSigningKeyPair(SigningAlgorithmES256, 0, &mockEncCrypto{})
+	require.NoError(t, err)
+
+	privateKeyBytes, err := Decrypt(privateKey, &mockEncCrypto{})
+	require.NoError(t, err)
+	signer, err := BytesToSigningKey(privateKeyBytes)
+	require.NoError(t, err)
+	publicKeyBytes, err := Decrypt(publicKey, &mockEncCrypto{})
+	require.NoError(t, err)
+	parsedPublicKey, err := BytesToPublicSigningKey(publicKeyBytes)
+	require.NoError(t, err)
+	assert.True(t, signer.Public().(*ecdsa.PublicKey).Equal(parsedPublicKey))
+}
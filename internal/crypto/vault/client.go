@@ -0,0 +1,128 @@
+// Package vault stores and protects the encryption keys with HashiCorp Vault.
+// The transit secrets engine wraps the keys stored in the database (envelope encryption),
+// the kv secrets engine (version 2) stores the keys in Vault itself.
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	tokenHeader     = "X-Vault-Token"
+	namespaceHeader = "X-Vault-Namespace"
+)
+
+type Config struct {
+	// Address of the Vault server, e.g. https://vault.example.com:8200
+	Address string
+	// Token used to authenticate against Vault
+	Token string
+	// Namespace the secrets engines are mounted in (Vault Enterprise only)
+	Namespace string
+	Timeout   time.Duration
+	Transit   TransitConfig
+	KV        KVConfig
+}
+
+type TransitConfig struct {
+	// Mount path of the transit secrets engine
+	Mount string
+	// Key is the name of the transit key used to wrap the encryption keys
+	Key string
+}
+
+type KVConfig struct {
+	// Mount path of the kv secrets engine (version 2)
+	Mount string
+	// Path under which the encryption keys are stored
+	Path string
+}
+
+type client struct {
+	address    string
+	token      string
+	namespace  string
+	httpClient *http.Client
+}
+
+func newClient(config *Config) (*client, error) {
+	if config.Address == "" || config.Token == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "VAULT-Kd82n", "vault address and token must be set")
+	}
+	return &client{
+		address:    strings.TrimSuffix(config.Address, "/"),
+		token:      config.Token,
+		namespace:  config.Namespace,
+		httpClient: &http.Client{Timeout: config.Timeout},
+	}, nil
+}
+
+// errorResponse is returned by Vault for all failed requests
+type errorResponse struct {
+	Errors []string `json:"errors"`
+}
+
+// do calls the Vault API on the path (without version prefix)
+// the response is unmarshalled into result if not nil
+func (c *client) do(method, path string, body, result interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return errors.ThrowInternal(err, "VAULT-Ps9d2", "unable to marshal vault request")
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, c.address+"/v1/"+path, reqBody)
+	if err != nil {
+		return errors.ThrowInternal(err, "VAULT-Lm2sd", "unable to create vault request")
+	}
+	req.Header.Set(tokenHeader, c.token)
+	if c.namespace != "" {
+		req.Header.Set(namespaceHeader, c.namespace)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.ThrowUnavailable(err, "VAULT-Wo2md", "unable to reach vault")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errors.ThrowNotFound(nil, "VAULT-Gj3ls", "not found in vault")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		vaultErr := new(errorResponse)
+		_ = json.NewDecoder(resp.Body).Decode(vaultErr)
+		return errors.ThrowInternalf(nil, "VAULT-Ak2md", "vault request failed with status %d: %s", resp.StatusCode, strings.Join(vaultErr.Errors, ", "))
+	}
+	if result == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err = json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return errors.ThrowInternal(err, "VAULT-Qp2ms", "unable to unmarshal vault response")
+	}
+	return nil
+}
+
+func joinPath(segments ...string) string {
+	escaped := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		for _, part := range strings.Split(strings.Trim(segment, "/"), "/") {
+			if part != "" {
+				escaped = append(escaped, url.PathEscape(part))
+			}
+		}
+	}
+	return strings.Join(escaped, "/")
+}
@@ -0,0 +1,107 @@
+package vault
+
+import (
+	"net/http"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+var _ crypto.KeyStorage = (*KVStorage)(nil)
+
+// KVStorage stores the encryption keys as secrets of the kv secrets engine (version 2)
+type KVStorage struct {
+	client *client
+	mount  string
+	path   string
+}
+
+func NewKVStorage(config *Config) (*KVStorage, error) {
+	if config.KV.Mount == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "VAULT-Ro2md", "kv mount must be set")
+	}
+	c, err := newClient(config)
+	if err != nil {
+		return nil, err
+	}
+	return &KVStorage{
+		client: c,
+		mount:  config.KV.Mount,
+		path:   config.KV.Path,
+	}, nil
+}
+
+const kvKeyField = "key"
+
+type kvSecret struct {
+	Data struct {
+		Data map[string]string `json:"data"`
+	} `json:"data"`
+}
+
+type kvList struct {
+	Data struct {
+		Keys []string `json:"keys"`
+	} `json:"data"`
+}
+
+func (s *KVStorage) ReadKeys() (crypto.Keys, error) {
+	list := new(kvList)
+	err := s.client.do(http.MethodGet, joinPath(s.mount, "metadata", s.path)+"?list=true", nil, list)
+	if errors.IsNotFound(err) {
+		return crypto.Keys{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	keys := make(crypto.Keys, len(list.Data.Keys))
+	for _, id := range list.Data.Keys {
+		key, err := s.ReadKey(id)
+		if err != nil {
+			return nil, err
+		}
+		keys[id] = key.Value
+	}
+	return keys, nil
+}
+
+func (s *KVStorage) ReadKey(id string) (*crypto.Key, error) {
+	secret := new(kvSecret)
+	if err := s.client.do(http.MethodGet, joinPath(s.mount, "data", s.path, id), nil, secret); err != nil {
+		return nil, err
+	}
+	key, ok := secret.Data.Data[kvKeyField]
+	if !ok {
+		return nil, errors.ThrowNotFound(nil, "VAULT-Hd9sk", "key not found")
+	}
+	return &crypto.Key{
+		ID:    id,
+		Value: key,
+	}, nil
+}
+
+// CreateKeys stores each key as separate secret
+// existing keys are never overwritten (check-and-set)
+func (s *KVStorage) CreateKeys(keys ...*crypto.Key) error {
+	for _, key := range keys {
+		err := s.client.do(http.MethodPost, joinPath(s.mount, "data", s.path, key.ID), map[string]interface{}{
+			"options": map[string]int{"cas": 0},
+			"data":    map[string]string{kvKeyField: key.Value},
+		}, nil)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteKeys deletes all versions of the keys
+func (s *KVStorage) DeleteKeys(ids ...string) error {
+	for _, id := range ids {
+		err := s.client.do(http.MethodDelete, joinPath(s.mount, "metadata", s.path, id), nil, nil)
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package vault
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/crypto/vault/vaulttest"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+func TestKVStorage(t *testing.T) {
+	server := vaulttest.NewServer()
+	defer server.Close()
+	storage, err := NewKVStorage(testConfig(server))
+	require.NoError(t, err)
+
+	keys, err := storage.ReadKeys()
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+
+	_, err = storage.ReadKey("key1")
+	assert.True(t, errors.IsNotFound(err), "unexpected error: %v", err)
+
+	require.NoError(t, storage.CreateKeys(
+		&crypto.Key{ID: "key1", Value: "value1"},
+		&crypto.Key{ID: "personal_data:instance:user", Value: "value2"},
+	))
+	data, ok := server.Secret("zitadel/keys/key1")
+	require.True(t, ok)
+	assert.Equal(t, "value1", data["key"])
+
+	key, err := storage.ReadKey("key1")
+	require.NoError(t, err)
+	assert.Equal(t, &crypto.Key{ID: "key1", Value: "value1"}, key)
+
+	keys, err = storage.ReadKeys()
+	require.NoError(t, err)
+	assert.Equal(t, crypto.Keys{"key1": "value1", "personal_data:instance:user": "value2"}, keys)
+
+	err = storage.CreateKeys(&crypto.Key{ID: "key1", Value: "overwritten"})
+	assert.Error(t, err, "existing keys must not be overwritten")
+
+	require.NoError(t, storage.DeleteKeys("personal_data:instance:user", "unknown"))
+	_, err = storage.ReadKey("personal_data:instance:user")
+	assert.True(t, errors.IsNotFound(err), "unexpected error: %v", err)
+}
@@ -0,0 +1,73 @@
+package vault
+
+import (
+	"encoding/base64"
+	"net/http"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+var _ crypto.RotatableKeyWrapper = (*Transit)(nil)
+
+// Transit wraps the encryption keys with a key of the transit secrets engine,
+// so the keys stored in the database can only be read with access to Vault
+type Transit struct {
+	client *client
+	mount  string
+	key    string
+}
+
+func NewTransit(config *Config) (*Transit, error) {
+	if config.Transit.Mount == "" || config.Transit.Key == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "VAULT-Ms82l", "transit mount and key must be set")
+	}
+	c, err := newClient(config)
+	if err != nil {
+		return nil, err
+	}
+	return &Transit{
+		client: c,
+		mount:  config.Transit.Mount,
+		key:    config.Transit.Key,
+	}, nil
+}
+
+type transitResponse struct {
+	Data struct {
+		Ciphertext string `json:"ciphertext"`
+		Plaintext  string `json:"plaintext"`
+	} `json:"data"`
+}
+
+func (t *Transit) WrapKey(key string) (string, error) {
+	resp := new(transitResponse)
+	err := t.client.do(http.MethodPost, joinPath(t.mount, "encrypt", t.key), map[string]string{
+		"plaintext": base64.StdEncoding.EncodeToString([]byte(key)),
+	}, resp)
+	if err != nil {
+		return "", err
+	}
+	return resp.Data.Ciphertext, nil
+}
+
+func (t *Transit) UnwrapKey(wrappedKey string) (string, error) {
+	resp := new(transitResponse)
+	err := t.client.do(http.MethodPost, joinPath(t.mount, "decrypt", t.key), map[string]string{
+		"ciphertext": wrappedKey,
+	}, resp)
+	if err != nil {
+		return "", err
+	}
+	key, err := base64.StdEncoding.DecodeString(resp.Data.Plaintext)
+	if err != nil {
+		return "", errors.ThrowInternal(err, "VAULT-Xm2ks", "unable to decode unwrapped key")
+	}
+	return string(key), nil
+}
+
+// RotateKey creates a new version of the transit key, which is used for all further wrapping
+// keys wrapped by previous versions can still be unwrapped
+func (t *Transit) RotateKey() error {
+	return t.client.do(http.MethodPost, joinPath(t.mount, "keys", t.key, "rotate"), nil, nil)
+}
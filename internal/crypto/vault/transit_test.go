@@ -0,0 +1,108 @@
+package vault
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/crypto/vault/vaulttest"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+func testConfig(server *vaulttest.Server) *Config {
+	return &Config{
+		Address: server.URL,
+		Token:   vaulttest.Token,
+		Transit: TransitConfig{
+			Mount: "transit",
+			Key:   "zitadel",
+		},
+		KV: KVConfig{
+			Mount: "secret",
+			Path:  "zitadel/keys",
+		},
+	}
+}
+
+func TestNewTransit(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *Config
+		wantErr func(error) bool
+	}{
+		{
+			name:    "address missing",
+			config:  &Config{Token: "token", Transit: TransitConfig{Mount: "transit", Key: "zitadel"}},
+			wantErr: errors.IsErrorInvalidArgument,
+		},
+		{
+			name:    "key missing",
+			config:  &Config{Address: "http://localhost:8200", Token: "token", Transit: TransitConfig{Mount: "transit"}},
+			wantErr: errors.IsErrorInvalidArgument,
+		},
+		{
+			name:   "ok",
+			config: &Config{Address: "http://localhost:8200", Token: "token", Transit: TransitConfig{Mount: "transit", Key: "zitadel"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTransit(tt.config)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+		})
+	}
+}
+
+func TestTransit_WrapKey(t *testing.T) {
+	server := vaulttest.NewServer()
+	defer server.Close()
+	transit, err := NewTransit(testConfig(server))
+	require.NoError(t, err)
+
+	wrapped, err := transit.WrapKey("encryptionkey")
+	require.NoError(t, err)
+	assert.NotContains(t, wrapped, "encryptionkey")
+
+	key, err := transit.UnwrapKey(wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, "encryptionkey", key)
+}
+
+func TestTransit_RotateKey(t *testing.T) {
+	server := vaulttest.NewServer()
+	defer server.Close()
+	transit, err := NewTransit(testConfig(server))
+	require.NoError(t, err)
+
+	wrappedV1, err := transit.WrapKey("encryptionkey")
+	require.NoError(t, err)
+	require.NoError(t, transit.RotateKey())
+	assert.Equal(t, 2, server.TransitKeyVersions("zitadel"))
+
+	wrappedV2, err := transit.WrapKey("encryptionkey")
+	require.NoError(t, err)
+	assert.NotEqual(t, wrappedV1, wrappedV2)
+
+	for _, wrapped := range []string{wrappedV1, wrappedV2} {
+		key, err := transit.UnwrapKey(wrapped)
+		require.NoError(t, err)
+		assert.Equal(t, "encryptionkey", key)
+	}
+}
+
+func TestTransit_invalidToken(t *testing.T) {
+	server := vaulttest.NewServer()
+	defer server.Close()
+	config := testConfig(server)
+	config.Token = "invalid"
+	transit, err := NewTransit(config)
+	require.NoError(t, err)
+
+	_, err = transit.WrapKey("encryptionkey")
+	assert.True(t, errors.IsInternal(err), "unexpected error: %v", err)
+}
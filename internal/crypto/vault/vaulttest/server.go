@@ -0,0 +1,236 @@
+// Package vaulttest provides an in-memory stand-in for the HashiCorp Vault API used in tests.
+package vaulttest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+)
+
+const Token = "test-token"
+
+// Server implements the endpoints of the transit and kv (version 2) secrets engines used by ZITADEL
+// the mount path is ignored, each engine is identified by its operation (e.g. encrypt or data)
+type Server struct {
+	*httptest.Server
+
+	mutex sync.Mutex
+	// transitKeys contains all versions of the transit keys by name
+	transitKeys map[string][]string
+	// secrets contains the data of the kv secrets by path
+	secrets map[string]map[string]string
+}
+
+// NewServer starts the stand-in server, which must be closed by the caller
+func NewServer() *Server {
+	s := &Server{
+		transitKeys: make(map[string][]string),
+		secrets:     make(map[string]map[string]string),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// TransitKeyVersions returns the number of versions of the transit key
+func (s *Server) TransitKeyVersions(name string) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return len(s.transitKeys[name])
+}
+
+// Secret returns the data of the kv secret
+func (s *Server) Secret(path string) (map[string]string, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	data, ok := s.secrets[path]
+	return data, ok
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-Vault-Token") != Token {
+		writeErr(w, http.StatusForbidden, "permission denied")
+		return
+	}
+	segments := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1/"), "/")
+	if len(segments) < 3 {
+		writeErr(w, http.StatusNotFound, "unsupported path")
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	operation, path := segments[1], strings.Join(segments[2:], "/")
+	switch {
+	case operation == "encrypt" && r.Method == http.MethodPost:
+		s.encrypt(w, r, path)
+	case operation == "decrypt" && r.Method == http.MethodPost:
+		s.decrypt(w, r, path)
+	case operation == "keys" && r.Method == http.MethodPost && strings.HasSuffix(path, "/rotate"):
+		s.rotate(w, strings.TrimSuffix(path, "/rotate"))
+	case operation == "data" && r.Method == http.MethodGet:
+		s.readSecret(w, path)
+	case operation == "data" && r.Method == http.MethodPost:
+		s.writeSecret(w, r, path)
+	case operation == "metadata" && r.Method == http.MethodGet && r.URL.Query().Get("list") == "true":
+		s.listSecrets(w, path)
+	case operation == "metadata" && r.Method == http.MethodDelete:
+		s.deleteSecret(w, path)
+	default:
+		writeErr(w, http.StatusNotFound, "unsupported path")
+	}
+}
+
+func (s *Server) encrypt(w http.ResponseWriter, r *http.Request, name string) {
+	req := struct {
+		Plaintext string `json:"plaintext"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErr(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	plaintext, err := base64.StdEncoding.DecodeString(req.Plaintext)
+	if err != nil {
+		writeErr(w, http.StatusBadRequest, "plaintext must be base64 encoded")
+		return
+	}
+	// like Vault, the key is created on first use
+	if len(s.transitKeys[name]) == 0 {
+		if err = s.addTransitKeyVersion(name); err != nil {
+			writeErr(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+	versions := s.transitKeys[name]
+	ciphertext, err := crypto.EncryptAES(plaintext, versions[len(versions)-1])
+	if err != nil {
+		writeErr(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeData(w, map[string]string{
+		"ciphertext": fmt.Sprintf("vault:v%d:%s", len(versions), base64.StdEncoding.EncodeToString(ciphertext)),
+	})
+}
+
+func (s *Server) decrypt(w http.ResponseWriter, r *http.Request, name string) {
+	req := struct {
+		Ciphertext string `json:"ciphertext"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErr(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	parts := strings.SplitN(req.Ciphertext, ":", 3)
+	if len(parts) != 3 || parts[0] != "vault" {
+		writeErr(w, http.StatusBadRequest, "invalid ciphertext")
+		return
+	}
+	version, err := strconv.Atoi(strings.TrimPrefix(parts[1], "v"))
+	if err != nil || version < 1 || version > len(s.transitKeys[name]) {
+		writeErr(w, http.StatusBadRequest, "invalid key version")
+		return
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		writeErr(w, http.StatusBadRequest, "invalid ciphertext")
+		return
+	}
+	plaintext, err := crypto.DecryptAES(ciphertext, s.transitKeys[name][version-1])
+	if err != nil {
+		writeErr(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeData(w, map[string]string{
+		"plaintext": base64.StdEncoding.EncodeToString(plaintext),
+	})
+}
+
+func (s *Server) rotate(w http.ResponseWriter, name string) {
+	if len(s.transitKeys[name]) == 0 {
+		writeErr(w, http.StatusNotFound, "key not found")
+		return
+	}
+	if err := s.addTransitKeyVersion(name); err != nil {
+		writeErr(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) addTransitKeyVersion(name string) error {
+	key, err := crypto.NewKey(name)
+	if err != nil {
+		return err
+	}
+	s.transitKeys[name] = append(s.transitKeys[name], key.Value)
+	return nil
+}
+
+func (s *Server) readSecret(w http.ResponseWriter, path string) {
+	data, ok := s.secrets[path]
+	if !ok {
+		writeErr(w, http.StatusNotFound, "")
+		return
+	}
+	writeData(w, map[string]interface{}{"data": data})
+}
+
+func (s *Server) writeSecret(w http.ResponseWriter, r *http.Request, path string) {
+	req := struct {
+		Options struct {
+			CAS *int `json:"cas"`
+		} `json:"options"`
+		Data map[string]string `json:"data"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErr(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if _, exists := s.secrets[path]; exists && req.Options.CAS != nil && *req.Options.CAS == 0 {
+		writeErr(w, http.StatusBadRequest, "check-and-set parameter did not match the current version")
+		return
+	}
+	s.secrets[path] = req.Data
+	writeData(w, map[string]interface{}{"version": 1})
+}
+
+func (s *Server) listSecrets(w http.ResponseWriter, path string) {
+	prefix := strings.TrimSuffix(path, "/") + "/"
+	keys := make([]string, 0)
+	for secretPath := range s.secrets {
+		if strings.HasPrefix(secretPath, prefix) {
+			keys = append(keys, strings.TrimPrefix(secretPath, prefix))
+		}
+	}
+	if len(keys) == 0 {
+		writeErr(w, http.StatusNotFound, "")
+		return
+	}
+	writeData(w, map[string]interface{}{"keys": keys})
+}
+
+func (s *Server) deleteSecret(w http.ResponseWriter, path string) {
+	delete(s.secrets, path)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeData(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+}
+
+func writeErr(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	errs := []string{}
+	if message != "" {
+		errs = append(errs, message)
+	}
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"errors": errs})
+}
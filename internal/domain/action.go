@@ -38,6 +38,33 @@ func (s ActionState) Exists() bool {
 	return s != ActionStateUnspecified && s != ActionStateRemoved
 }
 
+type EventActionExecutionState int32
+
+const (
+	EventActionExecutionStateUnspecified EventActionExecutionState = iota
+	EventActionExecutionStateSucceeded
+	// EventActionExecutionStateFailed is final: the execution failed on all attempts
+	EventActionExecutionStateFailed
+	// EventActionExecutionStatePending is set until the execution succeeded or all attempts failed
+	EventActionExecutionStatePending
+	eventActionExecutionStateCount
+)
+
+func (s EventActionExecutionState) Valid() bool {
+	return s >= 0 && s < eventActionExecutionStateCount
+}
+
+// ActionKeyValueUsage is the usage of the key-value storage of the actions of an organisation
+// and the current value of a key, the value is nil if the key is not set
+type ActionKeyValueUsage struct {
+	Entries    int
+	TotalBytes int
+
+	Value      []byte
+	Sequence   uint64
+	ChangeDate time.Time
+}
+
 type ActionsAllowed int32
 
 const (
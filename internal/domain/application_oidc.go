@@ -89,6 +89,7 @@ const (
 	OIDCGrantTypeAuthorizationCode OIDCGrantType = iota
 	OIDCGrantTypeImplicit
 	OIDCGrantTypeRefreshToken
+	OIDCGrantTypeDeviceCode
 )
 
 type OIDCApplicationType int32
@@ -125,7 +126,7 @@ func (a *OIDCApp) IsValid() bool {
 		return false
 	}
 	grantTypes := a.getRequiredGrantTypes()
-	if len(grantTypes) == 0 {
+	if len(grantTypes) == 0 && !containsOIDCGrantType(a.GrantTypes, OIDCGrantTypeDeviceCode) {
 		return false
 	}
 	for _, grantType := range grantTypes {
@@ -220,14 +221,24 @@ func GetOIDCV1Compliance(appType OIDCApplicationType, grantTypes []OIDCGrantType
 }
 
 func checkGrantTypesCombination(compliance *Compliance, grantTypes []OIDCGrantType) {
-	if containsOIDCGrantType(grantTypes, OIDCGrantTypeRefreshToken) && !containsOIDCGrantType(grantTypes, OIDCGrantTypeAuthorizationCode) {
+	if containsOIDCGrantType(grantTypes, OIDCGrantTypeRefreshToken) &&
+		!containsOIDCGrantType(grantTypes, OIDCGrantTypeAuthorizationCode) &&
+		!containsOIDCGrantType(grantTypes, OIDCGrantTypeDeviceCode) {
 		compliance.NoneCompliant = true
 		compliance.Problems = append(compliance.Problems, "Application.OIDC.V1.GrantType.Refresh.NoAuthCode")
 	}
 }
 
+// onlyDeviceCodeGrantType returns true if the user is never redirected back to the application,
+// which is the case for the device authorization grant
+func onlyDeviceCodeGrantType(grantTypes []OIDCGrantType) bool {
+	return containsOIDCGrantType(grantTypes, OIDCGrantTypeDeviceCode) &&
+		!containsOIDCGrantType(grantTypes, OIDCGrantTypeAuthorizationCode) &&
+		!containsOIDCGrantType(grantTypes, OIDCGrantTypeImplicit)
+}
+
 func checkRedirectURIs(compliance *Compliance, grantTypes []OIDCGrantType, appType OIDCApplicationType, redirectUris []string) {
-	if len(redirectUris) == 0 {
+	if len(redirectUris) == 0 && !onlyDeviceCodeGrantType(grantTypes) {
 		compliance.NoneCompliant = true
 		compliance.Problems = append([]string{"Application.OIDC.V1.NoRedirectUris"}, compliance.Problems...)
 	}
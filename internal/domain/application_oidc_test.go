@@ -59,6 +59,18 @@ func TestApplicationValid(t *testing.T) {
 			},
 			result: true,
 		},
+		{
+			name: "valid oidc application: device code",
+			args: args{
+				app: &OIDCApp{
+					ObjectRoot: models.ObjectRoot{AggregateID: "AggregateID"},
+					AppID:      "AppID",
+					AppName:    "Name",
+					GrantTypes: []OIDCGrantType{OIDCGrantTypeDeviceCode},
+				},
+			},
+			result: true,
+		},
 		{
 			name: "invalid oidc application: responsetype code",
 			args: args{
@@ -241,6 +253,11 @@ func Test_checkGrantTypesCombination(t *testing.T) {
 			want:       &Compliance{},
 			grantTypes: []OIDCGrantType{OIDCGrantTypeAuthorizationCode, OIDCGrantTypeRefreshToken},
 		},
+		{
+			name:       "refresh token and device code",
+			want:       &Compliance{},
+			grantTypes: []OIDCGrantType{OIDCGrantTypeDeviceCode, OIDCGrantTypeRefreshToken},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -279,6 +296,14 @@ func Test_checkRedirectURIs(t *testing.T) {
 			},
 			args: args{},
 		},
+		{
+			name: "no redirect uris with device code",
+			want: &Compliance{},
+			args: args{
+				grantTypes: []OIDCGrantType{OIDCGrantTypeDeviceCode, OIDCGrantTypeRefreshToken},
+				appType:    OIDCApplicationTypeNative,
+			},
+		},
 		{
 			name: "implicit and authorization code",
 			want: &Compliance{
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+var customRoleKeyRegex = regexp.MustCompile(`^(IAM|ORG)_[A-Z0-9_]+$`)
+
+// CustomRole is a role defined by the instance administrators,
+// which can be granted to instance (IAM_ prefix) or organisation (ORG_ prefix) members
+// in addition to the roles of the runtime configuration
+type CustomRole struct {
+	models.ObjectRoot
+
+	Key         string
+	DisplayName string
+	Permissions []string
+}
+
+type CustomRoleState int32
+
+const (
+	CustomRoleStateUnspecified CustomRoleState = iota
+	CustomRoleStateActive
+	CustomRoleStateRemoved
+)
+
+func (s CustomRoleState) Exists() bool {
+	return s == CustomRoleStateActive
+}
+
+// IsValid checks the key of the role and that all permissions are granted
+// by at least one of the zitadelRoles with the same prefix (IAM or ORG),
+// so a custom role never exceeds the permissions of the level it's granted on
+func (r *CustomRole) IsValid(zitadelRoles []authz.RoleMapping) error {
+	if !customRoleKeyRegex.MatchString(r.Key) || r.Key == RoleSelfManagementGlobal {
+		return caos_errs.ThrowInvalidArgument(nil, "DOMAIN-Mw9sk", "Errors.IAM.CustomRole.KeyInvalid")
+	}
+	for _, zitadelRole := range zitadelRoles {
+		if zitadelRole.Role == r.Key {
+			return caos_errs.ThrowInvalidArgument(nil, "DOMAIN-Ls8wm", "Errors.IAM.CustomRole.KeyReserved")
+		}
+	}
+	if len(r.Permissions) == 0 {
+		return caos_errs.ThrowInvalidArgument(nil, "DOMAIN-Ps92n", "Errors.IAM.CustomRole.PermissionsMissing")
+	}
+	knownPermissions := KnownPermissions(r.RolePrefix(), zitadelRoles)
+	for _, permission := range r.Permissions {
+		if !authz.ExistsPerm(knownPermissions, permission) {
+			return caos_errs.ThrowInvalidArgument(nil, "DOMAIN-Qm3ks", "Errors.IAM.CustomRole.PermissionInvalid")
+		}
+	}
+	return nil
+}
+
+// RolePrefix returns the member type prefix of the role (IAM or ORG)
+func (r *CustomRole) RolePrefix() string {
+	if strings.HasPrefix(r.Key, IAMRolePrefix) {
+		return IAMRolePrefix
+	}
+	return OrgRolePrefix
+}
+
+// KnownPermissions returns all distinct permissions of the zitadelRoles with the rolePrefix
+func KnownPermissions(rolePrefix string, zitadelRoles []authz.RoleMapping) []string {
+	permissions := make([]string, 0)
+	for _, role := range zitadelRoles {
+		if !strings.HasPrefix(role.Role, rolePrefix) {
+			continue
+		}
+		for _, permission := range role.Permissions {
+			if !authz.ExistsPerm(permissions, permission) {
+				permissions = append(permissions, permission)
+			}
+		}
+	}
+	return permissions
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+func TestCustomRole_IsValid(t *testing.T) {
+	zitadelRoles := []authz.RoleMapping{
+		{Role: "IAM_OWNER", Permissions: []string{"iam.read", "iam.write", "org.read"}},
+		{Role: "ORG_OWNER", Permissions: []string{"org.read", "user.read", "user.write", "user.delete"}},
+	}
+	tests := []struct {
+		name  string
+		role  CustomRole
+		valid bool
+	}{
+		{
+			"org role, ok",
+			CustomRole{Key: "ORG_HELPDESK", Permissions: []string{"user.read", "user.write"}},
+			true,
+		},
+		{
+			"iam role, ok",
+			CustomRole{Key: "IAM_AUDITOR", Permissions: []string{"iam.read", "org.read"}},
+			true,
+		},
+		{
+			"key without member prefix, invalid",
+			CustomRole{Key: "HELPDESK", Permissions: []string{"user.read"}},
+			false,
+		},
+		{
+			"lowercase key, invalid",
+			CustomRole{Key: "ORG_helpdesk", Permissions: []string{"user.read"}},
+			false,
+		},
+		{
+			"key of zitadel role, invalid",
+			CustomRole{Key: "ORG_OWNER", Permissions: []string{"user.read"}},
+			false,
+		},
+		{
+			"no permissions, invalid",
+			CustomRole{Key: "ORG_HELPDESK"},
+			false,
+		},
+		{
+			"unknown permission, invalid",
+			CustomRole{Key: "ORG_HELPDESK", Permissions: []string{"user.reset"}},
+			false,
+		},
+		{
+			"permission of other member type, invalid",
+			CustomRole{Key: "ORG_HELPDESK", Permissions: []string{"iam.write"}},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.role.IsValid(zitadelRoles)
+			if tt.valid {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, caos_errs.IsErrorInvalidArgument(err))
+		})
+	}
+}
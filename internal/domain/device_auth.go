@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"time"
+)
+
+// DeviceAuth represents a pending or finished device authorization (RFC 8628)
+type DeviceAuth struct {
+	ClientID   string
+	DeviceCode string
+	UserCode   string
+	Expires    time.Time
+	Scopes     []string
+	State      DeviceAuthState
+	Subject    string
+}
+
+type DeviceAuthState int32
+
+const (
+	DeviceAuthStateUndefined DeviceAuthState = iota
+	DeviceAuthStateInitiated
+	DeviceAuthStateApproved
+	DeviceAuthStateDenied
+	DeviceAuthStateExpired
+	DeviceAuthStateRemoved
+
+	deviceAuthStateCount
+)
+
+func (s DeviceAuthState) Valid() bool {
+	return s >= 0 && s < deviceAuthStateCount
+}
+
+func (s DeviceAuthState) Exists() bool {
+	return s != DeviceAuthStateUndefined && s != DeviceAuthStateRemoved
+}
+
+// DeviceAuthCanceled is the reason a device authorization was canceled
+type DeviceAuthCanceled string
+
+const (
+	DeviceAuthCanceledDenied  DeviceAuthCanceled = "denied"
+	DeviceAuthCanceledExpired DeviceAuthCanceled = "expired"
+)
+
+func (c DeviceAuthCanceled) State() DeviceAuthState {
+	switch c {
+	case DeviceAuthCanceledDenied:
+		return DeviceAuthStateDenied
+	case DeviceAuthCanceledExpired:
+		return DeviceAuthStateExpired
+	}
+	return DeviceAuthStateUndefined
+}
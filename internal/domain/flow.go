@@ -21,6 +21,9 @@ const (
 	FlowTypeExternalAuthentication
 	FlowTypeCustomiseToken
 	FlowTypeInternalAuthentication
+	FlowTypeUserManagement
+	FlowTypePreAuthentication
+	FlowTypeCustomiseSAMLResponse
 	flowTypeCount
 )
 
@@ -56,6 +59,23 @@ func (s FlowType) TriggerTypes() []TriggerType {
 			TriggerTypePreCreation,
 			TriggerTypePostCreation,
 		}
+	case FlowTypeUserManagement:
+		return []TriggerType{
+			TriggerTypePreCreation,
+			TriggerTypePostCreation,
+			TriggerTypePreUpdate,
+			TriggerTypePostUpdate,
+			TriggerTypePreDeactivation,
+			TriggerTypePostDeactivation,
+		}
+	case FlowTypePreAuthentication:
+		return []TriggerType{
+			TriggerTypePreAuthentication,
+		}
+	case FlowTypeCustomiseSAMLResponse:
+		return []TriggerType{
+			TriggerTypePreSAMLResponseCreation,
+		}
 	default:
 		return nil
 	}
@@ -80,6 +100,12 @@ func (s FlowType) LocalizationKey() string {
 		return "Action.Flow.Type.CustomiseToken"
 	case FlowTypeInternalAuthentication:
 		return "Action.Flow.Type.InternalAuthentication"
+	case FlowTypeUserManagement:
+		return "Action.Flow.Type.UserManagement"
+	case FlowTypePreAuthentication:
+		return "Action.Flow.Type.PreAuthentication"
+	case FlowTypeCustomiseSAMLResponse:
+		return "Action.Flow.Type.CustomiseSAMLResponse"
 	default:
 		return "Action.Flow.Type.Unspecified"
 	}
@@ -94,6 +120,12 @@ const (
 	TriggerTypePostCreation
 	TriggerTypePreUserinfoCreation
 	TriggerTypePreAccessTokenCreation
+	TriggerTypePreUpdate
+	TriggerTypePostUpdate
+	TriggerTypePreDeactivation
+	TriggerTypePostDeactivation
+	TriggerTypePreAuthentication
+	TriggerTypePreSAMLResponseCreation
 	triggerTypeCount
 )
 
@@ -124,6 +156,18 @@ func (s TriggerType) LocalizationKey() string {
 		return "Action.TriggerType.PreUserinfoCreation"
 	case TriggerTypePreAccessTokenCreation:
 		return "Action.TriggerType.PreAccessTokenCreation"
+	case TriggerTypePreUpdate:
+		return "Action.TriggerType.PreUpdate"
+	case TriggerTypePostUpdate:
+		return "Action.TriggerType.PostUpdate"
+	case TriggerTypePreDeactivation:
+		return "Action.TriggerType.PreDeactivation"
+	case TriggerTypePostDeactivation:
+		return "Action.TriggerType.PostDeactivation"
+	case TriggerTypePreAuthentication:
+		return "Action.TriggerType.PreAuthentication"
+	case TriggerTypePreSAMLResponseCreation:
+		return "Action.TriggerType.PreSAMLResponseCreation"
 	default:
 		return "Action.TriggerType.Unspecified"
 	}
@@ -2,14 +2,16 @@ package domain
 
 import (
 	"github.com/zitadel/zitadel/internal/crypto"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
 	es_models "github.com/zitadel/zitadel/internal/eventstore/v1/models"
 )
 
 type HashedPassword struct {
 	es_models.ObjectRoot
 
-	SecretString string
-	SecretCrypto *crypto.CryptoValue
+	SecretString   string
+	SecretCrypto   *crypto.CryptoValue
+	ChangeRequired bool
 }
 
 func NewHashedPassword(password, algorithm string) *HashedPassword {
@@ -22,3 +24,21 @@ func NewHashedPassword(password, algorithm string) *HashedPassword {
 		},
 	}
 }
+
+// Validate checks if the encoded hash is of a supported algorithm.
+// The algorithm is always taken from the encoding of the hash.
+func (p *HashedPassword) Validate() error {
+	if p.SecretString == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "DOMAIN-Eix3o", "Errors.User.Password.Empty")
+	}
+	algorithm, err := crypto.ValidateHash([]byte(p.SecretString))
+	if err != nil {
+		return caos_errs.ThrowInvalidArgument(err, "DOMAIN-Ahb1e", "Errors.User.Password.HashInvalid")
+	}
+	p.SecretCrypto = &crypto.CryptoValue{
+		CryptoType: crypto.TypeHash,
+		Algorithm:  algorithm,
+		Crypted:    []byte(p.SecretString),
+	}
+	return nil
+}
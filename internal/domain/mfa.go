@@ -18,10 +18,23 @@ func (f MFAState) Valid() bool {
 }
 
 type MultifactorConfigs struct {
-	OTP OTPConfig
+	OTP           OTPConfig
+	RecoveryCodes RecoveryCodesConfig
 }
 
 type OTPConfig struct {
 	Issuer    string
 	CryptoMFA crypto.EncryptionAlgorithm
 }
+
+type RecoveryCodesConfig struct {
+	Count     int
+	Generator crypto.Generator
+}
+
+// RecoveryCodes are the plain recovery codes of the user,
+// they are only available on generation as they are stored hashed
+type RecoveryCodes struct {
+	*ObjectDetails
+	Codes []string
+}
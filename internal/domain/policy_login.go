@@ -30,6 +30,7 @@ type LoginPolicy struct {
 	MultiFactorCheckLifetime   time.Duration
 	DisableLoginWithEmail      bool
 	DisableLoginWithPhone      bool
+	RiskRules                  LoginPolicyRiskRules
 }
 
 func ValidateDefaultRedirectURI(rawURL string) bool {
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"net"
+)
+
+// LoginPolicyRiskRules require a second factor for logins which look risky,
+// whereas ForceMFA requires it for every login
+type LoginPolicyRiskRules struct {
+	// TrustedIPRanges in CIDR notation, logins from other IPs require a second factor
+	TrustedIPRanges []string
+	// MFAOnNewUserAgent requires a second factor if the user never logged in with the user agent
+	MFAOnNewUserAgent bool
+	// MFAOnCountryChange requires a second factor if the country of the IP
+	// differs from the one of the last login
+	MFAOnCountryChange bool
+}
+
+func (r LoginPolicyRiskRules) IsEmpty() bool {
+	return len(r.TrustedIPRanges) == 0 && !r.MFAOnNewUserAgent && !r.MFAOnCountryChange
+}
+
+func (r LoginPolicyRiskRules) IsValid() bool {
+	for _, ipRange := range r.TrustedIPRanges {
+		if _, _, err := net.ParseCIDR(ipRange); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (r LoginPolicyRiskRules) Equal(other LoginPolicyRiskRules) bool {
+	if r.MFAOnNewUserAgent != other.MFAOnNewUserAgent ||
+		r.MFAOnCountryChange != other.MFAOnCountryChange ||
+		len(r.TrustedIPRanges) != len(other.TrustedIPRanges) {
+		return false
+	}
+	for i, ipRange := range r.TrustedIPRanges {
+		if ipRange != other.TrustedIPRanges[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Evaluate returns the reasons why the login is risky,
+// an unknown IP or country is treated as untrusted.
+// The remoteIP must be the IP of the client, determined only from hops of trusted proxies (see http.ClientIP)
+func (r LoginPolicyRiskRules) Evaluate(remoteIP net.IP, knownUserAgent bool, country, lastCountry string) []LoginRiskReason {
+	reasons := make([]LoginRiskReason, 0)
+	if len(r.TrustedIPRanges) > 0 && !r.isTrustedIP(remoteIP) {
+		reasons = append(reasons, LoginRiskReasonUntrustedIP)
+	}
+	if r.MFAOnNewUserAgent && !knownUserAgent {
+		reasons = append(reasons, LoginRiskReasonNewUserAgent)
+	}
+	if r.MFAOnCountryChange && lastCountry != "" && country != lastCountry {
+		reasons = append(reasons, LoginRiskReasonCountryChanged)
+	}
+	return reasons
+}
+
+func (r LoginPolicyRiskRules) isTrustedIP(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	for _, ipRange := range r.TrustedIPRanges {
+		_, network, err := net.ParseCIDR(ipRange)
+		if err != nil {
+			continue
+		}
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+type LoginRiskReason int32
+
+const (
+	LoginRiskReasonUnspecified LoginRiskReason = iota
+	LoginRiskReasonUntrustedIP
+	LoginRiskReasonNewUserAgent
+	LoginRiskReasonCountryChanged
+)
+
+type LoginRiskDecision struct {
+	Reasons []LoginRiskReason
+}
+
+func (d *LoginRiskDecision) MFARequired() bool {
+	return d != nil && len(d.Reasons) > 0
+}
@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginPolicyRiskRules_IsValid(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules LoginPolicyRiskRules
+		want  bool
+	}{
+		{
+			"no ranges, true",
+			LoginPolicyRiskRules{},
+			true,
+		},
+		{
+			"valid ranges, true",
+			LoginPolicyRiskRules{TrustedIPRanges: []string{"10.0.0.0/8", "2001:db8::/32"}},
+			true,
+		},
+		{
+			"ip without mask, false",
+			LoginPolicyRiskRules{TrustedIPRanges: []string{"10.0.0.1"}},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.rules.IsValid())
+		})
+	}
+}
+
+func TestLoginPolicyRiskRules_Evaluate(t *testing.T) {
+	type args struct {
+		remoteIP       net.IP
+		knownUserAgent bool
+		country        string
+		lastCountry    string
+	}
+	tests := []struct {
+		name  string
+		rules LoginPolicyRiskRules
+		args  args
+		want  []LoginRiskReason
+	}{
+		{
+			"no rules, no reasons",
+			LoginPolicyRiskRules{},
+			args{
+				remoteIP:    net.ParseIP("192.168.0.1"),
+				country:     "CH",
+				lastCountry: "DE",
+			},
+			[]LoginRiskReason{},
+		},
+		{
+			"ip in trusted range, no reasons",
+			LoginPolicyRiskRules{TrustedIPRanges: []string{"192.168.0.0/16"}},
+			args{
+				remoteIP: net.ParseIP("192.168.0.1"),
+			},
+			[]LoginRiskReason{},
+		},
+		{
+			"ip outside trusted range, untrusted ip",
+			LoginPolicyRiskRules{TrustedIPRanges: []string{"192.168.0.0/16"}},
+			args{
+				remoteIP: net.ParseIP("10.0.0.1"),
+			},
+			[]LoginRiskReason{LoginRiskReasonUntrustedIP},
+		},
+		{
+			"unknown ip, untrusted ip",
+			LoginPolicyRiskRules{TrustedIPRanges: []string{"192.168.0.0/16"}},
+			args{},
+			[]LoginRiskReason{LoginRiskReasonUntrustedIP},
+		},
+		{
+			"new user agent, new user agent",
+			LoginPolicyRiskRules{MFAOnNewUserAgent: true},
+			args{
+				knownUserAgent: false,
+			},
+			[]LoginRiskReason{LoginRiskReasonNewUserAgent},
+		},
+		{
+			"first login, no country change",
+			LoginPolicyRiskRules{MFAOnCountryChange: true},
+			args{
+				country: "CH",
+			},
+			[]LoginRiskReason{},
+		},
+		{
+			"other country, country changed",
+			LoginPolicyRiskRules{MFAOnCountryChange: true, MFAOnNewUserAgent: true},
+			args{
+				knownUserAgent: true,
+				country:        "DE",
+				lastCountry:    "CH",
+			},
+			[]LoginRiskReason{LoginRiskReasonCountryChanged},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.rules.Evaluate(tt.args.remoteIP, tt.args.knownUserAgent, tt.args.country, tt.args.lastCountry)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -9,5 +9,7 @@ type LockoutPolicy struct {
 
 	Default             bool
 	MaxPasswordAttempts uint64
+	// MaxOTPAttempts locks the user after the amount of failed second factor checks (OTP, U2F, ...), 0 disables the lockout
+	MaxOTPAttempts      uint64
 	ShowLockOutFailures bool
 }
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"time"
+
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 )
 
@@ -13,4 +15,7 @@ type PrivacyPolicy struct {
 	TOSLink     string
 	PrivacyLink string
 	HelpLink    string
+	// DeletionGracePeriod is the time between the request of a user to delete itself and the removal of the user.
+	// During this period the user is deactivated and can cancel the deletion by logging in.
+	DeletionGracePeriod time.Duration
 }
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+const minSigningKeyRSASize = 2048
+
+// SigningKeyPolicy defines the OIDC signing keys of an instance and their rotation
+// the SAML certificates are not affected by the policy, they always use RSA keys
+type SigningKeyPolicy struct {
+	models.ObjectRoot
+
+	// Algorithm (JWA name) of the keys, the curve of ECDSA keys is defined by it
+	Algorithm string
+	// KeySize is the size of RSA keys in bits, it's ignored for all other algorithms
+	KeySize int
+	// KeyLifetime is the duration a key is used for signing
+	KeyLifetime time.Duration
+	// OverlapPeriod is the duration the public key is still published after the key was rotated,
+	// so tokens signed just before can still be verified
+	OverlapPeriod time.Duration
+	// PrepublicationWindow is the duration the next key is published before it's used for signing,
+	// so relying parties can fetch it in advance
+	PrepublicationWindow time.Duration
+}
+
+func (p *SigningKeyPolicy) IsValid() error {
+	if !crypto.IsSigningAlgorithmSupported(p.Algorithm) {
+		return caos_errs.ThrowInvalidArgument(nil, "DOMAIN-Wm2ks", "Errors.IAM.SigningKeyPolicy.AlgorithmInvalid")
+	}
+	if crypto.IsRSASigningAlgorithm(p.Algorithm) && p.KeySize < minSigningKeyRSASize {
+		return caos_errs.ThrowInvalidArgument(nil, "DOMAIN-Sk2m9", "Errors.IAM.SigningKeyPolicy.KeySizeInvalid")
+	}
+	if p.KeyLifetime <= 0 || p.OverlapPeriod < 0 {
+		return caos_errs.ThrowInvalidArgument(nil, "DOMAIN-Ls93m", "Errors.IAM.SigningKeyPolicy.LifetimeInvalid")
+	}
+	if p.PrepublicationWindow < 0 || p.PrepublicationWindow >= p.KeyLifetime {
+		return caos_errs.ThrowInvalidArgument(nil, "DOMAIN-Pq8sm", "Errors.IAM.SigningKeyPolicy.PrepublicationWindowInvalid")
+	}
+	return nil
+}
+
+// KeySizeOfAlgorithm returns the KeySize if the Algorithm uses RSA keys, otherwise 0
+func (p *SigningKeyPolicy) KeySizeOfAlgorithm() int {
+	if crypto.IsRSASigningAlgorithm(p.Algorithm) {
+		return p.KeySize
+	}
+	return 0
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+func TestSigningKeyPolicy_IsValid(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy SigningKeyPolicy
+		valid  bool
+	}{
+		{
+			"rsa, ok",
+			SigningKeyPolicy{Algorithm: "RS256", KeySize: 2048, KeyLifetime: 6 * time.Hour, OverlapPeriod: 24 * time.Hour, PrepublicationWindow: time.Hour},
+			true,
+		},
+		{
+			"ecdsa without key size, ok",
+			SigningKeyPolicy{Algorithm: "ES384", KeyLifetime: 6 * time.Hour},
+			true,
+		},
+		{
+			"symmetric algorithm, invalid",
+			SigningKeyPolicy{Algorithm: "HS256", KeyLifetime: 6 * time.Hour},
+			false,
+		},
+		{
+			"rsa key too small, invalid",
+			SigningKeyPolicy{Algorithm: "RS512", KeySize: 1024, KeyLifetime: 6 * time.Hour},
+			false,
+		},
+		{
+			"no lifetime, invalid",
+			SigningKeyPolicy{Algorithm: "EdDSA"},
+			false,
+		},
+		{
+			"negative overlap, invalid",
+			SigningKeyPolicy{Algorithm: "EdDSA", KeyLifetime: 6 * time.Hour, OverlapPeriod: -time.Hour},
+			false,
+		},
+		{
+			"prepublication window as long as lifetime, invalid",
+			SigningKeyPolicy{Algorithm: "EdDSA", KeyLifetime: 6 * time.Hour, PrepublicationWindow: 6 * time.Hour},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.IsValid()
+			if tt.valid {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, caos_errs.IsErrorInvalidArgument(err))
+		})
+	}
+}
+
+func TestSigningKeyPolicy_KeySizeOfAlgorithm(t *testing.T) {
+	assert.Equal(t, 4096, (&SigningKeyPolicy{Algorithm: "RS384", KeySize: 4096}).KeySizeOfAlgorithm())
+	assert.Equal(t, 0, (&SigningKeyPolicy{Algorithm: "ES256", KeySize: 4096}).KeySizeOfAlgorithm())
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"encoding/base64"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	caos_errors "github.com/zitadel/zitadel/internal/errors"
+)
+
+type SessionState int32
+
+const (
+	SessionStateUnspecified SessionState = iota
+	SessionStateActive
+	SessionStateTerminated
+)
+
+const sessionIDPIntentPrefix = "idp"
+
+func NewSessionToken(sessionID, tokenID string, algorithm crypto.EncryptionAlgorithm) (string, error) {
+	encrypted, err := algorithm.Encrypt([]byte(sessionID + ":" + tokenID))
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(encrypted), nil
+}
+
+// VerifySessionToken checks if the token was issued for the session and is the latest token of it
+func VerifySessionToken(sessionToken, sessionID, tokenID string, algorithm crypto.EncryptionAlgorithm) error {
+	decoded, err := base64.RawURLEncoding.DecodeString(sessionToken)
+	if err != nil {
+		return caos_errors.ThrowPermissionDenied(err, "DOMAIN-Sfh3a", "Errors.Session.Token.Invalid")
+	}
+	decrypted, err := algorithm.Decrypt(decoded, algorithm.EncryptionKeyID())
+	if err != nil {
+		return caos_errors.ThrowPermissionDenied(err, "DOMAIN-Bf3dh", "Errors.Session.Token.Invalid")
+	}
+	split := strings.Split(string(decrypted), ":")
+	if len(split) != 2 || split[0] != sessionID || split[1] != tokenID {
+		return caos_errors.ThrowPermissionDenied(nil, "DOMAIN-Skh3a", "Errors.Session.Token.Invalid")
+	}
+	return nil
+}
+
+// NewSessionIDPIntent returns the intent of a started identity provider flow of the session,
+// which is passed to the login to authenticate the user on the identity provider
+func NewSessionIDPIntent(sessionID, idpID string, algorithm crypto.EncryptionAlgorithm) (string, error) {
+	encrypted, err := algorithm.Encrypt([]byte(sessionIDPIntentPrefix + ":" + sessionID + ":" + idpID))
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(encrypted), nil
+}
+
+func FromSessionIDPIntent(intent string, algorithm crypto.EncryptionAlgorithm) (sessionID, idpID string, err error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(intent)
+	if err != nil {
+		return "", "", caos_errors.ThrowInvalidArgument(err, "DOMAIN-Gd3sf", "Errors.Session.IDP.Invalid")
+	}
+	decrypted, err := algorithm.Decrypt(decoded, algorithm.EncryptionKeyID())
+	if err != nil {
+		return "", "", caos_errors.ThrowInvalidArgument(err, "DOMAIN-Ns2gh", "Errors.Session.IDP.Invalid")
+	}
+	split := strings.Split(string(decrypted), ":")
+	if len(split) != 3 || split[0] != sessionIDPIntentPrefix {
+		return "", "", caos_errors.ThrowInvalidArgument(nil, "DOMAIN-Mk2ha", "Errors.Session.IDP.Invalid")
+	}
+	return split[1], split[2], nil
+}
@@ -20,6 +20,16 @@ type Token struct {
 	Expiration        time.Time
 	Scopes            []string
 	PreferredLanguage string
+	Actor             *TokenActor
+}
+
+// TokenActor is the party acting on behalf of the subject of a token,
+// which was issued by a token exchange (RFC 8693, section 4.1).
+// Nested actors represent a chain of delegations.
+type TokenActor struct {
+	Actor  *TokenActor `json:"actor,omitempty"`
+	UserID string      `json:"userId,omitempty"`
+	Issuer string      `json:"issuer,omitempty"`
 }
 
 func AddAudScopeToAudience(ctx context.Context, audience, scopes []string) []string {
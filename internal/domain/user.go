@@ -51,6 +51,9 @@ const (
 	UserAuthMethodTypeOTP
 	UserAuthMethodTypeU2F
 	UserAuthMethodTypePasswordless
+	UserAuthMethodTypeOTPSMS
+	UserAuthMethodTypeOTPEmail
+	UserAuthMethodTypeRecoveryCode
 	userAuthMethodTypeCount
 )
 
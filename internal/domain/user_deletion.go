@@ -0,0 +1,12 @@
+package domain
+
+import (
+	"time"
+)
+
+// ScheduledUserDeletion is a user who requested its own deletion, which will be executed at the scheduled date
+type ScheduledUserDeletion struct {
+	UserID        string
+	ResourceOwner string
+	ScheduledDate time.Time
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"net/url"
+
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+type Webhook struct {
+	models.ObjectRoot
+
+	Name           string
+	URL            string
+	EventTypes     []string
+	AggregateTypes []string
+	State          WebhookState
+}
+
+func (w *Webhook) IsValid() bool {
+	if w.Name == "" || w.URL == "" {
+		return false
+	}
+	u, err := url.Parse(w.URL)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "https" || u.Scheme == "http") && u.Host != ""
+}
+
+type WebhookState int32
+
+const (
+	WebhookStateUnspecified WebhookState = iota
+	WebhookStateActive
+	WebhookStateInactive
+	WebhookStateRemoved
+	webhookStateCount
+)
+
+func (s WebhookState) Valid() bool {
+	return s >= 0 && s < webhookStateCount
+}
+
+func (s WebhookState) Exists() bool {
+	return s != WebhookStateUnspecified && s != WebhookStateRemoved
+}
+
+type WebhookDeliveryState int32
+
+const (
+	WebhookDeliveryStateUnspecified WebhookDeliveryState = iota
+	WebhookDeliveryStateDelivered
+	// WebhookDeliveryStateFailed is final: the delivery failed on all attempts
+	WebhookDeliveryStateFailed
+	// WebhookDeliveryStatePending is set until the delivery succeeded or all attempts failed
+	WebhookDeliveryStatePending
+	webhookDeliveryStateCount
+)
+
+func (s WebhookDeliveryState) Valid() bool {
+	return s >= 0 && s < webhookDeliveryStateCount
+}
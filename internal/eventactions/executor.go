@@ -0,0 +1,319 @@
+package eventactions
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/actions/trigger"
+	sd "github.com/zitadel/zitadel/internal/config/systemdefaults"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
+)
+
+// executionLease postpones the next attempt of a claimed execution,
+// it exceeds the maximum timeout of an action
+const executionLease = time.Minute
+
+var (
+	executionTable = projection.EventActionsTable + "_" + projection.EventActionsExecutionSuffix
+
+	scheduleExecutionStmt = "INSERT INTO " + executionTable +
+		" (" + projection.EventActionsExecutionActionIDCol +
+		", " + projection.EventActionsExecutionInstanceIDCol +
+		", " + projection.EventActionsExecutionEventSequenceCol +
+		", " + projection.EventActionsExecutionResourceOwnerCol +
+		", " + projection.EventActionsExecutionEventTypeCol +
+		", " + projection.EventActionsExecutionCreationDateCol +
+		", " + projection.EventActionsExecutionChangeDateCol +
+		", " + projection.EventActionsExecutionStateCol +
+		", " + projection.EventActionsExecutionAttemptsCol +
+		", " + projection.EventActionsExecutionLastErrorCol +
+		", " + projection.EventActionsExecutionEventCol +
+		", " + projection.EventActionsExecutionNextAttemptCol +
+		") VALUES ($1, $2, $3, $4, $5, $6, $6, $7, 0, '', $8, $6)" +
+		" ON CONFLICT (" + projection.EventActionsExecutionInstanceIDCol + ", " + projection.EventActionsExecutionActionIDCol + ", " + projection.EventActionsExecutionEventSequenceCol + ") DO NOTHING"
+
+	// claimExecutionsStmt postpones the next attempt of the due executions by the lease,
+	// so they are not run concurrently by another instance of ZITADEL
+	claimExecutionsStmt = "UPDATE " + executionTable +
+		" SET " + projection.EventActionsExecutionNextAttemptCol + " = $1" +
+		" WHERE " + projection.EventActionsExecutionStateCol + " = $2" +
+		" AND " + projection.EventActionsExecutionNextAttemptCol + " <= $3" +
+		" AND (" + projection.EventActionsExecutionInstanceIDCol + ", " + projection.EventActionsExecutionActionIDCol + ", " + projection.EventActionsExecutionEventSequenceCol + ") IN (" +
+		"SELECT " + projection.EventActionsExecutionInstanceIDCol + ", " + projection.EventActionsExecutionActionIDCol + ", " + projection.EventActionsExecutionEventSequenceCol +
+		" FROM " + executionTable +
+		" WHERE " + projection.EventActionsExecutionStateCol + " = $2" +
+		" AND " + projection.EventActionsExecutionNextAttemptCol + " <= $3" +
+		" ORDER BY " + projection.EventActionsExecutionNextAttemptCol +
+		" LIMIT $4)" +
+		" RETURNING " + projection.EventActionsExecutionInstanceIDCol +
+		", " + projection.EventActionsExecutionActionIDCol +
+		", " + projection.EventActionsExecutionEventSequenceCol +
+		", " + projection.EventActionsExecutionResourceOwnerCol +
+		", " + projection.EventActionsExecutionAttemptsCol +
+		", " + projection.EventActionsExecutionEventCol
+
+	setExecutionResultStmt = "UPDATE " + executionTable +
+		" SET (" + projection.EventActionsExecutionChangeDateCol +
+		", " + projection.EventActionsExecutionStateCol +
+		", " + projection.EventActionsExecutionAttemptsCol +
+		", " + projection.EventActionsExecutionLastErrorCol +
+		", " + projection.EventActionsExecutionNextAttemptCol +
+		") = ($1, $2, $3, $4, $5)" +
+		" WHERE " + projection.EventActionsExecutionInstanceIDCol + " = $6" +
+		" AND " + projection.EventActionsExecutionActionIDCol + " = $7" +
+		" AND " + projection.EventActionsExecutionEventSequenceCol + " = $8"
+)
+
+// storedEvent is the event as stored on the execution,
+// the data of the event is stored without password hashes, secrets, codes and tokens
+type storedEvent struct {
+	InstanceID    string          `json:"instanceId"`
+	ResourceOwner string          `json:"resourceOwner"`
+	AggregateType string          `json:"aggregateType"`
+	AggregateID   string          `json:"aggregateId"`
+	EventType     string          `json:"eventType"`
+	Sequence      uint64          `json:"sequence"`
+	CreationDate  time.Time       `json:"creationDate"`
+	EditorUser    string          `json:"editorUser"`
+	EditorService string          `json:"editorService"`
+	Payload       json.RawMessage `json:"payload,omitempty"`
+}
+
+func newStoredEvent(event eventstore.Event) *storedEvent {
+	return &storedEvent{
+		InstanceID:    event.Aggregate().InstanceID,
+		ResourceOwner: event.Aggregate().ResourceOwner,
+		AggregateType: string(event.Aggregate().Type),
+		AggregateID:   event.Aggregate().ID,
+		EventType:     string(event.Type()),
+		Sequence:      event.Sequence(),
+		CreationDate:  event.CreationDate(),
+		EditorUser:    event.EditorUser(),
+		EditorService: event.EditorService(),
+		Payload:       eventstore.RedactedData(event),
+	}
+}
+
+func (e *storedEvent) event() eventstore.Event {
+	return eventstore.BaseEventFromRepo(&repository.Event{
+		Sequence:      e.Sequence,
+		CreationDate:  e.CreationDate,
+		Type:          repository.EventType(e.EventType),
+		Data:          e.Payload,
+		EditorService: e.EditorService,
+		EditorUser:    e.EditorUser,
+		ResourceOwner: sql.NullString{String: e.ResourceOwner, Valid: e.ResourceOwner != ""},
+		InstanceID:    e.InstanceID,
+		AggregateType: repository.AggregateType(e.AggregateType),
+		AggregateID:   e.AggregateID,
+	})
+}
+
+type actionQuerier interface {
+	GetActionByID(ctx context.Context, id string, orgID string, withOwnerRemoved bool) (*query.Action, error)
+}
+
+// executor runs the pending executions scheduled by the event actions handler,
+// every execution is run and retried independently of all others
+type executor struct {
+	queries actionQuerier
+	client  *sql.DB
+	config  sd.EventActions
+}
+
+func newExecutor(queries actionQuerier, client *sql.DB, config sd.EventActions) *executor {
+	return &executor{
+		queries: queries,
+		client:  client,
+		config:  config,
+	}
+}
+
+type pendingExecution struct {
+	instanceID    string
+	actionID      string
+	eventSequence uint64
+	resourceOwner string
+	attempts      uint64
+	event         []byte
+}
+
+// Start runs the due executions every ExecutionInterval until the context is done
+func (e *executor) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(e.config.ExecutionInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				e.executeDue(ctx)
+			}
+		}
+	}()
+}
+
+func (e *executor) executeDue(ctx context.Context) {
+	executions, err := e.claimDue(ctx)
+	if err != nil {
+		logging.WithError(err).Warn("unable to query due event action executions")
+		return
+	}
+	var wg sync.WaitGroup
+	for _, execution := range executions {
+		wg.Add(1)
+		go func(execution *pendingExecution) {
+			defer wg.Done()
+			e.execute(execution)
+		}(execution)
+	}
+	wg.Wait()
+}
+
+// claimDue returns the due executions and postpones their next attempt by the executionLease
+func (e *executor) claimDue(ctx context.Context) (_ []*pendingExecution, err error) {
+	now := time.Now()
+	rows, err := e.client.QueryContext(ctx, claimExecutionsStmt,
+		now.Add(executionLease),
+		domain.EventActionExecutionStatePending,
+		now,
+		e.config.ExecutionBulkLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	executions := make([]*pendingExecution, 0)
+	for rows.Next() {
+		execution := new(pendingExecution)
+		if err = rows.Scan(
+			&execution.instanceID,
+			&execution.actionID,
+			&execution.eventSequence,
+			&execution.resourceOwner,
+			&execution.attempts,
+			&execution.event,
+		); err != nil {
+			return nil, err
+		}
+		executions = append(executions, execution)
+	}
+	return executions, rows.Err()
+}
+
+// execute runs the current script of the action and stores the result of the attempt
+func (e *executor) execute(execution *pendingExecution) {
+	ctx := setEventActionsContext(execution.instanceID, execution.resourceOwner)
+	logger := logging.WithFields("actionID", execution.actionID, "instance", execution.instanceID, "sequence", execution.eventSequence)
+
+	a, err := e.queries.GetActionByID(ctx, execution.actionID, execution.resourceOwner, false)
+	if err != nil && !errors.IsNotFound(err) {
+		// the execution is attempted again after the lease
+		logger.WithError(err).Warn("unable to query action")
+		return
+	}
+	if err != nil || a.State != domain.ActionStateActive {
+		err = errors.ThrowPreconditionFailed(err, "EVACT-ohc4E", "Errors.Action.NotActive")
+		// no further attempts of inactive and removed actions
+		execution.attempts = e.config.MaxExecutionAttempts
+	} else {
+		err = run(ctx, execution, a)
+		logger.OnError(err).Info("event action failed")
+	}
+	state, attempts, nextAttempt := e.nextState(execution.attempts, err, time.Now())
+	var lastError string
+	if err != nil {
+		lastError = err.Error()
+	}
+	_, err = e.client.ExecContext(ctx, setExecutionResultStmt,
+		time.Now(),
+		state,
+		attempts,
+		lastError,
+		nextAttempt,
+		execution.instanceID,
+		execution.actionID,
+		execution.eventSequence,
+	)
+	logger.OnError(err).Warn("unable to store event action execution")
+}
+
+// run executes the action with the stored event
+func run(ctx context.Context, execution *pendingExecution, a *query.Action) error {
+	stored := new(storedEvent)
+	if err := json.Unmarshal(execution.event, stored); err != nil {
+		return errors.ThrowInternal(err, "EVACT-Quai7", "unable to unmarshal event")
+	}
+	if err := execute(ctx, stored.event(), a); err != nil {
+		return errors.ThrowUnavailable(err, "EVACT-Oor6e", "Errors.Action.ExecutionFailed")
+	}
+	return nil
+}
+
+// nextState returns the state of the execution after the attempt,
+// failed executions are retried with an exponential backoff until MaxExecutionAttempts is reached
+func (e *executor) nextState(previousAttempts uint64, err error, now time.Time) (_ domain.EventActionExecutionState, attempts uint64, nextAttempt *time.Time) {
+	attempts = previousAttempts + 1
+	if err == nil {
+		return domain.EventActionExecutionStateSucceeded, attempts, nil
+	}
+	if attempts >= e.config.MaxExecutionAttempts {
+		return domain.EventActionExecutionStateFailed, attempts, nil
+	}
+	next := now.Add(e.retryDelay(attempts))
+	return domain.EventActionExecutionStatePending, attempts, &next
+}
+
+// retryDelay returns the time to wait after the failed attempt
+func (e *executor) retryDelay(attempts uint64) time.Duration {
+	if e.config.MaxRetryDelay <= e.config.RetryDelay {
+		return e.config.RetryDelay
+	}
+	delay := e.config.RetryDelay
+	for i := uint64(1); i < attempts && delay < e.config.MaxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > e.config.MaxRetryDelay {
+		return e.config.MaxRetryDelay
+	}
+	return delay
+}
+
+func execute(ctx context.Context, event eventstore.Event, a *query.Action) error {
+	actionCtx, cancel := context.WithTimeout(ctx, a.Timeout())
+	defer cancel()
+
+	return actions.Run(
+		actionCtx,
+		actions.SetContextFields(trigger.EventContextFields(event)...),
+		nil,
+		a.Script,
+		a.Name,
+		append(actions.ActionToOptions(a),
+			actions.WithHTTP(actionCtx),
+			actions.WithLogMetadata(logMetadata(event)),
+		)...,
+	)
+}
+
+// logMetadata identifies the event on the execution logs of the action
+func logMetadata(event eventstore.Event) map[string]interface{} {
+	return map[string]interface{}{
+		"eventType":     string(event.Type()),
+		"aggregateType": string(event.Aggregate().Type),
+		"aggregateID":   event.Aggregate().ID,
+		"sequence":      event.Sequence(),
+	}
+}
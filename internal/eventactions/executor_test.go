@@ -0,0 +1,107 @@
+package eventactions
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	sd "github.com/zitadel/zitadel/internal/config/systemdefaults"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func Test_run(t *testing.T) {
+	actions.SetLogstoreService(logstore.New(nil, nil, nil))
+	stored, err := json.Marshal(newStoredEvent(testEvent()))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		action  *query.Action
+		wantErr func(error) bool
+	}{
+		{
+			name: "action succeeds",
+			action: &query.Action{
+				ID:     "action1",
+				Name:   "check",
+				Script: `function check(ctx, api) { if (ctx.v1.event.payload.userName !== "username" || ctx.v1.event.payload.password !== undefined || ctx.v1.event.aggregateID !== "agg-id" || ctx.v1.event.sequence !== 15) { throw "wrong event" } }`,
+			},
+		},
+		{
+			name: "action fails, retry",
+			action: &query.Action{
+				ID:     "action1",
+				Name:   "fail",
+				Script: `function fail(ctx, api) { throw "failed" }`,
+			},
+			wantErr: errors.IsUnavailable,
+		},
+		{
+			name: "action allowed to fail",
+			action: &query.Action{
+				ID:            "action1",
+				Name:          "fail",
+				Script:        `function fail(ctx, api) { throw "failed" }`,
+				AllowedToFail: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := run(context.Background(), &pendingExecution{event: stored}, tt.action)
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_executor_retryDelay(t *testing.T) {
+	e := &executor{config: sd.EventActions{RetryDelay: time.Second, MaxRetryDelay: 5 * time.Second}}
+	assert.Equal(t, time.Second, e.retryDelay(1))
+	assert.Equal(t, 2*time.Second, e.retryDelay(2))
+	assert.Equal(t, 4*time.Second, e.retryDelay(3))
+	assert.Equal(t, 5*time.Second, e.retryDelay(4))
+	assert.Equal(t, 5*time.Second, e.retryDelay(100))
+}
+
+func Test_executor_nextState(t *testing.T) {
+	now := time.Now()
+	e := &executor{config: sd.EventActions{MaxExecutionAttempts: 3, RetryDelay: time.Second, MaxRetryDelay: time.Minute}}
+
+	state, attempts, next := e.nextState(0, nil, now)
+	assert.Equal(t, domain.EventActionExecutionStateSucceeded, state)
+	assert.Equal(t, uint64(1), attempts)
+	assert.Nil(t, next)
+
+	state, attempts, next = e.nextState(1, io.EOF, now)
+	assert.Equal(t, domain.EventActionExecutionStatePending, state)
+	assert.Equal(t, uint64(2), attempts)
+	require.NotNil(t, next)
+	assert.Equal(t, now.Add(2*time.Second), *next)
+
+	state, attempts, next = e.nextState(2, io.EOF, now)
+	assert.Equal(t, domain.EventActionExecutionStateFailed, state)
+	assert.Equal(t, uint64(3), attempts)
+	assert.Nil(t, next)
+}
+
+func Test_logMetadata(t *testing.T) {
+	assert.Equal(t, map[string]interface{}{
+		"eventType":     "user.human.added",
+		"aggregateType": "user",
+		"aggregateID":   "agg-id",
+		"sequence":      uint64(15),
+	}, logMetadata(testEvent()))
+}
@@ -0,0 +1,139 @@
+package eventactions
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	sd "github.com/zitadel/zitadel/internal/config/systemdefaults"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+)
+
+const (
+	// EventActionsHandlerName is used for the current sequences and failed events of the scheduling of the executions,
+	// the handler has no proprietary projection table
+	EventActionsHandlerName = "projections.event_action_executions"
+	EventActionsUserID      = "EVENT_ACTIONS"
+)
+
+type actionsQuerier interface {
+	GetActiveActionsByEventType(ctx context.Context, eventType, orgID string) ([]*query.Action, error)
+}
+
+// Start creates and starts the handler, which schedules the executions of the actions subscribed to the event types,
+// and the executor, which runs the scheduled executions outside of the projection.
+// Failed executions are retried with an exponential backoff and marked as failed after MaxExecutionAttempts.
+func Start(ctx context.Context, customConfig projection.CustomConfig, queries *query.Queries, client *sql.DB, config sd.EventActions) {
+	newEventActionsHandler(ctx, projection.ApplyCustomConfig(customConfig), queries)
+	newExecutor(queries, client, config).Start(ctx)
+}
+
+type eventActionsHandler struct {
+	crdb.StatementHandler
+	queries actionsQuerier
+}
+
+func newEventActionsHandler(
+	ctx context.Context,
+	config crdb.StatementHandlerConfig,
+	queries actionsQuerier,
+) *eventActionsHandler {
+	h := new(eventActionsHandler)
+	config.ProjectionName = EventActionsHandlerName
+	config.Reducers = h.reducers()
+	h.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	h.queries = queries
+
+	// needs to be started here as it is not part of the projection.projections / projection.newProjectionsList()
+	h.Start()
+	return h
+}
+
+func (h *eventActionsHandler) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate:      user.AggregateType,
+			DefaultReducer: h.reduceEvent,
+		},
+		{
+			Aggregate:      usergrant.AggregateType,
+			DefaultReducer: h.reduceEvent,
+		},
+		{
+			Aggregate:      org.AggregateType,
+			DefaultReducer: h.reduceEvent,
+		},
+		{
+			Aggregate:      project.AggregateType,
+			DefaultReducer: h.reduceEvent,
+		},
+	}
+}
+
+// reduceEvent schedules the execution of the active actions the organization owning the event subscribed to its event type,
+// the events of the key-value storage of the actions are skipped
+func (h *eventActionsHandler) reduceEvent(event eventstore.Event) (*handler.Statement, error) {
+	if org.IsActionKeyValueEventType(event.Type()) {
+		return crdb.NewNoOpStatement(event), nil
+	}
+	ctx := setEventActionsContext(event.Aggregate().InstanceID, event.Aggregate().ResourceOwner)
+	subscribed, err := h.queries.GetActiveActionsByEventType(ctx, string(event.Type()), event.Aggregate().ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if len(subscribed) == 0 {
+		return crdb.NewNoOpStatement(event), nil
+	}
+	return newScheduleStatement(event, subscribed), nil
+}
+
+// newScheduleStatement creates a statement, which schedules the execution of the subscribed actions for the event.
+// It only stores the pending executions, the executor runs them outside of the transaction of the projection.
+func newScheduleStatement(event eventstore.Event, subscribed []*query.Action) *handler.Statement {
+	return &handler.Statement{
+		AggregateType:    event.Aggregate().Type,
+		Sequence:         event.Sequence(),
+		PreviousSequence: event.PreviousAggregateTypeSequence(),
+		InstanceID:       event.Aggregate().InstanceID,
+		Execute: func(ex handler.Executer, _ string) error {
+			stored, err := json.Marshal(newStoredEvent(event))
+			if err != nil {
+				return errors.ThrowInternal(err, "EVACT-ahG5i", "unable to marshal event")
+			}
+			now := time.Now()
+			for _, a := range subscribed {
+				_, err = ex.Exec(scheduleExecutionStmt,
+					a.ID,
+					event.Aggregate().InstanceID,
+					event.Sequence(),
+					event.Aggregate().ResourceOwner,
+					event.Type(),
+					now,
+					domain.EventActionExecutionStatePending,
+					stored,
+				)
+				if err != nil {
+					return errors.ThrowInternal(err, "EVACT-Ahx3u", "unable to schedule event action execution")
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func setEventActionsContext(instanceID, resourceOwner string) context.Context {
+	ctx := authz.WithInstanceID(context.Background(), instanceID)
+	return authz.SetCtxData(ctx, authz.CtxData{UserID: EventActionsUserID, OrgID: resourceOwner})
+}
@@ -0,0 +1,130 @@
+package eventactions
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+type mockActionsQuerier struct {
+	actions []*query.Action
+	err     error
+}
+
+func (m *mockActionsQuerier) GetActiveActionsByEventType(context.Context, string, string) ([]*query.Action, error) {
+	return m.actions, m.err
+}
+
+func testEvent() eventstore.Event {
+	return eventstore.BaseEventFromRepo(&repository.Event{
+		ID:                            "event-id",
+		Sequence:                      15,
+		Type:                          "user.human.added",
+		Data:                          []byte(`{"userName":"username","password":{"cryptoType":1,"algorithm":"bcrypt","crypted":"JDJhJDE0"}}`),
+		EditorUser:                    "editor",
+		AggregateID:                   "agg-id",
+		AggregateType:                 "user",
+		ResourceOwner:                 sql.NullString{String: "ro-id", Valid: true},
+		InstanceID:                    "instance-id",
+		PreviousAggregateTypeSequence: 10,
+	})
+}
+
+type testExecuter struct {
+	args [][]interface{}
+}
+
+func (e *testExecuter) Exec(stmt string, args ...interface{}) (sql.Result, error) {
+	if stmt != scheduleExecutionStmt {
+		return nil, errors.ThrowInternal(nil, "ID", "unexpected statement")
+	}
+	e.args = append(e.args, args)
+	return nil, nil
+}
+
+func Test_eventActionsHandler_reduceEvent(t *testing.T) {
+	tests := []struct {
+		name       string
+		querier    *mockActionsQuerier
+		wantNoOp   bool
+		wantErr    func(error) bool
+		wantAction []string
+	}{
+		{
+			name:    "query failed",
+			querier: &mockActionsQuerier{err: errors.ThrowInternal(nil, "ID", "Errors.Internal")},
+			wantErr: errors.IsInternal,
+		},
+		{
+			name:     "no actions subscribed",
+			querier:  &mockActionsQuerier{},
+			wantNoOp: true,
+		},
+		{
+			name: "actions subscribed, executions scheduled",
+			querier: &mockActionsQuerier{actions: []*query.Action{
+				{ID: "action1"},
+				{ID: "action2"},
+			}},
+			wantAction: []string{"action1", "action2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &eventActionsHandler{queries: tt.querier}
+			stmt, err := h.reduceEvent(testEvent())
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, uint64(15), stmt.Sequence)
+			assert.Equal(t, uint64(10), stmt.PreviousSequence)
+			assert.Equal(t, "instance-id", stmt.InstanceID)
+			if tt.wantNoOp {
+				assert.Nil(t, stmt.Execute)
+				return
+			}
+			executer := new(testExecuter)
+			require.NoError(t, stmt.Execute(executer, ""))
+			require.Len(t, executer.args, len(tt.wantAction))
+			for i, args := range executer.args {
+				assert.Equal(t, tt.wantAction[i], args[0])
+				assert.Equal(t, "instance-id", args[1])
+				assert.Equal(t, uint64(15), args[2])
+				assert.Equal(t, "ro-id", args[3])
+				assert.Equal(t, domain.EventActionExecutionStatePending, args[6])
+				assert.JSONEq(t, `{"instanceId":"instance-id","resourceOwner":"ro-id","aggregateType":"user","aggregateId":"agg-id","eventType":"user.human.added","sequence":15,"creationDate":"0001-01-01T00:00:00Z","editorUser":"editor","editorService":"","payload":{"userName":"username"}}`, string(args[7].([]byte)))
+			}
+		})
+	}
+}
+
+func Test_eventActionsHandler_reduceEvent_keyValue(t *testing.T) {
+	h := &eventActionsHandler{queries: &mockActionsQuerier{actions: []*query.Action{{ID: "action1"}}}}
+	for _, eventType := range []repository.EventType{
+		repository.EventType(org.ActionKeyValueSetEventType),
+		repository.EventType(org.ActionKeyValueRemovedEventType),
+	} {
+		stmt, err := h.reduceEvent(eventstore.BaseEventFromRepo(&repository.Event{
+			Sequence:      15,
+			Type:          eventType,
+			AggregateID:   "ro-id",
+			AggregateType: repository.AggregateType(org.AggregateType),
+			ResourceOwner: sql.NullString{String: "ro-id", Valid: true},
+			InstanceID:    "instance-id",
+		}))
+		require.NoError(t, err)
+		assert.Nil(t, stmt.Execute, "no execution must be scheduled for %s", eventType)
+	}
+}
@@ -4,13 +4,18 @@ import (
 	"database/sql"
 	"time"
 
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/eventstore/repository"
 	z_sql "github.com/zitadel/zitadel/internal/eventstore/repository/sql"
 )
 
 type Config struct {
 	PushTimeout time.Duration
-	Client      *sql.DB
+	// EncryptPersonalData encrypts the personal data of new events with a key per aggregate,
+	// which is deleted if the aggregate (e.g. a user) is removed
+	EncryptPersonalData bool
+	Client              *sql.DB
+	KeyStorage          crypto.KeyStorage
 
 	repo repository.Repository
 }
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore/repository"
 )
@@ -23,18 +24,24 @@ type Eventstore struct {
 	eventTypes        []string
 	aggregateTypes    []string
 	PushTimeout       time.Duration
+	// EncryptPersonalData encrypts the registered personal data fields of pushed events
+	EncryptPersonalData bool
+	keyStorage          crypto.KeyStorage
 }
 
 type eventTypeInterceptors struct {
-	eventMapper func(*repository.Event) (Event, error)
+	eventMapper        func(*repository.Event) (Event, error)
+	personalDataFields []string
 }
 
 func NewEventstore(config *Config) *Eventstore {
 	return &Eventstore{
-		repo:              config.repo,
-		eventInterceptors: map[EventType]eventTypeInterceptors{},
-		interceptorMutex:  sync.Mutex{},
-		PushTimeout:       config.PushTimeout,
+		repo:                config.repo,
+		eventInterceptors:   map[EventType]eventTypeInterceptors{},
+		interceptorMutex:    sync.Mutex{},
+		PushTimeout:         config.PushTimeout,
+		EncryptPersonalData: config.EncryptPersonalData,
+		keyStorage:          config.KeyStorage,
 	}
 }
 
@@ -58,10 +65,22 @@ func (es *Eventstore) Push(ctx context.Context, cmds ...Command) ([]Event, error
 		defer cancel()
 	}
 
+	// the personal data is only stored encrypted, the pushed events are mapped with the plain data
+	plainData := make([][]byte, len(events))
+	for i, event := range events {
+		plainData[i] = event.Data
+	}
+	if err = es.encryptPersonalData(events); err != nil {
+		return nil, err
+	}
+
 	err = es.repo.Push(ctx, events, constraints...)
 	if err != nil {
 		return nil, err
 	}
+	for i, event := range events {
+		event.Data = plainData[i]
+	}
 
 	eventReaders, err := es.mapEvents(events)
 	if err != nil {
@@ -156,6 +175,9 @@ func (es *Eventstore) Filter(ctx context.Context, queryFactory *SearchQueryBuild
 }
 
 func (es *Eventstore) mapEvents(events []*repository.Event) (mappedEvents []Event, err error) {
+	if err = es.decryptPersonalData(events); err != nil {
+		return nil, err
+	}
 	mappedEvents = make([]Event, len(events))
 
 	es.interceptorMutex.Lock()
@@ -42,10 +42,11 @@ type StatementHandler struct {
 	failureCountStmt        string
 	setFailureCountStmt     string
 
-	aggregates  []eventstore.AggregateType
-	reduces     map[eventstore.EventType]handler.Reduce
-	initCheck   *handler.Check
-	initialized chan bool
+	aggregates     []eventstore.AggregateType
+	reduces        map[eventstore.EventType]handler.Reduce
+	defaultReduces map[eventstore.AggregateType]handler.Reduce
+	initCheck      *handler.Check
+	initialized    chan bool
 
 	bulkLimit uint64
 }
@@ -56,8 +57,12 @@ func NewStatementHandler(
 ) StatementHandler {
 	aggregateTypes := make([]eventstore.AggregateType, 0, len(config.Reducers))
 	reduces := make(map[eventstore.EventType]handler.Reduce, len(config.Reducers))
+	defaultReduces := make(map[eventstore.AggregateType]handler.Reduce)
 	for _, aggReducer := range config.Reducers {
 		aggregateTypes = append(aggregateTypes, aggReducer.Aggregate)
+		if aggReducer.DefaultReducer != nil {
+			defaultReduces[aggReducer.Aggregate] = aggReducer.DefaultReducer
+		}
 		for _, eventReducer := range aggReducer.EventRedusers {
 			reduces[eventReducer.Event] = eventReducer.Reduce
 		}
@@ -73,6 +78,7 @@ func NewStatementHandler(
 		setFailureCountStmt:     fmt.Sprintf(setFailureCountStmtFormat, config.FailedEventsTable),
 		aggregates:              aggregateTypes,
 		reduces:                 reduces,
+		defaultReduces:          defaultReduces,
 		bulkLimit:               config.BulkLimit,
 		Locker:                  NewLocker(config.Client, config.LockTable, config.ProjectionName),
 		initCheck:               config.InitCheck,
@@ -23,6 +23,10 @@ type ProjectionHandlerConfig struct {
 	ProjectionName      string
 	RequeueEvery        time.Duration
 	RetryFailedAfter    time.Duration
+	// MaxRetryFailedAfter enables exponential backoff:
+	// the wait between retries is doubled each time until it reaches MaxRetryFailedAfter.
+	// If not set, RetryFailedAfter is used for every retry.
+	MaxRetryFailedAfter time.Duration
 	Retries             uint
 	ConcurrentInstances uint
 }
@@ -54,6 +58,7 @@ type ProjectionHandler struct {
 	unlock              Unlock
 	requeueAfter        time.Duration
 	retryFailedAfter    time.Duration
+	maxRetryFailedAfter time.Duration
 	retries             int
 	concurrentInstances int
 }
@@ -83,6 +88,7 @@ func NewProjectionHandler(
 		requeueAfter:        config.RequeueEvery,
 		triggerProjection:   time.NewTimer(0), // first trigger is instant on startup
 		retryFailedAfter:    config.RetryFailedAfter,
+		maxRetryFailedAfter: config.MaxRetryFailedAfter,
 		retries:             int(config.Retries),
 		concurrentInstances: concurrentInstances,
 	}
@@ -143,11 +149,26 @@ func (h *ProjectionHandler) Process(ctx context.Context, events ...eventstore.Ev
 		if err == nil {
 			return index, nil
 		}
-		time.Sleep(h.retryFailedAfter)
+		time.Sleep(h.retryDelay(retry))
 	}
 	return index, err
 }
 
+// retryDelay returns the time to wait before the next retry
+func (h *ProjectionHandler) retryDelay(retry int) time.Duration {
+	if h.maxRetryFailedAfter <= h.retryFailedAfter {
+		return h.retryFailedAfter
+	}
+	delay := h.retryFailedAfter
+	for i := 0; i < retry && delay < h.maxRetryFailedAfter; i++ {
+		delay *= 2
+	}
+	if delay > h.maxRetryFailedAfter {
+		return h.maxRetryFailedAfter
+	}
+	return delay
+}
+
 // FetchEvents checks the current sequences and filters for newer events
 func (h *ProjectionHandler) FetchEvents(ctx context.Context, instances ...string) ([]eventstore.Event, bool, error) {
 	eventQuery, eventsLimit, err := h.searchQuery(ctx, instances)
@@ -1054,3 +1054,63 @@ func (m *cancelMocker) check(t *testing.T) {
 		}
 	}
 }
+
+func TestProjectionHandler_retryDelay(t *testing.T) {
+	type fields struct {
+		retryFailedAfter    time.Duration
+		maxRetryFailedAfter time.Duration
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		retry  int
+		want   time.Duration
+	}{
+		{
+			name: "no max, constant",
+			fields: fields{
+				retryFailedAfter: time.Second,
+			},
+			retry: 3,
+			want:  time.Second,
+		},
+		{
+			name: "first retry",
+			fields: fields{
+				retryFailedAfter:    time.Second,
+				maxRetryFailedAfter: 30 * time.Second,
+			},
+			retry: 0,
+			want:  time.Second,
+		},
+		{
+			name: "doubled",
+			fields: fields{
+				retryFailedAfter:    time.Second,
+				maxRetryFailedAfter: 30 * time.Second,
+			},
+			retry: 3,
+			want:  8 * time.Second,
+		},
+		{
+			name: "capped at max",
+			fields: fields{
+				retryFailedAfter:    time.Second,
+				maxRetryFailedAfter: 30 * time.Second,
+			},
+			retry: 10,
+			want:  30 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &ProjectionHandler{
+				retryFailedAfter:    tt.fields.retryFailedAfter,
+				maxRetryFailedAfter: tt.fields.maxRetryFailedAfter,
+			}
+			if got := h.retryDelay(tt.retry); got != tt.want {
+				t.Errorf("retryDelay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -14,4 +14,7 @@ type EventReducer struct {
 type AggregateReducer struct {
 	Aggregate     eventstore.AggregateType
 	EventRedusers []EventReducer
+	//DefaultReducer is called for events of the aggregate
+	//which have no dedicated EventReducer
+	DefaultReducer Reduce
 }
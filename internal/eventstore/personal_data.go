@@ -0,0 +1,230 @@
+package eventstore
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+// PersonalDataRedacted is returned instead of the personal data of an event
+// if the key of its aggregate was deleted (e.g. because the user was removed)
+const PersonalDataRedacted = "[REDACTED]"
+
+// personalDataPrefix marks the encrypted values in the event payload,
+// which allows to store plain (e.g. events pushed before encryption was enabled) and encrypted values side by side
+const personalDataPrefix = "pd:v1:"
+
+// PersonalDataKeyID returns the id of the key the personal data of the aggregate is encrypted with
+func PersonalDataKeyID(instanceID, aggregateID string) string {
+	return "personal_data:" + instanceID + ":" + aggregateID
+}
+
+// RegisterPersonalDataFields registers the (top level string) fields of the event payload containing personal data
+// they will be encrypted with a key per aggregate if EncryptPersonalData is enabled
+func (es *Eventstore) RegisterPersonalDataFields(eventType EventType, fields ...string) *Eventstore {
+	if eventType == "" || len(fields) == 0 {
+		return es
+	}
+	es.interceptorMutex.Lock()
+	defer es.interceptorMutex.Unlock()
+
+	interceptor := es.eventInterceptors[eventType]
+	interceptor.personalDataFields = append(interceptor.personalDataFields, fields...)
+	es.eventInterceptors[eventType] = interceptor
+
+	return es
+}
+
+// ShredPersonalData deletes the key of the aggregate (crypto shredding)
+// the events stay untouched, but their personal data will be returned as PersonalDataRedacted
+func (es *Eventstore) ShredPersonalData(ctx context.Context, aggregateID string) error {
+	if es.keyStorage == nil {
+		return nil
+	}
+	err := es.keyStorage.DeleteKeys(PersonalDataKeyID(authz.GetInstance(ctx).InstanceID(), aggregateID))
+	if err != nil {
+		return errors.ThrowInternal(err, "V2-Kd9sw", "unable to delete personal data key")
+	}
+	return nil
+}
+
+// PersonalDataDecryption returns a function decrypting the personal data of event payloads
+// the keys are cached, so the function must only be used for the events of a single filter
+func (es *Eventstore) PersonalDataDecryption() func(instanceID, aggregateID, eventType string, data []byte) ([]byte, error) {
+	keys := es.newPersonalDataKeys()
+	return func(instanceID, aggregateID, eventType string, data []byte) ([]byte, error) {
+		fields := es.personalDataFields(EventType(eventType))
+		if len(fields) == 0 || len(data) == 0 {
+			return data, nil
+		}
+		return transformPersonalData(data, fields, func(value string) (string, error) {
+			return keys.decrypt(PersonalDataKeyID(instanceID, aggregateID), value)
+		})
+	}
+}
+
+func (es *Eventstore) encryptPersonalData(events []*repository.Event) error {
+	if !es.EncryptPersonalData || es.keyStorage == nil {
+		return nil
+	}
+	keys := es.newPersonalDataKeys()
+	for _, event := range events {
+		fields := es.personalDataFields(EventType(event.Type))
+		if len(fields) == 0 || len(event.Data) == 0 {
+			continue
+		}
+		data, err := transformPersonalData(event.Data, fields, func(value string) (string, error) {
+			return keys.encrypt(PersonalDataKeyID(event.InstanceID, event.AggregateID), value)
+		})
+		if err != nil {
+			return err
+		}
+		event.Data = data
+	}
+	return nil
+}
+
+func (es *Eventstore) decryptPersonalData(events []*repository.Event) (err error) {
+	decrypt := es.PersonalDataDecryption()
+	for _, event := range events {
+		event.Data, err = decrypt(event.InstanceID, event.AggregateID, string(event.Type), event.Data)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (es *Eventstore) personalDataFields(eventType EventType) []string {
+	es.interceptorMutex.Lock()
+	defer es.interceptorMutex.Unlock()
+	return es.eventInterceptors[eventType].personalDataFields
+}
+
+func (es *Eventstore) newPersonalDataKeys() *personalDataKeys {
+	return &personalDataKeys{
+		storage: es.keyStorage,
+		keys:    make(map[string]*crypto.Key),
+	}
+}
+
+// personalDataKeys caches the keys read from the storage
+// a nil key means the key was deleted
+type personalDataKeys struct {
+	storage crypto.KeyStorage
+	keys    map[string]*crypto.Key
+}
+
+func (k *personalDataKeys) encrypt(keyID, value string) (string, error) {
+	if strings.HasPrefix(value, personalDataPrefix) {
+		return value, nil
+	}
+	key, err := k.key(keyID, true)
+	if err != nil {
+		return "", err
+	}
+	encrypted, err := crypto.EncryptAESString(value, key.Value)
+	if err != nil {
+		return "", errors.ThrowInternal(err, "V2-Wm2sd", "unable to encrypt personal data")
+	}
+	return personalDataPrefix + encrypted, nil
+}
+
+func (k *personalDataKeys) decrypt(keyID, value string) (string, error) {
+	if !strings.HasPrefix(value, personalDataPrefix) {
+		return value, nil
+	}
+	if k.storage == nil {
+		return PersonalDataRedacted, nil
+	}
+	key, err := k.key(keyID, false)
+	if err != nil {
+		return "", err
+	}
+	if key == nil {
+		return PersonalDataRedacted, nil
+	}
+	decrypted, err := crypto.DecryptAESString(strings.TrimPrefix(value, personalDataPrefix), key.Value)
+	if err != nil {
+		return "", errors.ThrowInternal(err, "V2-Pq0sk", "unable to decrypt personal data")
+	}
+	return decrypted, nil
+}
+
+func (k *personalDataKeys) key(id string, create bool) (*crypto.Key, error) {
+	if key, ok := k.keys[id]; ok && (key != nil || !create) {
+		return key, nil
+	}
+	key, err := k.storage.ReadKey(id)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.ThrowInternal(err, "V2-Hs82k", "unable to read personal data key")
+	}
+	if key == nil && create {
+		key, err = k.createKey(id)
+		if err != nil {
+			return nil, err
+		}
+	}
+	k.keys[id] = key
+	return key, nil
+}
+
+func (k *personalDataKeys) createKey(id string) (*crypto.Key, error) {
+	key, err := crypto.NewKey(id)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "V2-Ls9d2", "unable to create personal data key")
+	}
+	if err = k.storage.CreateKeys(key); err == nil {
+		return key, nil
+	}
+	// the key might have been created concurrently
+	key, readErr := k.storage.ReadKey(id)
+	if readErr != nil {
+		return nil, errors.ThrowInternal(err, "V2-Ao2ms", "unable to create personal data key")
+	}
+	return key, nil
+}
+
+// transformPersonalData applies the transformation on all non empty string values of the fields
+func transformPersonalData(data []byte, fields []string, transform func(string) (string, error)) ([]byte, error) {
+	payload := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &payload); err != nil {
+		// payloads which are not objects can't contain personal data fields
+		return data, nil
+	}
+	changed := false
+	for _, field := range fields {
+		raw, ok := payload[field]
+		if !ok {
+			continue
+		}
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil || value == "" {
+			continue
+		}
+		transformed, err := transform(value)
+		if err != nil {
+			return nil, err
+		}
+		if transformed == value {
+			continue
+		}
+		if payload[field], err = json.Marshal(transformed); err != nil {
+			return nil, errors.ThrowInternal(err, "V2-Xk2sd", "unable to marshal personal data")
+		}
+		changed = true
+	}
+	if !changed {
+		return data, nil
+	}
+	transformed, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "V2-Nm2ld", "unable to marshal personal data")
+	}
+	return transformed, nil
+}
@@ -0,0 +1,218 @@
+package eventstore
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+// testKeyStorage implements crypto.KeyStorage in memory
+type testKeyStorage struct {
+	keys crypto.Keys
+}
+
+func (s *testKeyStorage) ReadKeys() (crypto.Keys, error) {
+	return s.keys, nil
+}
+
+func (s *testKeyStorage) ReadKey(id string) (*crypto.Key, error) {
+	key, ok := s.keys[id]
+	if !ok {
+		return nil, errors.ThrowNotFound(nil, "TEST-Sk2ls", "key not found")
+	}
+	return &crypto.Key{ID: id, Value: key}, nil
+}
+
+func (s *testKeyStorage) CreateKeys(keys ...*crypto.Key) error {
+	for _, key := range keys {
+		if _, ok := s.keys[key.ID]; ok {
+			return errors.ThrowAlreadyExists(nil, "TEST-Wm2kd", "key already exists")
+		}
+		s.keys[key.ID] = key.Value
+	}
+	return nil
+}
+
+func (s *testKeyStorage) DeleteKeys(ids ...string) error {
+	for _, id := range ids {
+		delete(s.keys, id)
+	}
+	return nil
+}
+
+func newPersonalDataEventstore(encrypt bool, storage crypto.KeyStorage) *Eventstore {
+	es := NewEventstore(&Config{EncryptPersonalData: encrypt, KeyStorage: storage})
+	return es.RegisterPersonalDataFields("user.added", "email", "firstName")
+}
+
+func personalDataEvents() []*repository.Event {
+	return []*repository.Event{
+		{
+			InstanceID:  "instance",
+			AggregateID: "user1",
+			Type:        "user.added",
+			Data:        []byte(`{"email":"gigi@zitadel.ch","firstName":"Gigi","userName":"gigi"}`),
+		},
+		{
+			InstanceID:  "instance",
+			AggregateID: "user2",
+			Type:        "user.added",
+			Data:        []byte(`{"email":"","firstName":"Hodor","userName":"hodor"}`),
+		},
+		{
+			InstanceID:  "instance",
+			AggregateID: "user1",
+			Type:        "user.unregistered",
+			Data:        []byte(`{"email":"gigi@zitadel.ch"}`),
+		},
+	}
+}
+
+func TestEventstore_encryptPersonalData(t *testing.T) {
+	tests := []struct {
+		name          string
+		encrypt       bool
+		storage       *testKeyStorage
+		wantEncrypted []bool
+		wantKeys      []string
+	}{
+		{
+			name:          "encryption disabled",
+			encrypt:       false,
+			storage:       &testKeyStorage{keys: crypto.Keys{}},
+			wantEncrypted: []bool{false, false, false},
+		},
+		{
+			name:          "encryption enabled",
+			encrypt:       true,
+			storage:       &testKeyStorage{keys: crypto.Keys{}},
+			wantEncrypted: []bool{true, true, false},
+			wantKeys:      []string{PersonalDataKeyID("instance", "user1"), PersonalDataKeyID("instance", "user2")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := newPersonalDataEventstore(tt.encrypt, tt.storage)
+			events := personalDataEvents()
+			plain := personalDataEvents()
+			if err := es.encryptPersonalData(events); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for i, event := range events {
+				encrypted := strings.Contains(string(event.Data), personalDataPrefix)
+				if encrypted != tt.wantEncrypted[i] {
+					t.Errorf("event %d: expected encrypted %t, got: %s", i, tt.wantEncrypted[i], event.Data)
+				}
+				if encrypted && strings.Contains(string(event.Data), "Gigi") {
+					t.Errorf("event %d: personal data not encrypted: %s", i, event.Data)
+				}
+			}
+			if len(tt.storage.keys) != len(tt.wantKeys) {
+				t.Errorf("expected %d keys, got %d", len(tt.wantKeys), len(tt.storage.keys))
+			}
+			for _, id := range tt.wantKeys {
+				if _, ok := tt.storage.keys[id]; !ok {
+					t.Errorf("expected key %s", id)
+				}
+			}
+
+			if err := es.decryptPersonalData(events); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for i, event := range events {
+				if !jsonEqual(event.Data, plain[i].Data) {
+					t.Errorf("event %d: expected %s, got %s", i, plain[i].Data, event.Data)
+				}
+			}
+		})
+	}
+}
+
+func TestEventstore_ShredPersonalData(t *testing.T) {
+	storage := &testKeyStorage{keys: crypto.Keys{}}
+	es := newPersonalDataEventstore(true, storage)
+	events := personalDataEvents()
+	if err := es.encryptPersonalData(events); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := authz.WithInstanceID(context.Background(), "instance")
+	if err := es.ShredPersonalData(ctx, "user1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := es.decryptPersonalData(events); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{
+		`{"email":"[REDACTED]","firstName":"[REDACTED]","userName":"gigi"}`,
+		`{"email":"","firstName":"Hodor","userName":"hodor"}`,
+		`{"email":"gigi@zitadel.ch"}`,
+	}
+	for i, event := range events {
+		if !jsonEqual(event.Data, []byte(want[i])) {
+			t.Errorf("event %d: expected %s, got %s", i, want[i], event.Data)
+		}
+	}
+}
+
+func Test_transformPersonalData(t *testing.T) {
+	upper := func(value string) (string, error) {
+		return strings.ToUpper(value), nil
+	}
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{
+			name: "no object",
+			data: `["gigi"]`,
+			want: `["gigi"]`,
+		},
+		{
+			name: "field missing",
+			data: `{"userName":"gigi"}`,
+			want: `{"userName":"gigi"}`,
+		},
+		{
+			name: "field not a string",
+			data: `{"email":{"address":"gigi"}}`,
+			want: `{"email":{"address":"gigi"}}`,
+		},
+		{
+			name: "field transformed",
+			data: `{"email":"gigi","userName":"gigi"}`,
+			want: `{"email":"GIGI","userName":"gigi"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := transformPersonalData([]byte(tt.data), []string{"email"}, upper)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !jsonEqual(got, []byte(tt.want)) {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func jsonEqual(a, b []byte) bool {
+	var x, y interface{}
+	if err := json.Unmarshal(a, &x); err != nil {
+		return false
+	}
+	if err := json.Unmarshal(b, &y); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(x, y)
+}
@@ -0,0 +1,72 @@
+package eventstore
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// sensitiveDataKeys are the keys of event payloads, which must never leave ZITADEL
+// e.g. on a delivery to a webhook or as the event of an action
+var sensitiveDataKeys = map[string]bool{
+	"secret":         true,
+	"password":       true,
+	"otpSecret":      true,
+	"clientSecret":   true,
+	"signingKey":     true,
+	"privateKey":     true,
+	"code":           true,
+	"codes":          true,
+	"userCode":       true,
+	"deviceCode":     true,
+	"validationCode": true,
+	"token":          true,
+	"refreshToken":   true,
+}
+
+// encryptedValueKey identifies a crypto.CryptoValue, which holds either an encrypted secret or a hash
+const encryptedValueKey = "crypted"
+
+// RedactedData returns the payload of the event without password hashes, secrets, codes and tokens:
+// the values of the sensitive keys and all encrypted or hashed values are removed.
+// If the payload is empty or not a JSON object, nil is returned.
+func RedactedData(event Event) json.RawMessage {
+	data := event.DataAsBytes()
+	if len(data) == 0 {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var payload map[string]interface{}
+	if err := decoder.Decode(&payload); err != nil || payload == nil {
+		return nil
+	}
+	redacted, err := json.Marshal(redact(payload))
+	if err != nil {
+		return nil
+	}
+	return redacted
+}
+
+func redact(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if _, ok := v[encryptedValueKey]; ok {
+			return nil
+		}
+		for key, child := range v {
+			if sensitiveDataKeys[key] {
+				delete(v, key)
+				continue
+			}
+			v[key] = redact(child)
+		}
+		return v
+	case []interface{}:
+		for i, child := range v {
+			v[i] = redact(child)
+		}
+		return v
+	default:
+		return v
+	}
+}
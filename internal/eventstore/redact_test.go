@@ -0,0 +1,64 @@
+package eventstore
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+func TestRedactedData(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{
+			name: "no data",
+			data: "",
+			want: "",
+		},
+		{
+			name: "no object",
+			data: `["secret"]`,
+			want: "",
+		},
+		{
+			name: "nothing sensitive",
+			data: `{"userName":"username","sequence":12345678901234567890}`,
+			want: `{"sequence":12345678901234567890,"userName":"username"}`,
+		},
+		{
+			name: "sensitive keys removed",
+			data: `{"userName":"username","clientSecret":"abc","code":"123","refreshToken":"token"}`,
+			want: `{"userName":"username"}`,
+		},
+		{
+			name: "encrypted and hashed values removed",
+			data: `{"passwordHash":{"cryptoType":1,"algorithm":"bcrypt","crypted":"JDJhJDE0"},"changeRequired":true}`,
+			want: `{"changeRequired":true,"passwordHash":null}`,
+		},
+		{
+			name: "nested values removed",
+			data: `{"profile":{"firstName":"first","otpSecret":{"crypted":"c2VjcmV0"}},"keys":[{"id":"1","privateKey":"key"},{"value":{"crypted":"a2V5"}}]}`,
+			want: `{"keys":[{"id":"1"},{"value":null}],"profile":{"firstName":"first"}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := BaseEventFromRepo(&repository.Event{
+				Data: []byte(tt.data),
+			})
+			got := RedactedData(event)
+			if tt.want == "" {
+				if got != nil {
+					t.Errorf("RedactedData() = %s, want nil", got)
+				}
+				return
+			}
+			if !json.Valid(got) || string(got) != tt.want {
+				t.Errorf("RedactedData() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
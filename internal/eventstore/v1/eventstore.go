@@ -18,13 +18,20 @@ type Eventstore interface {
 
 var _ Eventstore = (*eventstore)(nil)
 
+// PersonalDataDecrypter decrypts the personal data of the event payloads (implemented by the v2 eventstore)
+type PersonalDataDecrypter interface {
+	PersonalDataDecryption() func(instanceID, aggregateID, eventType string, data []byte) ([]byte, error)
+}
+
 type eventstore struct {
-	repo repository.Repository
+	repo         repository.Repository
+	personalData PersonalDataDecrypter
 }
 
-func Start(db *sql.DB) (Eventstore, error) {
+func Start(db *sql.DB, personalData PersonalDataDecrypter) (Eventstore, error) {
 	return &eventstore{
-		repo: z_sql.Start(db),
+		repo:         z_sql.Start(db),
+		personalData: personalData,
 	}, nil
 }
 
@@ -32,7 +39,18 @@ func (es *eventstore) FilterEvents(ctx context.Context, searchQuery *models.Sear
 	if err := searchQuery.Validate(); err != nil {
 		return nil, err
 	}
-	return es.repo.Filter(ctx, models.FactoryFromSearchQuery(searchQuery))
+	events, err := es.repo.Filter(ctx, models.FactoryFromSearchQuery(searchQuery))
+	if err != nil || es.personalData == nil {
+		return events, err
+	}
+	decrypt := es.personalData.PersonalDataDecryption()
+	for _, event := range events {
+		event.Data, err = decrypt(event.InstanceID, event.AggregateID, string(event.Type), event.Data)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return events, nil
 }
 
 func (es *eventstore) Health(ctx context.Context) error {
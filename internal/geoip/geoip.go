@@ -0,0 +1,127 @@
+// Package geoip resolves the country of an IP address using a local database file.
+package geoip
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+type Config struct {
+	// Path to a CSV file with one network per line,
+	// either as `network,country` in CIDR notation
+	// or as `first ip,last ip,country` (e.g. the country lite database of db-ip.com)
+	Path string
+}
+
+// Database is an in memory lookup table of IP ranges to ISO 3166-1 alpha-2 country codes
+type Database struct {
+	ranges []ipRange
+}
+
+type ipRange struct {
+	first   net.IP
+	last    net.IP
+	country string
+}
+
+// Load reads the database file of the config,
+// if no path is configured nil is returned which resolves every IP to an unknown country
+func Load(config Config) (*Database, error) {
+	if config.Path == "" {
+		return nil, nil
+	}
+	file, err := os.Open(config.Path)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "GEOIP-Ksd2f", "unable to open geoip database")
+	}
+	defer file.Close()
+	return Read(file)
+}
+
+// Read parses the database from CSV, a header line is skipped
+func Read(r io.Reader) (*Database, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+
+	db := new(Database)
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.ThrowInternal(err, "GEOIP-Pk3s9", "unable to read geoip database")
+		}
+		ipRange, ok := parseRecord(record)
+		if !ok {
+			if line == 1 {
+				continue
+			}
+			return nil, errors.ThrowInternalf(nil, "GEOIP-Lw0fs", "invalid geoip database entry on line %d", line)
+		}
+		db.ranges = append(db.ranges, ipRange)
+	}
+	sort.Slice(db.ranges, func(i, j int) bool {
+		return bytes.Compare(db.ranges[i].first, db.ranges[j].first) < 0
+	})
+	return db, nil
+}
+
+func parseRecord(record []string) (ipRange, bool) {
+	switch len(record) {
+	case 2:
+		_, network, err := net.ParseCIDR(record[0])
+		if err != nil {
+			return ipRange{}, false
+		}
+		first := network.IP.To16()
+		last := make(net.IP, len(first))
+		mask := network.Mask
+		if len(mask) == net.IPv4len {
+			mask = append(net.CIDRMask(96, 128)[:net.IPv6len-net.IPv4len], mask...)
+		}
+		for i := range first {
+			last[i] = first[i] | ^mask[i]
+		}
+		return ipRange{first: first, last: last, country: normalizeCountry(record[1])}, true
+	case 3:
+		first, last := net.ParseIP(record[0]).To16(), net.ParseIP(record[1]).To16()
+		if first == nil || last == nil || bytes.Compare(first, last) > 0 {
+			return ipRange{}, false
+		}
+		return ipRange{first: first, last: last, country: normalizeCountry(record[2])}, true
+	default:
+		return ipRange{}, false
+	}
+}
+
+func normalizeCountry(country string) string {
+	return strings.ToUpper(strings.TrimSpace(country))
+}
+
+// Country returns the country code of the ip or an empty string if it's unknown
+func (db *Database) Country(ip net.IP) string {
+	if db == nil || ip == nil {
+		return ""
+	}
+	ip = ip.To16()
+	i := sort.Search(len(db.ranges), func(i int) bool {
+		return bytes.Compare(db.ranges[i].first, ip) > 0
+	})
+	if i == 0 {
+		return ""
+	}
+	if bytes.Compare(ip, db.ranges[i-1].last) > 0 {
+		return ""
+	}
+	return db.ranges[i-1].country
+}
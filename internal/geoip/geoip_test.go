@@ -0,0 +1,84 @@
+package geoip
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+func TestDatabase_Country(t *testing.T) {
+	db, err := Read(strings.NewReader(`network,country
+# comment
+10.0.0.0/8,ch
+192.168.1.0,192.168.1.255,DE
+2001:db8::/32,FR
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tests := []struct {
+		name string
+		db   *Database
+		ip   net.IP
+		want string
+	}{
+		{
+			name: "nil database",
+			db:   nil,
+			ip:   net.ParseIP("10.0.0.1"),
+			want: "",
+		},
+		{
+			name: "nil ip",
+			db:   db,
+			ip:   nil,
+			want: "",
+		},
+		{
+			name: "cidr",
+			db:   db,
+			ip:   net.ParseIP("10.255.255.255"),
+			want: "CH",
+		},
+		{
+			name: "range",
+			db:   db,
+			ip:   net.ParseIP("192.168.1.1"),
+			want: "DE",
+		},
+		{
+			name: "ipv6",
+			db:   db,
+			ip:   net.ParseIP("2001:db8::1"),
+			want: "FR",
+		},
+		{
+			name: "between ranges",
+			db:   db,
+			ip:   net.ParseIP("11.0.0.1"),
+			want: "",
+		},
+		{
+			name: "before first range",
+			db:   db,
+			ip:   net.ParseIP("1.1.1.1"),
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.db.Country(tt.ip); got != tt.want {
+				t.Errorf("Country() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRead_invalid(t *testing.T) {
+	_, err := Read(strings.NewReader("10.0.0.0/8,CH\ninvalid,CH\n"))
+	if !errors.IsInternal(err) {
+		t.Errorf("expected internal error, got %v", err)
+	}
+}
@@ -43,6 +43,7 @@ type Provider struct {
 	*oauth.Provider
 	tenant        TenantType
 	emailVerified bool
+	scopes        []string
 	options       []oauth.ProviderOpts
 }
 
@@ -63,6 +64,13 @@ func WithEmailVerified() ProviderOptions {
 	}
 }
 
+// WithScopes allows to request additional scopes. The default scopes (openid, profile and email) are always requested.
+func WithScopes(scopes ...string) ProviderOptions {
+	return func(p *Provider) {
+		p.scopes = append(p.scopes, scopes...)
+	}
+}
+
 // WithOAuthOptions allows to specify [oauth.ProviderOpts] like [oauth.WithLinkingAllowed]
 func WithOAuthOptions(opts ...oauth.ProviderOpts) ProviderOptions {
 	return func(p *Provider) {
@@ -75,12 +83,13 @@ func WithOAuthOptions(opts ...oauth.ProviderOpts) ProviderOptions {
 func New(name, clientID, clientSecret, redirectURI string, opts ...ProviderOptions) (*Provider, error) {
 	provider := &Provider{
 		tenant:  CommonTenant,
+		scopes:  []string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail},
 		options: make([]oauth.ProviderOpts, 0),
 	}
 	for _, opt := range opts {
 		opt(provider)
 	}
-	config := newConfig(provider.tenant, clientID, clientSecret, redirectURI, []string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail})
+	config := newConfig(provider.tenant, clientID, clientSecret, redirectURI, provider.scopes)
 	rp, err := oauth.New(
 		config,
 		name,
@@ -109,12 +118,32 @@ func newConfig(tenant TenantType, clientID, secret, callbackURL string, scopes [
 		Scopes: []string{oidc.ScopeOpenID},
 	}
 	if len(scopes) > 0 {
-		c.Scopes = scopes
+		c.Scopes = uniqueScopes(scopes)
 	}
 
 	return c
 }
 
+func uniqueScopes(scopes []string) []string {
+	unique := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if scope == "" || containsScope(unique, scope) {
+			continue
+		}
+		unique = append(unique, scope)
+	}
+	return unique
+}
+
+func containsScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
 // User represents the structure return on the userinfo endpoint and implements the [idp.User] interface
 //
 // AzureAD does not return an `email_verified` claim.
@@ -0,0 +1,128 @@
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// UserState is the state of a user entry in the directory
+type UserState int
+
+const (
+	UserStateActive UserState = iota
+	UserStateDisabled
+	UserStateNotFound
+)
+
+// adAccountDisabled is the ACCOUNTDISABLE flag of the userAccountControl attribute of Active Directory
+const adAccountDisabled = 0x2
+
+// connect dials the LDAP server and binds as the admin
+func (p *Provider) connect() (*ldap.Conn, error) {
+	l, err := ldap.DialURL("ldap://" + p.host + ":" + p.port)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.tls {
+		err = l.StartTLS(&tls.Config{ServerName: p.host})
+		if err != nil {
+			l.Close()
+			return nil, err
+		}
+	}
+
+	// Bind as the admin to search for users
+	err = l.Bind("cn="+p.admin+","+p.baseDN, p.password)
+	if err != nil {
+		l.Close()
+		return nil, err
+	}
+	return l, nil
+}
+
+// userAttributes returns the attributes of the user entry, which are mapped to the user
+func (p *Provider) userAttributes() []string {
+	attributes := []string{"dn"}
+	for _, attribute := range []string{
+		p.idAttribute,
+		p.firstNameAttribute,
+		p.lastNameAttribute,
+		p.displayNameAttribute,
+		p.nickNameAttribute,
+		p.preferredUsernameAttribute,
+		p.emailAttribute,
+		p.emailVerifiedAttribute,
+		p.phoneAttribute,
+		p.phoneVerifiedAttribute,
+		p.preferredLanguageAttribute,
+		p.avatarURLAttribute,
+		p.profileAttribute,
+		p.groupsAttribute,
+	} {
+		if attribute != "" {
+			attributes = append(attributes, attribute)
+		}
+	}
+	return attributes
+}
+
+// UserStates looks up the entries of the provided (mapped) user ids in the directory
+// and returns whether they are still active, disabled or not found anymore.
+// Ids matching multiple entries are considered active, as they cannot be assigned unambiguously.
+func (p *Provider) UserStates(_ context.Context, ids ...string) (map[string]UserState, error) {
+	l, err := p.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer l.Close()
+
+	attributes := []string{"dn"}
+	if p.disabledAttribute != "" {
+		attributes = append(attributes, p.disabledAttribute)
+	}
+	states := make(map[string]UserState, len(ids))
+	for _, id := range ids {
+		searchRequest := ldap.NewSearchRequest(
+			p.baseDN,
+			ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+			fmt.Sprintf("(&(objectClass="+p.userObjectClass+")("+p.idAttribute+"=%s))", ldap.EscapeFilter(id)),
+			attributes,
+			nil,
+		)
+		sr, err := l.Search(searchRequest)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case len(sr.Entries) == 0:
+			states[id] = UserStateNotFound
+		case len(sr.Entries) == 1 && p.disabledAttribute != "" && isDisabled(p.disabledAttribute, sr.Entries[0].GetAttributeValue(p.disabledAttribute)):
+			states[id] = UserStateDisabled
+		default:
+			states[id] = UserStateActive
+		}
+	}
+	return states, nil
+}
+
+// isDisabled checks the value of the configured disabled attribute:
+//   - boolean values (e.g. nsAccountLock: TRUE) are taken as is
+//   - the userAccountControl of Active Directory is checked for the ACCOUNTDISABLE flag
+//   - any other non-empty value (e.g. pwdAccountLockedTime of OpenLDAP) marks the entry as disabled
+func isDisabled(attribute, value string) bool {
+	value = strings.TrimSpace(value)
+	if disabled, err := strconv.ParseBool(value); err == nil {
+		return disabled
+	}
+	if strings.EqualFold(attribute, "userAccountControl") {
+		flags, err := strconv.Atoi(value)
+		return err == nil && flags&adAccountDisabled != 0
+	}
+	return value != ""
+}
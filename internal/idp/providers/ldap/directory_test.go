@@ -0,0 +1,89 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_isDisabled(t *testing.T) {
+	type args struct {
+		attribute string
+		value     string
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "empty value",
+			args: args{
+				attribute: "nsAccountLock",
+				value:     "",
+			},
+			want: false,
+		},
+		{
+			name: "boolean true",
+			args: args{
+				attribute: "nsAccountLock",
+				value:     "TRUE",
+			},
+			want: true,
+		},
+		{
+			name: "boolean false",
+			args: args{
+				attribute: "nsAccountLock",
+				value:     "false",
+			},
+			want: false,
+		},
+		{
+			name: "userAccountControl enabled",
+			args: args{
+				attribute: "userAccountControl",
+				value:     "512",
+			},
+			want: false,
+		},
+		{
+			name: "userAccountControl disabled",
+			args: args{
+				attribute: "userAccountControl",
+				value:     "514",
+			},
+			want: true,
+		},
+		{
+			name: "userAccountControl invalid",
+			args: args{
+				attribute: "userAccountControl",
+				value:     "invalid",
+			},
+			want: false,
+		},
+		{
+			name: "other value present",
+			args: args{
+				attribute: "pwdAccountLockedTime",
+				value:     "20230101000000Z",
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isDisabled(tt.args.attribute, tt.args.value))
+		})
+	}
+}
+
+func TestProvider_userAttributes(t *testing.T) {
+	provider := New("ldap", "host", "base", "class", "uid", "admin", "password", "url",
+		WithEmailAttribute("mail"),
+		WithGroupsAttribute("memberOf"),
+	)
+	assert.Equal(t, []string{"dn", "uid", "mail", "memberOf"}, provider.userAttributes())
+}
@@ -41,6 +41,8 @@ type Provider struct {
 	preferredLanguageAttribute string
 	avatarURLAttribute         string
 	profileAttribute           string
+	groupsAttribute            string
+	disabledAttribute          string
 }
 
 type ProviderOpts func(provider *Provider)
@@ -179,6 +181,20 @@ func WithProfileAttribute(name string) ProviderOpts {
 	}
 }
 
+// WithGroupsAttribute configures to map the LDAP attribute (e.g. memberOf) containing the DNs of the groups to the user
+func WithGroupsAttribute(name string) ProviderOpts {
+	return func(p *Provider) {
+		p.groupsAttribute = name
+	}
+}
+
+// WithDisabledAttribute configures the LDAP attribute, which marks the user entry as disabled (see [isDisabled])
+func WithDisabledAttribute(name string) ProviderOpts {
+	return func(p *Provider) {
+		p.disabledAttribute = name
+	}
+}
+
 func New(
 	name string,
 	host string,
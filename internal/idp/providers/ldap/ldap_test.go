@@ -39,6 +39,8 @@ func TestProvider_Options(t *testing.T) {
 		preferredLanguageAttribute string
 		avatarURLAttribute         string
 		profileAttribute           string
+		groupsAttribute            string
+		disabledAttribute          string
 	}
 	tests := []struct {
 		name   string
@@ -128,6 +130,8 @@ func TestProvider_Options(t *testing.T) {
 					WithPreferredLanguageAttribute("prefLang"),
 					WithAvatarURLAttribute("avatar"),
 					WithProfileAttribute("profile"),
+					WithGroupsAttribute("memberOf"),
+					WithDisabledAttribute("nsAccountLock"),
 				},
 			},
 			want: want{
@@ -151,6 +155,8 @@ func TestProvider_Options(t *testing.T) {
 				preferredLanguageAttribute: "prefLang",
 				avatarURLAttribute:         "avatar",
 				profileAttribute:           "profile",
+				groupsAttribute:            "memberOf",
+				disabledAttribute:          "nsAccountLock",
 			},
 		},
 	}
@@ -180,6 +186,8 @@ func TestProvider_Options(t *testing.T) {
 			a.Equal(tt.want.preferredLanguageAttribute, provider.preferredLanguageAttribute)
 			a.Equal(tt.want.avatarURLAttribute, provider.avatarURLAttribute)
 			a.Equal(tt.want.profileAttribute, provider.profileAttribute)
+			a.Equal(tt.want.groupsAttribute, provider.groupsAttribute)
+			a.Equal(tt.want.disabledAttribute, provider.disabledAttribute)
 		})
 	}
 }
@@ -2,7 +2,6 @@ package ldap
 
 import (
 	"context"
-	"crypto/tls"
 	"errors"
 	"fmt"
 	"strconv"
@@ -17,42 +16,30 @@ var ErrNoSingleUser = errors.New("user does not exist or too many entries return
 
 var _ idp.Session = (*Session)(nil)
 
+// Session of an LDAP provider, the user is authenticated with the username and password entered on the login
 type Session struct {
 	Provider *Provider
 	loginUrl string
-	user     string
-	password string
+	User     string
+	Password string
 }
 
 func (s *Session) GetAuthURL() string {
 	return s.loginUrl
 }
 func (s *Session) FetchUser(_ context.Context) (idp.User, error) {
-	l, err := ldap.DialURL("ldap://" + s.Provider.host + ":" + s.Provider.port)
+	l, err := s.Provider.connect()
 	if err != nil {
 		return nil, err
 	}
 	defer l.Close()
 
-	if s.Provider.tls {
-		err = l.StartTLS(&tls.Config{ServerName: s.Provider.host})
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	// Bind as the admin to search for user
-	err = l.Bind("cn="+s.Provider.admin+","+s.Provider.baseDN, s.Provider.password)
-	if err != nil {
-		return nil, err
-	}
-
 	// Search for user with the unique attribute for the userDN
 	searchRequest := ldap.NewSearchRequest(
 		s.Provider.baseDN,
 		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
-		fmt.Sprintf("(&(objectClass="+s.Provider.userObjectClass+")("+s.Provider.userUniqueAttribute+"=%s))", ldap.EscapeFilter(s.user)),
-		[]string{"dn"},
+		fmt.Sprintf("(&(objectClass="+s.Provider.userObjectClass+")("+s.Provider.userUniqueAttribute+"=%s))", ldap.EscapeFilter(s.User)),
+		s.Provider.userAttributes(),
 		nil,
 	)
 
@@ -66,16 +53,16 @@ func (s *Session) FetchUser(_ context.Context) (idp.User, error) {
 
 	user := sr.Entries[0]
 	// Bind as the user to verify their password
-	err = l.Bind(user.DN, s.password)
+	err = l.Bind(user.DN, s.Password)
 	if err != nil {
 		return nil, err
 	}
 
-	emailVerified, err := strconv.ParseBool(user.GetAttributeValue(s.Provider.emailVerifiedAttribute))
+	emailVerified, err := parseBoolAttribute(user.GetAttributeValue(s.Provider.emailVerifiedAttribute))
 	if err != nil {
 		return nil, err
 	}
-	phoneVerified, err := strconv.ParseBool(user.GetAttributeValue(s.Provider.phoneVerifiedAttribute))
+	phoneVerified, err := parseBoolAttribute(user.GetAttributeValue(s.Provider.phoneVerifiedAttribute))
 	if err != nil {
 		return nil, err
 	}
@@ -94,5 +81,14 @@ func (s *Session) FetchUser(_ context.Context) (idp.User, error) {
 		language.Make(user.GetAttributeValue(s.Provider.preferredLanguageAttribute)),
 		user.GetAttributeValue(s.Provider.avatarURLAttribute),
 		user.GetAttributeValue(s.Provider.profileAttribute),
+		user.GetAttributeValues(s.Provider.groupsAttribute),
 	), nil
 }
+
+// parseBoolAttribute returns false for a missing (or not mapped) attribute
+func parseBoolAttribute(value string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
@@ -16,6 +16,7 @@ type User struct {
 	preferredLanguage language.Tag
 	avatarURL         string
 	profile           string
+	groups            []string
 }
 
 func NewUser(
@@ -32,6 +33,7 @@ func NewUser(
 	preferredLanguage language.Tag,
 	avatarURL string,
 	profile string,
+	groups []string,
 ) *User {
 	return &User{
 		id,
@@ -47,6 +49,7 @@ func NewUser(
 		preferredLanguage,
 		avatarURL,
 		profile,
+		groups,
 	}
 }
 
@@ -89,3 +92,9 @@ func (u *User) GetAvatarURL() string {
 func (u *User) GetProfile() string {
 	return u.profile
 }
+
+// GetGroups returns the DNs of the groups the user is member of,
+// if the groups attribute is configured on the provider
+func (u *User) GetGroups() []string {
+	return u.groups
+}
@@ -15,6 +15,7 @@ var _ idp.Provider = (*Provider)(nil)
 type Provider struct {
 	rp.RelyingParty
 	options           []rp.Option
+	scopes            []string
 	name              string
 	isLinkingAllowed  bool
 	isCreationAllowed bool
@@ -61,6 +62,13 @@ func WithRelyingPartyOption(option rp.Option) ProviderOpts {
 	}
 }
 
+// WithScopes allows to request additional scopes. The `openid` scope is always requested.
+func WithScopes(scopes ...string) ProviderOpts {
+	return func(p *Provider) {
+		p.scopes = append(p.scopes, scopes...)
+	}
+}
+
 type UserInfoMapper func(info oidc.UserInfo) idp.User
 
 var DefaultMapper UserInfoMapper = func(info oidc.UserInfo) idp.User {
@@ -71,12 +79,13 @@ var DefaultMapper UserInfoMapper = func(info oidc.UserInfo) idp.User {
 func New(name, issuer, clientID, clientSecret, redirectURI string, userInfoMapper UserInfoMapper, options ...ProviderOpts) (provider *Provider, err error) {
 	provider = &Provider{
 		name:           name,
+		scopes:         []string{oidc.ScopeOpenID},
 		userInfoMapper: userInfoMapper,
 	}
 	for _, option := range options {
 		option(provider)
 	}
-	provider.RelyingParty, err = rp.NewRelyingPartyOIDC(issuer, clientID, clientSecret, redirectURI, []string{oidc.ScopeOpenID}, provider.options...)
+	provider.RelyingParty, err = rp.NewRelyingPartyOIDC(issuer, clientID, clientSecret, redirectURI, uniqueScopes(provider.scopes), provider.options...)
 	if err != nil {
 		return nil, err
 	}
@@ -114,3 +123,23 @@ func (p *Provider) IsAutoCreation() bool {
 func (p *Provider) IsAutoUpdate() bool {
 	return p.isAutoUpdate
 }
+
+func uniqueScopes(scopes []string) []string {
+	unique := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if scope == "" || containsScope(unique, scope) {
+			continue
+		}
+		unique = append(unique, scope)
+	}
+	return unique
+}
+
+func containsScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
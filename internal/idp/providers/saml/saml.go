@@ -0,0 +1,302 @@
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/beevik/etree"
+	"github.com/zitadel/saml/pkg/provider/signature"
+	saml_xml "github.com/zitadel/saml/pkg/provider/xml"
+	"github.com/zitadel/saml/pkg/provider/xml/md"
+
+	zcrypto "github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/idp"
+)
+
+const (
+	BindingRedirect = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+	BindingPost     = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+
+	namespaceProtocol   = "urn:oasis:names:tc:SAML:2.0:protocol"
+	namespaceAssertion  = "urn:oasis:names:tc:SAML:2.0:assertion"
+	namespaceMetadata   = "urn:oasis:names:tc:SAML:2.0:metadata"
+	namespaceSignature  = "http://www.w3.org/2000/09/xmldsig#"
+	namespaceEncryption = "http://www.w3.org/2001/04/xmlenc#"
+
+	signatureAlgorithmRSASHA256 = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"
+	nameIDFormatUnspecified     = "urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified"
+
+	requestIDPrefix = "id-"
+	timeFormat      = "2006-01-02T15:04:05.000Z"
+)
+
+var _ idp.Provider = (*Provider)(nil)
+
+var (
+	ErrInvalidMetadata      = errors.New("invalid identity provider metadata")
+	ErrNoRedirectBinding    = errors.New("identity provider does not support the HTTP-Redirect binding")
+	ErrNoSigningCertificate = errors.New("identity provider metadata does not contain a signing certificate")
+	ErrInvalidCertificate   = errors.New("invalid certificate")
+)
+
+// Provider is the [idp.Provider] implementation for a SAML 2.0 identity provider,
+// where ZITADEL acts as service provider
+type Provider struct {
+	name              string
+	entityID          string
+	acsURL            string
+	idpEntityID       string
+	ssoURL            string
+	idpCertificates   []*x509.Certificate
+	certificate       *x509.Certificate
+	key               *rsa.PrivateKey
+	withSignedRequest bool
+
+	isLinkingAllowed  bool
+	isCreationAllowed bool
+	isAutoCreation    bool
+	isAutoUpdate      bool
+}
+
+type ProviderOpts func(provider *Provider)
+
+// WithLinkingAllowed allows end users to link the federated user to an existing one
+func WithLinkingAllowed() ProviderOpts {
+	return func(p *Provider) {
+		p.isLinkingAllowed = true
+	}
+}
+
+// WithCreationAllowed allows end users to create a new user using the federated information
+func WithCreationAllowed() ProviderOpts {
+	return func(p *Provider) {
+		p.isCreationAllowed = true
+	}
+}
+
+// WithAutoCreation enables that federated users are automatically created if not already existing
+func WithAutoCreation() ProviderOpts {
+	return func(p *Provider) {
+		p.isAutoCreation = true
+	}
+}
+
+// WithAutoUpdate enables that information retrieved from the provider is automatically used to update
+// the existing user on each authentication
+func WithAutoUpdate() ProviderOpts {
+	return func(p *Provider) {
+		p.isAutoUpdate = true
+	}
+}
+
+// WithSignedRequest enables that the authentication requests are signed with the key of the provider
+func WithSignedRequest() ProviderOpts {
+	return func(p *Provider) {
+		p.withSignedRequest = true
+	}
+}
+
+// New creates a SAML provider.
+// The entityID identifies ZITADEL as service provider (typically the URL of its metadata)
+// and the acsURL is the endpoint the identity provider posts its responses to.
+// The metadata is the one of the identity provider and the PEM encoded certificate and key are used
+// to sign the requests and decrypt the assertions.
+func New(name, entityID, acsURL string, metadata, certificate, key []byte, options ...ProviderOpts) (*Provider, error) {
+	entity := new(md.EntityDescriptorType)
+	if err := xml.Unmarshal(metadata, entity); err != nil || entity.IDPSSODescriptor == nil {
+		return nil, ErrInvalidMetadata
+	}
+	ssoURL := ""
+	for _, service := range entity.IDPSSODescriptor.SingleSignOnService {
+		if service.Binding == BindingRedirect {
+			ssoURL = service.Location
+			break
+		}
+	}
+	if ssoURL == "" {
+		return nil, ErrNoRedirectBinding
+	}
+	idpCertificates, err := signature.ParseCertificates(saml_xml.GetCertsFromKeyDescriptors(entity.IDPSSODescriptor.KeyDescriptor))
+	if err != nil {
+		return nil, ErrInvalidMetadata
+	}
+	if len(idpCertificates) == 0 {
+		return nil, ErrNoSigningCertificate
+	}
+	block, _ := pem.Decode(certificate)
+	if block == nil {
+		return nil, ErrInvalidCertificate
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, ErrInvalidCertificate
+	}
+	privateKey, err := zcrypto.BytesToPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	provider := &Provider{
+		name:            name,
+		entityID:        entityID,
+		acsURL:          acsURL,
+		idpEntityID:     string(entity.EntityID),
+		ssoURL:          ssoURL,
+		idpCertificates: idpCertificates,
+		certificate:     cert,
+		key:             privateKey,
+	}
+	for _, option := range options {
+		option(provider)
+	}
+	return provider, nil
+}
+
+// Name implements the [idp.Provider] interface
+func (p *Provider) Name() string {
+	return p.name
+}
+
+// BeginAuth implements the [idp.Provider] interface.
+// It will create a [Session] with an AuthnRequest using the HTTP-Redirect binding as AuthURL.
+// The state will be sent as RelayState and is used to derive the ID of the request.
+func (p *Provider) BeginAuth(ctx context.Context, state string, _ ...any) (idp.Session, error) {
+	requestID := RequestID(state)
+	request, err := p.authnRequest(requestID, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	authURL, err := p.redirectURL(request, state)
+	if err != nil {
+		return nil, err
+	}
+	return &Session{
+		AuthURL:   authURL,
+		RequestID: requestID,
+		Provider:  p,
+	}, nil
+}
+
+// IsLinkingAllowed implements the [idp.Provider] interface
+func (p *Provider) IsLinkingAllowed() bool {
+	return p.isLinkingAllowed
+}
+
+// IsCreationAllowed implements the [idp.Provider] interface
+func (p *Provider) IsCreationAllowed() bool {
+	return p.isCreationAllowed
+}
+
+// IsAutoCreation implements the [idp.Provider] interface
+func (p *Provider) IsAutoCreation() bool {
+	return p.isAutoCreation
+}
+
+// IsAutoUpdate implements the [idp.Provider] interface
+func (p *Provider) IsAutoUpdate() bool {
+	return p.isAutoUpdate
+}
+
+// Metadata returns the service provider metadata, which can be registered on the identity provider
+func (p *Provider) Metadata() ([]byte, error) {
+	doc := etree.NewDocument()
+	doc.CreateProcInst("xml", `version="1.0" encoding="UTF-8"`)
+	entity := doc.CreateElement("md:EntityDescriptor")
+	entity.CreateAttr("xmlns:md", namespaceMetadata)
+	entity.CreateAttr("xmlns:ds", namespaceSignature)
+	entity.CreateAttr("entityID", p.entityID)
+
+	descriptor := entity.CreateElement("md:SPSSODescriptor")
+	descriptor.CreateAttr("AuthnRequestsSigned", strconv.FormatBool(p.withSignedRequest))
+	descriptor.CreateAttr("WantAssertionsSigned", "true")
+	descriptor.CreateAttr("protocolSupportEnumeration", namespaceProtocol)
+
+	certificate := base64.StdEncoding.EncodeToString(p.certificate.Raw)
+	for _, use := range []string{"signing", "encryption"} {
+		keyDescriptor := descriptor.CreateElement("md:KeyDescriptor")
+		keyDescriptor.CreateAttr("use", use)
+		keyDescriptor.CreateElement("ds:KeyInfo").
+			CreateElement("ds:X509Data").
+			CreateElement("ds:X509Certificate").
+			SetText(certificate)
+	}
+	descriptor.CreateElement("md:NameIDFormat").SetText(nameIDFormatUnspecified)
+
+	acs := descriptor.CreateElement("md:AssertionConsumerService")
+	acs.CreateAttr("Binding", BindingPost)
+	acs.CreateAttr("Location", p.acsURL)
+	acs.CreateAttr("index", "0")
+	acs.CreateAttr("isDefault", "true")
+
+	doc.Indent(2)
+	return doc.WriteToBytes()
+}
+
+// RequestID returns the ID of the AuthnRequest created for the provided state
+func RequestID(state string) string {
+	return requestIDPrefix + state
+}
+
+func (p *Provider) authnRequest(id string, issueInstant time.Time) ([]byte, error) {
+	doc := etree.NewDocument()
+	request := doc.CreateElement("samlp:AuthnRequest")
+	request.CreateAttr("xmlns:samlp", namespaceProtocol)
+	request.CreateAttr("xmlns:saml", namespaceAssertion)
+	request.CreateAttr("ID", id)
+	request.CreateAttr("Version", "2.0")
+	request.CreateAttr("IssueInstant", issueInstant.Format(timeFormat))
+	request.CreateAttr("Destination", p.ssoURL)
+	request.CreateAttr("ProtocolBinding", BindingPost)
+	request.CreateAttr("AssertionConsumerServiceURL", p.acsURL)
+	request.CreateElement("saml:Issuer").SetText(p.entityID)
+	nameIDPolicy := request.CreateElement("samlp:NameIDPolicy")
+	nameIDPolicy.CreateAttr("AllowCreate", "true")
+	return doc.WriteToBytes()
+}
+
+// redirectURL encodes the request according to the HTTP-Redirect binding
+// and signs the query if configured
+func (p *Provider) redirectURL(request []byte, relayState string) (string, error) {
+	var deflated bytes.Buffer
+	writer, err := flate.NewWriter(&deflated, flate.DefaultCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err = writer.Write(request); err != nil {
+		return "", err
+	}
+	if err = writer.Close(); err != nil {
+		return "", err
+	}
+
+	query := "SAMLRequest=" + url.QueryEscape(base64.StdEncoding.EncodeToString(deflated.Bytes()))
+	if relayState != "" {
+		query += "&RelayState=" + url.QueryEscape(relayState)
+	}
+	if p.withSignedRequest {
+		query += "&SigAlg=" + url.QueryEscape(signatureAlgorithmRSASHA256)
+		digest := sha256.Sum256([]byte(query))
+		signed, err := rsa.SignPKCS1v15(rand.Reader, p.key, crypto.SHA256, digest[:])
+		if err != nil {
+			return "", err
+		}
+		query += "&Signature=" + url.QueryEscape(base64.StdEncoding.EncodeToString(signed))
+	}
+	if strings.Contains(p.ssoURL, "?") {
+		return p.ssoURL + "&" + query, nil
+	}
+	return p.ssoURL + "?" + query, nil
+}
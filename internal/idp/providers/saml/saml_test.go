@@ -0,0 +1,313 @@
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
+	"io"
+	"math/big"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/saml/pkg/provider/xml/md"
+
+	zcrypto "github.com/zitadel/zitadel/internal/crypto"
+)
+
+const (
+	testIDPEntityID = "https://idp.example.com/metadata"
+	testSSOURL      = "https://idp.example.com/sso"
+	testEntityID    = "https://zitadel.example.com/idps/saml/metadata"
+	testACSURL      = "https://zitadel.example.com/idps/saml/acs"
+)
+
+func TestProvider_New(t *testing.T) {
+	idpKeyStore := dsig.RandomKeyStoreForTest()
+	certificate, key := testCertificateAndKey(t)
+	type args struct {
+		metadata    []byte
+		certificate []byte
+		key         []byte
+	}
+	tests := []struct {
+		name string
+		args args
+		err  error
+	}{
+		{
+			name: "invalid metadata, error",
+			args: args{
+				metadata:    []byte("invalid"),
+				certificate: certificate,
+				key:         key,
+			},
+			err: ErrInvalidMetadata,
+		},
+		{
+			name: "no redirect binding, error",
+			args: args{
+				metadata:    testIDPMetadata(t, idpKeyStore, BindingPost),
+				certificate: certificate,
+				key:         key,
+			},
+			err: ErrNoRedirectBinding,
+		},
+		{
+			name: "no signing certificate, error",
+			args: args{
+				metadata:    testIDPMetadata(t, nil, BindingRedirect),
+				certificate: certificate,
+				key:         key,
+			},
+			err: ErrNoSigningCertificate,
+		},
+		{
+			name: "invalid certificate, error",
+			args: args{
+				metadata:    testIDPMetadata(t, idpKeyStore, BindingRedirect),
+				certificate: []byte("invalid"),
+				key:         key,
+			},
+			err: ErrInvalidCertificate,
+		},
+		{
+			name: "successful",
+			args: args{
+				metadata:    testIDPMetadata(t, idpKeyStore, BindingRedirect),
+				certificate: certificate,
+				key:         key,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := New("saml", testEntityID, testACSURL, tt.args.metadata, tt.args.certificate, tt.args.key)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testIDPEntityID, provider.idpEntityID)
+			assert.Equal(t, testSSOURL, provider.ssoURL)
+			assert.Len(t, provider.idpCertificates, 1)
+		})
+	}
+}
+
+func TestProvider_BeginAuth(t *testing.T) {
+	idpKeyStore := dsig.RandomKeyStoreForTest()
+	certificate, key := testCertificateAndKey(t)
+	type fields struct {
+		opts []ProviderOpts
+	}
+	type want struct {
+		requestID string
+		signed    bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   want
+	}{
+		{
+			name: "unsigned request",
+			want: want{
+				requestID: "id-testState",
+			},
+		},
+		{
+			name: "signed request",
+			fields: fields{
+				opts: []ProviderOpts{WithSignedRequest()},
+			},
+			want: want{
+				requestID: "id-testState",
+				signed:    true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			r := require.New(t)
+
+			provider, err := New("saml", testEntityID, testACSURL, testIDPMetadata(t, idpKeyStore, BindingRedirect), certificate, key, tt.fields.opts...)
+			r.NoError(err)
+
+			session, err := provider.BeginAuth(context.Background(), "testState")
+			r.NoError(err)
+			a.Equal(tt.want.requestID, session.(*Session).RequestID)
+
+			authURL, err := url.Parse(session.GetAuthURL())
+			r.NoError(err)
+			a.Equal(testSSOURL, authURL.Scheme+"://"+authURL.Host+authURL.Path)
+			query := authURL.Query()
+			a.Equal("testState", query.Get("RelayState"))
+
+			request := inflateRequest(t, query.Get("SAMLRequest"))
+			a.Equal(tt.want.requestID, request.SelectAttrValue("ID", ""))
+			a.Equal(testACSURL, request.SelectAttrValue("AssertionConsumerServiceURL", ""))
+			a.Equal(testEntityID, request.FindElement("./Issuer").Text())
+
+			if !tt.want.signed {
+				a.Empty(query.Get("Signature"))
+				return
+			}
+			a.Equal(signatureAlgorithmRSASHA256, query.Get("SigAlg"))
+			signed := strings.SplitN(authURL.RawQuery, "&Signature=", 2)[0]
+			signature, err := base64.StdEncoding.DecodeString(query.Get("Signature"))
+			r.NoError(err)
+			digest := sha256.Sum256([]byte(signed))
+			a.NoError(rsa.VerifyPKCS1v15(&provider.key.PublicKey, crypto.SHA256, digest[:], signature))
+		})
+	}
+}
+
+func TestProvider_Options(t *testing.T) {
+	idpKeyStore := dsig.RandomKeyStoreForTest()
+	certificate, key := testCertificateAndKey(t)
+	type want struct {
+		name            string
+		linkingAllowed  bool
+		creationAllowed bool
+		autoCreation    bool
+		autoUpdate      bool
+		signedRequest   bool
+	}
+	tests := []struct {
+		name string
+		opts []ProviderOpts
+		want want
+	}{
+		{
+			name: "default",
+			want: want{
+				name: "saml",
+			},
+		},
+		{
+			name: "all true",
+			opts: []ProviderOpts{
+				WithLinkingAllowed(),
+				WithCreationAllowed(),
+				WithAutoCreation(),
+				WithAutoUpdate(),
+				WithSignedRequest(),
+			},
+			want: want{
+				name:            "saml",
+				linkingAllowed:  true,
+				creationAllowed: true,
+				autoCreation:    true,
+				autoUpdate:      true,
+				signedRequest:   true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			provider, err := New("saml", testEntityID, testACSURL, testIDPMetadata(t, idpKeyStore, BindingRedirect), certificate, key, tt.opts...)
+			require.NoError(t, err)
+
+			a.Equal(tt.want.name, provider.Name())
+			a.Equal(tt.want.linkingAllowed, provider.IsLinkingAllowed())
+			a.Equal(tt.want.creationAllowed, provider.IsCreationAllowed())
+			a.Equal(tt.want.autoCreation, provider.IsAutoCreation())
+			a.Equal(tt.want.autoUpdate, provider.IsAutoUpdate())
+			a.Equal(tt.want.signedRequest, provider.withSignedRequest)
+		})
+	}
+}
+
+func TestProvider_Metadata(t *testing.T) {
+	certificate, key := testCertificateAndKey(t)
+	provider, err := New("saml", testEntityID, testACSURL, testIDPMetadata(t, dsig.RandomKeyStoreForTest(), BindingRedirect), certificate, key, WithSignedRequest())
+	require.NoError(t, err)
+
+	metadata, err := provider.Metadata()
+	require.NoError(t, err)
+
+	entity := new(md.EntityDescriptorType)
+	require.NoError(t, xml.Unmarshal(metadata, entity))
+	assert.Equal(t, testEntityID, string(entity.EntityID))
+	require.NotNil(t, entity.SPSSODescriptor)
+	assert.Equal(t, "true", entity.SPSSODescriptor.AuthnRequestsSigned)
+	require.Len(t, entity.SPSSODescriptor.AssertionConsumerService, 1)
+	assert.Equal(t, BindingPost, entity.SPSSODescriptor.AssertionConsumerService[0].Binding)
+	assert.Equal(t, testACSURL, entity.SPSSODescriptor.AssertionConsumerService[0].Location)
+	require.Len(t, entity.SPSSODescriptor.KeyDescriptor, 2)
+	assert.Equal(t,
+		base64.StdEncoding.EncodeToString(provider.certificate.Raw),
+		entity.SPSSODescriptor.KeyDescriptor[0].KeyInfo.X509Data[0].X509Certificate,
+	)
+}
+
+// testCertificateAndKey returns the PEM encoded certificate and key of the service provider
+func testCertificateAndKey(t *testing.T) ([]byte, []byte) {
+	privateKey, publicKey, err := zcrypto.GenerateKeyPair(2048)
+	require.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{Organization: []string{"ZITADEL"}},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+	}
+	certificate, err := x509.CreateCertificate(rand.Reader, &template, &template, publicKey, privateKey)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate}), zcrypto.PrivateKeyToBytes(privateKey)
+}
+
+// testIDPMetadata returns the metadata of an identity provider with the signing certificate of the key store (if any)
+func testIDPMetadata(t *testing.T, keyStore dsig.X509KeyStore, binding string) []byte {
+	doc := etree.NewDocument()
+	entity := doc.CreateElement("md:EntityDescriptor")
+	entity.CreateAttr("xmlns:md", namespaceMetadata)
+	entity.CreateAttr("xmlns:ds", namespaceSignature)
+	entity.CreateAttr("entityID", testIDPEntityID)
+	descriptor := entity.CreateElement("md:IDPSSODescriptor")
+	descriptor.CreateAttr("protocolSupportEnumeration", namespaceProtocol)
+	if keyStore != nil {
+		_, certificate, err := keyStore.GetKeyPair()
+		require.NoError(t, err)
+		keyDescriptor := descriptor.CreateElement("md:KeyDescriptor")
+		keyDescriptor.CreateAttr("use", "signing")
+		keyDescriptor.CreateElement("ds:KeyInfo").
+			CreateElement("ds:X509Data").
+			CreateElement("ds:X509Certificate").
+			SetText(base64.StdEncoding.EncodeToString(certificate))
+	}
+	service := descriptor.CreateElement("md:SingleSignOnService")
+	service.CreateAttr("Binding", binding)
+	service.CreateAttr("Location", testSSOURL)
+	metadata, err := doc.WriteToBytes()
+	require.NoError(t, err)
+	return metadata
+}
+
+func inflateRequest(t *testing.T, encoded string) *etree.Element {
+	deflated, err := base64.StdEncoding.DecodeString(encoded)
+	require.NoError(t, err)
+	request, err := io.ReadAll(flate.NewReader(bytes.NewReader(deflated)))
+	require.NoError(t, err)
+	doc := etree.NewDocument()
+	require.NoError(t, doc.ReadFromBytes(request))
+	return doc.Root()
+}
@@ -0,0 +1,532 @@
+package saml
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rsa"
+	_ "crypto/sha1"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/russellhaering/goxmldsig/etreeutils"
+	"github.com/zitadel/saml/pkg/provider/xml/saml"
+
+	"github.com/zitadel/zitadel/internal/idp"
+)
+
+const (
+	namespaceEncryption11 = "http://www.w3.org/2009/xmlenc11#"
+
+	statusSuccess             = "urn:oasis:names:tc:SAML:2.0:status:Success"
+	subjectConfirmationBearer = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+
+	keyTransportRSAOAEPMGF1P = namespaceEncryption + "rsa-oaep-mgf1p"
+	keyTransportRSAOAEP      = namespaceEncryption11 + "rsa-oaep"
+	mgf1SHA1                 = namespaceEncryption11 + "mgf1sha1"
+	mgf1SHA256               = namespaceEncryption11 + "mgf1sha256"
+	mgf1SHA512               = namespaceEncryption11 + "mgf1sha512"
+	digestSHA1               = namespaceSignature + "sha1"
+	digestSHA256             = namespaceEncryption + "sha256"
+	digestSHA512             = namespaceEncryption + "sha512"
+
+	blockAES128CBC = namespaceEncryption + "aes128-cbc"
+	blockAES192CBC = namespaceEncryption + "aes192-cbc"
+	blockAES256CBC = namespaceEncryption + "aes256-cbc"
+	blockAES128GCM = namespaceEncryption11 + "aes128-gcm"
+	blockAES192GCM = namespaceEncryption11 + "aes192-gcm"
+	blockAES256GCM = namespaceEncryption11 + "aes256-gcm"
+
+	// allowedClockSkew is the tolerated time difference between ZITADEL and the identity provider
+	allowedClockSkew = 3 * time.Minute
+)
+
+var (
+	ErrMissingResponse       = errors.New("no SAML response provided")
+	ErrInvalidResponse       = errors.New("invalid SAML response")
+	ErrResponseStatus        = errors.New("SAML response is not successful")
+	ErrMissingSignature      = errors.New("neither the SAML response nor the assertion is signed")
+	ErrInvalidSignature      = errors.New("invalid signature")
+	ErrInvalidAssertion      = errors.New("invalid assertion")
+	ErrUnsupportedEncryption = errors.New("unsupported encryption of the assertion")
+)
+
+var _ idp.Session = (*Session)(nil)
+
+// Session is the [idp.Session] implementation for the SAML provider.
+type Session struct {
+	AuthURL string
+	// RequestID is the ID of the AuthnRequest, which the response has to refer to
+	RequestID string
+	// Response is the base64 encoded SAMLResponse posted by the identity provider
+	Response string
+	Provider *Provider
+}
+
+// GetAuthURL implements the [idp.Session] interface
+func (s *Session) GetAuthURL() string {
+	return s.AuthURL
+}
+
+// FetchUser implements the [idp.Session] interface.
+// It will validate the SAMLResponse (signature, status, conditions and subject confirmation),
+// decrypt the assertion if needed and map it to the [User].
+func (s *Session) FetchUser(_ context.Context) (idp.User, error) {
+	if s.Response == "" {
+		return nil, ErrMissingResponse
+	}
+	assertion, err := s.Provider.parseResponse(s.Response, s.RequestID, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	return NewUser(assertion), nil
+}
+
+func (p *Provider) parseResponse(encoded, requestID string, now time.Time) (*saml.AssertionType, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, ErrInvalidResponse
+	}
+	doc := etree.NewDocument()
+	if err := doc.ReadFromBytes(raw); err != nil {
+		return nil, ErrInvalidResponse
+	}
+	response := doc.Root()
+	if !isElement(response, namespaceProtocol, "Response") {
+		return nil, ErrInvalidResponse
+	}
+	responseSigned := childElement(response, namespaceSignature, "Signature") != nil
+	if responseSigned {
+		if response, err = p.validateSignature(response); err != nil {
+			return nil, err
+		}
+	}
+	if destination := response.SelectAttrValue("Destination", ""); destination != "" && destination != p.acsURL {
+		return nil, ErrInvalidResponse
+	}
+	if response.SelectAttrValue("InResponseTo", "") != requestID {
+		return nil, ErrInvalidResponse
+	}
+	if !isSuccess(response) {
+		return nil, ErrResponseStatus
+	}
+
+	assertion, err := p.assertionElement(response)
+	if err != nil {
+		return nil, err
+	}
+	if childElement(assertion, namespaceSignature, "Signature") != nil {
+		if assertion, err = p.validateSignature(assertion); err != nil {
+			return nil, err
+		}
+	} else if !responseSigned {
+		return nil, ErrMissingSignature
+	}
+	return p.validateAssertion(assertion, requestID, now)
+}
+
+// validateSignature verifies the enveloped signature of the element against the certificates of the identity provider
+// and returns the verified element
+func (p *Provider) validateSignature(el *etree.Element) (*etree.Element, error) {
+	ctx, err := etreeutils.NSBuildParentContext(el)
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+	ctx, err = ctx.SubContext(el)
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+	detached, err := etreeutils.NSDetatch(ctx, el)
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+	validationContext := dsig.NewDefaultValidationContext(&dsig.MemoryX509CertificateStore{Roots: p.idpCertificates})
+	validationContext.IdAttribute = "ID"
+	verified, err := validationContext.Validate(detached)
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+	return verified, nil
+}
+
+// assertionElement returns the single (decrypted) assertion of the response
+func (p *Provider) assertionElement(response *etree.Element) (*etree.Element, error) {
+	var assertions []*etree.Element
+	for _, child := range response.ChildElements() {
+		switch {
+		case isElement(child, namespaceAssertion, "Assertion"):
+			assertions = append(assertions, child)
+		case isElement(child, namespaceAssertion, "EncryptedAssertion"):
+			assertion, err := p.decryptAssertion(child)
+			if err != nil {
+				return nil, err
+			}
+			assertions = append(assertions, assertion)
+		}
+	}
+	if len(assertions) != 1 {
+		return nil, ErrInvalidAssertion
+	}
+	return assertions[0], nil
+}
+
+func (p *Provider) decryptAssertion(encrypted *etree.Element) (*etree.Element, error) {
+	encryptedData := childElement(encrypted, namespaceEncryption, "EncryptedData")
+	if encryptedData == nil {
+		return nil, ErrInvalidAssertion
+	}
+	encryptedKey := childElement(encrypted, namespaceEncryption, "EncryptedKey")
+	if keyInfo := childElement(encryptedData, namespaceSignature, "KeyInfo"); keyInfo != nil {
+		if key := childElement(keyInfo, namespaceEncryption, "EncryptedKey"); key != nil {
+			encryptedKey = key
+		}
+	}
+	if encryptedKey == nil {
+		return nil, ErrUnsupportedEncryption
+	}
+	key, err := p.decryptKey(encryptedKey)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := decryptData(encryptedData, key)
+	if err != nil {
+		return nil, err
+	}
+	return parseDecryptedAssertion(encrypted, plaintext)
+}
+
+// decryptKey decrypts the symmetric key of the assertion with the private key of the provider.
+// Only RSA-OAEP is supported, RSA PKCS#1 v1.5 key transport is deliberately rejected.
+func (p *Provider) decryptKey(encryptedKey *etree.Element) ([]byte, error) {
+	method := childElement(encryptedKey, namespaceEncryption, "EncryptionMethod")
+	if method == nil {
+		return nil, ErrUnsupportedEncryption
+	}
+	digest, err := digestHash(method)
+	if err != nil {
+		return nil, err
+	}
+	switch method.SelectAttrValue("Algorithm", "") {
+	case keyTransportRSAOAEPMGF1P:
+		if digest != crypto.SHA1 {
+			return nil, ErrUnsupportedEncryption
+		}
+	case keyTransportRSAOAEP:
+		mgf := mgf1SHA1
+		if mgfElement := childElement(method, namespaceEncryption11, "MGF"); mgfElement != nil {
+			mgf = mgfElement.SelectAttrValue("Algorithm", "")
+		}
+		mgfHash, err := mgf1Hash(mgf)
+		if err != nil {
+			return nil, err
+		}
+		// the standard library uses the same hash for the digest and the mask generation
+		if mgfHash != digest {
+			return nil, ErrUnsupportedEncryption
+		}
+	default:
+		return nil, ErrUnsupportedEncryption
+	}
+	cipherValue, err := cipherValue(encryptedKey)
+	if err != nil {
+		return nil, err
+	}
+	key, err := rsa.DecryptOAEP(digest.New(), nil, p.key, cipherValue, nil)
+	if err != nil {
+		return nil, ErrInvalidAssertion
+	}
+	return key, nil
+}
+
+func digestHash(method *etree.Element) (crypto.Hash, error) {
+	digestMethod := childElement(method, namespaceSignature, "DigestMethod")
+	if digestMethod == nil {
+		return crypto.SHA1, nil
+	}
+	switch digestMethod.SelectAttrValue("Algorithm", "") {
+	case digestSHA1:
+		return crypto.SHA1, nil
+	case digestSHA256:
+		return crypto.SHA256, nil
+	case digestSHA512:
+		return crypto.SHA512, nil
+	default:
+		return 0, ErrUnsupportedEncryption
+	}
+}
+
+func mgf1Hash(algorithm string) (crypto.Hash, error) {
+	switch algorithm {
+	case mgf1SHA1:
+		return crypto.SHA1, nil
+	case mgf1SHA256:
+		return crypto.SHA256, nil
+	case mgf1SHA512:
+		return crypto.SHA512, nil
+	default:
+		return 0, ErrUnsupportedEncryption
+	}
+}
+
+func decryptData(encryptedData *etree.Element, key []byte) ([]byte, error) {
+	method := childElement(encryptedData, namespaceEncryption, "EncryptionMethod")
+	if method == nil {
+		return nil, ErrUnsupportedEncryption
+	}
+	ciphertext, err := cipherValue(encryptedData)
+	if err != nil {
+		return nil, err
+	}
+	switch algorithm := method.SelectAttrValue("Algorithm", ""); algorithm {
+	case blockAES128CBC, blockAES192CBC, blockAES256CBC:
+		if err := checkKeySize(algorithm, key); err != nil {
+			return nil, err
+		}
+		return decryptCBC(ciphertext, key)
+	case blockAES128GCM, blockAES192GCM, blockAES256GCM:
+		if err := checkKeySize(algorithm, key); err != nil {
+			return nil, err
+		}
+		return decryptGCM(ciphertext, key)
+	default:
+		return nil, ErrUnsupportedEncryption
+	}
+}
+
+func checkKeySize(algorithm string, key []byte) error {
+	size := 32
+	switch algorithm {
+	case blockAES128CBC, blockAES128GCM:
+		size = 16
+	case blockAES192CBC, blockAES192GCM:
+		size = 24
+	}
+	if len(key) != size {
+		return ErrInvalidAssertion
+	}
+	return nil
+}
+
+// decryptCBC decrypts the ciphertext, which is prefixed with the IV.
+// XML Encryption uses an arbitrary padding, where only the last byte (the length of the padding) is relevant.
+func decryptCBC(ciphertext, key []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, ErrInvalidAssertion
+	}
+	if len(ciphertext) < 2*aes.BlockSize || len(ciphertext)%aes.BlockSize != 0 {
+		return nil, ErrInvalidAssertion
+	}
+	iv, data := ciphertext[:aes.BlockSize], ciphertext[aes.BlockSize:]
+	plaintext := make([]byte, len(data))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plaintext, data)
+	padding := int(plaintext[len(plaintext)-1])
+	if padding == 0 || padding > aes.BlockSize {
+		return nil, ErrInvalidAssertion
+	}
+	return plaintext[:len(plaintext)-padding], nil
+}
+
+// decryptGCM decrypts the ciphertext, which is prefixed with the nonce and suffixed with the authentication tag
+func decryptGCM(ciphertext, key []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, ErrInvalidAssertion
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, ErrInvalidAssertion
+	}
+	if len(ciphertext) < gcm.NonceSize()+gcm.Overhead() {
+		return nil, ErrInvalidAssertion
+	}
+	plaintext, err := gcm.Open(nil, ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, ErrInvalidAssertion
+	}
+	return plaintext, nil
+}
+
+func cipherValue(el *etree.Element) ([]byte, error) {
+	cipherData := childElement(el, namespaceEncryption, "CipherData")
+	if cipherData == nil {
+		return nil, ErrUnsupportedEncryption
+	}
+	value := childElement(cipherData, namespaceEncryption, "CipherValue")
+	if value == nil {
+		return nil, ErrUnsupportedEncryption
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(value.Text()), ""))
+	if err != nil {
+		return nil, ErrInvalidAssertion
+	}
+	return decoded, nil
+}
+
+// parseDecryptedAssertion parses the decrypted assertion in the namespace context of the encrypted element,
+// since the assertion might use prefixes declared on the response
+func parseDecryptedAssertion(encrypted *etree.Element, plaintext []byte) (*etree.Element, error) {
+	ctx, err := etreeutils.NSBuildParentContext(encrypted)
+	if err != nil {
+		return nil, ErrInvalidAssertion
+	}
+	ctx, err = ctx.SubContext(encrypted)
+	if err != nil {
+		return nil, ErrInvalidAssertion
+	}
+	if bytes.HasPrefix(plaintext, []byte("<?xml")) {
+		end := bytes.Index(plaintext, []byte("?>"))
+		if end < 0 {
+			return nil, ErrInvalidAssertion
+		}
+		plaintext = plaintext[end+2:]
+	}
+	wrapper, err := etreeutils.NSDetatch(ctx, etree.NewElement("wrapper"))
+	if err != nil {
+		return nil, ErrInvalidAssertion
+	}
+	doc := etree.NewDocument()
+	doc.SetRoot(wrapper)
+	start, err := doc.WriteToString()
+	if err != nil {
+		return nil, ErrInvalidAssertion
+	}
+	// the wrapper is written as empty element (<wrapper .../>), so it's reopened to embed the assertion
+	start = strings.TrimSuffix(strings.TrimSpace(start), "/>") + ">"
+	doc = etree.NewDocument()
+	if err := doc.ReadFromString(start + string(plaintext) + "</wrapper>"); err != nil {
+		return nil, ErrInvalidAssertion
+	}
+	children := doc.Root().ChildElements()
+	if len(children) != 1 || !isElement(children[0], namespaceAssertion, "Assertion") {
+		return nil, ErrInvalidAssertion
+	}
+	return children[0], nil
+}
+
+func (p *Provider) validateAssertion(el *etree.Element, requestID string, now time.Time) (*saml.AssertionType, error) {
+	doc := etree.NewDocument()
+	doc.SetRoot(el.Copy())
+	raw, err := doc.WriteToBytes()
+	if err != nil {
+		return nil, ErrInvalidAssertion
+	}
+	assertion := new(saml.AssertionType)
+	if err := xml.Unmarshal(raw, assertion); err != nil {
+		return nil, ErrInvalidAssertion
+	}
+	if strings.TrimSpace(assertion.Issuer.Text) != p.idpEntityID {
+		return nil, ErrInvalidAssertion
+	}
+	if assertion.Subject == nil || assertion.Subject.NameID == nil || strings.TrimSpace(assertion.Subject.NameID.Text) == "" {
+		return nil, ErrInvalidAssertion
+	}
+	if !p.hasValidBearerConfirmation(assertion.Subject, requestID, now) {
+		return nil, ErrInvalidAssertion
+	}
+	if !p.hasValidConditions(assertion.Conditions, now) {
+		return nil, ErrInvalidAssertion
+	}
+	return assertion, nil
+}
+
+func (p *Provider) hasValidBearerConfirmation(subject *saml.SubjectType, requestID string, now time.Time) bool {
+	for _, confirmation := range subject.SubjectConfirmation {
+		if confirmation.Method != subjectConfirmationBearer || confirmation.SubjectConfirmationData == nil {
+			continue
+		}
+		data := confirmation.SubjectConfirmationData
+		if data.Recipient != p.acsURL {
+			continue
+		}
+		if data.InResponseTo != "" && data.InResponseTo != requestID {
+			continue
+		}
+		// a bearer confirmation must not contain NotBefore (SAML 2.0 profiles 4.1.4.2),
+		// so it's ignored if an identity provider sends it anyway and only the expiry is checked
+		if !isBefore(now, data.NotOnOrAfter) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+func (p *Provider) hasValidConditions(conditions *saml.ConditionsType, now time.Time) bool {
+	if conditions == nil {
+		return true
+	}
+	if !isBefore(now, conditions.NotOnOrAfter) || !isNotBefore(now, conditions.NotBefore) {
+		return false
+	}
+	for _, restriction := range conditions.AudienceRestriction {
+		if !containsAudience(restriction.Audience, p.entityID) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsAudience(audiences []string, entityID string) bool {
+	for _, audience := range audiences {
+		if strings.TrimSpace(audience) == entityID {
+			return true
+		}
+	}
+	return false
+}
+
+// isBefore checks that now (considering the clock skew) is before the (optional) notOnOrAfter
+func isBefore(now time.Time, notOnOrAfter string) bool {
+	if notOnOrAfter == "" {
+		return true
+	}
+	t, err := time.Parse(time.RFC3339Nano, notOnOrAfter)
+	if err != nil {
+		return false
+	}
+	return now.Add(-allowedClockSkew).Before(t)
+}
+
+// isNotBefore checks that now (considering the clock skew) is not before the (optional) notBefore
+func isNotBefore(now time.Time, notBefore string) bool {
+	if notBefore == "" {
+		return true
+	}
+	t, err := time.Parse(time.RFC3339Nano, notBefore)
+	if err != nil {
+		return false
+	}
+	return !now.Add(allowedClockSkew).Before(t)
+}
+
+func isSuccess(response *etree.Element) bool {
+	status := childElement(response, namespaceProtocol, "Status")
+	if status == nil {
+		return false
+	}
+	code := childElement(status, namespaceProtocol, "StatusCode")
+	return code != nil && code.SelectAttrValue("Value", "") == statusSuccess
+}
+
+func isElement(el *etree.Element, namespace, tag string) bool {
+	return el != nil && el.Tag == tag && el.NamespaceURI() == namespace
+}
+
+func childElement(el *etree.Element, namespace, tag string) *etree.Element {
+	for _, child := range el.ChildElements() {
+		if isElement(child, namespace, tag) {
+			return child
+		}
+	}
+	return nil
+}
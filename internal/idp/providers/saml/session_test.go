@@ -0,0 +1,527 @@
+package saml
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestSession_FetchUser(t *testing.T) {
+	idpKeyStore := dsig.RandomKeyStoreForTest()
+	otherKeyStore := dsig.RandomKeyStoreForTest()
+	certificate, key := testCertificateAndKey(t)
+	type fields struct {
+		requestID string
+		response  func(p *Provider) string
+	}
+	type want struct {
+		err               error
+		id                string
+		firstName         string
+		lastName          string
+		displayName       string
+		preferredUsername string
+		email             string
+		isEmailVerified   bool
+		preferredLanguage language.Tag
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   want
+	}{
+		{
+			name: "missing response, error",
+			fields: fields{
+				requestID: "id-state",
+				response: func(*Provider) string {
+					return ""
+				},
+			},
+			want: want{
+				err: ErrMissingResponse,
+			},
+		},
+		{
+			name: "invalid response, error",
+			fields: fields{
+				requestID: "id-state",
+				response: func(*Provider) string {
+					return base64.StdEncoding.EncodeToString([]byte("<invalid/>"))
+				},
+			},
+			want: want{
+				err: ErrInvalidResponse,
+			},
+		},
+		{
+			name: "unsigned response and assertion, error",
+			fields: fields{
+				requestID: "id-state",
+				response: func(*Provider) string {
+					return testResponseFromOptions(t, defaultResponseOptions(idpKeyStore))
+				},
+			},
+			want: want{
+				err: ErrMissingSignature,
+			},
+		},
+		{
+			name: "signed by unknown key, error",
+			fields: fields{
+				requestID: "id-state",
+				response: func(*Provider) string {
+					options := defaultResponseOptions(otherKeyStore)
+					options.signResponse = true
+					return testResponseFromOptions(t, options)
+				},
+			},
+			want: want{
+				err: ErrInvalidSignature,
+			},
+		},
+		{
+			name: "manipulated response, error",
+			fields: fields{
+				requestID: "id-state",
+				response: func(*Provider) string {
+					options := defaultResponseOptions(idpKeyStore)
+					options.signResponse = true
+					options.manipulate = func(response *etree.Element) {
+						response.FindElement("//NameID").SetText("admin@example.com")
+					}
+					return testResponseFromOptions(t, options)
+				},
+			},
+			want: want{
+				err: ErrInvalidSignature,
+			},
+		},
+		{
+			name: "wrong in response to, error",
+			fields: fields{
+				requestID: "id-other",
+				response: func(*Provider) string {
+					options := defaultResponseOptions(idpKeyStore)
+					options.signResponse = true
+					return testResponseFromOptions(t, options)
+				},
+			},
+			want: want{
+				err: ErrInvalidResponse,
+			},
+		},
+		{
+			name: "unsuccessful status, error",
+			fields: fields{
+				requestID: "id-state",
+				response: func(*Provider) string {
+					options := defaultResponseOptions(idpKeyStore)
+					options.signResponse = true
+					options.status = "urn:oasis:names:tc:SAML:2.0:status:Requester"
+					return testResponseFromOptions(t, options)
+				},
+			},
+			want: want{
+				err: ErrResponseStatus,
+			},
+		},
+		{
+			name: "wrong issuer, error",
+			fields: fields{
+				requestID: "id-state",
+				response: func(*Provider) string {
+					options := defaultResponseOptions(idpKeyStore)
+					options.signAssertion = true
+					options.issuer = "https://other.example.com"
+					return testResponseFromOptions(t, options)
+				},
+			},
+			want: want{
+				err: ErrInvalidAssertion,
+			},
+		},
+		{
+			name: "wrong audience, error",
+			fields: fields{
+				requestID: "id-state",
+				response: func(*Provider) string {
+					options := defaultResponseOptions(idpKeyStore)
+					options.signAssertion = true
+					options.audience = "https://other.example.com"
+					return testResponseFromOptions(t, options)
+				},
+			},
+			want: want{
+				err: ErrInvalidAssertion,
+			},
+		},
+		{
+			name: "wrong recipient, error",
+			fields: fields{
+				requestID: "id-state",
+				response: func(*Provider) string {
+					options := defaultResponseOptions(idpKeyStore)
+					options.signAssertion = true
+					options.recipient = "https://other.example.com/acs"
+					return testResponseFromOptions(t, options)
+				},
+			},
+			want: want{
+				err: ErrInvalidAssertion,
+			},
+		},
+		{
+			name: "expired assertion, error",
+			fields: fields{
+				requestID: "id-state",
+				response: func(*Provider) string {
+					options := defaultResponseOptions(idpKeyStore)
+					options.signAssertion = true
+					options.notOnOrAfter = time.Now().Add(-10 * time.Minute)
+					return testResponseFromOptions(t, options)
+				},
+			},
+			want: want{
+				err: ErrInvalidAssertion,
+			},
+		},
+		{
+			name: "bearer confirmation with not before, successful",
+			fields: fields{
+				requestID: "id-state",
+				response: func(*Provider) string {
+					options := defaultResponseOptions(idpKeyStore)
+					options.signAssertion = true
+					options.notBefore = time.Now().Add(time.Minute)
+					return testResponseFromOptions(t, options)
+				},
+			},
+			want: want{
+				id:                "user@example.com",
+				firstName:         "Jane",
+				lastName:          "Doe",
+				displayName:       "Jane Doe",
+				preferredUsername: "jane",
+				email:             "jane.doe@example.com",
+				preferredLanguage: language.German,
+			},
+		},
+		{
+			name: "unsupported key transport, error",
+			fields: fields{
+				requestID: "id-state",
+				response: func(p *Provider) string {
+					options := defaultResponseOptions(idpKeyStore)
+					options.signAssertion = true
+					options.encryptionKey = &p.key.PublicKey
+					options.keyTransport = namespaceEncryption + "rsa-1_5"
+					options.blockEncryption = blockAES256CBC
+					return testResponseFromOptions(t, options)
+				},
+			},
+			want: want{
+				err: ErrUnsupportedEncryption,
+			},
+		},
+		{
+			name: "signed response, successful",
+			fields: fields{
+				requestID: "id-state",
+				response: func(*Provider) string {
+					options := defaultResponseOptions(idpKeyStore)
+					options.signResponse = true
+					return testResponseFromOptions(t, options)
+				},
+			},
+			want: want{
+				id:                "user@example.com",
+				firstName:         "Jane",
+				lastName:          "Doe",
+				displayName:       "Jane Doe",
+				preferredUsername: "jane",
+				email:             "jane.doe@example.com",
+				preferredLanguage: language.German,
+			},
+		},
+		{
+			name: "signed assertion, successful",
+			fields: fields{
+				requestID: "id-state",
+				response: func(*Provider) string {
+					options := defaultResponseOptions(idpKeyStore)
+					options.signAssertion = true
+					return testResponseFromOptions(t, options)
+				},
+			},
+			want: want{
+				id:                "user@example.com",
+				firstName:         "Jane",
+				lastName:          "Doe",
+				displayName:       "Jane Doe",
+				preferredUsername: "jane",
+				email:             "jane.doe@example.com",
+				preferredLanguage: language.German,
+			},
+		},
+		{
+			name: "encrypted assertion (aes-cbc), successful",
+			fields: fields{
+				requestID: "id-state",
+				response: func(p *Provider) string {
+					options := defaultResponseOptions(idpKeyStore)
+					options.signAssertion = true
+					options.encryptionKey = &p.key.PublicKey
+					options.keyTransport = keyTransportRSAOAEPMGF1P
+					options.blockEncryption = blockAES256CBC
+					return testResponseFromOptions(t, options)
+				},
+			},
+			want: want{
+				id:                "user@example.com",
+				firstName:         "Jane",
+				lastName:          "Doe",
+				displayName:       "Jane Doe",
+				preferredUsername: "jane",
+				email:             "jane.doe@example.com",
+				preferredLanguage: language.German,
+			},
+		},
+		{
+			name: "encrypted assertion (aes-gcm) in signed response, successful",
+			fields: fields{
+				requestID: "id-state",
+				response: func(p *Provider) string {
+					options := defaultResponseOptions(idpKeyStore)
+					options.signResponse = true
+					options.encryptionKey = &p.key.PublicKey
+					options.keyTransport = keyTransportRSAOAEP
+					options.blockEncryption = blockAES128GCM
+					return testResponseFromOptions(t, options)
+				},
+			},
+			want: want{
+				id:                "user@example.com",
+				firstName:         "Jane",
+				lastName:          "Doe",
+				displayName:       "Jane Doe",
+				preferredUsername: "jane",
+				email:             "jane.doe@example.com",
+				preferredLanguage: language.German,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			provider, err := New("saml", testEntityID, testACSURL, testIDPMetadata(t, idpKeyStore, BindingRedirect), certificate, key)
+			require.NoError(t, err)
+
+			session := &Session{
+				RequestID: tt.fields.requestID,
+				Response:  tt.fields.response(provider),
+				Provider:  provider,
+			}
+
+			user, err := session.FetchUser(context.Background())
+			if tt.want.err != nil {
+				a.ErrorIs(err, tt.want.err)
+				return
+			}
+			require.NoError(t, err)
+			a.Equal(tt.want.id, user.GetID())
+			a.Equal(tt.want.firstName, user.GetFirstName())
+			a.Equal(tt.want.lastName, user.GetLastName())
+			a.Equal(tt.want.displayName, user.GetDisplayName())
+			a.Equal(tt.want.preferredUsername, user.GetPreferredUsername())
+			a.Equal(tt.want.email, user.GetEmail())
+			a.Equal(tt.want.isEmailVerified, user.IsEmailVerified())
+			a.Equal(tt.want.preferredLanguage, user.GetPreferredLanguage())
+		})
+	}
+}
+
+type responseOptions struct {
+	keyStore        dsig.X509KeyStore
+	inResponseTo    string
+	issuer          string
+	audience        string
+	recipient       string
+	status          string
+	notOnOrAfter    time.Time
+	notBefore       time.Time
+	signResponse    bool
+	signAssertion   bool
+	encryptionKey   *rsa.PublicKey
+	keyTransport    string
+	blockEncryption string
+	manipulate      func(response *etree.Element)
+}
+
+func defaultResponseOptions(keyStore dsig.X509KeyStore) *responseOptions {
+	return &responseOptions{
+		keyStore:     keyStore,
+		inResponseTo: "id-state",
+		issuer:       testIDPEntityID,
+		audience:     testEntityID,
+		recipient:    testACSURL,
+		status:       statusSuccess,
+		notOnOrAfter: time.Now().Add(5 * time.Minute),
+	}
+}
+
+func testResponseFromOptions(t *testing.T, options *responseOptions) string {
+	now := time.Now().UTC()
+	signingContext := dsig.NewDefaultSigningContext(options.keyStore)
+	// identity providers use the exclusive canonicalization, so the signature of the assertion is independent of the response
+	signingContext.Canonicalizer = dsig.MakeC14N10ExclusiveCanonicalizerWithPrefixList("")
+
+	assertion := testAssertion(options, now)
+	if options.signAssertion {
+		var err error
+		assertion, err = signingContext.SignEnveloped(assertion)
+		require.NoError(t, err)
+	}
+
+	response := etree.NewElement("samlp:Response")
+	response.CreateAttr("xmlns:samlp", namespaceProtocol)
+	response.CreateAttr("xmlns:saml", namespaceAssertion)
+	response.CreateAttr("ID", "response-id")
+	response.CreateAttr("Version", "2.0")
+	response.CreateAttr("IssueInstant", now.Format(timeFormat))
+	response.CreateAttr("Destination", testACSURL)
+	response.CreateAttr("InResponseTo", options.inResponseTo)
+	response.CreateElement("saml:Issuer").SetText(options.issuer)
+	response.CreateElement("samlp:Status").CreateElement("samlp:StatusCode").CreateAttr("Value", options.status)
+	if options.encryptionKey != nil {
+		response.AddChild(testEncryptAssertion(t, assertion, options))
+	} else {
+		response.AddChild(assertion)
+	}
+	if options.signResponse {
+		var err error
+		response, err = signingContext.SignEnveloped(response)
+		require.NoError(t, err)
+	}
+	if options.manipulate != nil {
+		options.manipulate(response)
+	}
+
+	doc := etree.NewDocument()
+	doc.SetRoot(response)
+	raw, err := doc.WriteToBytes()
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(raw)
+}
+
+func testAssertion(options *responseOptions, now time.Time) *etree.Element {
+	assertion := etree.NewElement("saml:Assertion")
+	assertion.CreateAttr("xmlns:saml", namespaceAssertion)
+	assertion.CreateAttr("ID", "assertion-id")
+	assertion.CreateAttr("Version", "2.0")
+	assertion.CreateAttr("IssueInstant", now.Format(timeFormat))
+	assertion.CreateElement("saml:Issuer").SetText(options.issuer)
+
+	subject := assertion.CreateElement("saml:Subject")
+	nameID := subject.CreateElement("saml:NameID")
+	nameID.CreateAttr("Format", nameIDFormatEmail)
+	nameID.SetText("user@example.com")
+	confirmation := subject.CreateElement("saml:SubjectConfirmation")
+	confirmation.CreateAttr("Method", subjectConfirmationBearer)
+	confirmationData := confirmation.CreateElement("saml:SubjectConfirmationData")
+	confirmationData.CreateAttr("InResponseTo", options.inResponseTo)
+	confirmationData.CreateAttr("Recipient", options.recipient)
+	confirmationData.CreateAttr("NotOnOrAfter", options.notOnOrAfter.UTC().Format(timeFormat))
+	if !options.notBefore.IsZero() {
+		confirmationData.CreateAttr("NotBefore", options.notBefore.UTC().Format(timeFormat))
+	}
+
+	conditions := assertion.CreateElement("saml:Conditions")
+	conditions.CreateAttr("NotBefore", now.Add(-time.Minute).Format(timeFormat))
+	conditions.CreateAttr("NotOnOrAfter", options.notOnOrAfter.UTC().Format(timeFormat))
+	conditions.CreateElement("saml:AudienceRestriction").CreateElement("saml:Audience").SetText(options.audience)
+
+	statement := assertion.CreateElement("saml:AttributeStatement")
+	for _, attribute := range [][2]string{
+		{"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/givenname", "Jane"},
+		{"urn:oid:2.5.4.4", "Doe"},
+		{"displayName", "Jane Doe"},
+		{"uid", "jane"},
+		{"mail", "jane.doe@example.com"},
+		{"preferredLanguage", "de"},
+	} {
+		attributeElement := statement.CreateElement("saml:Attribute")
+		attributeElement.CreateAttr("Name", attribute[0])
+		attributeElement.CreateElement("saml:AttributeValue").SetText(attribute[1])
+	}
+	return assertion
+}
+
+func testEncryptAssertion(t *testing.T, assertion *etree.Element, options *responseOptions) *etree.Element {
+	doc := etree.NewDocument()
+	doc.SetRoot(assertion)
+	plaintext, err := doc.WriteToBytes()
+	require.NoError(t, err)
+
+	var key, ciphertext []byte
+	switch options.blockEncryption {
+	case blockAES256CBC:
+		key = testRandomBytes(t, 32)
+		block, err := aes.NewCipher(key)
+		require.NoError(t, err)
+		padding := aes.BlockSize - len(plaintext)%aes.BlockSize
+		for i := 0; i < padding; i++ {
+			plaintext = append(plaintext, byte(padding))
+		}
+		iv := testRandomBytes(t, aes.BlockSize)
+		ciphertext = make([]byte, len(plaintext))
+		cipher.NewCBCEncrypter(block, iv).CryptBlocks(ciphertext, plaintext)
+		ciphertext = append(iv, ciphertext...)
+	case blockAES128GCM:
+		key = testRandomBytes(t, 16)
+		block, err := aes.NewCipher(key)
+		require.NoError(t, err)
+		gcm, err := cipher.NewGCM(block)
+		require.NoError(t, err)
+		nonce := testRandomBytes(t, gcm.NonceSize())
+		ciphertext = gcm.Seal(nonce, nonce, plaintext, nil)
+	}
+	encryptedKey, err := rsa.EncryptOAEP(sha1.New(), rand.Reader, options.encryptionKey, key, nil)
+	require.NoError(t, err)
+
+	encrypted := etree.NewElement("saml:EncryptedAssertion")
+	encryptedData := encrypted.CreateElement("xenc:EncryptedData")
+	encryptedData.CreateAttr("xmlns:xenc", namespaceEncryption)
+	encryptedData.CreateElement("xenc:EncryptionMethod").CreateAttr("Algorithm", options.blockEncryption)
+	keyInfo := encryptedData.CreateElement("ds:KeyInfo")
+	keyInfo.CreateAttr("xmlns:ds", namespaceSignature)
+	keyElement := keyInfo.CreateElement("xenc:EncryptedKey")
+	keyElement.CreateElement("xenc:EncryptionMethod").CreateAttr("Algorithm", options.keyTransport)
+	keyElement.CreateElement("xenc:CipherData").
+		CreateElement("xenc:CipherValue").
+		SetText(base64.StdEncoding.EncodeToString(encryptedKey))
+	encryptedData.CreateElement("xenc:CipherData").
+		CreateElement("xenc:CipherValue").
+		SetText(base64.StdEncoding.EncodeToString(ciphertext))
+	return encrypted
+}
+
+func testRandomBytes(t *testing.T, length int) []byte {
+	b := make([]byte, length)
+	_, err := rand.Read(b)
+	require.NoError(t, err)
+	return b
+}
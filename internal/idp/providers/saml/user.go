@@ -0,0 +1,217 @@
+package saml
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/zitadel/saml/pkg/provider/xml/saml"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/idp"
+)
+
+const nameIDFormatEmail = "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"
+
+// the attribute names are checked in the provided order,
+// covering the ADFS / Azure AD claim types, the X.500 / eduPerson OIDs and common friendly names
+var (
+	firstNameAttributes = []string{
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/givenname",
+		"urn:oid:2.5.4.42",
+		"givenName",
+		"firstName",
+		"first_name",
+	}
+	lastNameAttributes = []string{
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/surname",
+		"urn:oid:2.5.4.4",
+		"sn",
+		"surname",
+		"lastName",
+		"last_name",
+	}
+	displayNameAttributes = []string{
+		"http://schemas.microsoft.com/identity/claims/displayname",
+		"urn:oid:2.16.840.1.113730.3.1.241",
+		"displayName",
+		"name",
+	}
+	nicknameAttributes = []string{
+		"nickname",
+		"nickName",
+	}
+	preferredUsernameAttributes = []string{
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/upn",
+		"urn:oid:1.3.6.1.4.1.5923.1.1.1.6",
+		"eduPersonPrincipalName",
+		"urn:oid:0.9.2342.19200300.100.1.1",
+		"uid",
+		"username",
+		"preferred_username",
+	}
+	emailAttributes = []string{
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress",
+		"urn:oid:0.9.2342.19200300.100.1.3",
+		"mail",
+		"email",
+		"emailAddress",
+	}
+	emailVerifiedAttributes = []string{
+		"email_verified",
+		"emailVerified",
+	}
+	phoneAttributes = []string{
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/mobilephone",
+		"urn:oid:0.9.2342.19200300.100.1.41",
+		"mobile",
+		"urn:oid:2.5.4.20",
+		"telephoneNumber",
+		"phone",
+	}
+	phoneVerifiedAttributes = []string{
+		"phone_verified",
+		"phoneVerified",
+	}
+	preferredLanguageAttributes = []string{
+		"urn:oid:2.16.840.1.113730.3.1.39",
+		"preferredLanguage",
+		"locale",
+	}
+	avatarURLAttributes = []string{
+		"picture",
+		"avatarURL",
+	}
+	profileAttributes = []string{
+		"profile",
+	}
+)
+
+var _ idp.User = (*User)(nil)
+
+// User is a representation of the authenticated SAML subject and implements the [idp.User] interface
+// by mapping well known attributes of the assertion.
+type User struct {
+	id           string
+	nameIDFormat string
+	attributes   map[string][]string
+}
+
+// NewUser creates a [User] from the (already validated) assertion
+func NewUser(assertion *saml.AssertionType) *User {
+	user := &User{
+		attributes: make(map[string][]string),
+	}
+	if assertion.Subject != nil && assertion.Subject.NameID != nil {
+		user.id = strings.TrimSpace(assertion.Subject.NameID.Text)
+		user.nameIDFormat = assertion.Subject.NameID.Format
+	}
+	for _, statement := range assertion.AttributeStatement {
+		for _, attribute := range statement.Attribute {
+			if attribute == nil {
+				continue
+			}
+			values := make([]string, 0, len(attribute.AttributeValue))
+			for _, value := range attribute.AttributeValue {
+				values = append(values, strings.TrimSpace(value))
+			}
+			user.attributes[attribute.Name] = append(user.attributes[attribute.Name], values...)
+			if attribute.FriendlyName != "" && attribute.FriendlyName != attribute.Name {
+				user.attributes[attribute.FriendlyName] = append(user.attributes[attribute.FriendlyName], values...)
+			}
+		}
+	}
+	return user
+}
+
+// GetID is an implementation of the [idp.User] interface.
+// It returns the NameID of the subject.
+func (u *User) GetID() string {
+	return u.id
+}
+
+// GetFirstName is an implementation of the [idp.User] interface.
+func (u *User) GetFirstName() string {
+	return u.attribute(firstNameAttributes)
+}
+
+// GetLastName is an implementation of the [idp.User] interface.
+func (u *User) GetLastName() string {
+	return u.attribute(lastNameAttributes)
+}
+
+// GetDisplayName is an implementation of the [idp.User] interface.
+func (u *User) GetDisplayName() string {
+	return u.attribute(displayNameAttributes)
+}
+
+// GetNickname is an implementation of the [idp.User] interface.
+func (u *User) GetNickname() string {
+	return u.attribute(nicknameAttributes)
+}
+
+// GetPreferredUsername is an implementation of the [idp.User] interface.
+func (u *User) GetPreferredUsername() string {
+	return u.attribute(preferredUsernameAttributes)
+}
+
+// GetEmail is an implementation of the [idp.User] interface.
+// If no email attribute is provided, the NameID is used if it's of the emailAddress format.
+func (u *User) GetEmail() string {
+	if email := u.attribute(emailAttributes); email != "" {
+		return email
+	}
+	if u.nameIDFormat == nameIDFormatEmail {
+		return u.id
+	}
+	return ""
+}
+
+// IsEmailVerified is an implementation of the [idp.User] interface.
+// SAML does not specify the verification of the email, so only an explicit attribute will be considered.
+func (u *User) IsEmailVerified() bool {
+	verified, _ := strconv.ParseBool(u.attribute(emailVerifiedAttributes))
+	return verified
+}
+
+// GetPhone is an implementation of the [idp.User] interface.
+func (u *User) GetPhone() string {
+	return u.attribute(phoneAttributes)
+}
+
+// IsPhoneVerified is an implementation of the [idp.User] interface.
+// SAML does not specify the verification of the phone, so only an explicit attribute will be considered.
+func (u *User) IsPhoneVerified() bool {
+	verified, _ := strconv.ParseBool(u.attribute(phoneVerifiedAttributes))
+	return verified
+}
+
+// GetPreferredLanguage is an implementation of the [idp.User] interface.
+func (u *User) GetPreferredLanguage() language.Tag {
+	return language.Make(u.attribute(preferredLanguageAttributes))
+}
+
+// GetAvatarURL is an implementation of the [idp.User] interface.
+func (u *User) GetAvatarURL() string {
+	return u.attribute(avatarURLAttributes)
+}
+
+// GetProfile is an implementation of the [idp.User] interface.
+func (u *User) GetProfile() string {
+	return u.attribute(profileAttributes)
+}
+
+// GetAttributes returns all attributes of the assertion by their name (and friendly name)
+func (u *User) GetAttributes() map[string][]string {
+	return u.attributes
+}
+
+func (u *User) attribute(names []string) string {
+	for _, name := range names {
+		for _, value := range u.attributes[name] {
+			if value != "" {
+				return value
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,76 @@
+package ldapsync
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// ReconcileUserID is used as editor of the deactivations of the periodic reconciliation
+const ReconcileUserID = "LDAP-RECONCILIATION"
+
+type Config struct {
+	// Enabled starts the periodic reconciliation of all active LDAP identity providers of all instances
+	Enabled bool
+	// Interval between the reconciliations
+	Interval time.Duration
+}
+
+// Start runs the reconciliation of all LDAP identity providers periodically, until the context is done
+func Start(ctx context.Context, config Config, queries *query.Queries, reconciler *Reconciler) {
+	if !config.Enabled || config.Interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(config.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				reconcileInstances(ctx, queries, reconciler)
+			}
+		}
+	}()
+}
+
+func reconcileInstances(ctx context.Context, queries *query.Queries, reconciler *Reconciler) {
+	instances, err := queries.SearchInstances(ctx, &query.InstanceSearchQueries{})
+	if err != nil {
+		logging.WithError(err).Warn("unable to search instances for ldap reconciliation")
+		return
+	}
+	for _, instance := range instances.Instances {
+		instanceCtx := authz.WithInstanceID(ctx, instance.ID)
+		reconcileInstance(instanceCtx, instance.ID, queries, reconciler)
+	}
+}
+
+func reconcileInstance(ctx context.Context, instanceID string, queries *query.Queries, reconciler *Reconciler) {
+	typeQuery, err := query.NewIDPTemplateTypeSearchQuery(domain.IDPTypeLDAP)
+	if err != nil {
+		return
+	}
+	templates, err := queries.IDPTemplates(ctx, &query.IDPTemplateSearchQueries{Queries: []query.SearchQuery{typeQuery}}, false)
+	if err != nil {
+		logging.WithFields("instance", instanceID).WithError(err).Warn("unable to search ldap identity providers for reconciliation")
+		return
+	}
+	for _, template := range templates.Templates {
+		if template.State != domain.IDPStateActive {
+			continue
+		}
+		report, err := reconciler.Reconcile(authz.SetCtxData(ctx, authz.CtxData{UserID: ReconcileUserID, OrgID: template.ResourceOwner}), template.ID, template.ResourceOwner, false)
+		if err != nil {
+			logging.WithFields("instance", instanceID, "idp", template.ID).WithError(err).Warn("ldap reconciliation failed")
+			continue
+		}
+		logging.WithFields("instance", instanceID, "idp", template.ID, "users", len(report.Entries)).Debug("ldap reconciliation done")
+	}
+}
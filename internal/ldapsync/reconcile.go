@@ -0,0 +1,186 @@
+package ldapsync
+
+import (
+	"context"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// Reason describes why a user is deactivated by the reconciliation
+type Reason int32
+
+const (
+	ReasonUnspecified Reason = iota
+	// ReasonNotFound means the linked entry does not exist in the directory anymore
+	ReasonNotFound
+	// ReasonDisabled means the linked entry is disabled in the directory
+	ReasonDisabled
+)
+
+// Entry is a user which is (or in case of a dry run would be) deactivated
+type Entry struct {
+	UserID           string
+	ResourceOwner    string
+	ProvidedUserID   string
+	ProvidedUsername string
+	Reason           Reason
+	Deactivated      bool
+}
+
+// Report lists the users of the reconciliation of an LDAP identity provider
+type Report struct {
+	IDPID   string
+	DryRun  bool
+	Entries []*Entry
+}
+
+// Reconciler deactivates the users linked to an LDAP identity provider,
+// whose entry in the directory disappeared or was disabled
+type Reconciler struct {
+	queries      *query.Queries
+	commands     *command.Commands
+	idpConfigAlg crypto.EncryptionAlgorithm
+}
+
+func NewReconciler(queries *query.Queries, commands *command.Commands, idpConfigAlg crypto.EncryptionAlgorithm) *Reconciler {
+	return &Reconciler{
+		queries:      queries,
+		commands:     commands,
+		idpConfigAlg: idpConfigAlg,
+	}
+}
+
+// Reconcile checks the users linked to the LDAP identity provider (of the resource owner) against the directory.
+// Active users without active entry are deactivated, unless dryRun is set, in which case only the report is returned.
+func (r *Reconciler) Reconcile(ctx context.Context, idpID, resourceOwner string, dryRun bool) (_ *Report, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	template, err := r.queries.IDPTemplateByIDAndResourceOwner(ctx, true, idpID, resourceOwner, false)
+	if err != nil {
+		return nil, err
+	}
+	if template.LDAPIDPTemplate == nil {
+		return nil, errors.ThrowPreconditionFailed(nil, "LDAPS-Mv2ko", "Errors.IDPConfig.NotExisting")
+	}
+	provider, err := r.provider(template)
+	if err != nil {
+		return nil, err
+	}
+	idpQuery, err := query.NewIDPUserLinkIDPIDSearchQuery(idpID)
+	if err != nil {
+		return nil, err
+	}
+	links, err := r.queries.IDPUserLinks(ctx, &query.IDPUserLinksSearchQuery{Queries: []query.SearchQuery{idpQuery}}, false)
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{
+		IDPID:  idpID,
+		DryRun: dryRun,
+	}
+	if len(links.Links) == 0 {
+		return report, nil
+	}
+	states, err := provider.UserStates(ctx, providedUserIDs(links.Links)...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "LDAPS-Qo3nd", "Errors.IDPConfig.LDAP.Unreachable")
+	}
+	for _, entry := range reconcileEntries(links.Links, states) {
+		user, err := r.queries.GetUserByID(ctx, false, entry.UserID, false)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		// users which are already inactive or cannot be deactivated are skipped
+		if user.State != domain.UserStateActive {
+			continue
+		}
+		report.Entries = append(report.Entries, entry)
+		if dryRun {
+			continue
+		}
+		_, err = r.commands.DeactivateUser(ctx, entry.UserID, entry.ResourceOwner)
+		logging.WithFields("idp", idpID, "user", entry.UserID).OnError(err).Warn("unable to deactivate user of ldap reconciliation")
+		entry.Deactivated = err == nil
+	}
+	return report, nil
+}
+
+func (r *Reconciler) provider(template *query.IDPTemplate) (*ldap.Provider, error) {
+	password, err := crypto.DecryptString(template.LDAPIDPTemplate.Password, r.idpConfigAlg)
+	if err != nil {
+		return nil, err
+	}
+	opts := make([]ldap.ProviderOpts, 0, 4)
+	if template.LDAPIDPTemplate.Port != "" {
+		opts = append(opts, ldap.WithCustomPort(template.LDAPIDPTemplate.Port))
+	}
+	if !template.LDAPIDPTemplate.TLS {
+		opts = append(opts, ldap.Insecure())
+	}
+	if template.LDAPIDPTemplate.IDAttribute != "" {
+		opts = append(opts, ldap.WithCustomIDAttribute(template.LDAPIDPTemplate.IDAttribute))
+	}
+	if template.LDAPIDPTemplate.DisabledAttribute != "" {
+		opts = append(opts, ldap.WithDisabledAttribute(template.LDAPIDPTemplate.DisabledAttribute))
+	}
+	return ldap.New(
+		template.Name,
+		template.LDAPIDPTemplate.Host,
+		template.LDAPIDPTemplate.BaseDN,
+		template.LDAPIDPTemplate.UserObjectClass,
+		template.LDAPIDPTemplate.UserUniqueAttribute,
+		template.LDAPIDPTemplate.Admin,
+		password,
+		"",
+		opts...,
+	), nil
+}
+
+func providedUserIDs(links []*query.IDPUserLink) []string {
+	ids := make([]string, 0, len(links))
+	for _, link := range links {
+		ids = append(ids, link.ProvidedUserID)
+	}
+	return ids
+}
+
+// reconcileEntries returns the links whose entry was not found or is disabled in the directory.
+// Links without state (e.g. because of an interrupted lookup) are kept.
+func reconcileEntries(links []*query.IDPUserLink, states map[string]ldap.UserState) []*Entry {
+	entries := make([]*Entry, 0)
+	for _, link := range links {
+		state, ok := states[link.ProvidedUserID]
+		if !ok {
+			continue
+		}
+		var reason Reason
+		switch state {
+		case ldap.UserStateNotFound:
+			reason = ReasonNotFound
+		case ldap.UserStateDisabled:
+			reason = ReasonDisabled
+		default:
+			continue
+		}
+		entries = append(entries, &Entry{
+			UserID:           link.UserID,
+			ResourceOwner:    link.ResourceOwner,
+			ProvidedUserID:   link.ProvidedUserID,
+			ProvidedUsername: link.ProvidedUsername,
+			Reason:           reason,
+		})
+	}
+	return entries
+}
@@ -0,0 +1,55 @@
+package ldapsync
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func Test_reconcileEntries(t *testing.T) {
+	type args struct {
+		links  []*query.IDPUserLink
+		states map[string]ldap.UserState
+	}
+	tests := []struct {
+		name string
+		args args
+		want []*Entry
+	}{
+		{
+			name: "no links",
+			args: args{
+				states: map[string]ldap.UserState{},
+			},
+			want: []*Entry{},
+		},
+		{
+			name: "active, not found, disabled and unknown",
+			args: args{
+				links: []*query.IDPUserLink{
+					{UserID: "user1", ResourceOwner: "org1", ProvidedUserID: "id1", ProvidedUsername: "active"},
+					{UserID: "user2", ResourceOwner: "org1", ProvidedUserID: "id2", ProvidedUsername: "removed"},
+					{UserID: "user3", ResourceOwner: "org2", ProvidedUserID: "id3", ProvidedUsername: "disabled"},
+					{UserID: "user4", ResourceOwner: "org2", ProvidedUserID: "id4", ProvidedUsername: "unknown"},
+				},
+				states: map[string]ldap.UserState{
+					"id1": ldap.UserStateActive,
+					"id2": ldap.UserStateNotFound,
+					"id3": ldap.UserStateDisabled,
+				},
+			},
+			want: []*Entry{
+				{UserID: "user2", ResourceOwner: "org1", ProvidedUserID: "id2", ProvidedUsername: "removed", Reason: ReasonNotFound},
+				{UserID: "user3", ResourceOwner: "org2", ProvidedUserID: "id3", ProvidedUsername: "disabled", Reason: ReasonDisabled},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, reconcileEntries(tt.args.links, tt.args.states))
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	actionKeyValuesTable = table{
+		name:          projection.ActionKeyValueTable,
+		instanceIDCol: projection.ActionKeyValueInstanceIDCol,
+	}
+	ActionKeyValueColumnKey = Column{
+		name:  projection.ActionKeyValueKeyCol,
+		table: actionKeyValuesTable,
+	}
+	ActionKeyValueColumnCreationDate = Column{
+		name:  projection.ActionKeyValueCreationDateCol,
+		table: actionKeyValuesTable,
+	}
+	ActionKeyValueColumnChangeDate = Column{
+		name:  projection.ActionKeyValueChangeDateCol,
+		table: actionKeyValuesTable,
+	}
+	ActionKeyValueColumnSequence = Column{
+		name:  projection.ActionKeyValueSequenceCol,
+		table: actionKeyValuesTable,
+	}
+	ActionKeyValueColumnResourceOwner = Column{
+		name:  projection.ActionKeyValueResourceOwnerCol,
+		table: actionKeyValuesTable,
+	}
+	ActionKeyValueColumnInstanceID = Column{
+		name:  projection.ActionKeyValueInstanceIDCol,
+		table: actionKeyValuesTable,
+	}
+	ActionKeyValueColumnValue = Column{
+		name:  projection.ActionKeyValueValueCol,
+		table: actionKeyValuesTable,
+	}
+	ActionKeyValueColumnOwnerRemoved = Column{
+		name:  projection.ActionKeyValueOwnerRemovedCol,
+		table: actionKeyValuesTable,
+	}
+)
+
+// ActionKeyValue is an entry of the key-value storage of the actions of an organisation
+type ActionKeyValue struct {
+	Key           string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	Sequence      uint64
+	Value         []byte
+}
+
+func (q *Queries) ActionKeyValue(ctx context.Context, shouldTriggerBulk bool, orgID, key string) (_ *ActionKeyValue, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		projection.ActionKeyValueProjection.Trigger(ctx)
+	}
+
+	query, scan := prepareActionKeyValueQuery()
+	stmt, args, err := query.Where(sq.Eq{
+		ActionKeyValueColumnKey.identifier():           key,
+		ActionKeyValueColumnResourceOwner.identifier(): orgID,
+		ActionKeyValueColumnInstanceID.identifier():    authz.GetInstance(ctx).InstanceID(),
+		ActionKeyValueColumnOwnerRemoved.identifier():  false,
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ni3ai", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, stmt, args...)
+	return scan(row)
+}
+
+func prepareActionKeyValueQuery() (sq.SelectBuilder, func(*sql.Row) (*ActionKeyValue, error)) {
+	return sq.Select(
+			ActionKeyValueColumnKey.identifier(),
+			ActionKeyValueColumnCreationDate.identifier(),
+			ActionKeyValueColumnChangeDate.identifier(),
+			ActionKeyValueColumnResourceOwner.identifier(),
+			ActionKeyValueColumnSequence.identifier(),
+			ActionKeyValueColumnValue.identifier(),
+		).
+			From(actionKeyValuesTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*ActionKeyValue, error) {
+			kv := new(ActionKeyValue)
+			err := row.Scan(
+				&kv.Key,
+				&kv.CreationDate,
+				&kv.ChangeDate,
+				&kv.ResourceOwner,
+				&kv.Sequence,
+				&kv.Value,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Gai2o", "Errors.Action.KeyValue.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-aiK3e", "Errors.Internal")
+			}
+			return kv, nil
+		}
+}
+
+// ActionKeyValueUsage returns the usage of the key-value storage of the organisation and the current value of the key,
+// the quotas of the storage are checked against it without replaying the history of the storage
+func (q *Queries) ActionKeyValueUsage(ctx context.Context, orgID, key string) (_ *domain.ActionKeyValueUsage, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	projection.ActionKeyValueProjection.Trigger(ctx)
+
+	query, scan := prepareActionKeyValueUsageQuery()
+	stmt, args, err := query.Where(sq.Eq{
+		ActionKeyValueColumnResourceOwner.identifier(): orgID,
+		ActionKeyValueColumnInstanceID.identifier():    authz.GetInstance(ctx).InstanceID(),
+		ActionKeyValueColumnOwnerRemoved.identifier():  false,
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ohc3u", "Errors.Query.SQLStatement")
+	}
+
+	usage, err := scan(q.client.QueryRowContext(ctx, stmt, args...))
+	if err != nil {
+		return nil, err
+	}
+	kv, err := q.ActionKeyValue(ctx, false, orgID, key)
+	if errors.IsNotFound(err) {
+		return usage, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	usage.Value = kv.Value
+	usage.Sequence = kv.Sequence
+	usage.ChangeDate = kv.ChangeDate
+	return usage, nil
+}
+
+func prepareActionKeyValueUsageQuery() (sq.SelectBuilder, func(*sql.Row) (*domain.ActionKeyValueUsage, error)) {
+	return sq.Select(
+			"COUNT(*)",
+			"COALESCE(SUM(LENGTH("+ActionKeyValueColumnValue.identifier()+")), 0)",
+		).
+			From(actionKeyValuesTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*domain.ActionKeyValueUsage, error) {
+			usage := new(domain.ActionKeyValueUsage)
+			err := row.Scan(
+				&usage.Entries,
+				&usage.TotalBytes,
+			)
+			if err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Eir2a", "Errors.Internal")
+			}
+			return usage, nil
+		}
+}
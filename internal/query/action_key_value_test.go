@@ -0,0 +1,168 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	actionKeyValueQuery = regexp.QuoteMeta(`SELECT projections.action_key_values.key,` +
+		` projections.action_key_values.creation_date,` +
+		` projections.action_key_values.change_date,` +
+		` projections.action_key_values.resource_owner,` +
+		` projections.action_key_values.sequence,` +
+		` projections.action_key_values.value` +
+		` FROM projections.action_key_values`)
+	actionKeyValueCols = []string{
+		"key",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+		"value",
+	}
+	actionKeyValueUsageQuery = regexp.QuoteMeta(`SELECT COUNT(*),` +
+		` COALESCE(SUM(LENGTH(projections.action_key_values.value)), 0)` +
+		` FROM projections.action_key_values`)
+	actionKeyValueUsageCols = []string{
+		"count",
+		"total_bytes",
+	}
+)
+
+func Test_ActionKeyValuePrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareActionKeyValueQuery no result",
+			prepare: prepareActionKeyValueQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					actionKeyValueQuery,
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*ActionKeyValue)(nil),
+		},
+		{
+			name:    "prepareActionKeyValueQuery found",
+			prepare: prepareActionKeyValueQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					actionKeyValueQuery,
+					actionKeyValueCols,
+					[]driver.Value{
+						"counter",
+						testNow,
+						testNow,
+						"ro",
+						uint64(20211108),
+						[]byte("1"),
+					},
+				),
+			},
+			object: &ActionKeyValue{
+				Key:           "counter",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				ResourceOwner: "ro",
+				Sequence:      20211108,
+				Value:         []byte("1"),
+			},
+		},
+		{
+			name:    "prepareActionKeyValueUsageQuery empty",
+			prepare: prepareActionKeyValueUsageQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					actionKeyValueUsageQuery,
+					actionKeyValueUsageCols,
+					[]driver.Value{
+						0,
+						0,
+					},
+				),
+			},
+			object: &domain.ActionKeyValueUsage{},
+		},
+		{
+			name:    "prepareActionKeyValueUsageQuery found",
+			prepare: prepareActionKeyValueUsageQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					actionKeyValueUsageQuery,
+					actionKeyValueUsageCols,
+					[]driver.Value{
+						2,
+						15,
+					},
+				),
+			},
+			object: &domain.ActionKeyValueUsage{
+				Entries:    2,
+				TotalBytes: 15,
+			},
+		},
+		{
+			name:    "prepareActionKeyValueUsageQuery sql err",
+			prepare: prepareActionKeyValueUsageQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					actionKeyValueUsageQuery,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+		{
+			name:    "prepareActionKeyValueQuery sql err",
+			prepare: prepareActionKeyValueQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					actionKeyValueQuery,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err)
+		})
+	}
+}
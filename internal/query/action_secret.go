@@ -0,0 +1,128 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	actionSecretsTable = table{
+		name:          projection.ActionSecretTable,
+		instanceIDCol: projection.ActionSecretInstanceIDCol,
+	}
+	ActionSecretColumnName = Column{
+		name:  projection.ActionSecretNameCol,
+		table: actionSecretsTable,
+	}
+	ActionSecretColumnCreationDate = Column{
+		name:  projection.ActionSecretCreationDateCol,
+		table: actionSecretsTable,
+	}
+	ActionSecretColumnChangeDate = Column{
+		name:  projection.ActionSecretChangeDateCol,
+		table: actionSecretsTable,
+	}
+	ActionSecretColumnSequence = Column{
+		name:  projection.ActionSecretSequenceCol,
+		table: actionSecretsTable,
+	}
+	ActionSecretColumnResourceOwner = Column{
+		name:  projection.ActionSecretResourceOwnerCol,
+		table: actionSecretsTable,
+	}
+	ActionSecretColumnInstanceID = Column{
+		name:  projection.ActionSecretInstanceIDCol,
+		table: actionSecretsTable,
+	}
+	ActionSecretColumnValue = Column{
+		name:  projection.ActionSecretValueCol,
+		table: actionSecretsTable,
+	}
+	ActionSecretColumnOwnerRemoved = Column{
+		name:  projection.ActionSecretOwnerRemovedCol,
+		table: actionSecretsTable,
+	}
+)
+
+// ActionSecret is a secret provided to the actions of the organisation by its name.
+// The value is encrypted and must never be returned by the API
+type ActionSecret struct {
+	Name          string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	Sequence      uint64
+	Value         *crypto.CryptoValue
+}
+
+// ActionSecrets returns all secrets of the organisation ordered by their name
+func (q *Queries) ActionSecrets(ctx context.Context, orgID string, withOwnerRemoved bool) (_ []*ActionSecret, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareActionSecretsQuery()
+	eq := sq.Eq{
+		ActionSecretColumnResourceOwner.identifier(): orgID,
+		ActionSecretColumnInstanceID.identifier():    authz.GetInstance(ctx).InstanceID(),
+	}
+	if !withOwnerRemoved {
+		eq[ActionSecretColumnOwnerRemoved.identifier()] = false
+	}
+	stmt, args, err := query.Where(eq).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-ohK4a", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Uaz9e", "Errors.Internal")
+	}
+	return scan(rows)
+}
+
+func prepareActionSecretsQuery() (sq.SelectBuilder, func(*sql.Rows) ([]*ActionSecret, error)) {
+	return sq.Select(
+			ActionSecretColumnName.identifier(),
+			ActionSecretColumnCreationDate.identifier(),
+			ActionSecretColumnChangeDate.identifier(),
+			ActionSecretColumnResourceOwner.identifier(),
+			ActionSecretColumnSequence.identifier(),
+			ActionSecretColumnValue.identifier(),
+		).
+			From(actionSecretsTable.identifier()).
+			OrderBy(ActionSecretColumnName.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) ([]*ActionSecret, error) {
+			secrets := make([]*ActionSecret, 0)
+			for rows.Next() {
+				secret := &ActionSecret{Value: new(crypto.CryptoValue)}
+				err := rows.Scan(
+					&secret.Name,
+					&secret.CreationDate,
+					&secret.ChangeDate,
+					&secret.ResourceOwner,
+					&secret.Sequence,
+					secret.Value,
+				)
+				if err != nil {
+					return nil, err
+				}
+				secrets = append(secrets, secret)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Xoo7i", "Errors.Query.CloseRows")
+			}
+
+			return secrets, nil
+		}
+}
@@ -0,0 +1,135 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+)
+
+var (
+	actionSecretsQuery = regexp.QuoteMeta(`SELECT projections.action_secrets.name,` +
+		` projections.action_secrets.creation_date,` +
+		` projections.action_secrets.change_date,` +
+		` projections.action_secrets.resource_owner,` +
+		` projections.action_secrets.sequence,` +
+		` projections.action_secrets.value` +
+		` FROM projections.action_secrets` +
+		` ORDER BY projections.action_secrets.name`)
+	actionSecretsCols = []string{
+		"name",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+		"value",
+	}
+)
+
+func Test_ActionSecretPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareActionSecretsQuery no result",
+			prepare: prepareActionSecretsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					actionSecretsQuery,
+					nil,
+					nil,
+				),
+			},
+			object: []*ActionSecret{},
+		},
+		{
+			name:    "prepareActionSecretsQuery multiple results",
+			prepare: prepareActionSecretsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					actionSecretsQuery,
+					actionSecretsCols,
+					[][]driver.Value{
+						{
+							"apiKey",
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211115),
+							[]byte(`{"cryptoType": 0, "algorithm": "enc", "keyID": "id", "crypted": "c2VjcmV0"}`),
+						},
+						{
+							"token",
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211116),
+							[]byte(`{"cryptoType": 0, "algorithm": "enc", "keyID": "id", "crypted": "dG9rZW4="}`),
+						},
+					},
+				),
+			},
+			object: []*ActionSecret{
+				{
+					Name:          "apiKey",
+					CreationDate:  testNow,
+					ChangeDate:    testNow,
+					ResourceOwner: "ro",
+					Sequence:      20211115,
+					Value: &crypto.CryptoValue{
+						CryptoType: crypto.TypeEncryption,
+						Algorithm:  "enc",
+						KeyID:      "id",
+						Crypted:    []byte("secret"),
+					},
+				},
+				{
+					Name:          "token",
+					CreationDate:  testNow,
+					ChangeDate:    testNow,
+					ResourceOwner: "ro",
+					Sequence:      20211116,
+					Value: &crypto.CryptoValue{
+						CryptoType: crypto.TypeEncryption,
+						Algorithm:  "enc",
+						KeyID:      "id",
+						Crypted:    []byte("token"),
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareActionSecretsQuery sql err",
+			prepare: prepareActionSecretsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					actionSecretsQuery,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err)
+		})
+	}
+}
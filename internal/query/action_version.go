@@ -0,0 +1,116 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/action"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// ActionVersion is the state of the action after it was added or changed
+type ActionVersion struct {
+	Version       uint64
+	CreationDate  time.Time
+	ResourceOwner string
+	Sequence      uint64
+	EditorUser    string
+	Name          string
+	Script        string
+	Timeout       time.Duration
+	AllowedToFail bool
+}
+
+// ActionVersions returns all versions of the action, starting with the oldest.
+// The versions are read from the events of the action, as every added and changed event creates a new version.
+func (q *Queries) ActionVersions(ctx context.Context, actionID, orgID string) (_ []*ActionVersion, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if actionID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "QUERY-eiR7o", "Errors.IDMissing")
+	}
+	model := NewActionVersionsReadModel(actionID, orgID)
+	if err = q.eventstore.FilterToQueryReducer(ctx, model); err != nil {
+		return nil, err
+	}
+	if !model.Exists {
+		return nil, errors.ThrowNotFound(nil, "QUERY-Ahng6", "Errors.Action.NotFound")
+	}
+	return model.Versions, nil
+}
+
+type ActionVersionsReadModel struct {
+	eventstore.WriteModel
+
+	Exists   bool
+	Versions []*ActionVersion
+}
+
+func NewActionVersionsReadModel(actionID, resourceOwner string) *ActionVersionsReadModel {
+	return &ActionVersionsReadModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   actionID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (rm *ActionVersionsReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *action.AddedEvent:
+			rm.Exists = true
+			rm.appendVersion(e, &ActionVersion{
+				Name:          e.Name,
+				Script:        e.Script,
+				Timeout:       e.Timeout,
+				AllowedToFail: e.AllowedToFail,
+			})
+		case *action.ChangedEvent:
+			if len(rm.Versions) == 0 {
+				continue
+			}
+			version := *rm.Versions[len(rm.Versions)-1]
+			if e.Name != nil {
+				version.Name = *e.Name
+			}
+			if e.Script != nil {
+				version.Script = *e.Script
+			}
+			if e.Timeout != nil {
+				version.Timeout = *e.Timeout
+			}
+			if e.AllowedToFail != nil {
+				version.AllowedToFail = *e.AllowedToFail
+			}
+			rm.appendVersion(e, &version)
+		case *action.RemovedEvent:
+			rm.Exists = false
+		}
+	}
+	return rm.WriteModel.Reduce()
+}
+
+func (rm *ActionVersionsReadModel) appendVersion(event eventstore.Event, version *ActionVersion) {
+	version.Version = uint64(len(rm.Versions) + 1)
+	version.CreationDate = event.CreationDate()
+	version.ResourceOwner = event.Aggregate().ResourceOwner
+	version.Sequence = event.Sequence()
+	version.EditorUser = event.EditorUser()
+	rm.Versions = append(rm.Versions, version)
+}
+
+func (rm *ActionVersionsReadModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(rm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(action.AggregateType).
+		AggregateIDs(rm.AggregateID).
+		EventTypes(action.AddedEventType,
+			action.ChangedEventType,
+			action.RemovedEventType).
+		Builder()
+}
@@ -0,0 +1,55 @@
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/repository/action"
+)
+
+func TestActionVersionsReadModel_Reduce(t *testing.T) {
+	ctx := authz.SetCtxData(context.Background(), authz.CtxData{UserID: "editor"})
+	agg := &action.NewAggregate("id1", "org1").Aggregate
+	changed := func(changes ...action.ActionChanges) *action.ChangedEvent {
+		event, _ := action.NewChangedEvent(ctx, agg, changes)
+		return event
+	}
+
+	model := NewActionVersionsReadModel("id1", "org1")
+	model.AppendEvents(
+		action.NewAddedEvent(ctx, agg, "name", "function name() {}", 0, false),
+		action.NewDeactivatedEvent(ctx, agg),
+		changed(action.ChangeScript("function name() { throw 'error' }")),
+		changed(action.ChangeName("name2", "name"), action.ChangeAllowedToFail(true)),
+	)
+	assert.NoError(t, model.Reduce())
+
+	assert.True(t, model.Exists)
+	assert.Equal(t, []*ActionVersion{
+		{
+			Version:       1,
+			ResourceOwner: "org1",
+			EditorUser:    "editor",
+			Name:          "name",
+			Script:        "function name() {}",
+		},
+		{
+			Version:       2,
+			ResourceOwner: "org1",
+			EditorUser:    "editor",
+			Name:          "name",
+			Script:        "function name() { throw 'error' }",
+		},
+		{
+			Version:       3,
+			ResourceOwner: "org1",
+			EditorUser:    "editor",
+			Name:          "name2",
+			Script:        "function name() { throw 'error' }",
+			AllowedToFail: true,
+		},
+	}, model.Versions)
+}
@@ -0,0 +1,233 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	customRolesTable = table{
+		name:          projection.CustomRoleProjectionTable,
+		instanceIDCol: projection.CustomRoleColumnInstanceID,
+	}
+	CustomRoleColumnInstanceID = Column{
+		name:  projection.CustomRoleColumnInstanceID,
+		table: customRolesTable,
+	}
+	CustomRoleColumnKey = Column{
+		name:  projection.CustomRoleColumnKey,
+		table: customRolesTable,
+	}
+	CustomRoleColumnCreationDate = Column{
+		name:  projection.CustomRoleColumnCreationDate,
+		table: customRolesTable,
+	}
+	CustomRoleColumnChangeDate = Column{
+		name:  projection.CustomRoleColumnChangeDate,
+		table: customRolesTable,
+	}
+	CustomRoleColumnResourceOwner = Column{
+		name:  projection.CustomRoleColumnResourceOwner,
+		table: customRolesTable,
+	}
+	CustomRoleColumnSequence = Column{
+		name:  projection.CustomRoleColumnSequence,
+		table: customRolesTable,
+	}
+	CustomRoleColumnDisplayName = Column{
+		name:  projection.CustomRoleColumnDisplayName,
+		table: customRolesTable,
+	}
+	CustomRoleColumnPermissions = Column{
+		name:  projection.CustomRoleColumnPermissions,
+		table: customRolesTable,
+	}
+)
+
+type CustomRoles struct {
+	SearchResponse
+	CustomRoles []*CustomRole
+}
+
+type CustomRole struct {
+	Key           string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	Sequence      uint64
+	DisplayName   string
+	Permissions   database.StringArray
+}
+
+type CustomRoleSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *CustomRoleSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func NewCustomRoleKeySearchQuery(method TextComparison, value string) (SearchQuery, error) {
+	return NewTextQuery(CustomRoleColumnKey, value, method)
+}
+
+func NewCustomRoleDisplayNameSearchQuery(method TextComparison, value string) (SearchQuery, error) {
+	return NewTextQuery(CustomRoleColumnDisplayName, value, method)
+}
+
+// CustomRoleMappings returns the permissions of all custom roles of the instance,
+// they complement the role mappings of the runtime configuration
+func (c *CustomRoles) CustomRoleMappings() []authz.RoleMapping {
+	mappings := make([]authz.RoleMapping, len(c.CustomRoles))
+	for i, role := range c.CustomRoles {
+		mappings[i] = authz.RoleMapping{
+			Role:        role.Key,
+			Permissions: role.Permissions,
+		}
+	}
+	return mappings
+}
+
+// zitadelRoleMappings returns the role mappings of the runtime configuration combined with the custom roles of the instance
+func (q *Queries) zitadelRoleMappings(ctx context.Context) ([]authz.RoleMapping, error) {
+	customRoles, err := q.SearchCustomRoles(ctx, &CustomRoleSearchQueries{})
+	if err != nil {
+		return nil, err
+	}
+	mappings := make([]authz.RoleMapping, 0, len(q.zitadelRoles)+len(customRoles.CustomRoles))
+	mappings = append(mappings, q.zitadelRoles...)
+	return append(mappings, customRoles.CustomRoleMappings()...), nil
+}
+
+func (q *Queries) SearchCustomRoles(ctx context.Context, queries *CustomRoleSearchQueries) (roles *CustomRoles, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareCustomRolesQuery()
+	stmt, args, err := queries.toQuery(query).
+		Where(sq.Eq{
+			CustomRoleColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Cr0sm", "Errors.Query.SQLStatement")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Lw2mf", "Errors.Internal")
+	}
+	roles, err = scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	roles.LatestSequence, err = q.latestSequence(ctx, customRolesTable)
+	return roles, err
+}
+
+func (q *Queries) CustomRoleByKey(ctx context.Context, key string) (_ *CustomRole, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	stmt, scan := prepareCustomRoleQuery()
+	query, args, err := stmt.Where(sq.Eq{
+		CustomRoleColumnKey.identifier():        key,
+		CustomRoleColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Mq8sk", "Errors.Query.SQLStatment")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+func prepareCustomRoleQuery() (sq.SelectBuilder, func(*sql.Row) (*CustomRole, error)) {
+	return sq.Select(
+			CustomRoleColumnKey.identifier(),
+			CustomRoleColumnCreationDate.identifier(),
+			CustomRoleColumnChangeDate.identifier(),
+			CustomRoleColumnResourceOwner.identifier(),
+			CustomRoleColumnSequence.identifier(),
+			CustomRoleColumnDisplayName.identifier(),
+			CustomRoleColumnPermissions.identifier()).
+			From(customRolesTable.identifier()).PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*CustomRole, error) {
+			role := new(CustomRole)
+			err := row.Scan(
+				&role.Key,
+				&role.CreationDate,
+				&role.ChangeDate,
+				&role.ResourceOwner,
+				&role.Sequence,
+				&role.DisplayName,
+				&role.Permissions,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Ws8nq", "Errors.IAM.CustomRole.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Pm2ls", "Errors.Internal")
+			}
+			return role, nil
+		}
+}
+
+func prepareCustomRolesQuery() (sq.SelectBuilder, func(*sql.Rows) (*CustomRoles, error)) {
+	return sq.Select(
+			CustomRoleColumnKey.identifier(),
+			CustomRoleColumnCreationDate.identifier(),
+			CustomRoleColumnChangeDate.identifier(),
+			CustomRoleColumnResourceOwner.identifier(),
+			CustomRoleColumnSequence.identifier(),
+			CustomRoleColumnDisplayName.identifier(),
+			CustomRoleColumnPermissions.identifier(),
+			countColumn.identifier()).
+			From(customRolesTable.identifier()).PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*CustomRoles, error) {
+			roles := make([]*CustomRole, 0)
+			var count uint64
+			for rows.Next() {
+				role := new(CustomRole)
+				err := rows.Scan(
+					&role.Key,
+					&role.CreationDate,
+					&role.ChangeDate,
+					&role.ResourceOwner,
+					&role.Sequence,
+					&role.DisplayName,
+					&role.Permissions,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				roles = append(roles, role)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Xm3sl", "Errors.Query.CloseRows")
+			}
+
+			return &CustomRoles{
+				CustomRoles: roles,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
@@ -0,0 +1,190 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	customRoleStmt = regexp.QuoteMeta(`SELECT projections.custom_roles.role_key,` +
+		` projections.custom_roles.creation_date,` +
+		` projections.custom_roles.change_date,` +
+		` projections.custom_roles.resource_owner,` +
+		` projections.custom_roles.sequence,` +
+		` projections.custom_roles.display_name,` +
+		` projections.custom_roles.permissions` +
+		` FROM projections.custom_roles`)
+	customRolesStmt = regexp.QuoteMeta(`SELECT projections.custom_roles.role_key,` +
+		` projections.custom_roles.creation_date,` +
+		` projections.custom_roles.change_date,` +
+		` projections.custom_roles.resource_owner,` +
+		` projections.custom_roles.sequence,` +
+		` projections.custom_roles.display_name,` +
+		` projections.custom_roles.permissions,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.custom_roles`)
+	customRoleCols = []string{
+		"role_key",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+		"display_name",
+		"permissions",
+	}
+)
+
+func Test_CustomRolePrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareCustomRoleQuery no result",
+			prepare: prepareCustomRoleQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					customRoleStmt,
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*CustomRole)(nil),
+		},
+		{
+			name:    "prepareCustomRoleQuery found",
+			prepare: prepareCustomRoleQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					customRoleStmt,
+					customRoleCols,
+					[]driver.Value{
+						"ORG_HELPDESK",
+						testNow,
+						testNow,
+						"ro",
+						uint64(20211108),
+						"Helpdesk",
+						database.StringArray{"user.read", "user.write"},
+					},
+				),
+			},
+			object: &CustomRole{
+				Key:           "ORG_HELPDESK",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				ResourceOwner: "ro",
+				Sequence:      20211108,
+				DisplayName:   "Helpdesk",
+				Permissions:   database.StringArray{"user.read", "user.write"},
+			},
+		},
+		{
+			name:    "prepareCustomRoleQuery sql err",
+			prepare: prepareCustomRoleQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					customRoleStmt,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+		{
+			name:    "prepareCustomRolesQuery no result",
+			prepare: prepareCustomRolesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					customRolesStmt,
+					nil,
+					nil,
+				),
+			},
+			object: &CustomRoles{CustomRoles: []*CustomRole{}},
+		},
+		{
+			name:    "prepareCustomRolesQuery one result",
+			prepare: prepareCustomRolesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					customRolesStmt,
+					append(customRoleCols, "count"),
+					[][]driver.Value{
+						{
+							"ORG_HELPDESK",
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211108),
+							"Helpdesk",
+							database.StringArray{"user.read"},
+						},
+					},
+				),
+			},
+			object: &CustomRoles{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				CustomRoles: []*CustomRole{
+					{
+						Key:           "ORG_HELPDESK",
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						ResourceOwner: "ro",
+						Sequence:      20211108,
+						DisplayName:   "Helpdesk",
+						Permissions:   database.StringArray{"user.read"},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareCustomRolesQuery sql err",
+			prepare: prepareCustomRolesQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					customRolesStmt,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err)
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	deviceAuthTable = table{
+		name:          projection.DeviceAuthProjectionTable,
+		instanceIDCol: projection.DeviceAuthColumnInstanceID,
+	}
+	DeviceAuthColumnID = Column{
+		name:  projection.DeviceAuthColumnID,
+		table: deviceAuthTable,
+	}
+	DeviceAuthColumnClientID = Column{
+		name:  projection.DeviceAuthColumnClientID,
+		table: deviceAuthTable,
+	}
+	DeviceAuthColumnDeviceCode = Column{
+		name:  projection.DeviceAuthColumnDeviceCode,
+		table: deviceAuthTable,
+	}
+	DeviceAuthColumnUserCode = Column{
+		name:  projection.DeviceAuthColumnUserCode,
+		table: deviceAuthTable,
+	}
+	DeviceAuthColumnExpires = Column{
+		name:  projection.DeviceAuthColumnExpires,
+		table: deviceAuthTable,
+	}
+	DeviceAuthColumnScopes = Column{
+		name:  projection.DeviceAuthColumnScopes,
+		table: deviceAuthTable,
+	}
+	DeviceAuthColumnState = Column{
+		name:  projection.DeviceAuthColumnState,
+		table: deviceAuthTable,
+	}
+	DeviceAuthColumnSubject = Column{
+		name:  projection.DeviceAuthColumnSubject,
+		table: deviceAuthTable,
+	}
+	DeviceAuthColumnCreationDate = Column{
+		name:  projection.DeviceAuthColumnCreationDate,
+		table: deviceAuthTable,
+	}
+	DeviceAuthColumnChangeDate = Column{
+		name:  projection.DeviceAuthColumnChangeDate,
+		table: deviceAuthTable,
+	}
+	DeviceAuthColumnSequence = Column{
+		name:  projection.DeviceAuthColumnSequence,
+		table: deviceAuthTable,
+	}
+	DeviceAuthColumnInstanceID = Column{
+		name:  projection.DeviceAuthColumnInstanceID,
+		table: deviceAuthTable,
+	}
+)
+
+type DeviceAuth struct {
+	ID           string
+	CreationDate time.Time
+	ChangeDate   time.Time
+	Sequence     uint64
+
+	ClientID   string
+	DeviceCode string
+	UserCode   string
+	Expires    time.Time
+	Scopes     database.StringArray
+	State      domain.DeviceAuthState
+	Subject    string
+}
+
+// DeviceAuthByDeviceCode is used by the token endpoint, where the client polls with its device code
+func (q *Queries) DeviceAuthByDeviceCode(ctx context.Context, clientID, deviceCode string) (_ *DeviceAuth, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	stmt, scan := prepareDeviceAuthQuery()
+	query, args, err := stmt.Where(sq.Eq{
+		DeviceAuthColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		DeviceAuthColumnClientID.identifier():   clientID,
+		DeviceAuthColumnDeviceCode.identifier(): deviceCode,
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ai9ae", "Errors.Query.SQLStatment")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+// DeviceAuthByUserCode is used by the login, where the user enters the code displayed on the device
+func (q *Queries) DeviceAuthByUserCode(ctx context.Context, userCode string) (_ *DeviceAuth, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	stmt, scan := prepareDeviceAuthQuery()
+	query, args, err := stmt.Where(sq.Eq{
+		DeviceAuthColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		DeviceAuthColumnUserCode.identifier():   userCode,
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-ohY7e", "Errors.Query.SQLStatment")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+func prepareDeviceAuthQuery() (sq.SelectBuilder, func(*sql.Row) (*DeviceAuth, error)) {
+	return sq.Select(
+			DeviceAuthColumnID.identifier(),
+			DeviceAuthColumnCreationDate.identifier(),
+			DeviceAuthColumnChangeDate.identifier(),
+			DeviceAuthColumnSequence.identifier(),
+			DeviceAuthColumnClientID.identifier(),
+			DeviceAuthColumnDeviceCode.identifier(),
+			DeviceAuthColumnUserCode.identifier(),
+			DeviceAuthColumnExpires.identifier(),
+			DeviceAuthColumnScopes.identifier(),
+			DeviceAuthColumnState.identifier(),
+			DeviceAuthColumnSubject.identifier()).
+			From(deviceAuthTable.identifier()).PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*DeviceAuth, error) {
+			deviceAuth := new(DeviceAuth)
+			subject := sql.NullString{}
+			err := row.Scan(
+				&deviceAuth.ID,
+				&deviceAuth.CreationDate,
+				&deviceAuth.ChangeDate,
+				&deviceAuth.Sequence,
+				&deviceAuth.ClientID,
+				&deviceAuth.DeviceCode,
+				&deviceAuth.UserCode,
+				&deviceAuth.Expires,
+				&deviceAuth.Scopes,
+				&deviceAuth.State,
+				&subject,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Oov4x", "Errors.DeviceAuth.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-iel3A", "Errors.Internal")
+			}
+			deviceAuth.Subject = subject.String
+			return deviceAuth, nil
+		}
+}
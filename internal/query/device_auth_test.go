@@ -0,0 +1,132 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	prepareDeviceAuthStmt = `SELECT projections.device_authorizations.id,` +
+		` projections.device_authorizations.creation_date,` +
+		` projections.device_authorizations.change_date,` +
+		` projections.device_authorizations.sequence,` +
+		` projections.device_authorizations.client_id,` +
+		` projections.device_authorizations.device_code,` +
+		` projections.device_authorizations.user_code,` +
+		` projections.device_authorizations.expires,` +
+		` projections.device_authorizations.scopes,` +
+		` projections.device_authorizations.state,` +
+		` projections.device_authorizations.subject` +
+		` FROM projections.device_authorizations`
+	prepareDeviceAuthCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"client_id",
+		"device_code",
+		"user_code",
+		"expires",
+		"scopes",
+		"state",
+		"subject",
+	}
+)
+
+func Test_DeviceAuthPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareDeviceAuthQuery no result",
+			prepare: prepareDeviceAuthQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareDeviceAuthStmt),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*DeviceAuth)(nil),
+		},
+		{
+			name:    "prepareDeviceAuthQuery found",
+			prepare: prepareDeviceAuthQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareDeviceAuthStmt),
+					prepareDeviceAuthCols,
+					[]driver.Value{
+						"id",
+						testNow,
+						testNow,
+						uint64(20211108),
+						"client1",
+						"device1",
+						"BCDF-GHJK",
+						testNow,
+						database.StringArray{"openid"},
+						domain.DeviceAuthStateApproved,
+						"user1",
+					},
+				),
+			},
+			object: &DeviceAuth{
+				ID:           "id",
+				CreationDate: testNow,
+				ChangeDate:   testNow,
+				Sequence:     20211108,
+				ClientID:     "client1",
+				DeviceCode:   "device1",
+				UserCode:     "BCDF-GHJK",
+				Expires:      testNow,
+				Scopes:       database.StringArray{"openid"},
+				State:        domain.DeviceAuthStateApproved,
+				Subject:      "user1",
+			},
+		},
+		{
+			name:    "prepareDeviceAuthQuery sql err",
+			prepare: prepareDeviceAuthQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareDeviceAuthStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err)
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	eventActionsTable = table{
+		name:          projection.EventActionsTable,
+		instanceIDCol: projection.EventActionsInstanceIDCol,
+	}
+	EventActionsColumnEventType = Column{
+		name:  projection.EventActionsEventTypeCol,
+		table: eventActionsTable,
+	}
+	EventActionsColumnChangeDate = Column{
+		name:  projection.EventActionsChangeDateCol,
+		table: eventActionsTable,
+	}
+	EventActionsColumnSequence = Column{
+		name:  projection.EventActionsSequenceCol,
+		table: eventActionsTable,
+	}
+	EventActionsColumnResourceOwner = Column{
+		name:  projection.EventActionsResourceOwnerCol,
+		table: eventActionsTable,
+	}
+	EventActionsColumnInstanceID = Column{
+		name:  projection.EventActionsInstanceIDCol,
+		table: eventActionsTable,
+	}
+	EventActionsColumnActionSequence = Column{
+		name:  projection.EventActionsActionSequenceCol,
+		table: eventActionsTable,
+	}
+	EventActionsColumnActionID = Column{
+		name:  projection.EventActionsActionIDCol,
+		table: eventActionsTable,
+	}
+	EventActionsColumnOwnerRemoved = Column{
+		name:  projection.EventActionsOwnerRemovedCol,
+		table: eventActionsTable,
+	}
+)
+
+// EventActions are the actions subscribed to an event type of an organisation
+type EventActions struct {
+	EventType     string
+	ChangeDate    time.Time
+	ResourceOwner string
+	Sequence      uint64
+
+	Actions []*Action
+}
+
+// GetEventActions returns all event types of the organisation with their subscribed actions
+func (q *Queries) GetEventActions(ctx context.Context, orgID string, withOwnerRemoved bool) (_ []*EventActions, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareEventActionsQuery()
+	eq := sq.Eq{
+		EventActionsColumnResourceOwner.identifier(): orgID,
+		EventActionsColumnInstanceID.identifier():    authz.GetInstance(ctx).InstanceID(),
+	}
+	if !withOwnerRemoved {
+		eq[EventActionsColumnOwnerRemoved.identifier()] = false
+	}
+	stmt, args, err := query.Where(eq).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-ua3Ae", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Sha9e", "Errors.Internal")
+	}
+	return scan(rows)
+}
+
+// GetActiveActionsByEventType returns the active actions subscribed to the event type in the order they have to be executed
+func (q *Queries) GetActiveActionsByEventType(ctx context.Context, eventType, orgID string) (_ []*Action, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareEventActionsActionsQuery()
+	stmt, args, err := query.Where(sq.Eq{
+		EventActionsColumnEventType.identifier():     eventType,
+		EventActionsColumnResourceOwner.identifier(): orgID,
+		EventActionsColumnInstanceID.identifier():    authz.GetInstance(ctx).InstanceID(),
+		EventActionsColumnOwnerRemoved.identifier():  false,
+		ActionColumnState.identifier():               domain.ActionStateActive,
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Eeh4o", "Errors.Query.SQLStatement")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-ohB2a", "Errors.Internal")
+	}
+	return scan(rows)
+}
+
+func prepareEventActionsActionsQuery() (sq.SelectBuilder, func(*sql.Rows) ([]*Action, error)) {
+	return sq.Select(
+			ActionColumnID.identifier(),
+			ActionColumnCreationDate.identifier(),
+			ActionColumnChangeDate.identifier(),
+			ActionColumnResourceOwner.identifier(),
+			ActionColumnState.identifier(),
+			ActionColumnSequence.identifier(),
+			ActionColumnName.identifier(),
+			ActionColumnScript.identifier(),
+			ActionColumnAllowedToFail.identifier(),
+			ActionColumnTimeout.identifier(),
+		).
+			From(eventActionsTable.name).
+			LeftJoin(join(ActionColumnID, EventActionsColumnActionID)).
+			OrderBy(EventActionsColumnActionSequence.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) ([]*Action, error) {
+			actions := make([]*Action, 0)
+			for rows.Next() {
+				action := new(Action)
+				err := rows.Scan(
+					&action.ID,
+					&action.CreationDate,
+					&action.ChangeDate,
+					&action.ResourceOwner,
+					&action.State,
+					&action.Sequence,
+					&action.Name,
+					&action.Script,
+					&action.AllowedToFail,
+					&action.timeout,
+				)
+				if err != nil {
+					return nil, err
+				}
+				actions = append(actions, action)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Aiy5u", "Errors.Query.CloseRows")
+			}
+
+			return actions, nil
+		}
+}
+
+func prepareEventActionsQuery() (sq.SelectBuilder, func(*sql.Rows) ([]*EventActions, error)) {
+	return sq.Select(
+			ActionColumnID.identifier(),
+			ActionColumnCreationDate.identifier(),
+			ActionColumnChangeDate.identifier(),
+			ActionColumnResourceOwner.identifier(),
+			ActionColumnState.identifier(),
+			ActionColumnSequence.identifier(),
+			ActionColumnName.identifier(),
+			ActionColumnScript.identifier(),
+			ActionColumnAllowedToFail.identifier(),
+			ActionColumnTimeout.identifier(),
+			EventActionsColumnEventType.identifier(),
+			EventActionsColumnChangeDate.identifier(),
+			EventActionsColumnSequence.identifier(),
+			EventActionsColumnResourceOwner.identifier(),
+		).
+			From(eventActionsTable.name).
+			LeftJoin(join(ActionColumnID, EventActionsColumnActionID)).
+			OrderBy(EventActionsColumnEventType.identifier(), EventActionsColumnActionSequence.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) ([]*EventActions, error) {
+			eventActions := make([]*EventActions, 0)
+			var current *EventActions
+			for rows.Next() {
+				var (
+					actionID            sql.NullString
+					actionCreationDate  sql.NullTime
+					actionChangeDate    sql.NullTime
+					actionResourceOwner sql.NullString
+					actionState         sql.NullInt32
+					actionSequence      sql.NullInt64
+					actionName          sql.NullString
+					actionScript        sql.NullString
+					actionAllowedToFail sql.NullBool
+					actionTimeout       sql.NullInt64
+
+					eventType     string
+					changeDate    time.Time
+					sequence      uint64
+					resourceOwner string
+				)
+				err := rows.Scan(
+					&actionID,
+					&actionCreationDate,
+					&actionChangeDate,
+					&actionResourceOwner,
+					&actionState,
+					&actionSequence,
+					&actionName,
+					&actionScript,
+					&actionAllowedToFail,
+					&actionTimeout,
+					&eventType,
+					&changeDate,
+					&sequence,
+					&resourceOwner,
+				)
+				if err != nil {
+					return nil, err
+				}
+				if current == nil || current.EventType != eventType {
+					current = &EventActions{
+						EventType:     eventType,
+						ChangeDate:    changeDate,
+						ResourceOwner: resourceOwner,
+						Sequence:      sequence,
+						Actions:       []*Action{},
+					}
+					eventActions = append(eventActions, current)
+				}
+				if !actionID.Valid {
+					continue
+				}
+				current.Actions = append(current.Actions, &Action{
+					ID:            actionID.String,
+					CreationDate:  actionCreationDate.Time,
+					ChangeDate:    actionChangeDate.Time,
+					ResourceOwner: actionResourceOwner.String,
+					State:         domain.ActionState(actionState.Int32),
+					Sequence:      uint64(actionSequence.Int64),
+					Name:          actionName.String,
+					Script:        actionScript.String,
+					AllowedToFail: actionAllowedToFail.Bool,
+					timeout:       time.Duration(actionTimeout.Int64),
+				})
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Ohp3a", "Errors.Query.CloseRows")
+			}
+
+			return eventActions, nil
+		}
+}
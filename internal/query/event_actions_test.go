@@ -0,0 +1,317 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+var (
+	eventActionsActionsQuery = regexp.QuoteMeta(`SELECT projections.actions3.id,` +
+		` projections.actions3.creation_date,` +
+		` projections.actions3.change_date,` +
+		` projections.actions3.resource_owner,` +
+		` projections.actions3.action_state,` +
+		` projections.actions3.sequence,` +
+		` projections.actions3.name,` +
+		` projections.actions3.script,` +
+		` projections.actions3.allowed_to_fail,` +
+		` projections.actions3.timeout` +
+		` FROM projections.event_actions` +
+		` LEFT JOIN projections.actions3 ON projections.event_actions.action_id = projections.actions3.id AND projections.event_actions.instance_id = projections.actions3.instance_id` +
+		` ORDER BY projections.event_actions.action_sequence`)
+	eventActionsActionsCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"state",
+		"sequence",
+		"name",
+		"script",
+		"allowed_to_fail",
+		"timeout",
+	}
+	eventActionsQuery = regexp.QuoteMeta(`SELECT projections.actions3.id,` +
+		` projections.actions3.creation_date,` +
+		` projections.actions3.change_date,` +
+		` projections.actions3.resource_owner,` +
+		` projections.actions3.action_state,` +
+		` projections.actions3.sequence,` +
+		` projections.actions3.name,` +
+		` projections.actions3.script,` +
+		` projections.actions3.allowed_to_fail,` +
+		` projections.actions3.timeout,` +
+		` projections.event_actions.event_type,` +
+		` projections.event_actions.change_date,` +
+		` projections.event_actions.sequence,` +
+		` projections.event_actions.resource_owner` +
+		` FROM projections.event_actions` +
+		` LEFT JOIN projections.actions3 ON projections.event_actions.action_id = projections.actions3.id AND projections.event_actions.instance_id = projections.actions3.instance_id` +
+		` ORDER BY projections.event_actions.event_type, projections.event_actions.action_sequence`)
+	eventActionsCols = append(eventActionsActionsCols,
+		"event_type",
+		"change_date",
+		"sequence",
+		"resource_owner",
+	)
+)
+
+func Test_EventActionsPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareEventActionsActionsQuery no result",
+			prepare: prepareEventActionsActionsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					eventActionsActionsQuery,
+					nil,
+					nil,
+				),
+			},
+			object: []*Action{},
+		},
+		{
+			name:    "prepareEventActionsActionsQuery multiple results",
+			prepare: prepareEventActionsActionsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					eventActionsActionsQuery,
+					eventActionsActionsCols,
+					[][]driver.Value{
+						{
+							"action-id-1",
+							testNow,
+							testNow,
+							"ro",
+							domain.ActionStateActive,
+							uint64(20211115),
+							"action-name-1",
+							"script",
+							true,
+							10000000000,
+						},
+						{
+							"action-id-2",
+							testNow,
+							testNow,
+							"ro",
+							domain.ActionStateActive,
+							uint64(20211115),
+							"action-name-2",
+							"script",
+							false,
+							5000000000,
+						},
+					},
+				),
+			},
+			object: []*Action{
+				{
+					ID:            "action-id-1",
+					CreationDate:  testNow,
+					ChangeDate:    testNow,
+					ResourceOwner: "ro",
+					State:         domain.ActionStateActive,
+					Sequence:      20211115,
+					Name:          "action-name-1",
+					Script:        "script",
+					AllowedToFail: true,
+					timeout:       10 * time.Second,
+				},
+				{
+					ID:            "action-id-2",
+					CreationDate:  testNow,
+					ChangeDate:    testNow,
+					ResourceOwner: "ro",
+					State:         domain.ActionStateActive,
+					Sequence:      20211115,
+					Name:          "action-name-2",
+					Script:        "script",
+					AllowedToFail: false,
+					timeout:       5 * time.Second,
+				},
+			},
+		},
+		{
+			name:    "prepareEventActionsActionsQuery sql err",
+			prepare: prepareEventActionsActionsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					eventActionsActionsQuery,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+		{
+			name:    "prepareEventActionsQuery no result",
+			prepare: prepareEventActionsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					eventActionsQuery,
+					nil,
+					nil,
+				),
+			},
+			object: []*EventActions{},
+		},
+		{
+			name:    "prepareEventActionsQuery multiple event types",
+			prepare: prepareEventActionsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					eventActionsQuery,
+					eventActionsCols,
+					[][]driver.Value{
+						{
+							"action-id-1",
+							testNow,
+							testNow,
+							"ro",
+							domain.ActionStateActive,
+							uint64(20211115),
+							"action-name-1",
+							"script",
+							true,
+							10000000000,
+							"user.grant.added",
+							testNow,
+							uint64(20211115),
+							"ro",
+						},
+						{
+							"action-id-1",
+							testNow,
+							testNow,
+							"ro",
+							domain.ActionStateActive,
+							uint64(20211115),
+							"action-name-1",
+							"script",
+							true,
+							10000000000,
+							"user.human.added",
+							testNow,
+							uint64(20211116),
+							"ro",
+						},
+						{
+							"action-id-2",
+							testNow,
+							testNow,
+							"ro",
+							domain.ActionStateInactive,
+							uint64(20211115),
+							"action-name-2",
+							"script",
+							false,
+							5000000000,
+							"user.human.added",
+							testNow,
+							uint64(20211116),
+							"ro",
+						},
+					},
+				),
+			},
+			object: []*EventActions{
+				{
+					EventType:     "user.grant.added",
+					ChangeDate:    testNow,
+					ResourceOwner: "ro",
+					Sequence:      20211115,
+					Actions: []*Action{
+						{
+							ID:            "action-id-1",
+							CreationDate:  testNow,
+							ChangeDate:    testNow,
+							ResourceOwner: "ro",
+							State:         domain.ActionStateActive,
+							Sequence:      20211115,
+							Name:          "action-name-1",
+							Script:        "script",
+							AllowedToFail: true,
+							timeout:       10 * time.Second,
+						},
+					},
+				},
+				{
+					EventType:     "user.human.added",
+					ChangeDate:    testNow,
+					ResourceOwner: "ro",
+					Sequence:      20211116,
+					Actions: []*Action{
+						{
+							ID:            "action-id-1",
+							CreationDate:  testNow,
+							ChangeDate:    testNow,
+							ResourceOwner: "ro",
+							State:         domain.ActionStateActive,
+							Sequence:      20211115,
+							Name:          "action-name-1",
+							Script:        "script",
+							AllowedToFail: true,
+							timeout:       10 * time.Second,
+						},
+						{
+							ID:            "action-id-2",
+							CreationDate:  testNow,
+							ChangeDate:    testNow,
+							ResourceOwner: "ro",
+							State:         domain.ActionStateInactive,
+							Sequence:      20211115,
+							Name:          "action-name-2",
+							Script:        "script",
+							AllowedToFail: false,
+							timeout:       5 * time.Second,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareEventActionsQuery sql err",
+			prepare: prepareEventActionsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					eventActionsQuery,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err)
+		})
+	}
+}
@@ -15,59 +15,113 @@ import (
 )
 
 var (
-	idpTemplateQuery = `SELECT projections.idp_templates.id,` +
-		` projections.idp_templates.resource_owner,` +
-		` projections.idp_templates.creation_date,` +
-		` projections.idp_templates.change_date,` +
-		` projections.idp_templates.sequence,` +
-		` projections.idp_templates.state,` +
-		` projections.idp_templates.name,` +
-		` projections.idp_templates.type,` +
-		` projections.idp_templates.owner_type,` +
-		` projections.idp_templates.is_creation_allowed,` +
-		` projections.idp_templates.is_linking_allowed,` +
-		` projections.idp_templates.is_auto_creation,` +
-		` projections.idp_templates.is_auto_update,` +
+	idpTemplateQuery = `SELECT projections.idp_templates2.id,` +
+		` projections.idp_templates2.resource_owner,` +
+		` projections.idp_templates2.creation_date,` +
+		` projections.idp_templates2.change_date,` +
+		` projections.idp_templates2.sequence,` +
+		` projections.idp_templates2.state,` +
+		` projections.idp_templates2.name,` +
+		` projections.idp_templates2.type,` +
+		` projections.idp_templates2.owner_type,` +
+		` projections.idp_templates2.is_creation_allowed,` +
+		` projections.idp_templates2.is_linking_allowed,` +
+		` projections.idp_templates2.is_auto_creation,` +
+		` projections.idp_templates2.is_auto_update,` +
 		// oauth
-		` projections.idp_templates_oauth.idp_id,` +
-		` projections.idp_templates_oauth.client_id,` +
-		` projections.idp_templates_oauth.client_secret,` +
-		` projections.idp_templates_oauth.authorization_endpoint,` +
-		` projections.idp_templates_oauth.token_endpoint,` +
-		` projections.idp_templates_oauth.user_endpoint,` +
-		` projections.idp_templates_oauth.scopes,` +
+		` projections.idp_templates2_oauth.idp_id,` +
+		` projections.idp_templates2_oauth.client_id,` +
+		` projections.idp_templates2_oauth.client_secret,` +
+		` projections.idp_templates2_oauth.authorization_endpoint,` +
+		` projections.idp_templates2_oauth.token_endpoint,` +
+		` projections.idp_templates2_oauth.user_endpoint,` +
+		` projections.idp_templates2_oauth.scopes,` +
 		// google
-		` projections.idp_templates_google.idp_id,` +
-		` projections.idp_templates_google.client_id,` +
-		` projections.idp_templates_google.client_secret,` +
-		` projections.idp_templates_google.scopes,` +
+		` projections.idp_templates2_google.idp_id,` +
+		` projections.idp_templates2_google.client_id,` +
+		` projections.idp_templates2_google.client_secret,` +
+		` projections.idp_templates2_google.scopes,` +
 		// ldap
-		` projections.idp_templates_ldap.idp_id,` +
-		` projections.idp_templates_ldap.host,` +
-		` projections.idp_templates_ldap.port,` +
-		` projections.idp_templates_ldap.tls,` +
-		` projections.idp_templates_ldap.base_dn,` +
-		` projections.idp_templates_ldap.user_object_class,` +
-		` projections.idp_templates_ldap.user_unique_attribute,` +
-		` projections.idp_templates_ldap.admin,` +
-		` projections.idp_templates_ldap.password,` +
-		` projections.idp_templates_ldap.id_attribute,` +
-		` projections.idp_templates_ldap.first_name_attribute,` +
-		` projections.idp_templates_ldap.last_name_attribute,` +
-		` projections.idp_templates_ldap.display_name_attribute,` +
-		` projections.idp_templates_ldap.nick_name_attribute,` +
-		` projections.idp_templates_ldap.preferred_username_attribute,` +
-		` projections.idp_templates_ldap.email_attribute,` +
-		` projections.idp_templates_ldap.email_verified,` +
-		` projections.idp_templates_ldap.phone_attribute,` +
-		` projections.idp_templates_ldap.phone_verified_attribute,` +
-		` projections.idp_templates_ldap.preferred_language_attribute,` +
-		` projections.idp_templates_ldap.avatar_url_attribute,` +
-		` projections.idp_templates_ldap.profile_attribute` +
-		` FROM projections.idp_templates` +
-		` LEFT JOIN projections.idp_templates_oauth ON projections.idp_templates.id = projections.idp_templates_oauth.idp_id AND projections.idp_templates.instance_id = projections.idp_templates_oauth.instance_id` +
-		` LEFT JOIN projections.idp_templates_google ON projections.idp_templates.id = projections.idp_templates_google.idp_id AND projections.idp_templates.instance_id = projections.idp_templates_google.instance_id` +
-		` LEFT JOIN projections.idp_templates_ldap ON projections.idp_templates.id = projections.idp_templates_ldap.idp_id AND projections.idp_templates.instance_id = projections.idp_templates_ldap.instance_id`
+		` projections.idp_templates2_ldap.idp_id,` +
+		` projections.idp_templates2_ldap.host,` +
+		` projections.idp_templates2_ldap.port,` +
+		` projections.idp_templates2_ldap.tls,` +
+		` projections.idp_templates2_ldap.base_dn,` +
+		` projections.idp_templates2_ldap.user_object_class,` +
+		` projections.idp_templates2_ldap.user_unique_attribute,` +
+		` projections.idp_templates2_ldap.admin,` +
+		` projections.idp_templates2_ldap.password,` +
+		` projections.idp_templates2_ldap.id_attribute,` +
+		` projections.idp_templates2_ldap.first_name_attribute,` +
+		` projections.idp_templates2_ldap.last_name_attribute,` +
+		` projections.idp_templates2_ldap.display_name_attribute,` +
+		` projections.idp_templates2_ldap.nick_name_attribute,` +
+		` projections.idp_templates2_ldap.preferred_username_attribute,` +
+		` projections.idp_templates2_ldap.email_attribute,` +
+		` projections.idp_templates2_ldap.email_verified,` +
+		` projections.idp_templates2_ldap.phone_attribute,` +
+		` projections.idp_templates2_ldap.phone_verified_attribute,` +
+		` projections.idp_templates2_ldap.preferred_language_attribute,` +
+		` projections.idp_templates2_ldap.avatar_url_attribute,` +
+		` projections.idp_templates2_ldap.profile_attribute,` +
+		` projections.idp_templates2_ldap.groups_attribute,` +
+		` projections.idp_templates2_ldap.disabled_attribute,` +
+		` projections.idp_templates2_ldap.group_mappings,` +
+		// saml
+		` projections.idp_templates2_saml.idp_id,` +
+		` projections.idp_templates2_saml.metadata,` +
+		` projections.idp_templates2_saml.metadata_url,` +
+		` projections.idp_templates2_saml.key,` +
+		` projections.idp_templates2_saml.certificate,` +
+		` projections.idp_templates2_saml.with_signed_request,` +
+		// oidc
+		` projections.idp_templates2_oidc.idp_id,` +
+		` projections.idp_templates2_oidc.issuer,` +
+		` projections.idp_templates2_oidc.client_id,` +
+		` projections.idp_templates2_oidc.client_secret,` +
+		` projections.idp_templates2_oidc.scopes,` +
+		// azure
+		` projections.idp_templates2_azure.idp_id,` +
+		` projections.idp_templates2_azure.client_id,` +
+		` projections.idp_templates2_azure.client_secret,` +
+		` projections.idp_templates2_azure.scopes,` +
+		` projections.idp_templates2_azure.tenant,` +
+		` projections.idp_templates2_azure.is_email_verified,` +
+		// github
+		` projections.idp_templates2_github.idp_id,` +
+		` projections.idp_templates2_github.client_id,` +
+		` projections.idp_templates2_github.client_secret,` +
+		` projections.idp_templates2_github.scopes,` +
+		// github enterprise
+		` projections.idp_templates2_github_enterprise.idp_id,` +
+		` projections.idp_templates2_github_enterprise.client_id,` +
+		` projections.idp_templates2_github_enterprise.client_secret,` +
+		` projections.idp_templates2_github_enterprise.authorization_endpoint,` +
+		` projections.idp_templates2_github_enterprise.token_endpoint,` +
+		` projections.idp_templates2_github_enterprise.user_endpoint,` +
+		` projections.idp_templates2_github_enterprise.scopes,` +
+		// gitlab
+		` projections.idp_templates2_gitlab.idp_id,` +
+		` projections.idp_templates2_gitlab.client_id,` +
+		` projections.idp_templates2_gitlab.client_secret,` +
+		` projections.idp_templates2_gitlab.scopes,` +
+		// gitlab self hosted
+		` projections.idp_templates2_gitlab_self_hosted.idp_id,` +
+		` projections.idp_templates2_gitlab_self_hosted.issuer,` +
+		` projections.idp_templates2_gitlab_self_hosted.client_id,` +
+		` projections.idp_templates2_gitlab_self_hosted.client_secret,` +
+		` projections.idp_templates2_gitlab_self_hosted.scopes` +
+		` FROM projections.idp_templates2` +
+		` LEFT JOIN projections.idp_templates2_oauth ON projections.idp_templates2.id = projections.idp_templates2_oauth.idp_id AND projections.idp_templates2.instance_id = projections.idp_templates2_oauth.instance_id` +
+		` LEFT JOIN projections.idp_templates2_google ON projections.idp_templates2.id = projections.idp_templates2_google.idp_id AND projections.idp_templates2.instance_id = projections.idp_templates2_google.instance_id` +
+		` LEFT JOIN projections.idp_templates2_ldap ON projections.idp_templates2.id = projections.idp_templates2_ldap.idp_id AND projections.idp_templates2.instance_id = projections.idp_templates2_ldap.instance_id` +
+		` LEFT JOIN projections.idp_templates2_saml ON projections.idp_templates2.id = projections.idp_templates2_saml.idp_id AND projections.idp_templates2.instance_id = projections.idp_templates2_saml.instance_id` +
+		` LEFT JOIN projections.idp_templates2_oidc ON projections.idp_templates2.id = projections.idp_templates2_oidc.idp_id AND projections.idp_templates2.instance_id = projections.idp_templates2_oidc.instance_id` +
+		` LEFT JOIN projections.idp_templates2_azure ON projections.idp_templates2.id = projections.idp_templates2_azure.idp_id AND projections.idp_templates2.instance_id = projections.idp_templates2_azure.instance_id` +
+		` LEFT JOIN projections.idp_templates2_github ON projections.idp_templates2.id = projections.idp_templates2_github.idp_id AND projections.idp_templates2.instance_id = projections.idp_templates2_github.instance_id` +
+		` LEFT JOIN projections.idp_templates2_github_enterprise ON projections.idp_templates2.id = projections.idp_templates2_github_enterprise.idp_id AND projections.idp_templates2.instance_id = projections.idp_templates2_github_enterprise.instance_id` +
+		` LEFT JOIN projections.idp_templates2_gitlab ON projections.idp_templates2.id = projections.idp_templates2_gitlab.idp_id AND projections.idp_templates2.instance_id = projections.idp_templates2_gitlab.instance_id` +
+		` LEFT JOIN projections.idp_templates2_gitlab_self_hosted ON projections.idp_templates2.id = projections.idp_templates2_gitlab_self_hosted.idp_id AND projections.idp_templates2.instance_id = projections.idp_templates2_gitlab_self_hosted.instance_id`
 	idpTemplateCols = []string{
 		"id",
 		"resource_owner",
@@ -118,61 +172,162 @@ var (
 		"preferred_language_attribute",
 		"avatar_url_attribute",
 		"profile_attribute",
+		"groups_attribute",
+		"disabled_attribute",
+		"group_mappings",
+		// saml config
+		"idp_id",
+		"metadata",
+		"metadata_url",
+		"key",
+		"certificate",
+		"with_signed_request",
+		// oidc config
+		"idp_id",
+		"issuer",
+		"client_id",
+		"client_secret",
+		"scopes",
+		// azure config
+		"idp_id",
+		"client_id",
+		"client_secret",
+		"scopes",
+		"tenant",
+		"is_email_verified",
+		// github config
+		"idp_id",
+		"client_id",
+		"client_secret",
+		"scopes",
+		// github enterprise config
+		"idp_id",
+		"client_id",
+		"client_secret",
+		"authorization_endpoint",
+		"token_endpoint",
+		"user_endpoint",
+		"scopes",
+		// gitlab config
+		"idp_id",
+		"client_id",
+		"client_secret",
+		"scopes",
+		// gitlab self hosted config
+		"idp_id",
+		"issuer",
+		"client_id",
+		"client_secret",
+		"scopes",
 	}
-	idpTemplatesQuery = `SELECT projections.idp_templates.id,` +
-		` projections.idp_templates.resource_owner,` +
-		` projections.idp_templates.creation_date,` +
-		` projections.idp_templates.change_date,` +
-		` projections.idp_templates.sequence,` +
-		` projections.idp_templates.state,` +
-		` projections.idp_templates.name,` +
-		` projections.idp_templates.type,` +
-		` projections.idp_templates.owner_type,` +
-		` projections.idp_templates.is_creation_allowed,` +
-		` projections.idp_templates.is_linking_allowed,` +
-		` projections.idp_templates.is_auto_creation,` +
-		` projections.idp_templates.is_auto_update,` +
+	idpTemplatesQuery = `SELECT projections.idp_templates2.id,` +
+		` projections.idp_templates2.resource_owner,` +
+		` projections.idp_templates2.creation_date,` +
+		` projections.idp_templates2.change_date,` +
+		` projections.idp_templates2.sequence,` +
+		` projections.idp_templates2.state,` +
+		` projections.idp_templates2.name,` +
+		` projections.idp_templates2.type,` +
+		` projections.idp_templates2.owner_type,` +
+		` projections.idp_templates2.is_creation_allowed,` +
+		` projections.idp_templates2.is_linking_allowed,` +
+		` projections.idp_templates2.is_auto_creation,` +
+		` projections.idp_templates2.is_auto_update,` +
 		// oauth
-		` projections.idp_templates_oauth.idp_id,` +
-		` projections.idp_templates_oauth.client_id,` +
-		` projections.idp_templates_oauth.client_secret,` +
-		` projections.idp_templates_oauth.authorization_endpoint,` +
-		` projections.idp_templates_oauth.token_endpoint,` +
-		` projections.idp_templates_oauth.user_endpoint,` +
-		` projections.idp_templates_oauth.scopes,` +
+		` projections.idp_templates2_oauth.idp_id,` +
+		` projections.idp_templates2_oauth.client_id,` +
+		` projections.idp_templates2_oauth.client_secret,` +
+		` projections.idp_templates2_oauth.authorization_endpoint,` +
+		` projections.idp_templates2_oauth.token_endpoint,` +
+		` projections.idp_templates2_oauth.user_endpoint,` +
+		` projections.idp_templates2_oauth.scopes,` +
 		// google
-		` projections.idp_templates_google.idp_id,` +
-		` projections.idp_templates_google.client_id,` +
-		` projections.idp_templates_google.client_secret,` +
-		` projections.idp_templates_google.scopes,` +
+		` projections.idp_templates2_google.idp_id,` +
+		` projections.idp_templates2_google.client_id,` +
+		` projections.idp_templates2_google.client_secret,` +
+		` projections.idp_templates2_google.scopes,` +
 		// ldap
-		` projections.idp_templates_ldap.idp_id,` +
-		` projections.idp_templates_ldap.host,` +
-		` projections.idp_templates_ldap.port,` +
-		` projections.idp_templates_ldap.tls,` +
-		` projections.idp_templates_ldap.base_dn,` +
-		` projections.idp_templates_ldap.user_object_class,` +
-		` projections.idp_templates_ldap.user_unique_attribute,` +
-		` projections.idp_templates_ldap.admin,` +
-		` projections.idp_templates_ldap.password,` +
-		` projections.idp_templates_ldap.id_attribute,` +
-		` projections.idp_templates_ldap.first_name_attribute,` +
-		` projections.idp_templates_ldap.last_name_attribute,` +
-		` projections.idp_templates_ldap.display_name_attribute,` +
-		` projections.idp_templates_ldap.nick_name_attribute,` +
-		` projections.idp_templates_ldap.preferred_username_attribute,` +
-		` projections.idp_templates_ldap.email_attribute,` +
-		` projections.idp_templates_ldap.email_verified,` +
-		` projections.idp_templates_ldap.phone_attribute,` +
-		` projections.idp_templates_ldap.phone_verified_attribute,` +
-		` projections.idp_templates_ldap.preferred_language_attribute,` +
-		` projections.idp_templates_ldap.avatar_url_attribute,` +
-		` projections.idp_templates_ldap.profile_attribute,` +
+		` projections.idp_templates2_ldap.idp_id,` +
+		` projections.idp_templates2_ldap.host,` +
+		` projections.idp_templates2_ldap.port,` +
+		` projections.idp_templates2_ldap.tls,` +
+		` projections.idp_templates2_ldap.base_dn,` +
+		` projections.idp_templates2_ldap.user_object_class,` +
+		` projections.idp_templates2_ldap.user_unique_attribute,` +
+		` projections.idp_templates2_ldap.admin,` +
+		` projections.idp_templates2_ldap.password,` +
+		` projections.idp_templates2_ldap.id_attribute,` +
+		` projections.idp_templates2_ldap.first_name_attribute,` +
+		` projections.idp_templates2_ldap.last_name_attribute,` +
+		` projections.idp_templates2_ldap.display_name_attribute,` +
+		` projections.idp_templates2_ldap.nick_name_attribute,` +
+		` projections.idp_templates2_ldap.preferred_username_attribute,` +
+		` projections.idp_templates2_ldap.email_attribute,` +
+		` projections.idp_templates2_ldap.email_verified,` +
+		` projections.idp_templates2_ldap.phone_attribute,` +
+		` projections.idp_templates2_ldap.phone_verified_attribute,` +
+		` projections.idp_templates2_ldap.preferred_language_attribute,` +
+		` projections.idp_templates2_ldap.avatar_url_attribute,` +
+		` projections.idp_templates2_ldap.profile_attribute,` +
+		` projections.idp_templates2_ldap.groups_attribute,` +
+		` projections.idp_templates2_ldap.disabled_attribute,` +
+		` projections.idp_templates2_ldap.group_mappings,` +
+		// saml
+		` projections.idp_templates2_saml.idp_id,` +
+		` projections.idp_templates2_saml.metadata,` +
+		` projections.idp_templates2_saml.metadata_url,` +
+		` projections.idp_templates2_saml.key,` +
+		` projections.idp_templates2_saml.certificate,` +
+		` projections.idp_templates2_saml.with_signed_request,` +
+		// oidc
+		` projections.idp_templates2_oidc.idp_id,` +
+		` projections.idp_templates2_oidc.issuer,` +
+		` projections.idp_templates2_oidc.client_id,` +
+		` projections.idp_templates2_oidc.client_secret,` +
+		` projections.idp_templates2_oidc.scopes,` +
+		// azure
+		` projections.idp_templates2_azure.idp_id,` +
+		` projections.idp_templates2_azure.client_id,` +
+		` projections.idp_templates2_azure.client_secret,` +
+		` projections.idp_templates2_azure.scopes,` +
+		` projections.idp_templates2_azure.tenant,` +
+		` projections.idp_templates2_azure.is_email_verified,` +
+		// github
+		` projections.idp_templates2_github.idp_id,` +
+		` projections.idp_templates2_github.client_id,` +
+		` projections.idp_templates2_github.client_secret,` +
+		` projections.idp_templates2_github.scopes,` +
+		// github enterprise
+		` projections.idp_templates2_github_enterprise.idp_id,` +
+		` projections.idp_templates2_github_enterprise.client_id,` +
+		` projections.idp_templates2_github_enterprise.client_secret,` +
+		` projections.idp_templates2_github_enterprise.authorization_endpoint,` +
+		` projections.idp_templates2_github_enterprise.token_endpoint,` +
+		` projections.idp_templates2_github_enterprise.user_endpoint,` +
+		` projections.idp_templates2_github_enterprise.scopes,` +
+		// gitlab
+		` projections.idp_templates2_gitlab.idp_id,` +
+		` projections.idp_templates2_gitlab.client_id,` +
+		` projections.idp_templates2_gitlab.client_secret,` +
+		` projections.idp_templates2_gitlab.scopes,` +
+		// gitlab self hosted
+		` projections.idp_templates2_gitlab_self_hosted.idp_id,` +
+		` projections.idp_templates2_gitlab_self_hosted.issuer,` +
+		` projections.idp_templates2_gitlab_self_hosted.client_id,` +
+		` projections.idp_templates2_gitlab_self_hosted.client_secret,` +
+		` projections.idp_templates2_gitlab_self_hosted.scopes,` +
 		` COUNT(*) OVER ()` +
-		` FROM projections.idp_templates` +
-		` LEFT JOIN projections.idp_templates_oauth ON projections.idp_templates.id = projections.idp_templates_oauth.idp_id AND projections.idp_templates.instance_id = projections.idp_templates_oauth.instance_id` +
-		` LEFT JOIN projections.idp_templates_google ON projections.idp_templates.id = projections.idp_templates_google.idp_id AND projections.idp_templates.instance_id = projections.idp_templates_google.instance_id` +
-		` LEFT JOIN projections.idp_templates_ldap ON projections.idp_templates.id = projections.idp_templates_ldap.idp_id AND projections.idp_templates.instance_id = projections.idp_templates_ldap.instance_id`
+		` FROM projections.idp_templates2` +
+		` LEFT JOIN projections.idp_templates2_oauth ON projections.idp_templates2.id = projections.idp_templates2_oauth.idp_id AND projections.idp_templates2.instance_id = projections.idp_templates2_oauth.instance_id` +
+		` LEFT JOIN projections.idp_templates2_google ON projections.idp_templates2.id = projections.idp_templates2_google.idp_id AND projections.idp_templates2.instance_id = projections.idp_templates2_google.instance_id` +
+		` LEFT JOIN projections.idp_templates2_ldap ON projections.idp_templates2.id = projections.idp_templates2_ldap.idp_id AND projections.idp_templates2.instance_id = projections.idp_templates2_ldap.instance_id` +
+		` LEFT JOIN projections.idp_templates2_saml ON projections.idp_templates2.id = projections.idp_templates2_saml.idp_id AND projections.idp_templates2.instance_id = projections.idp_templates2_saml.instance_id` +
+		` LEFT JOIN projections.idp_templates2_oidc ON projections.idp_templates2.id = projections.idp_templates2_oidc.idp_id AND projections.idp_templates2.instance_id = projections.idp_templates2_oidc.instance_id` +
+		` LEFT JOIN projections.idp_templates2_azure ON projections.idp_templates2.id = projections.idp_templates2_azure.idp_id AND projections.idp_templates2.instance_id = projections.idp_templates2_azure.instance_id` +
+		` LEFT JOIN projections.idp_templates2_github ON projections.idp_templates2.id = projections.idp_templates2_github.idp_id AND projections.idp_templates2.instance_id = projections.idp_templates2_github.instance_id` +
+		` LEFT JOIN projections.idp_templates2_github_enterprise ON projections.idp_templates2.id = projections.idp_templates2_github_enterprise.idp_id AND projections.idp_templates2.instance_id = projections.idp_templates2_github_enterprise.instance_id` +
+		` LEFT JOIN projections.idp_templates2_gitlab ON projections.idp_templates2.id = projections.idp_templates2_gitlab.idp_id AND projections.idp_templates2.instance_id = projections.idp_templates2_gitlab.instance_id` +
+		` LEFT JOIN projections.idp_templates2_gitlab_self_hosted ON projections.idp_templates2.id = projections.idp_templates2_gitlab_self_hosted.idp_id AND projections.idp_templates2.instance_id = projections.idp_templates2_gitlab_self_hosted.instance_id`
 	idpTemplatesCols = []string{
 		"id",
 		"resource_owner",
@@ -223,6 +378,53 @@ var (
 		"preferred_language_attribute",
 		"avatar_url_attribute",
 		"profile_attribute",
+		"groups_attribute",
+		"disabled_attribute",
+		"group_mappings",
+		// saml config
+		"idp_id",
+		"metadata",
+		"metadata_url",
+		"key",
+		"certificate",
+		"with_signed_request",
+		// oidc config
+		"idp_id",
+		"issuer",
+		"client_id",
+		"client_secret",
+		"scopes",
+		// azure config
+		"idp_id",
+		"client_id",
+		"client_secret",
+		"scopes",
+		"tenant",
+		"is_email_verified",
+		// github config
+		"idp_id",
+		"client_id",
+		"client_secret",
+		"scopes",
+		// github enterprise config
+		"idp_id",
+		"client_id",
+		"client_secret",
+		"authorization_endpoint",
+		"token_endpoint",
+		"user_endpoint",
+		"scopes",
+		// gitlab config
+		"idp_id",
+		"client_id",
+		"client_secret",
+		"scopes",
+		// gitlab self hosted config
+		"idp_id",
+		"issuer",
+		"client_id",
+		"client_secret",
+		"scopes",
 		"count",
 	}
 )
@@ -313,6 +515,53 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
+						nil,
+						// saml config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// oidc config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// azure config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// github config
+						nil,
+						nil,
+						nil,
+						nil,
+						// github enterprise config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab config
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab self hosted config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -398,6 +647,53 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
+						nil,
+						// saml config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// oidc config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// azure config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// github config
+						nil,
+						nil,
+						nil,
+						nil,
+						// github enterprise config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab config
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab self hosted config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -480,6 +776,53 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						"lang",
 						"avatar",
 						"profile",
+						"memberOf",
+						"nsAccountLock",
+						[]byte(`[{"groupDN": "cn=admins,dc=example,dc=com", "projectID": "project-id", "projectRoleKeys": ["admin"], "orgMemberRoles": ["ORG_OWNER"]}]`),
+						// saml config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// oidc config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// azure config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// github config
+						nil,
+						nil,
+						nil,
+						nil,
+						// github enterprise config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab config
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab self hosted config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -520,7 +863,929 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						PreferredLanguageAttribute: "lang",
 						AvatarURLAttribute:         "avatar",
 						ProfileAttribute:           "profile",
+						GroupsAttribute:            "memberOf",
+						DisabledAttribute:          "nsAccountLock",
+					},
+					GroupMappings: idp.LDAPGroupMappings{
+						{
+							GroupDN:         "cn=admins,dc=example,dc=com",
+							ProjectID:       "project-id",
+							ProjectRoleKeys: []string{"admin"},
+							OrgMemberRoles:  []string{"ORG_OWNER"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareIDPTemplateByIDQuery saml idp",
+			prepare: prepareIDPTemplateByIDQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(idpTemplateQuery),
+					idpTemplateCols,
+					[]driver.Value{
+						"idp-id",
+						"ro",
+						testNow,
+						testNow,
+						uint64(20211109),
+						domain.IDPConfigStateActive,
+						"idp-name",
+						domain.IDPTypeSAML,
+						domain.IdentityProviderTypeOrg,
+						true,
+						true,
+						true,
+						true,
+						// oauth
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// google
+						nil,
+						nil,
+						nil,
+						nil,
+						// ldap config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// saml config
+						"idp-id",
+						[]byte("metadata"),
+						"https://idp.example.com/metadata",
+						nil,
+						[]byte("certificate"),
+						true,
+						// oidc config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// azure config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// github config
+						nil,
+						nil,
+						nil,
+						nil,
+						// github enterprise config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab config
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab self hosted config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+					},
+				),
+			},
+			object: &IDPTemplate{
+				CreationDate:      testNow,
+				ChangeDate:        testNow,
+				Sequence:          20211109,
+				ResourceOwner:     "ro",
+				ID:                "idp-id",
+				State:             domain.IDPStateActive,
+				Name:              "idp-name",
+				Type:              domain.IDPTypeSAML,
+				OwnerType:         domain.IdentityProviderTypeOrg,
+				IsCreationAllowed: true,
+				IsLinkingAllowed:  true,
+				IsAutoCreation:    true,
+				IsAutoUpdate:      true,
+				SAMLIDPTemplate: &SAMLIDPTemplate{
+					IDPID:             "idp-id",
+					Metadata:          []byte("metadata"),
+					MetadataURL:       "https://idp.example.com/metadata",
+					Key:               nil,
+					Certificate:       []byte("certificate"),
+					WithSignedRequest: true,
+				},
+			},
+		},
+		{
+			name:    "prepareIDPTemplateByIDQuery oidc idp",
+			prepare: prepareIDPTemplateByIDQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(idpTemplateQuery),
+					idpTemplateCols,
+					[]driver.Value{
+						"idp-id",
+						"ro",
+						testNow,
+						testNow,
+						uint64(20211109),
+						domain.IDPConfigStateActive,
+						"idp-name",
+						domain.IDPTypeOIDC,
+						domain.IdentityProviderTypeOrg,
+						true,
+						true,
+						true,
+						true,
+						// oauth
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// google
+						nil,
+						nil,
+						nil,
+						nil,
+						// ldap config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// saml config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// oidc config
+						"idp-id",
+						"issuer",
+						"client_id",
+						nil,
+						database.StringArray{"profile"},
+						// azure config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// github config
+						nil,
+						nil,
+						nil,
+						nil,
+						// github enterprise config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab config
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab self hosted config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+					},
+				),
+			},
+			object: &IDPTemplate{
+				CreationDate:      testNow,
+				ChangeDate:        testNow,
+				Sequence:          20211109,
+				ResourceOwner:     "ro",
+				ID:                "idp-id",
+				State:             domain.IDPStateActive,
+				Name:              "idp-name",
+				Type:              domain.IDPTypeOIDC,
+				OwnerType:         domain.IdentityProviderTypeOrg,
+				IsCreationAllowed: true,
+				IsLinkingAllowed:  true,
+				IsAutoCreation:    true,
+				IsAutoUpdate:      true,
+				OIDCIDPTemplate: &OIDCIDPTemplate{
+					IDPID:        "idp-id",
+					Issuer:       "issuer",
+					ClientID:     "client_id",
+					ClientSecret: nil,
+					Scopes:       []string{"profile"},
+				},
+			},
+		},
+		{
+			name:    "prepareIDPTemplateByIDQuery azure idp",
+			prepare: prepareIDPTemplateByIDQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(idpTemplateQuery),
+					idpTemplateCols,
+					[]driver.Value{
+						"idp-id",
+						"ro",
+						testNow,
+						testNow,
+						uint64(20211109),
+						domain.IDPConfigStateActive,
+						"idp-name",
+						domain.IDPTypeAzureAD,
+						domain.IdentityProviderTypeOrg,
+						true,
+						true,
+						true,
+						true,
+						// oauth
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// google
+						nil,
+						nil,
+						nil,
+						nil,
+						// ldap config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// saml config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// oidc config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// azure config
+						"idp-id",
+						"client_id",
+						nil,
+						database.StringArray{"profile"},
+						"tenant",
+						true,
+						// github config
+						nil,
+						nil,
+						nil,
+						nil,
+						// github enterprise config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab config
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab self hosted config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+					},
+				),
+			},
+			object: &IDPTemplate{
+				CreationDate:      testNow,
+				ChangeDate:        testNow,
+				Sequence:          20211109,
+				ResourceOwner:     "ro",
+				ID:                "idp-id",
+				State:             domain.IDPStateActive,
+				Name:              "idp-name",
+				Type:              domain.IDPTypeAzureAD,
+				OwnerType:         domain.IdentityProviderTypeOrg,
+				IsCreationAllowed: true,
+				IsLinkingAllowed:  true,
+				IsAutoCreation:    true,
+				IsAutoUpdate:      true,
+				AzureADIDPTemplate: &AzureADIDPTemplate{
+					IDPID:           "idp-id",
+					ClientID:        "client_id",
+					ClientSecret:    nil,
+					Scopes:          []string{"profile"},
+					Tenant:          "tenant",
+					IsEmailVerified: true,
+				},
+			},
+		},
+		{
+			name:    "prepareIDPTemplateByIDQuery github idp",
+			prepare: prepareIDPTemplateByIDQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(idpTemplateQuery),
+					idpTemplateCols,
+					[]driver.Value{
+						"idp-id",
+						"ro",
+						testNow,
+						testNow,
+						uint64(20211109),
+						domain.IDPConfigStateActive,
+						"idp-name",
+						domain.IDPTypeGitHub,
+						domain.IdentityProviderTypeOrg,
+						true,
+						true,
+						true,
+						true,
+						// oauth
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// google
+						nil,
+						nil,
+						nil,
+						nil,
+						// ldap config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// saml config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// oidc config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// azure config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// github config
+						"idp-id",
+						"client_id",
+						nil,
+						database.StringArray{"profile"},
+						// github enterprise config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab config
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab self hosted config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+					},
+				),
+			},
+			object: &IDPTemplate{
+				CreationDate:      testNow,
+				ChangeDate:        testNow,
+				Sequence:          20211109,
+				ResourceOwner:     "ro",
+				ID:                "idp-id",
+				State:             domain.IDPStateActive,
+				Name:              "idp-name",
+				Type:              domain.IDPTypeGitHub,
+				OwnerType:         domain.IdentityProviderTypeOrg,
+				IsCreationAllowed: true,
+				IsLinkingAllowed:  true,
+				IsAutoCreation:    true,
+				IsAutoUpdate:      true,
+				GitHubIDPTemplate: &GitHubIDPTemplate{
+					IDPID:        "idp-id",
+					ClientID:     "client_id",
+					ClientSecret: nil,
+					Scopes:       []string{"profile"},
+				},
+			},
+		},
+		{
+			name:    "prepareIDPTemplateByIDQuery github enterprise idp",
+			prepare: prepareIDPTemplateByIDQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(idpTemplateQuery),
+					idpTemplateCols,
+					[]driver.Value{
+						"idp-id",
+						"ro",
+						testNow,
+						testNow,
+						uint64(20211109),
+						domain.IDPConfigStateActive,
+						"idp-name",
+						domain.IDPTypeGitHubEE,
+						domain.IdentityProviderTypeOrg,
+						true,
+						true,
+						true,
+						true,
+						// oauth
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// google
+						nil,
+						nil,
+						nil,
+						nil,
+						// ldap config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// saml config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// oidc config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// azure config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// github config
+						nil,
+						nil,
+						nil,
+						nil,
+						// github enterprise config
+						"idp-id",
+						"client_id",
+						nil,
+						"auth",
+						"token",
+						"user",
+						database.StringArray{"profile"},
+						// gitlab config
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab self hosted config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+					},
+				),
+			},
+			object: &IDPTemplate{
+				CreationDate:      testNow,
+				ChangeDate:        testNow,
+				Sequence:          20211109,
+				ResourceOwner:     "ro",
+				ID:                "idp-id",
+				State:             domain.IDPStateActive,
+				Name:              "idp-name",
+				Type:              domain.IDPTypeGitHubEE,
+				OwnerType:         domain.IdentityProviderTypeOrg,
+				IsCreationAllowed: true,
+				IsLinkingAllowed:  true,
+				IsAutoCreation:    true,
+				IsAutoUpdate:      true,
+				GitHubEnterpriseIDPTemplate: &GitHubEnterpriseIDPTemplate{
+					IDPID:                 "idp-id",
+					ClientID:              "client_id",
+					ClientSecret:          nil,
+					AuthorizationEndpoint: "auth",
+					TokenEndpoint:         "token",
+					UserEndpoint:          "user",
+					Scopes:                []string{"profile"},
+				},
+			},
+		},
+		{
+			name:    "prepareIDPTemplateByIDQuery gitlab idp",
+			prepare: prepareIDPTemplateByIDQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(idpTemplateQuery),
+					idpTemplateCols,
+					[]driver.Value{
+						"idp-id",
+						"ro",
+						testNow,
+						testNow,
+						uint64(20211109),
+						domain.IDPConfigStateActive,
+						"idp-name",
+						domain.IDPTypeGitLab,
+						domain.IdentityProviderTypeOrg,
+						true,
+						true,
+						true,
+						true,
+						// oauth
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// google
+						nil,
+						nil,
+						nil,
+						nil,
+						// ldap config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// saml config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// oidc config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// azure config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// github config
+						nil,
+						nil,
+						nil,
+						nil,
+						// github enterprise config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab config
+						"idp-id",
+						"client_id",
+						nil,
+						database.StringArray{"profile"},
+						// gitlab self hosted config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+					},
+				),
+			},
+			object: &IDPTemplate{
+				CreationDate:      testNow,
+				ChangeDate:        testNow,
+				Sequence:          20211109,
+				ResourceOwner:     "ro",
+				ID:                "idp-id",
+				State:             domain.IDPStateActive,
+				Name:              "idp-name",
+				Type:              domain.IDPTypeGitLab,
+				OwnerType:         domain.IdentityProviderTypeOrg,
+				IsCreationAllowed: true,
+				IsLinkingAllowed:  true,
+				IsAutoCreation:    true,
+				IsAutoUpdate:      true,
+				GitLabIDPTemplate: &GitLabIDPTemplate{
+					IDPID:        "idp-id",
+					ClientID:     "client_id",
+					ClientSecret: nil,
+					Scopes:       []string{"profile"},
+				},
+			},
+		},
+		{
+			name:    "prepareIDPTemplateByIDQuery gitlab self hosted idp",
+			prepare: prepareIDPTemplateByIDQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(idpTemplateQuery),
+					idpTemplateCols,
+					[]driver.Value{
+						"idp-id",
+						"ro",
+						testNow,
+						testNow,
+						uint64(20211109),
+						domain.IDPConfigStateActive,
+						"idp-name",
+						domain.IDPTypeGitLabSelfHosted,
+						domain.IdentityProviderTypeOrg,
+						true,
+						true,
+						true,
+						true,
+						// oauth
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// google
+						nil,
+						nil,
+						nil,
+						nil,
+						// ldap config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// saml config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// oidc config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// azure config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// github config
+						nil,
+						nil,
+						nil,
+						nil,
+						// github enterprise config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab config
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab self hosted config
+						"idp-id",
+						"issuer",
+						"client_id",
+						nil,
+						database.StringArray{"profile"},
 					},
+				),
+			},
+			object: &IDPTemplate{
+				CreationDate:      testNow,
+				ChangeDate:        testNow,
+				Sequence:          20211109,
+				ResourceOwner:     "ro",
+				ID:                "idp-id",
+				State:             domain.IDPStateActive,
+				Name:              "idp-name",
+				Type:              domain.IDPTypeGitLabSelfHosted,
+				OwnerType:         domain.IdentityProviderTypeOrg,
+				IsCreationAllowed: true,
+				IsLinkingAllowed:  true,
+				IsAutoCreation:    true,
+				IsAutoUpdate:      true,
+				GitLabSelfHostedIDPTemplate: &GitLabSelfHostedIDPTemplate{
+					IDPID:        "idp-id",
+					Issuer:       "issuer",
+					ClientID:     "client_id",
+					ClientSecret: nil,
+					Scopes:       []string{"profile"},
 				},
 			},
 		},
@@ -581,6 +1846,53 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
+						nil,
+						// saml config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// oidc config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// azure config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// github config
+						nil,
+						nil,
+						nil,
+						nil,
+						// github enterprise config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab config
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab self hosted config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -693,6 +2005,53 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							"lang",
 							"avatar",
 							"profile",
+							"memberOf",
+							"nsAccountLock",
+							[]byte(`[{"groupDN": "cn=admins,dc=example,dc=com", "projectID": "project-id", "projectRoleKeys": ["admin"], "orgMemberRoles": ["ORG_OWNER"]}]`),
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// oidc config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// azure config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// github config
+							nil,
+							nil,
+							nil,
+							nil,
+							// github enterprise config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// gitlab config
+							nil,
+							nil,
+							nil,
+							nil,
+							// gitlab self hosted config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -739,6 +2098,16 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 								PreferredLanguageAttribute: "lang",
 								AvatarURLAttribute:         "avatar",
 								ProfileAttribute:           "profile",
+								GroupsAttribute:            "memberOf",
+								DisabledAttribute:          "nsAccountLock",
+							},
+							GroupMappings: idp.LDAPGroupMappings{
+								{
+									GroupDN:         "cn=admins,dc=example,dc=com",
+									ProjectID:       "project-id",
+									ProjectRoleKeys: []string{"admin"},
+									OrgMemberRoles:  []string{"ORG_OWNER"},
+								},
 							},
 						},
 					},
@@ -803,6 +2172,53 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// oidc config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// azure config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// github config
+							nil,
+							nil,
+							nil,
+							nil,
+							// github enterprise config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// gitlab config
+							nil,
+							nil,
+							nil,
+							nil,
+							// gitlab self hosted config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -888,6 +2304,53 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							"lang",
 							"avatar",
 							"profile",
+							"memberOf",
+							"nsAccountLock",
+							[]byte(`[{"groupDN": "cn=admins,dc=example,dc=com", "projectID": "project-id", "projectRoleKeys": ["admin"], "orgMemberRoles": ["ORG_OWNER"]}]`),
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// oidc config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// azure config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// github config
+							nil,
+							nil,
+							nil,
+							nil,
+							// github enterprise config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// gitlab config
+							nil,
+							nil,
+							nil,
+							nil,
+							// gitlab self hosted config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 						{
 							"idp-id-google",
@@ -939,6 +2402,53 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// oidc config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// azure config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// github config
+							nil,
+							nil,
+							nil,
+							nil,
+							// github enterprise config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// gitlab config
+							nil,
+							nil,
+							nil,
+							nil,
+							// gitlab self hosted config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 						{
 							"idp-id-oauth",
@@ -990,6 +2500,53 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// oidc config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// azure config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// github config
+							nil,
+							nil,
+							nil,
+							nil,
+							// github enterprise config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// gitlab config
+							nil,
+							nil,
+							nil,
+							nil,
+							// gitlab self hosted config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -1036,6 +2593,16 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 								PreferredLanguageAttribute: "lang",
 								AvatarURLAttribute:         "avatar",
 								ProfileAttribute:           "profile",
+								GroupsAttribute:            "memberOf",
+								DisabledAttribute:          "nsAccountLock",
+							},
+							GroupMappings: idp.LDAPGroupMappings{
+								{
+									GroupDN:         "cn=admins,dc=example,dc=com",
+									ProjectID:       "project-id",
+									ProjectRoleKeys: []string{"admin"},
+									OrgMemberRoles:  []string{"ORG_OWNER"},
+								},
 							},
 						},
 					},
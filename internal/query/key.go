@@ -2,7 +2,6 @@ package query
 
 import (
 	"context"
-	"crypto/rsa"
 	"database/sql"
 	"time"
 
@@ -85,18 +84,19 @@ func (k *privateKey) Key() *crypto.CryptoValue {
 	return k.privateKey
 }
 
-type rsaPublicKey struct {
+// publicKey contains the parsed key, which is either an RSA, ECDSA or Ed25519 key
+type publicKey struct {
 	key
 	expiry    time.Time
-	publicKey *rsa.PublicKey
+	publicKey interface{}
 }
 
-func (r *rsaPublicKey) Expiry() time.Time {
-	return r.expiry
+func (k *publicKey) Expiry() time.Time {
+	return k.expiry
 }
 
-func (r *rsaPublicKey) Key() interface{} {
-	return r.publicKey
+func (k *publicKey) Key() interface{} {
+	return k.publicKey
 }
 
 var (
@@ -208,6 +208,8 @@ func (q *Queries) ActivePublicKeys(ctx context.Context, t time.Time) (_ *PublicK
 	return keys, nil
 }
 
+// ActivePrivateSigningKey returns the signing keys which are still valid at t, ordered by their expiry,
+// so the first one is the currently used key and the following ones are already published for the upcoming rotation
 func (q *Queries) ActivePrivateSigningKey(ctx context.Context, t time.Time) (_ *PrivateKeys, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -262,7 +264,7 @@ func preparePublicKeysQuery() (sq.SelectBuilder, func(*sql.Rows) (*PublicKeys, e
 			keys := make([]PublicKey, 0)
 			var count uint64
 			for rows.Next() {
-				k := new(rsaPublicKey)
+				k := new(publicKey)
 				var keyValue []byte
 				err := rows.Scan(
 					&k.id,
@@ -279,7 +281,7 @@ func preparePublicKeysQuery() (sq.SelectBuilder, func(*sql.Rows) (*PublicKeys, e
 				if err != nil {
 					return nil, err
 				}
-				k.publicKey, err = crypto.BytesToPublicKey(keyValue)
+				k.publicKey, err = crypto.BytesToPublicSigningKey(keyValue)
 				if err != nil {
 					return nil, err
 				}
@@ -28,16 +28,17 @@ func Test_LockoutPolicyPrepares(t *testing.T) {
 			prepare: prepareLockoutPolicyQuery,
 			want: want{
 				sqlExpectations: mockQueries(
-					regexp.QuoteMeta(`SELECT projections.lockout_policies2.id,`+
-						` projections.lockout_policies2.sequence,`+
-						` projections.lockout_policies2.creation_date,`+
-						` projections.lockout_policies2.change_date,`+
-						` projections.lockout_policies2.resource_owner,`+
-						` projections.lockout_policies2.show_failure,`+
-						` projections.lockout_policies2.max_password_attempts,`+
-						` projections.lockout_policies2.is_default,`+
-						` projections.lockout_policies2.state`+
-						` FROM projections.lockout_policies2`),
+					regexp.QuoteMeta(`SELECT projections.lockout_policies3.id,`+
+						` projections.lockout_policies3.sequence,`+
+						` projections.lockout_policies3.creation_date,`+
+						` projections.lockout_policies3.change_date,`+
+						` projections.lockout_policies3.resource_owner,`+
+						` projections.lockout_policies3.show_failure,`+
+						` projections.lockout_policies3.max_password_attempts,`+
+						` projections.lockout_policies3.max_otp_attempts,`+
+						` projections.lockout_policies3.is_default,`+
+						` projections.lockout_policies3.state`+
+						` FROM projections.lockout_policies3`),
 					nil,
 					nil,
 				),
@@ -55,16 +56,17 @@ func Test_LockoutPolicyPrepares(t *testing.T) {
 			prepare: prepareLockoutPolicyQuery,
 			want: want{
 				sqlExpectations: mockQuery(
-					regexp.QuoteMeta(`SELECT projections.lockout_policies2.id,`+
-						` projections.lockout_policies2.sequence,`+
-						` projections.lockout_policies2.creation_date,`+
-						` projections.lockout_policies2.change_date,`+
-						` projections.lockout_policies2.resource_owner,`+
-						` projections.lockout_policies2.show_failure,`+
-						` projections.lockout_policies2.max_password_attempts,`+
-						` projections.lockout_policies2.is_default,`+
-						` projections.lockout_policies2.state`+
-						` FROM projections.lockout_policies2`),
+					regexp.QuoteMeta(`SELECT projections.lockout_policies3.id,`+
+						` projections.lockout_policies3.sequence,`+
+						` projections.lockout_policies3.creation_date,`+
+						` projections.lockout_policies3.change_date,`+
+						` projections.lockout_policies3.resource_owner,`+
+						` projections.lockout_policies3.show_failure,`+
+						` projections.lockout_policies3.max_password_attempts,`+
+						` projections.lockout_policies3.max_otp_attempts,`+
+						` projections.lockout_policies3.is_default,`+
+						` projections.lockout_policies3.state`+
+						` FROM projections.lockout_policies3`),
 					[]string{
 						"id",
 						"sequence",
@@ -73,6 +75,7 @@ func Test_LockoutPolicyPrepares(t *testing.T) {
 						"resource_owner",
 						"show_failure",
 						"max_password_attempts",
+						"max_otp_attempts",
 						"is_default",
 						"state",
 					},
@@ -84,6 +87,7 @@ func Test_LockoutPolicyPrepares(t *testing.T) {
 						"ro",
 						true,
 						20,
+						5,
 						true,
 						domain.PolicyStateActive,
 					},
@@ -98,6 +102,7 @@ func Test_LockoutPolicyPrepares(t *testing.T) {
 				State:               domain.PolicyStateActive,
 				ShowFailures:        true,
 				MaxPasswordAttempts: 20,
+				MaxOTPAttempts:      5,
 				IsDefault:           true,
 			},
 		},
@@ -106,16 +111,17 @@ func Test_LockoutPolicyPrepares(t *testing.T) {
 			prepare: prepareLockoutPolicyQuery,
 			want: want{
 				sqlExpectations: mockQueryErr(
-					regexp.QuoteMeta(`SELECT projections.lockout_policies2.id,`+
-						` projections.lockout_policies2.sequence,`+
-						` projections.lockout_policies2.creation_date,`+
-						` projections.lockout_policies2.change_date,`+
-						` projections.lockout_policies2.resource_owner,`+
-						` projections.lockout_policies2.show_failure,`+
-						` projections.lockout_policies2.max_password_attempts,`+
-						` projections.lockout_policies2.is_default,`+
-						` projections.lockout_policies2.state`+
-						` FROM projections.lockout_policies2`),
+					regexp.QuoteMeta(`SELECT projections.lockout_policies3.id,`+
+						` projections.lockout_policies3.sequence,`+
+						` projections.lockout_policies3.creation_date,`+
+						` projections.lockout_policies3.change_date,`+
+						` projections.lockout_policies3.resource_owner,`+
+						` projections.lockout_policies3.show_failure,`+
+						` projections.lockout_policies3.max_password_attempts,`+
+						` projections.lockout_policies3.max_otp_attempts,`+
+						` projections.lockout_policies3.is_default,`+
+						` projections.lockout_policies3.state`+
+						` FROM projections.lockout_policies3`),
 					sql.ErrConnDone,
 				),
 				err: func(err error) (error, bool) {
@@ -40,6 +40,9 @@ type LoginPolicy struct {
 	MFAInitSkipLifetime        time.Duration
 	SecondFactorCheckLifetime  time.Duration
 	MultiFactorCheckLifetime   time.Duration
+	MFATrustedIPRanges         database.StringArray
+	MFAOnNewUserAgent          bool
+	MFAOnCountryChange         bool
 	IDPLinks                   []*IDPLoginPolicyLink
 }
 
@@ -154,6 +157,18 @@ var (
 		name:  projection.MultiFactorCheckLifetimeCol,
 		table: loginPolicyTable,
 	}
+	LoginPolicyColumnMFATrustedIPRanges = Column{
+		name:  projection.MFATrustedIPRangesCol,
+		table: loginPolicyTable,
+	}
+	LoginPolicyColumnMFAOnNewUserAgent = Column{
+		name:  projection.MFAOnNewUserAgentCol,
+		table: loginPolicyTable,
+	}
+	LoginPolicyColumnMFAOnCountryChange = Column{
+		name:  projection.MFAOnCountryChangeCol,
+		table: loginPolicyTable,
+	}
 	LoginPolicyColumnOwnerRemoved = Column{
 		name:  projection.LoginPolicyOwnerRemovedCol,
 		table: loginPolicyTable,
@@ -365,6 +380,9 @@ func prepareLoginPolicyQuery() (sq.SelectBuilder, func(*sql.Rows) (*LoginPolicy,
 			LoginPolicyColumnMFAInitSkipLifetime.identifier(),
 			LoginPolicyColumnSecondFactorCheckLifetime.identifier(),
 			LoginPolicyColumnMultiFactorCheckLifetime.identifier(),
+			LoginPolicyColumnMFATrustedIPRanges.identifier(),
+			LoginPolicyColumnMFAOnNewUserAgent.identifier(),
+			LoginPolicyColumnMFAOnCountryChange.identifier(),
 		).From(loginPolicyTable.identifier()).
 			PlaceholderFormat(sq.Dollar),
 		func(rows *sql.Rows) (*LoginPolicy, error) {
@@ -395,6 +413,9 @@ func prepareLoginPolicyQuery() (sq.SelectBuilder, func(*sql.Rows) (*LoginPolicy,
 					&p.MFAInitSkipLifetime,
 					&p.SecondFactorCheckLifetime,
 					&p.MultiFactorCheckLifetime,
+					&p.MFATrustedIPRanges,
+					&p.MFAOnNewUserAgent,
+					&p.MFAOnCountryChange,
 				)
 				if err != nil {
 					return nil, errors.ThrowInternal(err, "QUERY-YcC53", "Errors.Internal")
@@ -15,30 +15,33 @@ import (
 )
 
 var (
-	loginPolicyQuery = `SELECT projections.login_policies4.aggregate_id,` +
-		` projections.login_policies4.creation_date,` +
-		` projections.login_policies4.change_date,` +
-		` projections.login_policies4.sequence,` +
-		` projections.login_policies4.allow_register,` +
-		` projections.login_policies4.allow_username_password,` +
-		` projections.login_policies4.allow_external_idps,` +
-		` projections.login_policies4.force_mfa,` +
-		` projections.login_policies4.second_factors,` +
-		` projections.login_policies4.multi_factors,` +
-		` projections.login_policies4.passwordless_type,` +
-		` projections.login_policies4.is_default,` +
-		` projections.login_policies4.hide_password_reset,` +
-		` projections.login_policies4.ignore_unknown_usernames,` +
-		` projections.login_policies4.allow_domain_discovery,` +
-		` projections.login_policies4.disable_login_with_email,` +
-		` projections.login_policies4.disable_login_with_phone,` +
-		` projections.login_policies4.default_redirect_uri,` +
-		` projections.login_policies4.password_check_lifetime,` +
-		` projections.login_policies4.external_login_check_lifetime,` +
-		` projections.login_policies4.mfa_init_skip_lifetime,` +
-		` projections.login_policies4.second_factor_check_lifetime,` +
-		` projections.login_policies4.multi_factor_check_lifetime` +
-		` FROM projections.login_policies4`
+	loginPolicyQuery = `SELECT projections.login_policies5.aggregate_id,` +
+		` projections.login_policies5.creation_date,` +
+		` projections.login_policies5.change_date,` +
+		` projections.login_policies5.sequence,` +
+		` projections.login_policies5.allow_register,` +
+		` projections.login_policies5.allow_username_password,` +
+		` projections.login_policies5.allow_external_idps,` +
+		` projections.login_policies5.force_mfa,` +
+		` projections.login_policies5.second_factors,` +
+		` projections.login_policies5.multi_factors,` +
+		` projections.login_policies5.passwordless_type,` +
+		` projections.login_policies5.is_default,` +
+		` projections.login_policies5.hide_password_reset,` +
+		` projections.login_policies5.ignore_unknown_usernames,` +
+		` projections.login_policies5.allow_domain_discovery,` +
+		` projections.login_policies5.disable_login_with_email,` +
+		` projections.login_policies5.disable_login_with_phone,` +
+		` projections.login_policies5.default_redirect_uri,` +
+		` projections.login_policies5.password_check_lifetime,` +
+		` projections.login_policies5.external_login_check_lifetime,` +
+		` projections.login_policies5.mfa_init_skip_lifetime,` +
+		` projections.login_policies5.second_factor_check_lifetime,` +
+		` projections.login_policies5.multi_factor_check_lifetime,` +
+		` projections.login_policies5.mfa_trusted_ip_ranges,` +
+		` projections.login_policies5.mfa_on_new_user_agent,` +
+		` projections.login_policies5.mfa_on_country_change` +
+		` FROM projections.login_policies5`
 	loginPolicyCols = []string{
 		"aggregate_id",
 		"creation_date",
@@ -63,6 +66,9 @@ var (
 		"mfa_init_skip_lifetime",
 		"second_factor_check_lifetime",
 		"multi_factor_check_lifetime",
+		"mfa_trusted_ip_ranges",
+		"mfa_on_new_user_agent",
+		"mfa_on_country_change",
 	}
 )
 
@@ -126,6 +132,9 @@ func Test_LoginPolicyPrepares(t *testing.T) {
 						time.Hour * 2,
 						time.Hour * 2,
 						time.Hour * 2,
+						database.StringArray{"10.0.0.0/8"},
+						true,
+						true,
 					},
 				),
 			},
@@ -153,6 +162,9 @@ func Test_LoginPolicyPrepares(t *testing.T) {
 				MFAInitSkipLifetime:        time.Hour * 2,
 				SecondFactorCheckLifetime:  time.Hour * 2,
 				MultiFactorCheckLifetime:   time.Hour * 2,
+				MFATrustedIPRanges:         database.StringArray{"10.0.0.0/8"},
+				MFAOnNewUserAgent:          true,
+				MFAOnCountryChange:         true,
 			},
 		},
 		{
@@ -177,8 +189,8 @@ func Test_LoginPolicyPrepares(t *testing.T) {
 			prepare: prepareLoginPolicy2FAsQuery,
 			want: want{
 				sqlExpectations: mockQuery(
-					regexp.QuoteMeta(`SELECT projections.login_policies4.second_factors`+
-						` FROM projections.login_policies4`),
+					regexp.QuoteMeta(`SELECT projections.login_policies5.second_factors`+
+						` FROM projections.login_policies5`),
 					[]string{
 						"second_factors",
 					},
@@ -198,8 +210,8 @@ func Test_LoginPolicyPrepares(t *testing.T) {
 			prepare: prepareLoginPolicy2FAsQuery,
 			want: want{
 				sqlExpectations: mockQuery(
-					regexp.QuoteMeta(`SELECT projections.login_policies4.second_factors`+
-						` FROM projections.login_policies4`),
+					regexp.QuoteMeta(`SELECT projections.login_policies5.second_factors`+
+						` FROM projections.login_policies5`),
 					[]string{
 						"second_factors",
 					},
@@ -220,8 +232,8 @@ func Test_LoginPolicyPrepares(t *testing.T) {
 			prepare: prepareLoginPolicy2FAsQuery,
 			want: want{
 				sqlExpectations: mockQuery(
-					regexp.QuoteMeta(`SELECT projections.login_policies4.second_factors`+
-						` FROM projections.login_policies4`),
+					regexp.QuoteMeta(`SELECT projections.login_policies5.second_factors`+
+						` FROM projections.login_policies5`),
 					[]string{
 						"second_factors",
 					},
@@ -237,8 +249,8 @@ func Test_LoginPolicyPrepares(t *testing.T) {
 			prepare: prepareLoginPolicy2FAsQuery,
 			want: want{
 				sqlExpectations: mockQueryErr(
-					regexp.QuoteMeta(`SELECT projections.login_policies4.second_factors`+
-						` FROM projections.login_policies4`),
+					regexp.QuoteMeta(`SELECT projections.login_policies5.second_factors`+
+						` FROM projections.login_policies5`),
 					sql.ErrConnDone,
 				),
 				err: func(err error) (error, bool) {
@@ -255,8 +267,8 @@ func Test_LoginPolicyPrepares(t *testing.T) {
 			prepare: prepareLoginPolicyMFAsQuery,
 			want: want{
 				sqlExpectations: mockQuery(
-					regexp.QuoteMeta(`SELECT projections.login_policies4.multi_factors`+
-						` FROM projections.login_policies4`),
+					regexp.QuoteMeta(`SELECT projections.login_policies5.multi_factors`+
+						` FROM projections.login_policies5`),
 					[]string{
 						"multi_factors",
 					},
@@ -276,8 +288,8 @@ func Test_LoginPolicyPrepares(t *testing.T) {
 			prepare: prepareLoginPolicyMFAsQuery,
 			want: want{
 				sqlExpectations: mockQuery(
-					regexp.QuoteMeta(`SELECT projections.login_policies4.multi_factors`+
-						` FROM projections.login_policies4`),
+					regexp.QuoteMeta(`SELECT projections.login_policies5.multi_factors`+
+						` FROM projections.login_policies5`),
 					[]string{
 						"multi_factors",
 					},
@@ -298,8 +310,8 @@ func Test_LoginPolicyPrepares(t *testing.T) {
 			prepare: prepareLoginPolicyMFAsQuery,
 			want: want{
 				sqlExpectations: mockQuery(
-					regexp.QuoteMeta(`SELECT projections.login_policies4.multi_factors`+
-						` FROM projections.login_policies4`),
+					regexp.QuoteMeta(`SELECT projections.login_policies5.multi_factors`+
+						` FROM projections.login_policies5`),
 					[]string{
 						"multi_factors",
 					},
@@ -315,8 +327,8 @@ func Test_LoginPolicyPrepares(t *testing.T) {
 			prepare: prepareLoginPolicyMFAsQuery,
 			want: want{
 				sqlExpectations: mockQueryErr(
-					regexp.QuoteMeta(`SELECT projections.login_policies4.multi_factors`+
-						` FROM projections.login_policies4`),
+					regexp.QuoteMeta(`SELECT projections.login_policies5.multi_factors`+
+						` FROM projections.login_policies5`),
 					sql.ErrConnDone,
 				),
 				err: func(err error) (error, bool) {
@@ -58,3 +58,25 @@ func (q *Queries) GetProjectGrantMemberRoles() []string {
 	}
 	return roles
 }
+
+// GetCustomMemberRoles returns the keys of the custom roles of the instance with the rolePrefix (IAM or ORG)
+func (q *Queries) GetCustomMemberRoles(ctx context.Context, rolePrefix string) ([]string, error) {
+	keyQuery, err := NewCustomRoleKeySearchQuery(TextStartsWith, rolePrefix+"_")
+	if err != nil {
+		return nil, err
+	}
+	customRoles, err := q.SearchCustomRoles(ctx, &CustomRoleSearchQueries{Queries: []SearchQuery{keyQuery}})
+	if err != nil {
+		return nil, err
+	}
+	roles := make([]string, len(customRoles.CustomRoles))
+	for i, role := range customRoles.CustomRoles {
+		roles[i] = role.Key
+	}
+	return roles, nil
+}
+
+// GetCustomRolePermissions returns the permissions which can be granted by custom roles with the rolePrefix (IAM or ORG)
+func (q *Queries) GetCustomRolePermissions(rolePrefix string) []string {
+	return domain.KnownPermissions(rolePrefix, q.zitadelRoles)
+}
@@ -23,9 +23,10 @@ type PrivacyPolicy struct {
 	ResourceOwner string
 	State         domain.PolicyState
 
-	TOSLink     string
-	PrivacyLink string
-	HelpLink    string
+	TOSLink             string
+	PrivacyLink         string
+	HelpLink            string
+	DeletionGracePeriod time.Duration
 
 	IsDefault bool
 }
@@ -71,6 +72,10 @@ var (
 		name:  projection.PrivacyPolicyHelpLinkCol,
 		table: privacyTable,
 	}
+	PrivacyColDeletionGracePeriod = Column{
+		name:  projection.PrivacyPolicyDeletionGracePeriodCol,
+		table: privacyTable,
+	}
 	PrivacyColIsDefault = Column{
 		name:  projection.PrivacyPolicyIsDefaultCol,
 		table: privacyTable,
@@ -147,6 +152,7 @@ func preparePrivacyPolicyQuery() (sq.SelectBuilder, func(*sql.Row) (*PrivacyPoli
 			PrivacyColPrivacyLink.identifier(),
 			PrivacyColTOSLink.identifier(),
 			PrivacyColHelpLink.identifier(),
+			PrivacyColDeletionGracePeriod.identifier(),
 			PrivacyColIsDefault.identifier(),
 			PrivacyColState.identifier(),
 		).
@@ -162,6 +168,7 @@ func preparePrivacyPolicyQuery() (sq.SelectBuilder, func(*sql.Row) (*PrivacyPoli
 				&policy.PrivacyLink,
 				&policy.TOSLink,
 				&policy.HelpLink,
+				&policy.DeletionGracePeriod,
 				&policy.IsDefault,
 				&policy.State,
 			)
@@ -179,7 +186,8 @@ func (p *PrivacyPolicy) ToDomain() *domain.PrivacyPolicy {
 	return &domain.PrivacyPolicy{
 		TOSLink:     p.TOSLink,
 		PrivacyLink: p.PrivacyLink,
-		HelpLink:    p.HelpLink,
-		Default:     p.IsDefault,
+		HelpLink:            p.HelpLink,
+		DeletionGracePeriod: p.DeletionGracePeriod,
+		Default:             p.IsDefault,
 	}
 }
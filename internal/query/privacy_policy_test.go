@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/domain"
 	errs "github.com/zitadel/zitadel/internal/errors"
@@ -28,17 +29,18 @@ func Test_PrivacyPolicyPrepares(t *testing.T) {
 			prepare: preparePrivacyPolicyQuery,
 			want: want{
 				sqlExpectations: mockQueries(
-					regexp.QuoteMeta(`SELECT projections.privacy_policies2.id,`+
-						` projections.privacy_policies2.sequence,`+
-						` projections.privacy_policies2.creation_date,`+
-						` projections.privacy_policies2.change_date,`+
-						` projections.privacy_policies2.resource_owner,`+
-						` projections.privacy_policies2.privacy_link,`+
-						` projections.privacy_policies2.tos_link,`+
-						` projections.privacy_policies2.help_link,`+
-						` projections.privacy_policies2.is_default,`+
-						` projections.privacy_policies2.state`+
-						` FROM projections.privacy_policies2`),
+					regexp.QuoteMeta(`SELECT projections.privacy_policies3.id,`+
+						` projections.privacy_policies3.sequence,`+
+						` projections.privacy_policies3.creation_date,`+
+						` projections.privacy_policies3.change_date,`+
+						` projections.privacy_policies3.resource_owner,`+
+						` projections.privacy_policies3.privacy_link,`+
+						` projections.privacy_policies3.tos_link,`+
+						` projections.privacy_policies3.help_link,`+
+						` projections.privacy_policies3.deletion_grace_period,`+
+						` projections.privacy_policies3.is_default,`+
+						` projections.privacy_policies3.state`+
+						` FROM projections.privacy_policies3`),
 					nil,
 					nil,
 				),
@@ -56,17 +58,18 @@ func Test_PrivacyPolicyPrepares(t *testing.T) {
 			prepare: preparePrivacyPolicyQuery,
 			want: want{
 				sqlExpectations: mockQuery(
-					regexp.QuoteMeta(`SELECT projections.privacy_policies2.id,`+
-						` projections.privacy_policies2.sequence,`+
-						` projections.privacy_policies2.creation_date,`+
-						` projections.privacy_policies2.change_date,`+
-						` projections.privacy_policies2.resource_owner,`+
-						` projections.privacy_policies2.privacy_link,`+
-						` projections.privacy_policies2.tos_link,`+
-						` projections.privacy_policies2.help_link,`+
-						` projections.privacy_policies2.is_default,`+
-						` projections.privacy_policies2.state`+
-						` FROM projections.privacy_policies2`),
+					regexp.QuoteMeta(`SELECT projections.privacy_policies3.id,`+
+						` projections.privacy_policies3.sequence,`+
+						` projections.privacy_policies3.creation_date,`+
+						` projections.privacy_policies3.change_date,`+
+						` projections.privacy_policies3.resource_owner,`+
+						` projections.privacy_policies3.privacy_link,`+
+						` projections.privacy_policies3.tos_link,`+
+						` projections.privacy_policies3.help_link,`+
+						` projections.privacy_policies3.deletion_grace_period,`+
+						` projections.privacy_policies3.is_default,`+
+						` projections.privacy_policies3.state`+
+						` FROM projections.privacy_policies3`),
 					[]string{
 						"id",
 						"sequence",
@@ -76,6 +79,7 @@ func Test_PrivacyPolicyPrepares(t *testing.T) {
 						"privacy_link",
 						"tos_link",
 						"help_link",
+						"deletion_grace_period",
 						"is_default",
 						"state",
 					},
@@ -88,22 +92,24 @@ func Test_PrivacyPolicyPrepares(t *testing.T) {
 						"privacy.ch",
 						"tos.ch",
 						"help.ch",
+						720 * time.Hour,
 						true,
 						domain.PolicyStateActive,
 					},
 				),
 			},
 			object: &PrivacyPolicy{
-				ID:            "pol-id",
-				CreationDate:  testNow,
-				ChangeDate:    testNow,
-				Sequence:      20211109,
-				ResourceOwner: "ro",
-				State:         domain.PolicyStateActive,
-				PrivacyLink:   "privacy.ch",
-				TOSLink:       "tos.ch",
-				HelpLink:      "help.ch",
-				IsDefault:     true,
+				ID:                  "pol-id",
+				CreationDate:        testNow,
+				ChangeDate:          testNow,
+				Sequence:            20211109,
+				ResourceOwner:       "ro",
+				State:               domain.PolicyStateActive,
+				PrivacyLink:         "privacy.ch",
+				TOSLink:             "tos.ch",
+				HelpLink:            "help.ch",
+				DeletionGracePeriod: 720 * time.Hour,
+				IsDefault:           true,
 			},
 		},
 		{
@@ -111,17 +117,18 @@ func Test_PrivacyPolicyPrepares(t *testing.T) {
 			prepare: preparePrivacyPolicyQuery,
 			want: want{
 				sqlExpectations: mockQueryErr(
-					regexp.QuoteMeta(`SELECT projections.privacy_policies2.id,`+
-						` projections.privacy_policies2.sequence,`+
-						` projections.privacy_policies2.creation_date,`+
-						` projections.privacy_policies2.change_date,`+
-						` projections.privacy_policies2.resource_owner,`+
-						` projections.privacy_policies2.privacy_link,`+
-						` projections.privacy_policies2.tos_link,`+
-						` projections.privacy_policies2.help_link,`+
-						` projections.privacy_policies2.is_default,`+
-						` projections.privacy_policies2.state`+
-						` FROM projections.privacy_policies2`),
+					regexp.QuoteMeta(`SELECT projections.privacy_policies3.id,`+
+						` projections.privacy_policies3.sequence,`+
+						` projections.privacy_policies3.creation_date,`+
+						` projections.privacy_policies3.change_date,`+
+						` projections.privacy_policies3.resource_owner,`+
+						` projections.privacy_policies3.privacy_link,`+
+						` projections.privacy_policies3.tos_link,`+
+						` projections.privacy_policies3.help_link,`+
+						` projections.privacy_policies3.deletion_grace_period,`+
+						` projections.privacy_policies3.is_default,`+
+						` projections.privacy_policies3.state`+
+						` FROM projections.privacy_policies3`),
 					sql.ErrConnDone,
 				),
 				err: func(err error) (error, bool) {
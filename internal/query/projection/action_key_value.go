@@ -0,0 +1,142 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+const (
+	ActionKeyValueTable = "projections.action_key_values"
+
+	ActionKeyValueKeyCol           = "key"
+	ActionKeyValueCreationDateCol  = "creation_date"
+	ActionKeyValueChangeDateCol    = "change_date"
+	ActionKeyValueSequenceCol      = "sequence"
+	ActionKeyValueResourceOwnerCol = "resource_owner"
+	ActionKeyValueInstanceIDCol    = "instance_id"
+	ActionKeyValueValueCol         = "value"
+	ActionKeyValueOwnerRemovedCol  = "owner_removed"
+)
+
+type actionKeyValueProjection struct {
+	crdb.StatementHandler
+}
+
+func newActionKeyValueProjection(ctx context.Context, config crdb.StatementHandlerConfig) *actionKeyValueProjection {
+	p := new(actionKeyValueProjection)
+	config.ProjectionName = ActionKeyValueTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(ActionKeyValueKeyCol, crdb.ColumnTypeText),
+			crdb.NewColumn(ActionKeyValueCreationDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(ActionKeyValueChangeDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(ActionKeyValueSequenceCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(ActionKeyValueResourceOwnerCol, crdb.ColumnTypeText),
+			crdb.NewColumn(ActionKeyValueInstanceIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(ActionKeyValueValueCol, crdb.ColumnTypeBytes),
+			crdb.NewColumn(ActionKeyValueOwnerRemovedCol, crdb.ColumnTypeBool, crdb.Default(false)),
+		},
+			crdb.NewPrimaryKey(ActionKeyValueInstanceIDCol, ActionKeyValueResourceOwnerCol, ActionKeyValueKeyCol),
+			crdb.WithIndex(crdb.NewIndex("owner_removed", []string{ActionKeyValueOwnerRemovedCol})),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *actionKeyValueProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.ActionKeyValueSetEventType,
+					Reduce: p.reduceKeyValueSet,
+				},
+				{
+					Event:  org.ActionKeyValueRemovedEventType,
+					Reduce: p.reduceKeyValueRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(ActionKeyValueInstanceIDCol),
+				},
+			},
+		},
+	}
+}
+
+func (p *actionKeyValueProjection) reduceKeyValueSet(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.ActionKeyValueSetEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Eic3o", "reduce.wrong.event.type %s", org.ActionKeyValueSetEventType)
+	}
+	return crdb.NewUpsertStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(ActionKeyValueInstanceIDCol, nil),
+			handler.NewCol(ActionKeyValueResourceOwnerCol, nil),
+			handler.NewCol(ActionKeyValueKeyCol, e.Key),
+		},
+		[]handler.Column{
+			handler.NewCol(ActionKeyValueInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCol(ActionKeyValueResourceOwnerCol, e.Aggregate().ResourceOwner),
+			handler.NewCol(ActionKeyValueKeyCol, e.Key),
+			handler.NewCol(ActionKeyValueCreationDateCol, e.CreationDate()),
+			handler.NewCol(ActionKeyValueChangeDateCol, e.CreationDate()),
+			handler.NewCol(ActionKeyValueSequenceCol, e.Sequence()),
+			handler.NewCol(ActionKeyValueValueCol, e.Value),
+		},
+	), nil
+}
+
+func (p *actionKeyValueProjection) reduceKeyValueRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.ActionKeyValueRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-iePh4", "reduce.wrong.event.type %s", org.ActionKeyValueRemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(ActionKeyValueKeyCol, e.Key),
+			handler.NewCond(ActionKeyValueResourceOwnerCol, e.Aggregate().ResourceOwner),
+			handler.NewCond(ActionKeyValueInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *actionKeyValueProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Ua7ae", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(ActionKeyValueChangeDateCol, e.CreationDate()),
+			handler.NewCol(ActionKeyValueSequenceCol, e.Sequence()),
+			handler.NewCol(ActionKeyValueOwnerRemovedCol, true),
+		},
+		[]handler.Condition{
+			handler.NewCond(ActionKeyValueInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(ActionKeyValueResourceOwnerCol, e.Aggregate().ID),
+		},
+	), nil
+}
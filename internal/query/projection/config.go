@@ -17,6 +17,7 @@ type Config struct {
 type CustomConfig struct {
 	RequeueEvery        *time.Duration
 	RetryFailedAfter    *time.Duration
+	MaxRetryFailedAfter *time.Duration
 	MaxFailureCount     *uint
 	ConcurrentInstances *uint
 	BulkLimit           *uint64
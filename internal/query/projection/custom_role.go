@@ -0,0 +1,131 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+const (
+	CustomRoleProjectionTable = "projections.custom_roles"
+
+	CustomRoleColumnInstanceID    = "instance_id"
+	CustomRoleColumnKey           = "role_key"
+	CustomRoleColumnCreationDate  = "creation_date"
+	CustomRoleColumnChangeDate    = "change_date"
+	CustomRoleColumnResourceOwner = "resource_owner"
+	CustomRoleColumnSequence      = "sequence"
+	CustomRoleColumnDisplayName   = "display_name"
+	CustomRoleColumnPermissions   = "permissions"
+)
+
+type customRoleProjection struct {
+	crdb.StatementHandler
+}
+
+func newCustomRoleProjection(ctx context.Context, config crdb.StatementHandlerConfig) *customRoleProjection {
+	p := new(customRoleProjection)
+	config.ProjectionName = CustomRoleProjectionTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(CustomRoleColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(CustomRoleColumnKey, crdb.ColumnTypeText),
+			crdb.NewColumn(CustomRoleColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(CustomRoleColumnChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(CustomRoleColumnResourceOwner, crdb.ColumnTypeText),
+			crdb.NewColumn(CustomRoleColumnSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(CustomRoleColumnDisplayName, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(CustomRoleColumnPermissions, crdb.ColumnTypeTextArray),
+		},
+			crdb.NewPrimaryKey(CustomRoleColumnInstanceID, CustomRoleColumnKey),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *customRoleProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.CustomRoleAddedEventType,
+					Reduce: p.reduceAdded,
+				},
+				{
+					Event:  instance.CustomRoleChangedEventType,
+					Reduce: p.reduceChanged,
+				},
+				{
+					Event:  instance.CustomRoleRemovedEventType,
+					Reduce: p.reduceRemoved,
+				},
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(CustomRoleColumnInstanceID),
+				},
+			},
+		},
+	}
+}
+
+func (p *customRoleProjection) reduceAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.CustomRoleAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Cm3ls", "reduce.wrong.event.type %s", instance.CustomRoleAddedEventType)
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(CustomRoleColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(CustomRoleColumnKey, e.Key),
+			handler.NewCol(CustomRoleColumnCreationDate, e.CreationDate()),
+			handler.NewCol(CustomRoleColumnChangeDate, e.CreationDate()),
+			handler.NewCol(CustomRoleColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCol(CustomRoleColumnSequence, e.Sequence()),
+			handler.NewCol(CustomRoleColumnDisplayName, e.DisplayName),
+			handler.NewCol(CustomRoleColumnPermissions, database.StringArray(e.Permissions)),
+		},
+	), nil
+}
+
+func (p *customRoleProjection) reduceChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.CustomRoleChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Pw8sn", "reduce.wrong.event.type %s", instance.CustomRoleChangedEventType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(CustomRoleColumnChangeDate, e.CreationDate()),
+			handler.NewCol(CustomRoleColumnSequence, e.Sequence()),
+			handler.NewCol(CustomRoleColumnDisplayName, e.DisplayName),
+			handler.NewCol(CustomRoleColumnPermissions, database.StringArray(e.Permissions)),
+		},
+		[]handler.Condition{
+			handler.NewCond(CustomRoleColumnKey, e.Key),
+			handler.NewCond(CustomRoleColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *customRoleProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.CustomRoleRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Xs0qm", "reduce.wrong.event.type %s", instance.CustomRoleRemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(CustomRoleColumnKey, e.Key),
+			handler.NewCond(CustomRoleColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
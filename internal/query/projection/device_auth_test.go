@@ -0,0 +1,191 @@
+package projection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/deviceauth"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+func TestDeviceAuthProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(deviceauth.AddedEventType),
+					deviceauth.AggregateType,
+					[]byte(`{"clientId": "client1", "deviceCode": "device1", "userCode": "BCDF-GHJK", "expires": "2022-12-01T10:00:00Z", "scopes": ["openid"]}`),
+				), deviceauth.AddedEventMapper),
+			},
+			reduce: (&deviceAuthProjection{}).reduceAdded,
+			want: wantReduce{
+				aggregateType:    deviceauth.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.device_authorizations (id, client_id, device_code, user_code, expires, scopes, state, creation_date, change_date, sequence, instance_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"client1",
+								"device1",
+								"BCDF-GHJK",
+								time.Date(2022, 12, 1, 10, 0, 0, 0, time.UTC),
+								database.StringArray{"openid"},
+								domain.DeviceAuthStateInitiated,
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceApproved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(deviceauth.ApprovedEventType),
+					deviceauth.AggregateType,
+					[]byte(`{"subject": "user1"}`),
+				), deviceauth.ApprovedEventMapper),
+			},
+			reduce: (&deviceAuthProjection{}).reduceApproved,
+			want: wantReduce{
+				aggregateType:    deviceauth.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.device_authorizations SET (state, subject, change_date, sequence) = ($1, $2, $3, $4) WHERE (id = $5) AND (instance_id = $6)",
+							expectedArgs: []interface{}{
+								domain.DeviceAuthStateApproved,
+								"user1",
+								anyArg{},
+								uint64(15),
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceCanceled",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(deviceauth.CanceledEventType),
+					deviceauth.AggregateType,
+					[]byte(`{"reason": "denied"}`),
+				), deviceauth.CanceledEventMapper),
+			},
+			reduce: (&deviceAuthProjection{}).reduceCanceled,
+			want: wantReduce{
+				aggregateType:    deviceauth.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.device_authorizations SET (state, change_date, sequence) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								domain.DeviceAuthStateDenied,
+								anyArg{},
+								uint64(15),
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(deviceauth.RemovedEventType),
+					deviceauth.AggregateType,
+					[]byte(`{"deviceCode": "device1", "userCode": "BCDF-GHJK"}`),
+				), deviceauth.RemovedEventMapper),
+			},
+			reduce: (&deviceAuthProjection{}).reduceRemoved,
+			want: wantReduce{
+				aggregateType:    deviceauth.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.device_authorizations WHERE (id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.InstanceRemovedEventType),
+					instance.AggregateType,
+					nil,
+				), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(DeviceAuthColumnInstanceID),
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.device_authorizations WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, DeviceAuthProjectionTable, tt.want)
+		})
+	}
+}
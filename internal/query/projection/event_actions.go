@@ -0,0 +1,208 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/action"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+const (
+	EventActionsTable             = "projections.event_actions"
+	EventActionsEventTypeCol      = "event_type"
+	EventActionsChangeDateCol     = "change_date"
+	EventActionsSequenceCol       = "sequence"
+	EventActionsResourceOwnerCol  = "resource_owner"
+	EventActionsInstanceIDCol     = "instance_id"
+	EventActionsActionSequenceCol = "action_sequence"
+	EventActionsActionIDCol       = "action_id"
+	EventActionsOwnerRemovedCol   = "owner_removed"
+
+	// the executions are scheduled and updated by the event actions handler (internal/eventactions),
+	// the projection only creates and cleans up the table
+	EventActionsExecutionSuffix           = "executions"
+	EventActionsExecutionActionIDCol      = "action_id"
+	EventActionsExecutionInstanceIDCol    = "instance_id"
+	EventActionsExecutionEventSequenceCol = "event_sequence"
+	EventActionsExecutionResourceOwnerCol = "resource_owner"
+	EventActionsExecutionEventTypeCol     = "event_type"
+	EventActionsExecutionCreationDateCol  = "creation_date"
+	EventActionsExecutionChangeDateCol    = "change_date"
+	EventActionsExecutionStateCol         = "state"
+	EventActionsExecutionAttemptsCol      = "attempts"
+	EventActionsExecutionLastErrorCol     = "last_error"
+	EventActionsExecutionEventCol         = "event"
+	EventActionsExecutionNextAttemptCol   = "next_attempt"
+)
+
+type eventActionsProjection struct {
+	crdb.StatementHandler
+}
+
+func newEventActionsProjection(ctx context.Context, config crdb.StatementHandlerConfig) *eventActionsProjection {
+	p := new(eventActionsProjection)
+	config.ProjectionName = EventActionsTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewMultiTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(EventActionsEventTypeCol, crdb.ColumnTypeText),
+			crdb.NewColumn(EventActionsChangeDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(EventActionsSequenceCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(EventActionsResourceOwnerCol, crdb.ColumnTypeText),
+			crdb.NewColumn(EventActionsInstanceIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(EventActionsActionSequenceCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(EventActionsActionIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(EventActionsOwnerRemovedCol, crdb.ColumnTypeBool, crdb.Default(false)),
+		},
+			crdb.NewPrimaryKey(EventActionsInstanceIDCol, EventActionsResourceOwnerCol, EventActionsEventTypeCol, EventActionsActionIDCol),
+			crdb.WithIndex(crdb.NewIndex("action_id", []string{EventActionsActionIDCol})),
+			crdb.WithIndex(crdb.NewIndex("owner_removed", []string{EventActionsOwnerRemovedCol})),
+		),
+		crdb.NewSuffixedTable([]*crdb.Column{
+			crdb.NewColumn(EventActionsExecutionActionIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(EventActionsExecutionInstanceIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(EventActionsExecutionEventSequenceCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(EventActionsExecutionResourceOwnerCol, crdb.ColumnTypeText),
+			crdb.NewColumn(EventActionsExecutionEventTypeCol, crdb.ColumnTypeText),
+			crdb.NewColumn(EventActionsExecutionCreationDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(EventActionsExecutionChangeDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(EventActionsExecutionStateCol, crdb.ColumnTypeEnum),
+			crdb.NewColumn(EventActionsExecutionAttemptsCol, crdb.ColumnTypeInt64, crdb.Default(0)),
+			crdb.NewColumn(EventActionsExecutionLastErrorCol, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(EventActionsExecutionEventCol, crdb.ColumnTypeJSONB),
+			crdb.NewColumn(EventActionsExecutionNextAttemptCol, crdb.ColumnTypeTimestamp, crdb.Nullable()),
+		},
+			crdb.NewPrimaryKey(EventActionsExecutionInstanceIDCol, EventActionsExecutionActionIDCol, EventActionsExecutionEventSequenceCol),
+			EventActionsExecutionSuffix,
+			crdb.WithIndex(crdb.NewIndex("state", []string{EventActionsExecutionStateCol, EventActionsExecutionNextAttemptCol})),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *eventActionsProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.EventActionsSetEventType,
+					Reduce: p.reduceEventActionsSet,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: action.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  action.RemovedEventType,
+					Reduce: p.reduceActionRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: p.reduceInstanceRemoved,
+				},
+			},
+		},
+	}
+}
+
+func (p *eventActionsProjection) reduceEventActionsSet(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.EventActionsSetEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ahng4", "reduce.wrong.event.type %s", org.EventActionsSetEventType)
+	}
+	stmts := make([]func(reader eventstore.Event) crdb.Exec, len(e.ActionIDs)+1)
+	stmts[0] = crdb.AddDeleteStatement(
+		[]handler.Condition{
+			handler.NewCond(EventActionsEventTypeCol, e.EventType),
+			handler.NewCond(EventActionsResourceOwnerCol, e.Aggregate().ResourceOwner),
+			handler.NewCond(EventActionsInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	)
+	for i, id := range e.ActionIDs {
+		stmts[i+1] = crdb.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(EventActionsResourceOwnerCol, e.Aggregate().ResourceOwner),
+				handler.NewCol(EventActionsInstanceIDCol, e.Aggregate().InstanceID),
+				handler.NewCol(EventActionsEventTypeCol, e.EventType),
+				handler.NewCol(EventActionsChangeDateCol, e.CreationDate()),
+				handler.NewCol(EventActionsSequenceCol, e.Sequence()),
+				handler.NewCol(EventActionsActionIDCol, id),
+				handler.NewCol(EventActionsActionSequenceCol, i),
+			},
+		)
+	}
+	return crdb.NewMultiStatement(e, stmts...), nil
+}
+
+func (p *eventActionsProjection) reduceActionRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*action.RemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Eiph9", "reduce.wrong.event.type %s", action.RemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(EventActionsActionIDCol, e.Aggregate().ID),
+			handler.NewCond(EventActionsInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *eventActionsProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-ooS7a", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(EventActionsChangeDateCol, e.CreationDate()),
+			handler.NewCol(EventActionsSequenceCol, e.Sequence()),
+			handler.NewCol(EventActionsOwnerRemovedCol, true),
+		},
+		[]handler.Condition{
+			handler.NewCond(EventActionsInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(EventActionsResourceOwnerCol, e.Aggregate().ID),
+		},
+	), nil
+}
+
+// reduceInstanceRemoved removes the subscriptions and the executions of the instance
+func (p *eventActionsProjection) reduceInstanceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.InstanceRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-ieV3o", "reduce.wrong.event.type %s", instance.InstanceRemovedEventType)
+	}
+	return crdb.NewMultiStatement(
+		e,
+		crdb.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(EventActionsInstanceIDCol, e.Aggregate().ID),
+			},
+		),
+		crdb.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(EventActionsExecutionInstanceIDCol, e.Aggregate().ID),
+			},
+			crdb.WithTableSuffix(EventActionsExecutionSuffix),
+		),
+	), nil
+}
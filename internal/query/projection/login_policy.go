@@ -3,6 +3,7 @@ package projection
 import (
 	"context"
 
+	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/handler"
@@ -13,7 +14,7 @@ import (
 )
 
 const (
-	LoginPolicyTable = "projections.login_policies4"
+	LoginPolicyTable = "projections.login_policies5"
 
 	LoginPolicyIDCol                    = "aggregate_id"
 	LoginPolicyInstanceIDCol            = "instance_id"
@@ -39,6 +40,9 @@ const (
 	MFAInitSkipLifetimeCol              = "mfa_init_skip_lifetime"
 	SecondFactorCheckLifetimeCol        = "second_factor_check_lifetime"
 	MultiFactorCheckLifetimeCol         = "multi_factor_check_lifetime"
+	MFATrustedIPRangesCol               = "mfa_trusted_ip_ranges"
+	MFAOnNewUserAgentCol                = "mfa_on_new_user_agent"
+	MFAOnCountryChangeCol               = "mfa_on_country_change"
 	LoginPolicyOwnerRemovedCol          = "owner_removed"
 )
 
@@ -76,6 +80,9 @@ func newLoginPolicyProjection(ctx context.Context, config crdb.StatementHandlerC
 			crdb.NewColumn(MFAInitSkipLifetimeCol, crdb.ColumnTypeInt64),
 			crdb.NewColumn(SecondFactorCheckLifetimeCol, crdb.ColumnTypeInt64),
 			crdb.NewColumn(MultiFactorCheckLifetimeCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(MFATrustedIPRangesCol, crdb.ColumnTypeTextArray, crdb.Nullable()),
+			crdb.NewColumn(MFAOnNewUserAgentCol, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(MFAOnCountryChangeCol, crdb.ColumnTypeBool, crdb.Default(false)),
 			crdb.NewColumn(LoginPolicyOwnerRemovedCol, crdb.ColumnTypeBool, crdb.Default(false)),
 		},
 			crdb.NewPrimaryKey(LoginPolicyInstanceIDCol, LoginPolicyIDCol),
@@ -119,6 +126,10 @@ func (p *loginPolicyProjection) reducers() []handler.AggregateReducer {
 					Event:  org.LoginPolicySecondFactorRemovedEventType,
 					Reduce: p.reduce2FARemoved,
 				},
+				{
+					Event:  org.LoginPolicyRiskRulesSetEventType,
+					Reduce: p.reduceRiskRulesSet,
+				},
 				{
 					Event:  org.OrgRemovedEventType,
 					Reduce: p.reduceOwnerRemoved,
@@ -152,6 +163,10 @@ func (p *loginPolicyProjection) reducers() []handler.AggregateReducer {
 					Event:  instance.LoginPolicySecondFactorRemovedEventType,
 					Reduce: p.reduce2FARemoved,
 				},
+				{
+					Event:  instance.LoginPolicyRiskRulesSetEventType,
+					Reduce: p.reduceRiskRulesSet,
+				},
 				{
 					Event:  instance.InstanceRemovedEventType,
 					Reduce: reduceInstanceRemovedHelper(LoginPolicyInstanceIDCol),
@@ -389,6 +404,33 @@ func (p *loginPolicyProjection) reduce2FARemoved(event eventstore.Event) (*handl
 	), nil
 }
 
+func (p *loginPolicyProjection) reduceRiskRulesSet(event eventstore.Event) (*handler.Statement, error) {
+	var policyEvent policy.RiskRulesSetEvent
+	switch e := event.(type) {
+	case *instance.LoginPolicyRiskRulesSetEvent:
+		policyEvent = e.RiskRulesSetEvent
+	case *org.LoginPolicyRiskRulesSetEvent:
+		policyEvent = e.RiskRulesSetEvent
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Rr9sf", "reduce.wrong.event.type %v", []eventstore.EventType{org.LoginPolicyRiskRulesSetEventType, instance.LoginPolicyRiskRulesSetEventType})
+	}
+
+	return crdb.NewUpdateStatement(
+		&policyEvent,
+		[]handler.Column{
+			handler.NewCol(LoginPolicyChangeDateCol, policyEvent.CreationDate()),
+			handler.NewCol(LoginPolicySequenceCol, policyEvent.Sequence()),
+			handler.NewCol(MFATrustedIPRangesCol, database.StringArray(policyEvent.TrustedIPRanges)),
+			handler.NewCol(MFAOnNewUserAgentCol, policyEvent.MFAOnNewUserAgent),
+			handler.NewCol(MFAOnCountryChangeCol, policyEvent.MFAOnCountryChange),
+		},
+		[]handler.Condition{
+			handler.NewCond(LoginPolicyIDCol, policyEvent.Aggregate().ID),
+			handler.NewCond(LoginPolicyInstanceIDCol, policyEvent.Aggregate().InstanceID),
+		},
+	), nil
+}
+
 func (p *loginPolicyProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
 	e, ok := event.(*org.OrgRemovedEvent)
 	if !ok {
@@ -274,7 +274,9 @@ func isMessageTemplate(template string) bool {
 		template == domain.VerifyPhoneMessageType ||
 		template == domain.DomainClaimedMessageType ||
 		template == domain.PasswordlessRegistrationMessageType ||
-		template == domain.PasswordChangeMessageType
+		template == domain.PasswordChangeMessageType ||
+		template == domain.VerifySMSOTPMessageType ||
+		template == domain.VerifyEmailOTPMessageType
 }
 func isTitle(key string) bool {
 	return key == domain.MessageTitle
@@ -0,0 +1,68 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+const (
+	// PersonalDataShreddingProjectionName is used for the current sequences and failed events of the shredding,
+	// the handler has no proprietary projection table
+	PersonalDataShreddingProjectionName = "projections.personal_data_shredding"
+)
+
+type personalDataShredder interface {
+	ShredPersonalData(ctx context.Context, aggregateID string) error
+}
+
+// personalDataShreddingProjection deletes the personal data key of removed users (see eventstore.ShredPersonalData).
+// As the key storage is not part of the eventstore transaction, a failed deletion is retried like any failed statement.
+type personalDataShreddingProjection struct {
+	crdb.StatementHandler
+	shredder personalDataShredder
+}
+
+func newPersonalDataShreddingProjection(ctx context.Context, config crdb.StatementHandlerConfig) *personalDataShreddingProjection {
+	p := new(personalDataShreddingProjection)
+	config.ProjectionName = PersonalDataShreddingProjectionName
+	config.Reducers = p.reducers()
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	p.shredder = config.Eventstore
+	return p
+}
+
+func (p *personalDataShreddingProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: user.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  user.UserRemovedType,
+					Reduce: p.reduceUserRemoved,
+				},
+			},
+		},
+	}
+}
+
+func (p *personalDataShreddingProjection) reduceUserRemoved(event eventstore.Event) (*handler.Statement, error) {
+	if _, ok := event.(*user.UserRemovedEvent); !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ohm0a", "reduce.wrong.event.type %s", user.UserRemovedType)
+	}
+	return &handler.Statement{
+		AggregateType:    event.Aggregate().Type,
+		Sequence:         event.Sequence(),
+		PreviousSequence: event.PreviousAggregateTypeSequence(),
+		InstanceID:       event.Aggregate().InstanceID,
+		Execute: func(_ handler.Executer, _ string) error {
+			ctx := authz.WithInstanceID(context.Background(), event.Aggregate().InstanceID)
+			return p.shredder.ShredPersonalData(ctx, event.Aggregate().ID)
+		},
+	}, nil
+}
@@ -0,0 +1,77 @@
+package projection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+type testShredder struct {
+	instanceID  string
+	aggregateID string
+	err         error
+}
+
+func (s *testShredder) ShredPersonalData(ctx context.Context, aggregateID string) error {
+	s.instanceID = authz.GetInstance(ctx).InstanceID()
+	s.aggregateID = aggregateID
+	return s.err
+}
+
+func TestPersonalDataShreddingProjection_reduceUserRemoved(t *testing.T) {
+	tests := []struct {
+		name    string
+		event   func(t *testing.T) eventstore.Event
+		err     error
+		wantErr func(error) bool
+	}{
+		{
+			name: "shredded",
+			event: getEvent(testEvent(
+				repository.EventType(user.UserRemovedType),
+				user.AggregateType,
+				[]byte(`{}`),
+			), user.UserRemovedEventMapper),
+		},
+		{
+			name: "shredding failed, retried",
+			event: getEvent(testEvent(
+				repository.EventType(user.UserRemovedType),
+				user.AggregateType,
+				[]byte(`{}`),
+			), user.UserRemovedEventMapper),
+			err:     errors.ThrowInternal(nil, "id", "failed"),
+			wantErr: errors.IsInternal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shredder := &testShredder{err: tt.err}
+			p := &personalDataShreddingProjection{shredder: shredder}
+			event := tt.event(t)
+			stmt, err := p.reduceUserRemoved(event)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, eventstore.AggregateType(user.AggregateType), stmt.AggregateType)
+			assert.Equal(t, uint64(15), stmt.Sequence)
+			assert.Equal(t, uint64(10), stmt.PreviousSequence)
+
+			err = stmt.Execute(nil, PersonalDataShreddingProjectionName)
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err))
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, "instance-id", shredder.instanceID)
+			assert.Equal(t, "agg-id", shredder.aggregateID)
+		})
+	}
+}
@@ -14,7 +14,7 @@ import (
 )
 
 const (
-	PrivacyPolicyTable = "projections.privacy_policies2"
+	PrivacyPolicyTable = "projections.privacy_policies3"
 
 	PrivacyPolicyIDCol            = "id"
 	PrivacyPolicyCreationDateCol  = "creation_date"
@@ -28,6 +28,8 @@ const (
 	PrivacyPolicyTOSLinkCol       = "tos_link"
 	PrivacyPolicyHelpLinkCol      = "help_link"
 	PrivacyPolicyOwnerRemovedCol  = "owner_removed"
+
+	PrivacyPolicyDeletionGracePeriodCol = "deletion_grace_period"
 )
 
 type privacyPolicyProjection struct {
@@ -51,6 +53,7 @@ func newPrivacyPolicyProjection(ctx context.Context, config crdb.StatementHandle
 			crdb.NewColumn(PrivacyPolicyPrivacyLinkCol, crdb.ColumnTypeText),
 			crdb.NewColumn(PrivacyPolicyTOSLinkCol, crdb.ColumnTypeText),
 			crdb.NewColumn(PrivacyPolicyHelpLinkCol, crdb.ColumnTypeText),
+			crdb.NewColumn(PrivacyPolicyDeletionGracePeriodCol, crdb.ColumnTypeInt64, crdb.Default(0)),
 			crdb.NewColumn(PrivacyPolicyOwnerRemovedCol, crdb.ColumnTypeBool, crdb.Default(false)),
 		},
 			crdb.NewPrimaryKey(PrivacyPolicyInstanceIDCol, PrivacyPolicyIDCol),
@@ -128,6 +131,7 @@ func (p *privacyPolicyProjection) reduceAdded(event eventstore.Event) (*handler.
 			handler.NewCol(PrivacyPolicyPrivacyLinkCol, policyEvent.PrivacyLink),
 			handler.NewCol(PrivacyPolicyTOSLinkCol, policyEvent.TOSLink),
 			handler.NewCol(PrivacyPolicyHelpLinkCol, policyEvent.HelpLink),
+			handler.NewCol(PrivacyPolicyDeletionGracePeriodCol, policyEvent.DeletionGracePeriod),
 			handler.NewCol(PrivacyPolicyIsDefaultCol, isDefault),
 			handler.NewCol(PrivacyPolicyResourceOwnerCol, policyEvent.Aggregate().ResourceOwner),
 			handler.NewCol(PrivacyPolicyInstanceIDCol, policyEvent.Aggregate().InstanceID),
@@ -157,6 +161,9 @@ func (p *privacyPolicyProjection) reduceChanged(event eventstore.Event) (*handle
 	if policyEvent.HelpLink != nil {
 		cols = append(cols, handler.NewCol(PrivacyPolicyHelpLinkCol, *policyEvent.HelpLink))
 	}
+	if policyEvent.DeletionGracePeriod != nil {
+		cols = append(cols, handler.NewCol(PrivacyPolicyDeletionGracePeriodCol, *policyEvent.DeletionGracePeriod))
+	}
 	return crdb.NewUpdateStatement(
 		&policyEvent,
 		cols,
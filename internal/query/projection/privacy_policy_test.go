@@ -2,6 +2,7 @@ package projection
 
 import (
 	"testing"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
@@ -31,7 +32,8 @@ func TestPrivacyPolicyProjection_reduces(t *testing.T) {
 					[]byte(`{
 						"tosLink": "http://tos.link",
 						"privacyLink": "http://privacy.link",
-						"helpLink": "http://help.link"
+						"helpLink": "http://help.link",
+						"deletionGracePeriod": 2592000000000000
 }`),
 				), org.PrivacyPolicyAddedEventMapper),
 			},
@@ -43,7 +45,7 @@ func TestPrivacyPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.privacy_policies2 (creation_date, change_date, sequence, id, state, privacy_link, tos_link, help_link, is_default, resource_owner, instance_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)",
+							expectedStmt: "INSERT INTO projections.privacy_policies3 (creation_date, change_date, sequence, id, state, privacy_link, tos_link, help_link, deletion_grace_period, is_default, resource_owner, instance_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								anyArg{},
@@ -53,6 +55,7 @@ func TestPrivacyPolicyProjection_reduces(t *testing.T) {
 								"http://privacy.link",
 								"http://tos.link",
 								"http://help.link",
+								720 * time.Hour,
 								false,
 								"ro-id",
 								"instance-id",
@@ -72,7 +75,8 @@ func TestPrivacyPolicyProjection_reduces(t *testing.T) {
 					[]byte(`{
 						"tosLink": "http://tos.link",
 						"privacyLink": "http://privacy.link",
-						"helpLink": "http://help.link"
+						"helpLink": "http://help.link",
+						"deletionGracePeriod": 2592000000000000
 		}`),
 				), org.PrivacyPolicyChangedEventMapper),
 			},
@@ -83,13 +87,14 @@ func TestPrivacyPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.privacy_policies2 SET (change_date, sequence, privacy_link, tos_link, help_link) = ($1, $2, $3, $4, $5) WHERE (id = $6) AND (instance_id = $7)",
+							expectedStmt: "UPDATE projections.privacy_policies3 SET (change_date, sequence, privacy_link, tos_link, help_link, deletion_grace_period) = ($1, $2, $3, $4, $5, $6) WHERE (id = $7) AND (instance_id = $8)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
 								"http://privacy.link",
 								"http://tos.link",
 								"http://help.link",
+								720 * time.Hour,
 								"agg-id",
 								"instance-id",
 							},
@@ -115,7 +120,7 @@ func TestPrivacyPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.privacy_policies2 WHERE (id = $1) AND (instance_id = $2)",
+							expectedStmt: "DELETE FROM projections.privacy_policies3 WHERE (id = $1) AND (instance_id = $2)",
 							expectedArgs: []interface{}{
 								"agg-id",
 								"instance-id",
@@ -141,7 +146,7 @@ func TestPrivacyPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.privacy_policies2 WHERE (instance_id = $1)",
+							expectedStmt: "DELETE FROM projections.privacy_policies3 WHERE (instance_id = $1)",
 							expectedArgs: []interface{}{
 								"agg-id",
 							},
@@ -160,7 +165,8 @@ func TestPrivacyPolicyProjection_reduces(t *testing.T) {
 					[]byte(`{
 						"tosLink": "http://tos.link",
 						"privacyLink": "http://privacy.link",
-						"helpLink": "http://help.link"
+						"helpLink": "http://help.link",
+						"deletionGracePeriod": 2592000000000000
 					}`),
 				), instance.PrivacyPolicyAddedEventMapper),
 			},
@@ -171,7 +177,7 @@ func TestPrivacyPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.privacy_policies2 (creation_date, change_date, sequence, id, state, privacy_link, tos_link, help_link, is_default, resource_owner, instance_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)",
+							expectedStmt: "INSERT INTO projections.privacy_policies3 (creation_date, change_date, sequence, id, state, privacy_link, tos_link, help_link, deletion_grace_period, is_default, resource_owner, instance_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								anyArg{},
@@ -181,6 +187,7 @@ func TestPrivacyPolicyProjection_reduces(t *testing.T) {
 								"http://privacy.link",
 								"http://tos.link",
 								"http://help.link",
+								720 * time.Hour,
 								true,
 								"ro-id",
 								"instance-id",
@@ -200,7 +207,8 @@ func TestPrivacyPolicyProjection_reduces(t *testing.T) {
 					[]byte(`{
 						"tosLink": "http://tos.link",
 						"privacyLink": "http://privacy.link",
-						"helpLink": "http://help.link"
+						"helpLink": "http://help.link",
+						"deletionGracePeriod": 2592000000000000
 					}`),
 				), instance.PrivacyPolicyChangedEventMapper),
 			},
@@ -211,13 +219,14 @@ func TestPrivacyPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.privacy_policies2 SET (change_date, sequence, privacy_link, tos_link, help_link) = ($1, $2, $3, $4, $5) WHERE (id = $6) AND (instance_id = $7)",
+							expectedStmt: "UPDATE projections.privacy_policies3 SET (change_date, sequence, privacy_link, tos_link, help_link, deletion_grace_period) = ($1, $2, $3, $4, $5, $6) WHERE (id = $7) AND (instance_id = $8)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
 								"http://privacy.link",
 								"http://tos.link",
 								"http://help.link",
+								720 * time.Hour,
 								"agg-id",
 								"instance-id",
 							},
@@ -243,7 +252,7 @@ func TestPrivacyPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.privacy_policies2 SET (change_date, sequence, owner_removed) = ($1, $2, $3) WHERE (instance_id = $4) AND (resource_owner = $5)",
+							expectedStmt: "UPDATE projections.privacy_policies3 SET (change_date, sequence, owner_removed) = ($1, $2, $3) WHERE (instance_id = $4) AND (resource_owner = $5)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
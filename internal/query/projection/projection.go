@@ -62,6 +62,16 @@ var (
 	KeyProjection                       *keyProjection
 	SecurityPolicyProjection            *securityPolicyProjection
 	NotificationPolicyProjection        *notificationPolicyProjection
+	TokenExchangePolicyProjection       *tokenExchangePolicyProjection
+	SigningKeyPolicyProjection          *signingKeyPolicyProjection
+	CustomRoleProjection                *customRoleProjection
+	DeviceAuthProjection                *deviceAuthProjection
+	WebhookProjection                   *webhookProjection
+	SessionProjection                   *sessionProjection
+	EventActionsProjection              *eventActionsProjection
+	ActionSecretProjection              *actionSecretProjection
+	ActionKeyValueProjection            *actionKeyValueProjection
+	PersonalDataShreddingProjection     *personalDataShreddingProjection
 	NotificationsProjection             interface{}
 )
 
@@ -137,6 +147,16 @@ func Create(ctx context.Context, sqlClient *sql.DB, es *eventstore.Eventstore, c
 	KeyProjection = newKeyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["keys"]), keyEncryptionAlgorithm, certEncryptionAlgorithm)
 	SecurityPolicyProjection = newSecurityPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["security_policies"]))
 	NotificationPolicyProjection = newNotificationPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["notification_policies"]))
+	TokenExchangePolicyProjection = newTokenExchangePolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["token_exchange_policies"]))
+	SigningKeyPolicyProjection = newSigningKeyPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["signing_key_policies"]))
+	CustomRoleProjection = newCustomRoleProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["custom_roles"]))
+	DeviceAuthProjection = newDeviceAuthProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["device_auth"]))
+	WebhookProjection = newWebhookProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["webhooks"]))
+	SessionProjection = newSessionProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["sessions"]))
+	EventActionsProjection = newEventActionsProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["event_actions"]))
+	ActionSecretProjection = newActionSecretProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["action_secrets"]))
+	ActionKeyValueProjection = newActionKeyValueProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["action_key_values"]))
+	PersonalDataShreddingProjection = newPersonalDataShreddingProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["personal_data_shredding"]))
 	newProjectionsList()
 	return nil
 }
@@ -173,6 +193,9 @@ func applyCustomConfig(config crdb.StatementHandlerConfig, customConfig CustomCo
 	if customConfig.RetryFailedAfter != nil {
 		config.RetryFailedAfter = *customConfig.RetryFailedAfter
 	}
+	if customConfig.MaxRetryFailedAfter != nil {
+		config.MaxRetryFailedAfter = *customConfig.MaxRetryFailedAfter
+	}
 
 	return config
 }
@@ -229,5 +252,15 @@ func newProjectionsList() {
 		KeyProjection,
 		SecurityPolicyProjection,
 		NotificationPolicyProjection,
+		DeviceAuthProjection,
+		TokenExchangePolicyProjection,
+		SigningKeyPolicyProjection,
+		CustomRoleProjection,
+		WebhookProjection,
+		SessionProjection,
+		EventActionsProjection,
+		ActionSecretProjection,
+		ActionKeyValueProjection,
+		PersonalDataShreddingProjection,
 	}
 }
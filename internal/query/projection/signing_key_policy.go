@@ -0,0 +1,137 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+const (
+	SigningKeyPolicyProjectionTable = "projections.signing_key_policies"
+
+	SigningKeyPolicyColumnAggregateID          = "aggregate_id"
+	SigningKeyPolicyColumnCreationDate         = "creation_date"
+	SigningKeyPolicyColumnChangeDate           = "change_date"
+	SigningKeyPolicyColumnResourceOwner        = "resource_owner"
+	SigningKeyPolicyColumnInstanceID           = "instance_id"
+	SigningKeyPolicyColumnSequence             = "sequence"
+	SigningKeyPolicyColumnAlgorithm            = "algorithm"
+	SigningKeyPolicyColumnKeySize              = "key_size"
+	SigningKeyPolicyColumnKeyLifetime          = "key_lifetime"
+	SigningKeyPolicyColumnOverlapPeriod        = "overlap_period"
+	SigningKeyPolicyColumnPrepublicationWindow = "prepublication_window"
+)
+
+type signingKeyPolicyProjection struct {
+	crdb.StatementHandler
+}
+
+func newSigningKeyPolicyProjection(ctx context.Context, config crdb.StatementHandlerConfig) *signingKeyPolicyProjection {
+	p := new(signingKeyPolicyProjection)
+	config.ProjectionName = SigningKeyPolicyProjectionTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(SigningKeyPolicyColumnAggregateID, crdb.ColumnTypeText),
+			crdb.NewColumn(SigningKeyPolicyColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(SigningKeyPolicyColumnChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(SigningKeyPolicyColumnResourceOwner, crdb.ColumnTypeText),
+			crdb.NewColumn(SigningKeyPolicyColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(SigningKeyPolicyColumnSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(SigningKeyPolicyColumnAlgorithm, crdb.ColumnTypeText),
+			crdb.NewColumn(SigningKeyPolicyColumnKeySize, crdb.ColumnTypeInt64, crdb.Default(0)),
+			crdb.NewColumn(SigningKeyPolicyColumnKeyLifetime, crdb.ColumnTypeInt64),
+			crdb.NewColumn(SigningKeyPolicyColumnOverlapPeriod, crdb.ColumnTypeInt64),
+			crdb.NewColumn(SigningKeyPolicyColumnPrepublicationWindow, crdb.ColumnTypeInt64),
+		},
+			crdb.NewPrimaryKey(SigningKeyPolicyColumnInstanceID, SigningKeyPolicyColumnAggregateID),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *signingKeyPolicyProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.SigningKeyPolicyAddedEventType,
+					Reduce: p.reduceAdded,
+				},
+				{
+					Event:  instance.SigningKeyPolicyChangedEventType,
+					Reduce: p.reduceChanged,
+				},
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(SigningKeyPolicyColumnInstanceID),
+				},
+			},
+		},
+	}
+}
+
+func (p *signingKeyPolicyProjection) reduceAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.SigningKeyPolicyAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Sk29d", "reduce.wrong.event.type %s", instance.SigningKeyPolicyAddedEventType)
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(SigningKeyPolicyColumnAggregateID, e.Aggregate().ID),
+			handler.NewCol(SigningKeyPolicyColumnCreationDate, e.CreationDate()),
+			handler.NewCol(SigningKeyPolicyColumnChangeDate, e.CreationDate()),
+			handler.NewCol(SigningKeyPolicyColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCol(SigningKeyPolicyColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(SigningKeyPolicyColumnSequence, e.Sequence()),
+			handler.NewCol(SigningKeyPolicyColumnAlgorithm, e.Algorithm),
+			handler.NewCol(SigningKeyPolicyColumnKeySize, e.KeySize),
+			handler.NewCol(SigningKeyPolicyColumnKeyLifetime, e.KeyLifetime),
+			handler.NewCol(SigningKeyPolicyColumnOverlapPeriod, e.OverlapPeriod),
+			handler.NewCol(SigningKeyPolicyColumnPrepublicationWindow, e.PrepublicationWindow),
+		},
+	), nil
+}
+
+func (p *signingKeyPolicyProjection) reduceChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.SigningKeyPolicyChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Wm2sl", "reduce.wrong.event.type %s", instance.SigningKeyPolicyChangedEventType)
+	}
+
+	columns := make([]handler.Column, 0, 7)
+	columns = append(columns,
+		handler.NewCol(SigningKeyPolicyColumnChangeDate, e.CreationDate()),
+		handler.NewCol(SigningKeyPolicyColumnSequence, e.Sequence()),
+	)
+	if e.Algorithm != nil {
+		columns = append(columns, handler.NewCol(SigningKeyPolicyColumnAlgorithm, *e.Algorithm))
+	}
+	if e.KeySize != nil {
+		columns = append(columns, handler.NewCol(SigningKeyPolicyColumnKeySize, *e.KeySize))
+	}
+	if e.KeyLifetime != nil {
+		columns = append(columns, handler.NewCol(SigningKeyPolicyColumnKeyLifetime, *e.KeyLifetime))
+	}
+	if e.OverlapPeriod != nil {
+		columns = append(columns, handler.NewCol(SigningKeyPolicyColumnOverlapPeriod, *e.OverlapPeriod))
+	}
+	if e.PrepublicationWindow != nil {
+		columns = append(columns, handler.NewCol(SigningKeyPolicyColumnPrepublicationWindow, *e.PrepublicationWindow))
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		columns,
+		[]handler.Condition{
+			handler.NewCond(SigningKeyPolicyColumnAggregateID, e.Aggregate().ID),
+			handler.NewCond(SigningKeyPolicyColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
@@ -0,0 +1,194 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+const (
+	TokenExchangePolicyProjectionTable = "projections.token_exchange_policies"
+
+	TokenExchangePolicyColumnID                  = "id"
+	TokenExchangePolicyColumnCreationDate        = "creation_date"
+	TokenExchangePolicyColumnChangeDate          = "change_date"
+	TokenExchangePolicyColumnResourceOwner       = "resource_owner"
+	TokenExchangePolicyColumnInstanceID          = "instance_id"
+	TokenExchangePolicyColumnSequence            = "sequence"
+	TokenExchangePolicyColumnStateCol            = "state"
+	TokenExchangePolicyColumnIsDefault           = "is_default"
+	TokenExchangePolicyColumnDelegationActors    = "delegation_actors"
+	TokenExchangePolicyColumnImpersonationActors = "impersonation_actors"
+	TokenExchangePolicyColumnOwnerRemoved        = "owner_removed"
+)
+
+type tokenExchangePolicyProjection struct {
+	crdb.StatementHandler
+}
+
+func newTokenExchangePolicyProjection(ctx context.Context, config crdb.StatementHandlerConfig) *tokenExchangePolicyProjection {
+	p := new(tokenExchangePolicyProjection)
+	config.ProjectionName = TokenExchangePolicyProjectionTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(TokenExchangePolicyColumnID, crdb.ColumnTypeText),
+			crdb.NewColumn(TokenExchangePolicyColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(TokenExchangePolicyColumnChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(TokenExchangePolicyColumnResourceOwner, crdb.ColumnTypeText),
+			crdb.NewColumn(TokenExchangePolicyColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(TokenExchangePolicyColumnSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(TokenExchangePolicyColumnStateCol, crdb.ColumnTypeEnum),
+			crdb.NewColumn(TokenExchangePolicyColumnIsDefault, crdb.ColumnTypeBool),
+			crdb.NewColumn(TokenExchangePolicyColumnDelegationActors, crdb.ColumnTypeTextArray, crdb.Nullable()),
+			crdb.NewColumn(TokenExchangePolicyColumnImpersonationActors, crdb.ColumnTypeTextArray, crdb.Nullable()),
+			crdb.NewColumn(TokenExchangePolicyColumnOwnerRemoved, crdb.ColumnTypeBool, crdb.Default(false)),
+		},
+			crdb.NewPrimaryKey(TokenExchangePolicyColumnInstanceID, TokenExchangePolicyColumnID),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *tokenExchangePolicyProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.TokenExchangePolicyAddedEventType,
+					Reduce: p.reduceAdded,
+				},
+				{
+					Event:  org.TokenExchangePolicyChangedEventType,
+					Reduce: p.reduceChanged,
+				},
+				{
+					Event:  org.TokenExchangePolicyRemovedEventType,
+					Reduce: p.reduceRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(TokenExchangePolicyColumnInstanceID),
+				},
+				{
+					Event:  instance.TokenExchangePolicyAddedEventType,
+					Reduce: p.reduceAdded,
+				},
+				{
+					Event:  instance.TokenExchangePolicyChangedEventType,
+					Reduce: p.reduceChanged,
+				},
+			},
+		},
+	}
+}
+
+func (p *tokenExchangePolicyProjection) reduceAdded(event eventstore.Event) (*handler.Statement, error) {
+	var policyEvent policy.TokenExchangePolicyAddedEvent
+	var isDefault bool
+	switch e := event.(type) {
+	case *org.TokenExchangePolicyAddedEvent:
+		policyEvent = e.TokenExchangePolicyAddedEvent
+		isDefault = false
+	case *instance.TokenExchangePolicyAddedEvent:
+		policyEvent = e.TokenExchangePolicyAddedEvent
+		isDefault = true
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-5slQR", "reduce.wrong.event.type %v", []eventstore.EventType{org.TokenExchangePolicyAddedEventType, instance.TokenExchangePolicyAddedEventType})
+	}
+	return crdb.NewCreateStatement(
+		&policyEvent,
+		[]handler.Column{
+			handler.NewCol(TokenExchangePolicyColumnCreationDate, policyEvent.CreationDate()),
+			handler.NewCol(TokenExchangePolicyColumnChangeDate, policyEvent.CreationDate()),
+			handler.NewCol(TokenExchangePolicyColumnSequence, policyEvent.Sequence()),
+			handler.NewCol(TokenExchangePolicyColumnID, policyEvent.Aggregate().ID),
+			handler.NewCol(TokenExchangePolicyColumnStateCol, domain.PolicyStateActive),
+			handler.NewCol(TokenExchangePolicyColumnDelegationActors, database.StringArray(policyEvent.DelegationActors)),
+			handler.NewCol(TokenExchangePolicyColumnImpersonationActors, database.StringArray(policyEvent.ImpersonationActors)),
+			handler.NewCol(TokenExchangePolicyColumnIsDefault, isDefault),
+			handler.NewCol(TokenExchangePolicyColumnResourceOwner, policyEvent.Aggregate().ResourceOwner),
+			handler.NewCol(TokenExchangePolicyColumnInstanceID, policyEvent.Aggregate().InstanceID),
+		}), nil
+}
+
+func (p *tokenExchangePolicyProjection) reduceChanged(event eventstore.Event) (*handler.Statement, error) {
+	var policyEvent policy.TokenExchangePolicyChangedEvent
+	switch e := event.(type) {
+	case *org.TokenExchangePolicyChangedEvent:
+		policyEvent = e.TokenExchangePolicyChangedEvent
+	case *instance.TokenExchangePolicyChangedEvent:
+		policyEvent = e.TokenExchangePolicyChangedEvent
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-jB6KO", "reduce.wrong.event.type %v", []eventstore.EventType{org.TokenExchangePolicyChangedEventType, instance.TokenExchangePolicyChangedEventType})
+	}
+	cols := []handler.Column{
+		handler.NewCol(TokenExchangePolicyColumnChangeDate, policyEvent.CreationDate()),
+		handler.NewCol(TokenExchangePolicyColumnSequence, policyEvent.Sequence()),
+	}
+	if policyEvent.DelegationActors != nil {
+		cols = append(cols, handler.NewCol(TokenExchangePolicyColumnDelegationActors, database.StringArray(*policyEvent.DelegationActors)))
+	}
+	if policyEvent.ImpersonationActors != nil {
+		cols = append(cols, handler.NewCol(TokenExchangePolicyColumnImpersonationActors, database.StringArray(*policyEvent.ImpersonationActors)))
+	}
+	return crdb.NewUpdateStatement(
+		&policyEvent,
+		cols,
+		[]handler.Condition{
+			handler.NewCond(TokenExchangePolicyColumnID, policyEvent.Aggregate().ID),
+			handler.NewCond(TokenExchangePolicyColumnInstanceID, policyEvent.Aggregate().InstanceID),
+		}), nil
+}
+
+func (p *tokenExchangePolicyProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	policyEvent, ok := event.(*org.TokenExchangePolicyRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-jg1BA", "reduce.wrong.event.type %s", org.TokenExchangePolicyRemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		policyEvent,
+		[]handler.Condition{
+			handler.NewCond(TokenExchangePolicyColumnID, policyEvent.Aggregate().ID),
+			handler.NewCond(TokenExchangePolicyColumnInstanceID, policyEvent.Aggregate().InstanceID),
+		}), nil
+}
+
+func (p *tokenExchangePolicyProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-IM0yH", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(TokenExchangePolicyColumnChangeDate, e.CreationDate()),
+			handler.NewCol(TokenExchangePolicyColumnSequence, e.Sequence()),
+			handler.NewCol(TokenExchangePolicyColumnOwnerRemoved, true),
+		},
+		[]handler.Condition{
+			handler.NewCond(TokenExchangePolicyColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(TokenExchangePolicyColumnResourceOwner, e.Aggregate().ID),
+		},
+	), nil
+}
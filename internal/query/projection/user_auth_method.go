@@ -2,6 +2,7 @@ package projection
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
@@ -77,6 +78,14 @@ func (p *userAuthMethodProjection) reducers() []handler.AggregateReducer {
 					Event:  user.HumanMFAOTPAddedType,
 					Reduce: p.reduceInitAuthMethod,
 				},
+				{
+					Event:  user.HumanOTPSMSAddedType,
+					Reduce: p.reduceInitAuthMethod,
+				},
+				{
+					Event:  user.HumanOTPEmailAddedType,
+					Reduce: p.reduceInitAuthMethod,
+				},
 				{
 					Event:  user.HumanPasswordlessTokenVerifiedType,
 					Reduce: p.reduceActivateEvent,
@@ -99,8 +108,28 @@ func (p *userAuthMethodProjection) reducers() []handler.AggregateReducer {
 				},
 				{
 					Event:  user.HumanMFAOTPRemovedType,
+					Reduce: p.reduceOTPRemoved,
+				},
+				{
+					Event:  user.HumanOTPSMSRemovedType,
+					Reduce: p.reduceRemoveAuthMethod,
+				},
+				{
+					Event:  user.HumanPhoneRemovedType,
 					Reduce: p.reduceRemoveAuthMethod,
 				},
+				{
+					Event:  user.HumanOTPEmailRemovedType,
+					Reduce: p.reduceRemoveAuthMethod,
+				},
+				{
+					Event:  user.HumanRecoveryCodesAddedType,
+					Reduce: p.reduceRecoveryCodesAdded,
+				},
+				{
+					Event:  user.HumanRecoveryCodeCheckSucceededType,
+					Reduce: p.reduceRecoveryCodeUsed,
+				},
 			},
 		},
 		{
@@ -127,6 +156,7 @@ func (p *userAuthMethodProjection) reducers() []handler.AggregateReducer {
 func (p *userAuthMethodProjection) reduceInitAuthMethod(event eventstore.Event) (*handler.Statement, error) {
 	tokenID := ""
 	var methodType domain.UserAuthMethodType
+	state := domain.MFAStateNotReady
 	switch e := event.(type) {
 	case *user.HumanPasswordlessAddedEvent:
 		methodType = domain.UserAuthMethodTypePasswordless
@@ -136,6 +166,13 @@ func (p *userAuthMethodProjection) reduceInitAuthMethod(event eventstore.Event)
 		tokenID = e.WebAuthNTokenID
 	case *user.HumanOTPAddedEvent:
 		methodType = domain.UserAuthMethodTypeOTP
+	case *user.HumanOTPSMSAddedEvent:
+		// the verified phone number is required to add the method, so it's ready immediately
+		methodType = domain.UserAuthMethodTypeOTPSMS
+		state = domain.MFAStateReady
+	case *user.HumanOTPEmailAddedEvent:
+		methodType = domain.UserAuthMethodTypeOTPEmail
+		state = domain.MFAStateReady
 	default:
 		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-f92f", "reduce.wrong.event.type %v", []eventstore.EventType{user.HumanPasswordlessTokenAddedType, user.HumanU2FTokenAddedType})
 	}
@@ -156,7 +193,7 @@ func (p *userAuthMethodProjection) reduceInitAuthMethod(event eventstore.Event)
 			handler.NewCol(UserAuthMethodInstanceIDCol, event.Aggregate().InstanceID),
 			handler.NewCol(UserAuthMethodUserIDCol, event.Aggregate().ID),
 			handler.NewCol(UserAuthMethodSequenceCol, event.Sequence()),
-			handler.NewCol(UserAuthMethodStateCol, domain.MFAStateNotReady),
+			handler.NewCol(UserAuthMethodStateCol, state),
 			handler.NewCol(UserAuthMethodTypeCol, methodType),
 			handler.NewCol(UserAuthMethodNameCol, ""),
 		},
@@ -214,6 +251,11 @@ func (p *userAuthMethodProjection) reduceRemoveAuthMethod(event eventstore.Event
 		tokenID = e.WebAuthNTokenID
 	case *user.HumanOTPRemovedEvent:
 		methodType = domain.UserAuthMethodTypeOTP
+	case *user.HumanOTPSMSRemovedEvent,
+		*user.HumanPhoneRemovedEvent:
+		methodType = domain.UserAuthMethodTypeOTPSMS
+	case *user.HumanOTPEmailRemovedEvent:
+		methodType = domain.UserAuthMethodTypeOTPEmail
 
 	default:
 		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-f92f", "reduce.wrong.event.type %v", []eventstore.EventType{user.HumanPasswordlessTokenAddedType, user.HumanU2FTokenAddedType})
@@ -233,6 +275,69 @@ func (p *userAuthMethodProjection) reduceRemoveAuthMethod(event eventstore.Event
 	), nil
 }
 
+// reduceOTPRemoved removes the OTP and its recovery codes
+func (p *userAuthMethodProjection) reduceOTPRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanOTPRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Rc3gs", "reduce.wrong.event.type %s", user.HumanMFAOTPRemovedType)
+	}
+	return crdb.NewMultiStatement(
+		e,
+		crdb.AddDeleteStatement(userAuthMethodTypeConditions(e, domain.UserAuthMethodTypeOTP)),
+		crdb.AddDeleteStatement(userAuthMethodTypeConditions(e, domain.UserAuthMethodTypeRecoveryCode)),
+	), nil
+}
+
+// reduceRecoveryCodesAdded replaces the recovery codes of the user,
+// every unused code is represented by its index as token id
+func (p *userAuthMethodProjection) reduceRecoveryCodesAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanRecoveryCodesAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Rc8sd", "reduce.wrong.event.type %s", user.HumanRecoveryCodesAddedType)
+	}
+	statements := make([]func(eventstore.Event) crdb.Exec, 0, len(e.Codes)+1)
+	statements = append(statements, crdb.AddDeleteStatement(userAuthMethodTypeConditions(e, domain.UserAuthMethodTypeRecoveryCode)))
+	for i := range e.Codes {
+		statements = append(statements, crdb.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(UserAuthMethodTokenIDCol, strconv.Itoa(i)),
+				handler.NewCol(UserAuthMethodCreationDateCol, e.CreationDate()),
+				handler.NewCol(UserAuthMethodChangeDateCol, e.CreationDate()),
+				handler.NewCol(UserAuthMethodResourceOwnerCol, e.Aggregate().ResourceOwner),
+				handler.NewCol(UserAuthMethodInstanceIDCol, e.Aggregate().InstanceID),
+				handler.NewCol(UserAuthMethodUserIDCol, e.Aggregate().ID),
+				handler.NewCol(UserAuthMethodSequenceCol, e.Sequence()),
+				handler.NewCol(UserAuthMethodStateCol, domain.MFAStateReady),
+				handler.NewCol(UserAuthMethodTypeCol, domain.UserAuthMethodTypeRecoveryCode),
+				handler.NewCol(UserAuthMethodNameCol, ""),
+			},
+		))
+	}
+	return crdb.NewMultiStatement(e, statements...), nil
+}
+
+func (p *userAuthMethodProjection) reduceRecoveryCodeUsed(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanRecoveryCodeCheckSucceededEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Rc2fa", "reduce.wrong.event.type %s", user.HumanRecoveryCodeCheckSucceededType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		append(userAuthMethodTypeConditions(e, domain.UserAuthMethodTypeRecoveryCode),
+			handler.NewCond(UserAuthMethodTokenIDCol, strconv.Itoa(e.CodeIndex)),
+		),
+	), nil
+}
+
+func userAuthMethodTypeConditions(event eventstore.Event, methodType domain.UserAuthMethodType) []handler.Condition {
+	return []handler.Condition{
+		handler.NewCond(UserAuthMethodUserIDCol, event.Aggregate().ID),
+		handler.NewCond(UserAuthMethodTypeCol, methodType),
+		handler.NewCond(UserAuthMethodResourceOwnerCol, event.Aggregate().ResourceOwner),
+		handler.NewCond(UserAuthMethodInstanceIDCol, event.Aggregate().InstanceID),
+	}
+}
+
 func (p *userAuthMethodProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
 	e, ok := event.(*org.OrgRemovedEvent)
 	if !ok {
@@ -0,0 +1,307 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/webhook"
+)
+
+const (
+	WebhookTable             = "projections.webhooks"
+	WebhookDeliveryTable     = WebhookTable + "_" + WebhookDeliverySuffix
+	WebhookIDCol             = "id"
+	WebhookCreationDateCol   = "creation_date"
+	WebhookChangeDateCol     = "change_date"
+	WebhookResourceOwnerCol  = "resource_owner"
+	WebhookInstanceIDCol     = "instance_id"
+	WebhookStateCol          = "state"
+	WebhookSequenceCol       = "sequence"
+	WebhookNameCol           = "name"
+	WebhookURLCol            = "url"
+	WebhookEventTypesCol     = "event_types"
+	WebhookAggregateTypesCol = "aggregate_types"
+	WebhookSigningKeyCol     = "signing_key"
+	WebhookOwnerRemovedCol   = "owner_removed"
+
+	// the deliveries are scheduled and updated by the webhook handler (internal/webhook),
+	// the projection only creates the table
+	WebhookDeliverySuffix               = "deliveries"
+	WebhookDeliveryWebhookIDCol         = "webhook_id"
+	WebhookDeliveryInstanceIDCol        = "instance_id"
+	WebhookDeliveryEventSequenceCol     = "event_sequence"
+	WebhookDeliveryResourceOwnerCol     = "resource_owner"
+	WebhookDeliveryAggregateTypeCol     = "aggregate_type"
+	WebhookDeliveryAggregateIDCol       = "aggregate_id"
+	WebhookDeliveryEventTypeCol         = "event_type"
+	WebhookDeliveryEventCreationDateCol = "event_creation_date"
+	WebhookDeliveryCreationDateCol      = "creation_date"
+	WebhookDeliveryChangeDateCol        = "change_date"
+	WebhookDeliveryStateCol             = "state"
+	WebhookDeliveryAttemptsCol          = "attempts"
+	WebhookDeliveryStatusCodeCol        = "status_code"
+	WebhookDeliveryLastErrorCol         = "last_error"
+	WebhookDeliveryPayloadCol           = "payload"
+	WebhookDeliveryNextAttemptCol       = "next_attempt"
+)
+
+type webhookProjection struct {
+	crdb.StatementHandler
+}
+
+func newWebhookProjection(ctx context.Context, config crdb.StatementHandlerConfig) *webhookProjection {
+	p := new(webhookProjection)
+	config.ProjectionName = WebhookTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewMultiTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(WebhookIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(WebhookCreationDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(WebhookChangeDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(WebhookResourceOwnerCol, crdb.ColumnTypeText),
+			crdb.NewColumn(WebhookInstanceIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(WebhookStateCol, crdb.ColumnTypeEnum),
+			crdb.NewColumn(WebhookSequenceCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(WebhookNameCol, crdb.ColumnTypeText),
+			crdb.NewColumn(WebhookURLCol, crdb.ColumnTypeText),
+			crdb.NewColumn(WebhookEventTypesCol, crdb.ColumnTypeTextArray, crdb.Nullable()),
+			crdb.NewColumn(WebhookAggregateTypesCol, crdb.ColumnTypeTextArray, crdb.Nullable()),
+			crdb.NewColumn(WebhookSigningKeyCol, crdb.ColumnTypeJSONB),
+			crdb.NewColumn(WebhookOwnerRemovedCol, crdb.ColumnTypeBool, crdb.Default(false)),
+		},
+			crdb.NewPrimaryKey(WebhookInstanceIDCol, WebhookIDCol),
+			crdb.WithIndex(crdb.NewIndex("resource_owner", []string{WebhookResourceOwnerCol})),
+			crdb.WithIndex(crdb.NewIndex("owner_removed", []string{WebhookOwnerRemovedCol})),
+		),
+		crdb.NewSuffixedTable([]*crdb.Column{
+			crdb.NewColumn(WebhookDeliveryWebhookIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(WebhookDeliveryInstanceIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(WebhookDeliveryEventSequenceCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(WebhookDeliveryResourceOwnerCol, crdb.ColumnTypeText),
+			crdb.NewColumn(WebhookDeliveryAggregateTypeCol, crdb.ColumnTypeText),
+			crdb.NewColumn(WebhookDeliveryAggregateIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(WebhookDeliveryEventTypeCol, crdb.ColumnTypeText),
+			crdb.NewColumn(WebhookDeliveryEventCreationDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(WebhookDeliveryCreationDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(WebhookDeliveryChangeDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(WebhookDeliveryStateCol, crdb.ColumnTypeEnum),
+			crdb.NewColumn(WebhookDeliveryAttemptsCol, crdb.ColumnTypeInt64, crdb.Default(0)),
+			crdb.NewColumn(WebhookDeliveryStatusCodeCol, crdb.ColumnTypeInt64, crdb.Default(0)),
+			crdb.NewColumn(WebhookDeliveryLastErrorCol, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(WebhookDeliveryPayloadCol, crdb.ColumnTypeJSONB, crdb.Nullable()),
+			crdb.NewColumn(WebhookDeliveryNextAttemptCol, crdb.ColumnTypeTimestamp, crdb.Nullable()),
+		},
+			crdb.NewPrimaryKey(WebhookDeliveryInstanceIDCol, WebhookDeliveryWebhookIDCol, WebhookDeliveryEventSequenceCol),
+			WebhookDeliverySuffix,
+			crdb.WithForeignKey(crdb.NewForeignKey("webhook", []string{WebhookDeliveryInstanceIDCol, WebhookDeliveryWebhookIDCol}, nil)),
+			crdb.WithIndex(crdb.NewIndex("state", []string{WebhookDeliveryStateCol, WebhookDeliveryNextAttemptCol})),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *webhookProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: webhook.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  webhook.AddedEventType,
+					Reduce: p.reduceWebhookAdded,
+				},
+				{
+					Event:  webhook.ChangedEventType,
+					Reduce: p.reduceWebhookChanged,
+				},
+				{
+					Event:  webhook.SigningKeyChangedEventType,
+					Reduce: p.reduceWebhookSigningKeyChanged,
+				},
+				{
+					Event:  webhook.DeactivatedEventType,
+					Reduce: p.reduceWebhookDeactivated,
+				},
+				{
+					Event:  webhook.ReactivatedEventType,
+					Reduce: p.reduceWebhookReactivated,
+				},
+				{
+					Event:  webhook.RemovedEventType,
+					Reduce: p.reduceWebhookRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(WebhookInstanceIDCol),
+				},
+			},
+		},
+	}
+}
+
+func (p *webhookProjection) reduceWebhookAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*webhook.AddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Wai8u", "reduce.wrong.event.type %s", webhook.AddedEventType)
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(WebhookIDCol, e.Aggregate().ID),
+			handler.NewCol(WebhookCreationDateCol, e.CreationDate()),
+			handler.NewCol(WebhookChangeDateCol, e.CreationDate()),
+			handler.NewCol(WebhookResourceOwnerCol, e.Aggregate().ResourceOwner),
+			handler.NewCol(WebhookInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCol(WebhookSequenceCol, e.Sequence()),
+			handler.NewCol(WebhookStateCol, domain.WebhookStateActive),
+			handler.NewCol(WebhookNameCol, e.Name),
+			handler.NewCol(WebhookURLCol, e.URL),
+			handler.NewCol(WebhookEventTypesCol, database.StringArray(e.EventTypes)),
+			handler.NewCol(WebhookAggregateTypesCol, database.StringArray(e.AggregateTypes)),
+			handler.NewCol(WebhookSigningKeyCol, e.SigningKey),
+		},
+	), nil
+}
+
+func (p *webhookProjection) reduceWebhookChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*webhook.ChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ohch3", "reduce.wrong.event.type %s", webhook.ChangedEventType)
+	}
+	values := []handler.Column{
+		handler.NewCol(WebhookChangeDateCol, e.CreationDate()),
+		handler.NewCol(WebhookSequenceCol, e.Sequence()),
+	}
+	if e.Name != nil {
+		values = append(values, handler.NewCol(WebhookNameCol, *e.Name))
+	}
+	if e.URL != nil {
+		values = append(values, handler.NewCol(WebhookURLCol, *e.URL))
+	}
+	if e.EventTypes != nil {
+		values = append(values, handler.NewCol(WebhookEventTypesCol, database.StringArray(*e.EventTypes)))
+	}
+	if e.AggregateTypes != nil {
+		values = append(values, handler.NewCol(WebhookAggregateTypesCol, database.StringArray(*e.AggregateTypes)))
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		values,
+		[]handler.Condition{
+			handler.NewCond(WebhookIDCol, e.Aggregate().ID),
+			handler.NewCond(WebhookInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *webhookProjection) reduceWebhookSigningKeyChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*webhook.SigningKeyChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Iek8u", "reduce.wrong.event.type %s", webhook.SigningKeyChangedEventType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(WebhookChangeDateCol, e.CreationDate()),
+			handler.NewCol(WebhookSequenceCol, e.Sequence()),
+			handler.NewCol(WebhookSigningKeyCol, e.SigningKey),
+		},
+		[]handler.Condition{
+			handler.NewCond(WebhookIDCol, e.Aggregate().ID),
+			handler.NewCond(WebhookInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *webhookProjection) reduceWebhookDeactivated(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*webhook.DeactivatedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ma3ie", "reduce.wrong.event.type %s", webhook.DeactivatedEventType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(WebhookChangeDateCol, e.CreationDate()),
+			handler.NewCol(WebhookSequenceCol, e.Sequence()),
+			handler.NewCol(WebhookStateCol, domain.WebhookStateInactive),
+		},
+		[]handler.Condition{
+			handler.NewCond(WebhookIDCol, e.Aggregate().ID),
+			handler.NewCond(WebhookInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *webhookProjection) reduceWebhookReactivated(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*webhook.ReactivatedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Aiy0o", "reduce.wrong.event.type %s", webhook.ReactivatedEventType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(WebhookChangeDateCol, e.CreationDate()),
+			handler.NewCol(WebhookSequenceCol, e.Sequence()),
+			handler.NewCol(WebhookStateCol, domain.WebhookStateActive),
+		},
+		[]handler.Condition{
+			handler.NewCond(WebhookIDCol, e.Aggregate().ID),
+			handler.NewCond(WebhookInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *webhookProjection) reduceWebhookRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*webhook.RemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ee2ph", "reduce.wrong.event.type %s", webhook.RemovedEventType)
+	}
+	// the deliveries are removed by the foreign key (on delete cascade)
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(WebhookIDCol, e.Aggregate().ID),
+			handler.NewCond(WebhookInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *webhookProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Yoh5a", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(WebhookChangeDateCol, e.CreationDate()),
+			handler.NewCol(WebhookSequenceCol, e.Sequence()),
+			handler.NewCol(WebhookOwnerRemovedCol, true),
+		},
+		[]handler.Condition{
+			handler.NewCond(WebhookInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(WebhookResourceOwnerCol, e.Aggregate().ID),
+		},
+	), nil
+}
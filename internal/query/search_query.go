@@ -186,6 +186,8 @@ func (s *TextQuery) comp() sq.Sqlizer {
 		return sq.ILike{s.Column.identifier(): "%" + s.Text + "%"}
 	case TextListContains:
 		return &listContains{col: s.Column, args: []interface{}{s.Text}}
+	case TextNotEquals:
+		return sq.NotEq{s.Column.identifier(): s.Text}
 	}
 	return nil
 }
@@ -418,6 +420,51 @@ func (q *or) comp() sq.Sqlizer {
 	return sq.Or(queries)
 }
 
+type and struct {
+	queries []SearchQuery
+}
+
+func And(queries ...SearchQuery) *and {
+	return &and{
+		queries: queries,
+	}
+}
+
+func (q *and) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	return query.Where(q.comp())
+}
+
+func (q *and) comp() sq.Sqlizer {
+	queries := make([]sq.Sqlizer, 0)
+	for _, query := range q.queries {
+		queries = append(queries, query.comp())
+	}
+	return sq.And(queries)
+}
+
+type BytesQuery struct {
+	Column Column
+	Value  []byte
+}
+
+func NewBytesQuery(c Column, value []byte) (*BytesQuery, error) {
+	if c.isZero() {
+		return nil, ErrMissingColumn
+	}
+	return &BytesQuery{
+		Column: c,
+		Value:  value,
+	}, nil
+}
+
+func (q *BytesQuery) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	return query.Where(q.comp())
+}
+
+func (s *BytesQuery) comp() sq.Sqlizer {
+	return sq.Eq{s.Column.identifier(): s.Value}
+}
+
 type BoolQuery struct {
 	Column Column
 	Value  bool
@@ -974,6 +974,17 @@ func TestTextQuery_comp(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "not equals",
+			fields: fields{
+				Column:  testCol,
+				Text:    "Hurst",
+				Compare: TextNotEquals,
+			},
+			want: want{
+				query: sq.NotEq{"test_table.test_col": "Hurst"},
+			},
+		},
 		{
 			name: "too high comparison",
 			fields: fields{
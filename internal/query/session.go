@@ -0,0 +1,313 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	sessionsTable = table{
+		name:          projection.SessionsProjectionTable,
+		instanceIDCol: projection.SessionColumnInstanceID,
+	}
+	SessionColumnID = Column{
+		name:  projection.SessionColumnID,
+		table: sessionsTable,
+	}
+	SessionColumnCreationDate = Column{
+		name:  projection.SessionColumnCreationDate,
+		table: sessionsTable,
+	}
+	SessionColumnChangeDate = Column{
+		name:  projection.SessionColumnChangeDate,
+		table: sessionsTable,
+	}
+	SessionColumnSequence = Column{
+		name:  projection.SessionColumnSequence,
+		table: sessionsTable,
+	}
+	SessionColumnResourceOwner = Column{
+		name:  projection.SessionColumnResourceOwner,
+		table: sessionsTable,
+	}
+	SessionColumnInstanceID = Column{
+		name:  projection.SessionColumnInstanceID,
+		table: sessionsTable,
+	}
+	SessionColumnCreator = Column{
+		name:  projection.SessionColumnCreator,
+		table: sessionsTable,
+	}
+	SessionColumnUserID = Column{
+		name:  projection.SessionColumnUserID,
+		table: sessionsTable,
+	}
+	SessionColumnUserResourceOwner = Column{
+		name:  projection.SessionColumnUserResourceOwner,
+		table: sessionsTable,
+	}
+	SessionColumnUserCheckedAt = Column{
+		name:  projection.SessionColumnUserCheckedAt,
+		table: sessionsTable,
+	}
+	SessionColumnPasswordCheckedAt = Column{
+		name:  projection.SessionColumnPasswordCheckedAt,
+		table: sessionsTable,
+	}
+	SessionColumnOTPCheckedAt = Column{
+		name:  projection.SessionColumnOTPCheckedAt,
+		table: sessionsTable,
+	}
+	SessionColumnWebAuthNCheckedAt = Column{
+		name:  projection.SessionColumnWebAuthNCheckedAt,
+		table: sessionsTable,
+	}
+	SessionColumnIDPID = Column{
+		name:  projection.SessionColumnIDPID,
+		table: sessionsTable,
+	}
+	SessionColumnIDPCheckedAt = Column{
+		name:  projection.SessionColumnIDPCheckedAt,
+		table: sessionsTable,
+	}
+	SessionColumnTokenID = Column{
+		name:  projection.SessionColumnTokenID,
+		table: sessionsTable,
+	}
+)
+
+type Session struct {
+	ID            string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	Sequence      uint64
+	ResourceOwner string
+	Creator       string
+
+	UserID            string
+	UserResourceOwner string
+	UserCheckedAt     time.Time
+	PasswordCheckedAt time.Time
+	OTPCheckedAt      time.Time
+	WebAuthNCheckedAt time.Time
+	IDPID             string
+	IDPCheckedAt      time.Time
+	// TokenID is the id of the latest token of the session
+	TokenID string
+}
+
+type Sessions struct {
+	SearchResponse
+	Sessions []*Session
+}
+
+func (q *Queries) SessionByID(ctx context.Context, shouldTriggerBulk bool, id string) (_ *Session, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		err := projection.SessionProjection.Trigger(ctx)
+		logging.OnError(err).WithField("projection", sessionsTable.identifier()).Warn("could not trigger projection for query")
+	}
+
+	stmt, scan := prepareSessionQuery()
+	query, args, err := stmt.Where(sq.Eq{
+		SessionColumnID.identifier():         id,
+		SessionColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Dfbg2", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+// SessionsByUserID returns all sessions of the user
+func (q *Queries) SessionsByUserID(ctx context.Context, shouldTriggerBulk bool, userID string) (_ *Sessions, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		err := projection.SessionProjection.Trigger(ctx)
+		logging.OnError(err).WithField("projection", sessionsTable.identifier()).Warn("could not trigger projection for query")
+	}
+
+	stmt, scan := prepareSessionsQuery()
+	query, args, err := stmt.Where(sq.Eq{
+		SessionColumnUserID.identifier():     userID,
+		SessionColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).OrderBy(SessionColumnCreationDate.identifier()).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Wf3gs", "Errors.Query.SQLStatement")
+	}
+
+	rows, err := q.client.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Pk2nd", "Errors.Internal")
+	}
+	sessions, err := scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	sessions.LatestSequence, err = q.latestSequence(ctx, sessionsTable)
+	return sessions, err
+}
+
+func prepareSessionQuery() (sq.SelectBuilder, func(*sql.Row) (*Session, error)) {
+	return sq.Select(
+			SessionColumnID.identifier(),
+			SessionColumnCreationDate.identifier(),
+			SessionColumnChangeDate.identifier(),
+			SessionColumnSequence.identifier(),
+			SessionColumnResourceOwner.identifier(),
+			SessionColumnCreator.identifier(),
+			SessionColumnUserID.identifier(),
+			SessionColumnUserResourceOwner.identifier(),
+			SessionColumnUserCheckedAt.identifier(),
+			SessionColumnPasswordCheckedAt.identifier(),
+			SessionColumnOTPCheckedAt.identifier(),
+			SessionColumnWebAuthNCheckedAt.identifier(),
+			SessionColumnIDPID.identifier(),
+			SessionColumnIDPCheckedAt.identifier(),
+			SessionColumnTokenID.identifier(),
+		).From(sessionsTable.identifier()).PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*Session, error) {
+			session := new(Session)
+			var (
+				userID            sql.NullString
+				userResourceOwner sql.NullString
+				userCheckedAt     sql.NullTime
+				passwordCheckedAt sql.NullTime
+				otpCheckedAt      sql.NullTime
+				webAuthNCheckedAt sql.NullTime
+				idpID             sql.NullString
+				idpCheckedAt      sql.NullTime
+				tokenID           sql.NullString
+			)
+			err := row.Scan(
+				&session.ID,
+				&session.CreationDate,
+				&session.ChangeDate,
+				&session.Sequence,
+				&session.ResourceOwner,
+				&session.Creator,
+				&userID,
+				&userResourceOwner,
+				&userCheckedAt,
+				&passwordCheckedAt,
+				&otpCheckedAt,
+				&webAuthNCheckedAt,
+				&idpID,
+				&idpCheckedAt,
+				&tokenID,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-SFeaa", "Errors.Session.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-SAder", "Errors.Internal")
+			}
+			session.UserID = userID.String
+			session.UserResourceOwner = userResourceOwner.String
+			session.UserCheckedAt = userCheckedAt.Time
+			session.PasswordCheckedAt = passwordCheckedAt.Time
+			session.OTPCheckedAt = otpCheckedAt.Time
+			session.WebAuthNCheckedAt = webAuthNCheckedAt.Time
+			session.IDPID = idpID.String
+			session.IDPCheckedAt = idpCheckedAt.Time
+			session.TokenID = tokenID.String
+			return session, nil
+		}
+}
+
+func prepareSessionsQuery() (sq.SelectBuilder, func(*sql.Rows) (*Sessions, error)) {
+	return sq.Select(
+			SessionColumnID.identifier(),
+			SessionColumnCreationDate.identifier(),
+			SessionColumnChangeDate.identifier(),
+			SessionColumnSequence.identifier(),
+			SessionColumnResourceOwner.identifier(),
+			SessionColumnCreator.identifier(),
+			SessionColumnUserID.identifier(),
+			SessionColumnUserResourceOwner.identifier(),
+			SessionColumnUserCheckedAt.identifier(),
+			SessionColumnPasswordCheckedAt.identifier(),
+			SessionColumnOTPCheckedAt.identifier(),
+			SessionColumnWebAuthNCheckedAt.identifier(),
+			SessionColumnIDPID.identifier(),
+			SessionColumnIDPCheckedAt.identifier(),
+			SessionColumnTokenID.identifier(),
+			countColumn.identifier(),
+		).From(sessionsTable.identifier()).PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*Sessions, error) {
+			sessions := make([]*Session, 0)
+			var count uint64
+			for rows.Next() {
+				session := new(Session)
+				var (
+					userID            sql.NullString
+					userResourceOwner sql.NullString
+					userCheckedAt     sql.NullTime
+					passwordCheckedAt sql.NullTime
+					otpCheckedAt      sql.NullTime
+					webAuthNCheckedAt sql.NullTime
+					idpID             sql.NullString
+					idpCheckedAt      sql.NullTime
+					tokenID           sql.NullString
+				)
+				err := rows.Scan(
+					&session.ID,
+					&session.CreationDate,
+					&session.ChangeDate,
+					&session.Sequence,
+					&session.ResourceOwner,
+					&session.Creator,
+					&userID,
+					&userResourceOwner,
+					&userCheckedAt,
+					&passwordCheckedAt,
+					&otpCheckedAt,
+					&webAuthNCheckedAt,
+					&idpID,
+					&idpCheckedAt,
+					&tokenID,
+					&count,
+				)
+				if err != nil {
+					return nil, errors.ThrowInternal(err, "QUERY-Xm2sd", "Errors.Internal")
+				}
+				session.UserID = userID.String
+				session.UserResourceOwner = userResourceOwner.String
+				session.UserCheckedAt = userCheckedAt.Time
+				session.PasswordCheckedAt = passwordCheckedAt.Time
+				session.OTPCheckedAt = otpCheckedAt.Time
+				session.WebAuthNCheckedAt = webAuthNCheckedAt.Time
+				session.IDPID = idpID.String
+				session.IDPCheckedAt = idpCheckedAt.Time
+				session.TokenID = tokenID.String
+				sessions = append(sessions, session)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Gb3sf", "Errors.Query.CloseRows")
+			}
+
+			return &Sessions{
+				Sessions: sessions,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
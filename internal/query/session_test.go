@@ -0,0 +1,267 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	expectedSessionQuery = regexp.QuoteMeta(`SELECT projections.sessions.id,` +
+		` projections.sessions.creation_date,` +
+		` projections.sessions.change_date,` +
+		` projections.sessions.sequence,` +
+		` projections.sessions.resource_owner,` +
+		` projections.sessions.creator,` +
+		` projections.sessions.user_id,` +
+		` projections.sessions.user_resource_owner,` +
+		` projections.sessions.user_checked_at,` +
+		` projections.sessions.password_checked_at,` +
+		` projections.sessions.otp_checked_at,` +
+		` projections.sessions.webauthn_checked_at,` +
+		` projections.sessions.idp_id,` +
+		` projections.sessions.idp_checked_at,` +
+		` projections.sessions.token_id` +
+		` FROM projections.sessions`)
+	expectedSessionsQuery = regexp.QuoteMeta(`SELECT projections.sessions.id,` +
+		` projections.sessions.creation_date,` +
+		` projections.sessions.change_date,` +
+		` projections.sessions.sequence,` +
+		` projections.sessions.resource_owner,` +
+		` projections.sessions.creator,` +
+		` projections.sessions.user_id,` +
+		` projections.sessions.user_resource_owner,` +
+		` projections.sessions.user_checked_at,` +
+		` projections.sessions.password_checked_at,` +
+		` projections.sessions.otp_checked_at,` +
+		` projections.sessions.webauthn_checked_at,` +
+		` projections.sessions.idp_id,` +
+		` projections.sessions.idp_checked_at,` +
+		` projections.sessions.token_id,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.sessions`)
+	sessionCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"resource_owner",
+		"creator",
+		"user_id",
+		"user_resource_owner",
+		"user_checked_at",
+		"password_checked_at",
+		"otp_checked_at",
+		"webauthn_checked_at",
+		"idp_id",
+		"idp_checked_at",
+		"token_id",
+	}
+	sessionsCols = append(sessionCols, "count")
+)
+
+func Test_SessionPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareSessionQuery no result",
+			prepare: prepareSessionQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedSessionQuery,
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*Session)(nil),
+		},
+		{
+			name:    "prepareSessionQuery found",
+			prepare: prepareSessionQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					expectedSessionQuery,
+					sessionCols,
+					[]driver.Value{
+						"session-id",
+						testNow,
+						testNow,
+						uint64(20211109),
+						"ro",
+						"creator",
+						"user-id",
+						"user-ro",
+						testNow,
+						testNow,
+						nil,
+						nil,
+						nil,
+						nil,
+						"token-id",
+					},
+				),
+			},
+			object: &Session{
+				ID:                "session-id",
+				CreationDate:      testNow,
+				ChangeDate:        testNow,
+				Sequence:          20211109,
+				ResourceOwner:     "ro",
+				Creator:           "creator",
+				UserID:            "user-id",
+				UserResourceOwner: "user-ro",
+				UserCheckedAt:     testNow,
+				PasswordCheckedAt: testNow,
+				TokenID:           "token-id",
+			},
+		},
+		{
+			name:    "prepareSessionQuery sql err",
+			prepare: prepareSessionQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					expectedSessionQuery,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+		{
+			name:    "prepareSessionsQuery no result",
+			prepare: prepareSessionsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedSessionsQuery,
+					nil,
+					nil,
+				),
+			},
+			object: &Sessions{Sessions: []*Session{}},
+		},
+		{
+			name:    "prepareSessionsQuery multiple result",
+			prepare: prepareSessionsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedSessionsQuery,
+					sessionsCols,
+					[][]driver.Value{
+						{
+							"session-id",
+							testNow,
+							testNow,
+							uint64(20211109),
+							"ro",
+							"creator",
+							"user-id",
+							"user-ro",
+							testNow,
+							testNow,
+							nil,
+							nil,
+							nil,
+							nil,
+							"token-id",
+						},
+						{
+							"session-id2",
+							testNow,
+							testNow,
+							uint64(20211110),
+							"ro",
+							"creator",
+							"user-id",
+							"user-ro",
+							testNow,
+							nil,
+							nil,
+							testNow,
+							nil,
+							nil,
+							nil,
+						},
+					},
+				),
+			},
+			object: &Sessions{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				Sessions: []*Session{
+					{
+						ID:                "session-id",
+						CreationDate:      testNow,
+						ChangeDate:        testNow,
+						Sequence:          20211109,
+						ResourceOwner:     "ro",
+						Creator:           "creator",
+						UserID:            "user-id",
+						UserResourceOwner: "user-ro",
+						UserCheckedAt:     testNow,
+						PasswordCheckedAt: testNow,
+						TokenID:           "token-id",
+					},
+					{
+						ID:                "session-id2",
+						CreationDate:      testNow,
+						ChangeDate:        testNow,
+						Sequence:          20211110,
+						ResourceOwner:     "ro",
+						Creator:           "creator",
+						UserID:            "user-id",
+						UserResourceOwner: "user-ro",
+						UserCheckedAt:     testNow,
+						WebAuthNCheckedAt: testNow,
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareSessionsQuery sql err",
+			prepare: prepareSessionsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					expectedSessionsQuery,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	signingKeyPolicyTable = table{
+		name:          projection.SigningKeyPolicyProjectionTable,
+		instanceIDCol: projection.SigningKeyPolicyColumnInstanceID,
+	}
+	SigningKeyPolicyColumnAggregateID = Column{
+		name:  projection.SigningKeyPolicyColumnAggregateID,
+		table: signingKeyPolicyTable,
+	}
+	SigningKeyPolicyColumnCreationDate = Column{
+		name:  projection.SigningKeyPolicyColumnCreationDate,
+		table: signingKeyPolicyTable,
+	}
+	SigningKeyPolicyColumnChangeDate = Column{
+		name:  projection.SigningKeyPolicyColumnChangeDate,
+		table: signingKeyPolicyTable,
+	}
+	SigningKeyPolicyColumnResourceOwner = Column{
+		name:  projection.SigningKeyPolicyColumnResourceOwner,
+		table: signingKeyPolicyTable,
+	}
+	SigningKeyPolicyColumnInstanceID = Column{
+		name:  projection.SigningKeyPolicyColumnInstanceID,
+		table: signingKeyPolicyTable,
+	}
+	SigningKeyPolicyColumnSequence = Column{
+		name:  projection.SigningKeyPolicyColumnSequence,
+		table: signingKeyPolicyTable,
+	}
+	SigningKeyPolicyColumnAlgorithm = Column{
+		name:  projection.SigningKeyPolicyColumnAlgorithm,
+		table: signingKeyPolicyTable,
+	}
+	SigningKeyPolicyColumnKeySize = Column{
+		name:  projection.SigningKeyPolicyColumnKeySize,
+		table: signingKeyPolicyTable,
+	}
+	SigningKeyPolicyColumnKeyLifetime = Column{
+		name:  projection.SigningKeyPolicyColumnKeyLifetime,
+		table: signingKeyPolicyTable,
+	}
+	SigningKeyPolicyColumnOverlapPeriod = Column{
+		name:  projection.SigningKeyPolicyColumnOverlapPeriod,
+		table: signingKeyPolicyTable,
+	}
+	SigningKeyPolicyColumnPrepublicationWindow = Column{
+		name:  projection.SigningKeyPolicyColumnPrepublicationWindow,
+		table: signingKeyPolicyTable,
+	}
+)
+
+type SigningKeyPolicy struct {
+	AggregateID   string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	Sequence      uint64
+
+	Algorithm            string
+	KeySize              int
+	KeyLifetime          time.Duration
+	OverlapPeriod        time.Duration
+	PrepublicationWindow time.Duration
+}
+
+// SigningKeyPolicy returns the signing key policy of the instance
+// if none is set, a not found error is returned and the defaults of the runtime configuration apply
+func (q *Queries) SigningKeyPolicy(ctx context.Context) (_ *SigningKeyPolicy, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	stmt, scan := prepareSigningKeyPolicyQuery()
+	query, args, err := stmt.Where(sq.Eq{
+		SigningKeyPolicyColumnAggregateID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		SigningKeyPolicyColumnInstanceID.identifier():  authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ws9mk", "Errors.Query.SQLStatment")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+func prepareSigningKeyPolicyQuery() (sq.SelectBuilder, func(*sql.Row) (*SigningKeyPolicy, error)) {
+	return sq.Select(
+			SigningKeyPolicyColumnAggregateID.identifier(),
+			SigningKeyPolicyColumnCreationDate.identifier(),
+			SigningKeyPolicyColumnChangeDate.identifier(),
+			SigningKeyPolicyColumnResourceOwner.identifier(),
+			SigningKeyPolicyColumnSequence.identifier(),
+			SigningKeyPolicyColumnAlgorithm.identifier(),
+			SigningKeyPolicyColumnKeySize.identifier(),
+			SigningKeyPolicyColumnKeyLifetime.identifier(),
+			SigningKeyPolicyColumnOverlapPeriod.identifier(),
+			SigningKeyPolicyColumnPrepublicationWindow.identifier()).
+			From(signingKeyPolicyTable.identifier()).PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*SigningKeyPolicy, error) {
+			policy := new(SigningKeyPolicy)
+			err := row.Scan(
+				&policy.AggregateID,
+				&policy.CreationDate,
+				&policy.ChangeDate,
+				&policy.ResourceOwner,
+				&policy.Sequence,
+				&policy.Algorithm,
+				&policy.KeySize,
+				&policy.KeyLifetime,
+				&policy.OverlapPeriod,
+				&policy.PrepublicationWindow,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Lp2ms", "Errors.IAM.SigningKeyPolicy.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Ds8mk", "Errors.Internal")
+			}
+			return policy, nil
+		}
+}
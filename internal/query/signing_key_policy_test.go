@@ -0,0 +1,142 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+func Test_SigningKeyPolicyPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareSigningKeyPolicyQuery no result",
+			prepare: prepareSigningKeyPolicyQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					`SELECT projections.signing_key_policies.aggregate_id,`+
+						` projections.signing_key_policies.creation_date,`+
+						` projections.signing_key_policies.change_date,`+
+						` projections.signing_key_policies.resource_owner,`+
+						` projections.signing_key_policies.sequence,`+
+						` projections.signing_key_policies.algorithm,`+
+						` projections.signing_key_policies.key_size,`+
+						` projections.signing_key_policies.key_lifetime,`+
+						` projections.signing_key_policies.overlap_period,`+
+						` projections.signing_key_policies.prepublication_window`+
+						` FROM projections.signing_key_policies`,
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*SigningKeyPolicy)(nil),
+		},
+		{
+			name:    "prepareSigningKeyPolicyQuery found",
+			prepare: prepareSigningKeyPolicyQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(`SELECT projections.signing_key_policies.aggregate_id,`+
+						` projections.signing_key_policies.creation_date,`+
+						` projections.signing_key_policies.change_date,`+
+						` projections.signing_key_policies.resource_owner,`+
+						` projections.signing_key_policies.sequence,`+
+						` projections.signing_key_policies.algorithm,`+
+						` projections.signing_key_policies.key_size,`+
+						` projections.signing_key_policies.key_lifetime,`+
+						` projections.signing_key_policies.overlap_period,`+
+						` projections.signing_key_policies.prepublication_window`+
+						` FROM projections.signing_key_policies`),
+					[]string{
+						"aggregate_id",
+						"creation_date",
+						"change_date",
+						"resource_owner",
+						"sequence",
+						"algorithm",
+						"key_size",
+						"key_lifetime",
+						"overlap_period",
+						"prepublication_window",
+					},
+					[]driver.Value{
+						"agg-id",
+						testNow,
+						testNow,
+						"ro",
+						uint64(20211108),
+						"ES256",
+						0,
+						time.Hour * 6,
+						time.Hour * 24,
+						time.Hour * 1,
+					},
+				),
+			},
+			object: &SigningKeyPolicy{
+				AggregateID:          "agg-id",
+				CreationDate:         testNow,
+				ChangeDate:           testNow,
+				ResourceOwner:        "ro",
+				Sequence:             20211108,
+				Algorithm:            "ES256",
+				KeySize:              0,
+				KeyLifetime:          time.Hour * 6,
+				OverlapPeriod:        time.Hour * 24,
+				PrepublicationWindow: time.Hour * 1,
+			},
+		},
+		{
+			name:    "prepareSigningKeyPolicyQuery sql err",
+			prepare: prepareSigningKeyPolicyQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(`SELECT projections.signing_key_policies.aggregate_id,`+
+						` projections.signing_key_policies.creation_date,`+
+						` projections.signing_key_policies.change_date,`+
+						` projections.signing_key_policies.resource_owner,`+
+						` projections.signing_key_policies.sequence,`+
+						` projections.signing_key_policies.algorithm,`+
+						` projections.signing_key_policies.key_size,`+
+						` projections.signing_key_policies.key_lifetime,`+
+						` projections.signing_key_policies.overlap_period,`+
+						` projections.signing_key_policies.prepublication_window`+
+						` FROM projections.signing_key_policies`),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err)
+		})
+	}
+}
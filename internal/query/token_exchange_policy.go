@@ -0,0 +1,176 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+type TokenExchangePolicy struct {
+	ID            string
+	Sequence      uint64
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	State         domain.PolicyState
+
+	// DelegationActors are the machine users allowed to act on behalf of the users of the organization
+	DelegationActors database.StringArray
+	// ImpersonationActors are the machine users allowed to impersonate the users of the organization
+	ImpersonationActors database.StringArray
+
+	IsDefault bool
+}
+
+var (
+	tokenExchangePolicyTable = table{
+		name:          projection.TokenExchangePolicyProjectionTable,
+		instanceIDCol: projection.TokenExchangePolicyColumnInstanceID,
+	}
+	TokenExchangePolicyColID = Column{
+		name:  projection.TokenExchangePolicyColumnID,
+		table: tokenExchangePolicyTable,
+	}
+	TokenExchangePolicyColSequence = Column{
+		name:  projection.TokenExchangePolicyColumnSequence,
+		table: tokenExchangePolicyTable,
+	}
+	TokenExchangePolicyColCreationDate = Column{
+		name:  projection.TokenExchangePolicyColumnCreationDate,
+		table: tokenExchangePolicyTable,
+	}
+	TokenExchangePolicyColChangeDate = Column{
+		name:  projection.TokenExchangePolicyColumnChangeDate,
+		table: tokenExchangePolicyTable,
+	}
+	TokenExchangePolicyColResourceOwner = Column{
+		name:  projection.TokenExchangePolicyColumnResourceOwner,
+		table: tokenExchangePolicyTable,
+	}
+	TokenExchangePolicyColInstanceID = Column{
+		name:  projection.TokenExchangePolicyColumnInstanceID,
+		table: tokenExchangePolicyTable,
+	}
+	TokenExchangePolicyColDelegationActors = Column{
+		name:  projection.TokenExchangePolicyColumnDelegationActors,
+		table: tokenExchangePolicyTable,
+	}
+	TokenExchangePolicyColImpersonationActors = Column{
+		name:  projection.TokenExchangePolicyColumnImpersonationActors,
+		table: tokenExchangePolicyTable,
+	}
+	TokenExchangePolicyColIsDefault = Column{
+		name:  projection.TokenExchangePolicyColumnIsDefault,
+		table: tokenExchangePolicyTable,
+	}
+	TokenExchangePolicyColState = Column{
+		name:  projection.TokenExchangePolicyColumnStateCol,
+		table: tokenExchangePolicyTable,
+	}
+	TokenExchangePolicyColOwnerRemoved = Column{
+		name:  projection.TokenExchangePolicyColumnOwnerRemoved,
+		table: tokenExchangePolicyTable,
+	}
+)
+
+func (q *Queries) TokenExchangePolicyByOrg(ctx context.Context, shouldTriggerBulk bool, orgID string, withOwnerRemoved bool) (_ *TokenExchangePolicy, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		if err := projection.TokenExchangePolicyProjection.Trigger(ctx); err != nil {
+			return nil, err
+		}
+	}
+	eq := sq.Eq{TokenExchangePolicyColInstanceID.identifier(): authz.GetInstance(ctx).InstanceID()}
+	if !withOwnerRemoved {
+		eq[TokenExchangePolicyColOwnerRemoved.identifier()] = false
+	}
+	stmt, scan := prepareTokenExchangePolicyQuery()
+	query, args, err := stmt.Where(
+		sq.And{
+			eq,
+			sq.Or{
+				sq.Eq{TokenExchangePolicyColID.identifier(): orgID},
+				sq.Eq{TokenExchangePolicyColID.identifier(): authz.GetInstance(ctx).InstanceID()},
+			},
+		}).
+		OrderBy(TokenExchangePolicyColIsDefault.identifier()).Limit(1).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-6zZB6", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+func (q *Queries) DefaultTokenExchangePolicy(ctx context.Context, shouldTriggerBulk bool) (_ *TokenExchangePolicy, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		if err := projection.TokenExchangePolicyProjection.Trigger(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	stmt, scan := prepareTokenExchangePolicyQuery()
+	query, args, err := stmt.Where(sq.Eq{
+		TokenExchangePolicyColID.identifier():         authz.GetInstance(ctx).InstanceID(),
+		TokenExchangePolicyColInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).
+		OrderBy(TokenExchangePolicyColIsDefault.identifier()).
+		Limit(1).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-30wZY", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+func prepareTokenExchangePolicyQuery() (sq.SelectBuilder, func(*sql.Row) (*TokenExchangePolicy, error)) {
+	return sq.Select(
+			TokenExchangePolicyColID.identifier(),
+			TokenExchangePolicyColSequence.identifier(),
+			TokenExchangePolicyColCreationDate.identifier(),
+			TokenExchangePolicyColChangeDate.identifier(),
+			TokenExchangePolicyColResourceOwner.identifier(),
+			TokenExchangePolicyColDelegationActors.identifier(),
+			TokenExchangePolicyColImpersonationActors.identifier(),
+			TokenExchangePolicyColIsDefault.identifier(),
+			TokenExchangePolicyColState.identifier(),
+		).
+			From(tokenExchangePolicyTable.identifier()).PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*TokenExchangePolicy, error) {
+			policy := new(TokenExchangePolicy)
+			err := row.Scan(
+				&policy.ID,
+				&policy.Sequence,
+				&policy.CreationDate,
+				&policy.ChangeDate,
+				&policy.ResourceOwner,
+				&policy.DelegationActors,
+				&policy.ImpersonationActors,
+				&policy.IsDefault,
+				&policy.State,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-UVMnP", "Errors.Org.TokenExchangePolicy.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-yf6H3", "Errors.Internal")
+			}
+			return policy, nil
+		}
+}
@@ -0,0 +1,121 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var tokenExchangePolicyStmt = regexp.QuoteMeta(`SELECT projections.token_exchange_policies.id,` +
+	` projections.token_exchange_policies.sequence,` +
+	` projections.token_exchange_policies.creation_date,` +
+	` projections.token_exchange_policies.change_date,` +
+	` projections.token_exchange_policies.resource_owner,` +
+	` projections.token_exchange_policies.delegation_actors,` +
+	` projections.token_exchange_policies.impersonation_actors,` +
+	` projections.token_exchange_policies.is_default,` +
+	` projections.token_exchange_policies.state` +
+	` FROM projections.token_exchange_policies`)
+
+func Test_TokenExchangePolicyPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareTokenExchangePolicyQuery no result",
+			prepare: prepareTokenExchangePolicyQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					tokenExchangePolicyStmt,
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*TokenExchangePolicy)(nil),
+		},
+		{
+			name:    "prepareTokenExchangePolicyQuery found",
+			prepare: prepareTokenExchangePolicyQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					tokenExchangePolicyStmt,
+					[]string{
+						"id",
+						"sequence",
+						"creation_date",
+						"change_date",
+						"resource_owner",
+						"delegation_actors",
+						"impersonation_actors",
+						"is_default",
+						"state",
+					},
+					[]driver.Value{
+						"pol-id",
+						uint64(20211109),
+						testNow,
+						testNow,
+						"ro",
+						database.StringArray{"machine1"},
+						database.StringArray{"machine2"},
+						true,
+						domain.PolicyStateActive,
+					},
+				),
+			},
+			object: &TokenExchangePolicy{
+				ID:                  "pol-id",
+				CreationDate:        testNow,
+				ChangeDate:          testNow,
+				Sequence:            20211109,
+				ResourceOwner:       "ro",
+				State:               domain.PolicyStateActive,
+				DelegationActors:    database.StringArray{"machine1"},
+				ImpersonationActors: database.StringArray{"machine2"},
+				IsDefault:           true,
+			},
+		},
+		{
+			name:    "prepareTokenExchangePolicyQuery sql err",
+			prepare: prepareTokenExchangePolicyQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					tokenExchangePolicyStmt,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err)
+		})
+	}
+}
@@ -688,6 +688,38 @@ func NewUserLoginNameExistsQuery(value string, comparison TextComparison) (Searc
 	)
 }
 
+func NewUserMetadataExistsQuery(key string, value []byte) (SearchQuery, error) {
+	//linking queries for the subselect
+	instanceQuery, err := NewColumnComparisonQuery(UserMetadataInstanceIDCol, UserInstanceIDCol, ColumnEquals)
+	if err != nil {
+		return nil, err
+	}
+	userIDQuery, err := NewColumnComparisonQuery(UserMetadataUserIDCol, UserIDCol, ColumnEquals)
+	if err != nil {
+		return nil, err
+	}
+	//text and bytes query to select data from the linked sub select
+	keyQuery, err := NewUserMetadataKeySearchQuery(key, TextEquals)
+	if err != nil {
+		return nil, err
+	}
+	valueQuery, err := NewBytesQuery(UserMetadataValueCol, value)
+	if err != nil {
+		return nil, err
+	}
+	//full definition of the sub select
+	subSelect, err := NewSubSelect(UserMetadataUserIDCol, []SearchQuery{instanceQuery, userIDQuery, keyQuery, valueQuery})
+	if err != nil {
+		return nil, err
+	}
+	// "WHERE * IN (*)" query with subquery as list-data provider
+	return NewListQuery(
+		UserIDCol,
+		subSelect,
+		ListIn,
+	)
+}
+
 func prepareLoginNamesQuery() (string, []interface{}, error) {
 	return sq.Select(
 		userLoginNamesUserIDCol.identifier(),
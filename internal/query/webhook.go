@@ -0,0 +1,565 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	webhookTable = table{
+		name:          projection.WebhookTable,
+		instanceIDCol: projection.WebhookInstanceIDCol,
+	}
+	WebhookColumnID = Column{
+		name:  projection.WebhookIDCol,
+		table: webhookTable,
+	}
+	WebhookColumnCreationDate = Column{
+		name:  projection.WebhookCreationDateCol,
+		table: webhookTable,
+	}
+	WebhookColumnChangeDate = Column{
+		name:  projection.WebhookChangeDateCol,
+		table: webhookTable,
+	}
+	WebhookColumnResourceOwner = Column{
+		name:  projection.WebhookResourceOwnerCol,
+		table: webhookTable,
+	}
+	WebhookColumnInstanceID = Column{
+		name:  projection.WebhookInstanceIDCol,
+		table: webhookTable,
+	}
+	WebhookColumnSequence = Column{
+		name:  projection.WebhookSequenceCol,
+		table: webhookTable,
+	}
+	WebhookColumnState = Column{
+		name:  projection.WebhookStateCol,
+		table: webhookTable,
+	}
+	WebhookColumnName = Column{
+		name:  projection.WebhookNameCol,
+		table: webhookTable,
+	}
+	WebhookColumnURL = Column{
+		name:  projection.WebhookURLCol,
+		table: webhookTable,
+	}
+	WebhookColumnEventTypes = Column{
+		name:  projection.WebhookEventTypesCol,
+		table: webhookTable,
+	}
+	WebhookColumnAggregateTypes = Column{
+		name:  projection.WebhookAggregateTypesCol,
+		table: webhookTable,
+	}
+	WebhookColumnSigningKey = Column{
+		name:  projection.WebhookSigningKeyCol,
+		table: webhookTable,
+	}
+	WebhookColumnOwnerRemoved = Column{
+		name:  projection.WebhookOwnerRemovedCol,
+		table: webhookTable,
+	}
+)
+
+var (
+	webhookDeliveryTable = table{
+		name:          projection.WebhookDeliveryTable,
+		instanceIDCol: projection.WebhookDeliveryInstanceIDCol,
+	}
+	WebhookDeliveryColumnWebhookID = Column{
+		name:  projection.WebhookDeliveryWebhookIDCol,
+		table: webhookDeliveryTable,
+	}
+	WebhookDeliveryColumnInstanceID = Column{
+		name:  projection.WebhookDeliveryInstanceIDCol,
+		table: webhookDeliveryTable,
+	}
+	WebhookDeliveryColumnEventSequence = Column{
+		name:  projection.WebhookDeliveryEventSequenceCol,
+		table: webhookDeliveryTable,
+	}
+	WebhookDeliveryColumnResourceOwner = Column{
+		name:  projection.WebhookDeliveryResourceOwnerCol,
+		table: webhookDeliveryTable,
+	}
+	WebhookDeliveryColumnAggregateType = Column{
+		name:  projection.WebhookDeliveryAggregateTypeCol,
+		table: webhookDeliveryTable,
+	}
+	WebhookDeliveryColumnAggregateID = Column{
+		name:  projection.WebhookDeliveryAggregateIDCol,
+		table: webhookDeliveryTable,
+	}
+	WebhookDeliveryColumnEventType = Column{
+		name:  projection.WebhookDeliveryEventTypeCol,
+		table: webhookDeliveryTable,
+	}
+	WebhookDeliveryColumnEventCreationDate = Column{
+		name:  projection.WebhookDeliveryEventCreationDateCol,
+		table: webhookDeliveryTable,
+	}
+	WebhookDeliveryColumnCreationDate = Column{
+		name:  projection.WebhookDeliveryCreationDateCol,
+		table: webhookDeliveryTable,
+	}
+	WebhookDeliveryColumnChangeDate = Column{
+		name:  projection.WebhookDeliveryChangeDateCol,
+		table: webhookDeliveryTable,
+	}
+	WebhookDeliveryColumnState = Column{
+		name:  projection.WebhookDeliveryStateCol,
+		table: webhookDeliveryTable,
+	}
+	WebhookDeliveryColumnAttempts = Column{
+		name:  projection.WebhookDeliveryAttemptsCol,
+		table: webhookDeliveryTable,
+	}
+	WebhookDeliveryColumnStatusCode = Column{
+		name:  projection.WebhookDeliveryStatusCodeCol,
+		table: webhookDeliveryTable,
+	}
+	WebhookDeliveryColumnLastError = Column{
+		name:  projection.WebhookDeliveryLastErrorCol,
+		table: webhookDeliveryTable,
+	}
+)
+
+type Webhooks struct {
+	SearchResponse
+	Webhooks []*Webhook
+}
+
+type Webhook struct {
+	ID            string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	State         domain.WebhookState
+	Sequence      uint64
+
+	Name           string
+	URL            string
+	EventTypes     database.StringArray
+	AggregateTypes database.StringArray
+	SigningKey     *crypto.CryptoValue
+}
+
+// Matches checks the event and aggregate type against the filters of the webhook,
+// empty filters match all types
+func (w *Webhook) Matches(aggregateType, eventType string) bool {
+	return matchesFilter(w.AggregateTypes, aggregateType) && matchesFilter(w.EventTypes, eventType)
+}
+
+func matchesFilter(filter []string, value string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	for _, f := range filter {
+		if f == value {
+			return true
+		}
+	}
+	return false
+}
+
+type WebhookSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *WebhookSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+type WebhookDeliveries struct {
+	SearchResponse
+	Deliveries []*WebhookDelivery
+}
+
+type WebhookDelivery struct {
+	WebhookID         string
+	EventSequence     uint64
+	ResourceOwner     string
+	AggregateType     string
+	AggregateID       string
+	EventType         string
+	EventCreationDate time.Time
+	CreationDate      time.Time
+	ChangeDate        time.Time
+	State             domain.WebhookDeliveryState
+	Attempts          uint64
+	StatusCode        int
+	LastError         string
+}
+
+type WebhookDeliverySearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *WebhookDeliverySearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func (q *Queries) SearchWebhooks(ctx context.Context, queries *WebhookSearchQueries, withOwnerRemoved bool) (webhooks *Webhooks, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareWebhooksQuery()
+	eq := sq.Eq{
+		WebhookColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	if !withOwnerRemoved {
+		eq[WebhookColumnOwnerRemoved.identifier()] = false
+	}
+	stmt, args, err := queries.toQuery(query).Where(eq).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Phoo9", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Aek5e", "Errors.Internal")
+	}
+	webhooks, err = scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	webhooks.LatestSequence, err = q.latestSequence(ctx, webhookTable)
+	return webhooks, err
+}
+
+func (q *Queries) GetWebhookByID(ctx context.Context, id string, resourceOwner string, withOwnerRemoved bool) (_ *Webhook, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	stmt, scan := prepareWebhookQuery()
+	eq := sq.Eq{
+		WebhookColumnID.identifier():            id,
+		WebhookColumnResourceOwner.identifier(): resourceOwner,
+		WebhookColumnInstanceID.identifier():    authz.GetInstance(ctx).InstanceID(),
+	}
+	if !withOwnerRemoved {
+		eq[WebhookColumnOwnerRemoved.identifier()] = false
+	}
+	query, args, err := stmt.Where(eq).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-ahB4u", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+// ActiveWebhooksByResourceOwners returns the active webhooks of the instance,
+// which are owned by one of the resource owners (instance or organization)
+func (q *Queries) ActiveWebhooksByResourceOwners(ctx context.Context, resourceOwners ...string) (_ []*Webhook, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareWebhooksQuery()
+	stmt, args, err := query.Where(sq.Eq{
+		WebhookColumnInstanceID.identifier():    authz.GetInstance(ctx).InstanceID(),
+		WebhookColumnResourceOwner.identifier(): resourceOwners,
+		WebhookColumnState.identifier():         domain.WebhookStateActive,
+		WebhookColumnOwnerRemoved.identifier():  false,
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Ohs1e", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-ieX3o", "Errors.Internal")
+	}
+	webhooks, err := scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	return webhooks.Webhooks, nil
+}
+
+// SearchWebhookDeliveries returns the deliveries of the webhook,
+// the webhook has to be owned by the resource owner
+func (q *Queries) SearchWebhookDeliveries(ctx context.Context, webhookID, resourceOwner string, queries *WebhookDeliverySearchQueries) (deliveries *WebhookDeliveries, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareWebhookDeliveriesQuery()
+	stmt, args, err := queries.toQuery(query).Where(sq.Eq{
+		WebhookDeliveryColumnWebhookID.identifier():     webhookID,
+		WebhookDeliveryColumnResourceOwner.identifier(): resourceOwner,
+		WebhookDeliveryColumnInstanceID.identifier():    authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Shu2e", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-aiR2e", "Errors.Internal")
+	}
+	deliveries, err = scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	deliveries.LatestSequence, err = q.latestSequence(ctx, webhookTable)
+	return deliveries, err
+}
+
+func (q *Queries) GetWebhookDelivery(ctx context.Context, webhookID string, eventSequence uint64) (_ *WebhookDelivery, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	stmt, scan := prepareWebhookDeliveryQuery()
+	query, args, err := stmt.Where(sq.Eq{
+		WebhookDeliveryColumnWebhookID.identifier():     webhookID,
+		WebhookDeliveryColumnEventSequence.identifier(): eventSequence,
+		WebhookDeliveryColumnInstanceID.identifier():    authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Xoo2u", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+func NewWebhookResourceOwnerQuery(id string) (SearchQuery, error) {
+	return NewTextQuery(WebhookColumnResourceOwner, id, TextEquals)
+}
+
+func NewWebhookNameSearchQuery(method TextComparison, value string) (SearchQuery, error) {
+	return NewTextQuery(WebhookColumnName, value, method)
+}
+
+func NewWebhookStateSearchQuery(value domain.WebhookState) (SearchQuery, error) {
+	return NewNumberQuery(WebhookColumnState, int(value), NumberEquals)
+}
+
+func NewWebhookIDSearchQuery(id string) (SearchQuery, error) {
+	return NewTextQuery(WebhookColumnID, id, TextEquals)
+}
+
+func NewWebhookDeliveryStateSearchQuery(value domain.WebhookDeliveryState) (SearchQuery, error) {
+	return NewNumberQuery(WebhookDeliveryColumnState, int(value), NumberEquals)
+}
+
+func NewWebhookDeliveryEventTypeSearchQuery(method TextComparison, value string) (SearchQuery, error) {
+	return NewTextQuery(WebhookDeliveryColumnEventType, value, method)
+}
+
+func prepareWebhooksQuery() (sq.SelectBuilder, func(rows *sql.Rows) (*Webhooks, error)) {
+	return sq.Select(
+			WebhookColumnID.identifier(),
+			WebhookColumnCreationDate.identifier(),
+			WebhookColumnChangeDate.identifier(),
+			WebhookColumnResourceOwner.identifier(),
+			WebhookColumnSequence.identifier(),
+			WebhookColumnState.identifier(),
+			WebhookColumnName.identifier(),
+			WebhookColumnURL.identifier(),
+			WebhookColumnEventTypes.identifier(),
+			WebhookColumnAggregateTypes.identifier(),
+			WebhookColumnSigningKey.identifier(),
+			countColumn.identifier(),
+		).From(webhookTable.identifier()).PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*Webhooks, error) {
+			webhooks := make([]*Webhook, 0)
+			var count uint64
+			for rows.Next() {
+				webhook := new(Webhook)
+				err := rows.Scan(
+					&webhook.ID,
+					&webhook.CreationDate,
+					&webhook.ChangeDate,
+					&webhook.ResourceOwner,
+					&webhook.Sequence,
+					&webhook.State,
+					&webhook.Name,
+					&webhook.URL,
+					&webhook.EventTypes,
+					&webhook.AggregateTypes,
+					&webhook.SigningKey,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				webhooks = append(webhooks, webhook)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Jah6e", "Errors.Query.CloseRows")
+			}
+
+			return &Webhooks{
+				Webhooks: webhooks,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+func prepareWebhookQuery() (sq.SelectBuilder, func(row *sql.Row) (*Webhook, error)) {
+	return sq.Select(
+			WebhookColumnID.identifier(),
+			WebhookColumnCreationDate.identifier(),
+			WebhookColumnChangeDate.identifier(),
+			WebhookColumnResourceOwner.identifier(),
+			WebhookColumnSequence.identifier(),
+			WebhookColumnState.identifier(),
+			WebhookColumnName.identifier(),
+			WebhookColumnURL.identifier(),
+			WebhookColumnEventTypes.identifier(),
+			WebhookColumnAggregateTypes.identifier(),
+			WebhookColumnSigningKey.identifier(),
+		).From(webhookTable.identifier()).PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*Webhook, error) {
+			webhook := new(Webhook)
+			err := row.Scan(
+				&webhook.ID,
+				&webhook.CreationDate,
+				&webhook.ChangeDate,
+				&webhook.ResourceOwner,
+				&webhook.Sequence,
+				&webhook.State,
+				&webhook.Name,
+				&webhook.URL,
+				&webhook.EventTypes,
+				&webhook.AggregateTypes,
+				&webhook.SigningKey,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Ieng7", "Errors.Webhook.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Ohg4a", "Errors.Internal")
+			}
+			return webhook, nil
+		}
+}
+
+func prepareWebhookDeliveriesQuery() (sq.SelectBuilder, func(rows *sql.Rows) (*WebhookDeliveries, error)) {
+	return sq.Select(
+			WebhookDeliveryColumnWebhookID.identifier(),
+			WebhookDeliveryColumnEventSequence.identifier(),
+			WebhookDeliveryColumnResourceOwner.identifier(),
+			WebhookDeliveryColumnAggregateType.identifier(),
+			WebhookDeliveryColumnAggregateID.identifier(),
+			WebhookDeliveryColumnEventType.identifier(),
+			WebhookDeliveryColumnEventCreationDate.identifier(),
+			WebhookDeliveryColumnCreationDate.identifier(),
+			WebhookDeliveryColumnChangeDate.identifier(),
+			WebhookDeliveryColumnState.identifier(),
+			WebhookDeliveryColumnAttempts.identifier(),
+			WebhookDeliveryColumnStatusCode.identifier(),
+			WebhookDeliveryColumnLastError.identifier(),
+			countColumn.identifier(),
+		).From(webhookDeliveryTable.identifier()).PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*WebhookDeliveries, error) {
+			deliveries := make([]*WebhookDelivery, 0)
+			var count uint64
+			for rows.Next() {
+				delivery := new(WebhookDelivery)
+				err := rows.Scan(
+					&delivery.WebhookID,
+					&delivery.EventSequence,
+					&delivery.ResourceOwner,
+					&delivery.AggregateType,
+					&delivery.AggregateID,
+					&delivery.EventType,
+					&delivery.EventCreationDate,
+					&delivery.CreationDate,
+					&delivery.ChangeDate,
+					&delivery.State,
+					&delivery.Attempts,
+					&delivery.StatusCode,
+					&delivery.LastError,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				deliveries = append(deliveries, delivery)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-uShe8", "Errors.Query.CloseRows")
+			}
+
+			return &WebhookDeliveries{
+				Deliveries: deliveries,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+func prepareWebhookDeliveryQuery() (sq.SelectBuilder, func(row *sql.Row) (*WebhookDelivery, error)) {
+	return sq.Select(
+			WebhookDeliveryColumnWebhookID.identifier(),
+			WebhookDeliveryColumnEventSequence.identifier(),
+			WebhookDeliveryColumnResourceOwner.identifier(),
+			WebhookDeliveryColumnAggregateType.identifier(),
+			WebhookDeliveryColumnAggregateID.identifier(),
+			WebhookDeliveryColumnEventType.identifier(),
+			WebhookDeliveryColumnEventCreationDate.identifier(),
+			WebhookDeliveryColumnCreationDate.identifier(),
+			WebhookDeliveryColumnChangeDate.identifier(),
+			WebhookDeliveryColumnState.identifier(),
+			WebhookDeliveryColumnAttempts.identifier(),
+			WebhookDeliveryColumnStatusCode.identifier(),
+			WebhookDeliveryColumnLastError.identifier(),
+		).From(webhookDeliveryTable.identifier()).PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*WebhookDelivery, error) {
+			delivery := new(WebhookDelivery)
+			err := row.Scan(
+				&delivery.WebhookID,
+				&delivery.EventSequence,
+				&delivery.ResourceOwner,
+				&delivery.AggregateType,
+				&delivery.AggregateID,
+				&delivery.EventType,
+				&delivery.EventCreationDate,
+				&delivery.CreationDate,
+				&delivery.ChangeDate,
+				&delivery.State,
+				&delivery.Attempts,
+				&delivery.StatusCode,
+				&delivery.LastError,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Eim8o", "Errors.Webhook.Delivery.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-ooH3i", "Errors.Internal")
+			}
+			return delivery, nil
+		}
+}
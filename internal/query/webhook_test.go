@@ -0,0 +1,484 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+func Test_WebhookPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareWebhooksQuery no result",
+			prepare: prepareWebhooksQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(`SELECT projections.webhooks.id,`+
+						` projections.webhooks.creation_date,`+
+						` projections.webhooks.change_date,`+
+						` projections.webhooks.resource_owner,`+
+						` projections.webhooks.sequence,`+
+						` projections.webhooks.state,`+
+						` projections.webhooks.name,`+
+						` projections.webhooks.url,`+
+						` projections.webhooks.event_types,`+
+						` projections.webhooks.aggregate_types,`+
+						` projections.webhooks.signing_key,`+
+						` COUNT(*) OVER ()`+
+						` FROM projections.webhooks`),
+					nil,
+					nil,
+				),
+			},
+			object: &Webhooks{Webhooks: []*Webhook{}},
+		},
+		{
+			name:    "prepareWebhooksQuery one result",
+			prepare: prepareWebhooksQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(`SELECT projections.webhooks.id,`+
+						` projections.webhooks.creation_date,`+
+						` projections.webhooks.change_date,`+
+						` projections.webhooks.resource_owner,`+
+						` projections.webhooks.sequence,`+
+						` projections.webhooks.state,`+
+						` projections.webhooks.name,`+
+						` projections.webhooks.url,`+
+						` projections.webhooks.event_types,`+
+						` projections.webhooks.aggregate_types,`+
+						` projections.webhooks.signing_key,`+
+						` COUNT(*) OVER ()`+
+						` FROM projections.webhooks`),
+					[]string{
+						"id",
+						"creation_date",
+						"change_date",
+						"resource_owner",
+						"sequence",
+						"state",
+						"name",
+						"url",
+						"event_types",
+						"aggregate_types",
+						"signing_key",
+						"count",
+					},
+					[][]driver.Value{
+						{
+							"id",
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211109),
+							domain.WebhookStateActive,
+							"webhook-name",
+							"https://example.com/hook",
+							database.StringArray{"user.human.added"},
+							nil,
+							[]byte(`{"cryptoType":0,"algorithm":"enc","keyID":"id","crypted":"YQ=="}`),
+						},
+					},
+				),
+			},
+			object: &Webhooks{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Webhooks: []*Webhook{
+					{
+						ID:             "id",
+						CreationDate:   testNow,
+						ChangeDate:     testNow,
+						ResourceOwner:  "ro",
+						State:          domain.WebhookStateActive,
+						Sequence:       20211109,
+						Name:           "webhook-name",
+						URL:            "https://example.com/hook",
+						EventTypes:     database.StringArray{"user.human.added"},
+						AggregateTypes: nil,
+						SigningKey: &crypto.CryptoValue{
+							CryptoType: crypto.TypeEncryption,
+							Algorithm:  "enc",
+							KeyID:      "id",
+							Crypted:    []byte("a"),
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareWebhooksQuery sql err",
+			prepare: prepareWebhooksQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(`SELECT projections.webhooks.id,`+
+						` projections.webhooks.creation_date,`+
+						` projections.webhooks.change_date,`+
+						` projections.webhooks.resource_owner,`+
+						` projections.webhooks.sequence,`+
+						` projections.webhooks.state,`+
+						` projections.webhooks.name,`+
+						` projections.webhooks.url,`+
+						` projections.webhooks.event_types,`+
+						` projections.webhooks.aggregate_types,`+
+						` projections.webhooks.signing_key,`+
+						` COUNT(*) OVER ()`+
+						` FROM projections.webhooks`),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+		{
+			name:    "prepareWebhookQuery no result",
+			prepare: prepareWebhookQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(`SELECT projections.webhooks.id,`+
+						` projections.webhooks.creation_date,`+
+						` projections.webhooks.change_date,`+
+						` projections.webhooks.resource_owner,`+
+						` projections.webhooks.sequence,`+
+						` projections.webhooks.state,`+
+						` projections.webhooks.name,`+
+						` projections.webhooks.url,`+
+						` projections.webhooks.event_types,`+
+						` projections.webhooks.aggregate_types,`+
+						` projections.webhooks.signing_key`+
+						` FROM projections.webhooks`),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*Webhook)(nil),
+		},
+		{
+			name:    "prepareWebhookQuery found",
+			prepare: prepareWebhookQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(`SELECT projections.webhooks.id,`+
+						` projections.webhooks.creation_date,`+
+						` projections.webhooks.change_date,`+
+						` projections.webhooks.resource_owner,`+
+						` projections.webhooks.sequence,`+
+						` projections.webhooks.state,`+
+						` projections.webhooks.name,`+
+						` projections.webhooks.url,`+
+						` projections.webhooks.event_types,`+
+						` projections.webhooks.aggregate_types,`+
+						` projections.webhooks.signing_key`+
+						` FROM projections.webhooks`),
+					[]string{
+						"id",
+						"creation_date",
+						"change_date",
+						"resource_owner",
+						"sequence",
+						"state",
+						"name",
+						"url",
+						"event_types",
+						"aggregate_types",
+						"signing_key",
+					},
+					[]driver.Value{
+						"id",
+						testNow,
+						testNow,
+						"ro",
+						uint64(20211109),
+						domain.WebhookStateActive,
+						"webhook-name",
+						"https://example.com/hook",
+						database.StringArray{"user.human.added"},
+						nil,
+						[]byte(`{"cryptoType":0,"algorithm":"enc","keyID":"id","crypted":"YQ=="}`),
+					},
+				),
+			},
+			object: &Webhook{
+				ID:             "id",
+				CreationDate:   testNow,
+				ChangeDate:     testNow,
+				ResourceOwner:  "ro",
+				State:          domain.WebhookStateActive,
+				Sequence:       20211109,
+				Name:           "webhook-name",
+				URL:            "https://example.com/hook",
+				EventTypes:     database.StringArray{"user.human.added"},
+				AggregateTypes: nil,
+				SigningKey: &crypto.CryptoValue{
+					CryptoType: crypto.TypeEncryption,
+					Algorithm:  "enc",
+					KeyID:      "id",
+					Crypted:    []byte("a"),
+				},
+			},
+		},
+		{
+			name:    "prepareWebhookDeliveriesQuery one result",
+			prepare: prepareWebhookDeliveriesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(`SELECT projections.webhooks_deliveries.webhook_id,`+
+						` projections.webhooks_deliveries.event_sequence,`+
+						` projections.webhooks_deliveries.resource_owner,`+
+						` projections.webhooks_deliveries.aggregate_type,`+
+						` projections.webhooks_deliveries.aggregate_id,`+
+						` projections.webhooks_deliveries.event_type,`+
+						` projections.webhooks_deliveries.event_creation_date,`+
+						` projections.webhooks_deliveries.creation_date,`+
+						` projections.webhooks_deliveries.change_date,`+
+						` projections.webhooks_deliveries.state,`+
+						` projections.webhooks_deliveries.attempts,`+
+						` projections.webhooks_deliveries.status_code,`+
+						` projections.webhooks_deliveries.last_error,`+
+						` COUNT(*) OVER ()`+
+						` FROM projections.webhooks_deliveries`),
+					[]string{
+						"webhook_id",
+						"event_sequence",
+						"resource_owner",
+						"aggregate_type",
+						"aggregate_id",
+						"event_type",
+						"event_creation_date",
+						"creation_date",
+						"change_date",
+						"state",
+						"attempts",
+						"status_code",
+						"last_error",
+						"count",
+					},
+					[][]driver.Value{
+						{
+							"webhook-id",
+							uint64(20211109),
+							"ro",
+							"user",
+							"user-id",
+							"user.human.added",
+							testNow,
+							testNow,
+							testNow,
+							domain.WebhookDeliveryStateFailed,
+							uint64(2),
+							500,
+							"unexpected status code 500",
+						},
+					},
+				),
+			},
+			object: &WebhookDeliveries{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Deliveries: []*WebhookDelivery{
+					{
+						WebhookID:         "webhook-id",
+						EventSequence:     20211109,
+						ResourceOwner:     "ro",
+						AggregateType:     "user",
+						AggregateID:       "user-id",
+						EventType:         "user.human.added",
+						EventCreationDate: testNow,
+						CreationDate:      testNow,
+						ChangeDate:        testNow,
+						State:             domain.WebhookDeliveryStateFailed,
+						Attempts:          2,
+						StatusCode:        500,
+						LastError:         "unexpected status code 500",
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareWebhookDeliveryQuery no result",
+			prepare: prepareWebhookDeliveryQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(`SELECT projections.webhooks_deliveries.webhook_id,`+
+						` projections.webhooks_deliveries.event_sequence,`+
+						` projections.webhooks_deliveries.resource_owner,`+
+						` projections.webhooks_deliveries.aggregate_type,`+
+						` projections.webhooks_deliveries.aggregate_id,`+
+						` projections.webhooks_deliveries.event_type,`+
+						` projections.webhooks_deliveries.event_creation_date,`+
+						` projections.webhooks_deliveries.creation_date,`+
+						` projections.webhooks_deliveries.change_date,`+
+						` projections.webhooks_deliveries.state,`+
+						` projections.webhooks_deliveries.attempts,`+
+						` projections.webhooks_deliveries.status_code,`+
+						` projections.webhooks_deliveries.last_error`+
+						` FROM projections.webhooks_deliveries`),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*WebhookDelivery)(nil),
+		},
+		{
+			name:    "prepareWebhookDeliveryQuery found",
+			prepare: prepareWebhookDeliveryQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(`SELECT projections.webhooks_deliveries.webhook_id,`+
+						` projections.webhooks_deliveries.event_sequence,`+
+						` projections.webhooks_deliveries.resource_owner,`+
+						` projections.webhooks_deliveries.aggregate_type,`+
+						` projections.webhooks_deliveries.aggregate_id,`+
+						` projections.webhooks_deliveries.event_type,`+
+						` projections.webhooks_deliveries.event_creation_date,`+
+						` projections.webhooks_deliveries.creation_date,`+
+						` projections.webhooks_deliveries.change_date,`+
+						` projections.webhooks_deliveries.state,`+
+						` projections.webhooks_deliveries.attempts,`+
+						` projections.webhooks_deliveries.status_code,`+
+						` projections.webhooks_deliveries.last_error`+
+						` FROM projections.webhooks_deliveries`),
+					[]string{
+						"webhook_id",
+						"event_sequence",
+						"resource_owner",
+						"aggregate_type",
+						"aggregate_id",
+						"event_type",
+						"event_creation_date",
+						"creation_date",
+						"change_date",
+						"state",
+						"attempts",
+						"status_code",
+						"last_error",
+					},
+					[]driver.Value{
+						"webhook-id",
+						uint64(20211109),
+						"ro",
+						"user",
+						"user-id",
+						"user.human.added",
+						testNow,
+						testNow,
+						testNow,
+						domain.WebhookDeliveryStateFailed,
+						uint64(2),
+						500,
+						"unexpected status code 500",
+					},
+				),
+			},
+			object: &WebhookDelivery{
+				WebhookID:         "webhook-id",
+				EventSequence:     20211109,
+				ResourceOwner:     "ro",
+				AggregateType:     "user",
+				AggregateID:       "user-id",
+				EventType:         "user.human.added",
+				EventCreationDate: testNow,
+				CreationDate:      testNow,
+				ChangeDate:        testNow,
+				State:             domain.WebhookDeliveryStateFailed,
+				Attempts:          2,
+				StatusCode:        500,
+				LastError:         "unexpected status code 500",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err)
+		})
+	}
+}
+
+func TestWebhook_Matches(t *testing.T) {
+	tests := []struct {
+		name          string
+		webhook       *Webhook
+		aggregateType string
+		eventType     string
+		want          bool
+	}{
+		{
+			name:          "no filters",
+			webhook:       &Webhook{},
+			aggregateType: "user",
+			eventType:     "user.human.added",
+			want:          true,
+		},
+		{
+			name: "event type matches",
+			webhook: &Webhook{
+				EventTypes: database.StringArray{"user.human.added"},
+			},
+			aggregateType: "user",
+			eventType:     "user.human.added",
+			want:          true,
+		},
+		{
+			name: "event type does not match",
+			webhook: &Webhook{
+				EventTypes: database.StringArray{"user.human.added"},
+			},
+			aggregateType: "user",
+			eventType:     "user.removed",
+			want:          false,
+		},
+		{
+			name: "aggregate type does not match",
+			webhook: &Webhook{
+				AggregateTypes: database.StringArray{"org"},
+				EventTypes:     database.StringArray{"user.human.added"},
+			},
+			aggregateType: "user",
+			eventType:     "user.human.added",
+			want:          false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.webhook.Matches(tt.aggregateType, tt.eventType); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
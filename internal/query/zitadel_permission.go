@@ -30,17 +30,21 @@ func (q *Queries) MyZitadelPermissions(ctx context.Context, orgID, userID string
 	if err != nil {
 		return nil, err
 	}
+	roleMappings, err := q.zitadelRoleMappings(ctx)
+	if err != nil {
+		return nil, err
+	}
 	permissions := &domain.Permissions{Permissions: []string{}}
 	for _, membership := range memberships.Memberships {
 		for _, role := range membership.Roles {
-			permissions = q.mapRoleToPermission(permissions, membership, role)
+			permissions = mapRoleToPermission(permissions, roleMappings, membership, role)
 		}
 	}
 	return permissions, nil
 }
 
-func (q *Queries) mapRoleToPermission(permissions *domain.Permissions, membership *Membership, role string) *domain.Permissions {
-	for _, mapping := range q.zitadelRoles {
+func mapRoleToPermission(permissions *domain.Permissions, roleMappings []authz.RoleMapping, membership *Membership, role string) *domain.Permissions {
+	for _, mapping := range roleMappings {
 		if mapping.Role == role {
 			ctxID := ""
 			if membership.Project != nil {
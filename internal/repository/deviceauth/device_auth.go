@@ -0,0 +1,233 @@
+package deviceauth
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	UniqueDeviceCodeType = "device_auth_device_code"
+	UniqueUserCodeType   = "device_auth_user_code"
+	eventTypePrefix      = eventstore.EventType("device.authorization.")
+	AddedEventType       = eventTypePrefix + "added"
+	ApprovedEventType    = eventTypePrefix + "approved"
+	CanceledEventType    = eventTypePrefix + "canceled"
+	RemovedEventType     = eventTypePrefix + "removed"
+)
+
+func NewAddUniqueConstraints(deviceCode, userCode string) []*eventstore.EventUniqueConstraint {
+	return []*eventstore.EventUniqueConstraint{
+		eventstore.NewAddEventUniqueConstraint(
+			UniqueDeviceCodeType,
+			deviceCode,
+			"Errors.DeviceAuth.AlreadyExists",
+		),
+		eventstore.NewAddEventUniqueConstraint(
+			UniqueUserCodeType,
+			userCode,
+			"Errors.DeviceAuth.AlreadyExists",
+		),
+	}
+}
+
+func NewRemoveUniqueConstraints(deviceCode, userCode string) []*eventstore.EventUniqueConstraint {
+	return []*eventstore.EventUniqueConstraint{
+		eventstore.NewRemoveEventUniqueConstraint(
+			UniqueDeviceCodeType,
+			deviceCode,
+		),
+		eventstore.NewRemoveEventUniqueConstraint(
+			UniqueUserCodeType,
+			userCode,
+		),
+	}
+}
+
+type AddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ClientID   string    `json:"clientId"`
+	DeviceCode string    `json:"deviceCode"`
+	UserCode   string    `json:"userCode"`
+	Expires    time.Time `json:"expires"`
+	Scopes     []string  `json:"scopes"`
+}
+
+func (e *AddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *AddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return NewAddUniqueConstraints(e.DeviceCode, e.UserCode)
+}
+
+func NewAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	clientID string,
+	deviceCode string,
+	userCode string,
+	expires time.Time,
+	scopes []string,
+) *AddedEvent {
+	return &AddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			AddedEventType,
+		),
+		ClientID:   clientID,
+		DeviceCode: deviceCode,
+		UserCode:   userCode,
+		Expires:    expires,
+		Scopes:     scopes,
+	}
+}
+
+func AddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &AddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "DEVAUTH-Ohx8e", "unable to unmarshal device authorization added")
+	}
+
+	return e, nil
+}
+
+type ApprovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Subject string `json:"subject"`
+}
+
+func (e *ApprovedEvent) Data() interface{} {
+	return e
+}
+
+func (e *ApprovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewApprovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	subject string,
+) *ApprovedEvent {
+	return &ApprovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ApprovedEventType,
+		),
+		Subject: subject,
+	}
+}
+
+func ApprovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &ApprovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "DEVAUTH-ahr3E", "unable to unmarshal device authorization approved")
+	}
+
+	return e, nil
+}
+
+type CanceledEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Reason domain.DeviceAuthCanceled `json:"reason"`
+}
+
+func (e *CanceledEvent) Data() interface{} {
+	return e
+}
+
+func (e *CanceledEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewCanceledEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	reason domain.DeviceAuthCanceled,
+) *CanceledEvent {
+	return &CanceledEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			CanceledEventType,
+		),
+		Reason: reason,
+	}
+}
+
+func CanceledEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &CanceledEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "DEVAUTH-Bae7k", "unable to unmarshal device authorization canceled")
+	}
+
+	return e, nil
+}
+
+type RemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	DeviceCode string `json:"deviceCode"`
+	UserCode   string `json:"userCode"`
+}
+
+func (e *RemovedEvent) Data() interface{} {
+	return e
+}
+
+func (e *RemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return NewRemoveUniqueConstraints(e.DeviceCode, e.UserCode)
+}
+
+func NewRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	deviceCode string,
+	userCode string,
+) *RemovedEvent {
+	return &RemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			RemovedEventType,
+		),
+		DeviceCode: deviceCode,
+		UserCode:   userCode,
+	}
+}
+
+func RemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &RemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "DEVAUTH-Ahc2i", "unable to unmarshal device authorization removed")
+	}
+
+	return e, nil
+}
@@ -0,0 +1,12 @@
+package deviceauth
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+func RegisterEventMappers(es *eventstore.Eventstore) {
+	es.RegisterFilterEventMapper(AggregateType, AddedEventType, AddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, ApprovedEventType, ApprovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, CanceledEventType, CanceledEventMapper).
+		RegisterFilterEventMapper(AggregateType, RemovedEventType, RemovedEventMapper)
+}
@@ -1,6 +1,7 @@
 package idp
 
 import (
+	"database/sql/driver"
 	"encoding/json"
 
 	"github.com/zitadel/zitadel/internal/crypto"
@@ -25,9 +26,39 @@ type LDAPIDPAddedEvent struct {
 	Password            *crypto.CryptoValue `json:"password"`
 
 	LDAPAttributes
+	GroupMappings LDAPGroupMappings `json:"groupMappings,omitempty"`
 	Options
 }
 
+// LDAPGroupMapping maps the members of an LDAP group (identified by its DN)
+// to roles of a project (granted through a user grant) and / or roles as member of their organisation
+type LDAPGroupMapping struct {
+	GroupDN         string   `json:"groupDN"`
+	ProjectID       string   `json:"projectID,omitempty"`
+	ProjectRoleKeys []string `json:"projectRoleKeys,omitempty"`
+	OrgMemberRoles  []string `json:"orgMemberRoles,omitempty"`
+}
+
+// LDAPGroupMappings are stored as JSON in the projection
+type LDAPGroupMappings []LDAPGroupMapping
+
+func (m LDAPGroupMappings) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return json.Marshal(m)
+}
+
+func (m *LDAPGroupMappings) Scan(src interface{}) error {
+	if b, ok := src.([]byte); ok {
+		return json.Unmarshal(b, m)
+	}
+	if s, ok := src.(string); ok {
+		return json.Unmarshal([]byte(s), m)
+	}
+	return nil
+}
+
 type LDAPAttributes struct {
 	IDAttribute                string `json:"idAttribute,omitempty"`
 	FirstNameAttribute         string `json:"firstNameAttribute,omitempty"`
@@ -42,6 +73,8 @@ type LDAPAttributes struct {
 	PreferredLanguageAttribute string `json:"preferredLanguageAttribute,omitempty"`
 	AvatarURLAttribute         string `json:"avatarURLAttribute,omitempty"`
 	ProfileAttribute           string `json:"profileAttribute,omitempty"`
+	GroupsAttribute            string `json:"groupsAttribute,omitempty"`
+	DisabledAttribute          string `json:"disabledAttribute,omitempty"`
 }
 
 func (o *LDAPAttributes) Changes(attributes LDAPAttributes) LDAPAttributeChanges {
@@ -85,6 +118,12 @@ func (o *LDAPAttributes) Changes(attributes LDAPAttributes) LDAPAttributeChanges
 	if o.ProfileAttribute != attributes.ProfileAttribute {
 		attrs.ProfileAttribute = &attributes.ProfileAttribute
 	}
+	if o.GroupsAttribute != attributes.GroupsAttribute {
+		attrs.GroupsAttribute = &attributes.GroupsAttribute
+	}
+	if o.DisabledAttribute != attributes.DisabledAttribute {
+		attrs.DisabledAttribute = &attributes.DisabledAttribute
+	}
 	return attrs
 }
 
@@ -128,6 +167,12 @@ func (o *LDAPAttributes) ReduceChanges(changes LDAPAttributeChanges) {
 	if changes.ProfileAttribute != nil {
 		o.ProfileAttribute = *changes.ProfileAttribute
 	}
+	if changes.GroupsAttribute != nil {
+		o.GroupsAttribute = *changes.GroupsAttribute
+	}
+	if changes.DisabledAttribute != nil {
+		o.DisabledAttribute = *changes.DisabledAttribute
+	}
 }
 
 func NewLDAPIDPAddedEvent(
@@ -143,6 +188,7 @@ func NewLDAPIDPAddedEvent(
 	admin string,
 	password *crypto.CryptoValue,
 	attributes LDAPAttributes,
+	groupMappings []LDAPGroupMapping,
 	options Options,
 ) *LDAPIDPAddedEvent {
 	return &LDAPIDPAddedEvent{
@@ -158,6 +204,7 @@ func NewLDAPIDPAddedEvent(
 		Admin:               admin,
 		Password:            password,
 		LDAPAttributes:      attributes,
+		GroupMappings:       groupMappings,
 		Options:             options,
 	}
 }
@@ -200,6 +247,7 @@ type LDAPIDPChangedEvent struct {
 	Password            *crypto.CryptoValue `json:"password,omitempty"`
 
 	LDAPAttributeChanges
+	GroupMappings *LDAPGroupMappings `json:"groupMappings,omitempty"`
 	OptionChanges
 }
 
@@ -217,6 +265,8 @@ type LDAPAttributeChanges struct {
 	PreferredLanguageAttribute *string `json:"preferredLanguageAttribute,omitempty"`
 	AvatarURLAttribute         *string `json:"avatarURLAttribute,omitempty"`
 	ProfileAttribute           *string `json:"profileAttribute,omitempty"`
+	GroupsAttribute            *string `json:"groupsAttribute,omitempty"`
+	DisabledAttribute          *string `json:"disabledAttribute,omitempty"`
 }
 
 func (o LDAPAttributeChanges) IsZero() bool {
@@ -232,7 +282,9 @@ func (o LDAPAttributeChanges) IsZero() bool {
 		o.PhoneVerifiedAttribute == nil &&
 		o.PreferredLanguageAttribute == nil &&
 		o.AvatarURLAttribute == nil &&
-		o.ProfileAttribute == nil
+		o.ProfileAttribute == nil &&
+		o.GroupsAttribute == nil &&
+		o.DisabledAttribute == nil
 }
 
 func NewLDAPIDPChangedEvent(
@@ -317,6 +369,12 @@ func ChangeLDAPAttributes(attributes LDAPAttributeChanges) func(*LDAPIDPChangedE
 	}
 }
 
+func ChangeLDAPGroupMappings(groupMappings []LDAPGroupMapping) func(*LDAPIDPChangedEvent) {
+	return func(e *LDAPIDPChangedEvent) {
+		e.GroupMappings = (*LDAPGroupMappings)(&groupMappings)
+	}
+}
+
 func ChangeLDAPOptions(options OptionChanges) func(*LDAPIDPChangedEvent) {
 	return func(e *LDAPIDPChangedEvent) {
 		e.OptionChanges = options
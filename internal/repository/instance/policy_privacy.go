@@ -2,6 +2,7 @@ package instance
 
 import (
 	"context"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/eventstore"
 
@@ -24,6 +25,7 @@ func NewPrivacyPolicyAddedEvent(
 	tosLink,
 	privacyLink,
 	helpLink string,
+	deletionGracePeriod time.Duration,
 ) *PrivacyPolicyAddedEvent {
 	return &PrivacyPolicyAddedEvent{
 		PrivacyPolicyAddedEvent: *policy.NewPrivacyPolicyAddedEvent(
@@ -33,7 +35,8 @@ func NewPrivacyPolicyAddedEvent(
 				PrivacyPolicyAddedEventType),
 			tosLink,
 			privacyLink,
-			helpLink),
+			helpLink,
+			deletionGracePeriod),
 	}
 }
 
@@ -0,0 +1,151 @@
+package instance
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	signingKeyPolicyPrefix           = "policy.signing_key."
+	SigningKeyPolicyAddedEventType   = instanceEventTypePrefix + signingKeyPolicyPrefix + "added"
+	SigningKeyPolicyChangedEventType = instanceEventTypePrefix + signingKeyPolicyPrefix + "changed"
+)
+
+type SigningKeyPolicyAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Algorithm            string        `json:"algorithm,omitempty"`
+	KeySize              int           `json:"keySize,omitempty"`
+	KeyLifetime          time.Duration `json:"keyLifetime,omitempty"`
+	OverlapPeriod        time.Duration `json:"overlapPeriod,omitempty"`
+	PrepublicationWindow time.Duration `json:"prepublicationWindow,omitempty"`
+}
+
+func NewSigningKeyPolicyAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	algorithm string,
+	keySize int,
+	keyLifetime,
+	overlapPeriod,
+	prepublicationWindow time.Duration,
+) *SigningKeyPolicyAddedEvent {
+	return &SigningKeyPolicyAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SigningKeyPolicyAddedEventType,
+		),
+		Algorithm:            algorithm,
+		KeySize:              keySize,
+		KeyLifetime:          keyLifetime,
+		OverlapPeriod:        overlapPeriod,
+		PrepublicationWindow: prepublicationWindow,
+	}
+}
+
+func (e *SigningKeyPolicyAddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *SigningKeyPolicyAddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func SigningKeyPolicyAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &SigningKeyPolicyAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "INSTANCE-Wq8sk", "unable to unmarshal signing key policy added")
+	}
+	return e, nil
+}
+
+type SigningKeyPolicyChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Algorithm            *string        `json:"algorithm,omitempty"`
+	KeySize              *int           `json:"keySize,omitempty"`
+	KeyLifetime          *time.Duration `json:"keyLifetime,omitempty"`
+	OverlapPeriod        *time.Duration `json:"overlapPeriod,omitempty"`
+	PrepublicationWindow *time.Duration `json:"prepublicationWindow,omitempty"`
+}
+
+func (e *SigningKeyPolicyChangedEvent) Data() interface{} {
+	return e
+}
+
+func (e *SigningKeyPolicyChangedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewSigningKeyPolicyChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	changes []SigningKeyPolicyChanges,
+) (*SigningKeyPolicyChangedEvent, error) {
+	if len(changes) == 0 {
+		return nil, errors.ThrowPreconditionFailed(nil, "INSTANCE-Pw9sl", "Errors.NoChangesFound")
+	}
+	changeEvent := &SigningKeyPolicyChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SigningKeyPolicyChangedEventType,
+		),
+	}
+	for _, change := range changes {
+		change(changeEvent)
+	}
+	return changeEvent, nil
+}
+
+type SigningKeyPolicyChanges func(event *SigningKeyPolicyChangedEvent)
+
+func ChangeSigningKeyPolicyAlgorithm(algorithm string) func(event *SigningKeyPolicyChangedEvent) {
+	return func(e *SigningKeyPolicyChangedEvent) {
+		e.Algorithm = &algorithm
+	}
+}
+
+func ChangeSigningKeyPolicyKeySize(keySize int) func(event *SigningKeyPolicyChangedEvent) {
+	return func(e *SigningKeyPolicyChangedEvent) {
+		e.KeySize = &keySize
+	}
+}
+
+func ChangeSigningKeyPolicyKeyLifetime(keyLifetime time.Duration) func(event *SigningKeyPolicyChangedEvent) {
+	return func(e *SigningKeyPolicyChangedEvent) {
+		e.KeyLifetime = &keyLifetime
+	}
+}
+
+func ChangeSigningKeyPolicyOverlapPeriod(overlapPeriod time.Duration) func(event *SigningKeyPolicyChangedEvent) {
+	return func(e *SigningKeyPolicyChangedEvent) {
+		e.OverlapPeriod = &overlapPeriod
+	}
+}
+
+func ChangeSigningKeyPolicyPrepublicationWindow(prepublicationWindow time.Duration) func(event *SigningKeyPolicyChangedEvent) {
+	return func(e *SigningKeyPolicyChangedEvent) {
+		e.PrepublicationWindow = &prepublicationWindow
+	}
+}
+
+func SigningKeyPolicyChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &SigningKeyPolicyChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "INSTANCE-Lx0sm", "unable to unmarshal signing key policy changed")
+	}
+	return e, nil
+}
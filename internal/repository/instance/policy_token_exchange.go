@@ -0,0 +1,75 @@
+package instance
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+const (
+	TokenExchangePolicyAddedEventType   = instanceEventTypePrefix + policy.TokenExchangePolicyAddedEventType
+	TokenExchangePolicyChangedEventType = instanceEventTypePrefix + policy.TokenExchangePolicyChangedEventType
+)
+
+type TokenExchangePolicyAddedEvent struct {
+	policy.TokenExchangePolicyAddedEvent
+}
+
+func NewTokenExchangePolicyAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	delegationActors,
+	impersonationActors []string,
+) *TokenExchangePolicyAddedEvent {
+	return &TokenExchangePolicyAddedEvent{
+		TokenExchangePolicyAddedEvent: *policy.NewTokenExchangePolicyAddedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				TokenExchangePolicyAddedEventType),
+			delegationActors,
+			impersonationActors),
+	}
+}
+
+func TokenExchangePolicyAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := policy.TokenExchangePolicyAddedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenExchangePolicyAddedEvent{TokenExchangePolicyAddedEvent: *e.(*policy.TokenExchangePolicyAddedEvent)}, nil
+}
+
+type TokenExchangePolicyChangedEvent struct {
+	policy.TokenExchangePolicyChangedEvent
+}
+
+func NewTokenExchangePolicyChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	changes []policy.TokenExchangePolicyChanges,
+) (*TokenExchangePolicyChangedEvent, error) {
+	changedEvent, err := policy.NewTokenExchangePolicyChangedEvent(
+		eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			TokenExchangePolicyChangedEventType),
+		changes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenExchangePolicyChangedEvent{TokenExchangePolicyChangedEvent: *changedEvent}, nil
+}
+
+func TokenExchangePolicyChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := policy.TokenExchangePolicyChangedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenExchangePolicyChangedEvent{TokenExchangePolicyChangedEvent: *e.(*policy.TokenExchangePolicyChangedEvent)}, nil
+}
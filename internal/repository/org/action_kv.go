@@ -0,0 +1,112 @@
+package org
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	actionKeyValueEventTypePrefix  = orgEventTypePrefix + "action.kv."
+	ActionKeyValueSetEventType     = actionKeyValueEventTypePrefix + "set"
+	ActionKeyValueRemovedEventType = actionKeyValueEventTypePrefix + "removed"
+)
+
+// IsActionKeyValueEventType checks if the event changes the key-value storage of the actions.
+// The storage is changed by the actions themselves, so its events are not passed to webhooks and event actions,
+// which would otherwise be triggered by every execution of an action using the storage.
+func IsActionKeyValueEventType(eventType eventstore.EventType) bool {
+	return eventType == ActionKeyValueSetEventType || eventType == ActionKeyValueRemovedEventType
+}
+
+// ActionKeyValueSetEvent sets the value of a key in the key-value storage
+// of the actions of the organisation
+type ActionKeyValueSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Key   string `json:"key"`
+	Value []byte `json:"value"`
+}
+
+func (e *ActionKeyValueSetEvent) Data() interface{} {
+	return e
+}
+
+func (e *ActionKeyValueSetEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewActionKeyValueSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	key string,
+	value []byte,
+) *ActionKeyValueSetEvent {
+	return &ActionKeyValueSetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ActionKeyValueSetEventType,
+		),
+		Key:   key,
+		Value: value,
+	}
+}
+
+func ActionKeyValueSetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &ActionKeyValueSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "ORG-aeG4i", "unable to unmarshal action key value")
+	}
+
+	return e, nil
+}
+
+type ActionKeyValueRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Key string `json:"key"`
+}
+
+func (e *ActionKeyValueRemovedEvent) Data() interface{} {
+	return e
+}
+
+func (e *ActionKeyValueRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewActionKeyValueRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	key string,
+) *ActionKeyValueRemovedEvent {
+	return &ActionKeyValueRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ActionKeyValueRemovedEventType,
+		),
+		Key: key,
+	}
+}
+
+func ActionKeyValueRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &ActionKeyValueRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "ORG-Uo3ah", "unable to unmarshal action key value removed")
+	}
+
+	return e, nil
+}
@@ -0,0 +1,61 @@
+package org
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	EventActionsSetEventType = orgEventTypePrefix + "event_actions.set"
+)
+
+// EventActionsSetEvent sets the actions, which are executed asynchronously
+// for every event of the event type in the organisation
+type EventActionsSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	EventType string   `json:"eventType"`
+	ActionIDs []string `json:"actionIDs"`
+}
+
+func (e *EventActionsSetEvent) Data() interface{} {
+	return e
+}
+
+func (e *EventActionsSetEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewEventActionsSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	eventType string,
+	actionIDs []string,
+) *EventActionsSetEvent {
+	return &EventActionsSetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			EventActionsSetEventType,
+		),
+		EventType: eventType,
+		ActionIDs: actionIDs,
+	}
+}
+
+func EventActionsSetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &EventActionsSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "ORG-ohX6a", "unable to unmarshal event actions")
+	}
+
+	return e, nil
+}
@@ -10,13 +10,27 @@ import (
 )
 
 const (
-	OAuthIDPAddedEventType    eventstore.EventType = "org.idp.oauth.added"
-	OAuthIDPChangedEventType  eventstore.EventType = "org.idp.oauth.changed"
-	GoogleIDPAddedEventType   eventstore.EventType = "org.idp.google.added"
-	GoogleIDPChangedEventType eventstore.EventType = "org.idp.google.changed"
-	LDAPIDPAddedEventType     eventstore.EventType = "org.idp.ldap.added"
-	LDAPIDPChangedEventType   eventstore.EventType = "org.idp.ldap.changed"
-	IDPRemovedEventType       eventstore.EventType = "org.idp.removed"
+	OAuthIDPAddedEventType              eventstore.EventType = "org.idp.oauth.added"
+	OAuthIDPChangedEventType            eventstore.EventType = "org.idp.oauth.changed"
+	GoogleIDPAddedEventType             eventstore.EventType = "org.idp.google.added"
+	GoogleIDPChangedEventType           eventstore.EventType = "org.idp.google.changed"
+	LDAPIDPAddedEventType               eventstore.EventType = "org.idp.ldap.added"
+	LDAPIDPChangedEventType             eventstore.EventType = "org.idp.ldap.changed"
+	SAMLIDPAddedEventType               eventstore.EventType = "org.idp.saml.added"
+	SAMLIDPChangedEventType             eventstore.EventType = "org.idp.saml.changed"
+	OIDCIDPAddedEventType               eventstore.EventType = "org.idp.oidc.added"
+	OIDCIDPChangedEventType             eventstore.EventType = "org.idp.oidc.changed"
+	AzureADIDPAddedEventType            eventstore.EventType = "org.idp.azure.added"
+	AzureADIDPChangedEventType          eventstore.EventType = "org.idp.azure.changed"
+	GitHubIDPAddedEventType             eventstore.EventType = "org.idp.github.added"
+	GitHubIDPChangedEventType           eventstore.EventType = "org.idp.github.changed"
+	GitHubEnterpriseIDPAddedEventType   eventstore.EventType = "org.idp.github_enterprise.added"
+	GitHubEnterpriseIDPChangedEventType eventstore.EventType = "org.idp.github_enterprise.changed"
+	GitLabIDPAddedEventType             eventstore.EventType = "org.idp.gitlab.added"
+	GitLabIDPChangedEventType           eventstore.EventType = "org.idp.gitlab.changed"
+	GitLabSelfHostedIDPAddedEventType   eventstore.EventType = "org.idp.gitlab_self_hosted.added"
+	GitLabSelfHostedIDPChangedEventType eventstore.EventType = "org.idp.gitlab_self_hosted.changed"
+	IDPRemovedEventType                 eventstore.EventType = "org.idp.removed"
 )
 
 type OAuthIDPAddedEvent struct {
@@ -195,6 +209,7 @@ func NewLDAPIDPAddedEvent(
 	admin string,
 	password *crypto.CryptoValue,
 	attributes idp.LDAPAttributes,
+	groupMappings []idp.LDAPGroupMapping,
 	options idp.Options,
 ) *LDAPIDPAddedEvent {
 
@@ -216,6 +231,7 @@ func NewLDAPIDPAddedEvent(
 			admin,
 			password,
 			attributes,
+			groupMappings,
 			options,
 		),
 	}
@@ -267,6 +283,558 @@ func LDAPIDPChangedEventMapper(event *repository.Event) (eventstore.Event, error
 	return &LDAPIDPChangedEvent{LDAPIDPChangedEvent: *e.(*idp.LDAPIDPChangedEvent)}, nil
 }
 
+type SAMLIDPAddedEvent struct {
+	idp.SAMLIDPAddedEvent
+}
+
+func NewSAMLIDPAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name string,
+	metadata []byte,
+	metadataURL string,
+	key *crypto.CryptoValue,
+	certificate []byte,
+	withSignedRequest bool,
+	options idp.Options,
+) *SAMLIDPAddedEvent {
+
+	return &SAMLIDPAddedEvent{
+		SAMLIDPAddedEvent: *idp.NewSAMLIDPAddedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				SAMLIDPAddedEventType,
+			),
+			id,
+			name,
+			metadata,
+			metadataURL,
+			key,
+			certificate,
+			withSignedRequest,
+			options,
+		),
+	}
+}
+
+func SAMLIDPAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := idp.SAMLIDPAddedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SAMLIDPAddedEvent{SAMLIDPAddedEvent: *e.(*idp.SAMLIDPAddedEvent)}, nil
+}
+
+type SAMLIDPChangedEvent struct {
+	idp.SAMLIDPChangedEvent
+}
+
+func NewSAMLIDPChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	oldName string,
+	changes []idp.SAMLIDPChanges,
+) (*SAMLIDPChangedEvent, error) {
+
+	changedEvent, err := idp.NewSAMLIDPChangedEvent(
+		eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SAMLIDPChangedEventType,
+		),
+		id,
+		oldName,
+		changes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &SAMLIDPChangedEvent{SAMLIDPChangedEvent: *changedEvent}, nil
+}
+
+func SAMLIDPChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := idp.SAMLIDPChangedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SAMLIDPChangedEvent{SAMLIDPChangedEvent: *e.(*idp.SAMLIDPChangedEvent)}, nil
+}
+
+type OIDCIDPAddedEvent struct {
+	idp.OIDCIDPAddedEvent
+}
+
+func NewOIDCIDPAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	issuer,
+	clientID string,
+	clientSecret *crypto.CryptoValue,
+	scopes []string,
+	options idp.Options,
+) *OIDCIDPAddedEvent {
+
+	return &OIDCIDPAddedEvent{
+		OIDCIDPAddedEvent: *idp.NewOIDCIDPAddedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				OIDCIDPAddedEventType,
+			),
+			id,
+			name,
+			issuer,
+			clientID,
+			clientSecret,
+			scopes,
+			options,
+		),
+	}
+}
+
+func OIDCIDPAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := idp.OIDCIDPAddedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OIDCIDPAddedEvent{OIDCIDPAddedEvent: *e.(*idp.OIDCIDPAddedEvent)}, nil
+}
+
+type OIDCIDPChangedEvent struct {
+	idp.OIDCIDPChangedEvent
+}
+
+func NewOIDCIDPChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	changes []idp.OIDCIDPChanges,
+) (*OIDCIDPChangedEvent, error) {
+
+	changedEvent, err := idp.NewOIDCIDPChangedEvent(
+		eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			OIDCIDPChangedEventType,
+		),
+		id,
+		changes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &OIDCIDPChangedEvent{OIDCIDPChangedEvent: *changedEvent}, nil
+}
+
+func OIDCIDPChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := idp.OIDCIDPChangedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OIDCIDPChangedEvent{OIDCIDPChangedEvent: *e.(*idp.OIDCIDPChangedEvent)}, nil
+}
+
+type AzureADIDPAddedEvent struct {
+	idp.AzureADIDPAddedEvent
+}
+
+func NewAzureADIDPAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	clientID string,
+	clientSecret *crypto.CryptoValue,
+	scopes []string,
+	tenant string,
+	isEmailVerified bool,
+	options idp.Options,
+) *AzureADIDPAddedEvent {
+
+	return &AzureADIDPAddedEvent{
+		AzureADIDPAddedEvent: *idp.NewAzureADIDPAddedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				AzureADIDPAddedEventType,
+			),
+			id,
+			name,
+			clientID,
+			clientSecret,
+			scopes,
+			tenant,
+			isEmailVerified,
+			options,
+		),
+	}
+}
+
+func AzureADIDPAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := idp.AzureADIDPAddedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AzureADIDPAddedEvent{AzureADIDPAddedEvent: *e.(*idp.AzureADIDPAddedEvent)}, nil
+}
+
+type AzureADIDPChangedEvent struct {
+	idp.AzureADIDPChangedEvent
+}
+
+func NewAzureADIDPChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	changes []idp.AzureADIDPChanges,
+) (*AzureADIDPChangedEvent, error) {
+
+	changedEvent, err := idp.NewAzureADIDPChangedEvent(
+		eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			AzureADIDPChangedEventType,
+		),
+		id,
+		changes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &AzureADIDPChangedEvent{AzureADIDPChangedEvent: *changedEvent}, nil
+}
+
+func AzureADIDPChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := idp.AzureADIDPChangedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AzureADIDPChangedEvent{AzureADIDPChangedEvent: *e.(*idp.AzureADIDPChangedEvent)}, nil
+}
+
+type GitHubIDPAddedEvent struct {
+	idp.GitHubIDPAddedEvent
+}
+
+func NewGitHubIDPAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	clientID string,
+	clientSecret *crypto.CryptoValue,
+	scopes []string,
+	options idp.Options,
+) *GitHubIDPAddedEvent {
+
+	return &GitHubIDPAddedEvent{
+		GitHubIDPAddedEvent: *idp.NewGitHubIDPAddedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				GitHubIDPAddedEventType,
+			),
+			id,
+			name,
+			clientID,
+			clientSecret,
+			scopes,
+			options,
+		),
+	}
+}
+
+func GitHubIDPAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := idp.GitHubIDPAddedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GitHubIDPAddedEvent{GitHubIDPAddedEvent: *e.(*idp.GitHubIDPAddedEvent)}, nil
+}
+
+type GitHubIDPChangedEvent struct {
+	idp.GitHubIDPChangedEvent
+}
+
+func NewGitHubIDPChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	changes []idp.GitHubIDPChanges,
+) (*GitHubIDPChangedEvent, error) {
+
+	changedEvent, err := idp.NewGitHubIDPChangedEvent(
+		eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			GitHubIDPChangedEventType,
+		),
+		id,
+		changes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &GitHubIDPChangedEvent{GitHubIDPChangedEvent: *changedEvent}, nil
+}
+
+func GitHubIDPChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := idp.GitHubIDPChangedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GitHubIDPChangedEvent{GitHubIDPChangedEvent: *e.(*idp.GitHubIDPChangedEvent)}, nil
+}
+
+type GitHubEnterpriseIDPAddedEvent struct {
+	idp.GitHubEnterpriseIDPAddedEvent
+}
+
+func NewGitHubEnterpriseIDPAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	clientID string,
+	clientSecret *crypto.CryptoValue,
+	authorizationEndpoint,
+	tokenEndpoint,
+	userEndpoint string,
+	scopes []string,
+	options idp.Options,
+) *GitHubEnterpriseIDPAddedEvent {
+
+	return &GitHubEnterpriseIDPAddedEvent{
+		GitHubEnterpriseIDPAddedEvent: *idp.NewGitHubEnterpriseIDPAddedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				GitHubEnterpriseIDPAddedEventType,
+			),
+			id,
+			name,
+			clientID,
+			clientSecret,
+			authorizationEndpoint,
+			tokenEndpoint,
+			userEndpoint,
+			scopes,
+			options,
+		),
+	}
+}
+
+func GitHubEnterpriseIDPAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := idp.GitHubEnterpriseIDPAddedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GitHubEnterpriseIDPAddedEvent{GitHubEnterpriseIDPAddedEvent: *e.(*idp.GitHubEnterpriseIDPAddedEvent)}, nil
+}
+
+type GitHubEnterpriseIDPChangedEvent struct {
+	idp.GitHubEnterpriseIDPChangedEvent
+}
+
+func NewGitHubEnterpriseIDPChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	changes []idp.GitHubEnterpriseIDPChanges,
+) (*GitHubEnterpriseIDPChangedEvent, error) {
+
+	changedEvent, err := idp.NewGitHubEnterpriseIDPChangedEvent(
+		eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			GitHubEnterpriseIDPChangedEventType,
+		),
+		id,
+		changes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &GitHubEnterpriseIDPChangedEvent{GitHubEnterpriseIDPChangedEvent: *changedEvent}, nil
+}
+
+func GitHubEnterpriseIDPChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := idp.GitHubEnterpriseIDPChangedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GitHubEnterpriseIDPChangedEvent{GitHubEnterpriseIDPChangedEvent: *e.(*idp.GitHubEnterpriseIDPChangedEvent)}, nil
+}
+
+type GitLabIDPAddedEvent struct {
+	idp.GitLabIDPAddedEvent
+}
+
+func NewGitLabIDPAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	clientID string,
+	clientSecret *crypto.CryptoValue,
+	scopes []string,
+	options idp.Options,
+) *GitLabIDPAddedEvent {
+
+	return &GitLabIDPAddedEvent{
+		GitLabIDPAddedEvent: *idp.NewGitLabIDPAddedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				GitLabIDPAddedEventType,
+			),
+			id,
+			name,
+			clientID,
+			clientSecret,
+			scopes,
+			options,
+		),
+	}
+}
+
+func GitLabIDPAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := idp.GitLabIDPAddedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GitLabIDPAddedEvent{GitLabIDPAddedEvent: *e.(*idp.GitLabIDPAddedEvent)}, nil
+}
+
+type GitLabIDPChangedEvent struct {
+	idp.GitLabIDPChangedEvent
+}
+
+func NewGitLabIDPChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	changes []idp.GitLabIDPChanges,
+) (*GitLabIDPChangedEvent, error) {
+
+	changedEvent, err := idp.NewGitLabIDPChangedEvent(
+		eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			GitLabIDPChangedEventType,
+		),
+		id,
+		changes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &GitLabIDPChangedEvent{GitLabIDPChangedEvent: *changedEvent}, nil
+}
+
+func GitLabIDPChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := idp.GitLabIDPChangedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GitLabIDPChangedEvent{GitLabIDPChangedEvent: *e.(*idp.GitLabIDPChangedEvent)}, nil
+}
+
+type GitLabSelfHostedIDPAddedEvent struct {
+	idp.GitLabSelfHostedIDPAddedEvent
+}
+
+func NewGitLabSelfHostedIDPAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	issuer,
+	clientID string,
+	clientSecret *crypto.CryptoValue,
+	scopes []string,
+	options idp.Options,
+) *GitLabSelfHostedIDPAddedEvent {
+
+	return &GitLabSelfHostedIDPAddedEvent{
+		GitLabSelfHostedIDPAddedEvent: *idp.NewGitLabSelfHostedIDPAddedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				GitLabSelfHostedIDPAddedEventType,
+			),
+			id,
+			name,
+			issuer,
+			clientID,
+			clientSecret,
+			scopes,
+			options,
+		),
+	}
+}
+
+func GitLabSelfHostedIDPAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := idp.GitLabSelfHostedIDPAddedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GitLabSelfHostedIDPAddedEvent{GitLabSelfHostedIDPAddedEvent: *e.(*idp.GitLabSelfHostedIDPAddedEvent)}, nil
+}
+
+type GitLabSelfHostedIDPChangedEvent struct {
+	idp.GitLabSelfHostedIDPChangedEvent
+}
+
+func NewGitLabSelfHostedIDPChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	changes []idp.GitLabSelfHostedIDPChanges,
+) (*GitLabSelfHostedIDPChangedEvent, error) {
+
+	changedEvent, err := idp.NewGitLabSelfHostedIDPChangedEvent(
+		eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			GitLabSelfHostedIDPChangedEventType,
+		),
+		id,
+		changes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &GitLabSelfHostedIDPChangedEvent{GitLabSelfHostedIDPChangedEvent: *changedEvent}, nil
+}
+
+func GitLabSelfHostedIDPChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := idp.GitLabSelfHostedIDPChangedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GitLabSelfHostedIDPChangedEvent{GitLabSelfHostedIDPChangedEvent: *e.(*idp.GitLabSelfHostedIDPChangedEvent)}, nil
+}
+
 type IDPRemovedEvent struct {
 	idp.RemovedEvent
 }
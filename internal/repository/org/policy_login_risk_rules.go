@@ -0,0 +1,47 @@
+package org
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+var (
+	LoginPolicyRiskRulesSetEventType = orgEventTypePrefix + policy.LoginPolicyRiskRulesSetEventType
+)
+
+type LoginPolicyRiskRulesSetEvent struct {
+	policy.RiskRulesSetEvent
+}
+
+func NewLoginPolicyRiskRulesSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	trustedIPRanges []string,
+	mfaOnNewUserAgent,
+	mfaOnCountryChange bool,
+) *LoginPolicyRiskRulesSetEvent {
+	return &LoginPolicyRiskRulesSetEvent{
+		RiskRulesSetEvent: *policy.NewRiskRulesSetEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				LoginPolicyRiskRulesSetEventType),
+			trustedIPRanges,
+			mfaOnNewUserAgent,
+			mfaOnCountryChange),
+	}
+}
+
+func RiskRulesSetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := policy.RiskRulesSetEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoginPolicyRiskRulesSetEvent{
+		RiskRulesSetEvent: *e.(*policy.RiskRulesSetEvent),
+	}, nil
+}
@@ -0,0 +1,58 @@
+package policy
+
+import (
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	loginPolicyRiskRulesPrefix       = loginPolicyPrefix + "riskrules."
+	LoginPolicyRiskRulesSetEventType = loginPolicyRiskRulesPrefix + "set"
+)
+
+// RiskRulesSetEvent replaces the complete risk rule set of the login policy
+type RiskRulesSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	TrustedIPRanges    []string `json:"trustedIPRanges,omitempty"`
+	MFAOnNewUserAgent  bool     `json:"mfaOnNewUserAgent,omitempty"`
+	MFAOnCountryChange bool     `json:"mfaOnCountryChange,omitempty"`
+}
+
+func NewRiskRulesSetEvent(
+	base *eventstore.BaseEvent,
+	trustedIPRanges []string,
+	mfaOnNewUserAgent,
+	mfaOnCountryChange bool,
+) *RiskRulesSetEvent {
+	return &RiskRulesSetEvent{
+		BaseEvent:          *base,
+		TrustedIPRanges:    trustedIPRanges,
+		MFAOnNewUserAgent:  mfaOnNewUserAgent,
+		MFAOnCountryChange: mfaOnCountryChange,
+	}
+}
+
+func RiskRulesSetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &RiskRulesSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "POLIC-Rr8fs", "unable to unmarshal policy")
+	}
+
+	return e, nil
+}
+
+func (e *RiskRulesSetEvent) Data() interface{} {
+	return e
+}
+
+func (e *RiskRulesSetEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
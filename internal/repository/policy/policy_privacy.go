@@ -2,6 +2,7 @@ package policy
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/eventstore"
 
@@ -18,9 +19,10 @@ const (
 type PrivacyPolicyAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	TOSLink     string `json:"tosLink,omitempty"`
-	PrivacyLink string `json:"privacyLink,omitempty"`
-	HelpLink    string `json:"helpLink,omitempty"`
+	TOSLink             string        `json:"tosLink,omitempty"`
+	PrivacyLink         string        `json:"privacyLink,omitempty"`
+	HelpLink            string        `json:"helpLink,omitempty"`
+	DeletionGracePeriod time.Duration `json:"deletionGracePeriod,omitempty"`
 }
 
 func (e *PrivacyPolicyAddedEvent) Data() interface{} {
@@ -36,12 +38,14 @@ func NewPrivacyPolicyAddedEvent(
 	tosLink,
 	privacyLink,
 	helpLink string,
+	deletionGracePeriod time.Duration,
 ) *PrivacyPolicyAddedEvent {
 	return &PrivacyPolicyAddedEvent{
-		BaseEvent:   *base,
-		TOSLink:     tosLink,
-		PrivacyLink: privacyLink,
-		HelpLink:    helpLink,
+		BaseEvent:           *base,
+		TOSLink:             tosLink,
+		PrivacyLink:         privacyLink,
+		HelpLink:            helpLink,
+		DeletionGracePeriod: deletionGracePeriod,
 	}
 }
 
@@ -60,9 +64,10 @@ func PrivacyPolicyAddedEventMapper(event *repository.Event) (eventstore.Event, e
 type PrivacyPolicyChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	TOSLink     *string `json:"tosLink,omitempty"`
-	PrivacyLink *string `json:"privacyLink,omitempty"`
-	HelpLink    *string `json:"helpLink,omitempty"`
+	TOSLink             *string        `json:"tosLink,omitempty"`
+	PrivacyLink         *string        `json:"privacyLink,omitempty"`
+	HelpLink            *string        `json:"helpLink,omitempty"`
+	DeletionGracePeriod *time.Duration `json:"deletionGracePeriod,omitempty"`
 }
 
 func (e *PrivacyPolicyChangedEvent) Data() interface{} {
@@ -109,6 +114,12 @@ func ChangeHelpLink(helpLink string) func(*PrivacyPolicyChangedEvent) {
 	}
 }
 
+func ChangeDeletionGracePeriod(deletionGracePeriod time.Duration) func(*PrivacyPolicyChangedEvent) {
+	return func(e *PrivacyPolicyChangedEvent) {
+		e.DeletionGracePeriod = &deletionGracePeriod
+	}
+}
+
 func PrivacyPolicyChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
 	e := &PrivacyPolicyChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
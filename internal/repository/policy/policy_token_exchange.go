@@ -0,0 +1,137 @@
+package policy
+
+import (
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	TokenExchangePolicyAddedEventType   = "policy.token.exchange.added"
+	TokenExchangePolicyChangedEventType = "policy.token.exchange.changed"
+	TokenExchangePolicyRemovedEventType = "policy.token.exchange.removed"
+)
+
+type TokenExchangePolicyAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	DelegationActors    []string `json:"delegationActors,omitempty"`
+	ImpersonationActors []string `json:"impersonationActors,omitempty"`
+}
+
+func (e *TokenExchangePolicyAddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *TokenExchangePolicyAddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewTokenExchangePolicyAddedEvent(
+	base *eventstore.BaseEvent,
+	delegationActors,
+	impersonationActors []string,
+) *TokenExchangePolicyAddedEvent {
+	return &TokenExchangePolicyAddedEvent{
+		BaseEvent:           *base,
+		DelegationActors:    delegationActors,
+		ImpersonationActors: impersonationActors,
+	}
+}
+
+func TokenExchangePolicyAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &TokenExchangePolicyAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "POLIC-Ohz3a", "unable to unmarshal policy")
+	}
+
+	return e, nil
+}
+
+type TokenExchangePolicyChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	DelegationActors    *[]string `json:"delegationActors,omitempty"`
+	ImpersonationActors *[]string `json:"impersonationActors,omitempty"`
+}
+
+func (e *TokenExchangePolicyChangedEvent) Data() interface{} {
+	return e
+}
+
+func (e *TokenExchangePolicyChangedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewTokenExchangePolicyChangedEvent(
+	base *eventstore.BaseEvent,
+	changes []TokenExchangePolicyChanges,
+) (*TokenExchangePolicyChangedEvent, error) {
+	if len(changes) == 0 {
+		return nil, errors.ThrowPreconditionFailed(nil, "POLICY-ahR8i", "Errors.NoChangesFound")
+	}
+	changeEvent := &TokenExchangePolicyChangedEvent{
+		BaseEvent: *base,
+	}
+	for _, change := range changes {
+		change(changeEvent)
+	}
+	return changeEvent, nil
+}
+
+type TokenExchangePolicyChanges func(*TokenExchangePolicyChangedEvent)
+
+func ChangeDelegationActors(delegationActors []string) func(*TokenExchangePolicyChangedEvent) {
+	return func(e *TokenExchangePolicyChangedEvent) {
+		e.DelegationActors = &delegationActors
+	}
+}
+
+func ChangeImpersonationActors(impersonationActors []string) func(*TokenExchangePolicyChangedEvent) {
+	return func(e *TokenExchangePolicyChangedEvent) {
+		e.ImpersonationActors = &impersonationActors
+	}
+}
+
+func TokenExchangePolicyChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &TokenExchangePolicyChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "POLIC-Kah0u", "unable to unmarshal policy")
+	}
+
+	return e, nil
+}
+
+type TokenExchangePolicyRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *TokenExchangePolicyRemovedEvent) Data() interface{} {
+	return nil
+}
+
+func (e *TokenExchangePolicyRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewTokenExchangePolicyRemovedEvent(base *eventstore.BaseEvent) *TokenExchangePolicyRemovedEvent {
+	return &TokenExchangePolicyRemovedEvent{
+		BaseEvent: *base,
+	}
+}
+
+func TokenExchangePolicyRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	return &TokenExchangePolicyRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
@@ -0,0 +1,16 @@
+package session
+
+import "github.com/zitadel/zitadel/internal/eventstore"
+
+func RegisterEventMappers(es *eventstore.Eventstore) {
+	es.RegisterFilterEventMapper(AggregateType, AddedType, AddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, UserCheckedType, UserCheckedEventMapper).
+		RegisterFilterEventMapper(AggregateType, PasswordCheckedType, PasswordCheckedEventMapper).
+		RegisterFilterEventMapper(AggregateType, OTPCheckedType, OTPCheckedEventMapper).
+		RegisterFilterEventMapper(AggregateType, WebAuthNCheckedType, WebAuthNCheckedEventMapper).
+		RegisterFilterEventMapper(AggregateType, IDPStartedType, IDPStartedEventMapper).
+		RegisterFilterEventMapper(AggregateType, IDPCheckedType, IDPCheckedEventMapper).
+		RegisterFilterEventMapper(AggregateType, TokenSetType, TokenSetEventMapper).
+		RegisterFilterEventMapper(AggregateType, AuthRequestLinkedType, AuthRequestLinkedEventMapper).
+		RegisterFilterEventMapper(AggregateType, TerminateType, TerminateEventMapper)
+}
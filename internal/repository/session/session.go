@@ -0,0 +1,425 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	sessionEventPrefix    = "session."
+	AddedType             = sessionEventPrefix + "added"
+	UserCheckedType       = sessionEventPrefix + "user.checked"
+	PasswordCheckedType   = sessionEventPrefix + "password.checked"
+	OTPCheckedType        = sessionEventPrefix + "otp.checked"
+	WebAuthNCheckedType   = sessionEventPrefix + "webauthn.checked"
+	IDPStartedType        = sessionEventPrefix + "idp.started"
+	IDPCheckedType        = sessionEventPrefix + "idp.checked"
+	TokenSetType          = sessionEventPrefix + "token.set"
+	AuthRequestLinkedType = sessionEventPrefix + "auth.request.linked"
+	TerminateType         = sessionEventPrefix + "terminated"
+
+	UniqueAuthRequestLinkedType = "session_auth_request_linked"
+	UniqueAuthRequestType       = "session_auth_request"
+)
+
+type AddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *AddedEvent) Data() interface{} {
+	return nil
+}
+
+func (e *AddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewAddedEvent(ctx context.Context, aggregate *eventstore.Aggregate) *AddedEvent {
+	return &AddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			AddedType,
+		),
+	}
+}
+
+func AddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	return &AddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
+
+type UserCheckedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	UserID            string `json:"userID"`
+	UserResourceOwner string `json:"userResourceOwner"`
+}
+
+func (e *UserCheckedEvent) Data() interface{} {
+	return e
+}
+
+func (e *UserCheckedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewUserCheckedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	userID,
+	userResourceOwner string,
+) *UserCheckedEvent {
+	return &UserCheckedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserCheckedType,
+		),
+		UserID:            userID,
+		UserResourceOwner: userResourceOwner,
+	}
+}
+
+func UserCheckedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	added := &UserCheckedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, added)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "SESSION-DSGn5", "unable to unmarshal user checked")
+	}
+
+	return added, nil
+}
+
+type PasswordCheckedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *PasswordCheckedEvent) Data() interface{} {
+	return nil
+}
+
+func (e *PasswordCheckedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewPasswordCheckedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *PasswordCheckedEvent {
+	return &PasswordCheckedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			PasswordCheckedType,
+		),
+	}
+}
+
+func PasswordCheckedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	return &PasswordCheckedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
+
+type OTPCheckedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *OTPCheckedEvent) Data() interface{} {
+	return nil
+}
+
+func (e *OTPCheckedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewOTPCheckedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *OTPCheckedEvent {
+	return &OTPCheckedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			OTPCheckedType,
+		),
+	}
+}
+
+func OTPCheckedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	return &OTPCheckedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
+
+type WebAuthNCheckedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	UserVerified bool `json:"userVerified,omitempty"`
+}
+
+func (e *WebAuthNCheckedEvent) Data() interface{} {
+	return e
+}
+
+func (e *WebAuthNCheckedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewWebAuthNCheckedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	userVerified bool,
+) *WebAuthNCheckedEvent {
+	return &WebAuthNCheckedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			WebAuthNCheckedType,
+		),
+		UserVerified: userVerified,
+	}
+}
+
+func WebAuthNCheckedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	added := &WebAuthNCheckedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, added)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "SESSION-Ls9fe", "unable to unmarshal webauthn checked")
+	}
+
+	return added, nil
+}
+
+type IDPStartedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	IDPID      string `json:"idpID"`
+	SuccessURL string `json:"successURL"`
+	FailureURL string `json:"failureURL"`
+}
+
+func (e *IDPStartedEvent) Data() interface{} {
+	return e
+}
+
+func (e *IDPStartedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewIDPStartedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	idpID,
+	successURL,
+	failureURL string,
+) *IDPStartedEvent {
+	return &IDPStartedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			IDPStartedType,
+		),
+		IDPID:      idpID,
+		SuccessURL: successURL,
+		FailureURL: failureURL,
+	}
+}
+
+func IDPStartedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	added := &IDPStartedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, added)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "SESSION-Wo2mb", "unable to unmarshal idp started")
+	}
+
+	return added, nil
+}
+
+type IDPCheckedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	IDPID          string `json:"idpID"`
+	ExternalUserID string `json:"externalUserID"`
+}
+
+func (e *IDPCheckedEvent) Data() interface{} {
+	return e
+}
+
+func (e *IDPCheckedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewIDPCheckedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	idpID,
+	externalUserID string,
+) *IDPCheckedEvent {
+	return &IDPCheckedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			IDPCheckedType,
+		),
+		IDPID:          idpID,
+		ExternalUserID: externalUserID,
+	}
+}
+
+func IDPCheckedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	added := &IDPCheckedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, added)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "SESSION-Po4wn", "unable to unmarshal idp checked")
+	}
+
+	return added, nil
+}
+
+type TokenSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	TokenID string `json:"tokenID"`
+}
+
+func (e *TokenSetEvent) Data() interface{} {
+	return e
+}
+
+func (e *TokenSetEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewTokenSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	tokenID string,
+) *TokenSetEvent {
+	return &TokenSetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			TokenSetType,
+		),
+		TokenID: tokenID,
+	}
+}
+
+func TokenSetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	added := &TokenSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, added)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "SESSION-Sf3va", "unable to unmarshal token set")
+	}
+
+	return added, nil
+}
+
+type AuthRequestLinkedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	AuthRequestID string `json:"authRequestID"`
+}
+
+func (e *AuthRequestLinkedEvent) Data() interface{} {
+	return e
+}
+
+// UniqueConstraints ensures a session is only linked once to an auth request
+// and an auth request only to one session, which will also fail if they are linked concurrently
+func (e *AuthRequestLinkedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return []*eventstore.EventUniqueConstraint{
+		eventstore.NewAddEventUniqueConstraint(
+			UniqueAuthRequestLinkedType,
+			e.Aggregate().ID,
+			"Errors.Session.AuthRequest.AlreadyLinked",
+		),
+		eventstore.NewAddEventUniqueConstraint(
+			UniqueAuthRequestType,
+			e.AuthRequestID,
+			"Errors.Session.AuthRequest.AlreadyHandled",
+		),
+	}
+}
+
+func NewAuthRequestLinkedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	authRequestID string,
+) *AuthRequestLinkedEvent {
+	return &AuthRequestLinkedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			AuthRequestLinkedType,
+		),
+		AuthRequestID: authRequestID,
+	}
+}
+
+func AuthRequestLinkedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	added := &AuthRequestLinkedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, added)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "SESSION-Jd8sk", "unable to unmarshal auth request linked")
+	}
+
+	return added, nil
+}
+
+type TerminateEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *TerminateEvent) Data() interface{} {
+	return nil
+}
+
+func (e *TerminateEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewTerminateEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *TerminateEvent {
+	return &TerminateEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			TerminateType,
+		),
+	}
+}
+
+func TerminateEventMapper(event *repository.Event) (eventstore.Event, error) {
+	return &TerminateEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
@@ -1,11 +1,17 @@
 package user
 
-import "net"
+import (
+	"net"
+	"time"
+)
 
 type AuthRequestInfo struct {
 	ID                  string `json:"id,omitempty"`
 	UserAgentID         string `json:"userAgentID,omitempty"`
 	SelectedIDPConfigID string `json:"selectedIDPConfigID,omitempty"`
+	// CheckedAt is the time the factor was originally checked,
+	// if the check was done on a session before it was linked to the auth request
+	CheckedAt *time.Time `json:"checkedAt,omitempty"`
 	*BrowserInfo
 }
 
@@ -0,0 +1,92 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	userDeletionEventTypePrefix = userEventTypePrefix + "deletion."
+	UserDeletionRequestedType   = userDeletionEventTypePrefix + "requested"
+	UserDeletionCanceledType    = userDeletionEventTypePrefix + "canceled"
+)
+
+// UserDeletionRequestedEvent is pushed (together with the deactivation) if users request their own deletion.
+// The user is removed as soon as the grace period is over, unless the deletion is canceled before.
+type UserDeletionRequestedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	GracePeriod time.Duration `json:"gracePeriod,omitempty"`
+}
+
+func (e *UserDeletionRequestedEvent) Data() interface{} {
+	return e
+}
+
+func (e *UserDeletionRequestedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+// ScheduledDate is the time from which on the user will be removed
+func (e *UserDeletionRequestedEvent) ScheduledDate() time.Time {
+	return e.CreationDate().Add(e.GracePeriod)
+}
+
+func NewUserDeletionRequestedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	gracePeriod time.Duration,
+) *UserDeletionRequestedEvent {
+	return &UserDeletionRequestedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserDeletionRequestedType,
+		),
+		GracePeriod: gracePeriod,
+	}
+}
+
+func UserDeletionRequestedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	deletionRequested := &UserDeletionRequestedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, deletionRequested)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Dl3fs", "unable to unmarshal user deletion requested")
+	}
+	return deletionRequested, nil
+}
+
+type UserDeletionCanceledEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *UserDeletionCanceledEvent) Data() interface{} {
+	return nil
+}
+
+func (e *UserDeletionCanceledEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewUserDeletionCanceledEvent(ctx context.Context, aggregate *eventstore.Aggregate) *UserDeletionCanceledEvent {
+	return &UserDeletionCanceledEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserDeletionCanceledType,
+		),
+	}
+}
+
+func UserDeletionCanceledEventMapper(event *repository.Event) (eventstore.Event, error) {
+	return &UserDeletionCanceledEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
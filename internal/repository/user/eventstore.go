@@ -41,6 +41,8 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, UserDeactivatedType, UserDeactivatedEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserReactivatedType, UserReactivatedEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserRemovedType, UserRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, UserDeletionRequestedType, UserDeletionRequestedEventMapper).
+		RegisterFilterEventMapper(AggregateType, UserDeletionCanceledType, UserDeletionCanceledEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserTokenAddedType, UserTokenAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserTokenRemovedType, UserTokenRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserDomainClaimedType, DomainClaimedEventMapper).
@@ -62,9 +64,11 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, HumanPasswordChangeSentType, HumanPasswordChangeSentEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanPasswordCheckSucceededType, HumanPasswordCheckSucceededEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanPasswordCheckFailedType, HumanPasswordCheckFailedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanPasswordHashUpdatedType, HumanPasswordHashUpdatedEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserIDPLinkAddedType, UserIDPLinkAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserIDPLinkRemovedType, UserIDPLinkRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserIDPLinkCascadeRemovedType, UserIDPLinkCascadeRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, UserIDPLDAPRolesAppliedType, UserIDPLDAPRolesAppliedEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserIDPLoginCheckSucceededType, UserIDPCheckSucceededEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanEmailChangedType, HumanEmailChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanEmailVerifiedType, HumanEmailVerifiedEventMapper).
@@ -87,6 +91,22 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, HumanMFAOTPRemovedType, HumanOTPRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanMFAOTPCheckSucceededType, HumanOTPCheckSucceededEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanMFAOTPCheckFailedType, HumanOTPCheckFailedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanOTPSMSAddedType, HumanOTPSMSAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanOTPSMSRemovedType, HumanOTPSMSRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanOTPSMSCodeAddedType, HumanOTPSMSCodeAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanOTPSMSCodeSentType, HumanOTPSMSCodeSentEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanOTPSMSCheckSucceededType, HumanOTPSMSCheckSucceededEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanOTPSMSCheckFailedType, HumanOTPSMSCheckFailedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanOTPEmailAddedType, HumanOTPEmailAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanOTPEmailRemovedType, HumanOTPEmailRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanOTPEmailCodeAddedType, HumanOTPEmailCodeAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanOTPEmailCodeSentType, HumanOTPEmailCodeSentEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanOTPEmailCheckSucceededType, HumanOTPEmailCheckSucceededEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanOTPEmailCheckFailedType, HumanOTPEmailCheckFailedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanRecoveryCodesAddedType, HumanRecoveryCodesAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanRecoveryCodeCheckSucceededType, HumanRecoveryCodeCheckSucceededEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanRecoveryCodeCheckFailedType, HumanRecoveryCodeCheckFailedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanLoginRiskEvaluatedType, HumanLoginRiskEvaluatedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanU2FTokenAddedType, HumanU2FAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanU2FTokenVerifiedType, HumanU2FVerifiedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanU2FTokenSignCountChangedType, HumanU2FSignCountChangedEventMapper).
@@ -119,4 +139,29 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, MachineSecretRemovedType, MachineSecretRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MachineSecretCheckSucceededType, MachineSecretCheckSucceededEventMapper).
 		RegisterFilterEventMapper(AggregateType, MachineSecretCheckFailedType, MachineSecretCheckFailedEventMapper)
+
+	registerPersonalDataFields(es)
+}
+
+// registerPersonalDataFields registers the fields of the events containing personal data,
+// which can be pseudonymized by deleting the key of the user (see eventstore.ShredPersonalData)
+func registerPersonalDataFields(es *eventstore.Eventstore) {
+	profileFields := []string{"firstName", "lastName", "nickName", "displayName"}
+	addressFields := []string{"country", "locality", "postalCode", "region", "streetAddress"}
+	humanFields := append(append([]string{"userName", "email", "phone"}, profileFields...), addressFields...)
+
+	es.RegisterPersonalDataFields(UserV1AddedType, humanFields...).
+		RegisterPersonalDataFields(UserV1RegisteredType, humanFields...).
+		RegisterPersonalDataFields(HumanAddedType, humanFields...).
+		RegisterPersonalDataFields(HumanRegisteredType, humanFields...).
+		RegisterPersonalDataFields(UserV1ProfileChangedType, profileFields...).
+		RegisterPersonalDataFields(HumanProfileChangedType, profileFields...).
+		RegisterPersonalDataFields(UserV1EmailChangedType, "email").
+		RegisterPersonalDataFields(HumanEmailChangedType, "email").
+		RegisterPersonalDataFields(UserV1PhoneChangedType, "phone").
+		RegisterPersonalDataFields(HumanPhoneChangedType, "phone").
+		RegisterPersonalDataFields(UserV1AddressChangedType, addressFields...).
+		RegisterPersonalDataFields(HumanAddressChangedType, addressFields...).
+		RegisterPersonalDataFields(UserUserNameChangedType, "userName").
+		RegisterPersonalDataFields(UserIDPLinkAddedType, "displayName")
 }
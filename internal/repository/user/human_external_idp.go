@@ -18,6 +18,7 @@ const (
 	UserIDPLinkAddedType          = UserIDPLinkEventPrefix + "added"
 	UserIDPLinkRemovedType        = UserIDPLinkEventPrefix + "removed"
 	UserIDPLinkCascadeRemovedType = UserIDPLinkEventPrefix + "cascade.removed"
+	UserIDPLDAPRolesAppliedType   = UserIDPLinkEventPrefix + "ldap.roles.applied"
 
 	UserIDPLoginCheckSucceededType = idpLoginEventPrefix + "check.succeeded"
 )
@@ -212,3 +213,54 @@ func UserIDPCheckSucceededEventMapper(event *repository.Event) (eventstore.Event
 
 	return e, nil
 }
+
+// UserIDPLDAPRolesAppliedEvent holds the roles granted by the group mappings of an LDAP identity provider,
+// only these roles are revoked if the user is no longer member of the mapped groups
+type UserIDPLDAPRolesAppliedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	IDPConfigID string `json:"idpConfigId"`
+	// ProjectRoles maps the project id to the granted role keys
+	ProjectRoles   map[string][]string `json:"projectRoles,omitempty"`
+	OrgMemberRoles []string            `json:"orgMemberRoles,omitempty"`
+}
+
+func (e *UserIDPLDAPRolesAppliedEvent) Data() interface{} {
+	return e
+}
+
+func (e *UserIDPLDAPRolesAppliedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewUserIDPLDAPRolesAppliedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	idpConfigID string,
+	projectRoles map[string][]string,
+	orgMemberRoles []string,
+) *UserIDPLDAPRolesAppliedEvent {
+	return &UserIDPLDAPRolesAppliedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserIDPLDAPRolesAppliedType,
+		),
+		IDPConfigID:    idpConfigID,
+		ProjectRoles:   projectRoles,
+		OrgMemberRoles: orgMemberRoles,
+	}
+}
+
+func UserIDPLDAPRolesAppliedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &UserIDPLDAPRolesAppliedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Ohz1a", "unable to unmarshal user external idp ldap roles applied")
+	}
+
+	return e, nil
+}
@@ -0,0 +1,67 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	HumanLoginRiskEvaluatedType = humanEventPrefix + "login.risk.evaluated"
+)
+
+// HumanLoginRiskEvaluatedEvent records the decision of the risk rules of the login policy for an auth request,
+// a second factor is required if any reason is set
+type HumanLoginRiskEvaluatedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Country string                   `json:"country,omitempty"`
+	Reasons []domain.LoginRiskReason `json:"reasons,omitempty"`
+	*AuthRequestInfo
+}
+
+func (e *HumanLoginRiskEvaluatedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanLoginRiskEvaluatedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func (e *HumanLoginRiskEvaluatedEvent) MFARequired() bool {
+	return len(e.Reasons) > 0
+}
+
+func NewHumanLoginRiskEvaluatedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	country string,
+	reasons []domain.LoginRiskReason,
+	info *AuthRequestInfo,
+) *HumanLoginRiskEvaluatedEvent {
+	return &HumanLoginRiskEvaluatedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanLoginRiskEvaluatedType,
+		),
+		Country:         country,
+		Reasons:         reasons,
+		AuthRequestInfo: info,
+	}
+}
+
+func HumanLoginRiskEvaluatedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	riskEvaluated := &HumanLoginRiskEvaluatedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, riskEvaluated)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Rk2sd", "unable to unmarshal human login risk evaluated")
+	}
+	return riskEvaluated, nil
+}
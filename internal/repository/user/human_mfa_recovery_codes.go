@@ -0,0 +1,170 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	recoveryCodesEventPrefix            = mfaEventPrefix + "recoverycodes."
+	HumanRecoveryCodesAddedType         = recoveryCodesEventPrefix + "added"
+	HumanRecoveryCodeCheckSucceededType = recoveryCodesEventPrefix + "check.succeeded"
+	HumanRecoveryCodeCheckFailedType    = recoveryCodesEventPrefix + "check.failed"
+
+	UniqueRecoveryCodeType = "recovery_code"
+)
+
+// NewAddRecoveryCodeUniqueConstraint fails if the recovery code at the index is consumed concurrently
+func NewAddRecoveryCodeUniqueConstraint(userID string, codeIndex int) *eventstore.EventUniqueConstraint {
+	return eventstore.NewAddEventUniqueConstraint(
+		UniqueRecoveryCodeType,
+		userID+":"+strconv.Itoa(codeIndex),
+		"Errors.User.MFA.RecoveryCodes.InvalidCode")
+}
+
+func NewRemoveRecoveryCodeUniqueConstraint(userID string, codeIndex int) *eventstore.EventUniqueConstraint {
+	return eventstore.NewRemoveEventUniqueConstraint(
+		UniqueRecoveryCodeType,
+		userID+":"+strconv.Itoa(codeIndex))
+}
+
+// HumanRecoveryCodesAddedEvent replaces all previous recovery codes of the user,
+// the codes are removed together with the OTP they belong to.
+// The unique constraints of the consumed codes of the previous codes are released.
+type HumanRecoveryCodesAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Codes []*crypto.CryptoValue `json:"codes,omitempty"`
+
+	consumedCodeIndexes []int
+}
+
+func (e *HumanRecoveryCodesAddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanRecoveryCodesAddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	constraints := make([]*eventstore.EventUniqueConstraint, len(e.consumedCodeIndexes))
+	for i, codeIndex := range e.consumedCodeIndexes {
+		constraints[i] = NewRemoveRecoveryCodeUniqueConstraint(e.Aggregate().ID, codeIndex)
+	}
+	return constraints
+}
+
+func NewHumanRecoveryCodesAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	codes []*crypto.CryptoValue,
+	consumedCodeIndexes []int,
+) *HumanRecoveryCodesAddedEvent {
+	return &HumanRecoveryCodesAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanRecoveryCodesAddedType,
+		),
+		Codes:               codes,
+		consumedCodeIndexes: consumedCodeIndexes,
+	}
+}
+
+func HumanRecoveryCodesAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	codesAdded := &HumanRecoveryCodesAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, codesAdded)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Rc3sa", "unable to unmarshal human recovery codes added")
+	}
+	return codesAdded, nil
+}
+
+// HumanRecoveryCodeCheckSucceededEvent consumes the recovery code at the index of the codes added last,
+// its unique constraint prevents the code from being consumed concurrently
+type HumanRecoveryCodeCheckSucceededEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	CodeIndex int `json:"codeIndex"`
+	*AuthRequestInfo
+}
+
+func (e *HumanRecoveryCodeCheckSucceededEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanRecoveryCodeCheckSucceededEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return []*eventstore.EventUniqueConstraint{NewAddRecoveryCodeUniqueConstraint(e.Aggregate().ID, e.CodeIndex)}
+}
+
+func NewHumanRecoveryCodeCheckSucceededEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	codeIndex int,
+	info *AuthRequestInfo,
+) *HumanRecoveryCodeCheckSucceededEvent {
+	return &HumanRecoveryCodeCheckSucceededEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanRecoveryCodeCheckSucceededType,
+		),
+		CodeIndex:       codeIndex,
+		AuthRequestInfo: info,
+	}
+}
+
+func HumanRecoveryCodeCheckSucceededEventMapper(event *repository.Event) (eventstore.Event, error) {
+	checkEvent := &HumanRecoveryCodeCheckSucceededEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, checkEvent)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Rc4gs", "unable to unmarshal human recovery code check succeeded")
+	}
+	return checkEvent, nil
+}
+
+type HumanRecoveryCodeCheckFailedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	*AuthRequestInfo
+}
+
+func (e *HumanRecoveryCodeCheckFailedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanRecoveryCodeCheckFailedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanRecoveryCodeCheckFailedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	info *AuthRequestInfo,
+) *HumanRecoveryCodeCheckFailedEvent {
+	return &HumanRecoveryCodeCheckFailedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanRecoveryCodeCheckFailedType,
+		),
+		AuthRequestInfo: info,
+	}
+}
+
+func HumanRecoveryCodeCheckFailedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	checkEvent := &HumanRecoveryCodeCheckFailedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, checkEvent)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Rc5fe", "unable to unmarshal human recovery code check failed")
+	}
+	return checkEvent, nil
+}
@@ -0,0 +1,415 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	UniqueWebhookNameType            = "webhook_names"
+	eventTypePrefix                  = eventstore.EventType("webhook.")
+	AddedEventType                   = eventTypePrefix + "added"
+	ChangedEventType                 = eventTypePrefix + "changed"
+	SigningKeyChangedEventType       = eventTypePrefix + "signing.key.changed"
+	DeactivatedEventType             = eventTypePrefix + "deactivated"
+	ReactivatedEventType             = eventTypePrefix + "reactivated"
+	RemovedEventType                 = eventTypePrefix + "removed"
+	DeliveryReplayRequestedEventType = eventTypePrefix + "delivery.replay.requested"
+	DeliveryFailedEventType          = eventTypePrefix + "delivery.failed"
+)
+
+func NewAddWebhookNameUniqueConstraint(webhookName, resourceOwner string) *eventstore.EventUniqueConstraint {
+	return eventstore.NewAddEventUniqueConstraint(
+		UniqueWebhookNameType,
+		webhookName+":"+resourceOwner,
+		"Errors.Webhook.AlreadyExists")
+}
+
+func NewRemoveWebhookNameUniqueConstraint(webhookName, resourceOwner string) *eventstore.EventUniqueConstraint {
+	return eventstore.NewRemoveEventUniqueConstraint(
+		UniqueWebhookNameType,
+		webhookName+":"+resourceOwner)
+}
+
+type AddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Name           string              `json:"name"`
+	URL            string              `json:"url"`
+	EventTypes     []string            `json:"eventTypes,omitempty"`
+	AggregateTypes []string            `json:"aggregateTypes,omitempty"`
+	SigningKey     *crypto.CryptoValue `json:"signingKey"`
+}
+
+func (e *AddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *AddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return []*eventstore.EventUniqueConstraint{NewAddWebhookNameUniqueConstraint(e.Name, e.Aggregate().ResourceOwner)}
+}
+
+func NewAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	name,
+	url string,
+	eventTypes,
+	aggregateTypes []string,
+	signingKey *crypto.CryptoValue,
+) *AddedEvent {
+	return &AddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			AddedEventType,
+		),
+		Name:           name,
+		URL:            url,
+		EventTypes:     eventTypes,
+		AggregateTypes: aggregateTypes,
+		SigningKey:     signingKey,
+	}
+}
+
+func AddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &AddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "WEBHOOK-Ahph7", "unable to unmarshal webhook added")
+	}
+
+	return e, nil
+}
+
+type ChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Name           *string   `json:"name,omitempty"`
+	URL            *string   `json:"url,omitempty"`
+	EventTypes     *[]string `json:"eventTypes,omitempty"`
+	AggregateTypes *[]string `json:"aggregateTypes,omitempty"`
+	oldName        string
+}
+
+func (e *ChangedEvent) Data() interface{} {
+	return e
+}
+
+func (e *ChangedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	if e.oldName == "" {
+		return nil
+	}
+	return []*eventstore.EventUniqueConstraint{
+		NewRemoveWebhookNameUniqueConstraint(e.oldName, e.Aggregate().ResourceOwner),
+		NewAddWebhookNameUniqueConstraint(*e.Name, e.Aggregate().ResourceOwner),
+	}
+}
+
+func NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	changes []WebhookChanges,
+) (*ChangedEvent, error) {
+	if len(changes) == 0 {
+		return nil, errors.ThrowPreconditionFailed(nil, "WEBHOOK-Iequ4", "Errors.NoChangesFound")
+	}
+	changeEvent := &ChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ChangedEventType,
+		),
+	}
+	for _, change := range changes {
+		change(changeEvent)
+	}
+	return changeEvent, nil
+}
+
+type WebhookChanges func(event *ChangedEvent)
+
+func ChangeName(name, oldName string) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.Name = &name
+		e.oldName = oldName
+	}
+}
+
+func ChangeURL(url string) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.URL = &url
+	}
+}
+
+func ChangeEventTypes(eventTypes []string) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.EventTypes = &eventTypes
+	}
+}
+
+func ChangeAggregateTypes(aggregateTypes []string) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.AggregateTypes = &aggregateTypes
+	}
+}
+
+func ChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &ChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "WEBHOOK-ooCh4", "unable to unmarshal webhook changed")
+	}
+
+	return e, nil
+}
+
+type SigningKeyChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	SigningKey *crypto.CryptoValue `json:"signingKey"`
+}
+
+func (e *SigningKeyChangedEvent) Data() interface{} {
+	return e
+}
+
+func (e *SigningKeyChangedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewSigningKeyChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	signingKey *crypto.CryptoValue,
+) *SigningKeyChangedEvent {
+	return &SigningKeyChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SigningKeyChangedEventType,
+		),
+		SigningKey: signingKey,
+	}
+}
+
+func SigningKeyChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &SigningKeyChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "WEBHOOK-Eiph1", "unable to unmarshal webhook signing key changed")
+	}
+
+	return e, nil
+}
+
+type DeactivatedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *DeactivatedEvent) Data() interface{} {
+	return nil
+}
+
+func (e *DeactivatedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewDeactivatedEvent(ctx context.Context, aggregate *eventstore.Aggregate) *DeactivatedEvent {
+	return &DeactivatedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			DeactivatedEventType,
+		),
+	}
+}
+
+func DeactivatedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	return &DeactivatedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
+
+type ReactivatedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *ReactivatedEvent) Data() interface{} {
+	return nil
+}
+
+func (e *ReactivatedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewReactivatedEvent(ctx context.Context, aggregate *eventstore.Aggregate) *ReactivatedEvent {
+	return &ReactivatedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ReactivatedEventType,
+		),
+	}
+}
+
+func ReactivatedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	return &ReactivatedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
+
+type RemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	name string
+}
+
+func (e *RemovedEvent) Data() interface{} {
+	return e
+}
+
+func (e *RemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return []*eventstore.EventUniqueConstraint{NewRemoveWebhookNameUniqueConstraint(e.name, e.Aggregate().ResourceOwner)}
+}
+
+func NewRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	name string,
+) *RemovedEvent {
+	return &RemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			RemovedEventType,
+		),
+		name: name,
+	}
+}
+
+func RemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	return &RemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
+
+// DeliveryReplayRequestedEvent requests another delivery of an already delivered or failed event
+// to the webhook. The event is identified by its aggregate type and sequence.
+type DeliveryReplayRequestedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	EventSequence      uint64                   `json:"eventSequence"`
+	EventAggregateType eventstore.AggregateType `json:"eventAggregateType"`
+}
+
+func (e *DeliveryReplayRequestedEvent) Data() interface{} {
+	return e
+}
+
+func (e *DeliveryReplayRequestedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewDeliveryReplayRequestedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	eventSequence uint64,
+	eventAggregateType eventstore.AggregateType,
+) *DeliveryReplayRequestedEvent {
+	return &DeliveryReplayRequestedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			DeliveryReplayRequestedEventType,
+		),
+		EventSequence:      eventSequence,
+		EventAggregateType: eventAggregateType,
+	}
+}
+
+func DeliveryReplayRequestedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &DeliveryReplayRequestedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "WEBHOOK-gu3Ee", "unable to unmarshal webhook delivery replay requested")
+	}
+
+	return e, nil
+}
+
+// DeliveryFailedEvent dead-letters the delivery of the event (identified by its aggregate type and sequence),
+// after all attempts to deliver it failed. It can be delivered again by requesting a replay.
+type DeliveryFailedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	EventSequence      uint64                   `json:"eventSequence"`
+	EventAggregateType eventstore.AggregateType `json:"eventAggregateType"`
+	EventType          eventstore.EventType     `json:"eventType"`
+	Attempts           uint64                   `json:"attempts"`
+	StatusCode         int                      `json:"statusCode,omitempty"`
+	Error              string                   `json:"error,omitempty"`
+}
+
+func (e *DeliveryFailedEvent) Data() interface{} {
+	return e
+}
+
+func (e *DeliveryFailedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewDeliveryFailedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	eventSequence uint64,
+	eventAggregateType eventstore.AggregateType,
+	eventType eventstore.EventType,
+	attempts uint64,
+	statusCode int,
+	err string,
+) *DeliveryFailedEvent {
+	return &DeliveryFailedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			DeliveryFailedEventType,
+		),
+		EventSequence:      eventSequence,
+		EventAggregateType: eventAggregateType,
+		EventType:          eventType,
+		Attempts:           attempts,
+		StatusCode:         statusCode,
+		Error:              err,
+	}
+}
+
+func DeliveryFailedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &DeliveryFailedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "WEBHOOK-Aip3o", "unable to unmarshal webhook delivery failed")
+	}
+
+	return e, nil
+}
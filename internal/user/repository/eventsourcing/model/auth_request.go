@@ -3,6 +3,7 @@ package model
 import (
 	"encoding/json"
 	"net"
+	"time"
 
 	"github.com/zitadel/logging"
 
@@ -14,6 +15,9 @@ type AuthRequest struct {
 	ID                  string `json:"id,omitempty"`
 	UserAgentID         string `json:"userAgentID,omitempty"`
 	SelectedIDPConfigID string `json:"selectedIDPConfigID,omitempty"`
+	// CheckedAt is the time the factor was originally checked,
+	// if the check was done on a session before it was linked to the auth request
+	CheckedAt time.Time `json:"checkedAt,omitempty"`
 	*BrowserInfo
 }
 
@@ -30,3 +34,12 @@ func (a *AuthRequest) SetData(event *es_models.Event) error {
 	}
 	return nil
 }
+
+// VerificationTime returns the time the factor was originally checked,
+// which cannot be after the event itself, or else the creation date of the event
+func (a *AuthRequest) VerificationTime(event *es_models.Event) time.Time {
+	if a.CheckedAt.IsZero() || a.CheckedAt.After(event.CreationDate) {
+		return event.CreationDate
+	}
+	return a.CheckedAt
+}
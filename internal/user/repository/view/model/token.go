@@ -1,12 +1,14 @@
 package model
 
 import (
+	"database/sql/driver"
 	"encoding/json"
 	"time"
 
 	"github.com/zitadel/logging"
 
 	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	es_models "github.com/zitadel/zitadel/internal/eventstore/v1/models"
@@ -40,6 +42,7 @@ type TokenView struct {
 	PreferredLanguage string               `json:"preferredLanguage" gorm:"column:preferred_language"`
 	RefreshTokenID    string               `json:"refreshTokenID,omitempty" gorm:"refresh_token_id"`
 	IsPAT             bool                 `json:"-" gorm:"is_pat"`
+	Actor             *TokenActor          `json:"actor,omitempty" gorm:"column:actor"`
 	Deactivated       bool                 `json:"-" gorm:"-"`
 	InstanceID        string               `json:"instanceID" gorm:"column:instance_id;primary_key"`
 }
@@ -60,9 +63,35 @@ func TokenViewToModel(token *TokenView) *usr_model.TokenView {
 		PreferredLanguage: token.PreferredLanguage,
 		RefreshTokenID:    token.RefreshTokenID,
 		IsPAT:             token.IsPAT,
+		Actor:             (*domain.TokenActor)(token.Actor),
 	}
 }
 
+// TokenActor stores the actor of a token issued by a token exchange as json
+type TokenActor domain.TokenActor
+
+func (a *TokenActor) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return caos_errs.ThrowInternal(nil, "MODEL-Eil1o", "unable to scan token actor")
+	}
+	return json.Unmarshal(data, a)
+}
+
+func (a *TokenActor) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return json.Marshal(a)
+}
+
 func (t *TokenView) AppendEventIfMyToken(event *es_models.Event) (err error) {
 	view := new(TokenView)
 	switch eventstore.EventType(event.Type) {
@@ -89,6 +89,9 @@ type HumanView struct {
 	Region                   string         `json:"region" gorm:"column:region"`
 	StreetAddress            string         `json:"streetAddress" gorm:"column:street_address"`
 	OTPState                 int32          `json:"-" gorm:"column:otp_state"`
+	OTPSMSAdded              bool           `json:"-" gorm:"column:otp_sms_added"`
+	OTPEmailAdded            bool           `json:"-" gorm:"column:otp_email_added"`
+	RecoveryCodesLeft        int32          `json:"-" gorm:"column:recovery_codes_left"`
 	U2FTokens                WebAuthNTokens `json:"-" gorm:"column:u2f_tokens"`
 	MFAMaxSetUp              int32          `json:"-" gorm:"column:mfa_max_set_up"`
 	MFAInitSkipped           time.Time      `json:"-" gorm:"column:mfa_init_skipped"`
@@ -178,6 +181,9 @@ func UserToModel(user *UserView) *model.UserView {
 			Region:                   user.Region,
 			StreetAddress:            user.StreetAddress,
 			OTPState:                 model.MFAState(user.OTPState),
+			OTPSMSAdded:              user.OTPSMSAdded,
+			OTPEmailAdded:            user.OTPEmailAdded,
+			RecoveryCodesLeft:        int(user.RecoveryCodesLeft),
 			MFAMaxSetUp:              domain.MFALevel(user.MFAMaxSetUp),
 			MFAInitSkipped:           user.MFAInitSkipped,
 			InitRequired:             user.InitRequired,
@@ -301,6 +307,7 @@ func (u *UserView) AppendEvent(event *models.Event) (err error) {
 		user.HumanPhoneRemovedType:
 		u.Phone = ""
 		u.IsPhoneVerified = false
+		u.OTPSMSAdded = false
 	case user.UserDeactivatedType:
 		u.State = int32(model.UserStateInactive)
 	case user.UserReactivatedType,
@@ -326,6 +333,23 @@ func (u *UserView) AppendEvent(event *models.Event) (err error) {
 	case user.UserV1MFAOTPRemovedType,
 		user.HumanMFAOTPRemovedType:
 		u.OTPState = int32(model.MFAStateUnspecified)
+		u.RecoveryCodesLeft = 0
+	case user.HumanRecoveryCodesAddedType:
+		err = u.setRecoveryCodes(event)
+	case user.HumanRecoveryCodeCheckSucceededType:
+		if u.RecoveryCodesLeft > 0 {
+			u.RecoveryCodesLeft--
+		}
+	case user.HumanOTPSMSAddedType:
+		u.OTPSMSAdded = true
+		u.MFAInitSkipped = time.Time{}
+	case user.HumanOTPSMSRemovedType:
+		u.OTPSMSAdded = false
+	case user.HumanOTPEmailAddedType:
+		u.OTPEmailAdded = true
+		u.MFAInitSkipped = time.Time{}
+	case user.HumanOTPEmailRemovedType:
+		u.OTPEmailAdded = false
 	case user.HumanU2FTokenAddedType:
 		err = u.addU2FToken(event)
 	case user.HumanU2FTokenVerifiedType:
@@ -480,6 +504,17 @@ func (u *UserView) removeU2FToken(event *models.Event) error {
 	return nil
 }
 
+func (u *UserView) setRecoveryCodes(event *models.Event) error {
+	codes := new(struct {
+		Codes []json.RawMessage `json:"codes"`
+	})
+	if err := json.Unmarshal(event.Data, codes); err != nil {
+		return errors.ThrowInternal(err, "MODEL-Rc3fs", "could not unmarshal data")
+	}
+	u.RecoveryCodesLeft = int32(len(codes.Codes))
+	return nil
+}
+
 func webAuthNViewFromEvent(event *models.Event) (*WebAuthNView, error) {
 	token := new(WebAuthNView)
 	err := json.Unmarshal(event.Data, token)
@@ -520,7 +555,7 @@ func (u *UserView) ComputeMFAMaxSetUp() {
 			return
 		}
 	}
-	if u.OTPState == int32(model.MFAStateReady) {
+	if u.OTPState == int32(model.MFAStateReady) || u.OTPSMSAdded || u.OTPEmailAdded {
 		u.MFAMaxSetUp = int32(domain.MFALevelSecondFactor)
 		return
 	}
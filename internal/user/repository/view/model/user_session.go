@@ -94,7 +94,11 @@ func (v *UserSessionView) AppendEvent(event *models.Event) error {
 	switch eventstore.EventType(event.Type) {
 	case user.UserV1PasswordCheckSucceededType,
 		user.HumanPasswordCheckSucceededType:
-		v.PasswordVerification = event.CreationDate
+		checkedAt, err := verificationTime(event)
+		if err != nil {
+			return err
+		}
+		v.PasswordVerification = checkedAt
 		v.State = int32(domain.UserSessionStateActive)
 	case user.UserIDPLoginCheckSucceededType:
 		data := new(es_model.AuthRequest)
@@ -102,12 +106,16 @@ func (v *UserSessionView) AppendEvent(event *models.Event) error {
 		if err != nil {
 			return err
 		}
-		v.ExternalLoginVerification = event.CreationDate
+		v.ExternalLoginVerification = data.VerificationTime(event)
 		v.SelectedIDPConfigID = data.SelectedIDPConfigID
 		v.State = int32(domain.UserSessionStateActive)
 	case user.HumanPasswordlessTokenCheckSucceededType:
-		v.PasswordlessVerification = event.CreationDate
-		v.MultiFactorVerification = event.CreationDate
+		checkedAt, err := verificationTime(event)
+		if err != nil {
+			return err
+		}
+		v.PasswordlessVerification = checkedAt
+		v.MultiFactorVerification = checkedAt
 		v.MultiFactorVerificationType = int32(domain.MFATypeU2FUserVerification)
 		v.State = int32(domain.UserSessionStateActive)
 	case user.HumanPasswordlessTokenCheckFailedType,
@@ -138,11 +146,26 @@ func (v *UserSessionView) AppendEvent(event *models.Event) error {
 		}
 	case user.UserV1MFAOTPCheckSucceededType,
 		user.HumanMFAOTPCheckSucceededType:
-		v.setSecondFactorVerification(event.CreationDate, domain.MFATypeOTP)
+		checkedAt, err := verificationTime(event)
+		if err != nil {
+			return err
+		}
+		v.setSecondFactorVerification(checkedAt, domain.MFATypeOTP)
+	case user.HumanOTPSMSCheckSucceededType:
+		v.setSecondFactorVerification(event.CreationDate, domain.MFATypeOTPSMS)
+	case user.HumanOTPEmailCheckSucceededType:
+		v.setSecondFactorVerification(event.CreationDate, domain.MFATypeOTPEmail)
+	case user.HumanRecoveryCodeCheckSucceededType:
+		v.setSecondFactorVerification(event.CreationDate, domain.MFATypeRecoveryCode)
 	case user.UserV1MFAOTPCheckFailedType,
 		user.UserV1MFAOTPRemovedType,
 		user.HumanMFAOTPCheckFailedType,
 		user.HumanMFAOTPRemovedType,
+		user.HumanOTPSMSCheckFailedType,
+		user.HumanOTPSMSRemovedType,
+		user.HumanOTPEmailCheckFailedType,
+		user.HumanOTPEmailRemovedType,
+		user.HumanRecoveryCodeCheckFailedType,
 		user.HumanU2FTokenCheckFailedType,
 		user.HumanU2FTokenRemovedType:
 		v.SecondFactorVerification = time.Time{}
@@ -184,6 +207,19 @@ func (v *UserSessionView) AppendEvent(event *models.Event) error {
 	return nil
 }
 
+// verificationTime returns the time the factor was originally checked on a session linked to the auth request,
+// or the creation date of the event for checks of the login itself
+func verificationTime(event *models.Event) (time.Time, error) {
+	if len(event.Data) == 0 {
+		return event.CreationDate, nil
+	}
+	data := new(es_model.AuthRequest)
+	if err := data.SetData(event); err != nil {
+		return time.Time{}, err
+	}
+	return data.VerificationTime(event), nil
+}
+
 func (v *UserSessionView) setSecondFactorVerification(verificationTime time.Time, mfaType domain.MFAType) {
 	v.SecondFactorVerification = verificationTime
 	v.SecondFactorVerificationType = int32(mfaType)
@@ -0,0 +1,130 @@
+package userdeletion
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// DeletionUserID is used as editor of the removals of the periodic job
+const DeletionUserID = "USER-DELETION"
+
+type Config struct {
+	// Enabled starts the periodic removal of the users of all instances, whose deletion grace period is over
+	Enabled bool
+	// Interval between the runs
+	Interval time.Duration
+}
+
+// Start removes the users with a due deletion periodically, until the context is done
+func Start(ctx context.Context, config Config, queries *query.Queries, commands *command.Commands) {
+	if !config.Enabled || config.Interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(config.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				deleteInstancesUsers(ctx, queries, commands)
+			}
+		}
+	}()
+}
+
+func deleteInstancesUsers(ctx context.Context, queries *query.Queries, commands *command.Commands) {
+	instances, err := queries.SearchInstances(ctx, &query.InstanceSearchQueries{})
+	if err != nil {
+		logging.WithError(err).Warn("unable to search instances for user deletion")
+		return
+	}
+	for _, instance := range instances.Instances {
+		instanceCtx := authz.WithInstanceID(ctx, instance.ID)
+		deleteInstanceUsers(instanceCtx, instance.ID, queries, commands)
+	}
+}
+
+func deleteInstanceUsers(ctx context.Context, instanceID string, queries *query.Queries, commands *command.Commands) {
+	deletions, err := commands.DueUserDeletions(ctx)
+	if err != nil {
+		logging.WithFields("instance", instanceID).WithError(err).Warn("unable to get due user deletions")
+		return
+	}
+	for _, deletion := range deletions {
+		memberships, grantIDs, err := userDependencies(ctx, queries, deletion.UserID)
+		if err != nil {
+			logging.WithFields("instance", instanceID, "user", deletion.UserID).WithError(err).Warn("unable to get dependencies of user to delete")
+			continue
+		}
+		_, err = commands.ExecuteUserDeletion(authz.SetCtxData(ctx, authz.CtxData{UserID: DeletionUserID, OrgID: deletion.ResourceOwner}), deletion.UserID, deletion.ResourceOwner, memberships, grantIDs...)
+		if err != nil {
+			logging.WithFields("instance", instanceID, "user", deletion.UserID).WithError(err).Warn("user deletion failed")
+			continue
+		}
+		logging.WithFields("instance", instanceID, "user", deletion.UserID).Debug("user deleted")
+	}
+}
+
+func userDependencies(ctx context.Context, queries *query.Queries, userID string) ([]*command.CascadingMembership, []string, error) {
+	userGrantUserQuery, err := query.NewUserGrantUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	grants, err := queries.UserGrants(ctx, &query.UserGrantsQueries{
+		Queries: []query.SearchQuery{userGrantUserQuery},
+	}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	membershipsUserQuery, err := query.NewMembershipUserIDQuery(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	memberships, err := queries.Memberships(ctx, &query.MembershipSearchQuery{
+		Queries: []query.SearchQuery{membershipsUserQuery},
+	}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cascadingMemberships(memberships.Memberships), userGrantsToIDs(grants.UserGrants), nil
+}
+
+func cascadingMemberships(memberships []*query.Membership) []*command.CascadingMembership {
+	cascades := make([]*command.CascadingMembership, len(memberships))
+	for i, membership := range memberships {
+		cascade := &command.CascadingMembership{
+			UserID:        membership.UserID,
+			ResourceOwner: membership.ResourceOwner,
+		}
+		if membership.IAM != nil {
+			cascade.IAM = &command.CascadingIAMMembership{IAMID: membership.IAM.IAMID}
+		}
+		if membership.Org != nil {
+			cascade.Org = &command.CascadingOrgMembership{OrgID: membership.Org.OrgID}
+		}
+		if membership.Project != nil {
+			cascade.Project = &command.CascadingProjectMembership{ProjectID: membership.Project.ProjectID}
+		}
+		if membership.ProjectGrant != nil {
+			cascade.ProjectGrant = &command.CascadingProjectGrantMembership{ProjectID: membership.ProjectGrant.ProjectID, GrantID: membership.ProjectGrant.GrantID}
+		}
+		cascades[i] = cascade
+	}
+	return cascades
+}
+
+func userGrantsToIDs(userGrants []*query.UserGrant) []string {
+	ids := make([]string, len(userGrants))
+	for i, grant := range userGrants {
+		ids[i] = grant.ID
+	}
+	return ids
+}
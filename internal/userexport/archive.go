@@ -0,0 +1,91 @@
+package userexport
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const redacted = "[REDACTED]"
+
+// WriteZIP writes the bundle as ZIP archive with one JSON file per category
+func (b *Bundle) WriteZIP(w io.Writer) error {
+	archive := zip.NewWriter(w)
+	files := []struct {
+		name    string
+		content interface{}
+	}{
+		{"export.json", struct {
+			ExportDate time.Time `json:"exportDate"`
+			UserID     string    `json:"userId"`
+		}{b.ExportDate, b.User.ID}},
+		{"user.json", b.User},
+		{"metadata.json", b.Metadata},
+		{"idp_links.json", b.IDPLinks},
+		{"auth_methods.json", b.AuthMethods},
+		{"grants.json", b.Grants},
+		{"memberships.json", b.Memberships},
+		{"personal_access_tokens.json", b.PersonalAccessTokens},
+		{"sessions.json", b.Sessions},
+		{"events.json", b.Events},
+	}
+	for _, file := range files {
+		f, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     file.name,
+			Method:   zip.Deflate,
+			Modified: b.ExportDate,
+		})
+		if err != nil {
+			return errors.ThrowInternal(err, "EXPORT-Sk2nf", "Errors.User.Export.Failed")
+		}
+		encoder := json.NewEncoder(f)
+		encoder.SetIndent("", "  ")
+		if err = encoder.Encode(file.content); err != nil {
+			return errors.ThrowInternal(err, "EXPORT-Lw0ds", "Errors.User.Export.Failed")
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return errors.ThrowInternal(err, "EXPORT-Pq3md", "Errors.User.Export.Failed")
+	}
+	return nil
+}
+
+// redactPayload parses the event payload and replaces all encrypted values
+// (e.g. password hashes, verification codes and secrets) by a placeholder
+func redactPayload(payload []byte) interface{} {
+	if len(payload) == 0 {
+		return nil
+	}
+	var data interface{}
+	if err := json.Unmarshal(payload, &data); err != nil {
+		return nil
+	}
+	return redact(data)
+}
+
+func redact(data interface{}) interface{} {
+	switch value := data.(type) {
+	case map[string]interface{}:
+		if isCryptoValue(value) {
+			return redacted
+		}
+		for key, v := range value {
+			value[key] = redact(v)
+		}
+	case []interface{}:
+		for i, v := range value {
+			value[i] = redact(v)
+		}
+	}
+	return data
+}
+
+// isCryptoValue checks for the fields of a marshalled crypto.CryptoValue
+func isCryptoValue(value map[string]interface{}) bool {
+	_, crypted := value["Crypted"]
+	_, cryptoType := value["CryptoType"]
+	return crypted && cryptoType
+}
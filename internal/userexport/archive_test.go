@@ -0,0 +1,105 @@
+package userexport
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundle_WriteZIP(t *testing.T) {
+	exportDate := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	bundle := &Bundle{
+		ExportDate: exportDate,
+		User: &User{
+			ID:    "user1",
+			State: "active",
+			Type:  "human",
+			Human: &Human{
+				FirstName: "firstname",
+				Email:     "email@test.ch",
+			},
+		},
+		Metadata: []*Metadata{
+			{Key: "key", Value: "value"},
+		},
+		Events: []*Event{
+			{Sequence: 1, Type: "user.human.added"},
+		},
+	}
+	buf := new(bytes.Buffer)
+	require.NoError(t, bundle.WriteZIP(buf))
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	files := make(map[string][]byte, len(archive.File))
+	for _, file := range archive.File {
+		f, err := file.Open()
+		require.NoError(t, err)
+		files[file.Name], err = io.ReadAll(f)
+		require.NoError(t, err)
+		f.Close()
+	}
+	assert.Len(t, files, 10)
+
+	var user User
+	require.NoError(t, json.Unmarshal(files["user.json"], &user))
+	assert.Equal(t, *bundle.User, user)
+
+	var metadata []*Metadata
+	require.NoError(t, json.Unmarshal(files["metadata.json"], &metadata))
+	assert.Equal(t, bundle.Metadata, metadata)
+
+	var grants []*Grant
+	require.NoError(t, json.Unmarshal(files["grants.json"], &grants))
+	assert.Empty(t, grants)
+
+	assert.JSONEq(t, `{"exportDate":"2023-05-01T12:00:00Z","userId":"user1"}`, string(files["export.json"]))
+	assert.JSONEq(t, `[{"sequence":1,"creationDate":"0001-01-01T00:00:00Z","type":"user.human.added","editorId":""}]`, string(files["events.json"]))
+}
+
+func Test_redactPayload(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload []byte
+		want    string
+	}{
+		{
+			name:    "empty payload",
+			payload: nil,
+			want:    `null`,
+		},
+		{
+			name:    "invalid payload",
+			payload: []byte(`invalid`),
+			want:    `null`,
+		},
+		{
+			name:    "nothing to redact",
+			payload: []byte(`{"userName":"username","email":"email@test.ch"}`),
+			want:    `{"userName":"username","email":"email@test.ch"}`,
+		},
+		{
+			name:    "crypto value",
+			payload: []byte(`{"secret":{"CryptoType":1,"Algorithm":"bcrypt","KeyID":"","Crypted":"c2VjcmV0"},"changeRequired":true}`),
+			want:    `{"secret":"[REDACTED]","changeRequired":true}`,
+		},
+		{
+			name:    "crypto values in list",
+			payload: []byte(`{"codes":[{"CryptoType":0,"Algorithm":"aes","KeyID":"key","Crypted":"Y29kZQ=="},{"CryptoType":0,"Algorithm":"aes","KeyID":"key","Crypted":"Y29kZQ=="}]}`),
+			want:    `{"codes":["[REDACTED]","[REDACTED]"]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(redactPayload(tt.payload))
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
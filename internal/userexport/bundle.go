@@ -0,0 +1,319 @@
+package userexport
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// Bundle contains all data stored about a single user
+type Bundle struct {
+	ExportDate           time.Time
+	User                 *User
+	Metadata             []*Metadata
+	IDPLinks             []*IDPLink
+	AuthMethods          []*AuthMethod
+	Grants               []*Grant
+	Memberships          []*Membership
+	PersonalAccessTokens []*PersonalAccessToken
+	Sessions             []*Session
+	Events               []*Event
+}
+
+type User struct {
+	ID                 string    `json:"id"`
+	CreationDate       time.Time `json:"creationDate"`
+	ChangeDate         time.Time `json:"changeDate"`
+	ResourceOwner      string    `json:"resourceOwner"`
+	State              string    `json:"state"`
+	Type               string    `json:"type"`
+	Username           string    `json:"username"`
+	LoginNames         []string  `json:"loginNames"`
+	PreferredLoginName string    `json:"preferredLoginName"`
+	Human              *Human    `json:"human,omitempty"`
+	Machine            *Machine  `json:"machine,omitempty"`
+}
+
+type Human struct {
+	FirstName         string `json:"firstName"`
+	LastName          string `json:"lastName"`
+	NickName          string `json:"nickName,omitempty"`
+	DisplayName       string `json:"displayName"`
+	PreferredLanguage string `json:"preferredLanguage,omitempty"`
+	Gender            string `json:"gender,omitempty"`
+	Email             string `json:"email"`
+	IsEmailVerified   bool   `json:"isEmailVerified"`
+	Phone             string `json:"phone,omitempty"`
+	IsPhoneVerified   bool   `json:"isPhoneVerified"`
+}
+
+type Machine struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+type Metadata struct {
+	Key          string    `json:"key"`
+	Value        string    `json:"value"`
+	CreationDate time.Time `json:"creationDate"`
+	ChangeDate   time.Time `json:"changeDate"`
+}
+
+type IDPLink struct {
+	IDPID            string `json:"idpId"`
+	IDPName          string `json:"idpName"`
+	ProvidedUserID   string `json:"providedUserId"`
+	ProvidedUsername string `json:"providedUsername"`
+}
+
+type AuthMethod struct {
+	Type         string    `json:"type"`
+	State        string    `json:"state"`
+	Name         string    `json:"name,omitempty"`
+	CreationDate time.Time `json:"creationDate"`
+	ChangeDate   time.Time `json:"changeDate"`
+}
+
+type Grant struct {
+	ID             string    `json:"id"`
+	State          string    `json:"state"`
+	ProjectID      string    `json:"projectId"`
+	ProjectName    string    `json:"projectName"`
+	ProjectGrantID string    `json:"projectGrantId,omitempty"`
+	ResourceOwner  string    `json:"resourceOwner"`
+	OrgName        string    `json:"orgName"`
+	Roles          []string  `json:"roles"`
+	CreationDate   time.Time `json:"creationDate"`
+	ChangeDate     time.Time `json:"changeDate"`
+}
+
+type Membership struct {
+	InstanceID     string    `json:"instanceId,omitempty"`
+	OrgID          string    `json:"orgId,omitempty"`
+	ProjectID      string    `json:"projectId,omitempty"`
+	ProjectGrantID string    `json:"projectGrantId,omitempty"`
+	Roles          []string  `json:"roles"`
+	CreationDate   time.Time `json:"creationDate"`
+	ChangeDate     time.Time `json:"changeDate"`
+}
+
+// PersonalAccessToken only contains the metadata of the token, never the token itself
+type PersonalAccessToken struct {
+	ID           string    `json:"id"`
+	Scopes       []string  `json:"scopes"`
+	Expiration   time.Time `json:"expiration"`
+	CreationDate time.Time `json:"creationDate"`
+}
+
+type Session struct {
+	ID                string     `json:"id"`
+	CreationDate      time.Time  `json:"creationDate"`
+	ChangeDate        time.Time  `json:"changeDate"`
+	UserCheckedAt     *time.Time `json:"userCheckedAt,omitempty"`
+	PasswordCheckedAt *time.Time `json:"passwordCheckedAt,omitempty"`
+	OTPCheckedAt      *time.Time `json:"otpCheckedAt,omitempty"`
+	WebAuthNCheckedAt *time.Time `json:"webAuthNCheckedAt,omitempty"`
+	IDPID             string     `json:"idpId,omitempty"`
+	IDPCheckedAt      *time.Time `json:"idpCheckedAt,omitempty"`
+}
+
+type Event struct {
+	Sequence     uint64    `json:"sequence"`
+	CreationDate time.Time `json:"creationDate"`
+	Type         string    `json:"type"`
+	EditorID     string    `json:"editorId"`
+	EditorName   string    `json:"editorName,omitempty"`
+	// Payload is the data of the event, with encrypted or hashed values (e.g. passwords and codes) redacted
+	Payload interface{} `json:"payload,omitempty"`
+}
+
+var (
+	userStates      = []string{"unspecified", "active", "inactive", "deleted", "locked", "suspended", "initial"}
+	userTypes       = []string{"unspecified", "human", "machine"}
+	genders         = []string{"", "female", "male", "diverse"}
+	authMethodTypes = []string{"unspecified", "otp", "u2f", "passwordless", "otp_sms", "otp_email", "recovery_codes"}
+	mfaStates       = []string{"unspecified", "not_ready", "ready", "removed"}
+	grantStates     = []string{"unspecified", "active", "inactive", "removed"}
+)
+
+// enumName returns the name of the value or an empty string if it's unknown
+func enumName(names []string, value int32) string {
+	if value < 0 || int(value) >= len(names) {
+		return ""
+	}
+	return names[value]
+}
+
+func userToExport(user *query.User) *User {
+	exported := &User{
+		ID:                 user.ID,
+		CreationDate:       user.CreationDate,
+		ChangeDate:         user.ChangeDate,
+		ResourceOwner:      user.ResourceOwner,
+		State:              enumName(userStates, int32(user.State)),
+		Type:               enumName(userTypes, int32(user.Type)),
+		Username:           user.Username,
+		LoginNames:         user.LoginNames,
+		PreferredLoginName: user.PreferredLoginName,
+	}
+	if user.Human != nil {
+		exported.Human = &Human{
+			FirstName:         user.Human.FirstName,
+			LastName:          user.Human.LastName,
+			NickName:          user.Human.NickName,
+			DisplayName:       user.Human.DisplayName,
+			PreferredLanguage: user.Human.PreferredLanguage.String(),
+			Gender:            enumName(genders, int32(user.Human.Gender)),
+			Email:             user.Human.Email,
+			IsEmailVerified:   user.Human.IsEmailVerified,
+			Phone:             user.Human.Phone,
+			IsPhoneVerified:   user.Human.IsPhoneVerified,
+		}
+	}
+	if user.Machine != nil {
+		exported.Machine = &Machine{
+			Name:        user.Machine.Name,
+			Description: user.Machine.Description,
+		}
+	}
+	return exported
+}
+
+func metadataToExport(metadata []*query.UserMetadata) []*Metadata {
+	exported := make([]*Metadata, len(metadata))
+	for i, m := range metadata {
+		exported[i] = &Metadata{
+			Key:          m.Key,
+			Value:        string(m.Value),
+			CreationDate: m.CreationDate,
+			ChangeDate:   m.ChangeDate,
+		}
+	}
+	return exported
+}
+
+func idpLinksToExport(links []*query.IDPUserLink) []*IDPLink {
+	exported := make([]*IDPLink, len(links))
+	for i, link := range links {
+		exported[i] = &IDPLink{
+			IDPID:            link.IDPID,
+			IDPName:          link.IDPName,
+			ProvidedUserID:   link.ProvidedUserID,
+			ProvidedUsername: link.ProvidedUsername,
+		}
+	}
+	return exported
+}
+
+func authMethodsToExport(methods []*query.AuthMethod) []*AuthMethod {
+	exported := make([]*AuthMethod, len(methods))
+	for i, method := range methods {
+		exported[i] = &AuthMethod{
+			Type:         enumName(authMethodTypes, int32(method.Type)),
+			State:        enumName(mfaStates, int32(method.State)),
+			Name:         method.Name,
+			CreationDate: method.CreationDate,
+			ChangeDate:   method.ChangeDate,
+		}
+	}
+	return exported
+}
+
+func grantsToExport(grants []*query.UserGrant) []*Grant {
+	exported := make([]*Grant, len(grants))
+	for i, grant := range grants {
+		exported[i] = &Grant{
+			ID:             grant.ID,
+			State:          enumName(grantStates, int32(grant.State)),
+			ProjectID:      grant.ProjectID,
+			ProjectName:    grant.ProjectName,
+			ProjectGrantID: grant.GrantID,
+			ResourceOwner:  grant.ResourceOwner,
+			OrgName:        grant.OrgName,
+			Roles:          grant.Roles,
+			CreationDate:   grant.CreationDate,
+			ChangeDate:     grant.ChangeDate,
+		}
+	}
+	return exported
+}
+
+func membershipsToExport(memberships []*query.Membership) []*Membership {
+	exported := make([]*Membership, len(memberships))
+	for i, membership := range memberships {
+		m := &Membership{
+			Roles:        membership.Roles,
+			CreationDate: membership.CreationDate,
+			ChangeDate:   membership.ChangeDate,
+		}
+		switch {
+		case membership.IAM != nil:
+			m.InstanceID = membership.IAM.IAMID
+		case membership.Org != nil:
+			m.OrgID = membership.Org.OrgID
+		case membership.Project != nil:
+			m.ProjectID = membership.Project.ProjectID
+		case membership.ProjectGrant != nil:
+			m.ProjectID = membership.ProjectGrant.ProjectID
+			m.ProjectGrantID = membership.ProjectGrant.GrantID
+		}
+		exported[i] = m
+	}
+	return exported
+}
+
+func personalAccessTokensToExport(tokens []*query.PersonalAccessToken) []*PersonalAccessToken {
+	exported := make([]*PersonalAccessToken, len(tokens))
+	for i, token := range tokens {
+		exported[i] = &PersonalAccessToken{
+			ID:           token.ID,
+			Scopes:       token.Scopes,
+			Expiration:   token.Expiration,
+			CreationDate: token.CreationDate,
+		}
+	}
+	return exported
+}
+
+func sessionsToExport(sessions []*query.Session) []*Session {
+	exported := make([]*Session, len(sessions))
+	for i, session := range sessions {
+		exported[i] = &Session{
+			ID:                session.ID,
+			CreationDate:      session.CreationDate,
+			ChangeDate:        session.ChangeDate,
+			UserCheckedAt:     timeOrNil(session.UserCheckedAt),
+			PasswordCheckedAt: timeOrNil(session.PasswordCheckedAt),
+			OTPCheckedAt:      timeOrNil(session.OTPCheckedAt),
+			WebAuthNCheckedAt: timeOrNil(session.WebAuthNCheckedAt),
+			IDPID:             session.IDPID,
+			IDPCheckedAt:      timeOrNil(session.IDPCheckedAt),
+		}
+	}
+	return exported
+}
+
+func eventsToExport(events []*query.Event) []*Event {
+	exported := make([]*Event, len(events))
+	for i, event := range events {
+		e := &Event{
+			Sequence:     event.Sequence,
+			CreationDate: event.CreationDate,
+			Type:         event.Type,
+			Payload:      redactPayload(event.Payload),
+		}
+		if event.Editor != nil {
+			e.EditorID = event.Editor.ID
+			e.EditorName = event.Editor.DisplayName
+		}
+		exported[i] = e
+	}
+	return exported
+}
+
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
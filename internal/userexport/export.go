@@ -0,0 +1,105 @@
+// Package userexport assembles all data stored about a single user,
+// e.g. to answer data subject access requests.
+package userexport
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// Collect queries all data of the user of the resource owner
+func Collect(ctx context.Context, queries *query.Queries, userID, resourceOwner string) (_ *Bundle, err error) {
+	ownerQuery, err := query.NewUserResourceOwnerSearchQuery(resourceOwner, query.TextEquals)
+	if err != nil {
+		return nil, err
+	}
+	u, err := queries.GetUserByID(ctx, true, userID, false, ownerQuery)
+	if err != nil {
+		return nil, err
+	}
+	bundle := &Bundle{
+		ExportDate: time.Now().UTC(),
+		User:       userToExport(u),
+	}
+
+	metadata, err := queries.SearchUserMetadata(ctx, false, userID, &query.UserMetadataSearchQueries{}, false)
+	if err != nil {
+		return nil, err
+	}
+	bundle.Metadata = metadataToExport(metadata.Metadata)
+
+	idpLinkQuery, err := query.NewIDPUserLinksUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	idpLinks, err := queries.IDPUserLinks(ctx, &query.IDPUserLinksSearchQuery{Queries: []query.SearchQuery{idpLinkQuery}}, false)
+	if err != nil {
+		return nil, err
+	}
+	bundle.IDPLinks = idpLinksToExport(idpLinks.Links)
+
+	authMethodQuery, err := query.NewUserAuthMethodUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	authMethods, err := queries.SearchUserAuthMethods(ctx, &query.UserAuthMethodSearchQueries{Queries: []query.SearchQuery{authMethodQuery}}, false)
+	if err != nil {
+		return nil, err
+	}
+	bundle.AuthMethods = authMethodsToExport(authMethods.AuthMethods)
+
+	grantQuery, err := query.NewUserGrantUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := queries.UserGrants(ctx, &query.UserGrantsQueries{Queries: []query.SearchQuery{grantQuery}}, false)
+	if err != nil {
+		return nil, err
+	}
+	bundle.Grants = grantsToExport(grants.UserGrants)
+
+	membershipQuery, err := query.NewMembershipUserIDQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	memberships, err := queries.Memberships(ctx, &query.MembershipSearchQuery{Queries: []query.SearchQuery{membershipQuery}}, false)
+	if err != nil {
+		return nil, err
+	}
+	bundle.Memberships = membershipsToExport(memberships.Memberships)
+
+	patQuery, err := query.NewPersonalAccessTokenUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	pats, err := queries.SearchPersonalAccessTokens(ctx, &query.PersonalAccessTokenSearchQueries{Queries: []query.SearchQuery{patQuery}}, false)
+	if err != nil {
+		return nil, err
+	}
+	bundle.PersonalAccessTokens = personalAccessTokensToExport(pats.PersonalAccessTokens)
+
+	sessions, err := queries.SessionsByUserID(ctx, false, userID)
+	if err != nil {
+		return nil, err
+	}
+	bundle.Sessions = sessionsToExport(sessions.Sessions)
+
+	events, err := queries.SearchEvents(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		InstanceID(authz.GetInstance(ctx).InstanceID()).
+		OrderAsc().
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(userID).
+		Builder(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	bundle.Events = eventsToExport(events)
+	return bundle, nil
+}
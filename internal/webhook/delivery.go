@@ -0,0 +1,386 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	sd "github.com/zitadel/zitadel/internal/config/systemdefaults"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
+)
+
+const (
+	SignatureHeader  = "Zitadel-Signature"
+	EventTypeHeader  = "Zitadel-Event-Type"
+	DeliveryIDHeader = "Zitadel-Delivery-Id"
+
+	signatureVersion = "v1"
+)
+
+var (
+	scheduleDeliveryValues = "INSERT INTO " + projection.WebhookDeliveryTable +
+		" (" + projection.WebhookDeliveryWebhookIDCol +
+		", " + projection.WebhookDeliveryInstanceIDCol +
+		", " + projection.WebhookDeliveryEventSequenceCol +
+		", " + projection.WebhookDeliveryResourceOwnerCol +
+		", " + projection.WebhookDeliveryAggregateTypeCol +
+		", " + projection.WebhookDeliveryAggregateIDCol +
+		", " + projection.WebhookDeliveryEventTypeCol +
+		", " + projection.WebhookDeliveryEventCreationDateCol +
+		", " + projection.WebhookDeliveryCreationDateCol +
+		", " + projection.WebhookDeliveryChangeDateCol +
+		", " + projection.WebhookDeliveryStateCol +
+		", " + projection.WebhookDeliveryAttemptsCol +
+		", " + projection.WebhookDeliveryStatusCodeCol +
+		", " + projection.WebhookDeliveryLastErrorCol +
+		", " + projection.WebhookDeliveryPayloadCol +
+		", " + projection.WebhookDeliveryNextAttemptCol +
+		") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9, $10, 0, 0, '', $11, $9)" +
+		" ON CONFLICT (" + projection.WebhookDeliveryInstanceIDCol + ", " + projection.WebhookDeliveryWebhookIDCol + ", " + projection.WebhookDeliveryEventSequenceCol + ")"
+
+	scheduleDeliveryStmt   = scheduleDeliveryValues + " DO NOTHING"
+	rescheduleDeliveryStmt = scheduleDeliveryValues +
+		" DO UPDATE SET (" + projection.WebhookDeliveryChangeDateCol +
+		", " + projection.WebhookDeliveryStateCol +
+		", " + projection.WebhookDeliveryAttemptsCol +
+		", " + projection.WebhookDeliveryStatusCodeCol +
+		", " + projection.WebhookDeliveryLastErrorCol +
+		", " + projection.WebhookDeliveryPayloadCol +
+		", " + projection.WebhookDeliveryNextAttemptCol +
+		") = (EXCLUDED." + projection.WebhookDeliveryChangeDateCol +
+		", EXCLUDED." + projection.WebhookDeliveryStateCol +
+		", 0, 0, ''" +
+		", EXCLUDED." + projection.WebhookDeliveryPayloadCol +
+		", EXCLUDED." + projection.WebhookDeliveryNextAttemptCol + ")"
+
+	// claimDeliveriesStmt postpones the next attempt of the due deliveries by the lease,
+	// so they are not sent concurrently by another instance of ZITADEL
+	claimDeliveriesStmt = "UPDATE " + projection.WebhookDeliveryTable +
+		" SET " + projection.WebhookDeliveryNextAttemptCol + " = $1" +
+		" WHERE " + projection.WebhookDeliveryStateCol + " = $2" +
+		" AND " + projection.WebhookDeliveryNextAttemptCol + " <= $3" +
+		" AND (" + projection.WebhookDeliveryInstanceIDCol + ", " + projection.WebhookDeliveryWebhookIDCol + ", " + projection.WebhookDeliveryEventSequenceCol + ") IN (" +
+		"SELECT " + projection.WebhookDeliveryInstanceIDCol + ", " + projection.WebhookDeliveryWebhookIDCol + ", " + projection.WebhookDeliveryEventSequenceCol +
+		" FROM " + projection.WebhookDeliveryTable +
+		" WHERE " + projection.WebhookDeliveryStateCol + " = $2" +
+		" AND " + projection.WebhookDeliveryNextAttemptCol + " <= $3" +
+		" ORDER BY " + projection.WebhookDeliveryNextAttemptCol +
+		" LIMIT $4)" +
+		" RETURNING " + projection.WebhookDeliveryInstanceIDCol +
+		", " + projection.WebhookDeliveryWebhookIDCol +
+		", " + projection.WebhookDeliveryEventSequenceCol +
+		", " + projection.WebhookDeliveryResourceOwnerCol +
+		", " + projection.WebhookDeliveryAggregateTypeCol +
+		", " + projection.WebhookDeliveryEventTypeCol +
+		", " + projection.WebhookDeliveryAttemptsCol +
+		", " + projection.WebhookDeliveryPayloadCol
+
+	setDeliveryResultStmt = "UPDATE " + projection.WebhookDeliveryTable +
+		" SET (" + projection.WebhookDeliveryChangeDateCol +
+		", " + projection.WebhookDeliveryStateCol +
+		", " + projection.WebhookDeliveryAttemptsCol +
+		", " + projection.WebhookDeliveryStatusCodeCol +
+		", " + projection.WebhookDeliveryLastErrorCol +
+		", " + projection.WebhookDeliveryNextAttemptCol +
+		") = ($1, $2, $3, $4, $5, $6)" +
+		" WHERE " + projection.WebhookDeliveryInstanceIDCol + " = $7" +
+		" AND " + projection.WebhookDeliveryWebhookIDCol + " = $8" +
+		" AND " + projection.WebhookDeliveryEventSequenceCol + " = $9"
+)
+
+// Payload is the body of every delivery
+type Payload struct {
+	ID            string          `json:"id"`
+	InstanceID    string          `json:"instanceId"`
+	ResourceOwner string          `json:"resourceOwner"`
+	AggregateType string          `json:"aggregateType"`
+	AggregateID   string          `json:"aggregateId"`
+	EventType     string          `json:"eventType"`
+	Sequence      uint64          `json:"sequence"`
+	CreationDate  time.Time       `json:"creationDate"`
+	EditorUser    string          `json:"editorUser"`
+	Payload       json.RawMessage `json:"payload,omitempty"`
+}
+
+// newPayload creates the payload of the delivery,
+// the data of the event is passed without password hashes, secrets, codes and tokens
+func newPayload(webhookID string, event eventstore.Event) *Payload {
+	payload := &Payload{
+		ID:            deliveryID(webhookID, event.Sequence()),
+		InstanceID:    event.Aggregate().InstanceID,
+		ResourceOwner: event.Aggregate().ResourceOwner,
+		AggregateType: string(event.Aggregate().Type),
+		AggregateID:   event.Aggregate().ID,
+		EventType:     string(event.Type()),
+		Sequence:      event.Sequence(),
+		CreationDate:  event.CreationDate(),
+		EditorUser:    event.EditorUser(),
+	}
+	payload.Payload = eventstore.RedactedData(event)
+	return payload
+}
+
+// deliveryID is the same for every attempt of the delivery, so the receiver is able to deduplicate
+func deliveryID(webhookID string, sequence uint64) string {
+	return webhookID + ":" + strconv.FormatUint(sequence, 10)
+}
+
+// Sign computes the signature header value of the body: t=<unix timestamp>,v1=<hex(HMAC-SHA256(key, "<unix timestamp>.<body>"))>
+// The timestamp is part of the signed content to prevent replay attacks.
+func Sign(body []byte, signingKey string, timestamp time.Time) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(signingKey))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "t=" + ts + "," + signatureVersion + "=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type webhookQuerier interface {
+	GetWebhookByID(ctx context.Context, id string, resourceOwner string, withOwnerRemoved bool) (*query.Webhook, error)
+}
+
+type webhookCommander interface {
+	FailWebhookDelivery(ctx context.Context, webhookID, resourceOwner string, eventSequence uint64, eventAggregateType eventstore.AggregateType, eventType eventstore.EventType, attempts uint64, statusCode int, lastError string) (*domain.ObjectDetails, error)
+}
+
+// deliverer sends the pending deliveries scheduled by the webhook handler,
+// every delivery is sent and retried independently of all others
+type deliverer struct {
+	queries          webhookQuerier
+	commands         webhookCommander
+	client           *sql.DB
+	httpClient       *http.Client
+	signingKeyCrypto crypto.EncryptionAlgorithm
+	config           sd.Webhooks
+}
+
+func newDeliverer(queries webhookQuerier, commands webhookCommander, client *sql.DB, signingKeyCrypto crypto.EncryptionAlgorithm, config sd.Webhooks) *deliverer {
+	return &deliverer{
+		queries:  queries,
+		commands: commands,
+		client:   client,
+		httpClient: &http.Client{
+			Timeout:   config.DeliveryTimeout,
+			Transport: actions.NewDenyListTransport(),
+		},
+		signingKeyCrypto: signingKeyCrypto,
+		config:           config,
+	}
+}
+
+type pendingDelivery struct {
+	instanceID    string
+	webhookID     string
+	eventSequence uint64
+	resourceOwner string
+	aggregateType string
+	eventType     string
+	attempts      uint64
+	payload       []byte
+}
+
+// Start sends the due deliveries every DeliveryInterval until the context is done
+func (d *deliverer) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(d.config.DeliveryInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				d.deliverDue(ctx)
+			}
+		}
+	}()
+}
+
+func (d *deliverer) deliverDue(ctx context.Context) {
+	deliveries, err := d.claimDue(ctx)
+	if err != nil {
+		logging.WithError(err).Warn("unable to query due webhook deliveries")
+		return
+	}
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		go func(delivery *pendingDelivery) {
+			defer wg.Done()
+			d.deliver(delivery)
+		}(delivery)
+	}
+	wg.Wait()
+}
+
+// claimDue returns the due deliveries and postpones their next attempt by twice the DeliveryTimeout
+func (d *deliverer) claimDue(ctx context.Context) (_ []*pendingDelivery, err error) {
+	now := time.Now()
+	rows, err := d.client.QueryContext(ctx, claimDeliveriesStmt,
+		now.Add(2*d.config.DeliveryTimeout),
+		domain.WebhookDeliveryStatePending,
+		now,
+		d.config.DeliveryBulkLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	deliveries := make([]*pendingDelivery, 0)
+	for rows.Next() {
+		delivery := new(pendingDelivery)
+		if err = rows.Scan(
+			&delivery.instanceID,
+			&delivery.webhookID,
+			&delivery.eventSequence,
+			&delivery.resourceOwner,
+			&delivery.aggregateType,
+			&delivery.eventType,
+			&delivery.attempts,
+			&delivery.payload,
+		); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+// deliver sends the payload to the webhook and stores the result of the attempt
+func (d *deliverer) deliver(delivery *pendingDelivery) {
+	ctx := webhookContext(delivery.instanceID, delivery.resourceOwner)
+	logger := logging.WithFields("webhook", delivery.webhookID, "instance", delivery.instanceID, "sequence", delivery.eventSequence)
+
+	hook, err := d.queries.GetWebhookByID(ctx, delivery.webhookID, delivery.resourceOwner, false)
+	if err != nil && !errors.IsNotFound(err) {
+		// the delivery is attempted again after the lease
+		logger.WithError(err).Warn("unable to query webhook")
+		return
+	}
+	var (
+		statusCode int
+		sent       bool
+	)
+	if err != nil || hook.State != domain.WebhookStateActive {
+		err = errors.ThrowPreconditionFailed(err, "WEBHO-Ke3ai", "Errors.Webhook.NotActive")
+		// no further attempts to inactive and removed webhooks, but they can be replayed after reactivation
+		delivery.attempts = d.config.MaxDeliveryAttempts
+	} else {
+		statusCode, err = d.send(ctx, hook, delivery)
+		sent = true
+		logger.OnError(err).Info("webhook delivery failed")
+	}
+	state, attempts, nextAttempt := d.nextState(delivery.attempts, err, time.Now())
+	var lastError string
+	if err != nil {
+		lastError = err.Error()
+	}
+	if sent && state == domain.WebhookDeliveryStateFailed {
+		d.deadLetter(ctx, delivery, attempts, statusCode, lastError)
+	}
+	_, err = d.client.ExecContext(ctx, setDeliveryResultStmt,
+		time.Now(),
+		state,
+		attempts,
+		statusCode,
+		lastError,
+		nextAttempt,
+		delivery.instanceID,
+		delivery.webhookID,
+		delivery.eventSequence,
+	)
+	logger.OnError(err).Warn("unable to store webhook delivery")
+}
+
+// deadLetter stores the delivery, which failed after all attempts, as event of the webhook,
+// so the failure is part of its history and the delivery can be replayed
+func (d *deliverer) deadLetter(ctx context.Context, delivery *pendingDelivery, attempts uint64, statusCode int, lastError string) {
+	_, err := d.commands.FailWebhookDelivery(ctx,
+		delivery.webhookID,
+		delivery.resourceOwner,
+		delivery.eventSequence,
+		eventstore.AggregateType(delivery.aggregateType),
+		eventstore.EventType(delivery.eventType),
+		attempts,
+		statusCode,
+		lastError,
+	)
+	logging.WithFields("webhook", delivery.webhookID, "instance", delivery.instanceID, "sequence", delivery.eventSequence).OnError(err).Warn("unable to dead-letter webhook delivery")
+}
+
+// nextState returns the state of the delivery after the attempt,
+// failed deliveries are retried with an exponential backoff until MaxDeliveryAttempts is reached
+func (d *deliverer) nextState(previousAttempts uint64, err error, now time.Time) (_ domain.WebhookDeliveryState, attempts uint64, nextAttempt *time.Time) {
+	attempts = previousAttempts + 1
+	if err == nil {
+		return domain.WebhookDeliveryStateDelivered, attempts, nil
+	}
+	if attempts >= d.config.MaxDeliveryAttempts {
+		return domain.WebhookDeliveryStateFailed, attempts, nil
+	}
+	next := now.Add(d.retryDelay(attempts))
+	return domain.WebhookDeliveryStatePending, attempts, &next
+}
+
+// retryDelay returns the time to wait after the failed attempt
+func (d *deliverer) retryDelay(attempts uint64) time.Duration {
+	if d.config.MaxRetryDelay <= d.config.RetryDelay {
+		return d.config.RetryDelay
+	}
+	delay := d.config.RetryDelay
+	for i := uint64(1); i < attempts && delay < d.config.MaxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > d.config.MaxRetryDelay {
+		return d.config.MaxRetryDelay
+	}
+	return delay
+}
+
+func (d *deliverer) send(ctx context.Context, hook *query.Webhook, delivery *pendingDelivery) (statusCode int, err error) {
+	// the url is checked again as the deny list might have changed since the webhook was saved
+	if err = actions.CheckURLAllowed(hook.URL); err != nil {
+		return 0, err
+	}
+	signingKey, err := crypto.DecryptString(hook.SigningKey, d.signingKeyCrypto)
+	if err != nil {
+		return 0, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, d.config.DeliveryTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(delivery.payload))
+	if err != nil {
+		return 0, errors.ThrowInternal(err, "WEBHO-ooX4e", "unable to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(delivery.payload, signingKey, time.Now()))
+	req.Header.Set(EventTypeHeader, delivery.eventType)
+	req.Header.Set(DeliveryIDHeader, deliveryID(hook.ID, delivery.eventSequence))
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
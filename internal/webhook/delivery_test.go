@@ -0,0 +1,100 @@
+package webhook
+
+import (
+	"database/sql"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sd "github.com/zitadel/zitadel/internal/config/systemdefaults"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+func TestSign(t *testing.T) {
+	timestamp := time.Unix(1672531200, 0)
+	body := []byte(`{"id":"webhook:1"}`)
+
+	got := Sign(body, "key", timestamp)
+	// echo -n '1672531200.{"id":"webhook:1"}' | openssl dgst -sha256 -hmac key
+	assert.Equal(t, "t=1672531200,v1=7645257c8bc6559828608acc2ea711eddba741a1fd45656ff3bfd1ab1e221cb6", got)
+}
+
+func TestSign_differentInput(t *testing.T) {
+	timestamp := time.Unix(1672531200, 0)
+	body := []byte(`{"id":"webhook:1"}`)
+
+	signature := Sign(body, "key", timestamp)
+	assert.NotEqual(t, signature, Sign(body, "other", timestamp), "key must be part of the signature")
+	assert.NotEqual(t, signature, Sign(body, "key", timestamp.Add(time.Second)), "timestamp must be part of the signature")
+	assert.NotEqual(t, signature, Sign([]byte(`{"id":"webhook:2"}`), "key", timestamp), "body must be part of the signature")
+}
+
+func Test_newPayload(t *testing.T) {
+	creationDate := time.Now()
+	event := eventstore.BaseEventFromRepo(&repository.Event{
+		ID:                            "event-id",
+		Sequence:                      15,
+		CreationDate:                  creationDate,
+		Type:                          "user.human.added",
+		Data:                          []byte(`{"userName":"username","password":{"cryptoType":1,"algorithm":"bcrypt","crypted":"JDJhJDE0"},"code":"123"}`),
+		EditorUser:                    "editor",
+		AggregateID:                   "agg-id",
+		AggregateType:                 "user",
+		ResourceOwner:                 sql.NullString{String: "ro-id", Valid: true},
+		InstanceID:                    "instance-id",
+		PreviousAggregateTypeSequence: 10,
+	})
+
+	payload := newPayload("webhook-id", event)
+	assert.Equal(t, &Payload{
+		ID:            "webhook-id:15",
+		InstanceID:    "instance-id",
+		ResourceOwner: "ro-id",
+		AggregateType: "user",
+		AggregateID:   "agg-id",
+		EventType:     "user.human.added",
+		Sequence:      15,
+		CreationDate:  creationDate,
+		EditorUser:    "editor",
+		Payload:       json.RawMessage(`{"userName":"username"}`),
+	}, payload)
+
+	_, err := json.Marshal(payload)
+	require.NoError(t, err)
+}
+
+func Test_deliverer_retryDelay(t *testing.T) {
+	d := &deliverer{config: sd.Webhooks{RetryDelay: time.Second, MaxRetryDelay: 5 * time.Second}}
+	assert.Equal(t, time.Second, d.retryDelay(1))
+	assert.Equal(t, 2*time.Second, d.retryDelay(2))
+	assert.Equal(t, 4*time.Second, d.retryDelay(3))
+	assert.Equal(t, 5*time.Second, d.retryDelay(4))
+	assert.Equal(t, 5*time.Second, d.retryDelay(100))
+}
+
+func Test_deliverer_nextState(t *testing.T) {
+	now := time.Now()
+	d := &deliverer{config: sd.Webhooks{MaxDeliveryAttempts: 3, RetryDelay: time.Second, MaxRetryDelay: time.Minute}}
+
+	state, attempts, next := d.nextState(0, nil, now)
+	assert.Equal(t, domain.WebhookDeliveryStateDelivered, state)
+	assert.Equal(t, uint64(1), attempts)
+	assert.Nil(t, next)
+
+	state, attempts, next = d.nextState(1, io.EOF, now)
+	assert.Equal(t, domain.WebhookDeliveryStatePending, state)
+	assert.Equal(t, uint64(2), attempts)
+	require.NotNil(t, next)
+	assert.Equal(t, now.Add(2*time.Second), *next)
+
+	state, attempts, next = d.nextState(2, io.EOF, now)
+	assert.Equal(t, domain.WebhookDeliveryStateFailed, state)
+	assert.Equal(t, uint64(3), attempts)
+	assert.Nil(t, next)
+}
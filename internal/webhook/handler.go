@@ -0,0 +1,214 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command"
+	sd "github.com/zitadel/zitadel/internal/config/systemdefaults"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/repository/webhook"
+)
+
+const (
+	// WebhooksHandlerName is used for the current sequences and failed events of the scheduling of the deliveries,
+	// the handler has no proprietary projection table
+	WebhooksHandlerName = "projections.webhook_deliveries"
+	WebhookUserID       = "WEBHOOK"
+)
+
+// Start creates and starts the handler, which schedules the deliveries of the events to the webhooks,
+// and the deliverer, which sends the scheduled deliveries outside of the projection.
+// Failed deliveries are retried with an exponential backoff and marked as failed after MaxDeliveryAttempts,
+// the failed delivery is then dead-lettered as event of the webhook.
+func Start(ctx context.Context, customConfig projection.CustomConfig, queries *query.Queries, commands *command.Commands, es *eventstore.Eventstore, client *sql.DB, signingKeyCrypto crypto.EncryptionAlgorithm, config sd.Webhooks) {
+	newWebhookHandler(ctx, projection.ApplyCustomConfig(customConfig), queries, es)
+	newDeliverer(queries, commands, client, signingKeyCrypto, config).Start(ctx)
+}
+
+type webhookHandler struct {
+	crdb.StatementHandler
+	queries *query.Queries
+	es      *eventstore.Eventstore
+}
+
+func newWebhookHandler(
+	ctx context.Context,
+	config crdb.StatementHandlerConfig,
+	queries *query.Queries,
+	es *eventstore.Eventstore,
+) *webhookHandler {
+	h := new(webhookHandler)
+	config.ProjectionName = WebhooksHandlerName
+	config.Reducers = h.reducers()
+	h.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	h.queries = queries
+	h.es = es
+
+	// needs to be started here as it is not part of the projection.projections / projection.newProjectionsList()
+	h.Start()
+	return h
+}
+
+func (h *webhookHandler) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate:      user.AggregateType,
+			DefaultReducer: h.reduceEvent,
+		},
+		{
+			Aggregate:      usergrant.AggregateType,
+			DefaultReducer: h.reduceEvent,
+		},
+		{
+			Aggregate:      org.AggregateType,
+			DefaultReducer: h.reduceEvent,
+		},
+		{
+			Aggregate:      project.AggregateType,
+			DefaultReducer: h.reduceEvent,
+		},
+		{
+			Aggregate:      instance.AggregateType,
+			DefaultReducer: h.reduceEvent,
+		},
+		{
+			Aggregate: webhook.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  webhook.DeliveryReplayRequestedEventType,
+					Reduce: h.reduceDeliveryReplayRequested,
+				},
+			},
+		},
+	}
+}
+
+// reduceEvent schedules the delivery of the event to all active webhooks of the instance and of the organization
+// owning the event, whose filters match the event. The events of the key-value storage of the actions are skipped.
+func (h *webhookHandler) reduceEvent(event eventstore.Event) (*handler.Statement, error) {
+	if org.IsActionKeyValueEventType(event.Type()) {
+		return crdb.NewNoOpStatement(event), nil
+	}
+	ctx := setWebhookContext(event.Aggregate())
+	webhooks, err := h.queries.ActiveWebhooksByResourceOwners(ctx, event.Aggregate().InstanceID, event.Aggregate().ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	matching := make([]*query.Webhook, 0, len(webhooks))
+	for _, hook := range webhooks {
+		if hook.Matches(string(event.Aggregate().Type), string(event.Type())) {
+			matching = append(matching, hook)
+		}
+	}
+	if len(matching) == 0 {
+		return crdb.NewNoOpStatement(event), nil
+	}
+	return newScheduleStatement(event, event, matching, false), nil
+}
+
+// reduceDeliveryReplayRequested schedules the requested event again for the webhook,
+// regardless of the state of the previous delivery
+func (h *webhookHandler) reduceDeliveryReplayRequested(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*webhook.DeliveryReplayRequestedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Bie3a", "reduce.wrong.event.type %s", webhook.DeliveryReplayRequestedEventType)
+	}
+	ctx := setWebhookContext(e.Aggregate())
+	hook, err := h.queries.GetWebhookByID(ctx, e.Aggregate().ID, e.Aggregate().ResourceOwner, false)
+	if errors.IsNotFound(err) {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if hook.State != domain.WebhookStateActive {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	events, err := h.es.Filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		InstanceID(e.Aggregate().InstanceID).
+		AddQuery().
+		AggregateTypes(e.EventAggregateType).
+		SequenceGreater(e.EventSequence-1).
+		SequenceLess(e.EventSequence+1).
+		Builder(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	original := events[0]
+	// organization webhooks must only receive events of their organization
+	if hook.ResourceOwner != original.Aggregate().InstanceID && hook.ResourceOwner != original.Aggregate().ResourceOwner {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	return newScheduleStatement(e, original, []*query.Webhook{hook}, true), nil
+}
+
+// newScheduleStatement creates a statement, which schedules the delivery of the event to the webhooks.
+// It only stores the pending deliveries, the deliverer sends them outside of the transaction of the projection.
+// Already scheduled deliveries are kept, unless it's a replay.
+func newScheduleStatement(stmtEvent, event eventstore.Event, webhooks []*query.Webhook, replay bool) *handler.Statement {
+	stmt := scheduleDeliveryStmt
+	if replay {
+		stmt = rescheduleDeliveryStmt
+	}
+	return &handler.Statement{
+		AggregateType:    stmtEvent.Aggregate().Type,
+		Sequence:         stmtEvent.Sequence(),
+		PreviousSequence: stmtEvent.PreviousAggregateTypeSequence(),
+		InstanceID:       stmtEvent.Aggregate().InstanceID,
+		Execute: func(ex handler.Executer, _ string) error {
+			now := time.Now()
+			for _, hook := range webhooks {
+				payload, err := json.Marshal(newPayload(hook.ID, event))
+				if err != nil {
+					return errors.ThrowInternal(err, "WEBHO-Jie8e", "unable to marshal payload")
+				}
+				_, err = ex.Exec(stmt,
+					hook.ID,
+					event.Aggregate().InstanceID,
+					event.Sequence(),
+					hook.ResourceOwner,
+					event.Aggregate().Type,
+					event.Aggregate().ID,
+					event.Type(),
+					event.CreationDate(),
+					now,
+					domain.WebhookDeliveryStatePending,
+					payload,
+				)
+				if err != nil {
+					return errors.ThrowInternal(err, "WEBHO-eiW4u", "unable to schedule webhook delivery")
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func setWebhookContext(event eventstore.Aggregate) context.Context {
+	return webhookContext(event.InstanceID, event.ResourceOwner)
+}
+
+func webhookContext(instanceID, resourceOwner string) context.Context {
+	ctx := authz.WithInstanceID(context.Background(), instanceID)
+	return authz.SetCtxData(ctx, authz.CtxData{UserID: WebhookUserID, OrgID: resourceOwner})
+}
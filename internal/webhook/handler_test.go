@@ -0,0 +1,32 @@
+package webhook
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+func Test_webhookHandler_reduceEvent_keyValue(t *testing.T) {
+	h := &webhookHandler{}
+	for _, eventType := range []repository.EventType{
+		repository.EventType(org.ActionKeyValueSetEventType),
+		repository.EventType(org.ActionKeyValueRemovedEventType),
+	} {
+		stmt, err := h.reduceEvent(eventstore.BaseEventFromRepo(&repository.Event{
+			Sequence:      15,
+			Type:          eventType,
+			AggregateID:   "ro-id",
+			AggregateType: repository.AggregateType(org.AggregateType),
+			ResourceOwner: sql.NullString{String: "ro-id", Valid: true},
+			InstanceID:    "instance-id",
+		}))
+		require.NoError(t, err)
+		assert.Nil(t, stmt.Execute, "no delivery must be scheduled for %s", eventType)
+	}
+}